@@ -2,7 +2,7 @@ package abcicli
 
 import (
 	"context"
-
+	"fmt"
 	"sync"
 
 	"github.com/lazyledger/lazyledger-core/abci/types"
@@ -10,6 +10,11 @@ import (
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 )
 
+const (
+	dialRetryIntervalSeconds = 3
+	echoRetryIntervalSeconds = 1
+)
+
 //go:generate mockery --case underscore --name Client
 
 // Client defines an interface for an ABCI client.
@@ -42,6 +47,8 @@ type Client interface {
 	LoadSnapshotChunkAsync(context.Context, types.RequestLoadSnapshotChunk) (*ReqRes, error)
 	ApplySnapshotChunkAsync(context.Context, types.RequestApplySnapshotChunk) (*ReqRes, error)
 	PreprocessTxsAsync(context.Context, types.RequestPreprocessTxs) (*ReqRes, error)
+	ProcessProposalAsync(context.Context, types.RequestProcessProposal) (*ReqRes, error)
+	GenerateFraudProofAsync(context.Context, types.RequestGenerateFraudProof) (*ReqRes, error)
 
 	// Synchronous requests
 	FlushSync(context.Context) error
@@ -59,6 +66,38 @@ type Client interface {
 	LoadSnapshotChunkSync(context.Context, types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunkSync(context.Context, types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
 	PreprocessTxsSync(context.Context, types.RequestPreprocessTxs) (*types.ResponsePreprocessTxs, error)
+	ProcessProposalSync(context.Context, types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
+	GenerateFraudProofSync(context.Context, types.RequestGenerateFraudProof) (*types.ResponseGenerateFraudProof, error)
+}
+
+// NewClient returns a new ABCI client of the specified transport type.
+// It returns an error if the transport is not "socket" or "grpc"
+func NewClient(addr, transport string, mustConnect bool) (client Client, err error) {
+	switch transport {
+	case "socket":
+		client = NewSocketClient(addr, mustConnect)
+	case "grpc":
+		client = NewGRPCClient(addr, mustConnect)
+	default:
+		err = fmt.Errorf("unknown abci transport %s", transport)
+	}
+	return
+}
+
+// NewReconnectingClient returns a new ABCI client of the specified transport
+// type, which keeps retrying to connect, both on start and whenever the
+// connection is lost. It returns an error if the transport is not "socket" or
+// "grpc".
+func NewReconnectingClient(addr, transport string) (client Client, err error) {
+	switch transport {
+	case "socket":
+		client = NewReconnectingSocketClient(addr)
+	case "grpc":
+		client = NewReconnectingGRPCClient(addr)
+	default:
+		err = fmt.Errorf("unknown abci transport %s", transport)
+	}
+	return
 }
 
 type Callback func(*types.Request, *types.Response)
@@ -216,6 +216,34 @@ func (app *localClient) PreprocessTxsAsync(
 	), nil
 }
 
+func (app *localClient) ProcessProposalAsync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*ReqRes, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.ProcessProposal(req)
+	return app.callback(
+		types.ToRequestProcessProposal(req),
+		types.ToResponseProcessProposal(res),
+	), nil
+}
+
+func (app *localClient) GenerateFraudProofAsync(
+	ctx context.Context,
+	req types.RequestGenerateFraudProof,
+) (*ReqRes, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.GenerateFraudProof(req)
+	return app.callback(
+		types.ToRequestGenerateFraudProof(req),
+		types.ToResponseGenerateFraudProof(res),
+	), nil
+}
+
 //-------------------------------------------------------
 
 func (app *localClient) FlushSync(ctx context.Context) error {
@@ -369,6 +397,28 @@ func (app *localClient) PreprocessTxsSync(
 	return &res, nil
 }
 
+func (app *localClient) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.ProcessProposal(req)
+	return &res, nil
+}
+
+func (app *localClient) GenerateFraudProofSync(
+	ctx context.Context,
+	req types.RequestGenerateFraudProof,
+) (*types.ResponseGenerateFraudProof, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	res := app.Application.GenerateFraudProof(req)
+	return &res, nil
+}
+
 //-------------------------------------------------------
 
 func (app *localClient) callback(req *types.Request, res *types.Response) *ReqRes {
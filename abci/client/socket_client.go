@@ -0,0 +1,787 @@
+package abcicli
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/lazyledger/lazyledger-core/abci/types"
+	tmnet "github.com/lazyledger/lazyledger-core/libs/net"
+	"github.com/lazyledger/lazyledger-core/libs/service"
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
+	"github.com/lazyledger/lazyledger-core/libs/timer"
+)
+
+const (
+	// reqQueueSize is the max number of queued async requests.
+	// (memory: 256MB max assuming 1MB transactions)
+	reqQueueSize = 256
+	// Don't wait longer than...
+	flushThrottle = 20 * time.Millisecond
+)
+
+// errConnectionLost is returned for requests which were in flight when the
+// connection to the application was lost.
+var errConnectionLost = errors.New("connection to the application was lost")
+
+type reqResWithContext struct {
+	R *ReqRes
+	C context.Context // if context.Err is not nil, reqRes will be thrown away (ignored)
+}
+
+// This is goroutine-safe, but users should beware that the application in
+// general is not meant to be interfaced with concurrent callers.
+type socketClient struct {
+	service.BaseService
+
+	addr        string
+	mustConnect bool
+	reconnect   bool
+
+	reqQueue   chan *reqResWithContext
+	flushTimer *timer.ThrottleTimer
+
+	mtx      tmsync.Mutex
+	conn     net.Conn      // nil while (re)connecting
+	connQuit chan struct{} // closed when conn fails
+	err      error
+	reqSent  *list.List                            // list of requests sent, waiting for response
+	resCb    func(*types.Request, *types.Response) // called on all requests, if set.
+}
+
+var _ Client = (*socketClient)(nil)
+
+// NewSocketClient creates a new socket client, which connects to a given
+// address.
+//
+// If mustConnect is true, the client will return an error upon start if it
+// fails to connect. Otherwise, it keeps retrying to connect. Once connected,
+// the client stops with an error if the connection is lost.
+func NewSocketClient(addr string, mustConnect bool) Client {
+	return newSocketClient(addr, mustConnect, false)
+}
+
+// NewReconnectingSocketClient creates a new socket client, which keeps
+// retrying to connect to the given address, both on start and whenever the
+// connection is lost. Requests in flight at that moment fail, while new ones
+// are queued until the connection is re-established.
+//
+// The application may lose state it did not commit while disconnected, so it
+// must only be used for connections that don't execute blocks.
+func NewReconnectingSocketClient(addr string) Client {
+	return newSocketClient(addr, false, true)
+}
+
+func newSocketClient(addr string, mustConnect, reconnect bool) *socketClient {
+	cli := &socketClient{
+		reqQueue:    make(chan *reqResWithContext, reqQueueSize),
+		flushTimer:  timer.NewThrottleTimer("socketClient", flushThrottle),
+		mustConnect: mustConnect,
+		reconnect:   reconnect,
+
+		addr:    addr,
+		reqSent: list.New(),
+		resCb:   nil,
+	}
+	cli.BaseService = *service.NewBaseService(nil, "socketClient", cli)
+	return cli
+}
+
+// OnStart implements Service by connecting to the server and spawning reading
+// and writing goroutines.
+func (cli *socketClient) OnStart() error {
+	for {
+		conn, err := tmnet.Connect(cli.addr)
+		if err == nil {
+			cli.setConn(conn)
+			return nil
+		}
+		if cli.mustConnect {
+			return err
+		}
+		cli.Logger.Error(fmt.Sprintf("abci.socketClient failed to connect to %v.  Retrying after %vs...",
+			cli.addr, dialRetryIntervalSeconds), "err", err)
+		time.Sleep(time.Second * dialRetryIntervalSeconds)
+	}
+}
+
+// OnStop implements Service by closing connection and flushing all queues.
+func (cli *socketClient) OnStop() {
+	cli.mtx.Lock()
+	if cli.conn != nil {
+		cli.conn.Close()
+	}
+	cli.mtx.Unlock()
+
+	cli.flushQueue()
+	cli.flushTimer.Stop()
+}
+
+// Error returns an error if the client was stopped abruptly or is currently
+// reconnecting.
+func (cli *socketClient) Error() error {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.err
+}
+
+// SetResponseCallback sets a callback, which will be executed for each
+// non-error & non-empty response from the server.
+//
+// NOTE: callback may get internally generated flush responses.
+func (cli *socketClient) SetResponseCallback(resCb Callback) {
+	cli.mtx.Lock()
+	cli.resCb = resCb
+	cli.mtx.Unlock()
+}
+
+//----------------------------------------
+
+func (cli *socketClient) setConn(conn net.Conn) {
+	connQuit := make(chan struct{})
+
+	cli.mtx.Lock()
+	cli.conn = conn
+	cli.connQuit = connQuit
+	cli.err = nil
+	cli.mtx.Unlock()
+
+	go cli.sendRequestsRoutine(conn, connQuit)
+	go cli.recvResponseRoutine(conn)
+}
+
+func (cli *socketClient) sendRequestsRoutine(conn net.Conn, connQuit <-chan struct{}) {
+	bw := bufio.NewWriter(conn)
+	for {
+		select {
+		case reqres := <-cli.reqQueue:
+			if reqres.C.Err() != nil {
+				cli.Logger.Debug("Request's context is done", "req", reqres.R, "err", reqres.C.Err())
+				continue
+			}
+
+			if !cli.willSendReq(conn, reqres.R) {
+				// the connection failed in the meantime, leave the request to the next one
+				cli.requeue(reqres)
+				return
+			}
+			err := types.WriteMessage(reqres.R.Request, bw)
+			if err != nil {
+				cli.connFailed(conn, fmt.Errorf("write to buffer: %w", err))
+				return
+			}
+
+			// If it's a flush request, flush the current buffer.
+			if _, ok := reqres.R.Request.Value.(*types.Request_Flush); ok {
+				err = bw.Flush()
+				if err != nil {
+					cli.connFailed(conn, fmt.Errorf("flush buffer: %w", err))
+					return
+				}
+			}
+		case <-cli.flushTimer.Ch: // flush queue
+			select {
+			case cli.reqQueue <- &reqResWithContext{R: NewReqRes(types.ToRequestFlush()), C: context.Background()}:
+			default:
+				// Probably will fill the buffer, or retry later.
+			}
+		case <-connQuit:
+			return
+		case <-cli.Quit():
+			return
+		}
+	}
+}
+
+func (cli *socketClient) recvResponseRoutine(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		var res = &types.Response{}
+		err := types.ReadMessage(r, res)
+		if err != nil {
+			cli.connFailed(conn, fmt.Errorf("read message: %w", err))
+			return
+		}
+
+		switch r := res.Value.(type) {
+		case *types.Response_Exception: // app responded with error
+			cli.connFailed(conn, errors.New(r.Exception.Error))
+			return
+		default:
+			err := cli.didRecvResponse(conn, res)
+			if err != nil {
+				cli.connFailed(conn, err)
+				return
+			}
+		}
+	}
+}
+
+func (cli *socketClient) willSendReq(conn net.Conn, reqres *ReqRes) bool {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if cli.conn != conn {
+		return false
+	}
+	cli.reqSent.PushBack(reqres)
+	return true
+}
+
+func (cli *socketClient) didRecvResponse(conn net.Conn, res *types.Response) error {
+	cli.mtx.Lock()
+	if cli.conn != conn {
+		cli.mtx.Unlock()
+		return errors.New("response on a stale connection")
+	}
+
+	// Get the first ReqRes.
+	next := cli.reqSent.Front()
+	if next == nil {
+		cli.mtx.Unlock()
+		return fmt.Errorf("unexpected %v when nothing expected", reflect.TypeOf(res.Value))
+	}
+
+	reqres := next.Value.(*ReqRes)
+	if !resMatchesReq(reqres.Request, res) {
+		cli.mtx.Unlock()
+		return fmt.Errorf("unexpected %v when response to %v expected",
+			reflect.TypeOf(res.Value), reflect.TypeOf(reqres.Request.Value))
+	}
+
+	reqres.Response = res
+	cli.reqSent.Remove(next) // pop first item from linked list
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+
+	reqres.Done() // release waiters
+	reqres.SetDone()
+
+	// Notify client listener if set (global callback).
+	if resCb != nil {
+		resCb(reqres.Request, res)
+	}
+
+	// Notify reqRes listener if set (request specific callback).
+	//
+	// NOTE: It is possible this callback isn't set on the reqres object. At this
+	// point, in which case it will be called after, when it is set.
+	if cb := reqres.GetCallback(); cb != nil {
+		cb(res)
+	}
+
+	return nil
+}
+
+// requeue puts back a request taken from the queue for a failed connection.
+// The request is released if the queue is full or the client is stopped.
+func (cli *socketClient) requeue(reqres *reqResWithContext) {
+	if !cli.IsRunning() {
+		reqres.R.Done()
+		return
+	}
+	select {
+	case cli.reqQueue <- reqres:
+	default:
+		reqres.R.Done()
+	}
+}
+
+// connFailed closes the given connection and releases the requests sent over
+// it. If reconnect is set, it then starts reconnecting, otherwise the client
+// is stopped.
+func (cli *socketClient) connFailed(conn net.Conn, err error) {
+	cli.mtx.Lock()
+	if cli.conn != conn {
+		// already handled
+		cli.mtx.Unlock()
+		return
+	}
+	cli.conn = nil
+	cli.err = err
+	close(cli.connQuit)
+	sent := cli.reqSent
+	cli.reqSent = list.New()
+	cli.mtx.Unlock()
+
+	conn.Close()
+	// mark all in-flight messages as resolved (they will get errConnectionLost)
+	for req := sent.Front(); req != nil; req = req.Next() {
+		req.Value.(*ReqRes).Done()
+	}
+
+	if !cli.reconnect {
+		cli.stopForError(err)
+		return
+	}
+	if !cli.IsRunning() {
+		return
+	}
+	cli.Logger.Error("Lost connection to the application. Reconnecting...", "err", err)
+	go cli.reconnectRoutine()
+}
+
+func (cli *socketClient) reconnectRoutine() {
+	for {
+		conn, err := tmnet.Connect(cli.addr)
+		if err == nil {
+			cli.Logger.Info("Reconnected to the application", "addr", cli.addr)
+			cli.setConn(conn)
+			// the client could have been stopped while dialing
+			if !cli.IsRunning() {
+				conn.Close()
+			}
+			return
+		}
+		cli.Logger.Error(fmt.Sprintf("abci.socketClient failed to connect to %v.  Retrying after %vs...",
+			cli.addr, dialRetryIntervalSeconds), "err", err)
+
+		select {
+		case <-time.After(time.Second * dialRetryIntervalSeconds):
+		case <-cli.Quit():
+			return
+		}
+	}
+}
+
+//----------------------------------------
+
+func (cli *socketClient) EchoAsync(ctx context.Context, msg string) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestEcho(msg))
+}
+
+func (cli *socketClient) FlushAsync(ctx context.Context) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestFlush())
+}
+
+func (cli *socketClient) InfoAsync(ctx context.Context, req types.RequestInfo) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestInfo(req))
+}
+
+func (cli *socketClient) DeliverTxAsync(ctx context.Context, req types.RequestDeliverTx) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestDeliverTx(req))
+}
+
+func (cli *socketClient) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestCheckTx(req))
+}
+
+func (cli *socketClient) QueryAsync(ctx context.Context, req types.RequestQuery) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestQuery(req))
+}
+
+func (cli *socketClient) CommitAsync(ctx context.Context) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestCommit())
+}
+
+func (cli *socketClient) InitChainAsync(ctx context.Context, req types.RequestInitChain) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestInitChain(req))
+}
+
+func (cli *socketClient) BeginBlockAsync(ctx context.Context, req types.RequestBeginBlock) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestBeginBlock(req))
+}
+
+func (cli *socketClient) EndBlockAsync(ctx context.Context, req types.RequestEndBlock) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestEndBlock(req))
+}
+
+func (cli *socketClient) ListSnapshotsAsync(ctx context.Context, req types.RequestListSnapshots) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestListSnapshots(req))
+}
+
+func (cli *socketClient) OfferSnapshotAsync(ctx context.Context, req types.RequestOfferSnapshot) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestOfferSnapshot(req))
+}
+
+func (cli *socketClient) LoadSnapshotChunkAsync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestLoadSnapshotChunk(req))
+}
+
+func (cli *socketClient) ApplySnapshotChunkAsync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestApplySnapshotChunk(req))
+}
+
+func (cli *socketClient) PreprocessTxsAsync(ctx context.Context, req types.RequestPreprocessTxs) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestPreprocessTxs(req))
+}
+
+func (cli *socketClient) ProcessProposalAsync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestProcessProposal(req))
+}
+
+func (cli *socketClient) GenerateFraudProofAsync(
+	ctx context.Context,
+	req types.RequestGenerateFraudProof,
+) (*ReqRes, error) {
+	return cli.queueRequestAsync(ctx, types.ToRequestGenerateFraudProof(req))
+}
+
+//----------------------------------------
+
+func (cli *socketClient) FlushSync(ctx context.Context) error {
+	reqRes, err := cli.queueRequest(ctx, types.ToRequestFlush(), true)
+	if err != nil {
+		return queueErr(err)
+	}
+
+	gotResp := make(chan struct{})
+	go func() {
+		// NOTE: if we don't flush the queue, its possible to get stuck here
+		reqRes.Wait()
+		close(gotResp)
+	}()
+
+	select {
+	case <-gotResp:
+		return responseErr(cli, reqRes)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (cli *socketClient) EchoSync(ctx context.Context, msg string) (*types.ResponseEcho, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestEcho(msg))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetEcho(), nil
+}
+
+func (cli *socketClient) InfoSync(ctx context.Context, req types.RequestInfo) (*types.ResponseInfo, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestInfo(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetInfo(), nil
+}
+
+func (cli *socketClient) DeliverTxSync(
+	ctx context.Context,
+	req types.RequestDeliverTx,
+) (*types.ResponseDeliverTx, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestDeliverTx(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetDeliverTx(), nil
+}
+
+func (cli *socketClient) CheckTxSync(ctx context.Context, req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestCheckTx(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCheckTx(), nil
+}
+
+func (cli *socketClient) QuerySync(ctx context.Context, req types.RequestQuery) (*types.ResponseQuery, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestQuery(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetQuery(), nil
+}
+
+func (cli *socketClient) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestCommit())
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetCommit(), nil
+}
+
+func (cli *socketClient) InitChainSync(
+	ctx context.Context,
+	req types.RequestInitChain,
+) (*types.ResponseInitChain, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestInitChain(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetInitChain(), nil
+}
+
+func (cli *socketClient) BeginBlockSync(
+	ctx context.Context,
+	req types.RequestBeginBlock,
+) (*types.ResponseBeginBlock, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestBeginBlock(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetBeginBlock(), nil
+}
+
+func (cli *socketClient) EndBlockSync(
+	ctx context.Context,
+	req types.RequestEndBlock,
+) (*types.ResponseEndBlock, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestEndBlock(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetEndBlock(), nil
+}
+
+func (cli *socketClient) ListSnapshotsSync(
+	ctx context.Context,
+	req types.RequestListSnapshots,
+) (*types.ResponseListSnapshots, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestListSnapshots(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetListSnapshots(), nil
+}
+
+func (cli *socketClient) OfferSnapshotSync(
+	ctx context.Context,
+	req types.RequestOfferSnapshot,
+) (*types.ResponseOfferSnapshot, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestOfferSnapshot(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetOfferSnapshot(), nil
+}
+
+func (cli *socketClient) LoadSnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*types.ResponseLoadSnapshotChunk, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestLoadSnapshotChunk(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetLoadSnapshotChunk(), nil
+}
+
+func (cli *socketClient) ApplySnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*types.ResponseApplySnapshotChunk, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestApplySnapshotChunk(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetApplySnapshotChunk(), nil
+}
+
+func (cli *socketClient) PreprocessTxsSync(
+	ctx context.Context,
+	req types.RequestPreprocessTxs,
+) (*types.ResponsePreprocessTxs, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestPreprocessTxs(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetPreprocessTxs(), nil
+}
+
+func (cli *socketClient) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestProcessProposal(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetProcessProposal(), nil
+}
+
+func (cli *socketClient) GenerateFraudProofSync(
+	ctx context.Context,
+	req types.RequestGenerateFraudProof,
+) (*types.ResponseGenerateFraudProof, error) {
+	reqres, err := cli.queueRequestAndFlushSync(ctx, types.ToRequestGenerateFraudProof(req))
+	if err != nil {
+		return nil, err
+	}
+	return reqres.Response.GetGenerateFraudProof(), nil
+}
+
+//----------------------------------------
+
+// queueRequest enqueues req onto the queue. If the queue is full, it ether
+// returns an error (sync=false) or blocks (sync=true).
+//
+// When sync=true, ctx can be used to break early. When sync=false, ctx will be
+// used later to determine if request should be dropped (if ctx.Err is
+// non-nil).
+//
+// The caller is responsible for checking cli.Error.
+func (cli *socketClient) queueRequest(ctx context.Context, req *types.Request, sync bool) (*ReqRes, error) {
+	// nobody would release requests queued after the client stopped
+	select {
+	case <-cli.Quit():
+		if err := cli.Error(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("client has been stopped")
+	default:
+	}
+
+	reqres := NewReqRes(req)
+
+	if sync {
+		select {
+		case cli.reqQueue <- &reqResWithContext{R: reqres, C: context.Background()}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else {
+		select {
+		case cli.reqQueue <- &reqResWithContext{R: reqres, C: ctx}:
+		default:
+			return nil, errors.New("buffer is full")
+		}
+	}
+
+	// Maybe auto-flush, or unset auto-flush
+	switch req.Value.(type) {
+	case *types.Request_Flush:
+		cli.flushTimer.Unset()
+	default:
+		cli.flushTimer.Set()
+	}
+
+	return reqres, nil
+}
+
+func (cli *socketClient) queueRequestAsync(ctx context.Context, req *types.Request) (*ReqRes, error) {
+	reqres, err := cli.queueRequest(ctx, req, false)
+	if err != nil {
+		return nil, queueErr(err)
+	}
+	return reqres, cli.Error()
+}
+
+func (cli *socketClient) queueRequestAndFlushSync(ctx context.Context, req *types.Request) (*ReqRes, error) {
+	reqres, err := cli.queueRequest(ctx, req, true)
+	if err != nil {
+		return nil, queueErr(err)
+	}
+
+	if err := cli.FlushSync(ctx); err != nil {
+		return nil, err
+	}
+
+	// the request is answered before the flush, unless the connection was
+	// lost in between
+	if err := responseErr(cli, reqres); err != nil {
+		return nil, err
+	}
+	return reqres, nil
+}
+
+// responseErr returns an error if the request was released without a
+// response.
+func responseErr(cli *socketClient, reqres *ReqRes) error {
+	if reqres.Response != nil {
+		return nil
+	}
+	if err := cli.Error(); err != nil {
+		return err
+	}
+	return errConnectionLost
+}
+
+func queueErr(e error) error {
+	return fmt.Errorf("can't queue req: %w", e)
+}
+
+func (cli *socketClient) flushQueue() {
+	cli.mtx.Lock()
+	sent := cli.reqSent
+	cli.reqSent = list.New()
+	cli.mtx.Unlock()
+
+	// mark all in-flight messages as resolved (they will get cli.Error())
+	for req := sent.Front(); req != nil; req = req.Next() {
+		req.Value.(*ReqRes).Done()
+	}
+
+	// mark all queued messages as resolved
+LOOP:
+	for {
+		select {
+		case reqres := <-cli.reqQueue:
+			reqres.R.Done()
+		default:
+			break LOOP
+		}
+	}
+}
+
+//----------------------------------------
+
+func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
+	switch req.Value.(type) {
+	case *types.Request_Echo:
+		_, ok = res.Value.(*types.Response_Echo)
+	case *types.Request_Flush:
+		_, ok = res.Value.(*types.Response_Flush)
+	case *types.Request_Info:
+		_, ok = res.Value.(*types.Response_Info)
+	case *types.Request_DeliverTx:
+		_, ok = res.Value.(*types.Response_DeliverTx)
+	case *types.Request_CheckTx:
+		_, ok = res.Value.(*types.Response_CheckTx)
+	case *types.Request_Commit:
+		_, ok = res.Value.(*types.Response_Commit)
+	case *types.Request_Query:
+		_, ok = res.Value.(*types.Response_Query)
+	case *types.Request_InitChain:
+		_, ok = res.Value.(*types.Response_InitChain)
+	case *types.Request_BeginBlock:
+		_, ok = res.Value.(*types.Response_BeginBlock)
+	case *types.Request_EndBlock:
+		_, ok = res.Value.(*types.Response_EndBlock)
+	case *types.Request_ApplySnapshotChunk:
+		_, ok = res.Value.(*types.Response_ApplySnapshotChunk)
+	case *types.Request_LoadSnapshotChunk:
+		_, ok = res.Value.(*types.Response_LoadSnapshotChunk)
+	case *types.Request_ListSnapshots:
+		_, ok = res.Value.(*types.Response_ListSnapshots)
+	case *types.Request_OfferSnapshot:
+		_, ok = res.Value.(*types.Response_OfferSnapshot)
+	case *types.Request_PreprocessTxs:
+		_, ok = res.Value.(*types.Response_PreprocessTxs)
+	case *types.Request_ProcessProposal:
+		_, ok = res.Value.(*types.Response_ProcessProposal)
+	case *types.Request_GenerateFraudProof:
+		_, ok = res.Value.(*types.Response_GenerateFraudProof)
+	}
+	return ok
+}
+
+func (cli *socketClient) stopForError(err error) {
+	if !cli.IsRunning() {
+		return
+	}
+
+	cli.mtx.Lock()
+	if cli.err == nil {
+		cli.err = err
+	}
+	cli.mtx.Unlock()
+
+	cli.Logger.Info("Stopping abci.socketClient", "reason", err)
+	if err := cli.Stop(); err != nil {
+		cli.Logger.Error("Error stopping abci.socketClient", "err", err)
+	}
+}
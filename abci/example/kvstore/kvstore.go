@@ -10,6 +10,7 @@ import (
 	"github.com/lazyledger/lazyledger-core/abci/types"
 	dbm "github.com/lazyledger/lazyledger-core/libs/db"
 	memdb "github.com/lazyledger/lazyledger-core/libs/db/memdb"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	"github.com/lazyledger/lazyledger-core/version"
 )
 
@@ -173,5 +174,17 @@ func (app *Application) Query(reqQuery types.RequestQuery) (resQuery types.Respo
 
 func (app *Application) PreprocessTxs(
 	req types.RequestPreprocessTxs) types.ResponsePreprocessTxs {
-	return types.ResponsePreprocessTxs{Txs: req.Txs}
+	// include the messages paid for by the txs as they are
+	var msgs *tmproto.Messages
+	for i := range req.Messages {
+		msg := req.Messages[i]
+		if len(msg.NamespaceId) == 0 && len(msg.Data) == 0 {
+			continue
+		}
+		if msgs == nil {
+			msgs = &tmproto.Messages{}
+		}
+		msgs.MessagesList = append(msgs.MessagesList, &msg)
+	}
+	return types.ResponsePreprocessTxs{Txs: req.Txs, Messages: msgs}
 }
@@ -1,14 +1,23 @@
 package kvstore
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	abcicli "github.com/lazyledger/lazyledger-core/abci/client"
 	"github.com/lazyledger/lazyledger-core/abci/example/code"
+	abciserver "github.com/lazyledger/lazyledger-core/abci/server"
 	"github.com/lazyledger/lazyledger-core/abci/types"
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	tmnet "github.com/lazyledger/lazyledger-core/libs/net"
+	"github.com/lazyledger/lazyledger-core/libs/service"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 )
 
@@ -17,6 +26,8 @@ const (
 	testValue = "def"
 )
 
+var ctx = context.Background()
+
 func testKVStore(t *testing.T, app types.Application, tx []byte, key, value string) {
 	req := types.RequestDeliverTx{Tx: tx}
 	ar := app.DeliverTx(req)
@@ -219,3 +230,192 @@ func valsEqual(t *testing.T, vals1, vals2 []types.ValidatorUpdate) {
 		}
 	}
 }
+
+func makeClientServer(
+	t *testing.T,
+	app types.Application,
+	addr, transport string,
+	reconnect bool,
+) (abcicli.Client, service.Service) {
+	logger := log.TestingLogger()
+
+	server, err := abciserver.NewServer(addr, transport, app)
+	require.NoError(t, err)
+	server.SetLogger(logger.With("module", "abci-server"))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if server.IsRunning() {
+			require.NoError(t, server.Stop())
+		}
+	})
+
+	var client abcicli.Client
+	if reconnect {
+		client, err = abcicli.NewReconnectingClient(addr, transport)
+	} else {
+		client, err = abcicli.NewClient(addr, transport, false)
+	}
+	require.NoError(t, err)
+	client.SetLogger(logger.With("module", "abci-client"))
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		if client.IsRunning() {
+			require.NoError(t, client.Stop())
+		}
+	})
+
+	return client, server
+}
+
+// freeTCPAddr returns the address of an unused local TCP port.
+func freeTCPAddr(t *testing.T) string {
+	port, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	return fmt.Sprintf("tcp://127.0.0.1:%d", port)
+}
+
+// TestClientServer checks that the socket and gRPC clients and servers behave
+// like the app itself.
+func TestClientServer(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		name      string
+		addr      string
+		transport string
+	}{
+		{"socket unix", fmt.Sprintf("unix://%s", filepath.Join(dir, "kvstore.sock")), "socket"},
+		{"socket tcp", freeTCPAddr(t), "socket"},
+		{"grpc unix", fmt.Sprintf("unix://%s", filepath.Join(dir, "kvstore-grpc.sock")), "grpc"},
+		{"grpc tcp", freeTCPAddr(t), "grpc"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := makeClientServer(t, NewApplication(), tc.addr, tc.transport, false)
+			runClientTests(t, client)
+		})
+	}
+}
+
+// TestClientStopsOnConnectionLoss checks that the clients which don't reconnect
+// stop with an error once the app is gone.
+func TestClientStopsOnConnectionLoss(t *testing.T) {
+	for _, transport := range []string{"socket", "grpc"} {
+		transport := transport
+		t.Run(transport, func(t *testing.T) {
+			client, server := makeClientServer(t, NewApplication(), freeTCPAddr(t), transport, false)
+
+			_, err := client.EchoSync(ctx, "hello")
+			require.NoError(t, err)
+
+			require.NoError(t, server.Stop())
+			select {
+			case <-client.Quit():
+			case <-time.After(10 * time.Second):
+				t.Fatal("client did not stop after losing the connection")
+			}
+			require.Error(t, client.Error())
+
+			_, err = client.EchoSync(ctx, "hello again")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSocketClientReconnect(t *testing.T) {
+	addr := fmt.Sprintf("unix://%s", filepath.Join(t.TempDir(), "kvstore.sock"))
+	client, server := makeClientServer(t, NewApplication(), addr, "socket", true)
+
+	_, err := client.EchoSync(ctx, "hello")
+	require.NoError(t, err)
+
+	// restart the app
+	require.NoError(t, server.Stop())
+	restarted, err := abciserver.NewServer(addr, "socket", NewApplication())
+	require.NoError(t, err)
+	restarted.SetLogger(log.TestingLogger())
+	require.NoError(t, restarted.Start())
+	t.Cleanup(func() {
+		require.NoError(t, restarted.Stop())
+	})
+
+	require.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		res, err := client.EchoSync(ctx, "hello again")
+		return err == nil && res.Message == "hello again"
+	}, 10*time.Second, 100*time.Millisecond)
+	require.True(t, client.IsRunning())
+	require.NoError(t, client.Error())
+}
+
+func runClientTests(t *testing.T, client abcicli.Client) {
+	// run some tests....
+	key := testKey
+	value := key
+	tx := []byte(key)
+	testClient(t, client, tx, key, value)
+
+	value = testValue
+	tx = []byte(key + "=" + value)
+	testClient(t, client, tx, key, value)
+}
+
+func testClient(t *testing.T, app abcicli.Client, tx []byte, key, value string) {
+	echo, err := app.EchoSync(ctx, value)
+	require.NoError(t, err)
+	require.Equal(t, value, echo.Message)
+
+	resPreprocess, err := app.PreprocessTxsSync(ctx, types.RequestPreprocessTxs{Txs: [][]byte{tx}})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{tx}, resPreprocess.Txs)
+
+	resProcess, err := app.ProcessProposalSync(ctx, types.RequestProcessProposal{})
+	require.NoError(t, err)
+	require.Equal(t, types.ResponseProcessProposal_ACCEPT, resProcess.Result)
+
+	ar, err := app.DeliverTxSync(ctx, types.RequestDeliverTx{Tx: tx})
+	require.NoError(t, err)
+	require.False(t, ar.IsErr(), ar)
+	// repeating tx doesn't raise error
+	ar, err = app.DeliverTxSync(ctx, types.RequestDeliverTx{Tx: tx})
+	require.NoError(t, err)
+	require.False(t, ar.IsErr(), ar)
+	// commit
+	_, err = app.CommitSync(ctx)
+	require.NoError(t, err)
+
+	info, err := app.InfoSync(ctx, types.RequestInfo{})
+	require.NoError(t, err)
+	require.NotZero(t, info.LastBlockHeight)
+
+	// make sure query is fine
+	resQuery, err := app.QuerySync(ctx, types.RequestQuery{
+		Path: "/store",
+		Data: []byte(key),
+	})
+	require.Nil(t, err)
+	require.Equal(t, code.CodeTypeOK, resQuery.Code)
+	require.Equal(t, key, string(resQuery.Key))
+	require.Equal(t, value, string(resQuery.Value))
+	require.EqualValues(t, info.LastBlockHeight, resQuery.Height)
+
+	// make sure proof is fine
+	resQuery, err = app.QuerySync(ctx, types.RequestQuery{
+		Path:  "/store",
+		Data:  []byte(key),
+		Prove: true,
+	})
+	require.Nil(t, err)
+	require.Equal(t, code.CodeTypeOK, resQuery.Code)
+	require.Equal(t, key, string(resQuery.Key))
+	require.Equal(t, value, string(resQuery.Value))
+	require.EqualValues(t, info.LastBlockHeight, resQuery.Height)
+
+	// async requests are answered in order
+	reqRes, err := app.CheckTxAsync(ctx, types.RequestCheckTx{Tx: tx})
+	require.NoError(t, err)
+	require.NoError(t, app.FlushSync(ctx))
+	reqRes.Wait()
+	require.Equal(t, code.CodeTypeOK, reqRes.Response.GetCheckTx().Code)
+}
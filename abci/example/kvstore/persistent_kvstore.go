@@ -170,6 +170,16 @@ func (app *PersistentKVStoreApplication) PreprocessTxs(
 	return types.ResponsePreprocessTxs{Txs: req.Txs}
 }
 
+func (app *PersistentKVStoreApplication) ProcessProposal(
+	req types.RequestProcessProposal) types.ResponseProcessProposal {
+	return app.app.ProcessProposal(req)
+}
+
+func (app *PersistentKVStoreApplication) GenerateFraudProof(
+	req types.RequestGenerateFraudProof) types.ResponseGenerateFraudProof {
+	return app.app.GenerateFraudProof(req)
+}
+
 //---------------------------------------------
 // update validators
 
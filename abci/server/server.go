@@ -0,0 +1,31 @@
+/*
+Package server is used to start a new ABCI server.
+
+It contains two server implementation:
+  - gRPC server
+  - socket server
+*/
+package server
+
+import (
+	"fmt"
+
+	"github.com/lazyledger/lazyledger-core/abci/types"
+	"github.com/lazyledger/lazyledger-core/libs/service"
+)
+
+// NewServer is a utility function for out of process applications to set up
+// either a socket or a gRPC server.
+func NewServer(protoAddr, transport string, app types.Application) (service.Service, error) {
+	var s service.Service
+	var err error
+	switch transport {
+	case "socket":
+		s = NewSocketServer(protoAddr, app)
+	case "grpc":
+		s = NewGRPCServer(protoAddr, types.NewGRPCApplication(app))
+	default:
+		err = fmt.Errorf("unknown server type %s", transport)
+	}
+	return s, err
+}
@@ -18,12 +18,14 @@ type Application interface {
 	CheckTx(RequestCheckTx) ResponseCheckTx // Validate a tx for the mempool
 
 	// Consensus Connection
-	InitChain(RequestInitChain) ResponseInitChain             // Initialize blockchain w validators/other info from TendermintCore
-	BeginBlock(RequestBeginBlock) ResponseBeginBlock          // Signals the beginning of a block
-	DeliverTx(RequestDeliverTx) ResponseDeliverTx             // Deliver a tx for full processing
-	EndBlock(RequestEndBlock) ResponseEndBlock                // Signals the end of a block, returns changes to the validator set
-	Commit() ResponseCommit                                   // Commit the state and return the application Merkle root hash
-	PreprocessTxs(RequestPreprocessTxs) ResponsePreprocessTxs // State machine preprocessing of txs
+	InitChain(RequestInitChain) ResponseInitChain                            // Initialize blockchain w validators/other info from TendermintCore
+	BeginBlock(RequestBeginBlock) ResponseBeginBlock                         // Signals the beginning of a block
+	DeliverTx(RequestDeliverTx) ResponseDeliverTx                            // Deliver a tx for full processing
+	EndBlock(RequestEndBlock) ResponseEndBlock                               // Signals the end of a block, returns changes to the validator set
+	Commit() ResponseCommit                                                  // Commit the state and return the application Merkle root hash
+	PreprocessTxs(RequestPreprocessTxs) ResponsePreprocessTxs                // State machine preprocessing of txs
+	ProcessProposal(RequestProcessProposal) ResponseProcessProposal          // Vet the data of a proposed block before prevoting
+	GenerateFraudProof(RequestGenerateFraudProof) ResponseGenerateFraudProof // Witness the pre-state of txs of the current block
 
 	// State Sync Connection
 	ListSnapshots(RequestListSnapshots) ResponseListSnapshots                // List available snapshots
@@ -96,6 +98,14 @@ func (BaseApplication) PreprocessTxs(req RequestPreprocessTxs) ResponsePreproces
 	return ResponsePreprocessTxs{}
 }
 
+func (BaseApplication) ProcessProposal(req RequestProcessProposal) ResponseProcessProposal {
+	return ResponseProcessProposal{Result: ResponseProcessProposal_ACCEPT}
+}
+
+func (BaseApplication) GenerateFraudProof(req RequestGenerateFraudProof) ResponseGenerateFraudProof {
+	return ResponseGenerateFraudProof{}
+}
+
 //-------------------------------------------------------
 
 // GRPCApplication is a GRPC wrapper for Application
@@ -184,3 +194,15 @@ func (app *GRPCApplication) PreprocessTxs(
 	res := app.app.PreprocessTxs(*req)
 	return &res, nil
 }
+
+func (app *GRPCApplication) ProcessProposal(
+	ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
+	res := app.app.ProcessProposal(*req)
+	return &res, nil
+}
+
+func (app *GRPCApplication) GenerateFraudProof(
+	ctx context.Context, req *RequestGenerateFraudProof) (*ResponseGenerateFraudProof, error) {
+	res := app.app.GenerateFraudProof(*req)
+	return &res, nil
+}
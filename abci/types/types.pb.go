@@ -64,18 +64,21 @@ const (
 	EvidenceType_UNKNOWN             EvidenceType = 0
 	EvidenceType_DUPLICATE_VOTE      EvidenceType = 1
 	EvidenceType_LIGHT_CLIENT_ATTACK EvidenceType = 2
+	EvidenceType_INVALID_DA_HEADER   EvidenceType = 3
 )
 
 var EvidenceType_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "DUPLICATE_VOTE",
 	2: "LIGHT_CLIENT_ATTACK",
+	3: "INVALID_DA_HEADER",
 }
 
 var EvidenceType_value = map[string]int32{
 	"UNKNOWN":             0,
 	"DUPLICATE_VOTE":      1,
 	"LIGHT_CLIENT_ATTACK": 2,
+	"INVALID_DA_HEADER":   3,
 }
 
 func (x EvidenceType) String() string {
@@ -120,7 +123,7 @@ func (x ResponseOfferSnapshot_Result) String() string {
 }
 
 func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{31, 0}
 }
 
 type ResponseApplySnapshotChunk_Result int32
@@ -157,7 +160,35 @@ func (x ResponseApplySnapshotChunk_Result) String() string {
 }
 
 func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31, 0}
+	return fileDescriptor_252557cfdd89a31a, []int{33, 0}
+}
+
+type ResponseProcessProposal_Result int32
+
+const (
+	ResponseProcessProposal_UNKNOWN ResponseProcessProposal_Result = 0
+	ResponseProcessProposal_ACCEPT  ResponseProcessProposal_Result = 1
+	ResponseProcessProposal_REJECT  ResponseProcessProposal_Result = 2
+)
+
+var ResponseProcessProposal_Result_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "REJECT",
+}
+
+var ResponseProcessProposal_Result_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACCEPT":  1,
+	"REJECT":  2,
+}
+
+func (x ResponseProcessProposal_Result) String() string {
+	return proto.EnumName(ResponseProcessProposal_Result_name, int32(x))
+}
+
+func (ResponseProcessProposal_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{35, 0}
 }
 
 type Request struct {
@@ -177,6 +208,8 @@ type Request struct {
 	//	*Request_LoadSnapshotChunk
 	//	*Request_ApplySnapshotChunk
 	//	*Request_PreprocessTxs
+	//	*Request_ProcessProposal
+	//	*Request_GenerateFraudProof
 	Value isRequest_Value `protobuf_oneof:"value"`
 }
 
@@ -264,6 +297,12 @@ type Request_ApplySnapshotChunk struct {
 type Request_PreprocessTxs struct {
 	PreprocessTxs *RequestPreprocessTxs `protobuf:"bytes,15,opt,name=preprocess_txs,json=preprocessTxs,proto3,oneof" json:"preprocess_txs,omitempty"`
 }
+type Request_ProcessProposal struct {
+	ProcessProposal *RequestProcessProposal `protobuf:"bytes,16,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
+}
+type Request_GenerateFraudProof struct {
+	GenerateFraudProof *RequestGenerateFraudProof `protobuf:"bytes,17,opt,name=generate_fraud_proof,json=generateFraudProof,proto3,oneof" json:"generate_fraud_proof,omitempty"`
+}
 
 func (*Request_Echo) isRequest_Value()               {}
 func (*Request_Flush) isRequest_Value()              {}
@@ -280,6 +319,8 @@ func (*Request_OfferSnapshot) isRequest_Value()      {}
 func (*Request_LoadSnapshotChunk) isRequest_Value()  {}
 func (*Request_ApplySnapshotChunk) isRequest_Value() {}
 func (*Request_PreprocessTxs) isRequest_Value()      {}
+func (*Request_ProcessProposal) isRequest_Value()    {}
+func (*Request_GenerateFraudProof) isRequest_Value() {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -393,6 +434,20 @@ func (m *Request) GetPreprocessTxs() *RequestPreprocessTxs {
 	return nil
 }
 
+func (m *Request) GetProcessProposal() *RequestProcessProposal {
+	if x, ok := m.GetValue().(*Request_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
+func (m *Request) GetGenerateFraudProof() *RequestGenerateFraudProof {
+	if x, ok := m.GetValue().(*Request_GenerateFraudProof); ok {
+		return x.GenerateFraudProof
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -411,6 +466,8 @@ func (*Request) XXX_OneofWrappers() []interface{} {
 		(*Request_LoadSnapshotChunk)(nil),
 		(*Request_ApplySnapshotChunk)(nil),
 		(*Request_PreprocessTxs)(nil),
+		(*Request_ProcessProposal)(nil),
+		(*Request_GenerateFraudProof)(nil),
 	}
 }
 
@@ -1172,6 +1229,8 @@ func (m *RequestApplySnapshotChunk) GetSender() string {
 
 type RequestPreprocessTxs struct {
 	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	// messages[i] is the message paid for by txs[i], empty if it pays for none
+	Messages []types1.Message `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages"`
 }
 
 func (m *RequestPreprocessTxs) Reset()         { *m = RequestPreprocessTxs{} }
@@ -1214,6 +1273,139 @@ func (m *RequestPreprocessTxs) GetTxs() [][]byte {
 	return nil
 }
 
+func (m *RequestPreprocessTxs) GetMessages() []types1.Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// Asks the application to vet the data of a proposed block before prevoting.
+// Applications committing to intermediate state roots must reject the block if
+// its roots are not the ones they compute for its txs: once the block is
+// committed, a mismatch can only be reported with fraud proofs.
+type RequestProcessProposal struct {
+	Header                 types1.Header                  `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Data                   types1.Data                    `protobuf:"bytes,2,opt,name=data,proto3" json:"data"`
+	DataAvailabilityHeader *types1.DataAvailabilityHeader `protobuf:"bytes,3,opt,name=data_availability_header,json=dataAvailabilityHeader,proto3" json:"data_availability_header,omitempty"`
+}
+
+func (m *RequestProcessProposal) Reset()         { *m = RequestProcessProposal{} }
+func (m *RequestProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*RequestProcessProposal) ProtoMessage()    {}
+func (*RequestProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{16}
+}
+func (m *RequestProcessProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestProcessProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestProcessProposal.Merge(m, src)
+}
+func (m *RequestProcessProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestProcessProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestProcessProposal proto.InternalMessageInfo
+
+func (m *RequestProcessProposal) GetHeader() types1.Header {
+	if m != nil {
+		return m.Header
+	}
+	return types1.Header{}
+}
+
+func (m *RequestProcessProposal) GetData() types1.Data {
+	if m != nil {
+		return m.Data
+	}
+	return types1.Data{}
+}
+
+func (m *RequestProcessProposal) GetDataAvailabilityHeader() *types1.DataAvailabilityHeader {
+	if m != nil {
+		return m.DataAvailabilityHeader
+	}
+	return nil
+}
+
+// Asks the application for the pre-state witnesses of txs that were executed
+// in the current block, to prove an invalid intermediate state root
+type RequestGenerateFraudProof struct {
+	Height       int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	FirstTxIndex uint32   `protobuf:"varint,2,opt,name=first_tx_index,json=firstTxIndex,proto3" json:"first_tx_index,omitempty"`
+	Txs          [][]byte `protobuf:"bytes,3,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *RequestGenerateFraudProof) Reset()         { *m = RequestGenerateFraudProof{} }
+func (m *RequestGenerateFraudProof) String() string { return proto.CompactTextString(m) }
+func (*RequestGenerateFraudProof) ProtoMessage()    {}
+func (*RequestGenerateFraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{17}
+}
+func (m *RequestGenerateFraudProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestGenerateFraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestGenerateFraudProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestGenerateFraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestGenerateFraudProof.Merge(m, src)
+}
+func (m *RequestGenerateFraudProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestGenerateFraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestGenerateFraudProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestGenerateFraudProof proto.InternalMessageInfo
+
+func (m *RequestGenerateFraudProof) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RequestGenerateFraudProof) GetFirstTxIndex() uint32 {
+	if m != nil {
+		return m.FirstTxIndex
+	}
+	return 0
+}
+
+func (m *RequestGenerateFraudProof) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
 type Response struct {
 	// Types that are valid to be assigned to Value:
 	//	*Response_Exception
@@ -1232,6 +1424,8 @@ type Response struct {
 	//	*Response_LoadSnapshotChunk
 	//	*Response_ApplySnapshotChunk
 	//	*Response_PreprocessTxs
+	//	*Response_ProcessProposal
+	//	*Response_GenerateFraudProof
 	Value isResponse_Value `protobuf_oneof:"value"`
 }
 
@@ -1239,7 +1433,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{16}
+	return fileDescriptor_252557cfdd89a31a, []int{18}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1322,6 +1516,12 @@ type Response_ApplySnapshotChunk struct {
 type Response_PreprocessTxs struct {
 	PreprocessTxs *ResponsePreprocessTxs `protobuf:"bytes,16,opt,name=preprocess_txs,json=preprocessTxs,proto3,oneof" json:"preprocess_txs,omitempty"`
 }
+type Response_ProcessProposal struct {
+	ProcessProposal *ResponseProcessProposal `protobuf:"bytes,17,opt,name=process_proposal,json=processProposal,proto3,oneof" json:"process_proposal,omitempty"`
+}
+type Response_GenerateFraudProof struct {
+	GenerateFraudProof *ResponseGenerateFraudProof `protobuf:"bytes,18,opt,name=generate_fraud_proof,json=generateFraudProof,proto3,oneof" json:"generate_fraud_proof,omitempty"`
+}
 
 func (*Response_Exception) isResponse_Value()          {}
 func (*Response_Echo) isResponse_Value()               {}
@@ -1339,6 +1539,8 @@ func (*Response_OfferSnapshot) isResponse_Value()      {}
 func (*Response_LoadSnapshotChunk) isResponse_Value()  {}
 func (*Response_ApplySnapshotChunk) isResponse_Value() {}
 func (*Response_PreprocessTxs) isResponse_Value()      {}
+func (*Response_ProcessProposal) isResponse_Value()    {}
+func (*Response_GenerateFraudProof) isResponse_Value() {}
 
 func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
@@ -1459,6 +1661,20 @@ func (m *Response) GetPreprocessTxs() *ResponsePreprocessTxs {
 	return nil
 }
 
+func (m *Response) GetProcessProposal() *ResponseProcessProposal {
+	if x, ok := m.GetValue().(*Response_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
+func (m *Response) GetGenerateFraudProof() *ResponseGenerateFraudProof {
+	if x, ok := m.GetValue().(*Response_GenerateFraudProof); ok {
+		return x.GenerateFraudProof
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1478,6 +1694,8 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_LoadSnapshotChunk)(nil),
 		(*Response_ApplySnapshotChunk)(nil),
 		(*Response_PreprocessTxs)(nil),
+		(*Response_ProcessProposal)(nil),
+		(*Response_GenerateFraudProof)(nil),
 	}
 }
 
@@ -1490,7 +1708,7 @@ func (m *ResponseException) Reset()         { *m = ResponseException{} }
 func (m *ResponseException) String() string { return proto.CompactTextString(m) }
 func (*ResponseException) ProtoMessage()    {}
 func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{17}
+	return fileDescriptor_252557cfdd89a31a, []int{19}
 }
 func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1534,7 +1752,7 @@ func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
 func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
 func (*ResponseEcho) ProtoMessage()    {}
 func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{18}
+	return fileDescriptor_252557cfdd89a31a, []int{20}
 }
 func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1577,7 +1795,7 @@ func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
 func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
 func (*ResponseFlush) ProtoMessage()    {}
 func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{19}
+	return fileDescriptor_252557cfdd89a31a, []int{21}
 }
 func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1619,7 +1837,7 @@ func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
 func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
 func (*ResponseInfo) ProtoMessage()    {}
 func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{20}
+	return fileDescriptor_252557cfdd89a31a, []int{22}
 }
 func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1693,7 +1911,7 @@ func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
 func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
 func (*ResponseInitChain) ProtoMessage()    {}
 func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{21}
+	return fileDescriptor_252557cfdd89a31a, []int{23}
 }
 func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1760,7 +1978,7 @@ func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
 func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
 func (*ResponseQuery) ProtoMessage()    {}
 func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{22}
+	return fileDescriptor_252557cfdd89a31a, []int{24}
 }
 func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1860,7 +2078,7 @@ func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
 func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseBeginBlock) ProtoMessage()    {}
 func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{23}
+	return fileDescriptor_252557cfdd89a31a, []int{25}
 }
 func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1905,13 +2123,21 @@ type ResponseCheckTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// the message the tx pays for, if any, kept by the mempool next to the tx
+	Message *types1.Message `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	// priority of the tx, used by the priority mempool to order and evict txs
+	Priority int64 `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
+	// sender and nonce of the tx; the mempool holds one tx per sender and nonce
+	// and replaces it with a later one only if that has a higher priority
+	Sender string `protobuf:"bytes,11,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce  uint64 `protobuf:"varint,12,opt,name=nonce,proto3" json:"nonce,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
 func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseCheckTx) ProtoMessage()    {}
 func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{24}
+	return fileDescriptor_252557cfdd89a31a, []int{26}
 }
 func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1996,6 +2222,34 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetMessage() *types1.Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *ResponseCheckTx) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2005,13 +2259,16 @@ type ResponseDeliverTx struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// intermediate state root after this tx. Apps committing to intermediate state
+	// roots return one after every txs_per_root txs of the block and after the last tx
+	IntermediateStateRoot []byte `protobuf:"bytes,9,opt,name=intermediate_state_root,json=intermediateStateRoot,proto3" json:"intermediate_state_root,omitempty"`
 }
 
 func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
 func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseDeliverTx) ProtoMessage()    {}
 func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{25}
+	return fileDescriptor_252557cfdd89a31a, []int{27}
 }
 func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2096,6 +2353,13 @@ func (m *ResponseDeliverTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseDeliverTx) GetIntermediateStateRoot() []byte {
+	if m != nil {
+		return m.IntermediateStateRoot
+	}
+	return nil
+}
+
 type ResponseEndBlock struct {
 	ValidatorUpdates      []ValidatorUpdate `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
 	ConsensusParamUpdates *ConsensusParams  `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
@@ -2106,7 +2370,7 @@ func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
 func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
 func (*ResponseEndBlock) ProtoMessage()    {}
 func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{26}
+	return fileDescriptor_252557cfdd89a31a, []int{28}
 }
 func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2166,7 +2430,7 @@ func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
 func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
 func (*ResponseCommit) ProtoMessage()    {}
 func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{27}
+	return fileDescriptor_252557cfdd89a31a, []int{29}
 }
 func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2217,7 +2481,7 @@ func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
 func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
 func (*ResponseListSnapshots) ProtoMessage()    {}
 func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{28}
+	return fileDescriptor_252557cfdd89a31a, []int{30}
 }
 func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2261,7 +2525,7 @@ func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
 func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
 func (*ResponseOfferSnapshot) ProtoMessage()    {}
 func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{29}
+	return fileDescriptor_252557cfdd89a31a, []int{31}
 }
 func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2305,7 +2569,7 @@ func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotC
 func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
 func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{30}
+	return fileDescriptor_252557cfdd89a31a, []int{32}
 }
 func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2351,7 +2615,7 @@ func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapsho
 func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
 func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{31}
+	return fileDescriptor_252557cfdd89a31a, []int{33}
 }
 func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2404,13 +2668,16 @@ func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 type ResponsePreprocessTxs struct {
 	Txs      [][]byte         `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
 	Messages *types1.Messages `protobuf:"bytes,2,opt,name=messages,proto3" json:"messages,omitempty"`
+	// intermediate state roots the app commits to for the txs, one after every
+	// txs_per_root txs. Apps not committing to intermediate state roots return none.
+	IntermediateStateRoots *types1.IntermediateStateRoots `protobuf:"bytes,3,opt,name=intermediate_state_roots,json=intermediateStateRoots,proto3" json:"intermediate_state_roots,omitempty"`
 }
 
 func (m *ResponsePreprocessTxs) Reset()         { *m = ResponsePreprocessTxs{} }
 func (m *ResponsePreprocessTxs) String() string { return proto.CompactTextString(m) }
 func (*ResponsePreprocessTxs) ProtoMessage()    {}
 func (*ResponsePreprocessTxs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{32}
+	return fileDescriptor_252557cfdd89a31a, []int{34}
 }
 func (m *ResponsePreprocessTxs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2453,6 +2720,101 @@ func (m *ResponsePreprocessTxs) GetMessages() *types1.Messages {
 	return nil
 }
 
+func (m *ResponsePreprocessTxs) GetIntermediateStateRoots() *types1.IntermediateStateRoots {
+	if m != nil {
+		return m.IntermediateStateRoots
+	}
+	return nil
+}
+
+type ResponseProcessProposal struct {
+	Result ResponseProcessProposal_Result `protobuf:"varint,1,opt,name=result,proto3,enum=tendermint.abci.ResponseProcessProposal_Result" json:"result,omitempty"`
+}
+
+func (m *ResponseProcessProposal) Reset()         { *m = ResponseProcessProposal{} }
+func (m *ResponseProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*ResponseProcessProposal) ProtoMessage()    {}
+func (*ResponseProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{35}
+}
+func (m *ResponseProcessProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseProcessProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseProcessProposal.Merge(m, src)
+}
+func (m *ResponseProcessProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseProcessProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseProcessProposal proto.InternalMessageInfo
+
+func (m *ResponseProcessProposal) GetResult() ResponseProcessProposal_Result {
+	if m != nil {
+		return m.Result
+	}
+	return ResponseProcessProposal_UNKNOWN
+}
+
+type ResponseGenerateFraudProof struct {
+	StateWitness []byte `protobuf:"bytes,1,opt,name=state_witness,json=stateWitness,proto3" json:"state_witness,omitempty"`
+}
+
+func (m *ResponseGenerateFraudProof) Reset()         { *m = ResponseGenerateFraudProof{} }
+func (m *ResponseGenerateFraudProof) String() string { return proto.CompactTextString(m) }
+func (*ResponseGenerateFraudProof) ProtoMessage()    {}
+func (*ResponseGenerateFraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_252557cfdd89a31a, []int{36}
+}
+func (m *ResponseGenerateFraudProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseGenerateFraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseGenerateFraudProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseGenerateFraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseGenerateFraudProof.Merge(m, src)
+}
+func (m *ResponseGenerateFraudProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseGenerateFraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseGenerateFraudProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseGenerateFraudProof proto.InternalMessageInfo
+
+func (m *ResponseGenerateFraudProof) GetStateWitness() []byte {
+	if m != nil {
+		return m.StateWitness
+	}
+	return nil
+}
+
 // ConsensusParams contains all consensus-relevant parameters
 // that can be adjusted by the abci app
 type ConsensusParams struct {
@@ -2466,7 +2828,7 @@ func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
 func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
 func (*ConsensusParams) ProtoMessage()    {}
 func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{33}
+	return fileDescriptor_252557cfdd89a31a, []int{37}
 }
 func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2535,7 +2897,7 @@ func (m *BlockParams) Reset()         { *m = BlockParams{} }
 func (m *BlockParams) String() string { return proto.CompactTextString(m) }
 func (*BlockParams) ProtoMessage()    {}
 func (*BlockParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{34}
+	return fileDescriptor_252557cfdd89a31a, []int{38}
 }
 func (m *BlockParams) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2587,7 +2949,7 @@ func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
 func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
 func (*LastCommitInfo) ProtoMessage()    {}
 func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{35}
+	return fileDescriptor_252557cfdd89a31a, []int{39}
 }
 func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2642,7 +3004,7 @@ func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{36}
+	return fileDescriptor_252557cfdd89a31a, []int{40}
 }
 func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2696,7 +3058,7 @@ func (m *EventAttribute) Reset()         { *m = EventAttribute{} }
 func (m *EventAttribute) String() string { return proto.CompactTextString(m) }
 func (*EventAttribute) ProtoMessage()    {}
 func (*EventAttribute) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{37}
+	return fileDescriptor_252557cfdd89a31a, []int{41}
 }
 func (m *EventAttribute) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2760,7 +3122,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{38}
+	return fileDescriptor_252557cfdd89a31a, []int{42}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2828,7 +3190,7 @@ func (m *Validator) Reset()         { *m = Validator{} }
 func (m *Validator) String() string { return proto.CompactTextString(m) }
 func (*Validator) ProtoMessage()    {}
 func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{39}
+	return fileDescriptor_252557cfdd89a31a, []int{43}
 }
 func (m *Validator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2881,7 +3243,7 @@ func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
 func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
 func (*ValidatorUpdate) ProtoMessage()    {}
 func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{40}
+	return fileDescriptor_252557cfdd89a31a, []int{44}
 }
 func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2934,7 +3296,7 @@ func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
 func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
 func (*VoteInfo) ProtoMessage()    {}
 func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{41}
+	return fileDescriptor_252557cfdd89a31a, []int{45}
 }
 func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2995,7 +3357,7 @@ func (m *Evidence) Reset()         { *m = Evidence{} }
 func (m *Evidence) String() string { return proto.CompactTextString(m) }
 func (*Evidence) ProtoMessage()    {}
 func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{42}
+	return fileDescriptor_252557cfdd89a31a, []int{46}
 }
 func (m *Evidence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3071,7 +3433,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_252557cfdd89a31a, []int{43}
+	return fileDescriptor_252557cfdd89a31a, []int{47}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3140,6 +3502,7 @@ func init() {
 	proto.RegisterEnum("tendermint.abci.EvidenceType", EvidenceType_name, EvidenceType_value)
 	proto.RegisterEnum("tendermint.abci.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
 	proto.RegisterEnum("tendermint.abci.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	proto.RegisterEnum("tendermint.abci.ResponseProcessProposal_Result", ResponseProcessProposal_Result_name, ResponseProcessProposal_Result_value)
 	proto.RegisterType((*Request)(nil), "tendermint.abci.Request")
 	proto.RegisterType((*RequestEcho)(nil), "tendermint.abci.RequestEcho")
 	proto.RegisterType((*RequestFlush)(nil), "tendermint.abci.RequestFlush")
@@ -3156,6 +3519,8 @@ func init() {
 	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "tendermint.abci.RequestLoadSnapshotChunk")
 	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "tendermint.abci.RequestApplySnapshotChunk")
 	proto.RegisterType((*RequestPreprocessTxs)(nil), "tendermint.abci.RequestPreprocessTxs")
+	proto.RegisterType((*RequestProcessProposal)(nil), "tendermint.abci.RequestProcessProposal")
+	proto.RegisterType((*RequestGenerateFraudProof)(nil), "tendermint.abci.RequestGenerateFraudProof")
 	proto.RegisterType((*Response)(nil), "tendermint.abci.Response")
 	proto.RegisterType((*ResponseException)(nil), "tendermint.abci.ResponseException")
 	proto.RegisterType((*ResponseEcho)(nil), "tendermint.abci.ResponseEcho")
@@ -3173,6 +3538,8 @@ func init() {
 	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "tendermint.abci.ResponseLoadSnapshotChunk")
 	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "tendermint.abci.ResponseApplySnapshotChunk")
 	proto.RegisterType((*ResponsePreprocessTxs)(nil), "tendermint.abci.ResponsePreprocessTxs")
+	proto.RegisterType((*ResponseProcessProposal)(nil), "tendermint.abci.ResponseProcessProposal")
+	proto.RegisterType((*ResponseGenerateFraudProof)(nil), "tendermint.abci.ResponseGenerateFraudProof")
 	proto.RegisterType((*ConsensusParams)(nil), "tendermint.abci.ConsensusParams")
 	proto.RegisterType((*BlockParams)(nil), "tendermint.abci.BlockParams")
 	proto.RegisterType((*LastCommitInfo)(nil), "tendermint.abci.LastCommitInfo")
@@ -3189,182 +3556,206 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 2788 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcd, 0x77, 0x23, 0xc5,
-	0x11, 0xd7, 0xe8, 0x5b, 0x25, 0x4b, 0x96, 0x7b, 0x97, 0x45, 0x88, 0xc5, 0x5e, 0x86, 0x07, 0x59,
-	0x36, 0x60, 0x83, 0x79, 0x6c, 0xe0, 0x41, 0x02, 0x96, 0xd0, 0x22, 0xb3, 0xc6, 0x76, 0xda, 0xda,
-	0x25, 0x5f, 0xec, 0xd0, 0xd2, 0xb4, 0xa5, 0x61, 0xa5, 0x99, 0x61, 0x66, 0x64, 0x6c, 0x8e, 0xf9,
-	0xb8, 0x90, 0x43, 0x38, 0xe6, 0xc2, 0xff, 0x91, 0x53, 0x2e, 0xb9, 0xf0, 0x5e, 0x2e, 0x1c, 0x73,
-	0x22, 0x79, 0xec, 0x2d, 0xb7, 0x9c, 0x72, 0xca, 0x4b, 0x5e, 0x7f, 0xcc, 0x97, 0xa4, 0x91, 0xe4,
-	0x90, 0x5b, 0x6e, 0xdd, 0x35, 0x55, 0xa5, 0xe9, 0x9a, 0xee, 0x5f, 0xfd, 0xaa, 0x5a, 0xf0, 0xa4,
-	0x47, 0x4d, 0x9d, 0x3a, 0x63, 0xc3, 0xf4, 0x76, 0x48, 0xaf, 0x6f, 0xec, 0x78, 0x17, 0x36, 0x75,
-	0xb7, 0x6d, 0xc7, 0xf2, 0x2c, 0xb4, 0x1e, 0x3e, 0xdc, 0x66, 0x0f, 0x1b, 0x4f, 0x45, 0xb4, 0xfb,
-	0xce, 0x85, 0xed, 0x59, 0x3b, 0xb6, 0x63, 0x59, 0xa7, 0x42, 0xbf, 0x71, 0x3d, 0xf2, 0x98, 0xfb,
-	0x89, 0x7a, 0x8b, 0x3d, 0x95, 0xc6, 0x0f, 0xe9, 0x85, 0xff, 0xf4, 0xa9, 0x19, 0x5b, 0x9b, 0x38,
-	0x64, 0xec, 0x3f, 0xde, 0x1a, 0x58, 0xd6, 0x60, 0x44, 0x77, 0xf8, 0xac, 0x37, 0x39, 0xdd, 0xf1,
-	0x8c, 0x31, 0x75, 0x3d, 0x32, 0xb6, 0xa5, 0xc2, 0xd5, 0x81, 0x35, 0xb0, 0xf8, 0x70, 0x87, 0x8d,
-	0x84, 0x54, 0xfd, 0xb2, 0x08, 0x05, 0x4c, 0x3f, 0x99, 0x50, 0xd7, 0x43, 0xbb, 0x90, 0xa5, 0xfd,
-	0xa1, 0x55, 0x57, 0x6e, 0x28, 0x37, 0xcb, 0xbb, 0xd7, 0xb7, 0xa7, 0x16, 0xb7, 0x2d, 0xf5, 0xda,
-	0xfd, 0xa1, 0xd5, 0x49, 0x61, 0xae, 0x8b, 0x5e, 0x85, 0xdc, 0xe9, 0x68, 0xe2, 0x0e, 0xeb, 0x69,
-	0x6e, 0xf4, 0x54, 0x92, 0xd1, 0x1d, 0xa6, 0xd4, 0x49, 0x61, 0xa1, 0xcd, 0x7e, 0xca, 0x30, 0x4f,
-	0xad, 0x7a, 0x66, 0xf1, 0x4f, 0xed, 0x9b, 0xa7, 0xfc, 0xa7, 0x98, 0x2e, 0x6a, 0x02, 0x18, 0xa6,
-	0xe1, 0x69, 0xfd, 0x21, 0x31, 0xcc, 0x7a, 0x96, 0x5b, 0x3e, 0x9d, 0x6c, 0x69, 0x78, 0x2d, 0xa6,
-	0xd8, 0x49, 0xe1, 0x92, 0xe1, 0x4f, 0xd8, 0xeb, 0x7e, 0x32, 0xa1, 0xce, 0x45, 0x3d, 0xb7, 0xf8,
-	0x75, 0x7f, 0xcc, 0x94, 0xd8, 0xeb, 0x72, 0x6d, 0xd4, 0x86, 0x72, 0x8f, 0x0e, 0x0c, 0x53, 0xeb,
-	0x8d, 0xac, 0xfe, 0xc3, 0x7a, 0x9e, 0x1b, 0xab, 0x49, 0xc6, 0x4d, 0xa6, 0xda, 0x64, 0x9a, 0x9d,
-	0x14, 0x86, 0x5e, 0x30, 0x43, 0x6f, 0x42, 0xb1, 0x3f, 0xa4, 0xfd, 0x87, 0x9a, 0x77, 0x5e, 0x2f,
-	0x70, 0x1f, 0x5b, 0x49, 0x3e, 0x5a, 0x4c, 0xaf, 0x7b, 0xde, 0x49, 0xe1, 0x42, 0x5f, 0x0c, 0xd9,
-	0xfa, 0x75, 0x3a, 0x32, 0xce, 0xa8, 0xc3, 0xec, 0x8b, 0x8b, 0xd7, 0xff, 0x8e, 0xd0, 0xe4, 0x1e,
-	0x4a, 0xba, 0x3f, 0x41, 0x6f, 0x41, 0x89, 0x9a, 0xba, 0x5c, 0x46, 0x89, 0xbb, 0xb8, 0x91, 0xf8,
-	0x9d, 0x4d, 0xdd, 0x5f, 0x44, 0x91, 0xca, 0x31, 0x7a, 0x0d, 0xf2, 0x7d, 0x6b, 0x3c, 0x36, 0xbc,
-	0x3a, 0x70, 0xeb, 0xcd, 0xc4, 0x05, 0x70, 0xad, 0x4e, 0x0a, 0x4b, 0x7d, 0x74, 0x08, 0xd5, 0x91,
-	0xe1, 0x7a, 0x9a, 0x6b, 0x12, 0xdb, 0x1d, 0x5a, 0x9e, 0x5b, 0x2f, 0x73, 0x0f, 0xcf, 0x26, 0x79,
-	0x38, 0x30, 0x5c, 0xef, 0xc4, 0x57, 0xee, 0xa4, 0x70, 0x65, 0x14, 0x15, 0x30, 0x7f, 0xd6, 0xe9,
-	0x29, 0x75, 0x02, 0x87, 0xf5, 0xb5, 0xc5, 0xfe, 0x8e, 0x98, 0xb6, 0x6f, 0xcf, 0xfc, 0x59, 0x51,
-	0x01, 0xfa, 0x39, 0x5c, 0x19, 0x59, 0x44, 0x0f, 0xdc, 0x69, 0xfd, 0xe1, 0xc4, 0x7c, 0x58, 0xaf,
-	0x70, 0xa7, 0xcf, 0x27, 0xbe, 0xa4, 0x45, 0x74, 0xdf, 0x45, 0x8b, 0x19, 0x74, 0x52, 0x78, 0x63,
-	0x34, 0x2d, 0x44, 0x0f, 0xe0, 0x2a, 0xb1, 0xed, 0xd1, 0xc5, 0xb4, 0xf7, 0x2a, 0xf7, 0x7e, 0x2b,
-	0xc9, 0xfb, 0x1e, 0xb3, 0x99, 0x76, 0x8f, 0xc8, 0x8c, 0x94, 0x05, 0xc3, 0x76, 0xa8, 0xed, 0x58,
-	0x7d, 0xea, 0xba, 0x9a, 0x77, 0xee, 0xd6, 0xd7, 0x17, 0x07, 0xe3, 0x38, 0xd0, 0xee, 0x9e, 0xf3,
-	0xe0, 0xda, 0x51, 0x41, 0xb3, 0x00, 0xb9, 0x33, 0x32, 0x9a, 0x50, 0xf5, 0x7b, 0x50, 0x8e, 0x1c,
-	0x7b, 0x54, 0x87, 0xc2, 0x98, 0xba, 0x2e, 0x19, 0x50, 0x8e, 0x12, 0x25, 0xec, 0x4f, 0xd5, 0x2a,
-	0xac, 0x45, 0x8f, 0xba, 0xfa, 0x85, 0x12, 0x58, 0xb2, 0x53, 0xcc, 0x2c, 0xcf, 0xa8, 0xe3, 0x1a,
-	0x96, 0xe9, 0x5b, 0xca, 0x29, 0x7a, 0x06, 0x2a, 0x7c, 0x3f, 0x6a, 0xfe, 0x73, 0x06, 0x25, 0x59,
-	0xbc, 0xc6, 0x85, 0xf7, 0xa5, 0xd2, 0x16, 0x94, 0xed, 0x5d, 0x3b, 0x50, 0xc9, 0x70, 0x15, 0xb0,
-	0x77, 0x6d, 0x5f, 0xe1, 0x69, 0x58, 0x63, 0xeb, 0x0b, 0x34, 0xb2, 0xfc, 0x47, 0xca, 0x4c, 0x26,
-	0x55, 0xd4, 0x3f, 0xa7, 0xa1, 0x36, 0x0d, 0x0f, 0xe8, 0x35, 0xc8, 0x32, 0xa4, 0x94, 0xa0, 0xd7,
-	0xd8, 0x16, 0x30, 0xba, 0xed, 0xc3, 0xe8, 0x76, 0xd7, 0x87, 0xd1, 0x66, 0xf1, 0xab, 0x6f, 0xb6,
-	0x52, 0x5f, 0xfc, 0x75, 0x4b, 0xc1, 0xdc, 0x02, 0x3d, 0xc1, 0x4e, 0x33, 0x31, 0x4c, 0xcd, 0xd0,
-	0xf9, 0x2b, 0x97, 0xd8, 0x51, 0x25, 0x86, 0xb9, 0xaf, 0xa3, 0xbb, 0x50, 0xeb, 0x5b, 0xa6, 0x4b,
-	0x4d, 0x77, 0xe2, 0x6a, 0x02, 0xa6, 0x25, 0xd4, 0xcd, 0x9e, 0xb6, 0x96, 0xaf, 0x78, 0xcc, 0xf5,
-	0xf0, 0x7a, 0x3f, 0x2e, 0x40, 0x77, 0x00, 0xce, 0xc8, 0xc8, 0xd0, 0x89, 0x67, 0x39, 0x6e, 0x3d,
-	0x7b, 0x23, 0x33, 0xd7, 0xcd, 0x7d, 0x5f, 0xe5, 0x9e, 0xad, 0x13, 0x8f, 0x36, 0xb3, 0xec, 0x6d,
-	0x71, 0xc4, 0x12, 0x3d, 0x07, 0xeb, 0xc4, 0xb6, 0x35, 0xd7, 0x23, 0x1e, 0xd5, 0x7a, 0x17, 0x1e,
-	0x75, 0x39, 0x0a, 0xae, 0xe1, 0x0a, 0xb1, 0xed, 0x13, 0x26, 0x6d, 0x32, 0x21, 0x7a, 0x16, 0xaa,
-	0x0c, 0x30, 0x0d, 0x32, 0xd2, 0x86, 0xd4, 0x18, 0x0c, 0x3d, 0x8e, 0x77, 0x19, 0x5c, 0x91, 0xd2,
-	0x0e, 0x17, 0xaa, 0x7a, 0xf0, 0xc1, 0x39, 0x58, 0x22, 0x04, 0x59, 0x9d, 0x78, 0x84, 0x07, 0x72,
-	0x0d, 0xf3, 0x31, 0x93, 0xd9, 0xc4, 0x1b, 0xca, 0xf0, 0xf0, 0x31, 0xba, 0x06, 0x79, 0xe9, 0x36,
-	0xc3, 0xdd, 0xca, 0x19, 0xba, 0x0a, 0x39, 0xdb, 0xb1, 0xce, 0x28, 0xff, 0x72, 0x45, 0x2c, 0x26,
-	0xea, 0xaf, 0xd3, 0xb0, 0x31, 0x03, 0xab, 0xcc, 0xef, 0x90, 0xb8, 0x43, 0xff, 0xb7, 0xd8, 0x18,
-	0xdd, 0x66, 0x7e, 0x89, 0x4e, 0x1d, 0x99, 0x8a, 0xea, 0xd1, 0x10, 0x89, 0x34, 0xdb, 0xe1, 0xcf,
-	0x65, 0x68, 0xa4, 0x36, 0x3a, 0x82, 0xda, 0x88, 0xb8, 0x9e, 0x26, 0x60, 0x4a, 0x8b, 0xa4, 0xa5,
-	0x59, 0x70, 0x3e, 0x20, 0x3e, 0xb0, 0xb1, 0x3d, 0x2d, 0x1d, 0x55, 0x47, 0x31, 0x29, 0xc2, 0x70,
-	0xb5, 0x77, 0xf1, 0x19, 0x31, 0x3d, 0xc3, 0xa4, 0xda, 0xcc, 0x97, 0x7b, 0x62, 0xc6, 0x69, 0xfb,
-	0xcc, 0xd0, 0xa9, 0xd9, 0xf7, 0x3f, 0xd9, 0x95, 0xc0, 0x38, 0xf8, 0xa4, 0xae, 0x8a, 0xa1, 0x1a,
-	0x4f, 0x0c, 0xa8, 0x0a, 0x69, 0xef, 0x5c, 0x06, 0x20, 0xed, 0x9d, 0xa3, 0x97, 0x20, 0xcb, 0x16,
-	0xc9, 0x17, 0x5f, 0x9d, 0x93, 0x51, 0xa5, 0x5d, 0xf7, 0xc2, 0xa6, 0x98, 0x6b, 0xaa, 0x6a, 0x70,
-	0x1a, 0x82, 0x64, 0x31, 0xed, 0x55, 0x7d, 0x1e, 0xd6, 0xa7, 0xb2, 0x41, 0xe4, 0xfb, 0x29, 0xd1,
-	0xef, 0xa7, 0xae, 0x43, 0x25, 0x06, 0xfd, 0xea, 0x35, 0xb8, 0x3a, 0x0f, 0xc9, 0xd5, 0x61, 0x20,
-	0x8f, 0x21, 0x32, 0x7a, 0x15, 0x8a, 0x01, 0x94, 0x8b, 0xd3, 0x38, 0x1b, 0x2b, 0x5f, 0x19, 0x07,
-	0xaa, 0xec, 0x18, 0xb2, 0x6d, 0xcd, 0xf7, 0x43, 0x9a, 0xbf, 0x78, 0x81, 0xd8, 0x76, 0x87, 0xb8,
-	0x43, 0xf5, 0x23, 0xa8, 0x27, 0xc1, 0xf4, 0xd4, 0x32, 0xb2, 0xc1, 0x36, 0xbc, 0x06, 0xf9, 0x53,
-	0xcb, 0x19, 0x13, 0x8f, 0x3b, 0xab, 0x60, 0x39, 0x63, 0xdb, 0x53, 0x40, 0x76, 0x86, 0x8b, 0xc5,
-	0x44, 0xd5, 0xe0, 0x89, 0x44, 0xa8, 0x66, 0x26, 0x86, 0xa9, 0x53, 0x11, 0xcf, 0x0a, 0x16, 0x93,
-	0xd0, 0x91, 0x78, 0x59, 0x31, 0x61, 0x3f, 0xeb, 0xf2, 0xb5, 0x72, 0xff, 0x25, 0x2c, 0x67, 0xea,
-	0xcd, 0x20, 0x58, 0x31, 0xc4, 0x46, 0x35, 0xc8, 0x30, 0x94, 0x57, 0x6e, 0x64, 0x6e, 0xae, 0x61,
-	0x36, 0x54, 0xff, 0x51, 0x84, 0x22, 0xa6, 0xae, 0xcd, 0xd0, 0x03, 0x35, 0xa1, 0x44, 0xcf, 0xfb,
-	0xd4, 0xf6, 0x7c, 0xbc, 0x9d, 0x4f, 0x57, 0x84, 0x76, 0xdb, 0xd7, 0x64, 0x5c, 0x21, 0x30, 0x43,
-	0xaf, 0x48, 0x3a, 0x98, 0xcc, 0xec, 0xa4, 0x79, 0x94, 0x0f, 0xde, 0xf6, 0xf9, 0x60, 0x26, 0x91,
-	0x1e, 0x08, 0xab, 0x29, 0x42, 0xf8, 0x8a, 0x24, 0x84, 0xd9, 0x25, 0x3f, 0x16, 0x63, 0x84, 0xad,
-	0x18, 0x23, 0xcc, 0x2d, 0x59, 0x66, 0x02, 0x25, 0xbc, 0xed, 0x53, 0xc2, 0xfc, 0x92, 0x37, 0x9e,
-	0xe2, 0x84, 0x77, 0xe2, 0x9c, 0x50, 0xf0, 0xb9, 0x67, 0x12, 0xad, 0x13, 0x49, 0xe1, 0x0f, 0x23,
-	0xa4, 0xb0, 0x98, 0xc8, 0xc8, 0x84, 0x93, 0x39, 0xac, 0xb0, 0x15, 0x63, 0x85, 0xa5, 0x25, 0x31,
-	0x48, 0xa0, 0x85, 0x6f, 0x47, 0x69, 0x21, 0x24, 0x32, 0x4b, 0xf9, 0xbd, 0xe7, 0xf1, 0xc2, 0xd7,
-	0x03, 0x5e, 0x58, 0x4e, 0x24, 0xb6, 0x72, 0x0d, 0xd3, 0xc4, 0xf0, 0x68, 0x86, 0x18, 0x0a, 0x22,
-	0xf7, 0x5c, 0xa2, 0x8b, 0x25, 0xcc, 0xf0, 0x68, 0x86, 0x19, 0x56, 0x96, 0x38, 0x5c, 0x42, 0x0d,
-	0x7f, 0x31, 0x9f, 0x1a, 0x26, 0x93, 0x37, 0xf9, 0x9a, 0xab, 0x71, 0x43, 0x2d, 0x81, 0x1b, 0x0a,
-	0x06, 0xf7, 0xfd, 0x44, 0xf7, 0x2b, 0x93, 0xc3, 0xa3, 0x19, 0x72, 0x58, 0x5b, 0x12, 0x8f, 0x55,
-	0xd9, 0xe1, 0xf3, 0x2c, 0x39, 0x4f, 0x81, 0x08, 0x03, 0x38, 0xea, 0x38, 0x96, 0x23, 0x79, 0x9e,
-	0x98, 0xa8, 0x37, 0x19, 0x5d, 0x08, 0x01, 0x63, 0x01, 0x93, 0xe4, 0x89, 0x24, 0x02, 0x12, 0xea,
-	0x1f, 0x94, 0xd0, 0x96, 0x67, 0xd8, 0x28, 0xd5, 0x28, 0x49, 0xaa, 0x11, 0xe1, 0x97, 0xe9, 0x38,
-	0xbf, 0xdc, 0x82, 0x32, 0x4b, 0x10, 0x53, 0xd4, 0x91, 0xd8, 0x01, 0x75, 0xbc, 0x05, 0x1b, 0x9c,
-	0x01, 0x08, 0x16, 0x2a, 0xb3, 0x42, 0x96, 0x27, 0xb7, 0x75, 0xf6, 0x40, 0xec, 0x76, 0x91, 0x1e,
-	0x5e, 0x84, 0x2b, 0x11, 0xdd, 0x20, 0xf1, 0x08, 0x22, 0x55, 0x0b, 0xb4, 0xf7, 0x64, 0x06, 0xfa,
-	0x93, 0x12, 0x46, 0x28, 0xe4, 0x9c, 0xf3, 0xe8, 0xa1, 0xf2, 0xbf, 0xa1, 0x87, 0xe9, 0xff, 0x9a,
-	0x1e, 0x46, 0xf3, 0x68, 0x26, 0x9e, 0x47, 0xff, 0xa9, 0x84, 0x9f, 0x24, 0x20, 0x7b, 0x7d, 0x4b,
-	0xa7, 0x32, 0xb3, 0xf1, 0x31, 0x4b, 0x49, 0x23, 0x6b, 0x20, 0xf3, 0x17, 0x1b, 0x32, 0xad, 0x00,
-	0xd4, 0x4b, 0x12, 0xb3, 0x83, 0xa4, 0x98, 0xe3, 0x01, 0x96, 0x49, 0xb1, 0x06, 0x99, 0x87, 0x54,
-	0x40, 0xf0, 0x1a, 0x66, 0x43, 0xa6, 0xc7, 0xf7, 0x18, 0x07, 0xd6, 0x35, 0x2c, 0x26, 0xe8, 0x35,
-	0x28, 0xf1, 0x7e, 0x8a, 0x66, 0xd9, 0xae, 0x44, 0xcb, 0x27, 0xa3, 0x6b, 0x15, 0x6d, 0x93, 0xed,
-	0x63, 0xa6, 0x73, 0x64, 0xbb, 0xb8, 0x68, 0xcb, 0x51, 0x24, 0xdf, 0x97, 0x62, 0xb4, 0xf3, 0x3a,
-	0x94, 0xd8, 0xdb, 0xbb, 0x36, 0xe9, 0x53, 0x0e, 0x7d, 0x25, 0x1c, 0x0a, 0xd4, 0x07, 0x80, 0x66,
-	0x01, 0x1c, 0x75, 0x20, 0x4f, 0xcf, 0xa8, 0xe9, 0x89, 0xfc, 0x5b, 0xde, 0xbd, 0x36, 0x87, 0xd3,
-	0x51, 0xd3, 0x6b, 0xd6, 0x59, 0x90, 0xff, 0xfe, 0xcd, 0x56, 0x4d, 0x68, 0xbf, 0x60, 0x8d, 0x0d,
-	0x8f, 0x8e, 0x6d, 0xef, 0x02, 0x4b, 0x7b, 0xf5, 0x57, 0x69, 0x46, 0xb0, 0x62, 0xe0, 0x3e, 0x37,
-	0xb6, 0xfe, 0x8e, 0x4f, 0x47, 0xc8, 0xf5, 0x6a, 0xf1, 0xde, 0x04, 0x18, 0x10, 0x57, 0xfb, 0x94,
-	0x98, 0x1e, 0xd5, 0x65, 0xd0, 0x23, 0x12, 0xd4, 0x80, 0x22, 0x9b, 0x4d, 0x5c, 0xaa, 0x4b, 0x9e,
-	0x1f, 0xcc, 0x23, 0xeb, 0x2c, 0x7c, 0xb7, 0x75, 0xc6, 0xa3, 0x5c, 0x9c, 0x8e, 0xf2, 0x6f, 0xd2,
-	0xe1, 0x29, 0x09, 0xb9, 0xe8, 0xff, 0x5f, 0x1c, 0x7e, 0xcb, 0x0b, 0xd4, 0x78, 0x96, 0x45, 0x27,
-	0xb0, 0x11, 0x9c, 0x52, 0x6d, 0xc2, 0x4f, 0xaf, 0xbf, 0xef, 0x56, 0x3d, 0xe6, 0xb5, 0xb3, 0xb8,
-	0xd8, 0x45, 0x3f, 0x81, 0xc7, 0xa7, 0x10, 0x28, 0x70, 0x9d, 0x5e, 0x11, 0x88, 0x1e, 0x8b, 0x03,
-	0x91, 0xef, 0x39, 0x8c, 0x55, 0xe6, 0x3b, 0x9e, 0x8d, 0x7d, 0x56, 0xf3, 0x44, 0x39, 0xc3, 0xdc,
-	0xaf, 0xff, 0x0c, 0x54, 0x1c, 0xea, 0xb1, 0x32, 0x3c, 0x56, 0x55, 0xae, 0x09, 0xa1, 0xac, 0x55,
-	0x8f, 0xe1, 0xb1, 0xb9, 0xdc, 0x01, 0xfd, 0x00, 0x4a, 0x21, 0xed, 0x50, 0x12, 0x0a, 0xb4, 0xa0,
-	0xe8, 0x08, 0x75, 0xd5, 0x3f, 0x2a, 0xa1, 0xcb, 0x78, 0x19, 0xd3, 0x86, 0xbc, 0x43, 0xdd, 0xc9,
-	0x48, 0x14, 0x16, 0xd5, 0xdd, 0x17, 0x57, 0x63, 0x1d, 0x4c, 0x3a, 0x19, 0x79, 0x58, 0x1a, 0xab,
-	0x0f, 0x20, 0x2f, 0x24, 0xa8, 0x0c, 0x85, 0x7b, 0x87, 0x77, 0x0f, 0x8f, 0x3e, 0x38, 0xac, 0xa5,
-	0x10, 0x40, 0x7e, 0xaf, 0xd5, 0x6a, 0x1f, 0x77, 0x6b, 0x0a, 0x2a, 0x41, 0x6e, 0xaf, 0x79, 0x84,
-	0xbb, 0xb5, 0x34, 0x13, 0xe3, 0xf6, 0x7b, 0xed, 0x56, 0xb7, 0x96, 0x41, 0x1b, 0x50, 0x11, 0x63,
-	0xed, 0xce, 0x11, 0x7e, 0x7f, 0xaf, 0x5b, 0xcb, 0x46, 0x44, 0x27, 0xed, 0xc3, 0x77, 0xda, 0xb8,
-	0x96, 0x53, 0x5f, 0x66, 0x95, 0x4b, 0x02, 0x4f, 0x09, 0x6b, 0x14, 0x25, 0x52, 0xa3, 0xa8, 0xbf,
-	0x4f, 0x43, 0x23, 0x99, 0x7c, 0xa0, 0xf7, 0xa6, 0x16, 0xbe, 0x7b, 0x09, 0xe6, 0x32, 0xb5, 0x7a,
-	0xf4, 0x2c, 0x54, 0x1d, 0x7a, 0x4a, 0xbd, 0xfe, 0x50, 0x90, 0x21, 0x91, 0xd8, 0x2a, 0xb8, 0x22,
-	0xa5, 0xdc, 0xc8, 0x15, 0x6a, 0x1f, 0xd3, 0xbe, 0xa7, 0x89, 0x72, 0x49, 0x6c, 0xba, 0x12, 0x53,
-	0x63, 0xd2, 0x13, 0x21, 0x54, 0x3f, 0xba, 0x54, 0x2c, 0x4b, 0x90, 0xc3, 0xed, 0x2e, 0xfe, 0x69,
-	0x2d, 0x83, 0x10, 0x54, 0xf9, 0x50, 0x3b, 0x39, 0xdc, 0x3b, 0x3e, 0xe9, 0x1c, 0xb1, 0x58, 0x5e,
-	0x81, 0x75, 0x3f, 0x96, 0xbe, 0x30, 0xa7, 0x92, 0x70, 0x37, 0x2c, 0xa9, 0xd3, 0xd0, 0x6d, 0x28,
-	0x4a, 0xa6, 0xe3, 0x9f, 0xb5, 0xc6, 0x6c, 0xa7, 0xe2, 0x7d, 0xa9, 0x81, 0x03, 0x5d, 0xf5, 0xdf,
-	0x0a, 0xac, 0x4f, 0x9d, 0x41, 0xb4, 0x0b, 0x39, 0xc1, 0xd9, 0x93, 0x5a, 0xf6, 0x1c, 0x42, 0xe4,
-	0x81, 0x15, 0xaa, 0xe8, 0x4d, 0x28, 0x52, 0xd9, 0x71, 0x98, 0x77, 0xd6, 0xc5, 0xef, 0xfb, 0x3d,
-	0x09, 0x69, 0x1a, 0x58, 0xa0, 0xb7, 0xa0, 0x14, 0x80, 0x89, 0xac, 0xf1, 0x9e, 0x9e, 0x35, 0x0f,
-	0x60, 0x48, 0xda, 0x87, 0x36, 0xe8, 0xf5, 0x90, 0xa7, 0x65, 0x67, 0x2b, 0x05, 0x69, 0x2e, 0x14,
-	0xa4, 0xb1, 0xaf, 0xaf, 0xb6, 0xa0, 0x1c, 0x59, 0x0f, 0x7a, 0x12, 0x4a, 0x63, 0x72, 0x2e, 0x3b,
-	0x59, 0xa2, 0x17, 0x51, 0x1c, 0x93, 0x73, 0xd1, 0xc4, 0x7a, 0x1c, 0x0a, 0xec, 0xe1, 0x80, 0x88,
-	0x20, 0x67, 0x70, 0x7e, 0x4c, 0xce, 0xdf, 0x25, 0xae, 0xfa, 0x21, 0x54, 0xe3, 0x5d, 0x1c, 0xb6,
-	0xd9, 0x1d, 0x6b, 0x62, 0xea, 0xdc, 0x47, 0x0e, 0x8b, 0x09, 0x7a, 0x15, 0x72, 0x67, 0x96, 0xc0,
-	0xc3, 0xf9, 0xa8, 0x70, 0xdf, 0xf2, 0x68, 0xa4, 0x0b, 0x24, 0xb4, 0xd5, 0xcf, 0x20, 0xc7, 0xf1,
-	0x8d, 0x61, 0x15, 0xef, 0xc7, 0x48, 0x8e, 0xca, 0xc6, 0xe8, 0x43, 0x00, 0xe2, 0x79, 0x8e, 0xd1,
-	0x9b, 0x84, 0x8e, 0xb7, 0xe6, 0xe3, 0xe3, 0x9e, 0xaf, 0xd7, 0xbc, 0x2e, 0x81, 0xf2, 0x6a, 0x68,
-	0x1a, 0x01, 0xcb, 0x88, 0x43, 0xf5, 0x10, 0xaa, 0x71, 0x5b, 0x9f, 0x56, 0x29, 0x73, 0x68, 0x55,
-	0x3a, 0x4a, 0xab, 0x02, 0x52, 0x96, 0x11, 0xbd, 0x37, 0x3e, 0x51, 0x3f, 0x57, 0xa0, 0xd8, 0x3d,
-	0x97, 0x27, 0x27, 0xa1, 0xed, 0x13, 0x9a, 0xa6, 0xa3, 0x4d, 0x0e, 0xd1, 0x47, 0xca, 0x04, 0xdd,
-	0xa9, 0xb7, 0x03, 0x6c, 0xc8, 0xae, 0x5a, 0xa1, 0xfa, 0x6d, 0x3a, 0x89, 0x87, 0x6f, 0x40, 0x29,
-	0xd8, 0x55, 0x8c, 0xec, 0x13, 0x5d, 0x77, 0xa8, 0xeb, 0xca, 0xb5, 0xf9, 0x53, 0xde, 0x45, 0xb4,
-	0x3e, 0x95, 0x6d, 0x94, 0x0c, 0x16, 0x13, 0x55, 0x87, 0xf5, 0xa9, 0xcc, 0x88, 0xde, 0x80, 0x82,
-	0x3d, 0xe9, 0x69, 0x7e, 0x78, 0xa6, 0x0e, 0x8f, 0xcf, 0x23, 0x27, 0xbd, 0x91, 0xd1, 0xbf, 0x4b,
-	0x2f, 0xfc, 0x97, 0xb1, 0x27, 0xbd, 0xbb, 0x22, 0x8a, 0xe2, 0x57, 0xd2, 0xd1, 0x5f, 0x39, 0x83,
-	0xa2, 0xbf, 0x29, 0xd0, 0x8f, 0xa2, 0xe7, 0x44, 0x99, 0x3d, 0xe6, 0xf1, 0x6c, 0x2d, 0xdd, 0x47,
-	0x8e, 0xc9, 0x2d, 0xd8, 0x70, 0x8d, 0x81, 0x49, 0x75, 0x2d, 0x2c, 0x37, 0xf8, 0xaf, 0x15, 0xf1,
-	0xba, 0x78, 0x70, 0xe0, 0xd7, 0x1a, 0xea, 0xbf, 0x14, 0x28, 0xfa, 0x07, 0x16, 0xbd, 0x1c, 0xd9,
-	0x77, 0xd5, 0x39, 0x8d, 0x14, 0x5f, 0x31, 0x6c, 0x04, 0xc6, 0xdf, 0x35, 0x7d, 0xf9, 0x77, 0x4d,
-	0xea, 0xe8, 0xfa, 0xad, 0xf5, 0xec, 0xa5, 0x5b, 0xeb, 0x2f, 0x00, 0xf2, 0x2c, 0x8f, 0x8c, 0xb4,
-	0x33, 0xcb, 0x33, 0xcc, 0x81, 0x26, 0x82, 0x2d, 0x48, 0x5b, 0x8d, 0x3f, 0xb9, 0xcf, 0x1f, 0x1c,
-	0xf3, 0xb8, 0xff, 0x52, 0x81, 0x62, 0x90, 0x7e, 0x2f, 0xdb, 0xd7, 0xbb, 0x06, 0x79, 0x99, 0x61,
-	0x44, 0x63, 0x4f, 0xce, 0x82, 0x16, 0x73, 0x36, 0xd2, 0x62, 0x6e, 0x30, 0xe8, 0xf6, 0x08, 0xe7,
-	0x20, 0xa2, 0xe2, 0x0b, 0xe6, 0xb7, 0x5e, 0x87, 0x72, 0xa4, 0xc5, 0xca, 0x4e, 0xde, 0x61, 0xfb,
-	0x83, 0x5a, 0xaa, 0x51, 0xf8, 0xfc, 0xcb, 0x1b, 0x99, 0x43, 0xfa, 0x29, 0xdb, 0xb3, 0xb8, 0xdd,
-	0xea, 0xb4, 0x5b, 0x77, 0x6b, 0x4a, 0xa3, 0xfc, 0xf9, 0x97, 0x37, 0x0a, 0x98, 0xf2, 0x26, 0xce,
-	0xad, 0x0e, 0xac, 0x45, 0xbf, 0x4a, 0x3c, 0x49, 0x21, 0xa8, 0xbe, 0x73, 0xef, 0xf8, 0x60, 0xbf,
-	0xb5, 0xd7, 0x6d, 0x6b, 0xf7, 0x8f, 0xba, 0xed, 0x9a, 0x82, 0x1e, 0x87, 0x2b, 0x07, 0xfb, 0xef,
-	0x76, 0xba, 0x5a, 0xeb, 0x60, 0xbf, 0x7d, 0xd8, 0xd5, 0xf6, 0xba, 0xdd, 0xbd, 0xd6, 0xdd, 0x5a,
-	0x7a, 0xf7, 0x77, 0x00, 0xeb, 0x7b, 0xcd, 0xd6, 0x3e, 0x4b, 0xb0, 0x46, 0x9f, 0xf0, 0x72, 0xbc,
-	0x05, 0x59, 0x5e, 0x70, 0x2f, 0xbc, 0xcf, 0x6d, 0x2c, 0x6e, 0xef, 0xa1, 0x3b, 0x90, 0xe3, 0xb5,
-	0x38, 0x5a, 0x7c, 0xc1, 0xdb, 0x58, 0xd2, 0xef, 0x63, 0x2f, 0xc3, 0x8f, 0xc7, 0xc2, 0x1b, 0xdf,
-	0xc6, 0xe2, 0xf6, 0x1f, 0xc2, 0x50, 0x0a, 0xab, 0x84, 0xe5, 0x37, 0xa0, 0x8d, 0x15, 0xc0, 0x06,
-	0x1d, 0x40, 0xc1, 0xaf, 0xbf, 0x96, 0xdd, 0xc9, 0x36, 0x96, 0xf6, 0xe7, 0x58, 0xb8, 0x44, 0x9d,
-	0xbc, 0xf8, 0x82, 0xb9, 0xb1, 0xa4, 0xd9, 0x88, 0xf6, 0x21, 0x2f, 0xa9, 0xef, 0x92, 0x7b, 0xd6,
-	0xc6, 0xb2, 0x7e, 0x1b, 0x0b, 0x5a, 0xd8, 0x80, 0x58, 0x7e, 0x6d, 0xde, 0x58, 0xa1, 0x8f, 0x8a,
-	0xee, 0x01, 0x44, 0xaa, 0xe2, 0x15, 0xee, 0xc3, 0x1b, 0xab, 0xf4, 0x47, 0xd1, 0x11, 0x14, 0x83,
-	0xea, 0x67, 0xe9, 0xed, 0x74, 0x63, 0x79, 0xa3, 0x12, 0x3d, 0x80, 0x4a, 0x9c, 0xf6, 0xaf, 0x76,
-	0xe7, 0xdc, 0x58, 0xb1, 0x03, 0xc9, 0xfc, 0xc7, 0x6b, 0x80, 0xd5, 0xee, 0xa0, 0x1b, 0x2b, 0x36,
-	0x24, 0xd1, 0xc7, 0xb0, 0x31, 0xcb, 0xd1, 0x57, 0xbf, 0x92, 0x6e, 0x5c, 0xa2, 0x45, 0x89, 0xc6,
-	0x80, 0xe6, 0x70, 0xfb, 0x4b, 0xdc, 0x50, 0x37, 0x2e, 0xd3, 0xb1, 0x64, 0xa1, 0x8b, 0x13, 0xe6,
-	0xd5, 0x6e, 0xac, 0x1b, 0x2b, 0xf6, 0x2e, 0x9b, 0xef, 0x7d, 0xf5, 0xed, 0xa6, 0xf2, 0xf5, 0xb7,
-	0x9b, 0xca, 0xdf, 0xbe, 0xdd, 0x54, 0xbe, 0x78, 0xb4, 0x99, 0xfa, 0xfa, 0xd1, 0x66, 0xea, 0x2f,
-	0x8f, 0x36, 0x53, 0x3f, 0x7b, 0x69, 0x60, 0x78, 0xc3, 0x49, 0x6f, 0xbb, 0x6f, 0x8d, 0x77, 0x46,
-	0xe4, 0xb3, 0x8b, 0x11, 0xd5, 0x07, 0xd4, 0x89, 0x0c, 0x5f, 0xec, 0x5b, 0x0e, 0x8d, 0xfc, 0xe7,
-	0xa7, 0x97, 0xe7, 0x99, 0xeb, 0x95, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0xb0, 0xf7, 0x58, 0xc2,
-	0x13, 0x24, 0x00, 0x00,
+	// 3182 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0xcb, 0x73, 0x1b, 0xc7,
+	0xd1, 0xc7, 0xe2, 0x41, 0x02, 0x8d, 0x27, 0x47, 0x14, 0x05, 0xc1, 0x32, 0x29, 0xaf, 0x3f, 0xdb,
+	0xb2, 0x3e, 0x8b, 0xb4, 0xa9, 0xb2, 0x3e, 0xbb, 0xfc, 0x25, 0x31, 0x08, 0x41, 0x02, 0x2d, 0x9a,
+	0x64, 0x86, 0x90, 0x9c, 0x97, 0xb5, 0x5e, 0x60, 0x87, 0xc0, 0x5a, 0xc0, 0xee, 0x7a, 0x77, 0x40,
+	0x91, 0x3e, 0xba, 0x72, 0x72, 0x2e, 0xce, 0x2d, 0x17, 0xff, 0x01, 0xf9, 0x0f, 0x72, 0x49, 0x2e,
+	0xa9, 0x54, 0xb9, 0x2a, 0x17, 0x57, 0xe5, 0x92, 0x93, 0x93, 0xb2, 0x53, 0x39, 0xe4, 0x1f, 0xc8,
+	0x29, 0x95, 0xd4, 0x3c, 0x76, 0xb1, 0x0b, 0x60, 0x09, 0x30, 0xce, 0x2d, 0x27, 0xcc, 0xf4, 0x76,
+	0x37, 0x66, 0x7a, 0x66, 0xba, 0xfb, 0xd7, 0x33, 0xf0, 0x0c, 0x25, 0x96, 0x41, 0xdc, 0xa1, 0x69,
+	0xd1, 0x2d, 0xbd, 0xd3, 0x35, 0xb7, 0xe8, 0x99, 0x43, 0xbc, 0x4d, 0xc7, 0xb5, 0xa9, 0x8d, 0xca,
+	0xe3, 0x8f, 0x9b, 0xec, 0x63, 0xed, 0xd9, 0x10, 0x77, 0xd7, 0x3d, 0x73, 0xa8, 0xbd, 0xe5, 0xb8,
+	0xb6, 0x7d, 0x2c, 0xf8, 0x6b, 0xd7, 0x42, 0x9f, 0xb9, 0x9e, 0xb0, 0xb6, 0xda, 0xb5, 0x69, 0xe1,
+	0x27, 0xe4, 0xcc, 0xff, 0xfa, 0xec, 0x94, 0xac, 0xa3, 0xbb, 0xfa, 0xd0, 0xff, 0xbc, 0xd1, 0xb3,
+	0xed, 0xde, 0x80, 0x6c, 0xf1, 0x5e, 0x67, 0x74, 0xbc, 0x45, 0xcd, 0x21, 0xf1, 0xa8, 0x3e, 0x74,
+	0x24, 0xc3, 0x6a, 0xcf, 0xee, 0xd9, 0xbc, 0xb9, 0xc5, 0x5a, 0x82, 0xaa, 0xfe, 0x21, 0x07, 0xcb,
+	0x98, 0x7c, 0x34, 0x22, 0x1e, 0x45, 0xdb, 0x90, 0x26, 0xdd, 0xbe, 0x5d, 0x55, 0xae, 0x2b, 0x37,
+	0xf2, 0xdb, 0xd7, 0x36, 0x27, 0x26, 0xb7, 0x29, 0xf9, 0x9a, 0xdd, 0xbe, 0xdd, 0x4a, 0x60, 0xce,
+	0x8b, 0x5e, 0x87, 0xcc, 0xf1, 0x60, 0xe4, 0xf5, 0xab, 0x49, 0x2e, 0xf4, 0x6c, 0x9c, 0xd0, 0x3d,
+	0xc6, 0xd4, 0x4a, 0x60, 0xc1, 0xcd, 0xfe, 0xca, 0xb4, 0x8e, 0xed, 0x6a, 0xea, 0xfc, 0xbf, 0xda,
+	0xb5, 0x8e, 0xf9, 0x5f, 0x31, 0x5e, 0xb4, 0x03, 0x60, 0x5a, 0x26, 0xd5, 0xba, 0x7d, 0xdd, 0xb4,
+	0xaa, 0x69, 0x2e, 0xf9, 0x5c, 0xbc, 0xa4, 0x49, 0x1b, 0x8c, 0xb1, 0x95, 0xc0, 0x39, 0xd3, 0xef,
+	0xb0, 0xe1, 0x7e, 0x34, 0x22, 0xee, 0x59, 0x35, 0x73, 0xfe, 0x70, 0xbf, 0xcf, 0x98, 0xd8, 0x70,
+	0x39, 0x37, 0x6a, 0x42, 0xbe, 0x43, 0x7a, 0xa6, 0xa5, 0x75, 0x06, 0x76, 0xf7, 0x49, 0x75, 0x89,
+	0x0b, 0xab, 0x71, 0xc2, 0x3b, 0x8c, 0x75, 0x87, 0x71, 0xb6, 0x12, 0x18, 0x3a, 0x41, 0x0f, 0xfd,
+	0x3f, 0x64, 0xbb, 0x7d, 0xd2, 0x7d, 0xa2, 0xd1, 0xd3, 0xea, 0x32, 0xd7, 0xb1, 0x11, 0xa7, 0xa3,
+	0xc1, 0xf8, 0xda, 0xa7, 0xad, 0x04, 0x5e, 0xee, 0x8a, 0x26, 0x9b, 0xbf, 0x41, 0x06, 0xe6, 0x09,
+	0x71, 0x99, 0x7c, 0xf6, 0xfc, 0xf9, 0xdf, 0x15, 0x9c, 0x5c, 0x43, 0xce, 0xf0, 0x3b, 0xe8, 0x7b,
+	0x90, 0x23, 0x96, 0x21, 0xa7, 0x91, 0xe3, 0x2a, 0xae, 0xc7, 0xae, 0xb3, 0x65, 0xf8, 0x93, 0xc8,
+	0x12, 0xd9, 0x46, 0x6f, 0xc0, 0x52, 0xd7, 0x1e, 0x0e, 0x4d, 0x5a, 0x05, 0x2e, 0xbd, 0x1e, 0x3b,
+	0x01, 0xce, 0xd5, 0x4a, 0x60, 0xc9, 0x8f, 0xf6, 0xa1, 0x34, 0x30, 0x3d, 0xaa, 0x79, 0x96, 0xee,
+	0x78, 0x7d, 0x9b, 0x7a, 0xd5, 0x3c, 0xd7, 0xf0, 0x42, 0x9c, 0x86, 0x3d, 0xd3, 0xa3, 0x47, 0x3e,
+	0x73, 0x2b, 0x81, 0x8b, 0x83, 0x30, 0x81, 0xe9, 0xb3, 0x8f, 0x8f, 0x89, 0x1b, 0x28, 0xac, 0x16,
+	0xce, 0xd7, 0x77, 0xc0, 0xb8, 0x7d, 0x79, 0xa6, 0xcf, 0x0e, 0x13, 0xd0, 0x8f, 0xe1, 0xd2, 0xc0,
+	0xd6, 0x8d, 0x40, 0x9d, 0xd6, 0xed, 0x8f, 0xac, 0x27, 0xd5, 0x22, 0x57, 0xfa, 0x72, 0xec, 0x20,
+	0x6d, 0xdd, 0xf0, 0x55, 0x34, 0x98, 0x40, 0x2b, 0x81, 0x57, 0x06, 0x93, 0x44, 0xf4, 0x18, 0x56,
+	0x75, 0xc7, 0x19, 0x9c, 0x4d, 0x6a, 0x2f, 0x71, 0xed, 0x37, 0xe3, 0xb4, 0xd7, 0x99, 0xcc, 0xa4,
+	0x7a, 0xa4, 0x4f, 0x51, 0x99, 0x31, 0x1c, 0x97, 0x38, 0xae, 0xdd, 0x25, 0x9e, 0xa7, 0xd1, 0x53,
+	0xaf, 0x5a, 0x3e, 0xdf, 0x18, 0x87, 0x01, 0x77, 0xfb, 0x94, 0x1b, 0xd7, 0x09, 0x13, 0x50, 0x1b,
+	0x2a, 0xbe, 0x32, 0xc7, 0xb5, 0x1d, 0xdb, 0xd3, 0x07, 0xd5, 0x0a, 0xd7, 0xf8, 0x52, 0xbc, 0x46,
+	0xce, 0x7f, 0x28, 0xd9, 0x5b, 0x09, 0x5c, 0x76, 0xa2, 0x24, 0x66, 0x85, 0x1e, 0xb1, 0x88, 0xab,
+	0x53, 0xa2, 0x1d, 0xbb, 0xfa, 0xc8, 0xd0, 0xb8, 0x73, 0xac, 0xae, 0x9c, 0x6f, 0x85, 0xfb, 0x52,
+	0xe6, 0x1e, 0x13, 0x39, 0x64, 0x12, 0xcc, 0x0a, 0xbd, 0x29, 0xea, 0xce, 0x32, 0x64, 0x4e, 0xf4,
+	0xc1, 0x88, 0xa8, 0x2f, 0x41, 0x3e, 0xe4, 0xac, 0x50, 0x15, 0x96, 0x87, 0xc4, 0xf3, 0xf4, 0x1e,
+	0xe1, 0xbe, 0x2d, 0x87, 0xfd, 0xae, 0x5a, 0x82, 0x42, 0xd8, 0x41, 0xa9, 0x9f, 0x29, 0x90, 0x0f,
+	0xf9, 0x1e, 0x26, 0x79, 0x42, 0x5c, 0xcf, 0xb4, 0x2d, 0x5f, 0x52, 0x76, 0xd1, 0xf3, 0x50, 0xe4,
+	0xa7, 0x48, 0xf3, 0xbf, 0x33, 0x07, 0x98, 0xc6, 0x05, 0x4e, 0x7c, 0x24, 0x99, 0x36, 0x20, 0xef,
+	0x6c, 0x3b, 0x01, 0x4b, 0x8a, 0xb3, 0x80, 0xb3, 0xed, 0xf8, 0x0c, 0xcf, 0x41, 0x81, 0xcd, 0x34,
+	0xe0, 0x48, 0xf3, 0x3f, 0xc9, 0x33, 0x9a, 0x64, 0x51, 0x7f, 0x9f, 0x84, 0xca, 0xa4, 0x53, 0x43,
+	0x6f, 0x40, 0x9a, 0xf9, 0x77, 0xe9, 0xaa, 0x6b, 0x9b, 0xc2, 0xf9, 0x6f, 0xfa, 0xce, 0x7f, 0xb3,
+	0xed, 0x3b, 0xff, 0x9d, 0xec, 0x17, 0x5f, 0x6d, 0x24, 0x3e, 0xfb, 0xd3, 0x86, 0x82, 0xb9, 0x04,
+	0xba, 0xca, 0x7c, 0x90, 0x6e, 0x5a, 0x9a, 0x69, 0xf0, 0x21, 0xe7, 0x98, 0x83, 0xd1, 0x4d, 0x6b,
+	0xd7, 0x40, 0x0f, 0xa0, 0xd2, 0xb5, 0x2d, 0x8f, 0x58, 0xde, 0xc8, 0xd3, 0x44, 0x70, 0xa9, 0xa6,
+	0x62, 0x7c, 0x44, 0xc3, 0x67, 0x3c, 0xe4, 0x7c, 0xb8, 0xdc, 0x8d, 0x12, 0xd0, 0x3d, 0x80, 0x13,
+	0x7d, 0x60, 0x1a, 0x3a, 0xb5, 0x5d, 0xaf, 0x9a, 0xbe, 0x9e, 0x9a, 0xa9, 0xe6, 0x91, 0xcf, 0xf2,
+	0xd0, 0x31, 0x74, 0x4a, 0x76, 0xd2, 0x6c, 0xb4, 0x38, 0x24, 0x89, 0x5e, 0x84, 0xb2, 0xee, 0x38,
+	0x9a, 0x47, 0xd9, 0xa6, 0xe9, 0x9c, 0x51, 0xe2, 0x71, 0xdf, 0x5d, 0xc0, 0x45, 0xdd, 0x71, 0x8e,
+	0x18, 0x75, 0x87, 0x11, 0xd1, 0x0b, 0x50, 0x62, 0x6e, 0xde, 0xd4, 0x07, 0x5a, 0x9f, 0x98, 0xbd,
+	0x3e, 0xe5, 0x5e, 0x3a, 0x85, 0x8b, 0x92, 0xda, 0xe2, 0x44, 0xd5, 0x80, 0x42, 0xd8, 0xc5, 0x23,
+	0x04, 0x69, 0x43, 0xa7, 0x3a, 0x37, 0x64, 0x01, 0xf3, 0x36, 0xa3, 0x39, 0x3a, 0xed, 0x4b, 0xf3,
+	0xf0, 0x36, 0x5a, 0x83, 0x25, 0xa9, 0x36, 0xc5, 0xd5, 0xca, 0x1e, 0x5a, 0x85, 0x8c, 0xe3, 0xda,
+	0x27, 0x84, 0xaf, 0x5c, 0x16, 0x8b, 0x8e, 0xfa, 0xd3, 0x24, 0xac, 0x4c, 0x05, 0x03, 0xa6, 0xb7,
+	0xaf, 0x7b, 0x7d, 0xff, 0xbf, 0x58, 0x1b, 0xdd, 0x61, 0x7a, 0x75, 0x83, 0xb8, 0x32, 0x80, 0x56,
+	0xc3, 0x26, 0x12, 0xc9, 0x41, 0x8b, 0x7f, 0x97, 0xa6, 0x91, 0xdc, 0xe8, 0x00, 0x2a, 0x03, 0xdd,
+	0xa3, 0x9a, 0x70, 0xae, 0x5a, 0x28, 0x98, 0x4e, 0x87, 0x94, 0x3d, 0xdd, 0x77, 0xc7, 0x6c, 0x4f,
+	0x4b, 0x45, 0xa5, 0x41, 0x84, 0x8a, 0x30, 0xac, 0x76, 0xce, 0x3e, 0xd6, 0x2d, 0x6a, 0x5a, 0x44,
+	0x9b, 0x5a, 0xb9, 0xab, 0x53, 0x4a, 0x9b, 0x27, 0xa6, 0x41, 0xac, 0xae, 0xbf, 0x64, 0x97, 0x02,
+	0xe1, 0x60, 0x49, 0x3d, 0x15, 0x43, 0x29, 0x1a, 0xce, 0x50, 0x09, 0x92, 0xf4, 0x54, 0x1a, 0x20,
+	0x49, 0x4f, 0xd1, 0xab, 0x90, 0x66, 0x93, 0xe4, 0x93, 0x2f, 0xcd, 0xc8, 0x03, 0xa4, 0x5c, 0xfb,
+	0xcc, 0x21, 0x98, 0x73, 0xaa, 0x2a, 0x54, 0x26, 0x43, 0xdc, 0xa4, 0x56, 0xf5, 0x65, 0x28, 0x4f,
+	0xc4, 0xb0, 0xd0, 0xfa, 0x29, 0xe1, 0xf5, 0x53, 0xcb, 0x50, 0x8c, 0x04, 0x2c, 0x75, 0x0d, 0x56,
+	0x67, 0xc5, 0x1f, 0xb5, 0x0f, 0xab, 0xb3, 0xe2, 0x08, 0x7a, 0x1d, 0xb2, 0x41, 0x00, 0x12, 0xa7,
+	0x71, 0xda, 0x56, 0x3e, 0x33, 0x0e, 0x58, 0xd9, 0x31, 0x64, 0xdb, 0x9a, 0xef, 0x87, 0x24, 0x1f,
+	0xf8, 0xb2, 0xee, 0x38, 0x2d, 0xdd, 0xeb, 0xab, 0x1f, 0x40, 0x35, 0x2e, 0xb8, 0x4c, 0x4c, 0x23,
+	0x1d, 0x6c, 0xc3, 0x35, 0x58, 0x3a, 0xb6, 0xdd, 0xa1, 0x4e, 0xb9, 0xb2, 0x22, 0x96, 0x3d, 0xb6,
+	0x3d, 0x45, 0xa0, 0x49, 0x71, 0xb2, 0xe8, 0xa8, 0x1a, 0x5c, 0x8d, 0x0d, 0x30, 0x4c, 0xc4, 0xb4,
+	0x0c, 0x22, 0xec, 0x59, 0xc4, 0xa2, 0x33, 0x56, 0x24, 0x06, 0x2b, 0x3a, 0xec, 0x6f, 0x3d, 0x3e,
+	0x57, 0xae, 0x3f, 0x87, 0x65, 0x4f, 0x25, 0xb0, 0x3a, 0x2b, 0xce, 0xa0, 0x0a, 0xa4, 0x58, 0x6c,
+	0x52, 0xae, 0xa7, 0x6e, 0x14, 0x30, 0x6b, 0xa2, 0xb7, 0x20, 0x2b, 0x7d, 0xb1, 0x57, 0x4d, 0x4e,
+	0x6f, 0x35, 0x71, 0x02, 0xde, 0x15, 0x1c, 0x72, 0xab, 0x05, 0x02, 0xea, 0x5f, 0x15, 0x58, 0x9b,
+	0x1d, 0x7d, 0x42, 0xe7, 0x4a, 0xb9, 0xd0, 0xb9, 0x7a, 0x55, 0xfa, 0x03, 0x71, 0x1a, 0xd7, 0xa6,
+	0xa5, 0xee, 0xea, 0x54, 0x97, 0x32, 0x9c, 0x13, 0x75, 0xa0, 0xca, 0x7e, 0x35, 0xfd, 0x44, 0x37,
+	0x07, 0x7a, 0xc7, 0x1c, 0x98, 0xf4, 0x4c, 0x93, 0xff, 0x2d, 0x4e, 0xe4, 0x8d, 0xd9, 0x5a, 0xea,
+	0x21, 0x01, 0x31, 0x16, 0xbc, 0x66, 0xcc, 0xa4, 0xab, 0x4f, 0xe0, 0x6a, 0x6c, 0x2c, 0x8c, 0xdb,
+	0xda, 0xe8, 0x7f, 0xa0, 0x74, 0x6c, 0xba, 0x1e, 0xd5, 0xe8, 0xa9, 0x26, 0x56, 0x54, 0xec, 0x8d,
+	0x02, 0xa7, 0xb6, 0x4f, 0x77, 0xf9, 0xc2, 0xca, 0x25, 0x49, 0x05, 0x4b, 0xa2, 0xfe, 0x12, 0x20,
+	0x8b, 0x89, 0xe7, 0x30, 0x87, 0x8e, 0x76, 0x20, 0x47, 0x4e, 0xbb, 0xc4, 0xa1, 0x7e, 0x08, 0x9c,
+	0x9d, 0xf7, 0x0a, 0xee, 0xa6, 0xcf, 0xc9, 0x92, 0xce, 0x40, 0x0c, 0xdd, 0x96, 0xb8, 0x22, 0x1e,
+	0x22, 0x48, 0xf1, 0x30, 0xb0, 0xb8, 0xe3, 0x03, 0x8b, 0x54, 0x6c, 0x9e, 0x29, 0xa4, 0x26, 0x90,
+	0xc5, 0x6d, 0x89, 0x2c, 0xd2, 0x73, 0xfe, 0x2c, 0x02, 0x2d, 0x1a, 0x11, 0x68, 0x91, 0x99, 0x33,
+	0xcd, 0x18, 0x6c, 0x71, 0xc7, 0xc7, 0x16, 0x4b, 0x73, 0x46, 0x3c, 0x01, 0x2e, 0xee, 0x45, 0xc1,
+	0x85, 0x00, 0x06, 0xcf, 0xc7, 0x4a, 0xc7, 0xa2, 0x8b, 0xef, 0x84, 0xd0, 0x45, 0x36, 0x36, 0xb5,
+	0x17, 0x4a, 0x66, 0xc0, 0x8b, 0x46, 0x04, 0x5e, 0xe4, 0xe6, 0xd8, 0x20, 0x06, 0x5f, 0xbc, 0x1d,
+	0xc6, 0x17, 0x10, 0x0b, 0x51, 0xe4, 0x7a, 0xcf, 0x02, 0x18, 0x6f, 0x06, 0x00, 0x23, 0x1f, 0x8b,
+	0x90, 0xe4, 0x1c, 0x26, 0x11, 0xc6, 0xc1, 0x14, 0xc2, 0x10, 0x88, 0xe0, 0xc5, 0x58, 0x15, 0x73,
+	0x20, 0xc6, 0xc1, 0x14, 0xc4, 0x28, 0xce, 0x51, 0x38, 0x07, 0x63, 0xfc, 0x64, 0x36, 0xc6, 0x88,
+	0x47, 0x01, 0x72, 0x98, 0x8b, 0x81, 0x0c, 0x2d, 0x06, 0x64, 0x08, 0x28, 0xf0, 0xbf, 0xb1, 0xea,
+	0x17, 0x46, 0x19, 0x07, 0x53, 0x28, 0xa3, 0x32, 0xc7, 0x1e, 0x73, 0x60, 0xc6, 0xc3, 0x19, 0x30,
+	0x63, 0x65, 0xda, 0x67, 0x4e, 0xa8, 0x9c, 0x8b, 0x33, 0xb4, 0x18, 0x9c, 0x81, 0xe6, 0x18, 0xe2,
+	0xe2, 0x40, 0xe3, 0x65, 0x58, 0xf1, 0x85, 0x03, 0xe7, 0xc7, 0x62, 0x25, 0x71, 0x5d, 0xdb, 0x95,
+	0x90, 0x41, 0x74, 0xd4, 0x1b, 0x50, 0x08, 0x58, 0xcf, 0x07, 0x25, 0x3c, 0x27, 0x09, 0x39, 0x37,
+	0xf5, 0x57, 0x0a, 0x14, 0xc2, 0x7e, 0x2b, 0x92, 0xb5, 0xe6, 0x64, 0x1c, 0x0a, 0x41, 0x95, 0x64,
+	0x14, 0xaa, 0x6c, 0x40, 0x9e, 0xe5, 0x1a, 0x13, 0x28, 0x44, 0x77, 0x02, 0x14, 0x72, 0x13, 0x56,
+	0x78, 0x32, 0x29, 0x00, 0x8d, 0x0c, 0x26, 0x69, 0x1e, 0x4c, 0xca, 0xec, 0x83, 0x38, 0xa5, 0x9c,
+	0x8c, 0x6e, 0xc1, 0xa5, 0x10, 0x6f, 0x90, 0xc3, 0x88, 0x9c, 0xbc, 0x12, 0x70, 0xd7, 0x65, 0x32,
+	0xf3, 0x5b, 0x05, 0x56, 0xa6, 0xfc, 0xe6, 0x4c, 0xa4, 0xa1, 0xfc, 0x67, 0x90, 0x46, 0xf2, 0xdf,
+	0x46, 0x1a, 0xe1, 0x94, 0x2c, 0x15, 0x4d, 0xc9, 0xfe, 0xae, 0x40, 0x31, 0xe2, 0xbd, 0xd9, 0x0a,
+	0x74, 0x6d, 0x83, 0xc8, 0x24, 0x89, 0xb7, 0x59, 0x28, 0x1d, 0xd8, 0x3d, 0x99, 0x0a, 0xb1, 0x26,
+	0xe3, 0x0a, 0x82, 0x51, 0x4e, 0xc6, 0x9a, 0x20, 0xbf, 0xca, 0x70, 0x03, 0x8b, 0x0e, 0x93, 0x7d,
+	0x42, 0x44, 0xe8, 0x28, 0x60, 0xd6, 0x44, 0xab, 0x72, 0x8f, 0xf1, 0x80, 0x50, 0xc0, 0xa2, 0x83,
+	0xde, 0x80, 0x1c, 0xdf, 0xcb, 0x9a, 0xed, 0x78, 0xd2, 0xcb, 0x3f, 0x13, 0x9e, 0xab, 0xa8, 0x1b,
+	0x6e, 0xf2, 0x6d, 0x7a, 0xe0, 0x78, 0x38, 0xeb, 0xc8, 0x56, 0x28, 0x4d, 0xc8, 0x45, 0xd2, 0x84,
+	0x6b, 0x90, 0x63, 0xa3, 0xf7, 0x1c, 0xbd, 0x4b, 0xb8, 0xcb, 0xce, 0xe1, 0x31, 0x41, 0x7d, 0x0c,
+	0x68, 0x3a, 0xf0, 0xa0, 0x16, 0x2c, 0x91, 0x13, 0x62, 0x51, 0x91, 0xca, 0x4d, 0xe4, 0x49, 0x12,
+	0x1e, 0x10, 0x8b, 0xee, 0x54, 0x99, 0x91, 0xff, 0xf6, 0xd5, 0x46, 0x45, 0x70, 0xbf, 0x62, 0x0f,
+	0x4d, 0x4a, 0x86, 0x0e, 0x3d, 0xc3, 0x52, 0x5e, 0xfd, 0x24, 0x05, 0x65, 0xff, 0x0f, 0x7c, 0x90,
+	0x30, 0xcb, 0xb6, 0x28, 0x94, 0x97, 0xf9, 0x38, 0x6d, 0x31, 0x7b, 0xaf, 0x03, 0xf4, 0x74, 0x4f,
+	0x7b, 0xaa, 0x5b, 0x94, 0x18, 0xd2, 0xe8, 0x21, 0x0a, 0xaa, 0x41, 0x96, 0xf5, 0x46, 0x1e, 0x31,
+	0x24, 0x64, 0x0c, 0xfa, 0xa1, 0x79, 0x2e, 0x7f, 0xbb, 0x79, 0x46, 0xad, 0x9c, 0x9d, 0xb0, 0x32,
+	0xba, 0x3d, 0xf6, 0x05, 0xb9, 0x69, 0x0c, 0x11, 0x49, 0x82, 0x03, 0x37, 0xc1, 0x06, 0xee, 0xb8,
+	0xa6, 0xed, 0x9a, 0xf4, 0x8c, 0xaf, 0x5b, 0x0a, 0x07, 0xfd, 0x50, 0x62, 0x9e, 0x0f, 0x27, 0xe6,
+	0x6c, 0x53, 0x59, 0xb6, 0xd5, 0x25, 0x3c, 0x32, 0xa6, 0xb1, 0xe8, 0xa8, 0xbf, 0x4e, 0xc2, 0xca,
+	0x54, 0x60, 0xff, 0x2f, 0x5c, 0x86, 0x3b, 0x70, 0xc5, 0xb4, 0x28, 0x71, 0x87, 0xc4, 0x30, 0x59,
+	0xec, 0x10, 0x45, 0x07, 0xd7, 0xb6, 0xc5, 0x99, 0x29, 0xe0, 0xcb, 0xe1, 0xcf, 0xbc, 0xf8, 0x80,
+	0x6d, 0x9b, 0xaa, 0x3f, 0xe3, 0x25, 0x9a, 0x68, 0x52, 0x83, 0x8e, 0x60, 0x25, 0x70, 0x2e, 0xda,
+	0x88, 0x3b, 0x1d, 0xff, 0xb8, 0x2c, 0xea, 0x9d, 0x2a, 0x27, 0x51, 0xb2, 0x87, 0x7e, 0x00, 0x57,
+	0x26, 0x1c, 0x67, 0xa0, 0x3a, 0xb9, 0xa0, 0xff, 0xbc, 0x1c, 0xf5, 0x9f, 0xbe, 0xe6, 0xb1, 0x8d,
+	0x53, 0xdf, 0xf2, 0x48, 0xef, 0x42, 0xc9, 0x37, 0x86, 0x48, 0xd1, 0x66, 0xee, 0x9a, 0xe7, 0xa1,
+	0xe8, 0x12, 0xca, 0x0a, 0x51, 0x91, 0xba, 0x4a, 0x41, 0x10, 0x65, 0xb5, 0xe6, 0x10, 0x2e, 0xcf,
+	0x4c, 0xd5, 0xd0, 0xff, 0x41, 0x6e, 0x9c, 0xe5, 0x29, 0x31, 0x25, 0x0a, 0x9f, 0x1d, 0x8f, 0x79,
+	0xd5, 0xdf, 0x28, 0x70, 0x79, 0x66, 0xb2, 0x86, 0x9a, 0xb0, 0xe4, 0x12, 0x6f, 0x34, 0x10, 0x30,
+	0xaa, 0xb4, 0x7d, 0x6b, 0xb1, 0x24, 0x8f, 0x51, 0x47, 0x03, 0x8a, 0xa5, 0xb0, 0xfa, 0x18, 0x96,
+	0x04, 0x05, 0xe5, 0x61, 0xf9, 0xe1, 0xfe, 0x83, 0xfd, 0x83, 0xf7, 0xf6, 0x2b, 0x09, 0x04, 0xb0,
+	0x54, 0x6f, 0x34, 0x9a, 0x87, 0xed, 0x8a, 0x82, 0x72, 0x90, 0xa9, 0xef, 0x1c, 0xe0, 0x76, 0x25,
+	0xc9, 0xc8, 0xb8, 0xf9, 0x4e, 0xb3, 0xd1, 0xae, 0xa4, 0xd0, 0x0a, 0x14, 0x45, 0x5b, 0xbb, 0x77,
+	0x80, 0xdf, 0xad, 0xb7, 0x2b, 0xe9, 0x10, 0xe9, 0xa8, 0xb9, 0x7f, 0xb7, 0x89, 0x2b, 0x19, 0xf5,
+	0x35, 0xb8, 0xea, 0x8f, 0x63, 0xba, 0x3c, 0x10, 0xa0, 0x74, 0x25, 0x84, 0xd2, 0xd5, 0x5f, 0x24,
+	0xa1, 0x16, 0x9f, 0xeb, 0xa1, 0x77, 0x26, 0x26, 0xbe, 0x7d, 0x81, 0x44, 0x71, 0x62, 0xf6, 0xac,
+	0x0a, 0xe7, 0x92, 0x63, 0x42, 0xbb, 0x7d, 0x91, 0x7b, 0x8a, 0x78, 0x5c, 0xc4, 0x45, 0x49, 0xe5,
+	0x42, 0x9e, 0x60, 0xfb, 0x90, 0x74, 0xa9, 0x26, 0xfc, 0x92, 0xd8, 0x74, 0x39, 0x5c, 0x14, 0xd4,
+	0x23, 0x41, 0x54, 0x3f, 0xb8, 0x90, 0x2d, 0x73, 0x90, 0xc1, 0xcd, 0x36, 0xfe, 0x61, 0x25, 0x85,
+	0x10, 0x94, 0x78, 0x53, 0x3b, 0xda, 0xaf, 0x1f, 0x1e, 0xb5, 0x0e, 0x98, 0x2d, 0x2f, 0x41, 0xd9,
+	0xb7, 0xa5, 0x4f, 0xcc, 0xa8, 0xbf, 0x0b, 0x6d, 0x87, 0x79, 0xa5, 0x8a, 0x3b, 0x91, 0x52, 0x85,
+	0xa8, 0xbb, 0xc6, 0x79, 0x69, 0x6f, 0x5c, 0xa5, 0x60, 0x05, 0x82, 0x18, 0xaf, 0xe2, 0xc5, 0x17,
+	0x08, 0x76, 0x67, 0x39, 0x1a, 0x0f, 0xaf, 0xcd, 0x74, 0x40, 0x9e, 0xfa, 0x73, 0x05, 0xae, 0xc4,
+	0x24, 0xc8, 0xe8, 0xfe, 0xc4, 0xfa, 0x6e, 0x2d, 0x9a, 0x5a, 0x4f, 0x6e, 0xed, 0x5b, 0xf3, 0x97,
+	0x63, 0xbc, 0x9f, 0x93, 0x6a, 0x1d, 0x6a, 0xf1, 0x89, 0x35, 0x3b, 0xff, 0xc2, 0x10, 0x4f, 0x4d,
+	0x6a, 0x11, 0xcf, 0x93, 0x5b, 0xb6, 0xc0, 0x89, 0xef, 0x09, 0x9a, 0xfa, 0x4f, 0x05, 0xca, 0x13,
+	0xfe, 0x0b, 0x6d, 0x43, 0x46, 0xc0, 0xcb, 0xb8, 0x6b, 0x4a, 0xee, 0x7e, 0x05, 0x33, 0xce, 0x74,
+	0xfc, 0x8b, 0x37, 0x22, 0xeb, 0x95, 0xb3, 0xfc, 0xa4, 0x30, 0xb9, 0x5f, 0xd1, 0x94, 0xa2, 0x81,
+	0x04, 0xbb, 0x34, 0x0b, 0x1c, 0x71, 0x35, 0x35, 0x0d, 0x6a, 0x85, 0x78, 0xe0, 0xc2, 0xa5, 0xfc,
+	0x58, 0x06, 0xbd, 0x39, 0x4e, 0xcd, 0xd3, 0xd3, 0xa0, 0x56, 0x8a, 0x0b, 0x06, 0x29, 0xec, 0xf3,
+	0xab, 0x0d, 0xc8, 0x87, 0xe6, 0x83, 0x9e, 0x81, 0xdc, 0x50, 0x3f, 0x95, 0x75, 0x70, 0x51, 0xee,
+	0xc9, 0x0e, 0xf5, 0x53, 0x51, 0x02, 0xbf, 0x02, 0xcb, 0xec, 0x63, 0x4f, 0x17, 0xfb, 0x33, 0x85,
+	0x97, 0x86, 0xfa, 0xe9, 0x7d, 0xdd, 0x53, 0xdf, 0x87, 0x52, 0xb4, 0x06, 0xcc, 0x1c, 0x85, 0x6b,
+	0x8f, 0x2c, 0x83, 0xeb, 0xc8, 0x60, 0xd1, 0x61, 0xb7, 0xa3, 0x27, 0x36, 0x9d, 0x5d, 0x89, 0x13,
+	0x61, 0xca, 0xa6, 0x24, 0x54, 0x43, 0x16, 0xdc, 0xea, 0xc7, 0x90, 0xe1, 0xb1, 0x81, 0xf9, 0x79,
+	0x5e, 0xcd, 0x95, 0xb0, 0x84, 0xb5, 0xd1, 0xfb, 0x00, 0x3a, 0xa5, 0xae, 0xd9, 0x19, 0x8d, 0x15,
+	0x6f, 0xcc, 0x8e, 0x2d, 0x75, 0x9f, 0x6f, 0xe7, 0x9a, 0x0c, 0x32, 0xab, 0x63, 0xd1, 0x50, 0xa0,
+	0x09, 0x29, 0x54, 0xf7, 0xa1, 0x14, 0x95, 0xf5, 0x33, 0x69, 0x65, 0x46, 0x26, 0x9d, 0x0c, 0x67,
+	0xd2, 0x41, 0x1e, 0x9e, 0x12, 0x95, 0x7b, 0xde, 0x51, 0x3f, 0x55, 0x20, 0xdb, 0x3e, 0x95, 0xdb,
+	0x3c, 0xae, 0xb2, 0x16, 0x88, 0x26, 0xc3, 0x25, 0x52, 0x51, 0x85, 0x4e, 0x05, 0xb5, 0xed, 0xb7,
+	0x83, 0x73, 0x97, 0x5e, 0xb4, 0x98, 0xe2, 0x17, 0x23, 0xe5, 0x81, 0x7b, 0x0b, 0x72, 0xc1, 0xae,
+	0x62, 0xf8, 0x4e, 0x37, 0x0c, 0x77, 0x7c, 0x54, 0xfc, 0x2e, 0x1b, 0x8e, 0x63, 0x3f, 0x95, 0xe5,
+	0xc6, 0x14, 0x16, 0x1d, 0xd5, 0x80, 0xf2, 0x44, 0x56, 0x81, 0xde, 0x82, 0x65, 0x67, 0xd4, 0xd1,
+	0x7c, 0xf3, 0x4c, 0x1c, 0x1e, 0x1f, 0x3a, 0x8c, 0x3a, 0x03, 0xb3, 0xfb, 0x80, 0x9c, 0xf9, 0x83,
+	0x71, 0x46, 0x9d, 0x07, 0xc2, 0x8a, 0xe2, 0x5f, 0x92, 0xe1, 0x7f, 0x39, 0x81, 0xac, 0xbf, 0x29,
+	0xd0, 0x77, 0xc3, 0xe7, 0x44, 0x99, 0xf6, 0x90, 0xd1, 0x4c, 0x47, 0xaa, 0x1f, 0x8b, 0x30, 0x18,
+	0xea, 0x99, 0x3d, 0x8b, 0x18, 0xda, 0x18, 0x61, 0xf2, 0x7f, 0xcb, 0xe2, 0xb2, 0xf8, 0xb0, 0xe7,
+	0xc3, 0x4b, 0xf5, 0x1f, 0x0a, 0x64, 0xfd, 0x03, 0x8b, 0x5e, 0x0b, 0xed, 0xbb, 0xd2, 0x8c, 0x9a,
+	0x9f, 0xcf, 0x38, 0xbe, 0x46, 0x88, 0x8e, 0x35, 0x79, 0xf1, 0xb1, 0xc6, 0xdd, 0x07, 0xf9, 0x17,
+	0x73, 0xe9, 0x0b, 0x5f, 0xcc, 0xbd, 0x02, 0x88, 0xda, 0x54, 0x1f, 0x68, 0x27, 0x36, 0x35, 0xad,
+	0x9e, 0x26, 0x8c, 0x2d, 0x12, 0xe5, 0x0a, 0xff, 0xf2, 0x88, 0x7f, 0x38, 0xe4, 0x76, 0xff, 0x44,
+	0x81, 0x6c, 0x90, 0xba, 0x5c, 0xf4, 0x56, 0x60, 0x0d, 0x96, 0x64, 0x74, 0x16, 0xd7, 0x02, 0xb2,
+	0x17, 0x5c, 0x50, 0xa5, 0x43, 0x17, 0x54, 0x35, 0x16, 0xf5, 0xa8, 0xce, 0xf3, 0x37, 0x01, 0xf2,
+	0x83, 0xfe, 0xcd, 0x37, 0x21, 0x1f, 0xba, 0xa0, 0x61, 0x27, 0x6f, 0xbf, 0xf9, 0x5e, 0x25, 0x51,
+	0x5b, 0xfe, 0xf4, 0xf3, 0xeb, 0xa9, 0x7d, 0xf2, 0x94, 0xed, 0x59, 0xdc, 0x6c, 0xb4, 0x9a, 0x8d,
+	0x07, 0x15, 0xa5, 0x96, 0xff, 0xf4, 0xf3, 0xeb, 0xcb, 0x98, 0xf0, 0x7a, 0xe3, 0x4d, 0x0d, 0x0a,
+	0xe1, 0x55, 0x89, 0x46, 0x14, 0x04, 0xa5, 0xbb, 0x0f, 0x0f, 0xf7, 0x76, 0x1b, 0xf5, 0x76, 0x53,
+	0x7b, 0x74, 0xd0, 0x6e, 0x56, 0x14, 0x74, 0x05, 0x2e, 0xed, 0xed, 0xde, 0x6f, 0xb5, 0xb5, 0xc6,
+	0xde, 0x6e, 0x73, 0xbf, 0xad, 0xd5, 0xdb, 0xed, 0x7a, 0xe3, 0x41, 0x25, 0x89, 0x2e, 0xc3, 0xca,
+	0xee, 0xfe, 0xa3, 0xfa, 0xde, 0xee, 0x5d, 0xed, 0x6e, 0x5d, 0x6b, 0x35, 0xeb, 0x2c, 0x4f, 0x4a,
+	0x6d, 0xff, 0x25, 0x0f, 0xe5, 0xfa, 0x4e, 0x63, 0x97, 0xe5, 0x2c, 0x66, 0x57, 0xe7, 0x85, 0x99,
+	0x06, 0xa4, 0x79, 0xe9, 0xe5, 0xdc, 0xa7, 0x2d, 0xb5, 0xf3, 0x0b, 0xd4, 0xe8, 0x1e, 0x64, 0x78,
+	0x55, 0x06, 0x9d, 0xff, 0xd6, 0xa5, 0x36, 0xa7, 0x62, 0xcd, 0x06, 0xc3, 0x4f, 0xcd, 0xb9, 0x8f,
+	0x5f, 0x6a, 0xe7, 0x17, 0xb0, 0x11, 0x86, 0xdc, 0x18, 0xb0, 0xcd, 0x7f, 0x0c, 0x52, 0x5b, 0xc0,
+	0x07, 0xa1, 0x3d, 0x58, 0xf6, 0x91, 0xf8, 0xbc, 0xe7, 0x29, 0xb5, 0xb9, 0x15, 0x66, 0x66, 0x2e,
+	0x51, 0x31, 0x39, 0xff, 0xad, 0x4d, 0x6d, 0x4e, 0xb9, 0x1c, 0xed, 0xc2, 0x92, 0x44, 0x13, 0x73,
+	0x9e, 0x9c, 0xd4, 0xe6, 0x55, 0x8c, 0x99, 0xd1, 0xc6, 0xa5, 0xa8, 0xf9, 0x2f, 0x88, 0x6a, 0x0b,
+	0xdc, 0x04, 0xa0, 0x87, 0x00, 0xa1, 0xfa, 0xc8, 0x02, 0x4f, 0x83, 0x6a, 0x8b, 0x54, 0xf8, 0xd1,
+	0x01, 0x64, 0x03, 0x40, 0x39, 0xf7, 0xa1, 0x4e, 0x6d, 0x7e, 0xa9, 0x1d, 0x3d, 0x86, 0x62, 0x14,
+	0x49, 0x2d, 0xf6, 0xfc, 0xa6, 0xb6, 0x60, 0x0d, 0x9d, 0xe9, 0x8f, 0xc2, 0xaa, 0xc5, 0x9e, 0xe3,
+	0xd4, 0x16, 0x2c, 0xa9, 0xa3, 0x0f, 0x61, 0x65, 0x1a, 0xf6, 0x2c, 0xfe, 0x3a, 0xa7, 0x76, 0x81,
+	0x22, 0x3b, 0x1a, 0x02, 0x9a, 0x01, 0x97, 0x2e, 0xf0, 0x58, 0xa7, 0x76, 0x91, 0x9a, 0x3b, 0x33,
+	0x5d, 0x14, 0x82, 0x2c, 0xf6, 0x78, 0xa7, 0xb6, 0x60, 0xf5, 0x1d, 0x19, 0x50, 0x9e, 0x84, 0x06,
+	0x8b, 0x3e, 0xe6, 0xa9, 0x2d, 0x5c, 0x8e, 0x67, 0x46, 0x9b, 0x91, 0xed, 0x5f, 0xe0, 0x6d, 0x4f,
+	0xed, 0x22, 0xf5, 0xf9, 0x9d, 0x77, 0xbe, 0xf8, 0x7a, 0x5d, 0xf9, 0xf2, 0xeb, 0x75, 0xe5, 0xcf,
+	0x5f, 0xaf, 0x2b, 0x9f, 0x7d, 0xb3, 0x9e, 0xf8, 0xf2, 0x9b, 0xf5, 0xc4, 0x1f, 0xbf, 0x59, 0x4f,
+	0xfc, 0xe8, 0xd5, 0x9e, 0x49, 0xfb, 0xa3, 0xce, 0x66, 0xd7, 0x1e, 0x6e, 0x0d, 0xf4, 0x8f, 0xcf,
+	0x06, 0xc4, 0xe8, 0x11, 0x37, 0xd4, 0xbc, 0xd5, 0xb5, 0x5d, 0x12, 0x7a, 0xd3, 0xd9, 0x59, 0xe2,
+	0x51, 0xfa, 0xf6, 0xbf, 0x06, 0x00, 0xca, 0x20, 0x83, 0x10, 0xf3, 0x29, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3394,6 +3785,8 @@ type ABCIApplicationClient interface {
 	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
 	PreprocessTxs(ctx context.Context, in *RequestPreprocessTxs, opts ...grpc.CallOption) (*ResponsePreprocessTxs, error)
+	ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error)
+	GenerateFraudProof(ctx context.Context, in *RequestGenerateFraudProof, opts ...grpc.CallOption) (*ResponseGenerateFraudProof, error)
 }
 
 type aBCIApplicationClient struct {
@@ -3539,6 +3932,24 @@ func (c *aBCIApplicationClient) PreprocessTxs(ctx context.Context, in *RequestPr
 	return out, nil
 }
 
+func (c *aBCIApplicationClient) ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error) {
+	out := new(ResponseProcessProposal)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/ProcessProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) GenerateFraudProof(ctx context.Context, in *RequestGenerateFraudProof, opts ...grpc.CallOption) (*ResponseGenerateFraudProof, error) {
+	out := new(ResponseGenerateFraudProof)
+	err := c.cc.Invoke(ctx, "/tendermint.abci.ABCIApplication/GenerateFraudProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ABCIApplicationServer is the server API for ABCIApplication service.
 type ABCIApplicationServer interface {
 	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
@@ -3556,6 +3967,8 @@ type ABCIApplicationServer interface {
 	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
 	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
 	PreprocessTxs(context.Context, *RequestPreprocessTxs) (*ResponsePreprocessTxs, error)
+	ProcessProposal(context.Context, *RequestProcessProposal) (*ResponseProcessProposal, error)
+	GenerateFraudProof(context.Context, *RequestGenerateFraudProof) (*ResponseGenerateFraudProof, error)
 }
 
 // UnimplementedABCIApplicationServer can be embedded to have forward compatible implementations.
@@ -3607,6 +4020,12 @@ func (*UnimplementedABCIApplicationServer) ApplySnapshotChunk(ctx context.Contex
 func (*UnimplementedABCIApplicationServer) PreprocessTxs(ctx context.Context, req *RequestPreprocessTxs) (*ResponsePreprocessTxs, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PreprocessTxs not implemented")
 }
+func (*UnimplementedABCIApplicationServer) ProcessProposal(ctx context.Context, req *RequestProcessProposal) (*ResponseProcessProposal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessProposal not implemented")
+}
+func (*UnimplementedABCIApplicationServer) GenerateFraudProof(ctx context.Context, req *RequestGenerateFraudProof) (*ResponseGenerateFraudProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateFraudProof not implemented")
+}
 
 func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
 	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
@@ -3882,6 +4301,42 @@ func _ABCIApplication_PreprocessTxs_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ABCIApplication_ProcessProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestProcessProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/ProcessProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, req.(*RequestProcessProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_GenerateFraudProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGenerateFraudProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).GenerateFraudProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.abci.ABCIApplication/GenerateFraudProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).GenerateFraudProof(ctx, req.(*RequestGenerateFraudProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.abci.ABCIApplication",
 	HandlerType: (*ABCIApplicationServer)(nil),
@@ -3946,6 +4401,14 @@ var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PreprocessTxs",
 			Handler:    _ABCIApplication_PreprocessTxs_Handler,
 		},
+		{
+			MethodName: "ProcessProposal",
+			Handler:    _ABCIApplication_ProcessProposal_Handler,
+		},
+		{
+			MethodName: "GenerateFraudProof",
+			Handler:    _ABCIApplication_GenerateFraudProof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/abci/types.proto",
@@ -4298,6 +4761,52 @@ func (m *Request_PreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Request_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ProcessProposal != nil {
+		{
+			size, err := m.ProcessProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Request_GenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request_GenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.GenerateFraudProof != nil {
+		{
+			size, err := m.GenerateFraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4463,12 +4972,12 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err17 != nil {
-		return 0, err17
+	n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err19 != nil {
+		return 0, err19
 	}
-	i -= n17
-	i = encodeVarintTypes(dAtA, i, uint64(n17))
+	i -= n19
+	i = encodeVarintTypes(dAtA, i, uint64(n19))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -4871,6 +5380,20 @@ func (m *RequestPreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Messages) > 0 {
+		for iNdEx := len(m.Messages) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Messages[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
 	if len(m.Txs) > 0 {
 		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Txs[iNdEx])
@@ -4883,6 +5406,103 @@ func (m *RequestPreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RequestProcessProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DataAvailabilityHeader != nil {
+		{
+			size, err := m.DataAvailabilityHeader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestGenerateFraudProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestGenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestGenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.FirstTxIndex != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.FirstTxIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5253,6 +5873,52 @@ func (m *Response_PreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Response_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_ProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ProcessProposal != nil {
+		{
+			size, err := m.ProcessProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Response_GenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response_GenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.GenerateFraudProof != nil {
+		{
+			size, err := m.GenerateFraudProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
 func (m *ResponseException) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5588,6 +6254,35 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Nonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Message != nil {
+		{
+			size, err := m.Message.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -5668,6 +6363,13 @@ func (m *ResponseDeliverTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.IntermediateStateRoot) > 0 {
+		i -= len(m.IntermediateStateRoot)
+		copy(dAtA[i:], m.IntermediateStateRoot)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.IntermediateStateRoot)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -5951,20 +6653,20 @@ func (m *ResponseApplySnapshotChunk) MarshalToSizedBuffer(dAtA []byte) (int, err
 		}
 	}
 	if len(m.RefetchChunks) > 0 {
-		dAtA41 := make([]byte, len(m.RefetchChunks)*10)
-		var j40 int
+		dAtA49 := make([]byte, len(m.RefetchChunks)*10)
+		var j48 int
 		for _, num := range m.RefetchChunks {
 			for num >= 1<<7 {
-				dAtA41[j40] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA49[j48] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j40++
+				j48++
 			}
-			dAtA41[j40] = uint8(num)
-			j40++
+			dAtA49[j48] = uint8(num)
+			j48++
 		}
-		i -= j40
-		copy(dAtA[i:], dAtA41[:j40])
-		i = encodeVarintTypes(dAtA, i, uint64(j40))
+		i -= j48
+		copy(dAtA[i:], dAtA49[:j48])
+		i = encodeVarintTypes(dAtA, i, uint64(j48))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -5996,6 +6698,18 @@ func (m *ResponsePreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IntermediateStateRoots != nil {
+		{
+			size, err := m.IntermediateStateRoots.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Messages != nil {
 		{
 			size, err := m.Messages.MarshalToSizedBuffer(dAtA[:i])
@@ -6020,7 +6734,7 @@ func (m *ResponsePreprocessTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
+func (m *ResponseProcessProposal) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -6030,20 +6744,78 @@ func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseProcessProposal) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ResponseProcessProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Version != nil {
-		{
-			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
+	if m.Result != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseGenerateFraudProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseGenerateFraudProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseGenerateFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StateWitness) > 0 {
+		i -= len(m.StateWitness)
+		copy(dAtA[i:], m.StateWitness)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.StateWitness)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != nil {
+		{
+			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
 				return 0, err
 			}
 			i -= size
@@ -6448,12 +7220,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n50, err50 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err50 != nil {
-		return 0, err50
+	n59, err59 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err59 != nil {
+		return 0, err59
 	}
-	i -= n50
-	i = encodeVarintTypes(dAtA, i, uint64(n50))
+	i -= n59
+	i = encodeVarintTypes(dAtA, i, uint64(n59))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -6734,6 +7506,30 @@ func (m *Request_PreprocessTxs) Size() (n int) {
 	}
 	return n
 }
+func (m *Request_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_GenerateFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GenerateFraudProof != nil {
+		l = m.GenerateFraudProof.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *RequestEcho) Size() (n int) {
 	if m == nil {
 		return 0
@@ -6983,6 +7779,50 @@ func (m *RequestPreprocessTxs) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequestProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.Data.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.DataAvailabilityHeader != nil {
+		l = m.DataAvailabilityHeader.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *RequestGenerateFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.FirstTxIndex != 0 {
+		n += 1 + sovTypes(uint64(m.FirstTxIndex))
+	}
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -7190,6 +8030,30 @@ func (m *Response_PreprocessTxs) Size() (n int) {
 	}
 	return n
 }
+func (m *Response_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_GenerateFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GenerateFraudProof != nil {
+		l = m.GenerateFraudProof.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *ResponseException) Size() (n int) {
 	if m == nil {
 		return 0
@@ -7369,6 +8233,20 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Message != nil {
+		l = m.Message.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Nonce != 0 {
+		n += 1 + sovTypes(uint64(m.Nonce))
+	}
 	return n
 }
 
@@ -7409,6 +8287,10 @@ func (m *ResponseDeliverTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.IntermediateStateRoot)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -7534,6 +8416,35 @@ func (m *ResponsePreprocessTxs) Size() (n int) {
 		l = m.Messages.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.IntermediateStateRoots != nil {
+		l = m.IntermediateStateRoots.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovTypes(uint64(m.Result))
+	}
+	return n
+}
+
+func (m *ResponseGenerateFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.StateWitness)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -8307,6 +9218,76 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Request_PreprocessTxs{v}
 			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_ProcessProposal{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenerateFraudProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestGenerateFraudProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_GenerateFraudProof{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10013,6 +10994,318 @@ func (m *RequestPreprocessTxs) Unmarshal(dAtA []byte) error {
 			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
 			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, types1.Message{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestProcessProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestProcessProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataAvailabilityHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DataAvailabilityHeader == nil {
+				m.DataAvailabilityHeader = &types1.DataAvailabilityHeader{}
+			}
+			if err := m.DataAvailabilityHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestGenerateFraudProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestGenerateFraudProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestGenerateFraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstTxIndex", wireType)
+			}
+			m.FirstTxIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstTxIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10626,6 +11919,76 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			}
 			m.Value = &Response_PreprocessTxs{v}
 			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ProcessProposal{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenerateFraudProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseGenerateFraudProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_GenerateFraudProof{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11802,7 +13165,73 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11829,14 +13258,35 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Message == nil {
+				m.Message = &types1.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11864,8 +13314,27 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			m.Sender = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12140,6 +13609,40 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntermediateStateRoot", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IntermediateStateRoot = append(m.IntermediateStateRoot[:0], dAtA[iNdEx:postIndex]...)
+			if m.IntermediateStateRoot == nil {
+				m.IntermediateStateRoot = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12950,6 +14453,201 @@ func (m *ResponsePreprocessTxs) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntermediateStateRoots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.IntermediateStateRoots == nil {
+				m.IntermediateStateRoots = &types1.IntermediateStateRoots{}
+			}
+			if err := m.IntermediateStateRoots.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseProcessProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			m.Result = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Result |= ResponseProcessProposal_Result(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseGenerateFraudProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseGenerateFraudProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseGenerateFraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateWitness", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StateWitness = append(m.StateWitness[:0], dAtA[iNdEx:postIndex]...)
+			if m.StateWitness == nil {
+				m.StateWitness = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
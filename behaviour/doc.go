@@ -4,7 +4,7 @@ Package Behaviour provides a mechanism for reactors to report behaviour of peers
 Instead of a reactor calling the switch directly it will call the behaviour module which will
 handle the stoping and marking peer as good on behalf of the reactor.
 
-There are four different behaviours a reactor can report.
+There are six different behaviours a reactor can report.
 
 1. bad message
 
@@ -38,5 +38,24 @@ type blockPart struct {
 
 This message will request the peer be marked as good
 
+5. valid block
+
+type validBlock struct {
+	explanation string
+}
+
+This message will request the peer be marked as good
+
+6. slow response
+
+type slowResponse struct {
+	explanation string
+}
+
+This message will request the peer be stopped for an error
+
+Besides the Switch, peer behaviour can be reported to a trust metric store with a
+TrustMetricReporter, which scores the peers by their behaviour over time.
+
 */
 package behaviour
@@ -47,3 +47,21 @@ type blockPart struct {
 func BlockPart(peerID p2p.ID, explanation string) PeerBehaviour {
 	return PeerBehaviour{peerID: peerID, reason: blockPart{explanation}}
 }
+
+type validBlock struct {
+	explanation string
+}
+
+// ValidBlock returns a validBlock PeerBehaviour.
+func ValidBlock(peerID p2p.ID, explanation string) PeerBehaviour {
+	return PeerBehaviour{peerID: peerID, reason: validBlock{explanation}}
+}
+
+type slowResponse struct {
+	explanation string
+}
+
+// SlowResponse returns a slowResponse PeerBehaviour.
+func SlowResponse(peerID p2p.ID, explanation string) PeerBehaviour {
+	return PeerBehaviour{peerID: peerID, reason: slowResponse{explanation}}
+}
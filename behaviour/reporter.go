@@ -5,6 +5,7 @@ import (
 
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/trust"
 )
 
 // Reporter provides an interface for reactors to report the behaviour
@@ -33,12 +34,60 @@ func (spbr *SwitchReporter) Report(behaviour PeerBehaviour) error {
 	}
 
 	switch reason := behaviour.reason.(type) {
-	case consensusVote, blockPart:
+	case consensusVote, blockPart, validBlock:
 		spbr.sw.MarkPeerAsGood(peer)
 	case badMessage:
 		spbr.sw.StopPeerForError(peer, reason.explanation)
 	case messageOutOfOrder:
 		spbr.sw.StopPeerForError(peer, reason.explanation)
+	case slowResponse:
+		spbr.sw.StopPeerForError(peer, reason.explanation)
+	default:
+		return errors.New("unknown reason reported")
+	}
+
+	return nil
+}
+
+// TrustMetricReporter reports peer behaviour to the trust metrics of a
+// MetricStore, keyed by the peer ID. Good behaviour is recorded as a good
+// event, and bad behaviour as one or more bad events depending on how
+// severe it is.
+type TrustMetricReporter struct {
+	store *trust.MetricStore
+}
+
+// The number of bad events recorded for each kind of bad behaviour.
+const (
+	badMessageEvents        = 10
+	messageOutOfOrderEvents = 2
+	slowResponseEvents      = 1
+)
+
+// NewTrustMetricReporter returns a new TrustMetricReporter instance which
+// records behaviour in the given MetricStore.
+func NewTrustMetricReporter(store *trust.MetricStore) *TrustMetricReporter {
+	return &TrustMetricReporter{
+		store: store,
+	}
+}
+
+// Report records the behaviour of a peer in its trust metric.
+func (tmr *TrustMetricReporter) Report(behaviour PeerBehaviour) error {
+	if behaviour.peerID == "" {
+		return errors.New("empty peer ID")
+	}
+	metric := tmr.store.GetPeerTrustMetric(string(behaviour.peerID))
+
+	switch behaviour.reason.(type) {
+	case consensusVote, blockPart, validBlock:
+		metric.GoodEvents(1)
+	case badMessage:
+		metric.BadEvents(badMessageEvents)
+	case messageOutOfOrder:
+		metric.BadEvents(messageOutOfOrderEvents)
+	case slowResponse:
+		metric.BadEvents(slowResponseEvents)
 	default:
 		return errors.New("unknown reason reported")
 	}
@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	bh "github.com/lazyledger/lazyledger-core/behaviour"
+	"github.com/lazyledger/lazyledger-core/libs/db/memdb"
+	"github.com/lazyledger/lazyledger-core/libs/log"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/trust"
 )
 
 // TestMockReporter tests the MockReporter's ability to store reported
@@ -203,3 +206,49 @@ func TestMockPeerBehaviourReporterConcurrency(t *testing.T) {
 		}
 	}
 }
+
+// TestTrustMetricReporter tests that the TrustMetricReporter scores peers by
+// the severity of the behaviour reported on them.
+func TestTrustMetricReporter(t *testing.T) {
+	store := trust.NewTrustMetricStore(memdb.NewDB(), trust.DefaultConfig())
+	store.SetLogger(log.TestingLogger())
+	if err := store.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := store.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	pr := bh.NewTrustMetricReporter(store)
+
+	peers := []p2p.ID{"good", "slow", "bad"}
+	for _, peerID := range peers {
+		for i := 0; i < 20; i++ {
+			if err := pr.Report(bh.ValidBlock(peerID, "valid block")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := pr.Report(bh.SlowResponse("slow", "timed out")); err != nil {
+		t.Fatal(err)
+	}
+	if err := pr.Report(bh.BadMessage("bad", "invalid block")); err != nil {
+		t.Fatal(err)
+	}
+
+	scores := make([]int, len(peers))
+	for i, peerID := range peers {
+		scores[i] = store.GetPeerTrustMetric(string(peerID)).TrustScore()
+	}
+	if scores[0] != 100 {
+		t.Errorf("expected well behaved peer to have a perfect score, got %d", scores[0])
+	}
+	if !(scores[0] > scores[1] && scores[1] > scores[2]) {
+		t.Errorf("expected scores to decrease with the severity of the behaviour, got %v", scores)
+	}
+
+	if err := pr.Report(bh.ValidBlock("", "valid block")); err == nil {
+		t.Error("expected an error reporting behaviour without a peer ID")
+	}
+}
@@ -0,0 +1,101 @@
+package v0
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
+	"github.com/lazyledger/lazyledger-core/light"
+	sm "github.com/lazyledger/lazyledger-core/state"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// headerVerifier verifies the consecutive batches of light blocks downloaded in header-first
+// mode, starting from the trusted state the node syncs from.
+//
+// The light blocks of a batch must be linked by their last block IDs, so verifying the commit of
+// the last light block verifies the whole batch. Like the light client's skipping verification,
+// this is done with a single commit check if at least 1/3 of the validators of the first light
+// block signed the last one. Otherwise, the batch is verified sequentially. Unlike the light
+// client, the trusted state is not subject to a trusting period, which matches the trust fast sync
+// puts in the validators of the state it starts from.
+type headerVerifier struct {
+	chainID    string
+	trustLevel tmmath.Fraction
+
+	height        int64  // height of the next light block
+	lastBlockHash []byte // hash of the block before height
+	valsHash      []byte // hash of the validator set at height
+}
+
+func newHeaderVerifier(state sm.State) *headerVerifier {
+	height := state.LastBlockHeight + 1
+	if state.LastBlockHeight == 0 {
+		height = state.InitialHeight
+	}
+	return &headerVerifier{
+		chainID:       state.ChainID,
+		trustLevel:    light.DefaultTrustLevel,
+		height:        height,
+		lastBlockHash: state.LastBlockID.Hash,
+		valsHash:      state.Validators.Hash(),
+	}
+}
+
+// verify verifies the next batch of light blocks. If they are valid, the verifier advances to the
+// height after the last one.
+func (v *headerVerifier) verify(lightBlocks []*types.LightBlock) error {
+	if len(lightBlocks) == 0 {
+		return errors.New("no light blocks")
+	}
+
+	lastBlockHash, valsHash := v.lastBlockHash, v.valsHash
+	for i, lb := range lightBlocks {
+		if lb == nil {
+			return fmt.Errorf("nil light block at index %v", i)
+		}
+		if height := v.height + int64(i); lb.Height != height {
+			return fmt.Errorf("expected light block at height %v, got %v", height, lb.Height)
+		}
+		if err := lb.ValidateBasic(v.chainID); err != nil {
+			return fmt.Errorf("invalid light block at height %v: %w", lb.Height, err)
+		}
+		if !bytes.Equal(lb.LastBlockID.Hash, lastBlockHash) {
+			return fmt.Errorf("last block ID %X of light block at height %v does not match previous block %X",
+				lb.LastBlockID.Hash, lb.Height, lastBlockHash)
+		}
+		if !bytes.Equal(lb.ValidatorsHash, valsHash) {
+			return fmt.Errorf("validators hash %X of light block at height %v does not match expected %X",
+				lb.ValidatorsHash, lb.Height, valsHash)
+		}
+		lastBlockHash, valsHash = lb.Hash(), lb.NextValidatorsHash
+	}
+
+	first, last := lightBlocks[0], lightBlocks[len(lightBlocks)-1]
+	err := first.ValidatorSet.VerifyCommitLightTrusting(v.chainID, last.Commit, v.trustLevel)
+	switch {
+	case err == nil:
+		if err := last.ValidatorSet.VerifyCommitLight(v.chainID, last.Commit.BlockID, last.Height,
+			last.Commit); err != nil {
+			return fmt.Errorf("invalid commit of light block at height %v: %w", last.Height, err)
+		}
+
+	case types.IsErrNotEnoughVotingPowerSigned(err):
+		// The validator set changed too much within the batch, so each light block is verified
+		// by its own validator set, which the previous light block committed to.
+		for _, lb := range lightBlocks {
+			if err := lb.ValidatorSet.VerifyCommitLight(v.chainID, lb.Commit.BlockID, lb.Height,
+				lb.Commit); err != nil {
+				return fmt.Errorf("invalid commit of light block at height %v: %w", lb.Height, err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("invalid commit of light block at height %v: %w", last.Height, err)
+	}
+
+	v.height = last.Height + 1
+	v.lastBlockHash, v.valsHash = lastBlockHash, valsHash
+	return nil
+}
@@ -0,0 +1,138 @@
+package v0
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
+	tmversion "github.com/lazyledger/lazyledger-core/proto/tendermint/version"
+	sm "github.com/lazyledger/lazyledger-core/state"
+	"github.com/lazyledger/lazyledger-core/types"
+	"github.com/lazyledger/lazyledger-core/version"
+)
+
+const testChainID = "test-chain"
+
+type testValSet struct {
+	vals     *types.ValidatorSet
+	privVals []types.PrivValidator
+}
+
+func newTestValSet(n int) testValSet {
+	vals, privVals := types.RandValidatorSet(n, 10)
+	return testValSet{vals: vals, privVals: privVals}
+}
+
+// makeLightBlocks makes consecutive light blocks from the given height on,
+// following the block with the given ID. The i-th light block is signed by
+// valSets[i], and the validators of the last one stay the same.
+func makeLightBlocks(
+	t *testing.T,
+	height int64,
+	lastBlockID types.BlockID,
+	valSets []testValSet,
+) []*types.LightBlock {
+	lightBlocks := make([]*types.LightBlock, 0, len(valSets))
+	for i, vs := range valSets {
+		next := vs
+		if i+1 < len(valSets) {
+			next = valSets[i+1]
+		}
+		header := &types.Header{
+			Version:            tmversion.Consensus{Block: version.BlockProtocol},
+			ChainID:            testChainID,
+			Height:             height + int64(i),
+			Time:               time.Date(2020, 1, 1, 0, 0, i, 0, time.UTC),
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vs.vals.Hash(),
+			NextValidatorsHash: next.vals.Hash(),
+			ProposerAddress:    vs.vals.Proposer.Address,
+		}
+		blockID := types.BlockID{
+			Hash:          header.Hash(),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))},
+		}
+		voteSet := types.NewVoteSet(testChainID, header.Height, 0, tmproto.PrecommitType, vs.vals)
+		commit, err := types.MakeCommit(blockID, header.Height, 0, voteSet, vs.privVals, header.Time)
+		require.NoError(t, err)
+
+		lightBlocks = append(lightBlocks, &types.LightBlock{
+			SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+			ValidatorSet: vs.vals,
+		})
+		lastBlockID = blockID
+	}
+	return lightBlocks
+}
+
+func repeatValSet(vs testValSet, n int) []testValSet {
+	valSets := make([]testValSet, n)
+	for i := range valSets {
+		valSets[i] = vs
+	}
+	return valSets
+}
+
+func testVerifierState(vals *types.ValidatorSet) sm.State {
+	return sm.State{
+		ChainID:       testChainID,
+		InitialHeight: 1,
+		Validators:    vals,
+	}
+}
+
+func TestHeaderVerifier(t *testing.T) {
+	valsA, valsB := newTestValSet(4), newTestValSet(4)
+	valSets := append(repeatValSet(valsA, 10), repeatValSet(valsB, 10)...)
+	lightBlocks := makeLightBlocks(t, 1, types.BlockID{}, valSets)
+
+	v := newHeaderVerifier(testVerifierState(valsA.vals))
+	require.NoError(t, v.verify(lightBlocks[:5]))
+	assert.EqualValues(t, 6, v.height)
+
+	// the validator set changes entirely within the batch, so it is verified sequentially
+	require.NoError(t, v.verify(lightBlocks[5:15]))
+	assert.EqualValues(t, 16, v.height)
+
+	require.NoError(t, v.verify(lightBlocks[15:]))
+	assert.EqualValues(t, 21, v.height)
+	assert.Equal(t, lightBlocks[19].Hash().Bytes(), v.lastBlockHash)
+	assert.Equal(t, valsB.vals.Hash(), v.valsHash)
+}
+
+func TestHeaderVerifier_Invalid(t *testing.T) {
+	valsA := newTestValSet(4)
+	lightBlocks := makeLightBlocks(t, 1, types.BlockID{}, repeatValSet(valsA, 5))
+	otherBlocks := makeLightBlocks(t, 1, types.BlockID{Hash: tmhash.Sum([]byte("other")),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}, repeatValSet(valsA, 5))
+
+	forged := makeLightBlocks(t, 1, types.BlockID{}, repeatValSet(valsA, 5))
+	forged[4].Commit.Signatures[0].Signature = tmhash.Sum([]byte("forged"))
+
+	testcases := map[string][]*types.LightBlock{
+		"empty":             {},
+		"nil":               {nil},
+		"wrong height":      lightBlocks[1:],
+		"not linked":        {lightBlocks[0], otherBlocks[1]},
+		"wrong last block":  otherBlocks,
+		"wrong validators":  makeLightBlocks(t, 1, types.BlockID{}, repeatValSet(newTestValSet(4), 5)),
+		"forged commit":     forged,
+		"invalid signature": {forged[4]},
+	}
+	for name, lbs := range testcases {
+		lbs := lbs
+		t.Run(name, func(t *testing.T) {
+			v := newHeaderVerifier(testVerifierState(valsA.vals))
+			if name == "invalid signature" {
+				require.NoError(t, v.verify(lightBlocks[:4]))
+			}
+			height := v.height
+			require.Error(t, v.verify(lbs))
+			assert.Equal(t, height, v.height)
+		})
+	}
+}
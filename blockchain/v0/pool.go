@@ -1,17 +1,24 @@
 package v0
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
+	"math/rand"
+	"runtime"
 	"sync/atomic"
 	"time"
 
+	"github.com/lazyledger/lazyledger-core/behaviour"
 	flow "github.com/lazyledger/lazyledger-core/libs/flowrate"
 	"github.com/lazyledger/lazyledger-core/libs/log"
+	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
 	"github.com/lazyledger/lazyledger-core/libs/service"
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/trust"
+	sm "github.com/lazyledger/lazyledger-core/state"
 	"github.com/lazyledger/lazyledger-core/types"
 )
 
@@ -43,6 +50,25 @@ const (
 
 	// Maximum difference between current and new block's height.
 	maxDiffBetweenCurrentAndReceivedBlockHeight = 100
+
+	// Number of light blocks requested at once in header-first mode.
+	lightBlocksBatchSize = 100
+	// Maximum number of verified headers kept ahead of the pool height in
+	// header-first mode.
+	maxHeadersAhead = 10 * maxTotalRequesters
+	// Time to wait before requesting light blocks again from a peer which did
+	// not have them, if it is the only peer to request them from.
+	lightBlocksRetryInterval = 500 * time.Millisecond
+	// Maximum number of received blocks waiting to be verified in header-first
+	// mode. Blocks received while the queue is full are dropped and requested
+	// again.
+	maxBlocksToVerify = 100
+
+	// Trust score below which a peer which sent us invalid data is banned from
+	// the pool when peer scoring is enabled.
+	banTrustScore = 50
+	// Time a banned peer is ignored by the pool for.
+	banDuration = 10 * time.Minute
 )
 
 var peerTimeout = 15 * time.Second // not const so we can override with tests
@@ -65,7 +91,42 @@ type BlockRequest struct {
 	PeerID p2p.ID
 }
 
+// LightBlocksRequest stores a request for the light blocks of Count heights
+// starting at Height, and the PeerID responsible for delivering them.
+type LightBlocksRequest struct {
+	Height int64
+	Count  int64
+	PeerID p2p.ID
+}
+
+// lightBlocksResponse stores the light blocks a peer sent in response to a
+// LightBlocksRequest.
+type lightBlocksResponse struct {
+	height      int64
+	lightBlocks []*types.LightBlock
+	peerID      p2p.ID
+}
+
+// blockToVerify stores a block received in header-first mode along with its
+// verified header.
+type blockToVerify struct {
+	peerID    p2p.ID
+	block     *types.Block
+	blockSize int
+	header    *types.Header
+}
+
 // BlockPool keeps track of the fast sync peers, block requests and block responses.
+//
+// In header-first mode, the pool first downloads the light blocks ahead of its
+// height in batches and verifies them with a headerVerifier. Blocks are only
+// requested once their header has been verified, and each block is verified
+// against its header as soon as it is received, so the block bodies can be
+// downloaded from many peers in parallel without waiting for the commit of the
+// next block.
+//
+// With peer scoring enabled, the pool also keeps track of how well each peer
+// responds in its trust metric, and prefers the peers it trusts the most.
 type BlockPool struct {
 	service.BaseService
 	lastAdvance time.Time
@@ -83,13 +144,32 @@ type BlockPool struct {
 
 	requestsCh chan<- BlockRequest
 	errorsCh   chan<- peerError
+
+	// header-first mode, see EnableHeaderFirst
+	headerFirst      bool
+	verifier         *headerVerifier // only used by headersRoutine
+	headers          map[int64]*types.Header
+	headersHeight    int64 // height of the last verified header
+	headersRequest   *LightBlocksRequest
+	headersRequested time.Time
+	headersRetry     time.Time // headersExcluded is not picked again before
+	headersExcluded  p2p.ID    // peer which sent no light blocks in response to the last request
+	lightBlocksCh    chan lightBlocksResponse
+	headersCh        chan<- LightBlocksRequest
+	verifyCh         chan blockToVerify
+
+	// peer scoring, see EnablePeerScoring
+	trustMetrics *trust.MetricStore
+	reporter     behaviour.Reporter
+	banned       map[p2p.ID]time.Time // banned peers and the end of their ban
 }
 
 // NewBlockPool returns a new BlockPool with the height equal to start. Block
 // requests and errors will be sent to requestsCh and errorsCh accordingly.
 func NewBlockPool(start int64, requestsCh chan<- BlockRequest, errorsCh chan<- peerError) *BlockPool {
 	bp := &BlockPool{
-		peers: make(map[p2p.ID]*bpPeer),
+		peers:  make(map[p2p.ID]*bpPeer),
+		banned: make(map[p2p.ID]time.Time),
 
 		requesters: make(map[int64]*bpRequester),
 		height:     start,
@@ -102,11 +182,48 @@ func NewBlockPool(start int64, requestsCh chan<- BlockRequest, errorsCh chan<- p
 	return bp
 }
 
+// EnableHeaderFirst switches the pool to header-first mode, verifying the light
+// blocks from the given state on. Light block requests will be sent to
+// headersCh. It must be called before the pool is started.
+func (pool *BlockPool) EnableHeaderFirst(state sm.State, headersCh chan<- LightBlocksRequest) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	pool.headerFirst = true
+	pool.verifier = newHeaderVerifier(state)
+	pool.headers = make(map[int64]*types.Header)
+	pool.headersHeight = pool.verifier.height - 1
+	pool.lightBlocksCh = make(chan lightBlocksResponse, 1)
+	pool.headersCh = headersCh
+	pool.verifyCh = make(chan blockToVerify, maxBlocksToVerify)
+}
+
+// EnablePeerScoring makes the pool score its peers in the trust metrics of the
+// given store: valid blocks are reported as good behaviour, while invalid,
+// unexpected and slow responses are reported as bad behaviour. Blocks and
+// light blocks are requested from the peers with the highest trust score
+// first, and peers which sent us invalid data and whose trust score dropped
+// below banTrustScore are banned from the pool for banDuration. It must be
+// called before the pool is started.
+func (pool *BlockPool) EnablePeerScoring(store *trust.MetricStore) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	pool.trustMetrics = store
+	pool.reporter = behaviour.NewTrustMetricReporter(store)
+}
+
 // OnStart implements service.Service by spawning requesters routine and recording
 // pool's start time.
 func (pool *BlockPool) OnStart() error {
 	pool.lastAdvance = time.Now()
 	go pool.makeRequestersRoutine()
+	if pool.headerFirst {
+		go pool.headersRoutine()
+		for i := 0; i < runtime.NumCPU(); i++ {
+			go pool.verifyRoutine()
+		}
+	}
 	return nil
 }
 
@@ -148,6 +265,7 @@ func (pool *BlockPool) removeTimedoutPeers() {
 			if curRate != 0 && curRate < minRecvRate {
 				err := errors.New("peer is not sending us data fast enough")
 				pool.sendError(err, peer.id)
+				pool.report(behaviour.SlowResponse(peer.id, err.Error()))
 				pool.Logger.Error("SendTimeout", "peer", peer.id,
 					"reason", err,
 					"curRate", fmt.Sprintf("%d KB/s", curRate/1024),
@@ -212,7 +330,9 @@ func (pool *BlockPool) PopRequest() {
 		if err := r.Stop(); err != nil {
 			pool.Logger.Error("Error stopping requester", "err", err)
 		}
+		pool.report(behaviour.ValidBlock(r.getPeerID(), "valid block"))
 		delete(pool.requesters, pool.height)
+		delete(pool.headers, pool.height)
 		pool.height++
 		pool.lastAdvance = time.Now()
 	} else {
@@ -238,7 +358,67 @@ func (pool *BlockPool) RedoRequest(height int64) p2p.ID {
 
 // AddBlock validates that the block comes from the peer it was expected from and calls the requester to store it.
 // TODO: ensure that blocks come in order for each peer.
+//
+// In header-first mode, blocks with a verified header are verified against it
+// first. Since verifying the block data is expensive, this is done by one
+// verifyRoutine per CPU. AddBlock does not wait for them: if too many blocks
+// are waiting to be verified, the block is dropped and requested again.
 func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, blockSize int) {
+	pool.mtx.RLock()
+	header := pool.headers[block.Height]
+	pool.mtx.RUnlock()
+	if header == nil {
+		pool.addBlock(peerID, block, blockSize)
+		return
+	}
+
+	select {
+	case pool.verifyCh <- blockToVerify{peerID: peerID, block: block, blockSize: blockSize, header: header}:
+	default:
+		pool.Logger.Info("Too many blocks to verify, requesting block again", "peer", peerID,
+			"blockHeight", block.Height)
+		pool.dropBlock(peerID, block.Height, blockSize)
+	}
+}
+
+// verifyRoutine verifies the blocks received in header-first mode against
+// their headers and adds the valid ones.
+func (pool *BlockPool) verifyRoutine() {
+	for {
+		select {
+		case <-pool.Quit():
+			return
+		case b := <-pool.verifyCh:
+			if err := verifyBlock(b.block, b.header); err != nil {
+				pool.Logger.Error("peer sent us an invalid block", "peer", b.peerID, "blockHeight", b.block.Height,
+					"err", err)
+				pool.sendError(err, b.peerID)
+				pool.reportInvalid(b.peerID, err)
+				pool.RemovePeer(b.peerID)
+				continue
+			}
+			pool.addBlock(b.peerID, b.block, b.blockSize)
+		}
+	}
+}
+
+// dropBlock drops a block the pool has no capacity to verify, and makes its
+// requester request it again. The peer is not held responsible.
+func (pool *BlockPool) dropBlock(peerID p2p.ID, height int64, blockSize int) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	requester := pool.requesters[height]
+	if requester == nil || requester.getPeerID() != peerID {
+		return
+	}
+	if peer := pool.peers[peerID]; peer != nil {
+		peer.decrPending(blockSize)
+	}
+	requester.redo(peerID)
+}
+
+func (pool *BlockPool) addBlock(peerID p2p.ID, block *types.Block, blockSize int) {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
@@ -251,7 +431,9 @@ func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, blockSize int
 			diff *= -1
 		}
 		if diff > maxDiffBetweenCurrentAndReceivedBlockHeight {
-			pool.sendError(errors.New("peer sent us a block we didn't expect with a height too far ahead/behind"), peerID)
+			err := errors.New("peer sent us a block we didn't expect with a height too far ahead/behind")
+			pool.sendError(err, peerID)
+			pool.report(behaviour.MessageOutOfOrder(peerID, err.Error()))
 		}
 		return
 	}
@@ -266,6 +448,7 @@ func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, blockSize int
 		err := errors.New("requester is different or block already exists")
 		pool.Logger.Error(err.Error(), "peer", peerID, "requester", requester.getPeerID(), "blockHeight", block.Height)
 		pool.sendError(err, peerID)
+		pool.report(behaviour.MessageOutOfOrder(peerID, err.Error()))
 	}
 }
 
@@ -284,11 +467,19 @@ func (pool *BlockPool) LastAdvance() time.Time {
 	return pool.lastAdvance
 }
 
-// SetPeerRange sets the peer's alleged blockchain base and height.
+// SetPeerRange sets the peer's alleged blockchain base and height. Banned
+// peers are ignored until their ban ends.
 func (pool *BlockPool) SetPeerRange(peerID p2p.ID, base int64, height int64) {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	if until, ok := pool.banned[peerID]; ok {
+		if time.Now().Before(until) {
+			return
+		}
+		delete(pool.banned, peerID)
+	}
+
 	peer := pool.peers[peerID]
 	if peer != nil {
 		peer.base = base
@@ -304,6 +495,21 @@ func (pool *BlockPool) SetPeerRange(peerID p2p.ID, base int64, height int64) {
 	}
 }
 
+// PeersWithHeight returns the peers which reported to have the block at the
+// given height, or all peers if height is 0.
+func (pool *BlockPool) PeersWithHeight(height int64) []p2p.ID {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	peers := make([]p2p.ID, 0, len(pool.peers))
+	for id, peer := range pool.peers {
+		if height == 0 || (peer.base <= height && height <= peer.height) {
+			peers = append(peers, id)
+		}
+	}
+	return peers
+}
+
 // RemovePeer removes the peer with peerID from the pool. If there's no peer
 // with peerID, function is a no-op.
 func (pool *BlockPool) RemovePeer(peerID p2p.ID) {
@@ -327,6 +533,9 @@ func (pool *BlockPool) removePeer(peerID p2p.ID) {
 		}
 
 		delete(pool.peers, peerID)
+		if pool.headersRequest != nil && pool.headersRequest.PeerID == peerID {
+			pool.headersRequest = nil
+		}
 
 		// Find a new peer with the biggest height and update maxPeerHeight if the
 		// peer's height was the biggest.
@@ -347,12 +556,16 @@ func (pool *BlockPool) updateMaxPeerHeight() {
 	pool.maxPeerHeight = max
 }
 
-// Pick an available peer with the given height available.
-// If no peers are available, returns nil.
+// Pick an available peer with the given height available, preferring the
+// peers with the highest trust score. If no peers are available, returns nil.
 func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	var (
+		picked    *bpPeer
+		bestScore = -1
+	)
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
@@ -364,10 +577,15 @@ func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 		if height < peer.base || height > peer.height {
 			continue
 		}
-		peer.incrPending()
-		return peer
+		if score := pool.trustScore(peer.id); score > bestScore {
+			picked, bestScore = peer, score
+		}
 	}
-	return nil
+	if picked == nil {
+		return nil
+	}
+	picked.incrPending()
+	return picked
 }
 
 func (pool *BlockPool) makeNextRequester() {
@@ -378,6 +596,9 @@ func (pool *BlockPool) makeNextRequester() {
 	if nextHeight > pool.maxPeerHeight {
 		return
 	}
+	if pool.headerFirst && nextHeight > pool.headersHeight {
+		return
+	}
 
 	request := newBPRequester(pool, nextHeight)
 
@@ -408,6 +629,205 @@ func (pool *BlockPool) sendError(err error, peerID p2p.ID) {
 	pool.errorsCh <- peerError{err, peerID}
 }
 
+// report reports the behaviour of a peer to its trust metric, if peer scoring
+// is enabled.
+func (pool *BlockPool) report(b behaviour.PeerBehaviour) {
+	if pool.reporter == nil {
+		return
+	}
+	if err := pool.reporter.Report(b); err != nil {
+		pool.Logger.Error("failed to report peer behaviour", "err", err)
+	}
+}
+
+// reportInvalid reports a peer which sent us invalid data, and bans it if its
+// trust score dropped below banTrustScore.
+func (pool *BlockPool) reportInvalid(peerID p2p.ID, err error) {
+	if pool.reporter == nil || peerID == "" {
+		return
+	}
+	pool.report(behaviour.BadMessage(peerID, err.Error()))
+
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	if score := pool.trustScore(peerID); score < banTrustScore {
+		pool.Logger.Info("Banning peer", "peer", peerID, "score", score, "duration", banDuration)
+		pool.banned[peerID] = time.Now().Add(banDuration)
+		pool.removePeer(peerID)
+	}
+}
+
+// trustScore returns the trust score of a peer, between 0 and 100. All peers
+// have the maximum score if peer scoring is disabled.
+func (pool *BlockPool) trustScore(peerID p2p.ID) int {
+	if pool.trustMetrics == nil {
+		return 100
+	}
+	return pool.trustMetrics.PeerTrustScore(string(peerID))
+}
+
+// verifyBlock checks that a block received in header-first mode matches its
+// verified header, and that its data matches its data availability header. In
+// the default mode, blocks are verified by the commit of the next block instead.
+func verifyBlock(block *types.Block, header *types.Header) error {
+	if err := block.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid block: %w", err)
+	}
+	if !bytes.Equal(block.Hash(), header.Hash()) {
+		return fmt.Errorf("block hash %X does not match verified header %X", block.Hash(), header.Hash())
+	}
+	if err := block.ValidateData(); err != nil {
+		return fmt.Errorf("invalid block data: %w", err)
+	}
+	return nil
+}
+
+// AddLightBlocks passes the light blocks a peer sent in header-first mode on
+// to be verified.
+func (pool *BlockPool) AddLightBlocks(peerID p2p.ID, height int64, lightBlocks []*types.LightBlock) {
+	pool.mtx.RLock()
+	lightBlocksCh := pool.lightBlocksCh
+	pool.mtx.RUnlock()
+	if lightBlocksCh == nil {
+		return
+	}
+	select {
+	case lightBlocksCh <- lightBlocksResponse{height: height, lightBlocks: lightBlocks, peerID: peerID}:
+	case <-pool.Quit():
+	}
+}
+
+// headersRoutine requests the light blocks ahead of the pool height one batch
+// at a time and verifies them.
+func (pool *BlockPool) headersRoutine() {
+	ticker := time.NewTicker(requestIntervalMS * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pool.Quit():
+			return
+		case resp := <-pool.lightBlocksCh:
+			pool.processLightBlocks(resp)
+		case <-ticker.C:
+			pool.requestLightBlocks()
+		}
+	}
+}
+
+// requestLightBlocks requests the next batch of light blocks from a random one
+// of the peers with the highest trust score, unless a request is already pending or enough headers are verified.
+// Pending requests time out after peerTimeout.
+func (pool *BlockPool) requestLightBlocks() {
+	pool.mtx.Lock()
+	if req := pool.headersRequest; req != nil {
+		if time.Since(pool.headersRequested) > peerTimeout {
+			err := errors.New("peer did not send us the requested light blocks")
+			pool.Logger.Error("SendTimeout", "peer", req.PeerID, "reason", err, "timeout", peerTimeout)
+			pool.sendError(err, req.PeerID)
+			pool.report(behaviour.SlowResponse(req.PeerID, err.Error()))
+			pool.removePeer(req.PeerID)
+		}
+		pool.mtx.Unlock()
+		return
+	}
+
+	height := pool.headersHeight + 1
+	if height > pool.maxPeerHeight || height-pool.height >= maxHeadersAhead {
+		pool.mtx.Unlock()
+		return
+	}
+	var (
+		peers     = make([]*bpPeer, 0, len(pool.peers))
+		bestScore = -1
+		excluded  *bpPeer
+	)
+	for _, peer := range pool.peers {
+		if peer.didTimeout || height < peer.base || height > peer.height {
+			continue
+		}
+		if peer.id == pool.headersExcluded {
+			excluded = peer
+			continue
+		}
+		switch score := pool.trustScore(peer.id); {
+		case score > bestScore:
+			peers, bestScore = append(peers[:0], peer), score
+		case score == bestScore:
+			peers = append(peers, peer)
+		}
+	}
+	// the peer which did not have the last light blocks is only asked again
+	// after a while, if it is the only one left
+	if len(peers) == 0 && excluded != nil && !time.Now().Before(pool.headersRetry) {
+		peers = append(peers, excluded)
+	}
+	if len(peers) == 0 {
+		pool.mtx.Unlock()
+		return
+	}
+	pool.headersExcluded = ""
+	peer := peers[rand.Intn(len(peers))] // nolint:gosec // G404: Use of weak random number generator
+	req := LightBlocksRequest{
+		Height: height,
+		Count:  tmmath.MinInt64(lightBlocksBatchSize, peer.height-height+1),
+		PeerID: peer.id,
+	}
+	pool.headersRequest = &req
+	pool.headersRequested = time.Now()
+	pool.mtx.Unlock()
+
+	select {
+	case pool.headersCh <- req:
+	case <-pool.Quit():
+	}
+}
+
+// processLightBlocks verifies the light blocks received in response to the
+// pending request, and makes their headers available to the requesters.
+func (pool *BlockPool) processLightBlocks(resp lightBlocksResponse) {
+	pool.mtx.Lock()
+	req := pool.headersRequest
+	if req == nil || req.PeerID != resp.peerID || req.Height != resp.height {
+		pool.mtx.Unlock()
+		pool.Logger.Debug("peer sent us light blocks we didn't expect", "peer", resp.peerID, "height", resp.height)
+		return
+	}
+	pool.headersRequest = nil
+	if len(resp.lightBlocks) == 0 {
+		// the peer claimed to have them, another peer is picked next
+		pool.headersExcluded = resp.peerID
+		pool.headersRetry = time.Now().Add(lightBlocksRetryInterval)
+	}
+	pool.mtx.Unlock()
+
+	if len(resp.lightBlocks) == 0 {
+		err := errors.New("peer did not send us the requested light blocks")
+		pool.Logger.Debug("peer does not have the requested light blocks", "peer", resp.peerID, "height", resp.height)
+		pool.report(behaviour.SlowResponse(resp.peerID, err.Error()))
+		return
+	}
+	if int64(len(resp.lightBlocks)) > req.Count {
+		resp.lightBlocks = resp.lightBlocks[:req.Count]
+	}
+
+	if err := pool.verifier.verify(resp.lightBlocks); err != nil {
+		pool.Logger.Error("peer sent us invalid light blocks", "peer", resp.peerID, "height", resp.height, "err", err)
+		pool.sendError(err, resp.peerID)
+		pool.reportInvalid(resp.peerID, err)
+		pool.RemovePeer(resp.peerID)
+		return
+	}
+	pool.report(behaviour.ValidBlock(resp.peerID, "valid light blocks"))
+
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	for _, lb := range resp.lightBlocks {
+		pool.headers[lb.Height] = lb.Header
+	}
+	pool.headersHeight = pool.verifier.height - 1
+}
+
 // for debugging purposes
 //nolint:unused
 func (pool *BlockPool) debug() string {
@@ -497,6 +917,7 @@ func (peer *bpPeer) onTimeout() {
 
 	err := errors.New("peer did not send us anything")
 	peer.pool.sendError(err, peer.id)
+	peer.pool.report(behaviour.SlowResponse(peer.id, err.Error()))
 	peer.logger.Error("SendTimeout", "reason", err, "timeout", peerTimeout)
 	peer.didTimeout = true
 }
@@ -1,6 +1,7 @@
 package v0
 
 import (
+	"errors"
 	"fmt"
 	mrand "math/rand"
 	"testing"
@@ -9,10 +10,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lazyledger/lazyledger-core/behaviour"
+	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
+	"github.com/lazyledger/lazyledger-core/libs/db/memdb"
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/trust"
 	"github.com/lazyledger/lazyledger-core/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 )
 
 func init() {
@@ -241,3 +247,245 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 
 	assert.EqualValues(t, 0, pool.MaxPeerHeight())
 }
+
+func receiveLightBlocksRequest(t *testing.T, headersCh <-chan LightBlocksRequest) LightBlocksRequest {
+	select {
+	case req := <-headersCh:
+		return req
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for light blocks request")
+	}
+	return LightBlocksRequest{}
+}
+
+func TestBlockPoolHeaderFirst(t *testing.T) {
+	valSet := newTestValSet(4)
+	lightBlocks := makeLightBlocks(t, 1, types.BlockID{}, repeatValSet(valSet, 10))
+
+	requestsCh := make(chan BlockRequest, 1000)
+	headersCh := make(chan LightBlocksRequest, 10)
+	errorsCh := make(chan peerError, 1000)
+	pool := NewBlockPool(1, requestsCh, errorsCh)
+	pool.SetLogger(log.TestingLogger())
+	pool.EnableHeaderFirst(testVerifierState(valSet.vals), headersCh)
+	require.NoError(t, pool.Start())
+	t.Cleanup(func() {
+		if err := pool.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// a peer sending invalid light blocks is reported and removed
+	pool.SetPeerRange("bad", 1, 10)
+	req := receiveLightBlocksRequest(t, headersCh)
+	assert.Equal(t, LightBlocksRequest{Height: 1, Count: 10, PeerID: "bad"}, req)
+	pool.AddLightBlocks("bad", 1, lightBlocks[1:])
+	select {
+	case pErr := <-errorsCh:
+		assert.EqualValues(t, "bad", pErr.peerID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for peer error")
+	}
+	assert.Eventually(t, func() bool { return pool.MaxPeerHeight() == 0 }, time.Second, 10*time.Millisecond)
+	assert.Empty(t, requestsCh)
+
+	// blocks are only requested once their headers are verified
+	pool.SetPeerRange("good", 1, 10)
+	req = receiveLightBlocksRequest(t, headersCh)
+	assert.Equal(t, LightBlocksRequest{Height: 1, Count: 10, PeerID: "good"}, req)
+	pool.AddLightBlocks("good", 1, lightBlocks[:5])
+
+	req = receiveLightBlocksRequest(t, headersCh)
+	assert.Equal(t, LightBlocksRequest{Height: 6, Count: 5, PeerID: "good"}, req)
+	heights := make(map[int64]bool)
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case request := <-requestsCh:
+				heights[request.Height] = true
+			default:
+				return len(heights) == 5
+			}
+		}
+	}, time.Second, 10*time.Millisecond)
+	for height := range heights {
+		assert.LessOrEqual(t, height, int64(5))
+	}
+	assert.Empty(t, errorsCh)
+}
+
+func TestBlockPoolHeaderFirstEmptyLightBlocks(t *testing.T) {
+	valSet := newTestValSet(4)
+	startPool := func(store *trust.MetricStore) (*BlockPool, chan LightBlocksRequest) {
+		headersCh := make(chan LightBlocksRequest, 10)
+		pool := NewBlockPool(1, make(chan BlockRequest, 1000), make(chan peerError, 1000))
+		pool.SetLogger(log.TestingLogger())
+		pool.EnableHeaderFirst(testVerifierState(valSet.vals), headersCh)
+		if store != nil {
+			pool.EnablePeerScoring(store)
+		}
+		require.NoError(t, pool.Start())
+		t.Cleanup(func() {
+			if err := pool.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		return pool, headersCh
+	}
+
+	// the peer which sent no light blocks is not picked next
+	pool, headersCh := startPool(nil)
+	pool.SetPeerRange("a", 1, 10)
+	pool.SetPeerRange("b", 1, 10)
+	req := receiveLightBlocksRequest(t, headersCh)
+	pool.AddLightBlocks(req.PeerID, req.Height, nil)
+	next := receiveLightBlocksRequest(t, headersCh)
+	assert.NotEqual(t, req.PeerID, next.PeerID)
+	pool.AddLightBlocks(next.PeerID, next.Height, nil)
+	assert.Equal(t, req.PeerID, receiveLightBlocksRequest(t, headersCh).PeerID)
+
+	// it is reported as slow, and only asked again after a while if it is the
+	// only peer
+	store := trust.NewTrustMetricStore(memdb.NewDB(), trust.DefaultConfig())
+	store.SetLogger(log.TestingLogger())
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		if err := store.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	pool, headersCh = startPool(store)
+	pool.SetPeerRange("a", 1, 10)
+	pool.report(behaviour.ValidBlock("a", "valid block"))
+	score := pool.trustScore("a")
+	req = receiveLightBlocksRequest(t, headersCh)
+	sent := time.Now()
+	pool.AddLightBlocks(req.PeerID, req.Height, nil)
+	req = receiveLightBlocksRequest(t, headersCh)
+	assert.EqualValues(t, "a", req.PeerID)
+	assert.GreaterOrEqual(t, time.Since(sent), lightBlocksRetryInterval)
+	assert.Less(t, pool.trustScore("a"), score)
+}
+
+func TestBlockPoolHeaderFirstFullVerificationQueue(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest, 1), make(chan peerError, 1))
+	pool.SetLogger(log.TestingLogger())
+	pool.EnableHeaderFirst(testVerifierState(newTestValSet(4).vals), make(chan LightBlocksRequest, 1))
+
+	block := types.MakeBlock(1, []types.Tx{types.Tx("foo")}, nil, types.IntermediateStateRoots{},
+		types.Messages{}, types.NewCommit(0, 0, types.BlockID{}, nil))
+	pool.headers[1] = &block.Header
+	pool.SetPeerRange("peer", 1, 10)
+	requester := newBPRequester(pool, 1)
+	requester.peerID = "peer"
+	pool.requesters[1] = requester
+	pool.peers["peer"].incrPending()
+
+	// the pool is not started, so no blocks are verified
+	for i := 0; i < maxBlocksToVerify; i++ {
+		pool.AddBlock("other", block, 100)
+	}
+
+	// the block is dropped without waiting for the queue and requested again
+	done := make(chan struct{})
+	go func() {
+		pool.AddBlock("peer", block, 100)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("AddBlock blocked on the full verification queue")
+	}
+	assert.Nil(t, requester.getBlock())
+	assert.Zero(t, pool.peers["peer"].numPending)
+	select {
+	case peerID := <-requester.redoCh:
+		assert.EqualValues(t, "peer", peerID)
+	default:
+		t.Fatal("block was not requested again")
+	}
+}
+
+func TestVerifyBlock(t *testing.T) {
+	makeBlock := func(txs []types.Tx) *types.Block {
+		block := types.MakeBlock(1, txs, nil, types.IntermediateStateRoots{}, types.Messages{}, types.NewCommit(0, 0, types.BlockID{}, nil))
+		block.ProposerAddress = tmrand.Bytes(20)
+		block.ValidatorsHash = tmhash.Sum([]byte("validators"))
+		return block
+	}
+	block := makeBlock([]types.Tx{types.Tx("foo")})
+	header := block.Header
+	require.NoError(t, verifyBlock(block, &header))
+
+	// the block must match the header
+	other := makeBlock([]types.Tx{types.Tx("foo")})
+	assert.Error(t, verifyBlock(other, &header))
+
+	// and its data must match the data availability header
+	tampered := makeBlock([]types.Tx{types.Tx("foo")})
+	tampered.Header = header
+	tampered.DataAvailabilityHeader = block.DataAvailabilityHeader
+	tampered.Data = makeBlock([]types.Tx{types.Tx("bar")}).Data
+	assert.Error(t, verifyBlock(tampered, &header))
+
+	// which doesn't fit into the largest data square
+	oversized := make([]types.Tx, consts.MaxSquareSize*consts.MaxSquareSize+1)
+	for i := range oversized {
+		oversized[i] = tmrand.Bytes(consts.TxShareSize - 2)
+	}
+	tampered.Data.Txs = oversized
+	assert.Error(t, verifyBlock(tampered, &header))
+}
+
+func TestBlockPoolPeerScoring(t *testing.T) {
+	store := trust.NewTrustMetricStore(memdb.NewDB(), trust.DefaultConfig())
+	store.SetLogger(log.TestingLogger())
+	require.NoError(t, store.Start())
+	t.Cleanup(func() {
+		if err := store.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	pool.SetLogger(log.TestingLogger())
+	pool.EnablePeerScoring(store)
+	pool.SetPeerRange("trusted", 1, 10)
+	pool.SetPeerRange("slow", 1, 10)
+	for i := 0; i < 100; i++ {
+		pool.report(behaviour.ValidBlock("trusted", "valid block"))
+	}
+	for i := 0; i < 10; i++ {
+		pool.report(behaviour.ValidBlock("slow", "valid block"))
+	}
+	pool.report(behaviour.SlowResponse("slow", "timed out"))
+	require.Greater(t, pool.trustScore("trusted"), pool.trustScore("slow"))
+
+	// the trusted peer is preferred until it has too many pending requests
+	for i := 0; i < maxPendingRequestsPerPeer; i++ {
+		peer := pool.pickIncrAvailablePeer(1)
+		require.NotNil(t, peer)
+		assert.EqualValues(t, "trusted", peer.id)
+	}
+	peer := pool.pickIncrAvailablePeer(1)
+	require.NotNil(t, peer)
+	assert.EqualValues(t, "slow", peer.id)
+
+	// a trusted peer sending an invalid block is not banned right away
+	pool.reportInvalid("trusted", errors.New("invalid block"))
+	assert.Contains(t, pool.peers, p2p.ID("trusted"))
+	assert.Empty(t, pool.banned)
+
+	// an untrusted peer is banned, and ignored until its ban ends
+	pool.reportInvalid("slow", errors.New("invalid block"))
+	assert.NotContains(t, pool.peers, p2p.ID("slow"))
+	assert.Contains(t, pool.banned, p2p.ID("slow"))
+	pool.SetPeerRange("slow", 1, 10)
+	assert.NotContains(t, pool.peers, p2p.ID("slow"))
+
+	pool.banned["slow"] = time.Now().Add(-time.Second)
+	pool.SetPeerRange("slow", 1, 10)
+	assert.Contains(t, pool.peers, p2p.ID("slow"))
+	assert.Empty(t, pool.banned)
+}
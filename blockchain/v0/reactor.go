@@ -1,22 +1,53 @@
 package v0
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"reflect"
+	"sync"
 	"time"
 
 	bc "github.com/lazyledger/lazyledger-core/blockchain"
 	"github.com/lazyledger/lazyledger-core/libs/log"
+	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
+	"github.com/lazyledger/lazyledger-core/libs/service"
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/trust"
 	bcproto "github.com/lazyledger/lazyledger-core/proto/tendermint/blockchain"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	sm "github.com/lazyledger/lazyledger-core/state"
 	"github.com/lazyledger/lazyledger-core/store"
 	"github.com/lazyledger/lazyledger-core/types"
 )
 
+var (
+	_ service.Service = (*Reactor)(nil)
+
+	// ChannelShims contains a map of ChannelDescriptorShim objects, where each
+	// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+	// p2p proto.Message the new p2p Channel is responsible for handling.
+	//
+	//
+	// TODO: Remove once p2p refactor is complete.
+	// ref: https://github.com/tendermint/tendermint/issues/5670
+	ChannelShims = map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		BlockchainChannel: {
+			MsgType: new(bcproto.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(BlockchainChannel),
+				Priority:            10,
+				SendQueueCapacity:   1000,
+				RecvBufferCapacity:  50 * 4096,
+				RecvMessageCapacity: bc.MaxMsgSize,
+			},
+		},
+	}
+)
+
 const (
 	// BlockchainChannel is a channel for blocks and status updates (`BlockStore` height)
-	BlockchainChannel = byte(0x40)
+	BlockchainChannel = p2p.ChannelID(0x40)
 
 	trySyncIntervalMS = 10
 
@@ -39,6 +70,22 @@ type consensusReactor interface {
 	SwitchToConsensus(state sm.State, skipWAL bool)
 }
 
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// ReactorHeaderFirst sets whether the reactor syncs in header-first mode,
+// downloading and verifying the light blocks ahead of the blocks. See
+// BlockPool.
+func ReactorHeaderFirst(headerFirst bool) ReactorOption {
+	return func(r *Reactor) { r.headerFirst = headerFirst }
+}
+
+// ReactorTrustMetricStore sets the store the reactor scores the fast sync
+// peers in, and bans them by. See BlockPool.EnablePeerScoring.
+func ReactorTrustMetricStore(store *trust.MetricStore) ReactorOption {
+	return func(r *Reactor) { r.trustMetrics = store }
+}
+
 type peerError struct {
 	err    error
 	peerID p2p.ID
@@ -48,26 +95,67 @@ func (e peerError) Error() string {
 	return fmt.Sprintf("error with peer %v: %s", e.peerID, e.err.Error())
 }
 
-// BlockchainReactor handles long-term catchup syncing.
-type BlockchainReactor struct {
-	p2p.BaseReactor
+// Reactor handles long-term catchup syncing.
+type Reactor struct {
+	service.BaseService
 
 	// immutable
 	initialState sm.State
 
-	blockExec *sm.BlockExecutor
-	store     *store.BlockStore
-	pool      *BlockPool
-	fastSync  bool
+	blockExec   *sm.BlockExecutor
+	store       *store.BlockStore
+	pool        *BlockPool
+	consReactor consensusReactor
+	fastSync    bool
+	headerFirst bool
+
+	trustMetrics *trust.MetricStore
+
+	blockchainCh *p2p.Channel
+	peerUpdates  *p2p.PeerUpdatesCh
+	closeCh      chan struct{}
 
 	requestsCh <-chan BlockRequest
+	headersCh  chan LightBlocksRequest
 	errorsCh   <-chan peerError
+
+	// poolWG is used to synchronize the graceful shutdown of the poolRoutine and
+	// requestRoutine spawned goroutines when stopping the reactor and before
+	// stopping the p2p Channel(s).
+	poolWG sync.WaitGroup
+
+	// lightBlockRequests holds the pending requests of LightBlock.
+	lightBlockMtx      tmsync.Mutex
+	lightBlockRequests map[lightBlockRequest]chan []*types.LightBlock
 }
 
-// NewBlockchainReactor returns new reactor instance.
-func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
-	fastSync bool) *BlockchainReactor {
+// lightBlockRequest identifies a pending LightBlock request.
+type lightBlockRequest struct {
+	peerID string
+	height int64
+}
 
+// NewReactor returns a reference to a new fast sync reactor, which implements
+// the service.Service interface. It accepts a logger, the initial state, a
+// block executor and store, the consensus reactor to switch to once caught
+// up, a p2p Channel and a channel to listen for peer updates on. The
+// consensus reactor may be nil, in which case the reactor simply stops
+// syncing once caught up. Note, the reactor will close all p2p Channels when
+// stopping.
+//
+// Light blocks are served to peers syncing in header-first mode, using the
+// validator sets in the state store of the block executor.
+func NewReactor(
+	logger log.Logger,
+	state sm.State,
+	blockExec *sm.BlockExecutor,
+	store *store.BlockStore,
+	consReactor consensusReactor,
+	blockchainCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdatesCh,
+	fastSync bool,
+	options ...ReactorOption,
+) *Reactor {
 	if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
 			store.Height()))
@@ -83,194 +171,546 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *st
 		startHeight = state.InitialHeight
 	}
 	pool := NewBlockPool(startHeight, requestsCh, errorsCh)
+	pool.SetLogger(logger)
 
-	bcR := &BlockchainReactor{
+	r := &Reactor{
 		initialState: state,
 		blockExec:    blockExec,
 		store:        store,
 		pool:         pool,
+		consReactor:  consReactor,
 		fastSync:     fastSync,
+		blockchainCh: blockchainCh,
+		peerUpdates:  peerUpdates,
+		closeCh:      make(chan struct{}),
 		requestsCh:   requestsCh,
+		headersCh:    make(chan LightBlocksRequest, 1),
 		errorsCh:     errorsCh,
+
+		lightBlockRequests: make(map[lightBlockRequest]chan []*types.LightBlock),
+	}
+	for _, option := range options {
+		option(r)
+	}
+	if r.trustMetrics != nil {
+		pool.EnablePeerScoring(r.trustMetrics)
 	}
-	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
-	return bcR
-}
 
-// SetLogger implements service.Service by setting the logger on reactor and pool.
-func (bcR *BlockchainReactor) SetLogger(l log.Logger) {
-	bcR.BaseService.Logger = l
-	bcR.pool.Logger = l
+	r.BaseService = *service.NewBaseService(logger, "Blockchain", r)
+	return r
 }
 
-// OnStart implements service.Service.
-func (bcR *BlockchainReactor) OnStart() error {
-	if bcR.fastSync {
-		err := bcR.pool.Start()
-		if err != nil {
+// OnStart starts separate go routines for each p2p Channel and listens for
+// envelopes on each. In addition, it also listens for peer updates and handles
+// messages on that p2p channel accordingly. The caller must be sure to execute
+// OnStop to ensure the outbound p2p Channels are closed.
+//
+// If fastSync is enabled, we also start the pool and the pool processing
+// goroutine. If the pool fails to start, an error is returned.
+func (r *Reactor) OnStart() error {
+	if r.fastSync {
+		if r.headerFirst {
+			r.pool.EnableHeaderFirst(r.initialState, r.headersCh)
+		}
+		if err := r.pool.Start(); err != nil {
 			return err
 		}
-		go bcR.poolRoutine(false)
+
+		r.poolWG.Add(2)
+		go r.requestRoutine()
+		go r.poolRoutine(false)
 	}
+
+	go r.processBlockchainCh()
+	go r.processPeerUpdates()
+
 	return nil
 }
 
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {
+	if r.fastSync {
+		if err := r.pool.Stop(); err != nil {
+			r.Logger.Error("failed to stop pool", "err", err)
+		}
+	}
+
+	// wait for the poolRoutine and requestRoutine goroutines to gracefully exit
+	r.poolWG.Wait()
+
+	// Close closeCh to signal to all spawned goroutines to gracefully exit. All
+	// p2p Channels should execute Close().
+	close(r.closeCh)
+
+	// Wait for all p2p Channels to be closed before returning. This ensures we
+	// can easily reason about synchronization of all p2p Channels and ensure no
+	// panics will occur.
+	<-r.blockchainCh.Done()
+	<-r.peerUpdates.Done()
+}
+
 // SwitchToFastSync is called by the state sync reactor when switching to fast sync.
-func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
-	bcR.fastSync = true
-	bcR.initialState = state
+func (r *Reactor) SwitchToFastSync(state sm.State) error {
+	r.fastSync = true
+	r.initialState = state
+	r.pool.height = state.LastBlockHeight + 1
 
-	bcR.pool.height = state.LastBlockHeight + 1
-	err := bcR.pool.Start()
-	if err != nil {
+	if r.headerFirst {
+		r.pool.EnableHeaderFirst(state, r.headersCh)
+	}
+	if err := r.pool.Start(); err != nil {
 		return err
 	}
-	go bcR.poolRoutine(true)
+
+	r.poolWG.Add(2)
+	go r.requestRoutine()
+	go r.poolRoutine(true)
+
 	return nil
 }
 
-// OnStop implements service.Service.
-func (bcR *BlockchainReactor) OnStop() {
-	if bcR.fastSync {
-		if err := bcR.pool.Stop(); err != nil {
-			bcR.Logger.Error("Error stopping pool", "err", err)
+// respondToPeer loads a block and sends it to the requesting peer, if we have it.
+// Otherwise, we'll respond saying we do not have it.
+func (r *Reactor) respondToPeer(msg *bcproto.BlockRequest, peerID p2p.PeerID) {
+	block := r.store.LoadBlock(msg.Height)
+	if block != nil {
+		blockProto, err := block.ToProto()
+		if err != nil {
+			r.Logger.Error("failed to convert msg to protobuf", "err", err)
+			return
+		}
+
+		r.blockchainCh.Out() <- p2p.Envelope{
+			To:      peerID,
+			Message: &bcproto.BlockResponse{Block: blockProto},
 		}
+
+		return
 	}
-}
 
-// GetChannels implements Reactor
-func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
-	return []*p2p.ChannelDescriptor{
-		{
-			ID:                  BlockchainChannel,
-			Priority:            10,
-			SendQueueCapacity:   1000,
-			RecvBufferCapacity:  50 * 4096,
-			RecvMessageCapacity: bc.MaxMsgSize,
-		},
+	r.Logger.Info("peer requesting a block we do not have", "peer", peerID.String(), "height", msg.Height)
+	r.blockchainCh.Out() <- p2p.Envelope{
+		To:      peerID,
+		Message: &bcproto.NoBlockResponse{Height: msg.Height},
 	}
 }
 
-// AddPeer implements Reactor by sending our state to peer.
-func (bcR *BlockchainReactor) AddPeer(peer p2p.Peer) {
-	msgBytes, err := bc.EncodeMsg(&bcproto.StatusResponse{
-		Base:   bcR.store.Base(),
-		Height: bcR.store.Height()})
-	if err != nil {
-		bcR.Logger.Error("could not convert msg to protobuf", "err", err)
-		return
+// statusResponse returns the range of blocks we can send to peers. Blocks
+// restored after state sync without their data are not included, as we would
+// only respond to requests for them with a NoBlockResponse.
+func (r *Reactor) statusResponse() *bcproto.StatusResponse {
+	base := r.store.FullBlockBase()
+	if base == 0 {
+		return &bcproto.StatusResponse{}
 	}
+	return &bcproto.StatusResponse{
+		Base:   base,
+		Height: r.store.Height(),
+	}
+}
 
-	_ = peer.Send(BlockchainChannel, msgBytes)
-	// it's OK if send fails. will try later in poolRoutine
+// respondWithLightBlocks loads the requested light blocks we have, up to
+// lightBlocksBatchSize and the maximum message size, and sends them to the
+// requesting peer. The response is empty if we don't have the first one. A
+// request for height 0 is answered with the latest light block.
+func (r *Reactor) respondWithLightBlocks(msg *bcproto.LightBlocksRequest, peerID p2p.PeerID) {
+	first := msg.Height
+	if first == 0 {
+		first = r.store.Height()
+	}
+	count := tmmath.MinInt64(msg.Count, lightBlocksBatchSize)
+	lightBlocks := make([]*tmproto.LightBlock, 0, count)
+	size := 0
+	for height := first; height < first+count; height++ {
+		lb := r.loadLightBlock(height)
+		if lb == nil {
+			break
+		}
+		lbProto, err := lb.ToProto()
+		if err != nil {
+			r.Logger.Error("failed to convert light block to protobuf", "height", height, "err", err)
+			break
+		}
+		size += lbProto.Size()
+		if size > bc.MaxMsgSize && len(lightBlocks) > 0 {
+			break
+		}
+		lightBlocks = append(lightBlocks, lbProto)
+	}
 
-	// peer is added to the pool once we receive the first
-	// bcStatusResponseMessage from the peer and call pool.SetPeerRange
+	if len(lightBlocks) == 0 {
+		r.Logger.Info("peer requesting a light block we do not have", "peer", peerID.String(), "height", msg.Height)
+	}
+	r.blockchainCh.Out() <- p2p.Envelope{
+		To:      peerID,
+		Message: &bcproto.LightBlocksResponse{Height: msg.Height, LightBlocks: lightBlocks},
+	}
 }
 
-// RemovePeer implements Reactor by removing peer from the pool.
-func (bcR *BlockchainReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
-	bcR.pool.RemovePeer(peer.ID())
+// loadLightBlock loads the header, commit and validator set at the given
+// height, returning nil if any of them is missing.
+func (r *Reactor) loadLightBlock(height int64) *types.LightBlock {
+	meta := r.store.LoadBlockMeta(height)
+	if meta == nil {
+		return nil
+	}
+	commit := r.store.LoadBlockCommit(height)
+	if commit == nil {
+		// the commit of the latest block is only available as seen commit
+		commit = r.store.LoadSeenCommit(height)
+	}
+	if commit == nil || commit.Height != height || !commit.BlockID.Equals(meta.BlockID) {
+		return nil
+	}
+	vals, err := r.blockExec.Store().LoadValidators(height)
+	if err != nil {
+		return nil
+	}
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &meta.Header, Commit: commit},
+		ValidatorSet: vals,
+	}
 }
 
-// respondToPeer loads a block and sends it to the requesting peer,
-// if we have it. Otherwise, we'll respond saying we don't have it.
-func (bcR *BlockchainReactor) respondToPeer(msg *bcproto.BlockRequest,
-	src p2p.Peer) (queued bool) {
+// handleBlockchainMessage handles envelopes sent from peers on the
+// BlockchainChannel. It returns an error only if the Envelope.Message is
+// unknown for this channel or if it carries an invalid block. This should
+// never be called outside of handleMessage.
+func (r *Reactor) handleBlockchainMessage(envelope p2p.Envelope) error {
+	logger := r.Logger.With("peer", envelope.From.String())
 
-	block := bcR.store.LoadBlock(msg.Height)
-	if block != nil {
-		bl, err := block.ToProto()
+	switch msg := envelope.Message.(type) {
+	case *bcproto.BlockRequest:
+		r.respondToPeer(msg, envelope.From)
+
+	case *bcproto.BlockResponse:
+		block, err := types.BlockFromProto(msg.Block)
 		if err != nil {
-			bcR.Logger.Error("could not convert msg to protobuf", "err", err)
-			return false
+			logger.Error("failed to convert block from proto", "err", err)
+			return err
 		}
 
-		msgBytes, err := bc.EncodeMsg(&bcproto.BlockResponse{Block: bl})
-		if err != nil {
-			bcR.Logger.Error("could not marshal msg", "err", err)
-			return false
+		r.pool.AddBlock(p2p.ID(envelope.From.String()), block, msg.Size())
+
+	case *bcproto.StatusRequest:
+		r.blockchainCh.Out() <- p2p.Envelope{
+			To:      envelope.From,
+			Message: r.statusResponse(),
+		}
+
+	case *bcproto.StatusResponse:
+		// Got a peer status. Unverified.
+		r.pool.SetPeerRange(p2p.ID(envelope.From.String()), msg.Base, msg.Height)
+
+	case *bcproto.NoBlockResponse:
+		logger.Debug("peer does not have the requested block", "height", msg.Height)
+
+	case *bcproto.LightBlocksRequest:
+		r.respondWithLightBlocks(msg, envelope.From)
+
+	case *bcproto.LightBlocksResponse:
+		lightBlocks := make([]*types.LightBlock, 0, len(msg.LightBlocks))
+		for _, lbProto := range msg.LightBlocks {
+			lb, err := types.LightBlockFromProto(lbProto)
+			if err != nil {
+				logger.Error("failed to convert light block from proto", "err", err)
+				return err
+			}
+			lightBlocks = append(lightBlocks, lb)
 		}
 
-		return src.TrySend(BlockchainChannel, msgBytes)
+		if !r.deliverLightBlocks(envelope.From, msg.Height, lightBlocks) {
+			r.pool.AddLightBlocks(p2p.ID(envelope.From.String()), msg.Height, lightBlocks)
+		}
+
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
 	}
 
-	bcR.Logger.Info("Peer asking for a block we don't have", "src", src, "height", msg.Height)
+	return nil
+}
+
+// LightBlock requests the light block at the given height, or the latest one
+// if height is 0, from the peers which have it according to their status, one
+// after another. It waits for such a peer until the context is done. The light
+// block is not verified, so it is meant to be used as a light client provider,
+// e.g. by state sync.
+func (r *Reactor) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	for {
+		for _, peerID := range r.pool.PeersWithHeight(height) {
+			lb, err := r.requestLightBlock(ctx, peerID, height)
+			if err == nil {
+				return lb, nil
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			r.Logger.Debug("failed to fetch light block", "peer", peerID, "height", height, "err", err)
+		}
 
-	msgBytes, err := bc.EncodeMsg(&bcproto.NoBlockResponse{Height: msg.Height})
+		select {
+		case <-time.After(lightBlocksRetryInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-r.closeCh:
+			return nil, errors.New("reactor stopped")
+		}
+	}
+}
+
+// requestLightBlock requests a single light block from a peer and waits for
+// its response.
+func (r *Reactor) requestLightBlock(ctx context.Context, id p2p.ID, height int64) (*types.LightBlock, error) {
+	peerID, err := p2p.PeerIDFromString(string(id))
 	if err != nil {
-		bcR.Logger.Error("could not convert msg to protobuf", "err", err)
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, peerTimeout)
+	defer cancel()
+
+	req := lightBlockRequest{peerID: peerID.String(), height: height}
+	respCh := make(chan []*types.LightBlock, 1)
+	r.lightBlockMtx.Lock()
+	if _, ok := r.lightBlockRequests[req]; ok {
+		r.lightBlockMtx.Unlock()
+		return nil, errors.New("light block already requested from peer")
+	}
+	r.lightBlockRequests[req] = respCh
+	r.lightBlockMtx.Unlock()
+	defer func() {
+		r.lightBlockMtx.Lock()
+		delete(r.lightBlockRequests, req)
+		r.lightBlockMtx.Unlock()
+	}()
+
+	select {
+	case r.blockchainCh.Out() <- p2p.Envelope{
+		To:      peerID,
+		Message: &bcproto.LightBlocksRequest{Height: height, Count: 1},
+	}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case lightBlocks := <-respCh:
+		if len(lightBlocks) == 0 {
+			return nil, fmt.Errorf("peer does not have light block %v", height)
+		}
+		if height != 0 && lightBlocks[0].Height != height {
+			return nil, fmt.Errorf("peer sent light block %v instead of %v", lightBlocks[0].Height, height)
+		}
+		return lightBlocks[0], nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// deliverLightBlocks passes the light blocks a peer sent to a pending
+// LightBlock request. It returns false if there is no such request.
+func (r *Reactor) deliverLightBlocks(peerID p2p.PeerID, height int64, lightBlocks []*types.LightBlock) bool {
+	r.lightBlockMtx.Lock()
+	defer r.lightBlockMtx.Unlock()
+	respCh, ok := r.lightBlockRequests[lightBlockRequest{peerID: peerID.String(), height: height}]
+	if !ok {
 		return false
 	}
+	select {
+	case respCh <- lightBlocks:
+	default: // a response was delivered already
+	}
+	return true
+}
 
-	return src.TrySend(BlockchainChannel, msgBytes)
+// handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
+// It will handle errors and any possible panics gracefully. A caller can handle
+// any error returned by sending a PeerError on the respective channel.
+func (r *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			r.Logger.Error("recovering from processing message panic", "err", err)
+		}
+	}()
+
+	r.Logger.Debug("received message", "message", envelope.Message, "peer", envelope.From.String())
+
+	switch chID {
+	case BlockchainChannel:
+		err = r.handleBlockchainMessage(envelope)
+
+	default:
+		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
+	}
+
+	return err
 }
 
-// Receive implements Reactor by handling 4 types of messages (look below).
-// XXX: do not call any methods that can block or incur heavy processing.
-// https://github.com/tendermint/tendermint/issues/2888
-func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	logger := bcR.Logger.With("src", src, "chId", chID)
+// processBlockchainCh initiates a blocking process where we listen for and handle
+// envelopes on the BlockchainChannel. Any error encountered during message
+// execution will result in a PeerError being sent on the BlockchainChannel. When
+// the reactor is stopped, we will catch the signal and close the p2p Channel
+// gracefully.
+func (r *Reactor) processBlockchainCh() {
+	defer r.blockchainCh.Close()
 
-	msg, err := bc.DecodeMsg(msgBytes)
-	if err != nil {
-		logger.Error("Error decoding message", "err", err)
-		bcR.Switch.StopPeerForError(src, err)
-		return
+	for {
+		select {
+		case envelope := <-r.blockchainCh.In():
+			if err := r.handleMessage(r.blockchainCh.ID(), envelope); err != nil {
+				r.blockchainCh.Error() <- p2p.PeerError{
+					PeerID:   envelope.From,
+					Err:      err,
+					Severity: p2p.PeerErrorSeverityLow,
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on blockchain channel; closing...")
+			// processPeerUpdates sends on the channel too, so we wait for it to
+			// exit before closing the channel.
+			<-r.peerUpdates.Done()
+			return
+		}
 	}
+}
 
-	if err = bc.ValidateMsg(msg); err != nil {
-		logger.Error("Peer sent us invalid msg", "msg", msg, "err", err)
-		bcR.Switch.StopPeerForError(src, err)
-		return
+// processPeerUpdate processes a PeerUpdate. New peers are sent our status,
+// while peers that go down are removed from the pool.
+func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	r.Logger.Debug("received peer update", "peer", peerUpdate.PeerID.String(), "status", peerUpdate.Status)
+
+	switch peerUpdate.Status {
+	case p2p.PeerStatusNew, p2p.PeerStatusUp:
+		// send a status update the newly added peer
+		r.blockchainCh.Out() <- p2p.Envelope{
+			To:      peerUpdate.PeerID,
+			Message: r.statusResponse(),
+		}
+
+		// peer is added to the pool once we receive the first
+		// StatusResponse from the peer and call pool.SetPeerRange
+
+	case p2p.PeerStatusDown, p2p.PeerStatusRemoved, p2p.PeerStatusBanned:
+		r.pool.RemovePeer(p2p.ID(peerUpdate.PeerID.String()))
+		if r.trustMetrics != nil {
+			// the trust metric of a peer does not decay while it is disconnected
+			r.trustMetrics.PeerDisconnected(peerUpdate.PeerID.String())
+		}
 	}
+}
 
-	logger.Debug("Receive", "msg", msg)
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. When the reactor is stopped, we will catch the signal and
+// close the p2p PeerUpdatesCh gracefully.
+func (r *Reactor) processPeerUpdates() {
+	defer r.peerUpdates.Close()
 
-	switch msg := msg.(type) {
-	case *bcproto.BlockRequest:
-		bcR.respondToPeer(msg, src)
-	case *bcproto.BlockResponse:
-		bi, err := types.BlockFromProto(msg.Block)
-		if err != nil {
-			logger.Error("Block content is invalid", "err", err)
-			bcR.Switch.StopPeerForError(src, err)
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.processPeerUpdate(peerUpdate)
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on peer updates channel; closing...")
 			return
 		}
-		bcR.pool.AddBlock(src.ID(), bi, len(msgBytes))
-	case *bcproto.StatusRequest:
-		// Send peer our state.
-		msgBytes, err := bc.EncodeMsg(&bcproto.StatusResponse{
-			Height: bcR.store.Height(),
-			Base:   bcR.store.Base(),
-		})
-		if err != nil {
-			logger.Error("could not convert msg to protobut", "err", err)
+	}
+}
+
+// sendPeerError reports a peer the pool or the pool routine found misbehaving.
+func (r *Reactor) sendPeerError(peerID p2p.ID, err error) {
+	pID, perr := p2p.PeerIDFromString(string(peerID))
+	if perr != nil {
+		r.Logger.Error("failed to parse peer ID", "peer", peerID, "err", perr)
+		return
+	}
+
+	select {
+	case r.blockchainCh.Error() <- p2p.PeerError{
+		PeerID:   pID,
+		Err:      err,
+		Severity: p2p.PeerErrorSeverityLow,
+	}:
+	case <-r.pool.Quit():
+	}
+}
+
+// requestRoutine sends the block requests made by the pool to the respective
+// peers, reports the errors the pool finds and periodically asks all peers
+// for their status.
+func (r *Reactor) requestRoutine() {
+	statusUpdateTicker := time.NewTicker(statusUpdateIntervalSeconds * time.Second)
+	defer statusUpdateTicker.Stop()
+
+	defer r.poolWG.Done()
+
+	for {
+		select {
+		case <-r.pool.Quit():
 			return
+
+		case request := <-r.requestsCh:
+			peerID, err := p2p.PeerIDFromString(string(request.PeerID))
+			if err != nil {
+				r.Logger.Error("failed to parse peer ID", "peer", request.PeerID, "err", err)
+				continue
+			}
+
+			select {
+			case r.blockchainCh.Out() <- p2p.Envelope{
+				To:      peerID,
+				Message: &bcproto.BlockRequest{Height: request.Height},
+			}:
+			case <-r.pool.Quit():
+				return
+			}
+
+		case request := <-r.headersCh:
+			peerID, err := p2p.PeerIDFromString(string(request.PeerID))
+			if err != nil {
+				r.Logger.Error("failed to parse peer ID", "peer", request.PeerID, "err", err)
+				continue
+			}
+
+			select {
+			case r.blockchainCh.Out() <- p2p.Envelope{
+				To:      peerID,
+				Message: &bcproto.LightBlocksRequest{Height: request.Height, Count: request.Count},
+			}:
+			case <-r.pool.Quit():
+				return
+			}
+
+		case pErr := <-r.errorsCh:
+			r.sendPeerError(pErr.peerID, pErr.err)
+
+		case <-statusUpdateTicker.C:
+			// ask for status updates
+			select {
+			case r.blockchainCh.Out() <- p2p.Envelope{
+				Broadcast: true,
+				Message:   &bcproto.StatusRequest{},
+			}:
+			case <-r.pool.Quit():
+				return
+			}
 		}
-		src.TrySend(BlockchainChannel, msgBytes)
-	case *bcproto.StatusResponse:
-		// Got a peer status. Unverified.
-		bcR.pool.SetPeerRange(src.ID(), msg.Base, msg.Height)
-	case *bcproto.NoBlockResponse:
-		logger.Debug("Peer does not have requested block", "height", msg.Height)
-	default:
-		logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
 }
 
 // Handle messages from the poolReactor telling the reactor what to do.
 // NOTE: Don't sleep in the FOR_LOOP or otherwise slow it down!
-func (bcR *BlockchainReactor) poolRoutine(stateSynced bool) {
+func (r *Reactor) poolRoutine(stateSynced bool) {
 	var (
 		trySyncTicker           = time.NewTicker(trySyncIntervalMS * time.Millisecond)
-		statusUpdateTicker      = time.NewTicker(statusUpdateIntervalSeconds * time.Second)
 		switchToConsensusTicker = time.NewTicker(switchToConsensusIntervalSeconds * time.Second)
 
 		blocksSynced = uint64(0)
 
-		chainID = bcR.initialState.ChainID
-		state   = bcR.initialState
+		chainID = r.initialState.ChainID
+		state   = r.initialState
 
 		lastHundred = time.Now()
 		lastRate    = 0.0
@@ -278,45 +718,10 @@ func (bcR *BlockchainReactor) poolRoutine(stateSynced bool) {
 		didProcessCh = make(chan struct{}, 1)
 	)
 
-	go func() {
-		for {
-			select {
-
-			case <-bcR.Quit():
-				return
+	defer trySyncTicker.Stop()
+	defer switchToConsensusTicker.Stop()
 
-			case <-bcR.pool.Quit():
-				return
-
-			case request := <-bcR.requestsCh:
-				peer := bcR.Switch.Peers().Get(request.PeerID)
-				if peer == nil {
-					bcR.Logger.Debug("Can't send request: no peer", "peer_id", request.PeerID)
-					continue
-				}
-				msgBytes, err := bc.EncodeMsg(&bcproto.BlockRequest{Height: request.Height})
-				if err != nil {
-					bcR.Logger.Error("could not convert BlockRequest to proto", "err", err)
-					continue
-				}
-
-				queued := peer.TrySend(BlockchainChannel, msgBytes)
-				if !queued {
-					bcR.Logger.Debug("Send queue is full, drop block request", "peer", peer.ID(), "height", request.Height)
-				}
-
-			case err := <-bcR.errorsCh:
-				peer := bcR.Switch.Peers().Get(err.peerID)
-				if peer != nil {
-					bcR.Switch.StopPeerForError(peer, err)
-				}
-
-			case <-statusUpdateTicker.C:
-				// ask for status updates
-				go bcR.BroadcastStatusRequest()
-			}
-		}
-	}()
+	defer r.poolWG.Done()
 
 FOR_LOOP:
 	for {
@@ -324,34 +729,31 @@ FOR_LOOP:
 
 		case <-switchToConsensusTicker.C:
 			var (
-				height, numPending, lenRequesters = bcR.pool.GetStatus()
-				outbound, inbound, _              = bcR.Switch.NumPeers()
-				lastAdvance                       = bcR.pool.LastAdvance()
+				height, numPending, lenRequesters = r.pool.GetStatus()
+				lastAdvance                       = r.pool.LastAdvance()
 			)
 
-			bcR.Logger.Debug("Consensus ticker",
+			r.Logger.Debug("Consensus ticker",
 				"numPending", numPending,
 				"total", lenRequesters)
 
 			switch {
-			case bcR.pool.IsCaughtUp():
-				bcR.Logger.Info("Time to switch to consensus reactor!", "height", height)
+			case r.pool.IsCaughtUp():
+				r.Logger.Info("Time to switch to consensus reactor!", "height", height)
 			case time.Since(lastAdvance) > syncTimeout:
-				bcR.Logger.Error(fmt.Sprintf("No progress since last advance: %v", lastAdvance))
+				r.Logger.Error(fmt.Sprintf("No progress since last advance: %v", lastAdvance))
 			default:
-				bcR.Logger.Info("Not caught up yet",
-					"height", height, "max_peer_height", bcR.pool.MaxPeerHeight(),
-					"num_peers", outbound+inbound,
+				r.Logger.Info("Not caught up yet",
+					"height", height, "max_peer_height", r.pool.MaxPeerHeight(),
 					"timeout_in", syncTimeout-time.Since(lastAdvance))
 				continue
 			}
 
-			if err := bcR.pool.Stop(); err != nil {
-				bcR.Logger.Error("Error stopping pool", "err", err)
+			if err := r.pool.Stop(); err != nil {
+				r.Logger.Error("Error stopping pool", "err", err)
 			}
-			conR, ok := bcR.Switch.Reactor("CONSENSUS").(consensusReactor)
-			if ok {
-				conR.SwitchToConsensus(state, blocksSynced > 0 || stateSynced)
+			if r.consReactor != nil {
+				r.consReactor.SwitchToConsensus(state, blocksSynced > 0 || stateSynced)
 			}
 
 			break FOR_LOOP
@@ -372,8 +774,8 @@ FOR_LOOP:
 			// routine.
 
 			// See if there are any blocks to sync.
-			first, second := bcR.pool.PeekTwoBlocks()
-			// bcR.Logger.Info("TrySync peeked", "first", first, "second", second)
+			first, second := r.pool.PeekTwoBlocks()
+			// r.Logger.Info("TrySync peeked", "first", first, "second", second)
 			if first == nil || second == nil {
 				// We need both to sync the first block.
 				continue FOR_LOOP
@@ -392,38 +794,41 @@ FOR_LOOP:
 			// NOTE: we can probably make this more efficient, but note that calling
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
-			err := state.Validators.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
+			//
+			// In header-first mode, both blocks have already been verified against
+			// their verified headers, which commit to the second's last commit.
+			var err error
+			if !r.headerFirst {
+				err = state.Validators.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
+			}
 			if err != nil {
 				err = fmt.Errorf("invalid last commit: %w", err)
-				bcR.Logger.Error(err.Error(),
+				r.Logger.Error(err.Error(),
 					"last_commit", second.LastCommit, "block_id", firstID, "height", first.Height)
 
-				peerID := bcR.pool.RedoRequest(first.Height)
-				peer := bcR.Switch.Peers().Get(peerID)
-				if peer != nil {
-					// NOTE: we've already removed the peer's request, but we still need
-					// to clean up the rest.
-					bcR.Switch.StopPeerForError(peer, err)
-				}
+				// NOTE: we've already removed the peer's request, but we still need
+				// to clean up the rest.
+				peerID := r.pool.RedoRequest(first.Height)
+				r.sendPeerError(peerID, err)
+				r.pool.reportInvalid(peerID, err)
 
-				peerID2 := bcR.pool.RedoRequest(second.Height)
+				peerID2 := r.pool.RedoRequest(second.Height)
 				if peerID2 != peerID {
-					if peer2 := bcR.Switch.Peers().Get(peerID2); peer2 != nil {
-						bcR.Switch.StopPeerForError(peer2, err)
-					}
+					r.sendPeerError(peerID2, err)
+					r.pool.reportInvalid(peerID2, err)
 				}
 
 				continue FOR_LOOP
 			} else {
-				bcR.pool.PopRequest()
+				r.pool.PopRequest()
 
 				// TODO: batch saves so we dont persist to disk every block
-				bcR.store.SaveBlock(first, firstParts, second.LastCommit)
+				r.store.SaveBlock(first, firstParts, second.LastCommit)
 
 				// TODO: same thing for app - but we would need a way to get the hash
 				// without persisting the state.
 				var err error
-				state, _, err = bcR.blockExec.ApplyBlock(state, firstID, first)
+				state, _, err = r.blockExec.ApplyBlock(state, firstID, first)
 				if err != nil {
 					// TODO This is bad, are we zombie?
 					panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
@@ -432,27 +837,15 @@ FOR_LOOP:
 
 				if blocksSynced%100 == 0 {
 					lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
-					bcR.Logger.Info("Fast Sync Rate",
-						"height", bcR.pool.height, "max_peer_height", bcR.pool.MaxPeerHeight(), "blocks/s", lastRate)
+					r.Logger.Info("Fast Sync Rate",
+						"height", r.pool.height, "max_peer_height", r.pool.MaxPeerHeight(), "blocks/s", lastRate)
 					lastHundred = time.Now()
 				}
 			}
 			continue FOR_LOOP
 
-		case <-bcR.Quit():
+		case <-r.pool.Quit():
 			break FOR_LOOP
 		}
 	}
 }
-
-// BroadcastStatusRequest broadcasts `BlockStore` base and height.
-func (bcR *BlockchainReactor) BroadcastStatusRequest() {
-	bm, err := bc.EncodeMsg(&bcproto.StatusRequest{})
-	if err != nil {
-		bcR.Logger.Error("could not convert StatusRequest to proto", "err", err)
-		return
-	}
-
-	// We don't care about whenever broadcast is successful or not.
-	_ = bcR.Switch.Broadcast(BlockchainChannel, bm)
-}
@@ -1,10 +1,12 @@
 package v0
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"os"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	"github.com/lazyledger/lazyledger-core/mempool/mock"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	bcproto "github.com/lazyledger/lazyledger-core/proto/tendermint/blockchain"
 	"github.com/lazyledger/lazyledger-core/proxy"
 	sm "github.com/lazyledger/lazyledger-core/state"
 	"github.com/lazyledger/lazyledger-core/store"
@@ -47,16 +50,36 @@ func randGenesisDoc(numValidators int, randPower bool, minPower int64) (*types.G
 	}, privValidators
 }
 
-type BlockchainReactorPair struct {
-	reactor *BlockchainReactor
+type reactorTestSuite struct {
+	reactor *Reactor
 	app     proxy.AppConns
+	peerID  p2p.PeerID
+
+	blockchainChannel   *p2p.Channel
+	blockchainInCh      chan p2p.Envelope
+	blockchainOutCh     chan p2p.Envelope
+	blockchainPeerErrCh chan p2p.PeerError
+
+	peerUpdatesCh chan p2p.PeerUpdate
+	peerUpdates   *p2p.PeerUpdatesCh
 }
 
-func newBlockchainReactor(
-	logger log.Logger,
+// setup creates a fast sync reactor with a store holding maxBlockHeight
+// blocks. The reactor is not started and not connected to any peers; see
+// testNetwork.
+func setup(
+	t *testing.T,
+	peerID p2p.PeerID,
 	genDoc *types.GenesisDoc,
 	privVals []types.PrivValidator,
-	maxBlockHeight int64) BlockchainReactorPair {
+	maxBlockHeight int64,
+	options ...ReactorOption,
+) *reactorTestSuite {
+	t.Helper()
+
+	if len(privVals) != 1 {
+		panic("only support one validator")
+	}
 	if len(privVals) != 1 {
 		panic("only support one validator")
 	}
@@ -130,10 +153,150 @@ func newBlockchainReactor(
 		blockStore.SaveBlock(thisBlock, thisParts, lastCommit)
 	}
 
-	bcReactor := NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
-	bcReactor.SetLogger(logger.With("module", "blockchain"))
+	rts := &reactorTestSuite{
+		app:                 proxyApp,
+		peerID:              peerID,
+		blockchainInCh:      make(chan p2p.Envelope, 1000),
+		blockchainOutCh:     make(chan p2p.Envelope, 1000),
+		blockchainPeerErrCh: make(chan p2p.PeerError, 1000),
+		peerUpdatesCh:       make(chan p2p.PeerUpdate),
+	}
+	rts.peerUpdates = p2p.NewPeerUpdates(rts.peerUpdatesCh)
+
+	rts.blockchainChannel = p2p.NewChannel(
+		BlockchainChannel,
+		new(bcproto.Message),
+		rts.blockchainInCh,
+		rts.blockchainOutCh,
+		rts.blockchainPeerErrCh,
+	)
+
+	rts.reactor = NewReactor(
+		log.TestingLogger().With("module", "blockchain"),
+		state.Copy(),
+		blockExec,
+		blockStore,
+		nil,
+		rts.blockchainChannel,
+		rts.peerUpdates,
+		fastSync,
+		options...,
+	)
+
+	t.Cleanup(func() {
+		if rts.reactor.IsRunning() {
+			require.NoError(t, rts.reactor.Stop())
+		}
+		require.NoError(t, rts.app.Stop())
+	})
+
+	return rts
+}
+
+// testNetwork routes the envelopes sent by the reactors it knows to the
+// connected peers they are addressed to. A peer reported through a PeerError
+// is disconnected from the reporting reactor.
+type testNetwork struct {
+	mtx   sync.Mutex
+	nodes map[string]*reactorTestSuite
+	links map[string]map[string]bool
+
+	// peerErrors holds the peers each reactor has reported
+	peerErrors map[string][]p2p.PeerError
+}
+
+func newTestNetwork() *testNetwork {
+	return &testNetwork{
+		nodes:      make(map[string]*reactorTestSuite),
+		links:      make(map[string]map[string]bool),
+		peerErrors: make(map[string][]p2p.PeerError),
+	}
+}
+
+// start starts the reactor and routes its envelopes and peer errors.
+func (n *testNetwork) start(t *testing.T, rts *reactorTestSuite) {
+	n.mtx.Lock()
+	n.nodes[rts.peerID.String()] = rts
+	n.links[rts.peerID.String()] = make(map[string]bool)
+	n.mtx.Unlock()
+
+	require.NoError(t, rts.reactor.Start())
+
+	go func() {
+		for envelope := range rts.blockchainOutCh {
+			for _, dst := range n.destinations(rts, envelope) {
+				select {
+				case dst.blockchainInCh <- p2p.Envelope{From: rts.peerID, Message: envelope.Message}:
+				case <-dst.blockchainChannel.Done():
+				}
+			}
+		}
+	}()
+
+	go func() {
+		for peerErr := range rts.blockchainPeerErrCh {
+			n.mtx.Lock()
+			n.peerErrors[rts.peerID.String()] = append(n.peerErrors[rts.peerID.String()], peerErr)
+			peer := n.nodes[peerErr.PeerID.String()]
+			n.mtx.Unlock()
+
+			if peer != nil {
+				n.disconnect(rts, peer)
+			}
+		}
+	}()
+}
+
+func (n *testNetwork) destinations(src *reactorTestSuite, envelope p2p.Envelope) []*reactorTestSuite {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	var dsts []*reactorTestSuite
+	for peerKey := range n.links[src.peerID.String()] {
+		if envelope.Broadcast || envelope.To.String() == peerKey {
+			dsts = append(dsts, n.nodes[peerKey])
+		}
+	}
+	return dsts
+}
+
+func (n *testNetwork) connect(a, b *reactorTestSuite) {
+	n.mtx.Lock()
+	n.links[a.peerID.String()][b.peerID.String()] = true
+	n.links[b.peerID.String()][a.peerID.String()] = true
+	n.mtx.Unlock()
+
+	sendPeerUpdate(a, p2p.PeerUpdate{PeerID: b.peerID, Status: p2p.PeerStatusUp})
+	sendPeerUpdate(b, p2p.PeerUpdate{PeerID: a.peerID, Status: p2p.PeerStatusUp})
+}
+
+func (n *testNetwork) disconnect(a, b *reactorTestSuite) {
+	n.mtx.Lock()
+	delete(n.links[a.peerID.String()], b.peerID.String())
+	delete(n.links[b.peerID.String()], a.peerID.String())
+	n.mtx.Unlock()
+
+	sendPeerUpdate(a, p2p.PeerUpdate{PeerID: b.peerID, Status: p2p.PeerStatusDown})
+	sendPeerUpdate(b, p2p.PeerUpdate{PeerID: a.peerID, Status: p2p.PeerStatusDown})
+}
+
+func (n *testNetwork) numPeers(rts *reactorTestSuite) int {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return len(n.links[rts.peerID.String()])
+}
+
+func (n *testNetwork) reportedPeers(rts *reactorTestSuite) []p2p.PeerError {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.peerErrors[rts.peerID.String()]
+}
 
-	return BlockchainReactorPair{bcReactor, proxyApp}
+func sendPeerUpdate(rts *reactorTestSuite, update p2p.PeerUpdate) {
+	select {
+	case rts.peerUpdatesCh <- update:
+	case <-rts.peerUpdates.Done():
+	}
 }
 
 func TestNoBlockResponse(t *testing.T) {
@@ -143,25 +306,16 @@ func TestNoBlockResponse(t *testing.T) {
 
 	maxBlockHeight := int64(65)
 
-	reactorPairs := make([]BlockchainReactorPair, 2)
-
-	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
-	reactorPairs[1] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
-
-	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
-		return s
-
-	}, p2p.Connect2Switches)
+	suites := []*reactorTestSuite{
+		setup(t, p2p.PeerID{0x01}, genDoc, privVals, maxBlockHeight),
+		setup(t, p2p.PeerID{0x02}, genDoc, privVals, 0),
+	}
 
-	defer func() {
-		for _, r := range reactorPairs {
-			err := r.reactor.Stop()
-			require.NoError(t, err)
-			err = r.app.Stop()
-			require.NoError(t, err)
-		}
-	}()
+	network := newTestNetwork()
+	for _, rts := range suites {
+		network.start(t, rts)
+	}
+	network.connect(suites[0], suites[1])
 
 	tests := []struct {
 		height   int64
@@ -174,17 +328,17 @@ func TestNoBlockResponse(t *testing.T) {
 	}
 
 	for {
-		if reactorPairs[1].reactor.pool.IsCaughtUp() {
+		if suites[1].reactor.pool.IsCaughtUp() {
 			break
 		}
 
 		time.Sleep(10 * time.Millisecond)
 	}
 
-	assert.Equal(t, maxBlockHeight, reactorPairs[0].reactor.store.Height())
+	assert.Equal(t, maxBlockHeight, suites[0].reactor.store.Height())
 
 	for _, tt := range tests {
-		block := reactorPairs[1].reactor.store.LoadBlock(tt.height)
+		block := suites[1].reactor.store.LoadBlock(tt.height)
 		if tt.existent {
 			assert.True(t, block != nil)
 		} else {
@@ -193,11 +347,6 @@ func TestNoBlockResponse(t *testing.T) {
 	}
 }
 
-// NOTE: This is too hard to test without
-// an easy way to add test peer to switch
-// or without significant refactoring of the module.
-// Alternatively we could actually dial a TCP conn but
-// that seems extreme.
 func TestBadBlockStopsPeer(t *testing.T) {
 	config = cfg.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
@@ -207,43 +356,30 @@ func TestBadBlockStopsPeer(t *testing.T) {
 
 	// Other chain needs a different validator set
 	otherGenDoc, otherPrivVals := randGenesisDoc(1, false, 30)
-	otherChain := newBlockchainReactor(log.TestingLogger(), otherGenDoc, otherPrivVals, maxBlockHeight)
-
-	defer func() {
-		err := otherChain.reactor.Stop()
-		require.Error(t, err)
-		err = otherChain.app.Stop()
-		require.NoError(t, err)
-	}()
+	otherChain := setup(t, p2p.PeerID{0xFF}, otherGenDoc, otherPrivVals, maxBlockHeight)
 
-	reactorPairs := make([]BlockchainReactorPair, 4)
-
-	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
-	reactorPairs[1] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
-	reactorPairs[2] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
-	reactorPairs[3] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
-
-	switches := p2p.MakeConnectedSwitches(config.P2P, 4, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
-		return s
-
-	}, p2p.Connect2Switches)
-
-	defer func() {
-		for _, r := range reactorPairs {
-			err := r.reactor.Stop()
-			require.NoError(t, err)
+	suites := []*reactorTestSuite{
+		setup(t, p2p.PeerID{0x01}, genDoc, privVals, maxBlockHeight),
+		setup(t, p2p.PeerID{0x02}, genDoc, privVals, 0),
+		setup(t, p2p.PeerID{0x03}, genDoc, privVals, 0),
+		setup(t, p2p.PeerID{0x04}, genDoc, privVals, 0),
+	}
 
-			err = r.app.Stop()
-			require.NoError(t, err)
+	network := newTestNetwork()
+	for _, rts := range suites {
+		network.start(t, rts)
+	}
+	for i := 0; i < len(suites); i++ {
+		for j := i + 1; j < len(suites); j++ {
+			network.connect(suites[i], suites[j])
 		}
-	}()
+	}
 
 	for {
 		time.Sleep(1 * time.Second)
 		caughtUp := true
-		for _, r := range reactorPairs {
-			if !r.reactor.pool.IsCaughtUp() {
+		for _, rts := range suites {
+			if !rts.reactor.pool.IsCaughtUp() {
 				caughtUp = false
 			}
 		}
@@ -253,34 +389,165 @@ func TestBadBlockStopsPeer(t *testing.T) {
 	}
 
 	// at this time, reactors[0-3] is the newest
-	assert.Equal(t, 3, reactorPairs[1].reactor.Switch.Peers().Size())
+	assert.Equal(t, 3, network.numPeers(suites[1]))
 
-	// Mark reactorPairs[3] as an invalid peer. Fiddling with .store without a mutex is a data
+	// Mark suites[3] as an invalid peer. Fiddling with .store without a mutex is a data
 	// race, but can't be easily avoided.
-	reactorPairs[3].reactor.store = otherChain.reactor.store
-
-	lastReactorPair := newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
-	reactorPairs = append(reactorPairs, lastReactorPair)
-
-	switches = append(switches, p2p.MakeConnectedSwitches(config.P2P, 1, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("BLOCKCHAIN", reactorPairs[len(reactorPairs)-1].reactor)
-		return s
+	suites[3].reactor.store = otherChain.reactor.store
 
-	}, p2p.Connect2Switches)...)
-
-	for i := 0; i < len(reactorPairs)-1; i++ {
-		p2p.Connect2Switches(switches, i, len(reactorPairs)-1)
+	last := setup(t, p2p.PeerID{0x05}, genDoc, privVals, 0)
+	network.start(t, last)
+	for _, rts := range suites {
+		network.connect(rts, last)
 	}
 
 	for {
-		if lastReactorPair.reactor.pool.IsCaughtUp() || lastReactorPair.reactor.Switch.Peers().Size() == 0 {
+		if last.reactor.pool.IsCaughtUp() || network.numPeers(last) == 0 {
 			break
 		}
 
 		time.Sleep(1 * time.Second)
 	}
 
-	assert.True(t, lastReactorPair.reactor.Switch.Peers().Size() < len(reactorPairs)-1)
+	assert.True(t, network.numPeers(last) < len(suites))
+	assert.NotEmpty(t, network.reportedPeers(last))
+}
+
+func TestHeaderFirstSync(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(65)
+
+	suites := []*reactorTestSuite{
+		setup(t, p2p.PeerID{0x01}, genDoc, privVals, maxBlockHeight),
+		setup(t, p2p.PeerID{0x02}, genDoc, privVals, maxBlockHeight),
+		setup(t, p2p.PeerID{0x03}, genDoc, privVals, 0, ReactorHeaderFirst(true)),
+	}
+	// the blocks are downloaded from both peers, so they need to serve the same chain
+	suites[1].reactor.store = suites[0].reactor.store
+
+	network := newTestNetwork()
+	for _, rts := range suites {
+		network.start(t, rts)
+	}
+	network.connect(suites[0], suites[2])
+	network.connect(suites[1], suites[2])
+
+	syncing := suites[2].reactor
+	require.Eventually(t, func() bool { return syncing.pool.IsCaughtUp() }, 20*time.Second, 10*time.Millisecond)
+
+	// NOTE: setup saves the last commit of each block as its seen commit, so the
+	// light block at the latest height is not available, and the block before
+	// can't be applied without the last commit of the latest block.
+	assert.Equal(t, maxBlockHeight-2, syncing.store.Height())
+	for height := int64(1); height <= syncing.store.Height(); height++ {
+		block := syncing.store.LoadBlock(height)
+		require.NotNil(t, block, "block %v", height)
+		assert.Equal(t, suites[0].reactor.store.LoadBlock(height).Hash(), block.Hash())
+	}
+	assert.Empty(t, network.reportedPeers(suites[2]))
+}
+
+func TestStatusResponseOnlyFullBlocks(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	source := setup(t, p2p.PeerID{0x01}, genDoc, privVals, 6).reactor.store
+	rts := setup(t, p2p.PeerID{0x02}, genDoc, privVals, 0)
+	peerID := p2p.PeerID{0x03}
+
+	// restore the first blocks without their data, like state sync does
+	restored := store.NewBlockStore(memdb.NewDB(), mdutils.Mock())
+	rts.reactor.store = restored
+	for h := int64(1); h <= 3; h++ {
+		meta := source.LoadBlockMeta(h)
+		sh := &types.SignedHeader{Header: &meta.Header, Commit: source.LoadBlockCommit(h)}
+		require.NoError(t, restored.SaveSignedHeader(sh, &meta.DAHeader))
+	}
+
+	status := func() *bcproto.StatusResponse {
+		require.NoError(t, rts.reactor.handleBlockchainMessage(
+			p2p.Envelope{From: peerID, Message: &bcproto.StatusRequest{}}))
+		envelope := <-rts.blockchainOutCh
+		assert.Equal(t, peerID, envelope.To)
+		resp, ok := envelope.Message.(*bcproto.StatusResponse)
+		require.True(t, ok)
+		return resp
+	}
+
+	// we can't send any of the blocks
+	assert.Equal(t, &bcproto.StatusResponse{}, status())
+
+	for h := int64(4); h <= 5; h++ {
+		block := source.LoadBlock(h)
+		restored.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), source.LoadBlockCommit(h))
+	}
+	assert.Equal(t, &bcproto.StatusResponse{Base: 4, Height: 5}, status())
+}
+
+func TestLightBlock(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	suites := []*reactorTestSuite{
+		setup(t, p2p.PeerID{0x01}, genDoc, privVals, 10),
+		setup(t, p2p.PeerID{0x02}, genDoc, privVals, 0),
+	}
+	network := newTestNetwork()
+	for _, rts := range suites {
+		network.start(t, rts)
+	}
+	network.connect(suites[0], suites[1])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	lb, err := suites[1].reactor.LightBlock(ctx, 5)
+	require.NoError(t, err)
+	require.NoError(t, lb.ValidateBasic(genDoc.ChainID))
+	assert.EqualValues(t, 5, lb.Height)
+	assert.Equal(t, suites[0].reactor.store.LoadBlockMeta(5).BlockID.Hash, lb.Hash())
+
+	// no peer has light blocks beyond its height
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = suites[1].reactor.LightBlock(ctx, 11)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRespondWithLightBlocks(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	rts := setup(t, p2p.PeerID{0x01}, genDoc, privVals, 10)
+	peerID := p2p.PeerID{0x02}
+
+	// NOTE: setup saves the last commit of each block as its seen commit, so the
+	// light block at the latest height is not available.
+	rts.reactor.respondWithLightBlocks(&bcproto.LightBlocksRequest{Height: 6, Count: 5}, peerID)
+	envelope := <-rts.blockchainOutCh
+	assert.Equal(t, peerID, envelope.To)
+	resp, ok := envelope.Message.(*bcproto.LightBlocksResponse)
+	require.True(t, ok)
+	assert.EqualValues(t, 6, resp.Height)
+	require.Len(t, resp.LightBlocks, 4)
+	for i, lbProto := range resp.LightBlocks {
+		lb, err := types.LightBlockFromProto(lbProto)
+		require.NoError(t, err)
+		require.NoError(t, lb.ValidateBasic(genDoc.ChainID))
+		assert.EqualValues(t, 6+i, lb.Height)
+		assert.Equal(t, rts.reactor.store.LoadBlockMeta(lb.Height).BlockID.Hash, lb.Hash())
+	}
+
+	rts.reactor.respondWithLightBlocks(&bcproto.LightBlocksRequest{Height: 11, Count: 5}, peerID)
+	envelope = <-rts.blockchainOutCh
+	resp, ok = envelope.Message.(*bcproto.LightBlocksResponse)
+	require.True(t, ok)
+	assert.Empty(t, resp.LightBlocks)
 }
 
 //----------------------------------------------
@@ -295,7 +562,7 @@ func makeTxs(height int64) (txs []types.Tx) {
 
 func makeBlock(height int64, state sm.State, lastCommit *types.Commit) *types.Block {
 	block, _ := state.MakeBlock(height, makeTxs(height), nil,
-		nil, types.Messages{}, lastCommit, state.Validators.GetProposer().Address)
+		types.IntermediateStateRoots{}, types.Messages{}, lastCommit, state.Validators.GetProposer().Address)
 	return block
 }
 
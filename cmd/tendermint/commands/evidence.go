@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lazyledger/lazyledger-core/evidence"
+	tmbytes "github.com/lazyledger/lazyledger-core/libs/bytes"
+	tmjson "github.com/lazyledger/lazyledger-core/libs/json"
+	rpcclient "github.com/lazyledger/lazyledger-core/rpc/client"
+	rpchttp "github.com/lazyledger/lazyledger-core/rpc/client/http"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// evidencePerPage is the number of evidence requested from the node at once when exporting.
+const evidencePerPage = 100
+
+var (
+	evidenceRPCAddr   string
+	evidenceCommitted bool
+	evidenceFilter    evidence.Filter
+	evidenceValidator []byte
+)
+
+// EvidenceCmd groups the commands to move evidence of misbehavior between nodes.
+var EvidenceCmd = &cobra.Command{
+	Use:   "evidence",
+	Short: "Export and import evidence of validator misbehavior",
+	Long: `Export and import evidence of validator misbehavior as JSON.
+
+The commands talk to a running node over RPC, so that operators can move evidence
+from one node to another during incidents. Exported evidence is imported through
+broadcast_evidence, so the receiving node verifies it like any other evidence.`,
+}
+
+// ExportEvidenceCmd writes the evidence of a node to a JSON file.
+var ExportEvidenceCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the pending or committed evidence of a node as JSON",
+	Long: `Export the pending (or, with --committed, the committed) evidence of a node
+as a JSON array. The evidence can be filtered by height range, validator and
+type. If no file is given, the evidence is written to stdout.`,
+	Example: `evidence export evidence.json --min-height 100 --type DuplicateVoteEvidence`,
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := rpchttp.New(evidenceRPCAddr, "/websocket")
+		if err != nil {
+			return fmt.Errorf("failed to create new http client: %w", err)
+		}
+		evidenceFilter.Validator = evidenceValidator
+
+		evList, err := exportEvidence(context.Background(), client, evidenceCommitted, evidenceFilter)
+		if err != nil {
+			return err
+		}
+		bz, err := tmjson.MarshalIndent(evList, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal evidence: %w", err)
+		}
+
+		if len(args) == 0 {
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		}
+		if err := ioutil.WriteFile(args[0], bz, 0644); err != nil {
+			return fmt.Errorf("failed to write evidence to %s: %w", args[0], err)
+		}
+		logger.Info("Exported evidence", "file", args[0], "count", len(evList))
+		return nil
+	},
+}
+
+// ImportEvidenceCmd submits the evidence of a JSON file to a node.
+var ImportEvidenceCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import evidence from a JSON file into a node",
+	Long: `Import evidence, as written by "evidence export", into a node. Each evidence is
+submitted through broadcast_evidence and verified by the node. Evidence which the
+node already has is ignored. If no file is given, the evidence is read from stdin.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader = cmd.InOrStdin()
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open %s: %w", args[0], err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		client, err := rpchttp.New(evidenceRPCAddr, "/websocket")
+		if err != nil {
+			return fmt.Errorf("failed to create new http client: %w", err)
+		}
+		return importEvidence(context.Background(), client, r)
+	},
+}
+
+func init() {
+	EvidenceCmd.PersistentFlags().StringVar(&evidenceRPCAddr, "rpc-laddr", "tcp://localhost:26657",
+		"the Tendermint node's RPC address (<host>:<port>)")
+
+	ExportEvidenceCmd.Flags().BoolVar(&evidenceCommitted, "committed", false,
+		"export the committed evidence instead of the pending evidence")
+	ExportEvidenceCmd.Flags().Int64Var(&evidenceFilter.MinHeight, "min-height", 0,
+		"only export evidence from this height on")
+	ExportEvidenceCmd.Flags().Int64Var(&evidenceFilter.MaxHeight, "max-height", 0,
+		"only export evidence up to this height (0 means no limit)")
+	ExportEvidenceCmd.Flags().BytesHexVar(&evidenceValidator, "validator", nil,
+		"only export evidence against the validator with this address")
+	ExportEvidenceCmd.Flags().StringVar(&evidenceFilter.Type, "type", "",
+		"only export evidence of this type, e.g. DuplicateVoteEvidence")
+
+	EvidenceCmd.AddCommand(ExportEvidenceCmd)
+	EvidenceCmd.AddCommand(ImportEvidenceCmd)
+}
+
+// exportEvidence pages through all the pending or committed evidence of the node which matches
+// the filter.
+func exportEvidence(
+	ctx context.Context,
+	client rpcclient.EvidenceClient,
+	committed bool,
+	filter evidence.Filter,
+) ([]types.Evidence, error) {
+	list := client.PendingEvidence
+	if committed {
+		list = client.CommittedEvidence
+	}
+
+	evList := make([]types.Evidence, 0)
+	perPage := evidencePerPage
+	for page := 1; ; page++ {
+		res, err := list(ctx, filter.MinHeight, filter.MaxHeight, tmbytes.HexBytes(filter.Validator), filter.Type,
+			&page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list evidence: %w", err)
+		}
+		evList = append(evList, res.Evidence...)
+		if res.Count == 0 || len(evList) >= res.Total {
+			return evList, nil
+		}
+	}
+}
+
+// importEvidence broadcasts the evidence read from r to the node. All the evidence is submitted,
+// even if some of it is rejected.
+func importEvidence(ctx context.Context, client rpcclient.EvidenceClient, r io.Reader) error {
+	bz, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read evidence: %w", err)
+	}
+	var evList []types.Evidence
+	if err := tmjson.Unmarshal(bz, &evList); err != nil {
+		return fmt.Errorf("failed to unmarshal evidence: %w", err)
+	}
+
+	var failed int
+	for _, ev := range evList {
+		res, err := client.BroadcastEvidence(ctx, ev)
+		if err != nil {
+			failed++
+			logger.Error("Failed to import evidence", "evidence", ev, "err", err)
+			continue
+		}
+		logger.Info("Imported evidence", "hash", tmbytes.HexBytes(res.Hash), "height", ev.Height())
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d out of %d evidence", failed, len(evList))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/evidence"
+	tmbytes "github.com/lazyledger/lazyledger-core/libs/bytes"
+	tmjson "github.com/lazyledger/lazyledger-core/libs/json"
+	"github.com/lazyledger/lazyledger-core/rpc/client/mocks"
+	ctypes "github.com/lazyledger/lazyledger-core/rpc/core/types"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+func TestExportImportEvidence(t *testing.T) {
+	val := types.NewMockPV()
+	evList := make([]types.Evidence, 3)
+	for i := range evList {
+		evList[i] = types.NewMockDuplicateVoteEvidenceWithValidator(int64(i+1), time.Now().UTC(), val, "test-chain")
+	}
+	filter := evidence.Filter{MinHeight: 1, Validator: val.PrivKey.PubKey().Address()}
+
+	// the evidence is exported across several pages
+	client := &mocks.Client{}
+	pageArg := func(page int) interface{} {
+		return mock.MatchedBy(func(p *int) bool { return p != nil && *p == page })
+	}
+	for page, evs := range [][]types.Evidence{evList[:2], evList[2:]} {
+		client.On("CommittedEvidence", mock.Anything, int64(1), int64(0), tmbytes.HexBytes(filter.Validator), "",
+			pageArg(page+1), mock.Anything).
+			Return(&ctypes.ResultEvidenceList{Evidence: evs, Count: len(evs), Total: len(evList)}, nil).Once()
+	}
+	exported, err := exportEvidence(context.Background(), client, true, filter)
+	require.NoError(t, err)
+	assert.Equal(t, evList, exported)
+	client.AssertExpectations(t)
+
+	bz, err := tmjson.MarshalIndent(exported, "", "  ")
+	require.NoError(t, err)
+
+	// all the evidence is imported, even if some of it is rejected
+	client = &mocks.Client{}
+	for i, ev := range evList {
+		var err error
+		if i == 1 {
+			err = errors.New("evidence was rejected")
+		}
+		client.On("BroadcastEvidence", mock.Anything, ev).
+			Return(&ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, err).Once()
+	}
+	err = importEvidence(context.Background(), client, bytes.NewReader(bz))
+	assert.EqualError(t, err, "failed to import 1 out of 3 evidence")
+	client.AssertExpectations(t)
+
+	// the file must contain evidence
+	err = importEvidence(context.Background(), &mocks.Client{}, bytes.NewReader([]byte(`{"foo": 1}`)))
+	assert.Error(t, err)
+}
@@ -189,7 +189,7 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("could not start ipfs API: %w", err)
 		}
-		options = append(options, light.DataAvailabilitySampling(numSamples, ipfsNode.DAG))
+		options = append(options, light.DataAvailabilitySampling(numSamples, ipfsNode.DAG()))
 	case sequential:
 		options = append(options, light.SequentialVerification())
 	default:
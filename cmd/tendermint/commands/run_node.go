@@ -51,6 +51,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 			" 'persistent_kvstore',"+
 			" 'counter',"+
 			" 'counter_serial' or 'noop' for local testing.")
+	cmd.Flags().String("abci", config.ABCI, "specify abci transport (socket | grpc)")
 
 	// rpc flags
 	cmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address. Port required")
@@ -91,6 +92,11 @@ func AddNodeFlags(cmd *cobra.Command) {
 		config.DBPath,
 		"database directory")
 
+	cmd.Flags().String(
+		"ipfs.mode",
+		config.IPFS.Mode,
+		"mode the IPFS node runs in: embedded | in-process",
+	)
 	cmd.Flags().String(
 		"ipfs.repo-path",
 		config.IPFS.RepoPath,
@@ -101,6 +107,16 @@ func AddNodeFlags(cmd *cobra.Command) {
 		config.IPFS.ServeAPI,
 		"set this to expose IPFS API(useful for debugging)",
 	)
+	cmd.Flags().String(
+		"ipfs.datastore",
+		config.IPFS.Datastore,
+		"datastore backing IPFS repository: badger | badger3. Only applied on repo initialization",
+	)
+	cmd.Flags().Bool(
+		"ipfs.bridge-peers",
+		config.IPFS.BridgePeers,
+		"set this to advertise IPFS addresses to tendermint peers and dial their IPFS nodes",
+	)
 	cmd.Flags().BoolVar(
 		&initIPFS,
 		"ipfs.init",
@@ -121,12 +137,25 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 				return err
 			}
 
-			n, err := nodeProvider(
-				config,
-				ipfs.Embedded(initIPFS, config.IPFS, logger),
-				logger,
-			)
+			ipfsProvider := ipfs.Embedded(initIPFS, config.IPFS, logger)
+			// the node closes the in-process DB along with its IPFS node once it has been created
+			var ipfsDB io.Closer
+			if config.IPFS.Mode == ipfs.ModeInProcess {
+				db, err := nm.DefaultDBProvider(&nm.DBContext{ID: "ipfs", Config: config})
+				if err != nil {
+					return fmt.Errorf("failed to open ipfs db: %w", err)
+				}
+				ipfsDB = db
+				ipfsProvider = ipfs.InProcess(db, nil)
+			}
+
+			n, err := nodeProvider(config, ipfsProvider, logger)
 			if err != nil {
+				if ipfsDB != nil {
+					if err := ipfsDB.Close(); err != nil {
+						logger.Error("Failed to close ipfs db", "err", err)
+					}
+				}
 				return fmt.Errorf("failed to create node: %w", err)
 			}
 
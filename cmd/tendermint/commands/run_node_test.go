@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/lazyledger/lazyledger-core/config"
+	"github.com/lazyledger/lazyledger-core/ipfs"
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	nm "github.com/lazyledger/lazyledger-core/node"
+)
+
+func TestRunNodeClosesInProcessIPFSDB(t *testing.T) {
+	defer clearConfig(defaultRoot)
+	config = cfg.TestConfig()
+	config.SetRoot(t.TempDir())
+	config.IPFS.Mode = ipfs.ModeInProcess
+
+	failingProvider := func(*cfg.Config, ipfs.NodeProvider, log.Logger) (*nm.Node, error) {
+		return nil, errors.New("failed")
+	}
+	cmd := NewRunNodeCmd(failingProvider)
+	require.Error(t, cmd.RunE(cmd, nil))
+
+	// the DB can only be opened again once it has been closed
+	db, err := nm.DefaultDBProvider(&nm.DBContext{ID: "ipfs", Config: config})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
@@ -141,6 +141,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	if err := cfg.IPFS.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [ipfs] section: %w", err)
+	}
 	return nil
 }
 
@@ -194,7 +197,7 @@ type BaseConfig struct { //nolint: maligned
 	NodeKey string `mapstructure:"node-key-file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
-	// ABCI string `mapstructure:"abci"` // we only use in process apps
+	ABCI string `mapstructure:"abci"`
 
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
@@ -210,6 +213,7 @@ func DefaultBaseConfig() BaseConfig {
 		NodeKey:            defaultNodeKeyPath,
 		Moniker:            defaultMoniker,
 		ProxyApp:           "tcp://127.0.0.1:26658",
+		ABCI:               "socket",
 		LogLevel:           DefaultPackageLogLevels(),
 		LogFormat:          LogFormatPlain,
 		FastSyncMode:       true,
@@ -264,6 +268,11 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log format (must be 'plain' or 'json')")
 	}
+	switch cfg.ABCI {
+	case "socket", "grpc":
+	default:
+		return errors.New("unknown abci transport (must be 'socket' or 'grpc')")
+	}
 	return nil
 }
 
@@ -456,10 +465,25 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 //-----------------------------------------------------------------------------
 // P2PConfig
 
+const (
+	// P2PTransportTCP is the transport multiplexing all channels over one TCP
+	// connection
+	P2PTransportTCP = "tcp"
+	// P2PTransportQUIC is the transport sending every channel on a QUIC stream
+	// of its own
+	P2PTransportQUIC = "quic"
+)
+
 // P2PConfig defines the configuration options for the Tendermint peer-to-peer networking layer
 type P2PConfig struct { //nolint: maligned
 	RootDir string `mapstructure:"home"`
 
+	// Transport used to connect to peers
+	//   1) "tcp" (default) - multiplexes all channels over one TCP connection
+	//   2) "quic" - sends every channel on a QUIC stream of its own
+	// All peers must use the same transport.
+	Transport string `mapstructure:"transport"`
+
 	// Address to listen for incoming connections
 	ListenAddress string `mapstructure:"laddr"`
 
@@ -535,6 +559,7 @@ type P2PConfig struct { //nolint: maligned
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
+		Transport:                    P2PTransportTCP,
 		ListenAddress:                "tcp://0.0.0.0:26656",
 		ExternalAddress:              "",
 		UPNP:                         false,
@@ -573,6 +598,11 @@ func (cfg *P2PConfig) AddrBookFile() string {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case P2PTransportTCP, P2PTransportQUIC:
+	default:
+		return fmt.Errorf("unknown transport %q", cfg.Transport)
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return errors.New("max-num-inbound-peers can't be negative")
 	}
@@ -600,8 +630,20 @@ func (cfg *P2PConfig) ValidateBasic() error {
 //-----------------------------------------------------------------------------
 // MempoolConfig
 
+const (
+	// MempoolTypeFIFO is a mempool that reaps txs in the order they were received
+	MempoolTypeFIFO = "fifo"
+	// MempoolTypePriority is a mempool that reaps txs by their priority
+	MempoolTypePriority = "priority"
+)
+
 // MempoolConfig defines the configuration options for the Tendermint mempool
 type MempoolConfig struct {
+	// Type of mempool to use
+	//   1) "fifo" (default) - txs are reaped in the order they were received
+	//   2) "priority" - txs are reaped by the priority the app sets in CheckTx
+	//      and lower priority txs are evicted when the mempool is full
+	Type      string `mapstructure:"type"`
 	RootDir   string `mapstructure:"home"`
 	Recheck   bool   `mapstructure:"recheck"`
 	Broadcast bool   `mapstructure:"broadcast"`
@@ -620,11 +662,18 @@ type MempoolConfig struct {
 	// Maximum size of a batch of transactions to send to a peer
 	// Including space needed by encoding (one varint per transaction).
 	MaxBatchBytes int `mapstructure:"max-batch-bytes"`
+	// Maximum time a transaction can stay in the mempool before it is purged
+	// on the next block. 0 disables the limit.
+	TTLDuration time.Duration `mapstructure:"ttl-duration"`
+	// Maximum number of blocks a transaction can stay in the mempool before it
+	// is purged. 0 disables the limit.
+	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
+		Type:      MempoolTypeFIFO,
 		Recheck:   true,
 		Broadcast: true,
 		WalPath:   "",
@@ -658,6 +707,11 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
+	switch cfg.Type {
+	case MempoolTypeFIFO, MempoolTypePriority:
+	default:
+		return fmt.Errorf("unknown mempool type %q", cfg.Type)
+	}
 	if cfg.Size < 0 {
 		return errors.New("size can't be negative")
 	}
@@ -676,6 +730,12 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxBatchBytes <= cfg.MaxTxBytes {
 		return errors.New("max-batch-bytes can't be less or equal to max-tx-bytes")
 	}
+	if cfg.TTLDuration < 0 {
+		return errors.New("ttl-duration can't be negative")
+	}
+	if cfg.TTLNumBlocks < 0 {
+		return errors.New("ttl-num-blocks can't be negative")
+	}
 	return nil
 }
 
@@ -691,6 +751,22 @@ type StateSyncConfig struct {
 	TrustHeight   int64         `mapstructure:"trust-height"`
 	TrustHash     string        `mapstructure:"trust-hash"`
 	DiscoveryTime time.Duration `mapstructure:"discovery-time"`
+
+	// Number of most recent blocks, up to and including the snapshot height,
+	// whose headers, commits and data availability headers are restored after
+	// syncing, verified by the light client. 0 disables the restore.
+	RestoreBlocks int64 `mapstructure:"restore-blocks"`
+	// Also reconstruct the data of the restored blocks from the IPFS DAG.
+	RestoreBlockData bool `mapstructure:"restore-block-data"`
+
+	// Publish served snapshots to IPFS, and fetch chunks of snapshots with
+	// advertised chunk CIDs from IPFS before requesting them from peers.
+	IPFSChunks bool `mapstructure:"ipfs-chunks"`
+
+	// Run an independent light client with each RPC server as its primary, and
+	// one fetching light blocks from peers, and require them to agree on the
+	// state data used to bootstrap the node.
+	CrossCheck bool `mapstructure:"cross-check"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -707,6 +783,7 @@ func DefaultStateSyncConfig() *StateSyncConfig {
 	return &StateSyncConfig{
 		TrustPeriod:   168 * time.Hour,
 		DiscoveryTime: 15 * time.Second,
+		RestoreBlocks: 100,
 	}
 }
 
@@ -743,6 +820,9 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 			return fmt.Errorf("invalid trusted-hash: %w", err)
 		}
 	}
+	if cfg.RestoreBlocks < 0 {
+		return errors.New("restore-blocks can't be negative")
+	}
 	return nil
 }
 
@@ -752,6 +832,11 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 // FastSyncConfig defines the configuration for the Tendermint fast sync service
 type FastSyncConfig struct {
 	Version string `mapstructure:"version"`
+
+	// Download and verify the headers and commits ahead of the blocks in bulk,
+	// then download the blocks in parallel and verify them against their
+	// headers.
+	HeaderFirst bool `mapstructure:"header-first"`
 }
 
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
@@ -818,6 +903,16 @@ type ConsensusConfig struct {
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer-query-maj23-sleep-duration"`
 
 	DoubleSignCheckHeight int64 `mapstructure:"double-sign-check-height"`
+
+	// Number of random samples validators take from the data square of a received proposal
+	// to check that the proposer made the block data available. 0 disables the check, which
+	// is the default as every sample is retrieved from IPFS at every height.
+	DataAvailabilitySamples int `mapstructure:"data-availability-samples"`
+	// Reconstruct the whole block data from the network after successful sampling
+	DataAvailabilityReconstruct bool `mapstructure:"data-availability-reconstruct"`
+	// How long we wait for the block data to be sampled (and reconstructed) before
+	// reporting it as withheld
+	TimeoutDataAvailability time.Duration `mapstructure:"timeout-data-availability"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
@@ -837,6 +932,9 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		DataAvailabilitySamples:     0,
+		DataAvailabilityReconstruct: false,
+		TimeoutDataAvailability:     30 * time.Second,
 	}
 }
 
@@ -939,6 +1037,15 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double-sign-check-height can't be negative")
 	}
+	if cfg.DataAvailabilitySamples < 0 {
+		return errors.New("data-availability-samples can't be negative")
+	}
+	if cfg.TimeoutDataAvailability < 0 {
+		return errors.New("timeout-data-availability can't be negative")
+	}
+	if cfg.DataAvailabilitySamples > 0 && cfg.TimeoutDataAvailability == 0 {
+		return errors.New("timeout-data-availability must be positive when data availability sampling is enabled")
+	}
 	return nil
 }
 
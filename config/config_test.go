@@ -103,6 +103,11 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Transport = P2PTransportQUIC
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Transport = "udp"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -114,6 +119,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"TTLDuration",
+		"TTLNumBlocks",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -121,11 +128,22 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Type = MempoolTypePriority
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Type = "lifo"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.RestoreBlocks = 0
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.RestoreBlocks = -1
+	require.Error(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -165,6 +183,12 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"DataAvailabilitySamples negative":     {func(c *ConsensusConfig) { c.DataAvailabilitySamples = -1 }, true},
+		"TimeoutDataAvailability negative":     {func(c *ConsensusConfig) { c.TimeoutDataAvailability = -1 }, true},
+		"TimeoutDataAvailability zero": {
+			func(c *ConsensusConfig) { c.DataAvailabilitySamples, c.TimeoutDataAvailability = 15, 0 }, true},
+		"TimeoutDataAvailability zero no DAS": {
+			func(c *ConsensusConfig) { c.DataAvailabilitySamples, c.TimeoutDataAvailability = 0, 0 }, false},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
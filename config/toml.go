@@ -120,6 +120,9 @@ priv-validator-laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node-key-file = "{{ js .BaseConfig.NodeKey }}"
 
+# Mechanism to connect to the ABCI application: socket | grpc
+abci = "{{ .BaseConfig.ABCI }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter-peers = {{ .BaseConfig.FilterPeers }}
@@ -218,6 +221,12 @@ pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 #######################################################
 [p2p]
 
+# Transport used to connect to peers:
+#   1) "tcp" (default) - multiplexes all channels over one TCP connection
+#   2) "quic" - sends every channel on a QUIC stream of its own, over UDP on
+#      the laddr port. All peers must use the same transport.
+transport = "{{ .P2P.Transport }}"
+
 # Address to listen for incoming connections
 laddr = "{{ .P2P.ListenAddress }}"
 
@@ -291,6 +300,12 @@ dial-timeout = "{{ .P2P.DialTimeout }}"
 #######################################################
 [mempool]
 
+# Mempool type to use:
+#   1) "fifo" (default) - txs are reaped in the order they were received
+#   2) "priority" - txs are reaped by the priority the app sets in CheckTx and
+#      lower priority txs are evicted when the mempool is full
+type = "{{ .Mempool.Type }}"
+
 recheck = {{ .Mempool.Recheck }}
 broadcast = {{ .Mempool.Broadcast }}
 wal-dir = "{{ js .Mempool.WalPath }}"
@@ -314,6 +329,14 @@ max-tx-bytes = {{ .Mempool.MaxTxBytes }}
 # Including space needed by encoding (one varint per transaction).
 max-batch-bytes = {{ .Mempool.MaxBatchBytes }}
 
+# Maximum time a transaction can stay in the mempool. Expired transactions are
+# purged when the next block is committed. 0 disables the limit.
+ttl-duration = "{{ .Mempool.TTLDuration }}"
+
+# Maximum number of blocks a transaction can stay in the mempool. 0 disables the
+# limit.
+ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -343,6 +366,28 @@ discovery-time = "{{ .StateSync.DiscoveryTime }}"
 # Will create a new, randomly named directory within, and remove it when done.
 temp-dir = "{{ .StateSync.TempDir }}"
 
+# Number of most recent blocks, up to and including the snapshot height, whose headers, commits
+# and data availability headers are fetched and verified by the light client after restoring the
+# snapshot. This lets the node serve RPC and data availability sampling requests for recent heights
+# right away. Set to 0 to disable.
+restore-blocks = {{ .StateSync.RestoreBlocks }}
+
+# Also reconstruct the data of the restored blocks from the IPFS DAG, so they can be served in full.
+restore-block-data = {{ .StateSync.RestoreBlockData }}
+
+# Publish the snapshots served to peers as IPLD DAGs on the embedded IPFS node, advertising the chunk
+# CIDs along with the snapshots. When syncing, chunks of snapshots with advertised CIDs are fetched
+# via IPFS from any provider, falling back to requesting them from peers. This applies both to nodes
+# serving snapshots and to nodes restoring them.
+ipfs-chunks = {{ .StateSync.IPFSChunks }}
+
+# Run an independent light client with each of the RPC servers as its primary, and one fetching
+# light blocks from peers, query them in parallel and require them to agree on the app hash,
+# validator sets and consensus parameters of the snapshot. If they disagree, state sync fails and
+# evidence of the light client attack is sent to the RPC servers. Unreachable RPC servers are
+# skipped, as long as two light clients remain.
+cross-check = {{ .StateSync.CrossCheck }}
+
 #######################################################
 ###       Fast Sync Configuration Connections       ###
 #######################################################
@@ -352,6 +397,13 @@ temp-dir = "{{ .StateSync.TempDir }}"
 #   1) "v0" (default) - the legacy fast sync implementation
 version = "{{ .FastSync.Version }}"
 
+# Header-first mode: download the headers, commits and validator sets ahead of the blocks in
+# batches, verifying each batch with a single commit check if the validator set didn't change too
+# much, like the light client's skipping verification. The blocks are then downloaded from many
+# peers in parallel and each one is verified against its header and data availability header as
+# soon as it is received.
+header-first = {{ .FastSync.HeaderFirst }}
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -393,6 +445,18 @@ create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 peer-gossip-sleep-duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Number of random samples validators take from the data square of a received proposal
+# to check that the proposer made the block data available on the network.
+# Failed checks are reported as WithheldBlock events. 0 disables the check.
+# Sampling is disabled by default, as it retrieves the samples from IPFS at every
+# height. Around 15 samples give a high confidence that the data is available.
+data-availability-samples = {{ .Consensus.DataAvailabilitySamples }}
+# Reconstruct the whole block data from the network after successful sampling
+data-availability-reconstruct = {{ .Consensus.DataAvailabilityReconstruct }}
+# How long we wait for the block data to be sampled (and reconstructed)
+# before reporting it as withheld. Must be positive if sampling is enabled.
+timeout-data-availability = "{{ .Consensus.TimeoutDataAvailability }}"
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -437,8 +501,23 @@ namespace = "{{ .Instrumentation.Namespace }}"
 [ipfs]
 
 # IPFS related configuration
+# Mode the IPFS node runs in.
+# Options:
+#   1) "embedded" (default) - the full IPFS node with libp2p networking
+#   2) "in-process" - block data is only kept in the node's DB without any IPFS networking,
+#      useful for single validator deployments. Options below are ignored in this mode.
+mode = "{{ .IPFS.Mode }}"
 repo-path = "{{ .IPFS.RepoPath}}"
 serve-api = "{{ .IPFS.ServeAPI}}"
+# Datastore the IPFS repo is backed by. It is only applied on the repo initialization.
+# Options:
+#   1) "badger" (default) - Badger v1 used by IPFS by default
+#   2) "badger3" - Badger v3 shared with tendermint DBs
+datastore = "{{ .IPFS.Datastore }}"
+# If true, the libp2p addresses of the IPFS node are advertised to tendermint peers
+# and the IPFS nodes of connected tendermint peers are dialed, so block data can be
+# fetched from them directly. Requires the "embedded" mode.
+bridge-peers = {{ .IPFS.BridgePeers }}
 `
 
 /****** these are for test settings ***********/
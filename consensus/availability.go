@@ -0,0 +1,266 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/lazyledger/nmt/namespace"
+	"github.com/lazyledger/rsmt2d"
+
+	tmbytes "github.com/lazyledger/lazyledger-core/libs/bytes"
+	tmjson "github.com/lazyledger/lazyledger-core/libs/json"
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
+	"github.com/lazyledger/lazyledger-core/p2p/ipld"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// maxDataAvailabilityReports is the number of most recent heights for which
+// data availability reports are kept in memory.
+const maxDataAvailabilityReports = 100
+
+// DataAvailabilityOutcome describes the result of a single data availability check.
+type DataAvailabilityOutcome string
+
+const (
+	// DataAvailabilityPending means the check was started but has not finished yet.
+	DataAvailabilityPending DataAvailabilityOutcome = "pending"
+	// DataAvailabilitySucceeded means the check finished successfully.
+	DataAvailabilitySucceeded DataAvailabilityOutcome = "succeeded"
+	// DataAvailabilityFailed means the check failed, e.g. the data could not be
+	// found on the network in time.
+	DataAvailabilityFailed DataAvailabilityOutcome = "failed"
+)
+
+// Checks performed on the block data of a proposal. They are also used as the
+// `check` value of EventDataWithheldBlock.
+const (
+	// DataAvailabilityCheckProvide is performed by the proposer only: putting
+	// the block data to the DAG and providing its roots to the network.
+	DataAvailabilityCheckProvide = "provide"
+	// DataAvailabilityCheckSample is performed by all other validators:
+	// randomly sampling the proposal's extended data square from the network.
+	DataAvailabilityCheckSample = "sample"
+	// DataAvailabilityCheckReconstruct is optionally performed after successful
+	// sampling: retrieving enough shares to reconstruct the whole block data.
+	DataAvailabilityCheckReconstruct = "reconstruct"
+)
+
+// DataAvailabilityReport holds the outcomes of the data availability checks
+// for the proposal at a given height. Only the most recent round with a proposal is kept.
+// An empty outcome means that the corresponding check was not performed by this node.
+type DataAvailabilityReport struct {
+	Height      int64                   `json:"height"`
+	Round       int32                   `json:"round"`
+	DAHash      tmbytes.HexBytes        `json:"da_hash"`
+	Provide     DataAvailabilityOutcome `json:"provide,omitempty"`
+	Sample      DataAvailabilityOutcome `json:"sample,omitempty"`
+	Reconstruct DataAvailabilityOutcome `json:"reconstruct,omitempty"`
+	// Error of the last failed check, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// dataAvailabilityReports keeps DataAvailabilityReport's for the most recent heights.
+// It is safe for concurrent use as checks run outside of the consensus receive routine.
+type dataAvailabilityReports struct {
+	mtx     tmsync.RWMutex
+	reports map[int64]*DataAvailabilityReport
+}
+
+func newDataAvailabilityReports() *dataAvailabilityReports {
+	return &dataAvailabilityReports{
+		reports: make(map[int64]*DataAvailabilityReport),
+	}
+}
+
+// start registers a check as pending. A check started for a newer round
+// replaces the report of an older round at the same height.
+func (r *dataAvailabilityReports) start(height int64, round int32, dah *types.DataAvailabilityHeader, check string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	rep, ok := r.reports[height]
+	if !ok || rep.Round < round {
+		rep = &DataAvailabilityReport{Height: height, Round: round, DAHash: dah.Hash()}
+		r.reports[height] = rep
+		r.prune()
+	}
+	rep.setOutcome(check, DataAvailabilityPending)
+}
+
+// finish records the result of a check previously registered with start.
+// Results for outdated rounds are ignored.
+func (r *dataAvailabilityReports) finish(height int64, round int32, check string, err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	rep, ok := r.reports[height]
+	if !ok || rep.Round != round {
+		return
+	}
+	if err != nil {
+		rep.setOutcome(check, DataAvailabilityFailed)
+		rep.Error = err.Error()
+		return
+	}
+	rep.setOutcome(check, DataAvailabilitySucceeded)
+}
+
+// get returns a copy of the report for the given height.
+func (r *dataAvailabilityReports) get(height int64) (DataAvailabilityReport, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	rep, ok := r.reports[height]
+	if !ok {
+		return DataAvailabilityReport{}, false
+	}
+	return *rep, true
+}
+
+// list returns copies of all the kept reports ordered by height.
+func (r *dataAvailabilityReports) list() []DataAvailabilityReport {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	reps := make([]DataAvailabilityReport, 0, len(r.reports))
+	for _, rep := range r.reports {
+		reps = append(reps, *rep)
+	}
+	sort.Slice(reps, func(i, j int) bool { return reps[i].Height < reps[j].Height })
+	return reps
+}
+
+// prune drops the reports of the oldest heights above the limit.
+// CONTRACT: r.mtx is locked.
+func (r *dataAvailabilityReports) prune() {
+	if len(r.reports) <= maxDataAvailabilityReports {
+		return
+	}
+	heights := make([]int64, 0, len(r.reports))
+	for h := range r.reports {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	for _, h := range heights[:len(heights)-maxDataAvailabilityReports] {
+		delete(r.reports, h)
+	}
+}
+
+func (rep *DataAvailabilityReport) setOutcome(check string, outcome DataAvailabilityOutcome) {
+	switch check {
+	case DataAvailabilityCheckProvide:
+		rep.Provide = outcome
+	case DataAvailabilityCheckSample:
+		rep.Sample = outcome
+	case DataAvailabilityCheckReconstruct:
+		rep.Reconstruct = outcome
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// GetDataAvailabilityJSON returns a json of the data availability reports
+// for the most recent heights.
+func (cs *State) GetDataAvailabilityJSON() ([]byte, error) {
+	return tmjson.Marshal(cs.daReports.list())
+}
+
+// GetDataAvailabilityReport returns the data availability report for the given height.
+func (cs *State) GetDataAvailabilityReport(height int64) (DataAvailabilityReport, bool) {
+	return cs.daReports.get(height)
+}
+
+// putBlock puts the proposed block data to the DAG and provides it to the network.
+// It records the outcome and reports a failure as a withheld block.
+func (cs *State) putBlock(ctx context.Context, block *types.Block, round int32) {
+	dah := &block.DataAvailabilityHeader
+	cs.daReports.start(block.Height, round, dah, DataAvailabilityCheckProvide)
+
+	cs.Logger.Info("Putting Block to IPFS", "height", block.Height)
+	err := ipld.PutBlock(ctx, cs.dag, block, cs.croute, cs.Logger)
+	cs.daReports.finish(block.Height, round, DataAvailabilityCheckProvide, err)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			cs.Logger.Error("Putting Block didn't finish in time and was terminated", "height", block.Height)
+		} else {
+			cs.Logger.Error("Failed to put Block to IPFS", "err", err, "height", block.Height)
+		}
+		cs.reportWithheldBlock(block.Height, round, dah, DataAvailabilityCheckProvide, err)
+		return
+	}
+	cs.Logger.Info("Finished putting block to IPFS", "height", block.Height)
+}
+
+// checkAvailability samples the data behind the DAH of a received proposal and,
+// if configured, reconstructs the whole block data from the DAG.
+// It records the outcomes and reports any failure as a withheld block.
+func (cs *State) checkAvailability(ctx context.Context, proposal *types.Proposal) {
+	height, round, dah := proposal.Height, proposal.Round, proposal.DAHeader
+	logger := cs.Logger.With("height", height, "round", round)
+
+	// Proposals received from peers are validated already, but sampling a malformed
+	// DAH would panic outside of the receive routine, so check it again.
+	if err := dah.ValidateBasic(); err != nil {
+		logger.Error("Can't sample the data of a proposal with an invalid DAHeader", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cs.config.TimeoutDataAvailability)
+	defer cancel()
+
+	squareWidth := len(dah.RowsRoots)
+	numSamples := cs.config.DataAvailabilitySamples
+	if numSamples > squareWidth*squareWidth {
+		numSamples = squareWidth * squareWidth
+	}
+
+	cs.daReports.start(height, round, dah, DataAvailabilityCheckSample)
+	start := time.Now()
+	err := ipld.ValidateAvailability(ctx, cs.dag, dah, numSamples, func(namespace.PrefixedData8) {})
+	cs.daReports.finish(height, round, DataAvailabilityCheckSample, err)
+	if err != nil {
+		logger.Error("Data availability sampling of the proposal failed", "err", err)
+		cs.reportWithheldBlock(height, round, dah, DataAvailabilityCheckSample, err)
+		return
+	}
+	logger.Info("Data availability sampling of the proposal succeeded",
+		"numSamples", numSamples, "elapsed", time.Since(start))
+
+	if !cs.config.DataAvailabilityReconstruct {
+		return
+	}
+
+	cs.daReports.start(height, round, dah, DataAvailabilityCheckReconstruct)
+	_, err = ipld.RetrieveBlockData(ctx, dah, cs.dag, rsmt2d.NewRSGF8Codec())
+	cs.daReports.finish(height, round, DataAvailabilityCheckReconstruct, err)
+	if err != nil {
+		logger.Error("Reconstruction of the proposal data failed", "err", err)
+		cs.reportWithheldBlock(height, round, dah, DataAvailabilityCheckReconstruct, err)
+		return
+	}
+	logger.Info("Reconstruction of the proposal data succeeded", "elapsed", time.Since(start))
+}
+
+func (cs *State) reportWithheldBlock(
+	height int64,
+	round int32,
+	dah *types.DataAvailabilityHeader,
+	check string,
+	err error,
+) {
+	cs.metrics.WithheldBlocks.With("check", check).Add(1)
+
+	if cs.eventBus == nil {
+		return
+	}
+	data := types.EventDataWithheldBlock{
+		Height: height,
+		Round:  round,
+		DAHash: dah.Hash(),
+		Check:  check,
+		Error:  err.Error(),
+	}
+	if err := cs.eventBus.PublishEventWithheldBlock(data); err != nil {
+		cs.Logger.Error("Error publishing withheld block", "err", err)
+	}
+}
@@ -0,0 +1,167 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/p2p/ipld"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+func TestDataAvailabilityReports(t *testing.T) {
+	reports := newDataAvailabilityReports()
+	dah := &types.DataAvailabilityHeader{}
+
+	reports.start(1, 0, dah, DataAvailabilityCheckSample)
+	rep, ok := reports.get(1)
+	require.True(t, ok)
+	assert.Equal(t, DataAvailabilityPending, rep.Sample)
+	assert.Empty(t, rep.Provide)
+	assert.EqualValues(t, dah.Hash(), rep.DAHash)
+
+	reports.finish(1, 0, DataAvailabilityCheckSample, nil)
+	rep, _ = reports.get(1)
+	assert.Equal(t, DataAvailabilitySucceeded, rep.Sample)
+
+	// a newer round replaces the report
+	reports.start(1, 1, dah, DataAvailabilityCheckSample)
+	// results of the outdated round are ignored
+	reports.finish(1, 0, DataAvailabilityCheckSample, nil)
+	rep, _ = reports.get(1)
+	assert.EqualValues(t, 1, rep.Round)
+	assert.Equal(t, DataAvailabilityPending, rep.Sample)
+
+	reports.finish(1, 1, DataAvailabilityCheckSample, errors.New("withheld"))
+	rep, _ = reports.get(1)
+	assert.Equal(t, DataAvailabilityFailed, rep.Sample)
+	assert.Equal(t, "withheld", rep.Error)
+
+	// only the most recent heights are kept
+	for h := int64(2); h <= maxDataAvailabilityReports+1; h++ {
+		reports.start(h, 0, dah, DataAvailabilityCheckProvide)
+	}
+	_, ok = reports.get(1)
+	assert.False(t, ok)
+	list := reports.list()
+	require.Len(t, list, maxDataAvailabilityReports)
+	assert.EqualValues(t, 2, list[0].Height)
+	assert.EqualValues(t, maxDataAvailabilityReports+1, list[len(list)-1].Height)
+}
+
+func TestStateProposerRecordsProvide(t *testing.T) {
+	cs1, _ := randState(1)
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	startTestRound(cs1, height, round)
+	ensureNewProposal(proposalCh, height, round)
+
+	// putting the block to the mock DAG happens in the background
+	assert.Eventually(t, func() bool {
+		rep, ok := cs1.GetDataAvailabilityReport(height)
+		return ok && rep.Provide == DataAvailabilitySucceeded
+	}, ensureTimeout, 10*time.Millisecond)
+}
+
+func TestStateCheckAvailability(t *testing.T) {
+	cs1, _ := randState(1)
+	consensusConfig := *cs1.config
+	consensusConfig.DataAvailabilitySamples = 4
+	consensusConfig.DataAvailabilityReconstruct = true
+	consensusConfig.TimeoutDataAvailability = time.Second
+	cs1.config = &consensusConfig
+
+	withheldCh := subscribe(cs1.eventBus, types.EventQueryWithheldBlock)
+
+	block, parts := cs1.createProposalBlock()
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	proposal := types.NewProposal(block.Height, 0, -1, blockID, &block.DataAvailabilityHeader)
+
+	// the block data is available on the network
+	require.NoError(t, ipld.PutBlock(context.Background(), cs1.dag, block, cs1.croute, cs1.Logger))
+	cs1.checkAvailability(context.Background(), proposal)
+
+	rep, ok := cs1.GetDataAvailabilityReport(block.Height)
+	require.True(t, ok)
+	assert.Equal(t, DataAvailabilitySucceeded, rep.Sample)
+	assert.Equal(t, DataAvailabilitySucceeded, rep.Reconstruct)
+	assert.Empty(t, rep.Error)
+	select {
+	case msg := <-withheldCh:
+		t.Fatalf("unexpected withheld block %v", msg.Data())
+	default:
+	}
+}
+
+func TestStateCheckAvailabilityWithheld(t *testing.T) {
+	cs1, _ := randState(1)
+	consensusConfig := *cs1.config
+	consensusConfig.DataAvailabilitySamples = 4
+	consensusConfig.DataAvailabilityReconstruct = true
+	consensusConfig.TimeoutDataAvailability = 100 * time.Millisecond
+	cs1.config = &consensusConfig
+
+	withheldCh := subscribe(cs1.eventBus, types.EventQueryWithheldBlock)
+
+	// the block data is never put to the DAG
+	block, parts := cs1.createProposalBlock()
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	withheld := types.NewProposal(block.Height, 1, -1, blockID, &block.DataAvailabilityHeader)
+	cs1.checkAvailability(context.Background(), withheld)
+
+	rep, ok := cs1.GetDataAvailabilityReport(block.Height)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, rep.Round)
+	assert.Equal(t, DataAvailabilityFailed, rep.Sample)
+	assert.Empty(t, rep.Reconstruct, "reconstruction must not be attempted after failed sampling")
+	assert.NotEmpty(t, rep.Error)
+
+	select {
+	case msg := <-withheldCh:
+		data := msg.Data().(types.EventDataWithheldBlock)
+		assert.Equal(t, block.Height, data.Height)
+		assert.EqualValues(t, 1, data.Round)
+		assert.Equal(t, DataAvailabilityCheckSample, data.Check)
+		assert.EqualValues(t, block.DataAvailabilityHeader.Hash(), data.DAHash)
+	case <-time.After(ensureTimeout):
+		t.Fatal("expected a withheld block event")
+	}
+}
+
+func TestStateCheckAvailabilityMalformedDAHeader(t *testing.T) {
+	cs1, _ := randState(1)
+	consensusConfig := *cs1.config
+	consensusConfig.DataAvailabilitySamples = 16
+	consensusConfig.TimeoutDataAvailability = 100 * time.Millisecond
+	cs1.config = &consensusConfig
+
+	block, parts := cs1.createProposalBlock()
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	roots := block.DataAvailabilityHeader.RowsRoots
+	threeRoots := types.NmtRoots{roots[0], roots[1], roots[0]}
+
+	testCases := []struct {
+		name string
+		dah  *types.DataAvailabilityHeader
+	}{
+		{"no roots", &types.DataAvailabilityHeader{}},
+		{"mismatched roots", &types.DataAvailabilityHeader{RowsRoots: threeRoots, ColumnRoots: roots}},
+		{"width not a power of two", &types.DataAvailabilityHeader{RowsRoots: threeRoots, ColumnRoots: threeRoots}},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		proposal := types.NewProposal(block.Height, int32(i), -1, blockID, tc.dah)
+		require.Error(t, proposal.DAHeader.ValidateBasic(), tc.name)
+
+		// must not panic
+		require.NotPanics(t, func() { cs1.checkAvailability(context.Background(), proposal) }, tc.name)
+		_, ok := cs1.GetDataAvailabilityReport(block.Height)
+		assert.False(t, ok, "sampling must not be attempted for %s", tc.name)
+	}
+}
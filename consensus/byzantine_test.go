@@ -19,7 +19,6 @@ import (
 	"github.com/lazyledger/lazyledger-core/ipfs"
 	"github.com/lazyledger/lazyledger-core/libs/db/memdb"
 	"github.com/lazyledger/lazyledger-core/libs/log"
-	"github.com/lazyledger/lazyledger-core/libs/service"
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	mempl "github.com/lazyledger/lazyledger-core/mempool"
 	"github.com/lazyledger/lazyledger-core/p2p"
@@ -99,12 +98,13 @@ func TestByzantinePrevoteEquivocation(t *testing.T) {
 	}
 
 	// initialize the reactors for each of the validators
-	reactors := make([]*Reactor, nValidators)
+	reactors := make([]*testReactor, nValidators)
+	shims := make([]*p2p.ReactorShim, nValidators)
 	blocksSubs := make([]types.Subscription, 0)
 	eventBuses := make([]*types.EventBus, nValidators)
 	for i := 0; i < nValidators; i++ {
-		reactors[i] = NewReactor(css[i], true) // so we dont start the consensus states
-		reactors[i].SetLogger(css[i].Logger)
+		reactor, shim := newReactorWithShim(css[i], true) // so we dont start the consensus states
+		reactors[i], shims[i] = &testReactor{Reactor: reactor}, shim
 
 		// eventBus is already started with the cs
 		eventBuses[i] = css[i].eventBus
@@ -118,11 +118,15 @@ func TestByzantinePrevoteEquivocation(t *testing.T) {
 			err = css[i].blockExec.Store().Save(css[i].state)
 			require.NoError(t, err)
 		}
+
+		// the reactors must be running before their switches add any peers
+		require.NoError(t, reactors[i].Start())
 	}
-	// make connected switches and start all reactors
+	// make connected switches and start all reactor shims
 	p2p.MakeConnectedSwitches(config.P2P, nValidators, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("CONSENSUS", reactors[i])
+		s.AddReactor("CONSENSUS", shims[i])
 		s.SetLogger(reactors[i].conS.Logger.With("module", "p2p"))
+		reactors[i].sw = s
 		return s
 	}, p2p.Connect2Switches)
 
@@ -138,16 +142,16 @@ func TestByzantinePrevoteEquivocation(t *testing.T) {
 			require.NoError(t, err)
 			prevote2, err := bcs.signVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
 			require.NoError(t, err)
-			peerList := reactors[byzantineNode].Switch.Peers().List()
+			peerList := reactors[byzantineNode].sw.Peers().List()
 			bcs.Logger.Info("Getting peer list", "peers", peerList)
 			// send two votes to all peers (1st to one half, 2nd to another half)
 			for i, peer := range peerList {
 				if i < len(peerList)/2 {
 					bcs.Logger.Info("Signed and pushed vote", "vote", prevote1, "peer", peer)
-					peer.Send(VoteChannel, MustEncode(&VoteMessage{prevote1}))
+					peer.Send(byte(VoteChannel), MustEncode(&VoteMessage{prevote1}))
 				} else {
 					bcs.Logger.Info("Signed and pushed vote", "vote", prevote2, "peer", peer)
-					peer.Send(VoteChannel, MustEncode(&VoteMessage{prevote2}))
+					peer.Send(byte(VoteChannel), MustEncode(&VoteMessage{prevote2}))
 				}
 			}
 		} else {
@@ -245,7 +249,8 @@ func TestByzantineConflictingProposalsWithPartition(t *testing.T) {
 	}
 
 	blocksSubs := make([]types.Subscription, N)
-	reactors := make([]p2p.Reactor, N)
+	reactors := make([]*Reactor, N)
+	shims := make([]*p2p.ReactorShim, N)
 	for i := 0; i < N; i++ {
 
 		// enable txs so we can create different proposals
@@ -272,37 +277,26 @@ func TestByzantineConflictingProposalsWithPartition(t *testing.T) {
 		blocksSubs[i], err = eventBus.Subscribe(context.Background(), testSubscriber, types.EventQueryNewBlock)
 		require.NoError(t, err)
 
-		conR := NewReactor(css[i], true) // so we don't start the consensus states
-		conR.SetLogger(logger.With("validator", i))
-		conR.SetEventBus(eventBus)
-
-		var conRI p2p.Reactor = conR
-
-		// make first val byzantine
-		if i == 0 {
-			conRI = NewByzantineReactor(conR)
-		}
+		reactors[i], shims[i] = newReactorWithShim(css[i], true) // so we don't start the consensus states
+		reactors[i].SetEventBus(eventBus)
 
-		reactors[i] = conRI
 		err = css[i].blockExec.Store().Save(css[i].state) // for save height 1's validators info
 		require.NoError(t, err)
+
+		// the reactors must be running before their switches add any peers
+		require.NoError(t, reactors[i].Start())
 	}
 
 	defer func() {
-		for _, r := range reactors {
-			if rr, ok := r.(*ByzantineReactor); ok {
-				err := rr.reactor.Switch.Stop()
-				require.NoError(t, err)
-			} else {
-				err := r.(*Reactor).Switch.Stop()
-				require.NoError(t, err)
-			}
+		for i, r := range reactors {
+			require.NoError(t, switches[i].Stop())
+			require.NoError(t, r.Stop())
 		}
 	}()
 
 	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
 		// ignore new switch s, we already made ours
-		switches[i].AddReactor("CONSENSUS", reactors[i])
+		switches[i].AddReactor("CONSENSUS", shims[i])
 		return switches[i]
 	}, func(sws []*p2p.Switch, i, j int) {
 		// the network starts partitioned with globally active adversary
@@ -315,14 +309,14 @@ func TestByzantineConflictingProposalsWithPartition(t *testing.T) {
 	// start the non-byz state machines.
 	// note these must be started before the byz
 	for i := 1; i < N; i++ {
-		cr := reactors[i].(*Reactor)
+		cr := reactors[i]
 		cr.SwitchToConsensus(cr.conS.GetState(), false)
 	}
 
 	// start the byzantine state machine
-	byzR := reactors[0].(*ByzantineReactor)
-	s := byzR.reactor.conS.GetState()
-	byzR.reactor.SwitchToConsensus(s, false)
+	byzR := reactors[0]
+	s := byzR.conS.GetState()
+	byzR.SwitchToConsensus(s, false)
 
 	// byz proposer sends one block to peers[0]
 	// and the other block to peers[1] and peers[2].
@@ -433,7 +427,7 @@ func sendProposalAndParts(
 ) {
 	// proposal
 	msg := &ProposalMessage{Proposal: proposal}
-	peer.Send(DataChannel, MustEncode(msg))
+	peer.Send(byte(DataChannel), MustEncode(msg))
 
 	// parts
 	for i := 0; i < int(parts.Total()); i++ {
@@ -443,7 +437,7 @@ func sendProposalAndParts(
 			Round:  round,  // This tells peer that this part applies to us.
 			Part:   part,
 		}
-		peer.Send(DataChannel, MustEncode(msg))
+		peer.Send(byte(DataChannel), MustEncode(msg))
 	}
 
 	// votes
@@ -452,46 +446,6 @@ func sendProposalAndParts(
 	precommit, _ := cs.signVote(tmproto.PrecommitType, blockHash, parts.Header())
 	cs.mtx.Unlock()
 
-	peer.Send(VoteChannel, MustEncode(&VoteMessage{prevote}))
-	peer.Send(VoteChannel, MustEncode(&VoteMessage{precommit}))
-}
-
-//----------------------------------------
-// byzantine consensus reactor
-
-type ByzantineReactor struct {
-	service.Service
-	reactor *Reactor
-}
-
-func NewByzantineReactor(conR *Reactor) *ByzantineReactor {
-	return &ByzantineReactor{
-		Service: conR,
-		reactor: conR,
-	}
-}
-
-func (br *ByzantineReactor) SetSwitch(s *p2p.Switch)               { br.reactor.SetSwitch(s) }
-func (br *ByzantineReactor) GetChannels() []*p2p.ChannelDescriptor { return br.reactor.GetChannels() }
-func (br *ByzantineReactor) AddPeer(peer p2p.Peer) {
-	if !br.reactor.IsRunning() {
-		return
-	}
-
-	// Create peerState for peer
-	peerState := NewPeerState(peer).SetLogger(br.reactor.Logger)
-	peer.Set(types.PeerStateKey, peerState)
-
-	// Send our state to peer.
-	// If we're syncing, broadcast a RoundStepMessage later upon SwitchToConsensus().
-	if !br.reactor.waitSync {
-		br.reactor.sendNewRoundStepMessage(peer)
-	}
-}
-func (br *ByzantineReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
-	br.reactor.RemovePeer(peer, reason)
-}
-func (br *ByzantineReactor) Receive(chID byte, peer p2p.Peer, msgBytes []byte) {
-	br.reactor.Receive(chID, peer, msgBytes)
+	peer.Send(byte(VoteChannel), MustEncode(&VoteMessage{prevote}))
+	peer.Send(byte(VoteChannel), MustEncode(&VoteMessage{precommit}))
 }
-func (br *ByzantineReactor) InitPeer(peer p2p.Peer) p2p.Peer { return peer }
@@ -44,7 +44,7 @@ func TestReactorInvalidPrecommit(t *testing.T) {
 	byzVal.mtx.Lock()
 	pv := byzVal.privValidator
 	byzVal.doPrevote = func(height int64, round int32) {
-		invalidDoPrevoteFunc(t, height, round, byzVal, byzR.Switch, pv)
+		invalidDoPrevoteFunc(t, height, round, byzVal, byzR.sw, pv)
 	}
 	byzVal.mtx.Unlock()
 	t.Cleanup(func() { stopConsensusNet(log.TestingLogger(), reactors, eventBuses) })
@@ -98,7 +98,7 @@ func invalidDoPrevoteFunc(t *testing.T, height int64, round int32, cs *State, sw
 		peers := sw.Peers().List()
 		for _, peer := range peers {
 			cs.Logger.Info("Sending bad vote", "block", blockHash, "peer", peer)
-			peer.Send(VoteChannel, MustEncode(&VoteMessage{precommit}))
+			peer.Send(byte(VoteChannel), MustEncode(&VoteMessage{precommit}))
 		}
 	}()
 }
@@ -60,6 +60,9 @@ type Metrics struct {
 
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
+
+	// Number of proposals whose block data failed a data availability check.
+	WithheldBlocks metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -186,6 +189,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		WithheldBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "withheld_blocks",
+			Help:      "Number of proposals whose block data failed a data availability check.",
+		}, append(labels, "check")).With(labelsAndValues...),
 	}
 }
 
@@ -216,5 +225,6 @@ func NopMetrics() *Metrics {
 		FastSyncing:     discard.NewGauge(),
 		StateSyncing:    discard.NewGauge(),
 		BlockParts:      discard.NewCounter(),
+		WithheldBlocks:  discard.NewCounter(),
 	}
 }
@@ -3,7 +3,6 @@ package consensus
 import (
 	"errors"
 	"fmt"
-	"reflect"
 	"sync"
 	"time"
 
@@ -14,6 +13,7 @@ import (
 	tmevents "github.com/lazyledger/lazyledger-core/libs/events"
 	tmjson "github.com/lazyledger/lazyledger-core/libs/json"
 	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/libs/service"
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
 	tmcons "github.com/lazyledger/lazyledger-core/proto/tendermint/consensus"
@@ -23,11 +23,68 @@ import (
 	tmtime "github.com/lazyledger/lazyledger-core/types/time"
 )
 
+var (
+	_ service.Service = (*Reactor)(nil)
+	_ p2p.Wrapper     = (*tmcons.Message)(nil)
+
+	// ChannelShims contains a map of ChannelDescriptorShim objects, where each
+	// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+	// p2p proto.Message the new p2p Channel is responsible for handling.
+	//
+	//
+	// TODO: Remove once p2p refactor is complete.
+	// ref: https://github.com/tendermint/tendermint/issues/5670
+	ChannelShims = map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		StateChannel: {
+			MsgType: new(tmcons.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(StateChannel),
+				Priority:            5,
+				SendQueueCapacity:   100,
+				RecvMessageCapacity: maxMsgSize,
+			},
+		},
+		DataChannel: {
+			MsgType: new(tmcons.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				// TODO: Consider a split between gossiping current block and catchup
+				// stuff. Once we gossip the whole block there is nothing left to send
+				// until next height or round.
+				ID:                  byte(DataChannel),
+				Priority:            10,
+				SendQueueCapacity:   100,
+				RecvBufferCapacity:  50 * 4096,
+				RecvMessageCapacity: maxMsgSize,
+			},
+		},
+		VoteChannel: {
+			MsgType: new(tmcons.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(VoteChannel),
+				Priority:            5,
+				SendQueueCapacity:   100,
+				RecvBufferCapacity:  100 * 100,
+				RecvMessageCapacity: maxMsgSize,
+			},
+		},
+		VoteSetBitsChannel: {
+			MsgType: new(tmcons.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(VoteSetBitsChannel),
+				Priority:            1,
+				SendQueueCapacity:   2,
+				RecvBufferCapacity:  1024,
+				RecvMessageCapacity: maxMsgSize,
+			},
+		},
+	}
+)
+
 const (
-	StateChannel       = byte(0x20)
-	DataChannel        = byte(0x21)
-	VoteChannel        = byte(0x22)
-	VoteSetBitsChannel = byte(0x23)
+	StateChannel       = p2p.ChannelID(0x20)
+	DataChannel        = p2p.ChannelID(0x21)
+	VoteChannel        = p2p.ChannelID(0x22)
+	VoteSetBitsChannel = p2p.ChannelID(0x23)
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
@@ -39,28 +96,66 @@ const (
 
 // Reactor defines a reactor for the consensus service.
 type Reactor struct {
-	p2p.BaseReactor // BaseService + p2p.Switch
+	service.BaseService
 
 	conS *State
 
 	mtx      tmsync.RWMutex
+	peers    map[string]*PeerState
 	waitSync bool
 	eventBus *types.EventBus
 
 	Metrics *Metrics
+
+	stateCh       *p2p.Channel
+	dataCh        *p2p.Channel
+	voteCh        *p2p.Channel
+	voteSetBitsCh *p2p.Channel
+	peerUpdates   *p2p.PeerUpdatesCh
+
+	// NOTE: We need a dedicated stateCloseCh channel for signaling closure of
+	// the StateChannel due to the fact that the StateChannel message handler
+	// performs a send on the VoteSetBitsChannel. Closing the StateChannel
+	// first ensures nothing sends on the VoteSetBitsChannel once it is closed.
+	stateCloseCh chan struct{}
+	closeCh      chan struct{}
+
+	// peerWG tracks the gossip goroutines of all peers, including the ones
+	// already removed, since they may still be sending on the p2p Channels.
+	peerWG sync.WaitGroup
 }
 
 type ReactorOption func(*Reactor)
 
-// NewReactor returns a new Reactor with the given
-// consensusState.
-func NewReactor(consensusState *State, waitSync bool, options ...ReactorOption) *Reactor {
+// NewReactor returns a reference to a new consensus reactor, which implements
+// the service.Service interface. It accepts a logger, the consensus state,
+// references to the four consensus p2p Channels and a channel to listen for
+// peer updates on. Note, the reactor will close all p2p Channels when stopping.
+func NewReactor(
+	logger log.Logger,
+	consensusState *State,
+	stateCh *p2p.Channel,
+	dataCh *p2p.Channel,
+	voteCh *p2p.Channel,
+	voteSetBitsCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdatesCh,
+	waitSync bool,
+	options ...ReactorOption,
+) *Reactor {
 	conR := &Reactor{
-		conS:     consensusState,
-		waitSync: waitSync,
-		Metrics:  NopMetrics(),
-	}
-	conR.BaseReactor = *p2p.NewBaseReactor("Consensus", conR)
+		conS:          consensusState,
+		waitSync:      waitSync,
+		peers:         make(map[string]*PeerState),
+		Metrics:       NopMetrics(),
+		stateCh:       stateCh,
+		dataCh:        dataCh,
+		voteCh:        voteCh,
+		voteSetBitsCh: voteSetBitsCh,
+		peerUpdates:   peerUpdates,
+		stateCloseCh:  make(chan struct{}),
+		closeCh:       make(chan struct{}),
+	}
+	conR.BaseService = *service.NewBaseService(logger, "Consensus", conR)
 
 	for _, option := range options {
 		option(conR)
@@ -69,8 +164,12 @@ func NewReactor(consensusState *State, waitSync bool, options ...ReactorOption)
 	return conR
 }
 
-// OnStart implements BaseService by subscribing to events, which later will be
-// broadcasted to other peers and starting state if we're not in fast sync.
+// OnStart starts separate go routines for each p2p Channel and listens for
+// envelopes on each. In addition, it also listens for peer updates and handles
+// messages on that p2p channel accordingly. It also subscribes to events,
+// which later will be broadcasted to other peers, and starts the state if
+// we're not in fast sync. The caller must be sure to execute OnStop to ensure
+// the outbound p2p Channels are closed.
 func (conR *Reactor) OnStart() error {
 	conR.Logger.Info("Reactor ", "waitSync", conR.WaitSync())
 
@@ -86,11 +185,18 @@ func (conR *Reactor) OnStart() error {
 		}
 	}
 
+	go conR.processStateCh()
+	go conR.processDataCh()
+	go conR.processVoteCh()
+	go conR.processVoteSetBitsCh()
+	go conR.processPeerUpdates()
+
 	return nil
 }
 
-// OnStop implements BaseService by unsubscribing from events and stopping
-// state.
+// OnStop stops the reactor by unsubscribing from events and stopping the
+// state. It then signals all spawned goroutines to exit and blocks until
+// they all exit.
 func (conR *Reactor) OnStop() {
 	conR.unsubscribeFromBroadcastEvents()
 	if err := conR.conS.Stop(); err != nil {
@@ -99,6 +205,33 @@ func (conR *Reactor) OnStop() {
 	if !conR.WaitSync() {
 		conR.conS.Wait()
 	}
+
+	conR.mtx.Lock()
+	for _, ps := range conR.peers {
+		ps.closer.Close()
+	}
+	conR.mtx.Unlock()
+
+	// Wait for all spawned peer gossip goroutines to gracefully exit before
+	// closing the p2p Channels they send on.
+	conR.peerWG.Wait()
+
+	// Close the StateChannel goroutine separately since it uses its own channel
+	// to signal closure.
+	close(conR.stateCloseCh)
+	<-conR.stateCh.Done()
+
+	// Close closeCh to signal to all spawned goroutines to gracefully exit. All
+	// p2p Channels should execute Close().
+	close(conR.closeCh)
+
+	// Wait for all p2p Channels to be closed before returning. This ensures we
+	// can easily reason about synchronization of all p2p Channels and ensure no
+	// panics will occur.
+	<-conR.dataCh.Done()
+	<-conR.voteCh.Done()
+	<-conR.voteSetBitsCh.Done()
+	<-conR.peerUpdates.Done()
 }
 
 // SwitchToConsensus switches from fast_sync mode to consensus mode.
@@ -136,152 +269,218 @@ conR:
 	}
 }
 
-// GetChannels implements Reactor
-func (conR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
-	// TODO optimize
-	return []*p2p.ChannelDescriptor{
-		{
-			ID:                  StateChannel,
-			Priority:            5,
-			SendQueueCapacity:   100,
-			RecvMessageCapacity: maxMsgSize,
-		},
-		{
-			ID: DataChannel, // maybe split between gossiping current block and catchup stuff
-			// once we gossip the whole block there's nothing left to send until next height or round
-			Priority:            10,
-			SendQueueCapacity:   100,
-			RecvBufferCapacity:  50 * 4096,
-			RecvMessageCapacity: maxMsgSize,
-		},
-		{
-			ID:                  VoteChannel,
-			Priority:            5,
-			SendQueueCapacity:   100,
-			RecvBufferCapacity:  100 * 100,
-			RecvMessageCapacity: maxMsgSize,
-		},
-		{
-			ID:                  VoteSetBitsChannel,
-			Priority:            1,
-			SendQueueCapacity:   2,
-			RecvBufferCapacity:  1024,
-			RecvMessageCapacity: maxMsgSize,
-		},
-	}
+// GetPeerState returns the PeerState of the given peer, if the peer is known.
+func (conR *Reactor) GetPeerState(peerID p2p.PeerID) (*PeerState, bool) {
+	conR.mtx.RLock()
+	defer conR.mtx.RUnlock()
+
+	ps, ok := conR.peers[peerID.String()]
+	return ps, ok
 }
 
-// InitPeer implements Reactor by creating a state for the peer.
-func (conR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
-	peerState := NewPeerState(peer).SetLogger(conR.Logger)
-	peer.Set(types.PeerStateKey, peerState)
-	return peer
+// GetHeight returns the latest height the given peer is known to be at, or 0
+// if the peer is not known. It is used by the mempool and evidence reactors to
+// ensure peers are caught up before sending them txs or evidence.
+func (conR *Reactor) GetHeight(peerID p2p.PeerID) int64 {
+	ps, ok := conR.GetPeerState(peerID)
+	if !ok {
+		return 0
+	}
+	return ps.GetHeight()
 }
 
-// AddPeer implements Reactor by spawning multiple gossiping goroutines for the
-// peer.
-func (conR *Reactor) AddPeer(peer p2p.Peer) {
-	if !conR.IsRunning() {
-		return
+// processPeerUpdate processes a PeerUpdate. For new or live peers it creates
+// a PeerState and starts the gossiping goroutines for the peer, which are
+// stopped once the peer goes down.
+func (conR *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	conR.Logger.Debug("received peer update", "peer", peerUpdate.PeerID.String(), "status", peerUpdate.Status)
+
+	conR.mtx.Lock()
+	defer conR.mtx.Unlock()
+
+	peerKey := peerUpdate.PeerID.String()
+
+	switch peerUpdate.Status {
+	case p2p.PeerStatusNew, p2p.PeerStatusUp:
+		// Do not allow starting new gossip loops after reactor shutdown has been
+		// initiated. This can happen after we've manually closed all peer gossip
+		// loops, but the router still sends in-flight peer updates.
+		if !conR.IsRunning() {
+			return
+		}
+
+		if _, ok := conR.peers[peerKey]; ok {
+			return
+		}
+
+		ps := NewPeerState(peerUpdate.PeerID).SetLogger(conR.Logger)
+		conR.peers[peerKey] = ps
+
+		// Begin routines for this peer.
+		conR.peerWG.Add(3)
+		go conR.gossipDataRoutine(ps)
+		go conR.gossipVotesRoutine(ps)
+		go conR.queryMaj23Routine(ps)
+
+	case p2p.PeerStatusDown, p2p.PeerStatusRemoved, p2p.PeerStatusBanned:
+		if ps, ok := conR.peers[peerKey]; ok {
+			ps.closer.Close()
+			delete(conR.peers, peerKey)
+		}
 	}
+}
 
-	peerState, ok := peer.Get(types.PeerStateKey).(*PeerState)
-	if !ok {
-		panic(fmt.Sprintf("peer %v has no state", peer))
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. When the reactor is stopped, we will catch the signal and
+// close the p2p PeerUpdatesCh gracefully.
+func (conR *Reactor) processPeerUpdates() {
+	defer conR.peerUpdates.Close()
+
+	for {
+		select {
+		case peerUpdate := <-conR.peerUpdates.Updates():
+			conR.processPeerUpdate(peerUpdate)
+
+		case <-conR.closeCh:
+			conR.Logger.Debug("stopped listening on peer updates channel; closing...")
+			return
+		}
 	}
-	// Begin routines for this peer.
-	go conR.gossipDataRoutine(peer, peerState)
-	go conR.gossipVotesRoutine(peer, peerState)
-	go conR.queryMaj23Routine(peer, peerState)
+}
 
-	// Send our state to peer.
-	// If we're fast_syncing, broadcast a RoundStepMessage later upon SwitchToConsensus().
-	if !conR.WaitSync() {
-		conR.sendNewRoundStepMessage(peer)
+// handleStateMessage handles envelopes sent from peers on the StateChannel.
+// An error is returned if the message is invalid for the peer. This should
+// never be called outside of handleMessage.
+func (conR *Reactor) handleStateMessage(envelope p2p.Envelope, msg Message, ps *PeerState) error {
+	switch msg := msg.(type) {
+	case *NewRoundStepMessage:
+		conR.conS.mtx.Lock()
+		initialHeight := conR.conS.state.InitialHeight
+		conR.conS.mtx.Unlock()
+		if err := msg.ValidateHeight(initialHeight); err != nil {
+			return err
+		}
+		ps.ApplyNewRoundStepMessage(msg)
+
+	case *NewValidBlockMessage:
+		ps.ApplyNewValidBlockMessage(msg)
+
+	case *HasVoteMessage:
+		ps.ApplyHasVoteMessage(msg)
+
+	case *VoteSetMaj23Message:
+		cs := conR.conS
+		cs.mtx.Lock()
+		height, votes := cs.Height, cs.Votes
+		cs.mtx.Unlock()
+		if height != msg.Height {
+			return nil
+		}
+		// Peer claims to have a maj23 for some BlockID at H,R,S,
+		err := votes.SetPeerMaj23(msg.Round, msg.Type, p2p.ID(envelope.From.String()), msg.BlockID)
+		if err != nil {
+			return err
+		}
+		// Respond with a VoteSetBitsMessage showing which votes we have.
+		// (and consequently shows which we don't have)
+		var ourVotes *bits.BitArray
+		switch msg.Type {
+		case tmproto.PrevoteType:
+			ourVotes = votes.Prevotes(msg.Round).BitArrayByBlockID(msg.BlockID)
+		case tmproto.PrecommitType:
+			ourVotes = votes.Precommits(msg.Round).BitArrayByBlockID(msg.BlockID)
+		default:
+			panic("Bad VoteSetBitsMessage field Type. Forgot to add a check in ValidateBasic?")
+		}
+		conR.voteSetBitsCh.Out() <- p2p.Envelope{
+			To: envelope.From,
+			Message: mustProto(&VoteSetBitsMessage{
+				Height:  msg.Height,
+				Round:   msg.Round,
+				Type:    msg.Type,
+				BlockID: msg.BlockID,
+				Votes:   ourVotes,
+			}),
+		}
+
+	default:
+		return fmt.Errorf("received unknown message on StateChannel: %T", msg)
 	}
+
+	return nil
 }
 
-// RemovePeer is a noop.
-func (conR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
-	if !conR.IsRunning() {
-		return
+// handleDataMessage handles envelopes sent from peers on the DataChannel. This
+// should never be called outside of handleMessage.
+func (conR *Reactor) handleDataMessage(envelope p2p.Envelope, msg Message, ps *PeerState) error {
+	if conR.WaitSync() {
+		conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+		return nil
 	}
-	// TODO
-	// ps, ok := peer.Get(PeerStateKey).(*PeerState)
-	// if !ok {
-	// 	panic(fmt.Sprintf("Peer %v has no state", peer))
-	// }
-	// ps.Disconnect()
-}
-
-// Receive implements Reactor
-// NOTE: We process these messages even when we're fast_syncing.
-// Messages affect either a peer state or the consensus state.
-// Peer state updates can happen in parallel, but processing of
-// proposals, block parts, and votes are ordered by the receiveRoutine
-// NOTE: blocks on consensus state for proposals, block parts, and votes
-// XXX: do not call any methods that can block or incur heavy processing.
-// https://github.com/tendermint/tendermint/issues/2888
-func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	if !conR.IsRunning() {
-		conR.Logger.Debug("Receive", "src", src, "chId", chID, "bytes", msgBytes)
-		return
+
+	switch msg := msg.(type) {
+	case *ProposalMessage:
+		ps.SetHasProposal(msg.Proposal)
+		conR.conS.peerMsgQueue <- msgInfo{msg, p2p.ID(envelope.From.String())}
+
+	case *ProposalPOLMessage:
+		ps.ApplyProposalPOLMessage(msg)
+
+	case *BlockPartMessage:
+		ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
+		conR.Metrics.BlockParts.With("peer_id", envelope.From.String()).Add(1)
+		conR.conS.peerMsgQueue <- msgInfo{msg, p2p.ID(envelope.From.String())}
+
+	default:
+		return fmt.Errorf("received unknown message on DataChannel: %T", msg)
 	}
 
-	msg, err := decodeMsg(msgBytes)
-	if err != nil {
-		conR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		conR.Switch.StopPeerForError(src, err)
-		return
+	return nil
+}
+
+// handleVoteMessage handles envelopes sent from peers on the VoteChannel. This
+// should never be called outside of handleMessage.
+func (conR *Reactor) handleVoteMessage(envelope p2p.Envelope, msg Message, ps *PeerState) error {
+	if conR.WaitSync() {
+		conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+		return nil
 	}
 
-	if err = msg.ValidateBasic(); err != nil {
-		conR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		conR.Switch.StopPeerForError(src, err)
-		return
+	switch msg := msg.(type) {
+	case *VoteMessage:
+		cs := conR.conS
+		cs.mtx.RLock()
+		height, valSize, lastCommitSize := cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
+		cs.mtx.RUnlock()
+		ps.EnsureVoteBitArrays(height, valSize)
+		ps.EnsureVoteBitArrays(height-1, lastCommitSize)
+		ps.SetHasVote(msg.Vote)
+
+		cs.peerMsgQueue <- msgInfo{msg, p2p.ID(envelope.From.String())}
+
+	default:
+		return fmt.Errorf("received unknown message on VoteChannel: %T", msg)
 	}
 
-	conR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
+	return nil
+}
 
-	// Get peer states
-	ps, ok := src.Get(types.PeerStateKey).(*PeerState)
-	if !ok {
-		panic(fmt.Sprintf("Peer %v has no state", src))
+// handleVoteSetBitsMessage handles envelopes sent from peers on the
+// VoteSetBitsChannel. This should never be called outside of handleMessage.
+func (conR *Reactor) handleVoteSetBitsMessage(envelope p2p.Envelope, msg Message, ps *PeerState) error {
+	if conR.WaitSync() {
+		conR.Logger.Info("Ignoring message received during sync", "msg", msg)
+		return nil
 	}
 
-	switch chID {
-	case StateChannel:
-		switch msg := msg.(type) {
-		case *NewRoundStepMessage:
-			conR.conS.mtx.Lock()
-			initialHeight := conR.conS.state.InitialHeight
-			conR.conS.mtx.Unlock()
-			if err = msg.ValidateHeight(initialHeight); err != nil {
-				conR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-				conR.Switch.StopPeerForError(src, err)
-				return
-			}
-			ps.ApplyNewRoundStepMessage(msg)
-		case *NewValidBlockMessage:
-			ps.ApplyNewValidBlockMessage(msg)
-		case *HasVoteMessage:
-			ps.ApplyHasVoteMessage(msg)
-		case *VoteSetMaj23Message:
-			cs := conR.conS
-			cs.mtx.Lock()
-			height, votes := cs.Height, cs.Votes
-			cs.mtx.Unlock()
-			if height != msg.Height {
-				return
-			}
-			// Peer claims to have a maj23 for some BlockID at H,R,S,
-			err := votes.SetPeerMaj23(msg.Round, msg.Type, ps.peer.ID(), msg.BlockID)
-			if err != nil {
-				conR.Switch.StopPeerForError(src, err)
-				return
-			}
-			// Respond with a VoteSetBitsMessage showing which votes we have.
-			// (and consequently shows which we don't have)
+	switch msg := msg.(type) {
+	case *VoteSetBitsMessage:
+		cs := conR.conS
+		cs.mtx.Lock()
+		height, votes := cs.Height, cs.Votes
+		cs.mtx.Unlock()
+
+		if height == msg.Height {
 			var ourVotes *bits.BitArray
 			switch msg.Type {
 			case tmproto.PrevoteType:
@@ -291,94 +490,130 @@ func (conR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 			default:
 				panic("Bad VoteSetBitsMessage field Type. Forgot to add a check in ValidateBasic?")
 			}
-			src.TrySend(VoteSetBitsChannel, MustEncode(&VoteSetBitsMessage{
-				Height:  msg.Height,
-				Round:   msg.Round,
-				Type:    msg.Type,
-				BlockID: msg.BlockID,
-				Votes:   ourVotes,
-			}))
-		default:
-			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+			ps.ApplyVoteSetBitsMessage(msg, ourVotes)
+		} else {
+			ps.ApplyVoteSetBitsMessage(msg, nil)
 		}
 
-	case DataChannel:
-		if conR.WaitSync() {
-			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
-			return
-		}
-		switch msg := msg.(type) {
-		case *ProposalMessage:
-			ps.SetHasProposal(msg.Proposal)
-			conR.conS.peerMsgQueue <- msgInfo{msg, src.ID()}
-		case *ProposalPOLMessage:
-			ps.ApplyProposalPOLMessage(msg)
-		case *BlockPartMessage:
-			ps.SetHasProposalBlockPart(msg.Height, msg.Round, int(msg.Part.Index))
-			conR.Metrics.BlockParts.With("peer_id", string(src.ID())).Add(1)
-			conR.conS.peerMsgQueue <- msgInfo{msg, src.ID()}
-		default:
-			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
-		}
+	default:
+		return fmt.Errorf("received unknown message on VoteSetBitsChannel: %T", msg)
+	}
 
-	case VoteChannel:
-		if conR.WaitSync() {
-			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
-			return
-		}
-		switch msg := msg.(type) {
-		case *VoteMessage:
-			cs := conR.conS
-			cs.mtx.RLock()
-			height, valSize, lastCommitSize := cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
-			cs.mtx.RUnlock()
-			ps.EnsureVoteBitArrays(height, valSize)
-			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
-			ps.SetHasVote(msg.Vote)
-
-			cs.peerMsgQueue <- msgInfo{msg, src.ID()}
+	return nil
+}
 
-		default:
-			// don't punish (leave room for soft upgrades)
-			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+// handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
+// It will handle errors and any possible panics gracefully. A caller can handle
+// any error returned by sending a PeerError on the respective channel.
+//
+// NOTE: We process these messages even when we're fast_syncing. Messages affect
+// either a peer state or the consensus state. Peer state updates can happen in
+// parallel, but processing of proposals, block parts, and votes are ordered by
+// the p2p channel.
+//
+// NOTE: We block on consensus state for proposals, block parts, and votes.
+func (conR *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			conR.Logger.Error("recovering from processing message panic", "err", err)
 		}
+	}()
+
+	// We wrap the envelope's message in a Proto wire type so we can convert back
+	// to the domain type and validate it.
+	pb := new(tmcons.Message)
+	if err := pb.Wrap(envelope.Message); err != nil {
+		return err
+	}
+
+	msg, err := MsgFromProto(pb)
+	if err != nil {
+		return err
+	}
+
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	conR.Logger.Debug("received message", "ch_id", chID, "msg", msg, "peer", envelope.From.String())
+
+	// Messages of a peer we have no state for are dropped. This happens if they
+	// arrive before the peer's PeerUpdate or after the peer is removed.
+	ps, ok := conR.GetPeerState(envelope.From)
+	if !ok {
+		conR.Logger.Debug("failed to find peer state", "peer", envelope.From.String(), "ch_id", chID)
+		return nil
+	}
+
+	switch chID {
+	case StateChannel:
+		err = conR.handleStateMessage(envelope, msg, ps)
+
+	case DataChannel:
+		err = conR.handleDataMessage(envelope, msg, ps)
+
+	case VoteChannel:
+		err = conR.handleVoteMessage(envelope, msg, ps)
 
 	case VoteSetBitsChannel:
-		if conR.WaitSync() {
-			conR.Logger.Info("Ignoring message received during sync", "msg", msg)
-			return
-		}
-		switch msg := msg.(type) {
-		case *VoteSetBitsMessage:
-			cs := conR.conS
-			cs.mtx.Lock()
-			height, votes := cs.Height, cs.Votes
-			cs.mtx.Unlock()
-
-			if height == msg.Height {
-				var ourVotes *bits.BitArray
-				switch msg.Type {
-				case tmproto.PrevoteType:
-					ourVotes = votes.Prevotes(msg.Round).BitArrayByBlockID(msg.BlockID)
-				case tmproto.PrecommitType:
-					ourVotes = votes.Precommits(msg.Round).BitArrayByBlockID(msg.BlockID)
-				default:
-					panic("Bad VoteSetBitsMessage field Type. Forgot to add a check in ValidateBasic?")
+		err = conR.handleVoteSetBitsMessage(envelope, msg, ps)
+
+	default:
+		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
+	}
+
+	return err
+}
+
+// processChannel initiates a blocking process where we listen for and handle
+// envelopes on the given p2p Channel until closeCh is closed. Any error
+// encountered during message execution will result in a PeerError being sent
+// on the Channel. When the reactor is stopped, we will catch the signal and
+// close the p2p Channel gracefully.
+func (conR *Reactor) processChannel(ch *p2p.Channel, closeCh <-chan struct{}) {
+	defer ch.Close()
+
+	for {
+		select {
+		case envelope := <-ch.In():
+			if err := conR.handleMessage(ch.ID(), envelope); err != nil {
+				conR.Logger.Error("failed to process message", "ch_id", ch.ID(), "envelope", envelope, "err", err)
+				ch.Error() <- p2p.PeerError{
+					PeerID:   envelope.From,
+					Err:      err,
+					Severity: p2p.PeerErrorSeverityLow,
 				}
-				ps.ApplyVoteSetBitsMessage(msg, ourVotes)
-			} else {
-				ps.ApplyVoteSetBitsMessage(msg, nil)
 			}
-		default:
-			// don't punish (leave room for soft upgrades)
-			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
-		}
 
-	default:
-		conR.Logger.Error(fmt.Sprintf("Unknown chId %X", chID))
+		case <-closeCh:
+			conR.Logger.Debug("stopped listening on channel; closing...", "ch_id", ch.ID())
+			return
+		}
 	}
 }
 
+// processStateCh listens for envelopes on the StateChannel. It is closed
+// separately from the other channels; see stateCloseCh.
+func (conR *Reactor) processStateCh() {
+	conR.processChannel(conR.stateCh, conR.stateCloseCh)
+}
+
+// processDataCh listens for envelopes on the DataChannel.
+func (conR *Reactor) processDataCh() {
+	conR.processChannel(conR.dataCh, conR.closeCh)
+}
+
+// processVoteCh listens for envelopes on the VoteChannel.
+func (conR *Reactor) processVoteCh() {
+	conR.processChannel(conR.voteCh, conR.closeCh)
+}
+
+// processVoteSetBitsCh listens for envelopes on the VoteSetBitsChannel.
+func (conR *Reactor) processVoteSetBitsCh() {
+	conR.processChannel(conR.voteSetBitsCh, conR.closeCh)
+}
+
 // SetEventBus sets event bus.
 func (conR *Reactor) SetEventBus(b *types.EventBus) {
 	conR.eventBus = b
@@ -429,7 +664,10 @@ func (conR *Reactor) unsubscribeFromBroadcastEvents() {
 
 func (conR *Reactor) broadcastNewRoundStepMessage(rs *cstypes.RoundState) {
 	nrsMsg := makeRoundStepMessage(rs)
-	conR.Switch.Broadcast(StateChannel, MustEncode(nrsMsg))
+	conR.stateCh.Out() <- p2p.Envelope{
+		Broadcast: true,
+		Message:   mustProto(nrsMsg),
+	}
 }
 
 func (conR *Reactor) broadcastNewValidBlockMessage(rs *cstypes.RoundState) {
@@ -440,7 +678,10 @@ func (conR *Reactor) broadcastNewValidBlockMessage(rs *cstypes.RoundState) {
 		BlockParts:         rs.ProposalBlockParts.BitArray(),
 		IsCommit:           rs.Step == cstypes.RoundStepCommit,
 	}
-	conR.Switch.Broadcast(StateChannel, MustEncode(csMsg))
+	conR.stateCh.Out() <- p2p.Envelope{
+		Broadcast: true,
+		Message:   mustProto(csMsg),
+	}
 }
 
 // Broadcasts HasVoteMessage to peers that care.
@@ -451,7 +692,10 @@ func (conR *Reactor) broadcastHasVoteMessage(vote *types.Vote) {
 		Type:   vote.Type,
 		Index:  vote.ValidatorIndex,
 	}
-	conR.Switch.Broadcast(StateChannel, MustEncode(msg))
+	conR.stateCh.Out() <- p2p.Envelope{
+		Broadcast: true,
+		Message:   mustProto(msg),
+	}
 	/*
 		// TODO: Make this broadcast more selective.
 		for _, peer := range conR.Switch.Peers().List() {
@@ -483,19 +727,52 @@ func makeRoundStepMessage(rs *cstypes.RoundState) (nrsMsg *NewRoundStepMessage)
 	return
 }
 
-func (conR *Reactor) sendNewRoundStepMessage(peer p2p.Peer) {
+func (conR *Reactor) sendNewRoundStepMessage(peerID p2p.PeerID) {
 	rs := conR.conS.GetRoundState()
 	nrsMsg := makeRoundStepMessage(rs)
-	peer.Send(StateChannel, MustEncode(nrsMsg))
+	conR.stateCh.Out() <- p2p.Envelope{
+		To:      peerID,
+		Message: mustProto(nrsMsg),
+	}
 }
 
-func (conR *Reactor) gossipDataRoutine(peer p2p.Peer, ps *PeerState) {
-	logger := conR.Logger.With("peer", peer)
+// mustProto returns the proto message that is sent over the p2p Channels for
+// the given consensus message, i.e. the message wrapped by tmcons.Message.
+func mustProto(msg Message) proto.Message {
+	pb, err := MsgToProto(msg)
+	if err != nil {
+		panic(err)
+	}
+
+	inner, err := pb.Unwrap()
+	if err != nil {
+		panic(err)
+	}
+
+	return inner
+}
+
+func (conR *Reactor) gossipDataRoutine(ps *PeerState) {
+	defer conR.peerWG.Done()
+
+	logger := conR.Logger.With("peer", ps.peerID.String())
+
+	// Send our state to peer.
+	// If we're fast_syncing, broadcast a RoundStepMessage later upon SwitchToConsensus().
+	if !conR.WaitSync() {
+		conR.sendNewRoundStepMessage(ps.peerID)
+	}
 
 OUTER_LOOP:
 	for {
 		// Manage disconnects from self or peer.
-		if !peer.IsRunning() || !conR.IsRunning() {
+		select {
+		case <-ps.closer.Done():
+			logger.Info("Stopping gossipDataRoutine for peer")
+			return
+		default:
+		}
+		if !conR.IsRunning() {
 			logger.Info("Stopping gossipDataRoutine for peer")
 			return
 		}
@@ -512,9 +789,11 @@ OUTER_LOOP:
 					Part:   part,
 				}
 				logger.Debug("Sending block part", "height", prs.Height, "round", prs.Round)
-				if peer.Send(DataChannel, MustEncode(msg)) {
-					ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
+				conR.dataCh.Out() <- p2p.Envelope{
+					To:      ps.peerID,
+					Message: mustProto(msg),
 				}
+				ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 				continue OUTER_LOOP
 			}
 		}
@@ -536,7 +815,7 @@ OUTER_LOOP:
 				// continue the loop since prs is a copy and not effected by this initialization
 				continue OUTER_LOOP
 			}
-			conR.gossipDataForCatchup(heightLogger, rs, prs, ps, peer)
+			conR.gossipDataForCatchup(heightLogger, rs, prs, ps)
 			continue OUTER_LOOP
 		}
 
@@ -559,10 +838,12 @@ OUTER_LOOP:
 			{
 				msg := &ProposalMessage{Proposal: rs.Proposal}
 				logger.Debug("Sending proposal", "height", prs.Height, "round", prs.Round)
-				if peer.Send(DataChannel, MustEncode(msg)) {
-					// NOTE[ZM]: A peer might have received different proposal msg so this Proposal msg will be rejected!
-					ps.SetHasProposal(rs.Proposal)
+				conR.dataCh.Out() <- p2p.Envelope{
+					To:      ps.peerID,
+					Message: mustProto(msg),
 				}
+				// NOTE[ZM]: A peer might have received different proposal msg so this Proposal msg will be rejected!
+				ps.SetHasProposal(rs.Proposal)
 			}
 			// ProposalPOL: lets peer know which POL votes we have so far.
 			// Peer must receive ProposalMessage first.
@@ -575,7 +856,10 @@ OUTER_LOOP:
 					ProposalPOL:      rs.Votes.Prevotes(rs.Proposal.POLRound).BitArray(),
 				}
 				logger.Debug("Sending POL", "height", prs.Height, "round", prs.Round)
-				peer.Send(DataChannel, MustEncode(msg))
+				conR.dataCh.Out() <- p2p.Envelope{
+					To:      ps.peerID,
+					Message: mustProto(msg),
+				}
 			}
 			continue OUTER_LOOP
 		}
@@ -587,7 +871,7 @@ OUTER_LOOP:
 }
 
 func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundState,
-	prs *cstypes.PeerRoundState, ps *PeerState, peer p2p.Peer) {
+	prs *cstypes.PeerRoundState, ps *PeerState) {
 
 	if index, ok := prs.ProposalBlockParts.Not().PickRandom(); ok {
 		// Ensure that the peer's PartSetHeader is correct
@@ -618,19 +902,21 @@ func (conR *Reactor) gossipDataForCatchup(logger log.Logger, rs *cstypes.RoundSt
 			Part:   part,
 		}
 		logger.Debug("Sending block part for catchup", "round", prs.Round, "index", index)
-		if peer.Send(DataChannel, MustEncode(msg)) {
-			ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
-		} else {
-			logger.Debug("Sending block part for catchup failed")
+		conR.dataCh.Out() <- p2p.Envelope{
+			To:      ps.peerID,
+			Message: mustProto(msg),
 		}
+		ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 		return
 	}
 	//  logger.Info("No parts to send in catch-up, sleeping")
 	time.Sleep(conR.conS.config.PeerGossipSleepDuration)
 }
 
-func (conR *Reactor) gossipVotesRoutine(peer p2p.Peer, ps *PeerState) {
-	logger := conR.Logger.With("peer", peer)
+func (conR *Reactor) gossipVotesRoutine(ps *PeerState) {
+	defer conR.peerWG.Done()
+
+	logger := conR.Logger.With("peer", ps.peerID.String())
 
 	// Simple hack to throttle logs upon sleep.
 	var sleeping = 0
@@ -638,7 +924,13 @@ func (conR *Reactor) gossipVotesRoutine(peer p2p.Peer, ps *PeerState) {
 OUTER_LOOP:
 	for {
 		// Manage disconnects from self or peer.
-		if !peer.IsRunning() || !conR.IsRunning() {
+		select {
+		case <-ps.closer.Done():
+			logger.Info("Stopping gossipVotesRoutine for peer")
+			return
+		default:
+		}
+		if !conR.IsRunning() {
 			logger.Info("Stopping gossipVotesRoutine for peer")
 			return
 		}
@@ -666,7 +958,7 @@ OUTER_LOOP:
 		// Special catchup logic.
 		// If peer is lagging by height 1, send LastCommit.
 		if prs.Height != 0 && rs.Height == prs.Height+1 {
-			if ps.PickSendVote(rs.LastCommit) {
+			if conR.pickSendVote(ps, rs.LastCommit) {
 				logger.Debug("Picked rs.LastCommit to send", "height", prs.Height)
 				continue OUTER_LOOP
 			}
@@ -678,7 +970,7 @@ OUTER_LOOP:
 			// Load the block commit for prs.Height,
 			// which contains precommit signatures for prs.Height.
 			if commit := conR.conS.blockStore.LoadBlockCommit(prs.Height); commit != nil {
-				if ps.PickSendVote(commit) {
+				if conR.pickSendVote(ps, commit) {
 					logger.Debug("Picked Catchup commit to send", "height", prs.Height)
 					continue OUTER_LOOP
 				}
@@ -710,7 +1002,7 @@ func (conR *Reactor) gossipVotesForHeight(
 
 	// If there are lastCommits to send...
 	if prs.Step == cstypes.RoundStepNewHeight {
-		if ps.PickSendVote(rs.LastCommit) {
+		if conR.pickSendVote(ps, rs.LastCommit) {
 			logger.Debug("Picked rs.LastCommit to send")
 			return true
 		}
@@ -718,7 +1010,7 @@ func (conR *Reactor) gossipVotesForHeight(
 	// If there are POL prevotes to send...
 	if prs.Step <= cstypes.RoundStepPropose && prs.Round != -1 && prs.Round <= rs.Round && prs.ProposalPOLRound != -1 {
 		if polPrevotes := rs.Votes.Prevotes(prs.ProposalPOLRound); polPrevotes != nil {
-			if ps.PickSendVote(polPrevotes) {
+			if conR.pickSendVote(ps, polPrevotes) {
 				logger.Debug("Picked rs.Prevotes(prs.ProposalPOLRound) to send",
 					"round", prs.ProposalPOLRound)
 				return true
@@ -727,21 +1019,21 @@ func (conR *Reactor) gossipVotesForHeight(
 	}
 	// If there are prevotes to send...
 	if prs.Step <= cstypes.RoundStepPrevoteWait && prs.Round != -1 && prs.Round <= rs.Round {
-		if ps.PickSendVote(rs.Votes.Prevotes(prs.Round)) {
+		if conR.pickSendVote(ps, rs.Votes.Prevotes(prs.Round)) {
 			logger.Debug("Picked rs.Prevotes(prs.Round) to send", "round", prs.Round)
 			return true
 		}
 	}
 	// If there are precommits to send...
 	if prs.Step <= cstypes.RoundStepPrecommitWait && prs.Round != -1 && prs.Round <= rs.Round {
-		if ps.PickSendVote(rs.Votes.Precommits(prs.Round)) {
+		if conR.pickSendVote(ps, rs.Votes.Precommits(prs.Round)) {
 			logger.Debug("Picked rs.Precommits(prs.Round) to send", "round", prs.Round)
 			return true
 		}
 	}
 	// If there are prevotes to send...Needed because of validBlock mechanism
 	if prs.Round != -1 && prs.Round <= rs.Round {
-		if ps.PickSendVote(rs.Votes.Prevotes(prs.Round)) {
+		if conR.pickSendVote(ps, rs.Votes.Prevotes(prs.Round)) {
 			logger.Debug("Picked rs.Prevotes(prs.Round) to send", "round", prs.Round)
 			return true
 		}
@@ -749,7 +1041,7 @@ func (conR *Reactor) gossipVotesForHeight(
 	// If there are POLPrevotes to send...
 	if prs.ProposalPOLRound != -1 {
 		if polPrevotes := rs.Votes.Prevotes(prs.ProposalPOLRound); polPrevotes != nil {
-			if ps.PickSendVote(polPrevotes) {
+			if conR.pickSendVote(ps, polPrevotes) {
 				logger.Debug("Picked rs.Prevotes(prs.ProposalPOLRound) to send",
 					"round", prs.ProposalPOLRound)
 				return true
@@ -760,15 +1052,38 @@ func (conR *Reactor) gossipVotesForHeight(
 	return false
 }
 
+// pickSendVote picks a vote and sends it to the peer.
+// Returns true if vote was sent.
+func (conR *Reactor) pickSendVote(ps *PeerState, votes types.VoteSetReader) bool {
+	if vote, ok := ps.PickVoteToSend(votes); ok {
+		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
+		conR.voteCh.Out() <- p2p.Envelope{
+			To:      ps.peerID,
+			Message: mustProto(&VoteMessage{vote}),
+		}
+		ps.SetHasVote(vote)
+		return true
+	}
+	return false
+}
+
 // NOTE: `queryMaj23Routine` has a simple crude design since it only comes
 // into play for liveness when there's a signature DDoS attack happening.
-func (conR *Reactor) queryMaj23Routine(peer p2p.Peer, ps *PeerState) {
-	logger := conR.Logger.With("peer", peer)
+func (conR *Reactor) queryMaj23Routine(ps *PeerState) {
+	defer conR.peerWG.Done()
+
+	logger := conR.Logger.With("peer", ps.peerID.String())
 
 OUTER_LOOP:
 	for {
 		// Manage disconnects from self or peer.
-		if !peer.IsRunning() || !conR.IsRunning() {
+		select {
+		case <-ps.closer.Done():
+			logger.Info("Stopping queryMaj23Routine for peer")
+			return
+		default:
+		}
+		if !conR.IsRunning() {
 			logger.Info("Stopping queryMaj23Routine for peer")
 			return
 		}
@@ -779,12 +1094,15 @@ OUTER_LOOP:
 			prs := ps.GetRoundState()
 			if rs.Height == prs.Height {
 				if maj23, ok := rs.Votes.Prevotes(prs.Round).TwoThirdsMajority(); ok {
-					peer.TrySend(StateChannel, MustEncode(&VoteSetMaj23Message{
-						Height:  prs.Height,
-						Round:   prs.Round,
-						Type:    tmproto.PrevoteType,
-						BlockID: maj23,
-					}))
+					conR.stateCh.Out() <- p2p.Envelope{
+						To: ps.peerID,
+						Message: mustProto(&VoteSetMaj23Message{
+							Height:  prs.Height,
+							Round:   prs.Round,
+							Type:    tmproto.PrevoteType,
+							BlockID: maj23,
+						}),
+					}
 					time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
 				}
 			}
@@ -796,12 +1114,15 @@ OUTER_LOOP:
 			prs := ps.GetRoundState()
 			if rs.Height == prs.Height {
 				if maj23, ok := rs.Votes.Precommits(prs.Round).TwoThirdsMajority(); ok {
-					peer.TrySend(StateChannel, MustEncode(&VoteSetMaj23Message{
-						Height:  prs.Height,
-						Round:   prs.Round,
-						Type:    tmproto.PrecommitType,
-						BlockID: maj23,
-					}))
+					conR.stateCh.Out() <- p2p.Envelope{
+						To: ps.peerID,
+						Message: mustProto(&VoteSetMaj23Message{
+							Height:  prs.Height,
+							Round:   prs.Round,
+							Type:    tmproto.PrecommitType,
+							BlockID: maj23,
+						}),
+					}
 					time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
 				}
 			}
@@ -813,12 +1134,15 @@ OUTER_LOOP:
 			prs := ps.GetRoundState()
 			if rs.Height == prs.Height && prs.ProposalPOLRound >= 0 {
 				if maj23, ok := rs.Votes.Prevotes(prs.ProposalPOLRound).TwoThirdsMajority(); ok {
-					peer.TrySend(StateChannel, MustEncode(&VoteSetMaj23Message{
-						Height:  prs.Height,
-						Round:   prs.ProposalPOLRound,
-						Type:    tmproto.PrevoteType,
-						BlockID: maj23,
-					}))
+					conR.stateCh.Out() <- p2p.Envelope{
+						To: ps.peerID,
+						Message: mustProto(&VoteSetMaj23Message{
+							Height:  prs.Height,
+							Round:   prs.ProposalPOLRound,
+							Type:    tmproto.PrevoteType,
+							BlockID: maj23,
+						}),
+					}
 					time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
 				}
 			}
@@ -833,12 +1157,15 @@ OUTER_LOOP:
 			if prs.CatchupCommitRound != -1 && prs.Height > 0 && prs.Height <= conR.conS.blockStore.Height() &&
 				prs.Height >= conR.conS.blockStore.Base() {
 				if commit := conR.conS.LoadCommit(prs.Height); commit != nil {
-					peer.TrySend(StateChannel, MustEncode(&VoteSetMaj23Message{
-						Height:  prs.Height,
-						Round:   commit.Round,
-						Type:    tmproto.PrecommitType,
-						BlockID: commit.BlockID,
-					}))
+					conR.stateCh.Out() <- p2p.Envelope{
+						To: ps.peerID,
+						Message: mustProto(&VoteSetMaj23Message{
+							Height:  prs.Height,
+							Round:   commit.Round,
+							Type:    tmproto.PrecommitType,
+							BlockID: commit.BlockID,
+						}),
+					}
 					time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
 				}
 			}
@@ -859,26 +1186,29 @@ func (conR *Reactor) peerStatsRoutine() {
 
 		select {
 		case msg := <-conR.conS.statsMsgQueue:
-			// Get peer
-			peer := conR.Switch.Peers().Get(msg.PeerID)
-			if peer == nil {
+			// Get peer state
+			conR.mtx.RLock()
+			ps, ok := conR.peers[string(msg.PeerID)]
+			conR.mtx.RUnlock()
+			if !ok {
 				conR.Logger.Debug("Attempt to update stats for non-existent peer",
 					"peer", msg.PeerID)
 				continue
 			}
-			// Get peer state
-			ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
-			if !ok {
-				panic(fmt.Sprintf("Peer %v has no state", peer))
-			}
 			switch msg.Msg.(type) {
 			case *VoteMessage:
 				if numVotes := ps.RecordVote(); numVotes%votesToContributeToBecomeGoodPeer == 0 {
-					conR.Switch.MarkPeerAsGood(peer)
+					conR.peerUpdates.SendUpdate(p2p.PeerUpdate{
+						PeerID: ps.peerID,
+						Status: p2p.PeerStatusGood,
+					})
 				}
 			case *BlockPartMessage:
 				if numParts := ps.RecordBlockPart(); numParts%blocksToContributeToBecomeGoodPeer == 0 {
-					conR.Switch.MarkPeerAsGood(peer)
+					conR.peerUpdates.SendUpdate(p2p.PeerUpdate{
+						PeerID: ps.peerID,
+						Status: p2p.PeerStatusGood,
+					})
 				}
 			}
 		case <-conR.conS.Quit():
@@ -902,13 +1232,11 @@ func (conR *Reactor) String() string {
 func (conR *Reactor) StringIndented(indent string) string {
 	s := "ConsensusReactor{\n"
 	s += indent + "  " + conR.conS.StringIndented(indent+"  ") + "\n"
-	for _, peer := range conR.Switch.Peers().List() {
-		ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
-		if !ok {
-			panic(fmt.Sprintf("Peer %v has no state", peer))
-		}
+	conR.mtx.RLock()
+	for _, ps := range conR.peers {
 		s += indent + "  " + ps.StringIndented(indent+"  ") + "\n"
 	}
+	conR.mtx.RUnlock()
 	s += indent + "}"
 	return s
 }
@@ -930,9 +1258,13 @@ var (
 // NOTE: THIS GETS DUMPED WITH rpc/core/consensus.go.
 // Be mindful of what you Expose.
 type PeerState struct {
-	peer   p2p.Peer
+	peerID p2p.PeerID
 	logger log.Logger
 
+	// closer is closed when the peer is removed, which stops its gossip
+	// goroutines.
+	closer *tmsync.Closer
+
 	mtx   sync.Mutex             // NOTE: Modify below using setters, never directly.
 	PRS   cstypes.PeerRoundState `json:"round_state"` // Exposed.
 	Stats *peerStateStats        `json:"stats"`       // Exposed.
@@ -949,11 +1281,12 @@ func (pss peerStateStats) String() string {
 		pss.Votes, pss.BlockParts)
 }
 
-// NewPeerState returns a new PeerState for the given Peer
-func NewPeerState(peer p2p.Peer) *PeerState {
+// NewPeerState returns a new PeerState for the given peer.
+func NewPeerState(peerID p2p.PeerID) *PeerState {
 	return &PeerState{
-		peer:   peer,
+		peerID: peerID,
 		logger: log.NewNopLogger(),
+		closer: tmsync.NewCloser(),
 		PRS: cstypes.PeerRoundState{
 			Round:              -1,
 			ProposalPOLRound:   -1,
@@ -1048,21 +1381,6 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
-// PickSendVote picks a vote and sends it to the peer.
-// Returns true if vote was sent.
-func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
-	if vote, ok := ps.PickVoteToSend(votes); ok {
-		msg := &VoteMessage{vote}
-		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
-		if ps.peer.Send(VoteChannel, MustEncode(msg)) {
-			ps.SetHasVote(vote)
-			return true
-		}
-		return false
-	}
-	return false
-}
-
 // PickVoteToSend picks a vote to send to the peer.
 // Returns true if a vote was picked.
 // NOTE: `votes` must be the correct Size() for the Height().
@@ -1394,7 +1712,7 @@ func (ps *PeerState) StringIndented(indent string) string {
 %s  RoundState %v
 %s  Stats      %v
 %s}`,
-		indent, ps.peer.ID(),
+		indent, ps.peerID,
 		indent, ps.PRS.StringIndented(indent+"  "),
 		indent, ps.Stats,
 		indent)
@@ -1420,15 +1738,6 @@ func init() {
 	tmjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
 }
 
-func decodeMsg(bz []byte) (msg Message, err error) {
-	pb := &tmcons.Message{}
-	if err = proto.Unmarshal(bz, pb); err != nil {
-		return msg, err
-	}
-
-	return MsgFromProto(pb)
-}
-
 //-------------------------------------
 
 // NewRoundStepMessage is sent for every step taken in the ConsensusState.
@@ -31,7 +31,7 @@ import (
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	mempl "github.com/lazyledger/lazyledger-core/mempool"
 	"github.com/lazyledger/lazyledger-core/p2p"
-	p2pmock "github.com/lazyledger/lazyledger-core/p2p/mock"
+	tmcons "github.com/lazyledger/lazyledger-core/proto/tendermint/consensus"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	sm "github.com/lazyledger/lazyledger-core/state"
 	statemocks "github.com/lazyledger/lazyledger-core/state/mocks"
@@ -44,19 +44,46 @@ import (
 
 var defaultTestTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
 
+// testReactor is a consensus Reactor together with the Switch serving its
+// p2p Channels through a ReactorShim.
+type testReactor struct {
+	*Reactor
+	sw *p2p.Switch
+}
+
+// newReactorWithShim creates a consensus Reactor for the given state whose
+// p2p Channels are provided by a ReactorShim, which can be added to a Switch.
+func newReactorWithShim(cs *State, waitSync bool) (*Reactor, *p2p.ReactorShim) {
+	shim := p2p.NewReactorShim("ConsensusShim", ChannelShims)
+	shim.SetLogger(cs.Logger.With("module", "p2p"))
+
+	reactor := NewReactor(
+		cs.Logger,
+		cs,
+		shim.GetChannel(StateChannel),
+		shim.GetChannel(DataChannel),
+		shim.GetChannel(VoteChannel),
+		shim.GetChannel(VoteSetBitsChannel),
+		shim.PeerUpdates,
+		waitSync,
+	)
+	return reactor, shim
+}
+
 func startConsensusNet(t *testing.T, css []*State, n int) (
-	[]*Reactor,
+	[]*testReactor,
 	[]types.Subscription,
 	[]*types.EventBus,
 ) {
-	reactors := make([]*Reactor, n)
+	reactors := make([]*testReactor, n)
+	shims := make([]*p2p.ReactorShim, n)
 	blocksSubs := make([]types.Subscription, 0)
 	eventBuses := make([]*types.EventBus, n)
 	for i := 0; i < n; i++ {
 		/*logger, err := tmflags.ParseLogLevel("consensus:info,*:error", logger, "info")
 		if err != nil {	t.Fatal(err)}*/
-		reactors[i] = NewReactor(css[i], true) // so we dont start the consensus states
-		reactors[i].SetLogger(css[i].Logger)
+		reactor, shim := newReactorWithShim(css[i], true) // so we dont start the consensus states
+		reactors[i], shims[i] = &testReactor{Reactor: reactor}, shim
 
 		// eventBus is already started with the cs
 		eventBuses[i] = css[i].eventBus
@@ -72,11 +99,15 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 			}
 
 		}
+
+		// the reactors must be running before their switches add any peers
+		require.NoError(t, reactors[i].Start())
 	}
-	// make connected switches and start all reactors
+	// make connected switches and start all reactor shims
 	p2p.MakeConnectedSwitches(config.P2P, n, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("CONSENSUS", reactors[i])
+		s.AddReactor("CONSENSUS", shims[i])
 		s.SetLogger(reactors[i].conS.Logger.With("module", "p2p"))
+		reactors[i].sw = s
 		return s
 	}, p2p.Connect2Switches)
 
@@ -91,13 +122,16 @@ func startConsensusNet(t *testing.T, css []*State, n int) (
 	return reactors, blocksSubs, eventBuses
 }
 
-func stopConsensusNet(logger log.Logger, reactors []*Reactor, eventBuses []*types.EventBus) {
+func stopConsensusNet(logger log.Logger, reactors []*testReactor, eventBuses []*types.EventBus) {
 	logger.Info("stopConsensusNet", "n", len(reactors))
 	for i, r := range reactors {
 		logger.Info("stopConsensusNet: Stopping Reactor", "i", i)
-		if err := r.Switch.Stop(); err != nil {
+		if err := r.sw.Stop(); err != nil {
 			logger.Error("error trying to stop switch", "error", err)
 		}
+		if err := r.Stop(); err != nil {
+			logger.Error("error trying to stop reactor", "error", err)
+		}
 	}
 	for i, b := range eventBuses {
 		logger.Info("stopConsensusNet: Stopping eventBus", "i", i)
@@ -243,59 +277,110 @@ func TestReactorCreatesBlockWhenEmptyBlocksFalse(t *testing.T) {
 	}, css)
 }
 
-func TestReactorReceiveDoesNotPanicIfAddPeerHasntBeenCalledYet(t *testing.T) {
-	N := 1
-	css, cleanup := randConsensusNet(
-		N,
-		"consensus_reactor_test",
-		newMockTickerFunc(true),
-		newCounter,
-	)
-	defer cleanup()
-	reactors, _, eventBuses := startConsensusNet(t, css, N)
-	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+// channelTestSuite is a consensus Reactor wired directly to go channels, so
+// tests can drive its p2p Channels without a Switch.
+type channelTestSuite struct {
+	reactor *Reactor
 
-	var (
-		reactor = reactors[0]
-		peer    = p2pmock.NewPeer(nil)
-		msg     = MustEncode(&HasVoteMessage{Height: 1,
-			Round: 1, Index: 1, Type: tmproto.PrevoteType})
-	)
+	inChs  map[p2p.ChannelID]chan p2p.Envelope
+	errChs map[p2p.ChannelID]chan p2p.PeerError
+
+	peerUpdatesCh chan p2p.PeerUpdate
+}
+
+func setupChannelReactor(t *testing.T, cs *State) *channelTestSuite {
+	t.Helper()
+
+	rts := &channelTestSuite{
+		inChs:         make(map[p2p.ChannelID]chan p2p.Envelope),
+		errChs:        make(map[p2p.ChannelID]chan p2p.PeerError),
+		peerUpdatesCh: make(chan p2p.PeerUpdate),
+	}
 
-	reactor.InitPeer(peer)
+	channels := make(map[p2p.ChannelID]*p2p.Channel)
+	for _, chID := range []p2p.ChannelID{StateChannel, DataChannel, VoteChannel, VoteSetBitsChannel} {
+		rts.inChs[chID] = make(chan p2p.Envelope, 10)
+		rts.errChs[chID] = make(chan p2p.PeerError, 10)
+		outCh := make(chan p2p.Envelope, 10)
+		channels[chID] = p2p.NewChannel(chID, new(tmcons.Message), rts.inChs[chID], outCh, rts.errChs[chID])
 
-	// simulate switch calling Receive before AddPeer
-	assert.NotPanics(t, func() {
-		reactor.Receive(StateChannel, peer, msg)
-		reactor.AddPeer(peer)
+		// drain outbound envelopes until the channel is closed
+		go func() {
+			for range outCh {
+			}
+		}()
+	}
+
+	rts.reactor = NewReactor(
+		cs.Logger,
+		cs,
+		channels[StateChannel],
+		channels[DataChannel],
+		channels[VoteChannel],
+		channels[VoteSetBitsChannel],
+		p2p.NewPeerUpdates(rts.peerUpdatesCh),
+		true, // so we don't start the consensus state
+	)
+	require.NoError(t, rts.reactor.Start())
+	t.Cleanup(func() {
+		require.NoError(t, rts.reactor.Stop())
 	})
+
+	return rts
 }
 
-func TestReactorReceivePanicsIfInitPeerHasntBeenCalledYet(t *testing.T) {
-	N := 1
-	css, cleanup := randConsensusNet(
-		N,
-		"consensus_reactor_test",
-		newMockTickerFunc(true),
-		newCounter,
-	)
+func TestReactorDropsMessagesFromUnknownPeer(t *testing.T) {
+	css, cleanup := randConsensusNet(1, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
 	defer cleanup()
-	reactors, _, eventBuses := startConsensusNet(t, css, N)
-	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+	rts := setupChannelReactor(t, css[0])
+
+	peerID, unknownPeerID := p2p.PeerID{0x01}, p2p.PeerID{0x02}
+	msg := &NewRoundStepMessage{Height: 1, Round: 0, Step: cstypes.RoundStepNewHeight, LastCommitRound: -1}
+
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusUp}
+	require.Eventually(t, func() bool {
+		_, ok := rts.reactor.GetPeerState(peerID)
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	// envelopes are handled in order, so once the known peer's state is updated
+	// the unknown peer's message must have been dropped
+	rts.inChs[StateChannel] <- p2p.Envelope{From: unknownPeerID, Message: mustProto(msg)}
+	rts.inChs[StateChannel] <- p2p.Envelope{From: peerID, Message: mustProto(msg)}
+	require.Eventually(t, func() bool {
+		return rts.reactor.GetHeight(peerID) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	_, ok := rts.reactor.GetPeerState(unknownPeerID)
+	assert.False(t, ok)
+	assert.Empty(t, rts.errChs[StateChannel])
+
+	// and it is forgotten once it goes down
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusDown}
+	require.Eventually(t, func() bool {
+		_, ok := rts.reactor.GetPeerState(peerID)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
 
-	var (
-		reactor = reactors[0]
-		peer    = p2pmock.NewPeer(nil)
-		msg     = MustEncode(&HasVoteMessage{Height: 1,
-			Round: 1, Index: 1, Type: tmproto.PrevoteType})
-	)
+func TestReactorInvalidMessageReportsPeerError(t *testing.T) {
+	css, cleanup := randConsensusNet(1, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	rts := setupChannelReactor(t, css[0])
 
-	// we should call InitPeer here
+	peerID := p2p.PeerID{0x01}
+	rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusUp}
 
-	// simulate switch calling Receive before AddPeer
-	assert.Panics(t, func() {
-		reactor.Receive(StateChannel, peer, msg)
-	})
+	msg := &NewRoundStepMessage{Height: -1, Round: 0, Step: cstypes.RoundStepNewHeight}
+	rts.inChs[StateChannel] <- p2p.Envelope{From: peerID, Message: mustProto(msg)}
+
+	select {
+	case peerErr := <-rts.errChs[StateChannel]:
+		require.Equal(t, peerID, peerErr.PeerID)
+		require.Error(t, peerErr.Err)
+	case <-time.After(time.Second):
+		t.Fatal("expected peer error for invalid message")
+	}
 }
 
 // Test we record stats about votes and block parts from other peers.
@@ -317,9 +402,12 @@ func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	}, css)
 
 	// Get peer
-	peer := reactors[1].Switch.Peers().List()[0]
+	peer := reactors[1].sw.Peers().List()[0]
+	peerID, err := p2p.PeerIDFromString(string(peer.ID()))
+	require.NoError(t, err)
 	// Get peer state
-	ps := peer.Get(types.PeerStateKey).(*PeerState)
+	ps, ok := reactors[1].GetPeerState(peerID)
+	require.True(t, ok)
 
 	assert.Equal(t, true, ps.VotesSent() > 0, "number of votes sent should have increased")
 	assert.Equal(t, true, ps.BlockPartsSent() > 0, "number of votes sent should have increased")
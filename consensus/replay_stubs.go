@@ -23,6 +23,16 @@ func (emptyMempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo)
 }
 func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (emptyMempool) ReapMaxSharesMaxGas(_ int, _ int64) (types.Txs, []types.Message) {
+	return types.Txs{}, nil
+}
+func (emptyMempool) TxByKey(_ [mempl.TxKeySize]byte) (mempl.TxEntry, bool) {
+	return mempl.TxEntry{}, false
+}
+func (emptyMempool) TxEntries() []mempl.TxEntry { return nil }
+func (emptyMempool) RemoveTxByKey(_ [mempl.TxKeySize]byte, _ bool) error {
+	return mempl.ErrTxNotFound
+}
 func (emptyMempool) Update(
 	_ int64,
 	_ types.Txs,
@@ -1006,7 +1006,7 @@ func makeBlock(state sm.State, lastBlock *types.Block, lastBlockMeta *types.Bloc
 		height,
 		[]types.Tx{},
 		nil,
-		nil,
+		types.IntermediateStateRoots{},
 		types.Messages{},
 		lastCommit,
 		state.Validators.GetProposer().Address,
@@ -1078,16 +1078,11 @@ func makeBlockchainFromWAL(wal WAL) ([]*types.Block, []*types.Commit, error) {
 		case EndHeightMessage:
 			// if its not the first one, we have a full block
 			if thisBlockParts != nil {
-				var pbb = new(tmproto.Block)
 				bz, err := ioutil.ReadAll(thisBlockParts.GetReader())
 				if err != nil {
 					panic(err)
 				}
-				err = proto.Unmarshal(bz, pbb)
-				if err != nil {
-					panic(err)
-				}
-				block, err := types.BlockFromProto(pbb)
+				block, err := types.BlockFromPartSetBytes(bz)
 				if err != nil {
 					panic(err)
 				}
@@ -1122,12 +1117,7 @@ func makeBlockchainFromWAL(wal WAL) ([]*types.Block, []*types.Commit, error) {
 	if err != nil {
 		panic(err)
 	}
-	var pbb = new(tmproto.Block)
-	err = proto.Unmarshal(bz, pbb)
-	if err != nil {
-		panic(err)
-	}
-	block, err := types.BlockFromProto(pbb)
+	block, err := types.BlockFromPartSetBytes(bz)
 	if err != nil {
 		panic(err)
 	}
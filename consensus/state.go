@@ -11,7 +11,6 @@ import (
 	"runtime/debug"
 	"time"
 
-	"github.com/gogo/protobuf/proto"
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/libp2p/go-libp2p-core/routing"
 
@@ -27,7 +26,6 @@ import (
 	"github.com/lazyledger/lazyledger-core/libs/service"
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
-	"github.com/lazyledger/lazyledger-core/p2p/ipld"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	sm "github.com/lazyledger/lazyledger-core/state"
 	"github.com/lazyledger/lazyledger-core/types"
@@ -158,6 +156,12 @@ type State struct {
 	// context of the recent proposed block
 	proposalCtx    context.Context
 	proposalCancel context.CancelFunc
+
+	// outcomes of the data availability checks for the recent heights
+	daReports *dataAvailabilityReports
+	// context of the running data availability checks, canceled on stop
+	daCtx    context.Context
+	daCancel context.CancelFunc
 }
 
 // StateOption sets an optional parameter on the State.
@@ -192,7 +196,9 @@ func NewState(
 		evpool:           evpool,
 		evsw:             tmevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		daReports:        newDataAvailabilityReports(),
 	}
+	cs.daCtx, cs.daCancel = context.WithCancel(context.Background())
 	// set function defaults (may be overwritten before calling Start)
 	cs.decideProposal = cs.defaultDecideProposal
 	cs.doPrevote = cs.defaultDoPrevote
@@ -424,6 +430,7 @@ func (cs *State) loadWalFile() error {
 
 // OnStop implements service.Service.
 func (cs *State) OnStop() {
+	cs.daCancel()
 	if err := cs.evsw.Stop(); err != nil {
 		cs.Logger.Error("error trying to stop eventSwitch", "error", err)
 	}
@@ -1142,19 +1149,7 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 		// cs.proposalCancel()
 	}
 	cs.proposalCtx, cs.proposalCancel = context.WithCancel(context.TODO())
-	go func(ctx context.Context) {
-		cs.Logger.Info("Putting Block to IPFS", "height", block.Height)
-		err = ipld.PutBlock(ctx, cs.dag, block, cs.croute, cs.Logger)
-		if err != nil {
-			if errors.Is(err, context.Canceled) {
-				cs.Logger.Error("Putting Block didn't finish in time and was terminated", "height", block.Height)
-				return
-			}
-			cs.Logger.Error("Failed to put Block to IPFS", "err", err, "height", block.Height)
-			return
-		}
-		cs.Logger.Info("Finished putting block to IPFS", "height", block.Height)
-	}(cs.proposalCtx)
+	go cs.putBlock(cs.proposalCtx, block, round)
 }
 
 // Returns true if the proposal block is complete &&
@@ -1267,6 +1262,28 @@ func (cs *State) defaultDoPrevote(height int64, round int32) {
 		return
 	}
 
+	// The block data is sampled against the data availability header of the proposal, so it
+	// must match it.
+	if !cs.isProposalDataValid() {
+		logger.Error("enterPrevote: ProposalBlock data does not match the proposal")
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
+	// Let the application vet the block data
+	accepted, err := cs.blockExec.ProcessProposal(cs.ProposalBlock)
+	if err != nil {
+		logger.Error("enterPrevote: Failed to process ProposalBlock", "err", err)
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+	if !accepted {
+		// ProposalBlock was rejected by the application, prevote nil.
+		logger.Info("enterPrevote: ProposalBlock was rejected by the application")
+		cs.signAddVote(tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1810,9 +1827,49 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 		cs.ProposalBlockParts = types.NewPartSetFromHeader(proposal.BlockID.PartSetHeader)
 	}
 	cs.Logger.Info("Received proposal", "proposal", proposal)
+
+	// The proposer puts the block data itself, others check it is available on the network.
+	ownProposal := cs.privValidatorPubKey != nil && cs.isProposer(cs.privValidatorPubKey.Address())
+	if cs.config.DataAvailabilitySamples > 0 && !cs.replayMode && !ownProposal {
+		go cs.checkAvailability(cs.daCtx, proposal)
+	}
 	return nil
 }
 
+// isProposalDataValid checks that the data of the proposal block matches the data availability
+// header of the proposal. If it does not, the proposer signed an inconsistent proposal, and
+// evidence against it is sent to the evidence pool.
+func (cs *State) isProposalDataValid() bool {
+	if cs.Proposal == nil || !cs.ProposalBlockParts.HasHeader(cs.Proposal.BlockID.PartSetHeader) {
+		// the proposal block is not the one of the proposal, e.g. a valid block from a previous round
+		return true
+	}
+	dah := cs.Proposal.DAHeader
+	if err := cs.ProposalBlock.ValidateData(); err == nil && cs.ProposalBlock.DataAvailabilityHeader.Equals(dah) {
+		return true
+	}
+
+	var timestamp time.Time
+	if cs.Height == cs.state.InitialHeight {
+		timestamp = cs.state.LastBlockTime // genesis time
+	} else {
+		timestamp = sm.MedianTime(cs.LastCommit.MakeCommit(), cs.LastValidators)
+	}
+	ev, err := types.NewInvalidDAHeaderEvidence(cs.Proposal, cs.ProposalBlockParts, timestamp, cs.Validators)
+	if err != nil {
+		// e.g. the block is invalid, but its data matches the proposal
+		cs.Logger.Error("Failed to form invalid data availability header evidence", "err", err)
+		return false
+	}
+
+	if err := cs.evpool.AddEvidenceFromConsensus(ev); err != nil {
+		cs.Logger.Error("Failed to add evidence to the evidence pool", "err", err)
+	} else {
+		cs.Logger.Debug("Added evidence to the evidence pool", "ev", ev)
+	}
+	return false
+}
+
 // NOTE: block is not necessarily valid.
 // Asynchronously triggers either enterPrevote (before we timeout of propose) or tryFinalizeCommit,
 // once we have the full block.
@@ -1834,6 +1891,13 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 		return false, nil
 	}
 
+	// All parts but the last one are of the same size, so that the data shares of the block can be
+	// located in them, see types.InvalidDAHeaderEvidence.
+	if part.Index+1 < cs.ProposalBlockParts.Total() && len(part.Bytes) != int(types.BlockPartSizeBytes) {
+		return false, fmt.Errorf("block part #%d has %d bytes, expected %d",
+			part.Index, len(part.Bytes), types.BlockPartSizeBytes)
+	}
+
 	added, err = cs.ProposalBlockParts.AddPart(part)
 	if err != nil {
 		return added, err
@@ -1849,13 +1913,7 @@ func (cs *State) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (add
 			return added, err
 		}
 
-		var pbb = new(tmproto.Block)
-		err = proto.Unmarshal(bz, pbb)
-		if err != nil {
-			return added, err
-		}
-
-		block, err := types.BlockFromProto(pbb)
+		block, err := types.BlockFromPartSetBytes(bz)
 		if err != nil {
 			return added, err
 		}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/lazyledger/lazyledger-core/abci/example/counter"
+	abci "github.com/lazyledger/lazyledger-core/abci/types"
 	cstypes "github.com/lazyledger/lazyledger-core/consensus/types"
 	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
 	"github.com/lazyledger/lazyledger-core/libs/log"
@@ -20,6 +21,7 @@ import (
 	p2pmock "github.com/lazyledger/lazyledger-core/p2p/mock"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	"github.com/lazyledger/lazyledger-core/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 )
 
 /*
@@ -30,6 +32,8 @@ x * TestProposerSelection2 - round robin ordering, round 2++
 x * TestEnterProposeNoValidator - timeout into prevote round
 x * TestEnterPropose - finish propose without timing out (we have the proposal)
 x * TestBadProposal - 2 vals, bad proposal (bad block state hash), should prevote and precommit nil
+x * TestInvalidDAHeaderProposal - 2 vals, block data doesn't match the DAH of the proposal, should prevote nil and report the proposer
+x * TestOversizedDataProposal - 2 vals, block data doesn't fit into the largest data square, should prevote nil
 x * TestOversizedBlock - block with too many txs should be rejected
 FullRoundSuite
 x * TestFullRound1 - 1 val, full successful round
@@ -243,6 +247,172 @@ func TestStateBadProposal(t *testing.T) {
 	signAddVotes(cs1, tmproto.PrecommitType, propBlock.Hash(), propBlock.MakePartSet(partSize).Header(), vs2)
 }
 
+func TestStateInvalidDAHeaderProposal(t *testing.T) {
+	cs1, vss := randState(2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	evidenceCh := make(chan types.Evidence, 1)
+	cs1.evpool = evidenceChPool(evidenceCh)
+
+	partSize := types.BlockPartSizeBytes
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	propBlock, _ := cs1.createProposalBlock()
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	// make the block data inconsistent with its data availability header
+	propBlock.Data.Txs = types.Txs{types.Tx("tampered")}
+	propBlockParts := propBlock.MakePartSet(partSize)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID, &propBlock.DataAvailabilityHeader)
+	p, err := proposal.ToProto()
+	require.NoError(t, err)
+	require.NoError(t, vs2.SignProposal(config.ChainID(), p))
+	proposal.Signature = p.Signature
+
+	// set the proposal block
+	require.NoError(t, cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"))
+
+	// start the machine
+	startTestRound(cs1, height, round)
+
+	// wait for proposal
+	ensureProposal(proposalCh, height, round, blockID)
+
+	// the inconsistent proposal is prevoted nil and the proposer gets reported
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+	var ev *types.InvalidDAHeaderEvidence
+	select {
+	case e := <-evidenceCh:
+		require.IsType(t, ev, e)
+		ev = e.(*types.InvalidDAHeaderEvidence)
+	default:
+		t.Fatal("expected evidence against the proposer")
+	}
+	addr, err := vs2.GetPubKey()
+	require.NoError(t, err)
+	assert.EqualValues(t, addr.Address(), ev.ProposerAddress)
+	assert.Equal(t, proposal, ev.Proposal)
+}
+
+func TestStateOversizedDataProposal(t *testing.T) {
+	cs1, vss := randState(2)
+	height, round := cs1.Height, cs1.Round
+	vs2 := vss[1]
+
+	evidenceCh := make(chan types.Evidence, 1)
+	cs1.evpool = evidenceChPool(evidenceCh)
+
+	partSize := types.BlockPartSizeBytes
+
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	propBlock, _ := cs1.createProposalBlock()
+
+	// make the second validator the proposer by incrementing round
+	round++
+	incrementRound(vss[1:]...)
+
+	// the block data needs more shares than the largest data square has
+	txs := make(types.Txs, consts.MaxSquareSize*consts.MaxSquareSize+1)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(consts.TxShareSize - 2)
+	}
+	propBlock.Data.Txs = txs
+	propBlockParts := propBlock.MakePartSet(partSize)
+	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
+	proposal := types.NewProposal(vs2.Height, round, -1, blockID, &propBlock.DataAvailabilityHeader)
+	p, err := proposal.ToProto()
+	require.NoError(t, err)
+	require.NoError(t, vs2.SignProposal(config.ChainID(), p))
+	proposal.Signature = p.Signature
+
+	// set the proposal block
+	require.NoError(t, cs1.SetProposalAndBlock(proposal, propBlock, propBlockParts, "some peer"))
+
+	// start the machine
+	startTestRound(cs1, height, round)
+
+	// the block can't be decoded from its parts, so the proposal is prevoted nil once the
+	// propose step times out, and as its data availability header can't be proven there is
+	// no evidence against the proposer
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vss[0], nil)
+	select {
+	case ev := <-evidenceCh:
+		t.Fatalf("unexpected evidence %v", ev)
+	default:
+	}
+}
+
+// evidenceChPool sends the evidence it receives from consensus on the channel.
+type evidenceChPool chan types.Evidence
+
+func (ch evidenceChPool) AddEvidenceFromConsensus(ev types.Evidence) error {
+	ch <- ev
+	return nil
+}
+
+// rejectingApp rejects every proposal in ProcessProposal.
+type rejectingApp struct {
+	*counter.Application
+}
+
+func (rejectingApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	return abci.ResponseProcessProposal{Result: abci.ResponseProcessProposal_REJECT}
+}
+
+// a validator prevotes nil for a valid block the application rejected
+func TestStateProposalRejectedByApp(t *testing.T) {
+	state, privVals := randGenesisState(1, false, 10)
+	cs1 := newState(state, privVals[0], rejectingApp{counter.NewApplication(true)}, mdutils.Mock())
+	vs1 := newValidatorStub(privVals[0], 0)
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	startTestRound(cs1, height, round)
+
+	ensureNewProposal(proposalCh, height, round)
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vs1, nil)
+}
+
+// unimplementedApp does not implement ProcessProposal, so it answers with an UNKNOWN result.
+type unimplementedApp struct {
+	*counter.Application
+}
+
+func (unimplementedApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	return abci.ResponseProcessProposal{}
+}
+
+// a validator prevotes for a valid block the application did not process
+func TestStateProposalUnknownToApp(t *testing.T) {
+	state, privVals := randGenesisState(1, false, 10)
+	cs1 := newState(state, privVals[0], unimplementedApp{counter.NewApplication(true)}, mdutils.Mock())
+	vs1 := newValidatorStub(privVals[0], 0)
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+	voteCh := subscribe(cs1.eventBus, types.EventQueryVote)
+
+	startTestRound(cs1, height, round)
+
+	ensureNewProposal(proposalCh, height, round)
+	rs := cs1.GetRoundState()
+	ensurePrevote(voteCh, height, round)
+	validatePrevote(t, cs1, round, vs1, rs.ProposalBlock.Hash())
+}
+
 func TestStateOversizedBlock(t *testing.T) {
 
 	cs1, vss := randState(2)
@@ -1834,7 +2004,7 @@ func TestStateOutputsBlockPartsStats(t *testing.T) {
 	peer := p2pmock.NewPeer(nil)
 
 	// 1) new block part
-	parts := types.NewPartSetFromData(tmrand.Bytes(100), 10)
+	parts := types.NewPartSetFromData(tmrand.Bytes(2*int(types.BlockPartSizeBytes)), types.BlockPartSizeBytes)
 	msg := &BlockPartMessage{
 		Height: 1,
 		Round:  0,
@@ -35,6 +35,19 @@ type PrivKey interface {
 	Type() string
 }
 
+// BatchVerifier verifies a batch of signatures at once, which is faster than
+// verifying them one by one. Key types supporting it are listed in
+// crypto/batch.
+type BatchVerifier interface {
+	// Add appends an entry to the batch. It returns an error if the key is of
+	// the wrong type or the key or signature are malformed.
+	Add(key PubKey, message, signature []byte) error
+	// Verify verifies all entries added so far. It returns true if every
+	// signature is valid. Otherwise it also returns whether each signature is
+	// valid, in the order they were added, so the bad ones can be identified.
+	Verify() (bool, []bool)
+}
+
 type Symmetric interface {
 	Keygen() []byte
 	Encrypt(plaintext []byte, secret []byte) (ciphertext []byte)
@@ -170,3 +170,56 @@ func (pubKey PubKey) Equals(other crypto.PubKey) bool {
 
 	return false
 }
+
+//-------------------------------------
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for ed25519.
+type BatchVerifier struct {
+	ed25519consensus.BatchVerifier
+
+	keys []PubKey
+	msgs [][]byte
+	sigs [][]byte
+}
+
+// NewBatchVerifier returns a new, empty ed25519 batch verifier.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{BatchVerifier: ed25519consensus.NewBatchVerifier()}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pkEd, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not Ed25519")
+	}
+	if l := len(pkEd); l != PubKeySize {
+		return fmt.Errorf("pubkey size is incorrect; expected: %d, got %d", PubKeySize, l)
+	}
+	if l := len(signature); l != SignatureSize {
+		return fmt.Errorf("signature size is incorrect; expected: %d, got %d", SignatureSize, l)
+	}
+
+	b.BatchVerifier.Add(ed25519.PublicKey(pkEd), msg, signature)
+	b.keys = append(b.keys, pkEd)
+	b.msgs = append(b.msgs, msg)
+	b.sigs = append(b.sigs, signature)
+	return nil
+}
+
+// Verify verifies the batch and, if that fails, each signature on its own.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(b.keys))
+	if b.BatchVerifier.Verify() {
+		for i := range valid {
+			valid[i] = true
+		}
+		return true, valid
+	}
+
+	for i, key := range b.keys {
+		valid[i] = key.VerifySignature(b.msgs[i], b.sigs[i])
+	}
+	return false, valid
+}
@@ -28,3 +28,52 @@ func TestSignAndValidateEd25519(t *testing.T) {
 
 	assert.False(t, pubKey.VerifySignature(msg, sig))
 }
+
+func TestBatchSafe(t *testing.T) {
+	v := ed25519.NewBatchVerifier()
+
+	for i := 0; i <= 38; i++ {
+		priv := ed25519.GenPrivKey()
+		pub := priv.PubKey()
+
+		var msg []byte
+		if i%2 == 0 {
+			msg = []byte("easter")
+		} else {
+			msg = []byte("egg")
+		}
+
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+
+		err = v.Add(pub, msg, sig)
+		require.NoError(t, err)
+	}
+
+	ok, valid := v.Verify()
+	require.True(t, ok)
+	require.Len(t, valid, 39)
+	for _, b := range valid {
+		require.True(t, b)
+	}
+}
+
+func TestBatchIdentifiesBadSignature(t *testing.T) {
+	v := ed25519.NewBatchVerifier()
+
+	msg := []byte("easter")
+	for i := 0; i < 4; i++ {
+		priv := ed25519.GenPrivKey()
+		signed := msg
+		if i == 2 {
+			signed = []byte("egg")
+		}
+		sig, err := priv.Sign(signed)
+		require.NoError(t, err)
+		require.NoError(t, v.Add(priv.PubKey(), msg, sig))
+	}
+
+	ok, valid := v.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []bool{true, true, false, true}, valid)
+}
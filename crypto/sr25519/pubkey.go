@@ -15,7 +15,7 @@ var _ crypto.PubKey = PubKey{}
 // PubKeySize is the number of bytes in an Sr25519 public key.
 const (
 	PubKeySize = 32
-	keyType    = "sr25519"
+	KeyType    = "sr25519"
 )
 
 // PubKeySr25519 implements crypto.PubKey for the Sr25519 signature scheme.
@@ -72,6 +72,76 @@ func (pubKey PubKey) Equals(other crypto.PubKey) bool {
 }
 
 func (pubKey PubKey) Type() string {
-	return keyType
+	return KeyType
 
 }
+
+//-------------------------------------
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for sr25519.
+type BatchVerifier struct {
+	*schnorrkel.BatchVerifier
+
+	keys []PubKey
+	msgs [][]byte
+	sigs [][]byte
+}
+
+// NewBatchVerifier returns a new, empty sr25519 batch verifier.
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{BatchVerifier: schnorrkel.NewBatchVerifier()}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pkSr, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not Sr25519")
+	}
+	if l := len(pkSr); l != PubKeySize {
+		return fmt.Errorf("pubkey size is incorrect; expected: %d, got %d", PubKeySize, l)
+	}
+	if l := len(signature); l != SignatureSize {
+		return fmt.Errorf("signature size is incorrect; expected: %d, got %d", SignatureSize, l)
+	}
+
+	var p [PubKeySize]byte
+	copy(p[:], pkSr)
+	publicKey := &(schnorrkel.PublicKey{})
+	if err := publicKey.Decode(p); err != nil {
+		return err
+	}
+
+	var sig64 [SignatureSize]byte
+	copy(sig64[:], signature)
+	sig := &(schnorrkel.Signature{})
+	if err := sig.Decode(sig64); err != nil {
+		return err
+	}
+
+	signingContext := schnorrkel.NewSigningContext([]byte{}, msg)
+	if err := b.BatchVerifier.Add(signingContext, sig, publicKey); err != nil {
+		return err
+	}
+	b.keys = append(b.keys, pkSr)
+	b.msgs = append(b.msgs, msg)
+	b.sigs = append(b.sigs, signature)
+	return nil
+}
+
+// Verify verifies the batch and, if that fails, each signature on its own.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	valid := make([]bool, len(b.keys))
+	if len(b.keys) > 0 && b.BatchVerifier.Verify() {
+		for i := range valid {
+			valid[i] = true
+		}
+		return true, valid
+	}
+
+	for i, key := range b.keys {
+		valid[i] = key.VerifySignature(b.msgs[i], b.sigs[i])
+	}
+	return false, valid
+}
@@ -49,5 +49,15 @@ and hence pruned. Currently, only committed evidence in which a marker to the he
 and hence very small is saved. All updates are made from the `Update(block, state)` function which should be called
 when a new block is committed.
 
+Inspection
+
+Both buckets are keyed by the height and hash of the evidence, so `ListPendingEvidence` and `ListCommittedEvidence`
+can list the evidence within a height range, and further filter it by validator and type (see Filter). Only the
+requested page of evidence is loaded, while the rest of the matching evidence is counted. Committed evidence is
+loaded from the evidence of the block of the height in its marker, which is read from the parts holding it, so
+evidence in pruned blocks isn't listed. These back the `pending_evidence` and `committed_evidence` RPC routes, which
+the `evidence export` command uses to export evidence as JSON. `evidence import` submits exported evidence to another
+node through `broadcast_evidence`, where it is verified like any other evidence.
+
 */
 package evidence
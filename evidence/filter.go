@@ -0,0 +1,50 @@
+package evidence
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// Filter selects evidence when listing the evidence of the pool. The zero value matches all
+// evidence.
+type Filter struct {
+	// MinHeight and MaxHeight bound the height of the evidence, inclusive. A MaxHeight of 0 means
+	// there is no upper bound.
+	MinHeight int64
+	MaxHeight int64
+	// Validator, if set, only matches evidence against the validator with this address.
+	Validator types.Address
+	// Type, if set, only matches evidence of this type, e.g. "DuplicateVoteEvidence".
+	Type string
+}
+
+// EvidenceType returns the name of the type of the evidence, as used by Filter.
+func EvidenceType(ev types.Evidence) string {
+	return reflect.Indirect(reflect.ValueOf(ev)).Type().Name()
+}
+
+func (f Filter) matches(ev types.Evidence) bool {
+	if ev.Height() < f.MinHeight || (f.MaxHeight > 0 && ev.Height() > f.MaxHeight) {
+		return false
+	}
+	if f.Type != "" && f.Type != EvidenceType(ev) {
+		return false
+	}
+	if len(f.Validator) == 0 {
+		return true
+	}
+	for _, abciEv := range ev.ABCI() {
+		if bytes.Equal(abciEv.Validator.Address, f.Validator) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsEvidence returns whether the filter selects evidence by more than its height, which is in
+// the key of the evidence.
+func (f Filter) needsEvidence() bool {
+	return len(f.Validator) > 0 || f.Type != ""
+}
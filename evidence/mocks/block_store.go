@@ -28,6 +28,22 @@ func (_m *BlockStore) LoadBlockCommit(height int64) *types.Commit {
 	return r0
 }
 
+// LoadBlockEvidence provides a mock function with given fields: height
+func (_m *BlockStore) LoadBlockEvidence(height int64) types.EvidenceList {
+	ret := _m.Called(height)
+
+	var r0 types.EvidenceList
+	if rf, ok := ret.Get(0).(func(int64) types.EvidenceList); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.EvidenceList)
+		}
+	}
+
+	return r0
+}
+
 // LoadBlockMeta provides a mock function with given fields: height
 func (_m *BlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 	ret := _m.Called(height)
@@ -107,7 +107,7 @@ func (evpool *Pool) Update(state sm.State, ev types.EvidenceList) {
 	// update the state
 	evpool.updateState(state)
 
-	evpool.markEvidenceAsCommitted(ev)
+	evpool.markEvidenceAsCommitted(ev, state.LastBlockHeight)
 
 	// prune pending evidence when it has expired. This also updates when the next evidence will expire
 	if evpool.Size() > 0 && state.LastBlockHeight > evpool.pruningHeight &&
@@ -163,6 +163,11 @@ func (evpool *Pool) AddEvidenceFromConsensus(ev types.Evidence) error {
 		return nil
 	}
 
+	// evidence which doesn't fit into a block can't be committed, so there is no use in keeping it
+	if err := checkEvidenceSize(ev, evpool.State().ConsensusParams.Evidence.MaxBytes); err != nil {
+		return err
+	}
+
 	if err := evpool.addPendingEvidence(ev); err != nil {
 		return fmt.Errorf("can't add evidence to pending list: %w", err)
 	}
@@ -174,6 +179,20 @@ func (evpool *Pool) AddEvidenceFromConsensus(ev types.Evidence) error {
 	return nil
 }
 
+// ListPendingEvidence returns the pending evidence which matches the filter, ordered by height,
+// skipping the first skip matches and returning at most limit of them, along with the total
+// number of matches.
+func (evpool *Pool) ListPendingEvidence(filter Filter, skip, limit int) ([]types.Evidence, int, error) {
+	return evpool.filterEvidence(baseKeyPending, filter, skip, limit)
+}
+
+// ListCommittedEvidence returns the committed evidence which matches the filter, ordered by
+// height, skipping the first skip matches and returning at most limit of them, along with the
+// total number of matches.
+func (evpool *Pool) ListCommittedEvidence(filter Filter, skip, limit int) ([]types.Evidence, int, error) {
+	return evpool.filterEvidence(baseKeyCommitted, filter, skip, limit)
+}
+
 // CheckEvidence takes an array of evidence from a block and verifies all the evidence there.
 // If it has already verified the evidence then it jumps to the next one. It ensures that no
 // evidence has already been committed or is being proposed twice. It also adds any
@@ -368,9 +387,9 @@ func (evpool *Pool) removePendingEvidence(evidence types.Evidence) {
 	}
 }
 
-// markEvidenceAsCommitted processes all the evidence in the block, marking it as
-// committed and removing it from the pending database.
-func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
+// markEvidenceAsCommitted processes all the evidence in the block at the given height,
+// marking it as committed and removing it from the pending database.
+func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList, height int64) {
 	blockEvidenceMap := make(map[string]struct{}, len(evidence))
 	for _, ev := range evidence {
 		if evpool.isPending(ev) {
@@ -382,7 +401,7 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList) {
 		// we only need to record the height that it was saved at.
 		key := keyCommitted(ev)
 
-		h := gogotypes.Int64Value{Value: ev.Height()}
+		h := gogotypes.Int64Value{Value: height}
 		evBytes, err := proto.Marshal(&h)
 		if err != nil {
 			evpool.logger.Error("failed to marshal committed evidence", "err", err, "key(height/hash)", key)
@@ -424,10 +443,9 @@ func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Eviden
 		evList.Evidence = append(evList.Evidence, evpb)
 		evSize = int64(evList.Size())
 		if maxBytes != -1 && evSize > maxBytes {
-			if err := iter.Error(); err != nil {
-				return evidence, totalSize, err
-			}
-			return evidence, totalSize, nil
+			// skip the evidence, as smaller evidence after it may still fit
+			evList.Evidence = evList.Evidence[:len(evList.Evidence)-1]
+			continue
 		}
 
 		ev, err := types.EvidenceFromProto(&evpb)
@@ -445,6 +463,82 @@ func (evpool *Pool) listEvidence(prefixKey byte, maxBytes int64) ([]types.Eviden
 	return evidence, totalSize, nil
 }
 
+// filterEvidence lists the page of the evidence under the prefix key which matches the filter,
+// from oldest to newest, along with the total number of matches. Past the page, pending evidence
+// is only decoded if the filter needs more than the height in its key. Committed evidence is
+// loaded from the block it was committed in, see loadCommittedEvidence.
+func (evpool *Pool) filterEvidence(prefixKey byte, filter Filter, skip, limit int) ([]types.Evidence, int, error) {
+	start := append([]byte{prefixKey}, bE(filter.MinHeight)...)
+	end := []byte{prefixKey + 1}
+	if filter.MaxHeight > 0 {
+		end = append([]byte{prefixKey}, bE(filter.MaxHeight+1)...)
+	}
+
+	iter, err := evpool.evidenceStore.Iterator(start, end)
+	if err != nil {
+		return nil, 0, fmt.Errorf("database error: %v", err)
+	}
+	defer iter.Close()
+
+	var (
+		evidence      = make([]types.Evidence, 0)
+		total         int
+		blockEvidence = make(map[int64]types.EvidenceList) // most blocks hold several pieces of evidence
+	)
+	for ; iter.Valid(); iter.Next() {
+		inPage := total >= skip && len(evidence) < limit
+		if !inPage && prefixKey == baseKeyPending && !filter.needsEvidence() {
+			total++
+			continue
+		}
+
+		var ev types.Evidence
+		if prefixKey == baseKeyCommitted {
+			ev = evpool.loadCommittedEvidence(iter.Key(), iter.Value(), blockEvidence)
+		} else if ev, err = bytesToEv(iter.Value()); err != nil {
+			return nil, 0, err
+		}
+		if ev == nil || !filter.matches(ev) {
+			continue
+		}
+		if inPage {
+			evidence = append(evidence, ev)
+		}
+		total++
+	}
+	if err := iter.Error(); err != nil {
+		return nil, 0, err
+	}
+	return evidence, total, nil
+}
+
+// loadCommittedEvidence loads the committed evidence with the given key from the evidence of the
+// block whose height is recorded in the marker, caching the evidence of the block by height. It
+// returns nil if the block has been pruned, or if the marker was saved by an earlier version of
+// the pool, which recorded the height of the evidence instead.
+func (evpool *Pool) loadCommittedEvidence(
+	key, marker []byte,
+	blockEvidence map[int64]types.EvidenceList,
+) types.Evidence {
+	var h gogotypes.Int64Value
+	if err := proto.Unmarshal(marker, &h); err != nil {
+		evpool.logger.Error("Unable to decode committed evidence marker", "err", err, "key(height/hash)", key)
+		return nil
+	}
+
+	evList, ok := blockEvidence[h.Value]
+	if !ok {
+		evList = evpool.blockStore.LoadBlockEvidence(h.Value)
+		blockEvidence[h.Value] = evList
+	}
+	for _, ev := range evList {
+		if bytes.Equal(keyCommitted(ev), key) {
+			return ev
+		}
+	}
+	return nil
+}
+
 func (evpool *Pool) removeExpiredPendingEvidence() (int64, time.Time) {
 	iter, err := dbm.IteratePrefix(evpool.evidenceStore, []byte{baseKeyPending})
 	if err != nil {
@@ -5,16 +5,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
 	mdutils "github.com/ipfs/go-merkledag/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
 	"github.com/lazyledger/lazyledger-core/evidence"
 	"github.com/lazyledger/lazyledger-core/evidence/mocks"
 	dbm "github.com/lazyledger/lazyledger-core/libs/db"
 	"github.com/lazyledger/lazyledger-core/libs/db/memdb"
 	"github.com/lazyledger/lazyledger-core/libs/log"
+	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	tmversion "github.com/lazyledger/lazyledger-core/proto/tendermint/version"
 	sm "github.com/lazyledger/lazyledger-core/state"
@@ -161,6 +165,73 @@ func TestAddEvidenceFromConsensus(t *testing.T) {
 	assert.Equal(t, 1, len(evs))
 }
 
+func TestOversizedEvidence(t *testing.T) {
+	height := int64(10)
+	val := types.NewMockPV()
+	stateStore := initializeValidatorState(val, height)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore := initializeBlockStore(memdb.NewDB(), state, val.PrivKey.PubKey().Address())
+	state.ConsensusParams.Evidence.MaxBytes = 4096
+	require.NoError(t, stateStore.Save(state))
+	pool, err := evidence.NewPool(memdb.NewDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	// the evidence includes the parts holding the first row of the proposed block, so its size
+	// depends on the txs
+	makeEvidence := func(txs types.Txs) *types.InvalidDAHeaderEvidence {
+		makeBlock := func(txs types.Txs) *types.Block {
+			block := types.MakeBlock(5, txs, nil, types.IntermediateStateRoots{}, types.Messages{},
+				makeCommit(4, val.PrivKey.PubKey().Address()))
+			block.ValidatorsHash = state.Validators.Hash()
+			block.ProposerAddress = state.Validators.Proposer.Address
+			return block
+		}
+		otherTxs := make(types.Txs, len(txs))
+		for i, tx := range txs {
+			otherTxs[i] = append(types.Tx{tx[0] + 1}, tx[1:]...)
+		}
+		block, other := makeBlock(txs), makeBlock(otherTxs)
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		proposal := types.NewProposal(block.Height, 0, -1, blockID, &other.DataAvailabilityHeader)
+		p, err := proposal.ToProto()
+		require.NoError(t, err)
+		require.NoError(t, val.SignProposal(evidenceChainID, p))
+		proposal.Signature = p.Signature
+		ev, err := types.NewInvalidDAHeaderEvidence(proposal, parts, defaultEvidenceTime.Add(5*time.Minute),
+			state.Validators)
+		require.NoError(t, err)
+		return ev
+	}
+
+	// evidence which doesn't fit into a block is rejected
+	oversizedEv := makeEvidence(types.Txs{tmrand.Bytes(5000)})
+	err = pool.AddEvidenceFromConsensus(oversizedEv)
+	if assert.Error(t, err) {
+		assert.IsType(t, &types.ErrEvidenceOverflow{}, err)
+	}
+	assert.Error(t, pool.CheckEvidence(types.EvidenceList{oversizedEv}))
+	assert.Zero(t, pool.Size())
+
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(8, defaultEvidenceTime, val, evidenceChainID)
+	require.NoError(t, pool.AddEvidenceFromConsensus(ev))
+	evs, size := pool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	require.Equal(t, []types.Evidence{ev}, evs)
+
+	// evidence which doesn't fit into the remaining bytes is skipped, without stopping at it
+	largeEv := makeEvidence(types.Txs{types.Tx("foo")})
+	require.NoError(t, pool.AddEvidenceFromConsensus(largeEv))
+	evs, _ = pool.PendingEvidence(size)
+	assert.Equal(t, []types.Evidence{ev}, evs)
+	evs, _ = pool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	if assert.Len(t, evs, 2) {
+		assert.Equal(t, largeEv.Hash(), evs[0].Hash())
+		assert.Equal(t, ev, evs[1])
+	}
+}
+
 func TestEvidencePoolUpdate(t *testing.T) {
 	height := int64(21)
 	pool, val := defaultTestPool(height)
@@ -174,7 +245,7 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	ev := types.NewMockDuplicateVoteEvidenceWithValidator(height, defaultEvidenceTime.Add(21*time.Minute),
 		val, evidenceChainID)
 	lastCommit := makeCommit(height, val.PrivKey.PubKey().Address())
-	block := types.MakeBlock(height+1, []types.Tx{}, []types.Evidence{ev}, nil, types.Messages{}, lastCommit)
+	block := types.MakeBlock(height+1, []types.Tx{}, []types.Evidence{ev}, types.IntermediateStateRoots{}, types.Messages{}, lastCommit)
 	// update state (partially)
 	state.LastBlockHeight = height + 1
 	state.LastBlockTime = defaultEvidenceTime.Add(22 * time.Minute)
@@ -194,6 +265,107 @@ func TestEvidencePoolUpdate(t *testing.T) {
 	}
 }
 
+func TestListEvidence(t *testing.T) {
+	height := int64(10)
+	val := types.NewMockPV()
+	val2 := types.NewMockPV()
+	evidenceDB := memdb.NewDB()
+	stateStore := initializeValidatorState(val, height)
+	state, err := stateStore.Load()
+	require.NoError(t, err)
+	blockStore := initializeBlockStore(memdb.NewDB(), state, val.PrivKey.PubKey().Address())
+
+	// committed evidence used to be marked with its own height, so its block can't be found
+	marker, err := proto.Marshal(&gogotypes.Int64Value{Value: 1})
+	require.NoError(t, err)
+	require.NoError(t, evidenceDB.Set([]byte("\x000000000000000001/ABCD"), marker))
+
+	pool, err := evidence.NewPool(evidenceDB, stateStore, blockStore)
+	require.NoError(t, err)
+	pool.SetLogger(log.TestingLogger())
+
+	ev3 := types.NewMockDuplicateVoteEvidenceWithValidator(3, defaultEvidenceTime, val, evidenceChainID)
+	ev5 := types.NewMockDuplicateVoteEvidenceWithValidator(5, defaultEvidenceTime, val, evidenceChainID)
+	ev8 := types.NewMockDuplicateVoteEvidenceWithValidator(8, defaultEvidenceTime, val2, evidenceChainID)
+	for _, ev := range []types.Evidence{ev8, ev3, ev5} {
+		require.NoError(t, pool.AddEvidenceFromConsensus(ev))
+	}
+
+	testCases := []struct {
+		name   string
+		filter evidence.Filter
+		expEvs []types.Evidence
+	}{
+		{"all", evidence.Filter{}, []types.Evidence{ev3, ev5, ev8}},
+		{"min height", evidence.Filter{MinHeight: 4}, []types.Evidence{ev5, ev8}},
+		{"max height", evidence.Filter{MaxHeight: 5}, []types.Evidence{ev3, ev5}},
+		{"height range", evidence.Filter{MinHeight: 5, MaxHeight: 5}, []types.Evidence{ev5}},
+		{"validator", evidence.Filter{Validator: val2.PrivKey.PubKey().Address()}, []types.Evidence{ev8}},
+		{"type", evidence.Filter{Type: "DuplicateVoteEvidence"}, []types.Evidence{ev3, ev5, ev8}},
+		{"other type", evidence.Filter{Type: "LightClientAttackEvidence"}, []types.Evidence{}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			evs, total, err := pool.ListPendingEvidence(tc.filter, 0, 10)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expEvs, evs)
+			assert.Equal(t, len(tc.expEvs), total)
+		})
+	}
+
+	// only the page is listed, while all the evidence matching the filter is counted
+	evs, total, err := pool.ListPendingEvidence(evidence.Filter{}, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Evidence{ev5}, evs)
+	assert.Equal(t, 3, total)
+	evs, total, err = pool.ListPendingEvidence(evidence.Filter{Validator: val.PrivKey.PubKey().Address()}, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Evidence{ev3}, evs)
+	assert.Equal(t, 2, total)
+	evs, total, err = pool.ListPendingEvidence(evidence.Filter{}, 3, 1)
+	require.NoError(t, err)
+	assert.Empty(t, evs)
+	assert.Equal(t, 3, total)
+
+	evs, total, err = pool.ListCommittedEvidence(evidence.Filter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, evs)
+	assert.Zero(t, total)
+
+	// once committed, the evidence is listed as committed evidence instead, loaded from its block
+	state.LastBlockHeight = height + 1
+	lastCommit := makeCommit(height, val.PrivKey.PubKey().Address())
+	lastCommit.HeaderHash = tmhash.Sum([]byte("last_header"))
+	lastCommit.BlockID = types.BlockID{
+		Hash:          tmhash.Sum([]byte("last_block")),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("last_block_parts"))},
+	}
+	block, _ := state.MakeBlock(height+1, []types.Tx{}, types.EvidenceList{ev5}, types.IntermediateStateRoots{},
+		types.Messages{}, lastCommit, val.PrivKey.PubKey().Address())
+	block.Header.Version = tmversion.Consensus{Block: version.BlockProtocol, App: 1}
+	blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes),
+		makeCommit(height+1, val.PrivKey.PubKey().Address()))
+	pool.Update(state, types.EvidenceList{ev5})
+
+	evs, _, err = pool.ListPendingEvidence(evidence.Filter{}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Evidence{ev3, ev8}, evs)
+	evs, total, err = pool.ListCommittedEvidence(evidence.Filter{}, 0, 10)
+	require.NoError(t, err)
+	if assert.Len(t, evs, 1) {
+		assert.Equal(t, ev5.Hash(), evs[0].Hash())
+	}
+	assert.Equal(t, 1, total)
+	evs, total, err = pool.ListCommittedEvidence(evidence.Filter{}, 1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, evs)
+	assert.Equal(t, 1, total)
+	evs, _, err = pool.ListCommittedEvidence(evidence.Filter{MinHeight: 6}, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, evs)
+}
+
 func TestVerifyPendingEvidencePasses(t *testing.T) {
 	var height int64 = 1
 	pool, val := defaultTestPool(height)
@@ -400,7 +572,7 @@ func initializeBlockStore(db dbm.DB, state sm.State, valAddr []byte) *store.Bloc
 
 	for i := int64(1); i <= state.LastBlockHeight; i++ {
 		lastCommit := makeCommit(i-1, valAddr)
-		block, _ := state.MakeBlock(i, []types.Tx{}, nil, nil,
+		block, _ := state.MakeBlock(i, []types.Tx{}, nil, types.IntermediateStateRoots{},
 			types.Messages{}, lastCommit, state.Validators.GetProposer().Address)
 		block.Header.Time = defaultEvidenceTime.Add(time.Duration(i) * time.Minute)
 		block.Header.Version = tmversion.Consensus{Block: version.BlockProtocol, App: 1}
@@ -2,17 +2,42 @@ package evidence
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	clist "github.com/lazyledger/lazyledger-core/libs/clist"
 	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/libs/service"
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	"github.com/lazyledger/lazyledger-core/types"
 )
 
+var (
+	_ service.Service = (*Reactor)(nil)
+
+	// ChannelShims contains a map of ChannelDescriptorShim objects, where each
+	// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+	// p2p proto.Message the new p2p Channel is responsible for handling.
+	//
+	//
+	// TODO: Remove once p2p refactor is complete.
+	// ref: https://github.com/tendermint/tendermint/issues/5670
+	ChannelShims = map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		EvidenceChannel: {
+			MsgType: new(tmproto.EvidenceList),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(EvidenceChannel),
+				Priority:            5,
+				RecvMessageCapacity: maxMsgSize,
+			},
+		},
+	}
+)
+
 const (
-	EvidenceChannel = byte(0x38)
+	EvidenceChannel = p2p.ChannelID(0x38)
 
 	maxMsgSize = 1048576 // 1MB TODO make it configurable
 
@@ -21,80 +46,234 @@ const (
 	// Most evidence should be committed in the very next block that is why we wait
 	// just over the block production rate before sending evidence again.
 	broadcastEvidenceIntervalS = 10
-	// If a message fails wait this much before sending it again
-	peerRetryMessageIntervalMS = 100
 )
 
+// PeerManager provides the information the reactor needs about peers that it
+// does not track itself. It is implemented by the consensus reactor.
+type PeerManager interface {
+	// GetHeight returns the latest height the peer is known to be at, or 0 if
+	// it is not known.
+	GetHeight(p2p.PeerID) int64
+}
+
 // Reactor handles evpool evidence broadcasting amongst peers.
 type Reactor struct {
-	p2p.BaseReactor
-	evpool   *Pool
-	eventBus *types.EventBus
+	service.BaseService
+
+	evpool      *Pool
+	eventBus    *types.EventBus
+	peerMgr     PeerManager
+	evidenceCh  *p2p.Channel
+	peerUpdates *p2p.PeerUpdatesCh
+	closeCh     chan struct{}
+
+	peerWG sync.WaitGroup
+
+	mtx          tmsync.Mutex
+	peerRoutines map[string]*tmsync.Closer
 }
 
-// NewReactor returns a new Reactor with the given config and evpool.
-func NewReactor(evpool *Pool) *Reactor {
-	evR := &Reactor{
-		evpool: evpool,
+// NewReactor returns a reference to a new evidence reactor, which implements
+// the service.Service interface. It accepts a logger, a reference to the
+// evidence pool, a PeerManager used to learn the peers' heights, a p2p Channel
+// to gossip evidence on and a channel to listen for peer updates on. The
+// PeerManager may be nil, in which case evidence is sent to peers regardless
+// of their height. Note, the reactor will close all p2p Channels when stopping.
+func NewReactor(
+	logger log.Logger,
+	evpool *Pool,
+	peerMgr PeerManager,
+	evidenceCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdatesCh,
+) *Reactor {
+	r := &Reactor{
+		evpool:       evpool,
+		peerMgr:      peerMgr,
+		evidenceCh:   evidenceCh,
+		peerUpdates:  peerUpdates,
+		closeCh:      make(chan struct{}),
+		peerRoutines: make(map[string]*tmsync.Closer),
 	}
-	evR.BaseReactor = *p2p.NewBaseReactor("Evidence", evR)
-	return evR
+
+	r.BaseService = *service.NewBaseService(logger, "Evidence", r)
+	return r
 }
 
-// SetLogger sets the Logger on the reactor and the underlying Evidence.
-func (evR *Reactor) SetLogger(l log.Logger) {
-	evR.Logger = l
-	evR.evpool.SetLogger(l)
+// SetEventBus implements events.Eventable.
+func (r *Reactor) SetEventBus(b *types.EventBus) {
+	r.eventBus = b
 }
 
-// GetChannels implements Reactor.
-// It returns the list of channels for this reactor.
-func (evR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
-	return []*p2p.ChannelDescriptor{
-		{
-			ID:                  EvidenceChannel,
-			Priority:            5,
-			RecvMessageCapacity: maxMsgSize,
-		},
+// OnStart starts separate go routines for each p2p Channel and listens for
+// envelopes on each. In addition, it also listens for peer updates and handles
+// messages on that p2p channel accordingly. The caller must be sure to execute
+// OnStop to ensure the outbound p2p Channels are closed. No error is returned.
+func (r *Reactor) OnStart() error {
+	go r.processEvidenceCh()
+	go r.processPeerUpdates()
+
+	return nil
+}
+
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {
+	r.mtx.Lock()
+	for _, c := range r.peerRoutines {
+		c.Close()
+	}
+	r.mtx.Unlock()
+
+	// Wait for all spawned evidence broadcasting goroutines to gracefully exit
+	// before closing the p2p Channel they send on.
+	r.peerWG.Wait()
+
+	// Close closeCh to signal to all spawned goroutines to gracefully exit. All
+	// p2p Channels should execute Close().
+	close(r.closeCh)
+
+	// Wait for all p2p Channels to be closed before returning. This ensures we
+	// can easily reason about synchronization of all p2p Channels and ensure no
+	// panics will occur.
+	<-r.evidenceCh.Done()
+	<-r.peerUpdates.Done()
+}
+
+// handleEvidenceMessage handles envelopes sent from peers on the
+// EvidenceChannel. It returns an error only if the Envelope.Message is unknown
+// for this channel or if the given evidence is invalid. This should never be
+// called outside of handleMessage.
+func (r *Reactor) handleEvidenceMessage(envelope p2p.Envelope) error {
+	logger := r.Logger.With("peer", envelope.From.String())
+
+	switch msg := envelope.Message.(type) {
+	case *tmproto.EvidenceList:
+		evis, err := evidenceListFromProto(msg)
+		if err != nil {
+			logger.Error("failed to convert evidence", "err", err)
+			return err
+		}
+
+		for _, ev := range evis {
+			err := r.evpool.AddEvidence(ev)
+			switch err.(type) {
+			case *types.ErrInvalidEvidence:
+				logger.Error(err.Error())
+				// punish peer
+				return err
+			case nil:
+			default:
+				// continue to the next piece of evidence
+				logger.Error("Evidence has not been added", "evidence", evis, "err", err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
 	}
+
+	return nil
 }
 
-// AddPeer implements Reactor.
-func (evR *Reactor) AddPeer(peer p2p.Peer) {
-	go evR.broadcastEvidenceRoutine(peer)
+// handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
+// It will handle errors and any possible panics gracefully. A caller can handle
+// any error returned by sending a PeerError on the respective channel.
+func (r *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			r.Logger.Error("recovering from processing message panic", "err", err)
+		}
+	}()
+
+	switch chID {
+	case EvidenceChannel:
+		err = r.handleEvidenceMessage(envelope)
+
+	default:
+		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
+	}
+
+	return err
 }
 
-// Receive implements Reactor.
-// It adds any received evidence to the evpool.
-// XXX: do not call any methods that can block or incur heavy processing.
-// https://github.com/tendermint/tendermint/issues/2888
-func (evR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	evis, err := decodeMsg(msgBytes)
-	if err != nil {
-		evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		evR.Switch.StopPeerForError(src, err)
-		return
+// processEvidenceCh initiates a blocking process where we listen for and handle
+// envelopes on the EvidenceChannel. Any error encountered during message
+// execution will result in a PeerError being sent on the EvidenceChannel. When
+// the reactor is stopped, we will catch the signal and close the p2p Channel
+// gracefully.
+func (r *Reactor) processEvidenceCh() {
+	defer r.evidenceCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.evidenceCh.In():
+			if err := r.handleMessage(r.evidenceCh.ID(), envelope); err != nil {
+				r.evidenceCh.Error() <- p2p.PeerError{
+					PeerID:   envelope.From,
+					Err:      err,
+					Severity: p2p.PeerErrorSeverityLow,
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on evidence channel; closing...")
+			return
+		}
 	}
+}
+
+// processPeerUpdate processes a PeerUpdate. For new or live peers it will
+// start a goroutine to broadcast evidence to that peer, which is stopped once
+// the peer goes down.
+func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	r.Logger.Debug("received peer update", "peer", peerUpdate.PeerID.String(), "status", peerUpdate.Status)
 
-	for _, ev := range evis {
-		err := evR.evpool.AddEvidence(ev)
-		switch err.(type) {
-		case *types.ErrInvalidEvidence:
-			evR.Logger.Error(err.Error())
-			// punish peer
-			evR.Switch.StopPeerForError(src, err)
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	switch peerUpdate.Status {
+	case p2p.PeerStatusNew, p2p.PeerStatusUp:
+		// Do not allow starting new evidence broadcast loops after reactor shutdown
+		// has been initiated. This can happen after we've manually closed all
+		// peer broadcast loops, but the router still sends in-flight peer updates.
+		if !r.IsRunning() {
 			return
-		case nil:
-		default:
-			// continue to the next piece of evidence
-			evR.Logger.Error("Evidence has not been added", "evidence", evis, "err", err)
+		}
+
+		peerKey := peerUpdate.PeerID.String()
+		if _, ok := r.peerRoutines[peerKey]; !ok {
+			closer := tmsync.NewCloser()
+			r.peerRoutines[peerKey] = closer
+			r.peerWG.Add(1)
+			go r.broadcastEvidenceRoutine(peerUpdate.PeerID, closer)
+		}
+
+	case p2p.PeerStatusDown, p2p.PeerStatusRemoved, p2p.PeerStatusBanned:
+		peerKey := peerUpdate.PeerID.String()
+		if closer, ok := r.peerRoutines[peerKey]; ok {
+			closer.Close()
+			delete(r.peerRoutines, peerKey)
 		}
 	}
 }
 
-// SetEventBus implements events.Eventable.
-func (evR *Reactor) SetEventBus(b *types.EventBus) {
-	evR.eventBus = b
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. When the reactor is stopped, we will catch the signal and
+// close the p2p PeerUpdatesCh gracefully.
+func (r *Reactor) processPeerUpdates() {
+	defer r.peerUpdates.Close()
+
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.processPeerUpdate(peerUpdate)
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on peer updates channel; closing...")
+			return
+		}
+	}
 }
 
 // Modeled after the mempool routine.
@@ -103,7 +282,9 @@ func (evR *Reactor) SetEventBus(b *types.EventBus) {
 // sending available evidence to the peer.
 // - If we're waiting for new evidence and the list is not empty,
 // start iterating from the beginning again.
-func (evR *Reactor) broadcastEvidenceRoutine(peer p2p.Peer) {
+func (r *Reactor) broadcastEvidenceRoutine(peerID p2p.PeerID, closer *tmsync.Closer) {
+	defer r.peerWG.Done()
+
 	var next *clist.CElement
 	for {
 		// This happens because the CElement we were looking at got garbage
@@ -111,29 +292,27 @@ func (evR *Reactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 		// start from the beginning.
 		if next == nil {
 			select {
-			case <-evR.evpool.EvidenceWaitChan(): // Wait until evidence is available
-				if next = evR.evpool.EvidenceFront(); next == nil {
+			case <-r.evpool.EvidenceWaitChan(): // Wait until evidence is available
+				if next = r.evpool.EvidenceFront(); next == nil {
 					continue
 				}
-			case <-peer.Quit():
-				return
-			case <-evR.Quit():
+			case <-closer.Done():
 				return
 			}
 		}
 
 		ev := next.Value.(types.Evidence)
-		evis := evR.prepareEvidenceMessage(peer, ev)
+		evis := r.prepareEvidenceMessage(peerID, ev)
 		if len(evis) > 0 {
-			msgBytes, err := encodeMsg(evis)
+			msg, err := evidenceListToProto(evis)
 			if err != nil {
 				panic(err)
 			}
-			evR.Logger.Debug("Gossiping evidence to peer", "ev", ev, "peer", peer.ID())
-			success := peer.Send(EvidenceChannel, msgBytes)
-			if !success {
-				time.Sleep(peerRetryMessageIntervalMS * time.Millisecond)
-				continue
+			r.Logger.Debug("Gossiping evidence to peer", "ev", ev, "peer", peerID.String())
+			select {
+			case r.evidenceCh.Out() <- p2p.Envelope{To: peerID, Message: msg}:
+			case <-closer.Done():
+				return
 			}
 		}
 
@@ -146,9 +325,7 @@ func (evR *Reactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 		case <-next.NextWaitChan():
 			// see the start of the for loop for nil check
 			next = next.Next()
-		case <-peer.Quit():
-			return
-		case <-evR.Quit():
+		case <-closer.Done():
 			return
 		}
 	}
@@ -156,44 +333,38 @@ func (evR *Reactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 
 // Returns the message to send to the peer, or nil if the evidence is invalid for the peer.
 // If message is nil, we should sleep and try again.
-func (evR Reactor) prepareEvidenceMessage(
-	peer p2p.Peer,
+func (r *Reactor) prepareEvidenceMessage(
+	peerID p2p.PeerID,
 	ev types.Evidence,
 ) (evis []types.Evidence) {
-
-	// make sure the peer is up to date
-	evHeight := ev.Height()
-	peerState, ok := peer.Get(types.PeerStateKey).(PeerState)
-	if !ok {
-		// Peer does not have a state yet. We set it in the consensus reactor, but
-		// when we add peer in Switch, the order we call reactors#AddPeer is
-		// different every time due to us using a map. Sometimes other reactors
-		// will be initialized before the consensus reactor. We should wait a few
-		// milliseconds and retry.
-		return nil
+	if r.peerMgr == nil {
+		return []types.Evidence{ev}
 	}
 
+	// make sure the peer is up to date
+	//
 	// NOTE: We only send evidence to peers where
 	// peerHeight - maxAge < evidenceHeight < peerHeight
 	var (
-		peerHeight   = peerState.GetHeight()
-		params       = evR.evpool.State().ConsensusParams.Evidence
+		evHeight     = ev.Height()
+		peerHeight   = r.peerMgr.GetHeight(peerID)
+		params       = r.evpool.State().ConsensusParams.Evidence
 		ageNumBlocks = peerHeight - evHeight
 	)
 
-	if peerHeight <= evHeight { // peer is behind. sleep while he catches up
+	if peerHeight <= evHeight { // peer is behind (or its height is unknown). sleep while he catches up
 		return nil
 	} else if ageNumBlocks > params.MaxAgeNumBlocks { // evidence is too old relative to the peer, skip
 
 		// NOTE: if evidence is too old for an honest peer, then we're behind and
 		// either it already got committed or it never will!
-		evR.Logger.Info("Not sending peer old evidence",
+		r.Logger.Info("Not sending peer old evidence",
 			"peerHeight", peerHeight,
 			"evHeight", evHeight,
 			"maxAgeNumBlocks", params.MaxAgeNumBlocks,
-			"lastBlockTime", evR.evpool.State().LastBlockTime,
+			"lastBlockTime", r.evpool.State().LastBlockTime,
 			"maxAgeDuration", params.MaxAgeDuration,
-			"peer", peer,
+			"peer", peerID.String(),
 		)
 
 		return nil
@@ -203,14 +374,9 @@ func (evR Reactor) prepareEvidenceMessage(
 	return []types.Evidence{ev}
 }
 
-// PeerState describes the state of a peer.
-type PeerState interface {
-	GetHeight() int64
-}
-
-// encodemsg takes a array of evidence
-// returns the byte encoding of the List Message
-func encodeMsg(evis []types.Evidence) ([]byte, error) {
+// evidenceListToProto takes an array of evidence and returns the proto
+// EvidenceList message.
+func evidenceListToProto(evis []types.Evidence) (*tmproto.EvidenceList, error) {
 	evi := make([]tmproto.Evidence, len(evis))
 	for i := 0; i < len(evis); i++ {
 		ev, err := types.EvidenceToProto(evis[i])
@@ -219,21 +385,13 @@ func encodeMsg(evis []types.Evidence) ([]byte, error) {
 		}
 		evi[i] = *ev
 	}
-	epl := tmproto.EvidenceList{
-		Evidence: evi,
-	}
 
-	return epl.Marshal()
+	return &tmproto.EvidenceList{Evidence: evi}, nil
 }
 
-// decodemsg takes an array of bytes
-// returns an array of evidence
-func decodeMsg(bz []byte) (evis []types.Evidence, err error) {
-	lm := tmproto.EvidenceList{}
-	if err := lm.Unmarshal(bz); err != nil {
-		return nil, err
-	}
-
+// evidenceListFromProto takes a proto EvidenceList message and returns the
+// array of evidence it contains, after validating each of them.
+func evidenceListFromProto(lm *tmproto.EvidenceList) (evis []types.Evidence, err error) {
 	evis = make([]types.Evidence, len(lm.Evidence))
 	for i := 0; i < len(lm.Evidence); i++ {
 		ev, err := types.EvidenceFromProto(&lm.Evidence[i])
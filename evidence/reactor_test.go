@@ -12,7 +12,6 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	cfg "github.com/lazyledger/lazyledger-core/config"
 	"github.com/lazyledger/lazyledger-core/crypto"
 	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
 	"github.com/lazyledger/lazyledger-core/evidence"
@@ -30,12 +29,122 @@ var (
 	timeout     = 120 * time.Second // ridiculously high because CircleCI is slow
 )
 
+type reactorTestSuite struct {
+	reactor *evidence.Reactor
+	pool    *evidence.Pool
+	peerMgr *peerHeights
+
+	peerID p2p.PeerID
+
+	evidenceChannel   *p2p.Channel
+	evidenceInCh      chan p2p.Envelope
+	evidenceOutCh     chan p2p.Envelope
+	evidencePeerErrCh chan p2p.PeerError
+
+	peerUpdatesCh chan p2p.PeerUpdate
+	peerUpdates   *p2p.PeerUpdatesCh
+}
+
+// setup creates an evidence reactor for each of the given state stores. The
+// reactors are not connected to each other; see connectReactors.
+func setup(t *testing.T, stateStores []sm.Store, chBuf uint) []*reactorTestSuite {
+	t.Helper()
+
+	logger := evidenceLogger()
+	evidenceTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suites := make([]*reactorTestSuite, len(stateStores))
+	for i, stateStore := range stateStores {
+		blockStore := &mocks.BlockStore{}
+		blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
+			&types.BlockMeta{Header: types.Header{Time: evidenceTime}},
+		)
+		pool, err := evidence.NewPool(memdb.NewDB(), stateStore, blockStore)
+		require.NoError(t, err)
+		pool.SetLogger(logger.With("validator", i))
+
+		rts := &reactorTestSuite{
+			pool:              pool,
+			peerMgr:           &peerHeights{heights: make(map[string]int64)},
+			peerID:            p2p.PeerID{byte(i + 1)},
+			evidenceInCh:      make(chan p2p.Envelope, chBuf),
+			evidenceOutCh:     make(chan p2p.Envelope, chBuf),
+			evidencePeerErrCh: make(chan p2p.PeerError, chBuf),
+			peerUpdatesCh:     make(chan p2p.PeerUpdate),
+		}
+		rts.peerUpdates = p2p.NewPeerUpdates(rts.peerUpdatesCh)
+
+		rts.evidenceChannel = p2p.NewChannel(
+			evidence.EvidenceChannel,
+			new(tmproto.EvidenceList),
+			rts.evidenceInCh,
+			rts.evidenceOutCh,
+			rts.evidencePeerErrCh,
+		)
+
+		rts.reactor = evidence.NewReactor(
+			logger.With("validator", i),
+			pool,
+			rts.peerMgr,
+			rts.evidenceChannel,
+			rts.peerUpdates,
+		)
+
+		require.NoError(t, rts.reactor.Start())
+		require.True(t, rts.reactor.IsRunning())
+
+		suites[i] = rts
+	}
+
+	t.Cleanup(func() {
+		for _, rts := range suites {
+			require.NoError(t, rts.reactor.Stop())
+			require.False(t, rts.reactor.IsRunning())
+		}
+	})
+
+	return suites
+}
+
+// simulateRouter delivers the envelopes sent by each reactor to the reactors
+// they are addressed to.
+func simulateRouter(suites []*reactorTestSuite) {
+	for _, src := range suites {
+		go func(src *reactorTestSuite) {
+			for envelope := range src.evidenceOutCh {
+				for _, dst := range suites {
+					if dst == src || !(envelope.Broadcast || envelope.To.Equal(dst.peerID)) {
+						continue
+					}
+
+					select {
+					case dst.evidenceInCh <- p2p.Envelope{From: src.peerID, Message: envelope.Message}:
+					case <-dst.evidenceChannel.Done():
+					}
+				}
+			}
+		}(src)
+	}
+}
+
+// connectReactors informs every reactor that all the other reactors are up.
+func connectReactors(suites []*reactorTestSuite) {
+	simulateRouter(suites)
+
+	for _, rts := range suites {
+		for _, peer := range suites {
+			if peer != rts {
+				rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peer.peerID, Status: p2p.PeerStatusUp}
+			}
+		}
+	}
+}
+
 // We have N evidence reactors connected to one another. The first reactor
 // receives a number of evidence at varying heights. We test that all
 // other reactors receive the evidence and add it to their own respective
 // evidence pools.
 func TestReactorBroadcastEvidence(t *testing.T) {
-	config := cfg.TestConfig()
 	N := 7
 
 	// create statedb for everyone
@@ -47,29 +156,27 @@ func TestReactorBroadcastEvidence(t *testing.T) {
 		stateDBs[i] = initializeValidatorState(val, height)
 	}
 
-	// make reactors from statedb
-	reactors, pools := makeAndConnectReactorsAndPools(config, stateDBs)
+	suites := setup(t, stateDBs, 0)
 
 	// set the peer height on each reactor
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			ps := peerState{height}
-			peer.Set(types.PeerStateKey, ps)
+	for _, rts := range suites {
+		for _, peer := range suites {
+			rts.peerMgr.SetHeight(peer.peerID, height)
 		}
 	}
 
+	connectReactors(suites)
+
 	// send a bunch of valid evidence to the first reactor's evpool
 	// and wait for them all to be received in the others
-	evList := sendEvidence(t, pools[0], val, numEvidence)
-	waitForEvidence(t, evList, pools)
+	evList := sendEvidence(t, suites[0].pool, val, numEvidence)
+	waitForEvidence(t, evList, pools(suites))
 }
 
 // We have two evidence reactors connected to one another but are at different heights.
 // Reactor 1 which is ahead receives a number of evidence. It should only send the evidence
 // that is below the height of the peer to that peer.
 func TestReactorSelectiveBroadcast(t *testing.T) {
-	config := cfg.TestConfig()
-
 	val := types.NewMockPV()
 	height1 := int64(numEvidence) + 10
 	height2 := int64(numEvidence) / 2
@@ -78,31 +185,22 @@ func TestReactorSelectiveBroadcast(t *testing.T) {
 	stateDB1 := initializeValidatorState(val, height1)
 	stateDB2 := initializeValidatorState(val, height2)
 
-	// make reactors from statedb
-	reactors, pools := makeAndConnectReactorsAndPools(config, []sm.Store{stateDB1, stateDB2})
+	suites := setup(t, []sm.Store{stateDB1, stateDB2}, 0)
 
-	// set the peer height on each reactor
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			ps := peerState{height1}
-			peer.Set(types.PeerStateKey, ps)
-		}
-	}
+	// the first reactor's peer is at a very small height
+	suites[0].peerMgr.SetHeight(suites[1].peerID, height2)
+	suites[1].peerMgr.SetHeight(suites[0].peerID, height1)
 
-	// update the first reactor peer's height to be very small
-	peer := reactors[0].Switch.Peers().List()[0]
-	ps := peerState{height2}
-	peer.Set(types.PeerStateKey, ps)
+	connectReactors(suites)
 
 	// send a bunch of valid evidence to the first reactor's evpool
-	evList := sendEvidence(t, pools[0], val, numEvidence)
+	evList := sendEvidence(t, suites[0].pool, val, numEvidence)
 
 	// only ones less than the peers height should make it through
-	waitForEvidence(t, evList[:numEvidence/2-1], []*evidence.Pool{pools[1]})
+	waitForEvidence(t, evList[:numEvidence/2-1], []*evidence.Pool{suites[1].pool})
 
-	// peers should still be connected
-	peers := reactors[1].Switch.Peers().List()
-	assert.Equal(t, 1, len(peers))
+	// the peer was not punished for the evidence it sent
+	require.Empty(t, suites[1].evidencePeerErrCh)
 }
 
 // This tests aims to ensure that reactors don't send evidence that they have committed or that ar
@@ -111,8 +209,6 @@ func TestReactorSelectiveBroadcast(t *testing.T) {
 // Second, evidence to a peer that is behind
 // Third, evidence that was pending and became committed just before the peer caught up
 func TestReactorsGossipNoCommittedEvidence(t *testing.T) {
-	config := cfg.TestConfig()
-
 	val := types.NewMockPV()
 	var height int64 = 10
 
@@ -123,22 +219,17 @@ func TestReactorsGossipNoCommittedEvidence(t *testing.T) {
 	require.NoError(t, err)
 	state.LastBlockHeight++
 
-	// make reactors from statedb
-	reactors, pools := makeAndConnectReactorsAndPools(config, []sm.Store{stateDB1, stateDB2})
+	suites := setup(t, []sm.Store{stateDB1, stateDB2}, 0)
+	pools := pools(suites)
 
 	evList := sendEvidence(t, pools[0], val, 2)
 	pools[0].Update(state, evList)
 	require.EqualValues(t, uint32(0), pools[0].Size())
 
-	time.Sleep(200 * time.Millisecond)
+	suites[0].peerMgr.SetHeight(suites[1].peerID, height-2)
+	suites[1].peerMgr.SetHeight(suites[0].peerID, height)
 
-	peer := reactors[0].Switch.Peers().List()[0]
-	ps := peerState{height - 2}
-	peer.Set(types.PeerStateKey, ps)
-
-	peer = reactors[1].Switch.Peers().List()[0]
-	ps = peerState{height}
-	peer.Set(types.PeerStateKey, ps)
+	connectReactors(suites)
 
 	// wait to see that no evidence comes through
 	time.Sleep(600 * time.Millisecond)
@@ -173,9 +264,7 @@ func TestReactorsGossipNoCommittedEvidence(t *testing.T) {
 
 	// now update the state of the second reactor
 	pools[1].Update(state, types.EvidenceList{})
-	peer = reactors[0].Switch.Peers().List()[0]
-	ps = peerState{height}
-	peer.Set(types.PeerStateKey, ps)
+	suites[0].peerMgr.SetHeight(suites[1].peerID, height)
 
 	// wait to see that only two evidence is sent
 	time.Sleep(1800 * time.Millisecond)
@@ -184,6 +273,28 @@ func TestReactorsGossipNoCommittedEvidence(t *testing.T) {
 	assert.EqualValues(t, []types.Evidence{evList[0], evList[1]}, peerEv)
 }
 
+func TestReactorInvalidEvidenceReportsPeerError(t *testing.T) {
+	val := types.NewMockPV()
+	height := int64(numEvidence) + 10
+	suites := setup(t, []sm.Store{initializeValidatorState(val, height)}, 1)
+	rts := suites[0]
+
+	// evidence signed for another chain can't be verified
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(1, defaultEvidenceTime, val, "other_chain")
+	evProto, err := types.EvidenceToProto(ev)
+	require.NoError(t, err)
+
+	rts.evidenceInCh <- p2p.Envelope{
+		From:    p2p.PeerID{0xAA},
+		Message: &tmproto.EvidenceList{Evidence: []tmproto.Evidence{*evProto}},
+	}
+
+	peerErr := <-rts.evidencePeerErrCh
+	require.Error(t, peerErr.Err)
+	require.Equal(t, p2p.PeerID{0xAA}, peerErr.PeerID)
+	require.EqualValues(t, 0, rts.pool.Size())
+}
+
 // evidenceLogger is a TestingLogger which uses a different
 // color for each validator ("validator" key must exist).
 func evidenceLogger() log.Logger {
@@ -197,38 +308,12 @@ func evidenceLogger() log.Logger {
 	})
 }
 
-// connect N evidence reactors through N switches
-func makeAndConnectReactorsAndPools(config *cfg.Config, stateStores []sm.Store) ([]*evidence.Reactor,
-	[]*evidence.Pool) {
-	N := len(stateStores)
-
-	reactors := make([]*evidence.Reactor, N)
-	pools := make([]*evidence.Pool, N)
-	logger := evidenceLogger()
-	evidenceTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
-
-	for i := 0; i < N; i++ {
-		evidenceDB := memdb.NewDB()
-		blockStore := &mocks.BlockStore{}
-		blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
-			&types.BlockMeta{Header: types.Header{Time: evidenceTime}},
-		)
-		pool, err := evidence.NewPool(evidenceDB, stateStores[i], blockStore)
-		if err != nil {
-			panic(err)
-		}
-		pools[i] = pool
-		reactors[i] = evidence.NewReactor(pool)
-		reactors[i].SetLogger(logger.With("validator", i))
+func pools(suites []*reactorTestSuite) []*evidence.Pool {
+	pools := make([]*evidence.Pool, len(suites))
+	for i, rts := range suites {
+		pools[i] = rts.pool
 	}
-
-	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("EVIDENCE", reactors[i])
-		return s
-
-	}, p2p.Connect2Switches)
-
-	return reactors, pools
+	return pools
 }
 
 // wait for all evidence on all reactors
@@ -298,12 +383,22 @@ func sendEvidence(t *testing.T, evpool *evidence.Pool, val types.PrivValidator,
 	return evList
 }
 
-type peerState struct {
-	height int64
+// peerHeights is an evidence.PeerManager with settable peer heights.
+type peerHeights struct {
+	mtx     sync.Mutex
+	heights map[string]int64
+}
+
+func (ph *peerHeights) SetHeight(peerID p2p.PeerID, height int64) {
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	ph.heights[peerID.String()] = height
 }
 
-func (ps peerState) GetHeight() int64 {
-	return ps.height
+func (ph *peerHeights) GetHeight(peerID p2p.PeerID) int64 {
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	return ph.heights[peerID.String()]
 }
 
 func exampleVote(t byte) *types.Vote {
@@ -9,4 +9,5 @@ import (
 type BlockStore interface {
 	LoadBlockMeta(height int64) *types.BlockMeta
 	LoadBlockCommit(height int64) *types.Commit
+	LoadBlockEvidence(height int64) types.EvidenceList
 }
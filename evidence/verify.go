@@ -8,12 +8,14 @@ import (
 	"time"
 
 	"github.com/lazyledger/lazyledger-core/light"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	"github.com/lazyledger/lazyledger-core/types"
 )
 
 // verify verifies the evidence fully by checking:
 // - It has not already been committed
 // - it is sufficiently recent (MaxAge)
+// - it fits into a block (MaxBytes)
 // - it is from a key who was a validator at the given height
 // - it is internally consistent with state
 // - it was properly signed by the alleged equivocator and meets the individual evidence verification requirements
@@ -48,6 +50,11 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		)
 	}
 
+	// check that the evidence fits into a block
+	if err := checkEvidenceSize(evidence, evidenceParams.MaxBytes); err != nil {
+		return err
+	}
+
 	// apply the evidence-specific verification logic
 	switch ev := evidence.(type) {
 	case *types.DuplicateVoteEvidence:
@@ -109,6 +116,14 @@ func (evpool *Pool) verify(evidence types.Evidence) error {
 		}
 
 		return nil
+
+	case *types.InvalidDAHeaderEvidence:
+		valSet, err := evpool.stateDB.LoadValidators(evidence.Height())
+		if err != nil {
+			return err
+		}
+		return VerifyInvalidDAHeader(ev, state.ChainID, valSet)
+
 	default:
 		return fmt.Errorf("unrecognized evidence type: %T", evidence)
 	}
@@ -224,6 +239,74 @@ func VerifyDuplicateVote(e *types.DuplicateVoteEvidence, chainID string, valSet
 	return nil
 }
 
+// VerifyInvalidDAHeader verifies InvalidDAHeaderEvidence against the state of the full node. This
+// involves the following checks:
+//      - the validator is the proposer of the round of the proposal, given the validator set at the
+//        height of the evidence
+//      - the proposal was signed by the proposer
+//      - the shares of the row or column are proven against the proposal, and the root recomputed
+//        from them is the one of the evidence, and differs from the one in the data availability
+//        header of the proposal
+func VerifyInvalidDAHeader(e *types.InvalidDAHeaderEvidence, chainID string, valSet *types.ValidatorSet) error {
+	if e.Proposal.Round > 0 {
+		valSet = valSet.CopyIncrementProposerPriority(e.Proposal.Round)
+	}
+	proposer := valSet.GetProposer()
+	if !bytes.Equal(proposer.Address, e.ProposerAddress) {
+		return fmt.Errorf("address %X was not the proposer of height %d round %d, expected %X",
+			e.ProposerAddress, e.Proposal.Height, e.Proposal.Round, proposer.Address)
+	}
+
+	// validator voting power and total voting power must match
+	if proposer.VotingPower != e.ValidatorPower {
+		return fmt.Errorf("validator power from evidence and our validator set does not match (%d != %d)",
+			e.ValidatorPower, proposer.VotingPower)
+	}
+	if valSet.TotalVotingPower() != e.TotalVotingPower {
+		return fmt.Errorf("total voting power from the evidence and our validator set does not match (%d != %d)",
+			e.TotalVotingPower, valSet.TotalVotingPower())
+	}
+
+	p, err := e.Proposal.ToProto()
+	if err != nil {
+		return err
+	}
+	if !proposer.PubKey.VerifySignature(types.ProposalSignBytes(chainID, p), e.Proposal.Signature) {
+		return errors.New("invalid proposal signature")
+	}
+
+	root, err := e.ProvenRoot()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, e.Root) {
+		return fmt.Errorf("proven root %X does not match the root of the evidence %X", root, e.Root)
+	}
+	signedRoot := e.Proposal.DAHeader.RowsRoots[e.Index]
+	if e.Column {
+		signedRoot = e.Proposal.DAHeader.ColumnRoots[e.Index]
+	}
+	if bytes.Equal(root, signedRoot.Bytes()) {
+		return fmt.Errorf("data availability header of the proposal matches the proven root %X", root)
+	}
+
+	return nil
+}
+
+// checkEvidenceSize returns an error if the evidence on its own takes more than maxBytes of a
+// block, as it could then never be committed.
+func checkEvidenceSize(ev types.Evidence, maxBytes int64) error {
+	evpb, err := types.EvidenceToProto(ev)
+	if err != nil {
+		return fmt.Errorf("unable to convert to proto, err: %w", err)
+	}
+	evList := tmproto.EvidenceList{Evidence: []tmproto.Evidence{*evpb}}
+	if size := int64(evList.Size()); size > maxBytes {
+		return types.NewErrEvidenceOverflow(maxBytes, size)
+	}
+	return nil
+}
+
 func getSignedHeader(blockStore BlockStore, height int64) (*types.SignedHeader, error) {
 	blockMeta := blockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
@@ -386,6 +386,97 @@ func TestVerifyDuplicateVoteEvidence(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestVerifyInvalidDAHeader(t *testing.T) {
+	const chainID = "mychain"
+	val := types.NewMockPV()
+	val2 := types.NewMockPV()
+	valSet := types.NewValidatorSet([]*types.Validator{val.ExtractIntoValidator(10)})
+	valSet2 := types.NewValidatorSet([]*types.Validator{val2.ExtractIntoValidator(10)})
+
+	makeBlock := func(txs types.Txs) *types.Block {
+		block := types.MakeBlock(10, txs, nil, types.IntermediateStateRoots{}, types.Messages{}, types.NewCommit(0, 0, types.BlockID{}, nil))
+		block.ValidatorsHash = valSet.Hash()
+		block.ProposerAddress = valSet.Proposer.Address
+		return block
+	}
+	block, other := makeBlock(types.Txs{types.Tx("foo")}), makeBlock(types.Txs{types.Tx("bar")})
+	parts := block.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+
+	signProposal := func(signer types.PrivValidator, dah *types.DataAvailabilityHeader) *types.Proposal {
+		proposal := types.NewProposal(block.Height, 0, -1, blockID, dah)
+		p, err := proposal.ToProto()
+		require.NoError(t, err)
+		require.NoError(t, signer.SignProposal(chainID, p))
+		proposal.Signature = p.Signature
+		return proposal
+	}
+	makeEvidence := func(signer types.PrivValidator, dah *types.DataAvailabilityHeader) *types.InvalidDAHeaderEvidence {
+		ev, err := types.NewInvalidDAHeaderEvidence(signProposal(signer, dah), parts, defaultEvidenceTime, valSet)
+		require.NoError(t, err)
+		return ev
+	}
+
+	goodEv := makeEvidence(val, &other.DataAvailabilityHeader)
+	assert.NoError(t, evidence.VerifyInvalidDAHeader(goodEv, chainID, valSet))
+
+	// wrong chain id
+	assert.Error(t, evidence.VerifyInvalidDAHeader(goodEv, "mychain2", valSet))
+	// not the proposer
+	assert.Error(t, evidence.VerifyInvalidDAHeader(goodEv, chainID, valSet2))
+	// signed by the wrong key
+	assert.Error(t, evidence.VerifyInvalidDAHeader(makeEvidence(val2, &other.DataAvailabilityHeader), chainID, valSet))
+	// the data availability header matches the block data
+	matchingEv := makeEvidence(val, &other.DataAvailabilityHeader)
+	matchingEv.Proposal = signProposal(val, &block.DataAvailabilityHeader)
+	assert.Error(t, evidence.VerifyInvalidDAHeader(matchingEv, chainID, valSet))
+	// the root differs from the one recomputed from the shares
+	badRootEv := makeEvidence(val, &other.DataAvailabilityHeader)
+	badRootEv.Root = other.DataAvailabilityHeader.RowsRoots[0].Bytes()
+	assert.Error(t, evidence.VerifyInvalidDAHeader(badRootEv, chainID, valSet))
+	// the shares aren't proven against the part set header
+	badPartEv := makeEvidence(val, &other.DataAvailabilityHeader)
+	badPart := &types.Part{Index: 0, Bytes: append([]byte{}, parts.GetPart(0).Bytes...), Proof: parts.GetPart(0).Proof}
+	badPart.Bytes[10] ^= 0xFF
+	badPartEv.BlockParts[0] = badPart
+	assert.Error(t, evidence.VerifyInvalidDAHeader(badPartEv, chainID, valSet))
+
+	// a column root is proven by the shares of the column
+	columnDAH := &types.DataAvailabilityHeader{
+		RowsRoots:   block.DataAvailabilityHeader.RowsRoots,
+		ColumnRoots: types.NmtRoots{other.DataAvailabilityHeader.ColumnRoots[0], block.DataAvailabilityHeader.ColumnRoots[1]},
+	}
+	columnEv := makeEvidence(val, columnDAH)
+	assert.True(t, columnEv.Column)
+	assert.NoError(t, evidence.VerifyInvalidDAHeader(columnEv, chainID, valSet))
+
+	// validator power and total voting power must match
+	badPowerEv := makeEvidence(val, &other.DataAvailabilityHeader)
+	badPowerEv.ValidatorPower = 5
+	assert.Error(t, evidence.VerifyInvalidDAHeader(badPowerEv, chainID, valSet))
+	badTotalEv := makeEvidence(val, &other.DataAvailabilityHeader)
+	badTotalEv.TotalVotingPower = 20
+	assert.Error(t, evidence.VerifyInvalidDAHeader(badTotalEv, chainID, valSet))
+
+	// the pool checks the evidence against the validator set at its height
+	state := sm.State{
+		ChainID:         chainID,
+		LastBlockTime:   defaultEvidenceTime.Add(1 * time.Minute),
+		LastBlockHeight: 11,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", int64(10)).Return(valSet, nil)
+	stateStore.On("Load").Return(state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(10)).Return(&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}})
+
+	pool, err := evidence.NewPool(memdb.NewDB(), stateStore, blockStore)
+	require.NoError(t, err)
+	assert.NoError(t, pool.CheckEvidence(types.EvidenceList{goodEv}))
+	assert.Error(t, pool.CheckEvidence(types.EvidenceList{badPowerEv}))
+}
+
 func makeVote(
 	t *testing.T, val types.PrivValidator, chainID string, valIndex int32, height int64,
 	round int32, step int, blockID types.BlockID, time time.Time) *types.Vote {
@@ -0,0 +1,88 @@
+package ipfs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ipfs/go-ipfs/plugin"
+	"github.com/ipfs/go-ipfs/repo"
+	"github.com/ipfs/go-ipfs/repo/fsrepo"
+
+	"github.com/lazyledger/lazyledger-core/libs/db/badgerdb"
+)
+
+// badgerDatastoreType is the datastore type name of Badger v3 in the IPFS datastore spec.
+const badgerDatastoreType = "badger3ds"
+
+// badgerDatastore is the IPFS datastore plugin backing the IPFS repo by Badger v3
+// through our BadgerDB wrapper, so that it shares the engine and the tuning with tendermint DBs.
+type badgerDatastore struct{}
+
+var _ plugin.PluginDatastore = (*badgerDatastore)(nil)
+
+func (*badgerDatastore) Name() string {
+	return "ds-badger3"
+}
+
+func (*badgerDatastore) Version() string {
+	return "0.0.0"
+}
+
+func (*badgerDatastore) Init(_ *plugin.Environment) error {
+	return nil
+}
+
+func (*badgerDatastore) DatastoreTypeName() string {
+	return badgerDatastoreType
+}
+
+func (*badgerDatastore) DatastoreConfigParser() fsrepo.ConfigFromMap {
+	return func(params map[string]interface{}) (fsrepo.DatastoreConfig, error) {
+		path, ok := params["path"].(string)
+		if !ok {
+			return nil, fmt.Errorf("'path' field is missing or not string")
+		}
+
+		var syncWrites bool
+		if sw, ok := params["syncWrites"]; ok {
+			if syncWrites, ok = sw.(bool); !ok {
+				return nil, fmt.Errorf("'syncWrites' field was not a boolean")
+			}
+		}
+
+		return &badgerDatastoreConfig{path: path, syncWrites: syncWrites}, nil
+	}
+}
+
+type badgerDatastoreConfig struct {
+	path       string
+	syncWrites bool
+}
+
+func (c *badgerDatastoreConfig) DiskSpec() fsrepo.DiskSpec {
+	return map[string]interface{}{
+		"type": badgerDatastoreType,
+		"path": c.path,
+	}
+}
+
+func (c *badgerDatastoreConfig) Create(path string) (repo.Datastore, error) {
+	p := c.path
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(path, p)
+	}
+
+	if err := os.MkdirAll(p, 0755); err != nil {
+		return nil, err
+	}
+
+	opts := badgerdb.DefaultOptions(p)
+	opts.SyncWrites = c.syncWrites
+	db, err := badgerdb.NewDBWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDatastore(db), nil
+}
@@ -1,6 +1,25 @@
 package ipfs
 
-import "path/filepath"
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Modes the IPFS node can run in.
+const (
+	// ModeEmbedded runs the full IPFS node with libp2p networking within the same process.
+	ModeEmbedded = "embedded"
+	// ModeInProcess keeps the DAG in the node's DB without any IPFS networking.
+	ModeInProcess = "in-process"
+)
+
+// Datastores the IPFS repo can be backed by.
+const (
+	// DatastoreBadger is Badger v1 used by IPFS by default.
+	DatastoreBadger = "badger"
+	// DatastoreBadger3 is Badger v3 shared with tendermint DBs.
+	DatastoreBadger3 = "badger3"
+)
 
 // Config defines a subset of the IPFS config that will be passed to the IPFS init and IPFS node (as a service)
 // spun up by the tendermint node.
@@ -11,6 +30,14 @@ type Config struct {
 	// The default is ~/.tendermint/ipfs.
 	RepoPath string `mapstructure:"repo-path"`
 	ServeAPI bool   `mapstructure:"serve-api"`
+	// Mode the IPFS node runs in: "embedded" or "in-process".
+	Mode string `mapstructure:"mode"`
+	// Datastore the IPFS repo is backed by: "badger" or "badger3".
+	// NOTE: It is only applied on the repo initialization.
+	Datastore string `mapstructure:"datastore"`
+	// BridgePeers advertises the libp2p addresses of the IPFS node to tendermint peers
+	// and dials the IPFS nodes of connected tendermint peers.
+	BridgePeers bool `mapstructure:"bridge-peers"`
 }
 
 // DefaultConfig returns a default config different from the default IPFS config.
@@ -18,8 +45,10 @@ type Config struct {
 // locally for testing purposes.
 func DefaultConfig() *Config {
 	return &Config{
-		RepoPath: "ipfs",
-		ServeAPI: false,
+		RepoPath:  "ipfs",
+		ServeAPI:  false,
+		Mode:      ModeEmbedded,
+		Datastore: DatastoreBadger,
 	}
 }
 
@@ -29,3 +58,24 @@ func (cfg *Config) Path() string {
 	}
 	return filepath.Join(cfg.RootDir, cfg.RepoPath)
 }
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *Config) ValidateBasic() error {
+	switch cfg.Mode {
+	case ModeEmbedded:
+	case ModeInProcess:
+		if cfg.BridgePeers {
+			return fmt.Errorf("bridge-peers requires %q mode", ModeEmbedded)
+		}
+	default:
+		return fmt.Errorf("unknown mode %q", cfg.Mode)
+	}
+
+	switch cfg.Datastore {
+	case DatastoreBadger, DatastoreBadger3:
+		return nil
+	default:
+		return fmt.Errorf("unknown datastore %q", cfg.Datastore)
+	}
+}
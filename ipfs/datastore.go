@@ -0,0 +1,138 @@
+package ipfs
+
+import (
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	dbm "github.com/lazyledger/lazyledger-core/libs/db"
+)
+
+// Datastore implements IPFS datastore on top of tendermint's DB interface.
+// It allows to back the IPFS repo by the same storage engine(and even the same DB instance)
+// tendermint uses, reducing the number of storage engines and file handles per node.
+// Use namespace.Wrap to store IPFS data under a prefix of a shared DB.
+type Datastore struct {
+	db dbm.DB
+}
+
+var _ ds.Batching = (*Datastore)(nil)
+
+// NewDatastore wraps the given DB into the IPFS datastore.
+func NewDatastore(db dbm.DB) *Datastore {
+	return &Datastore{db: db}
+}
+
+// Get implements ds.Read.
+func (d *Datastore) Get(key ds.Key) ([]byte, error) {
+	val, err := d.db.Get(key.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, ds.ErrNotFound
+	}
+	return val, nil
+}
+
+// Has implements ds.Read.
+func (d *Datastore) Has(key ds.Key) (bool, error) {
+	return d.db.Has(key.Bytes())
+}
+
+// GetSize implements ds.Read.
+func (d *Datastore) GetSize(key ds.Key) (int, error) {
+	val, err := d.Get(key)
+	if err != nil {
+		return -1, err
+	}
+	return len(val), nil
+}
+
+// Query implements ds.Read.
+// Only the prefix is handled by the underlying DB, the rest is applied naively.
+func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
+	var prefix []byte
+	if q.Prefix != "" {
+		prefix = ds.NewKey(q.Prefix).Bytes()
+	}
+	it, err := dbm.IteratePrefix(d.db, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed bool
+	res := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			if failed {
+				return dsq.Result{}, false
+			}
+			if err := it.Error(); err != nil {
+				failed = true
+				return dsq.Result{Error: err}, true
+			}
+			if !it.Valid() {
+				return dsq.Result{}, false
+			}
+			defer it.Next()
+
+			val := it.Value()
+			entry := dsq.Entry{Key: string(it.Key()), Size: len(val)}
+			if !q.KeysOnly {
+				entry.Value = val
+			}
+			return dsq.Result{Entry: entry}, true
+		},
+		Close: it.Close,
+	})
+	return dsq.NaiveQueryApply(q, res), nil
+}
+
+// Put implements ds.Write.
+func (d *Datastore) Put(key ds.Key, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	return d.db.Set(key.Bytes(), value)
+}
+
+// Delete implements ds.Write.
+func (d *Datastore) Delete(key ds.Key) error {
+	return d.db.Delete(key.Bytes())
+}
+
+// Sync implements ds.Datastore.
+// It is a no-op, as the underlying DB is responsible for persisting writes.
+func (d *Datastore) Sync(ds.Key) error {
+	return nil
+}
+
+// Close implements ds.Datastore.
+// It closes the underlying DB.
+func (d *Datastore) Close() error {
+	return d.db.Close()
+}
+
+// Batch implements ds.Batching.
+func (d *Datastore) Batch() (ds.Batch, error) {
+	return &batch{b: d.db.NewBatch()}, nil
+}
+
+type batch struct {
+	b dbm.Batch
+}
+
+func (b *batch) Put(key ds.Key, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	return b.b.Set(key.Bytes(), value)
+}
+
+func (b *batch) Delete(key ds.Key) error {
+	return b.b.Delete(key.Bytes())
+}
+
+func (b *batch) Commit() error {
+	defer b.b.Close()
+	return b.b.Write()
+}
@@ -0,0 +1,25 @@
+package ipfs
+
+import (
+	"testing"
+
+	dstest "github.com/ipfs/go-datastore/test"
+
+	"github.com/lazyledger/lazyledger-core/libs/db/badgerdb"
+	"github.com/lazyledger/lazyledger-core/libs/db/memdb"
+)
+
+func TestDatastore(t *testing.T) {
+	t.Run("memdb", func(t *testing.T) {
+		dstest.SubtestAll(t, NewDatastore(memdb.NewDB()))
+	})
+	t.Run("badgerdb", func(t *testing.T) {
+		db, err := badgerdb.NewInMemoryDB()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ds := NewDatastore(db)
+		defer ds.Close()
+		dstest.SubtestAll(t, ds)
+	})
+}
@@ -12,7 +12,8 @@ import (
 var BootstrapPeers, _ = ipfscfg.DefaultBootstrapPeers()
 
 // DefaultFullNodeConfig provides default embedded IPFS configuration for FullNode
-func DefaultFullNodeConfig() (*ipfscfg.Config, error) {
+// with the repo backed by the given datastore.
+func DefaultFullNodeConfig(datastore string) (*ipfscfg.Config, error) {
 	identity, err := ipfscfg.CreateIdentity(os.Stdout, []options.KeyGenerateOption{
 		options.Key.Type(options.Ed25519Key),
 	})
@@ -103,17 +104,8 @@ func DefaultFullNodeConfig() (*ipfscfg.Config, error) {
 		},
 		// Persistent KV store configuration
 		Datastore: ipfscfg.Datastore{
-			// Configuration for badger kv we default to
-			Spec: map[string]interface{}{
-				"type":   "measure",
-				"prefix": "badger.datastore",
-				"child": map[string]interface{}{
-					"type":       "badgerds",
-					"path":       "badgerds",
-					"syncWrites": false,
-					"truncate":   true,
-				},
-			},
+			// Configuration for the badger kv
+			Spec: datastoreSpec(datastore),
 			// we don't use bloom filtered blockstore
 			BloomFilterSize: 0,
 			// we don't use GC so values below does not have any affect
@@ -181,6 +173,32 @@ func DefaultFullNodeConfig() (*ipfscfg.Config, error) {
 	return conf, err
 }
 
+// datastoreSpec returns IPFS datastore spec for the given datastore.
+func datastoreSpec(datastore string) map[string]interface{} {
+	var child map[string]interface{}
+	switch datastore {
+	case DatastoreBadger3:
+		child = map[string]interface{}{
+			"type":       badgerDatastoreType,
+			"path":       "badger3ds",
+			"syncWrites": false,
+		}
+	default:
+		child = map[string]interface{}{
+			"type":       "badgerds",
+			"path":       "badgerds",
+			"syncWrites": false,
+			"truncate":   true,
+		}
+	}
+
+	return map[string]interface{}{
+		"type":   "measure",
+		"prefix": "badger.datastore",
+		"child":  child,
+	}
+}
+
 // anonymous type aliases to keep default configuration succinct
 type (
 	//nolint
@@ -23,7 +23,7 @@ import (
 // Embedded is the provider that embeds IPFS node within the same process.
 // It also returns closable for graceful node shutdown.
 func Embedded(init bool, cfg *Config, logger log.Logger) NodeProvider {
-	return func() (*core.IpfsNode, error) {
+	return func() (Node, error) {
 		path := cfg.Path()
 		defer os.Setenv(ipfscfg.EnvDir, path)
 
@@ -33,7 +33,7 @@ func Embedded(init bool, cfg *Config, logger log.Logger) NodeProvider {
 		}
 		// Init Repo if requested
 		if init {
-			if err := InitRepo(path, logger); err != nil {
+			if err := InitRepoWithConfig(cfg, logger); err != nil {
 				return nil, err
 			}
 		}
@@ -78,7 +78,7 @@ func Embedded(init bool, cfg *Config, logger log.Logger) NodeProvider {
 		}
 
 		logger.Info("Successfully created embedded IPFS node", "ipfs-repo", path)
-		return node, nil
+		return newFullNode(node), nil
 	}
 }
 
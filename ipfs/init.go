@@ -7,9 +7,19 @@ import (
 	tmos "github.com/lazyledger/lazyledger-core/libs/os"
 )
 
-// InitRepo initialize IPFS repository under the given path.
+// InitRepo initialize IPFS repository under the given path with the default datastore.
 // It does nothing if repo is already created.
 func InitRepo(path string, logger log.Logger) error {
+	return initRepo(path, DefaultConfig().Datastore, logger)
+}
+
+// InitRepoWithConfig initialize IPFS repository under the path and with the datastore from the
+// given config. It does nothing if repo is already created.
+func InitRepoWithConfig(cfg *Config, logger log.Logger) error {
+	return initRepo(cfg.Path(), cfg.Datastore, logger)
+}
+
+func initRepo(path, datastore string, logger log.Logger) error {
 	if fsrepo.IsInitialized(path) {
 		logger.Info("IPFS is already initialized", "ipfs-path", path)
 		return nil
@@ -24,12 +34,12 @@ func InitRepo(path string, logger log.Logger) error {
 	}
 
 	// TODO: Define node types, pass a node type as param and get relative config instead
-	cfg, err := DefaultFullNodeConfig()
+	ipfsCfg, err := DefaultFullNodeConfig(datastore)
 	if err != nil {
 		return err
 	}
 
-	if err := fsrepo.Init(path, cfg); err != nil {
+	if err := fsrepo.Init(path, ipfsCfg); err != nil {
 		return err
 	}
 
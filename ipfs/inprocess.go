@@ -0,0 +1,52 @@
+package ipfs
+
+import (
+	"github.com/ipfs/go-blockservice"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p-core/routing"
+
+	"github.com/lazyledger/lazyledger-core/ipfs/plugin"
+	dbm "github.com/lazyledger/lazyledger-core/libs/db"
+)
+
+// InProcess is the provider of the node which keeps the DAG in the given DB and does not
+// run any IPFS networking, i.e. libp2p, DHT and bitswap. Block data can only be retrieved
+// from the local DB. This is useful for single validator deployments and tests.
+// The given ContentRouting is used to announce stored data and defaults to the no-op one if nil.
+func InProcess(db dbm.DB, croute routing.ContentRouting) NodeProvider {
+	return func() (Node, error) {
+		plugin.EnableNMT()
+
+		if croute == nil {
+			croute = MockRouting()
+		}
+
+		bs := blockstore.NewBlockstore(dssync.MutexWrap(NewDatastore(db)))
+		return &inProcessNode{
+			dag:    merkledag.NewDAGService(blockservice.New(bs, nil)),
+			croute: croute,
+			db:     db,
+		}, nil
+	}
+}
+
+type inProcessNode struct {
+	dag    ipld.DAGService
+	croute routing.ContentRouting
+	db     dbm.DB
+}
+
+func (n *inProcessNode) DAG() ipld.DAGService {
+	return n.dag
+}
+
+func (n *inProcessNode) Routing() routing.ContentRouting {
+	return n.croute
+}
+
+func (n *inProcessNode) Close() error {
+	return n.db.Close()
+}
@@ -0,0 +1,35 @@
+package ipfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lazyledger/rsmt2d"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/libs/db/memdb"
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/p2p/ipld"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+func TestInProcess(t *testing.T) {
+	nd, err := InProcess(memdb.NewDB(), nil)()
+	require.NoError(t, err)
+	defer nd.Close()
+
+	txs := types.Txs{types.Tx("foo"), types.Tx("bar"), types.Tx("baz")}
+	block := types.MakeBlock(1, txs, nil, types.IntermediateStateRoots{}, types.Messages{}, &types.Commit{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = ipld.PutBlock(ctx, nd.DAG(), block, nd.Routing(), log.TestingLogger())
+	require.NoError(t, err)
+
+	data, err := ipld.RetrieveBlockData(ctx, &block.DataAvailabilityHeader, nd.DAG(), rsmt2d.NewRSGF8Codec())
+	require.NoError(t, err)
+	assert.Equal(t, block.Data.Txs, data.Txs)
+}
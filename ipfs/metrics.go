@@ -0,0 +1,90 @@
+package ipfs
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "ipfs"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Size of the IPFS repo in bytes.
+	RepoSizeBytes metrics.Gauge
+	// Number of blocks received by bitswap.
+	BitswapBlocksReceived metrics.Counter
+	// Number of blocks sent by bitswap.
+	BitswapBlocksSent metrics.Counter
+	// Number of peers in the DHT routing table.
+	DHTRoutingTableSize metrics.Gauge
+	// Number of CIDs being provided to the network.
+	ProvideQueueLength metrics.Gauge
+	// Number of connected libp2p peers.
+	Peers metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RepoSizeBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "repo_size_bytes",
+			Help:      "Size of the IPFS repo in bytes.",
+		}, labels).With(labelsAndValues...),
+		BitswapBlocksReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "bitswap_blocks_received_total",
+			Help:      "Number of blocks received by bitswap.",
+		}, labels).With(labelsAndValues...),
+		BitswapBlocksSent: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "bitswap_blocks_sent_total",
+			Help:      "Number of blocks sent by bitswap.",
+		}, labels).With(labelsAndValues...),
+		DHTRoutingTableSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dht_routing_table_size",
+			Help:      "Number of peers in the DHT routing table.",
+		}, append(labels, "dht")).With(labelsAndValues...),
+		ProvideQueueLength: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "provide_queue_length",
+			Help:      "Number of CIDs being provided to the network.",
+		}, labels).With(labelsAndValues...),
+		Peers: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peers",
+			Help:      "Number of connected libp2p peers.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RepoSizeBytes:         discard.NewGauge(),
+		BitswapBlocksReceived: discard.NewCounter(),
+		BitswapBlocksSent:     discard.NewCounter(),
+		DHTRoutingTableSize:   discard.NewGauge(),
+		ProvideQueueLength:    discard.NewGauge(),
+		Peers:                 discard.NewGauge(),
+	}
+}
@@ -14,7 +14,7 @@ import (
 
 // Mock provides simple mock IPFS API useful for testing
 func Mock() NodeProvider {
-	return func() (*core.IpfsNode, error) {
+	return func() (Node, error) {
 		plugin.EnableNMT()
 
 		nd, err := MockNode()
@@ -22,15 +22,48 @@ func Mock() NodeProvider {
 			return nil, err
 		}
 
-		return nd, nil
+		return newFullNode(nd), nil
+	}
+}
+
+// MockNetwork provides mock IPFS APIs which all share the given mock network,
+// so that the nodes can exchange IPLD data with each other. This allows
+// simulating a whole network of nodes in a single process. Every new node is
+// linked, using the network's link defaults, and connected to all the nodes
+// created before it.
+func MockNetwork(mn mocknet.Mocknet) NodeProvider {
+	return func() (Node, error) {
+		plugin.EnableNMT()
+
+		nd, err := mockNode(mn)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range mn.Peers() {
+			if p == nd.Identity {
+				continue
+			}
+			if _, err := mn.LinkPeers(nd.Identity, p); err != nil {
+				return nil, err
+			}
+			if _, err := mn.ConnectPeers(nd.Identity, p); err != nil {
+				return nil, err
+			}
+		}
+
+		return newFullNode(nd), nil
 	}
 }
 
 func MockNode() (*core.IpfsNode, error) {
-	ctx := context.TODO()
-	nd, err := core.NewNode(ctx, &core.BuildCfg{
+	return mockNode(mocknet.New(context.TODO()))
+}
+
+func mockNode(mn mocknet.Mocknet) (*core.IpfsNode, error) {
+	nd, err := core.NewNode(context.TODO(), &core.BuildCfg{
 		Online: true,
-		Host:   coremock.MockHostOption(mocknet.New(ctx)),
+		Host:   coremock.MockHostOption(mn),
 	})
 	if err != nil {
 		return nil, err
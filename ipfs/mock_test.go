@@ -0,0 +1,36 @@
+package ipfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-merkledag"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockNetwork(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mn := mocknet.New(ctx)
+	mn.SetLinkDefaults(mocknet.LinkOptions{Latency: 10 * time.Millisecond})
+
+	provider := MockNetwork(mn)
+	nd1, err := provider()
+	require.NoError(t, err)
+	t.Cleanup(func() { nd1.Close() })
+	nd2, err := provider()
+	require.NoError(t, err)
+	t.Cleanup(func() { nd2.Close() })
+
+	// the block is only stored by the first node and fetched over the network
+	blk := merkledag.NewRawNode([]byte("shared over the mock network"))
+	require.NoError(t, nd1.DAG().Add(ctx, blk))
+
+	got, err := nd2.DAG().Get(ctx, blk.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, blk.RawData(), got.RawData())
+}
@@ -35,6 +35,11 @@ func plugins(path string) (err error) {
 			return
 		}
 
+		if err = ldr.Load(&badgerDatastore{}); err != nil {
+			err = fmt.Errorf("error loading Badger v3 datastore plugin: %w", err)
+			return
+		}
+
 		if err = ldr.Initialize(); err != nil {
 			err = fmt.Errorf("error initializing plugins:%w", err)
 			return
@@ -1,8 +1,124 @@
 package ipfs
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/ipfs/go-bitswap"
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipfs/core"
+	ipld "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/routing"
 )
 
+// Node is the subset of IPFS node functionality tendermint relies on.
+type Node interface {
+	io.Closer
+
+	// DAG returns the DAGService used to store and retrieve block data.
+	DAG() ipld.DAGService
+	// Routing returns the ContentRouting used to announce stored block data to the network.
+	Routing() routing.ContentRouting
+}
+
+// Networked is implemented by the Nodes running libp2p networking.
+type Networked interface {
+	// Host returns the libp2p host of the node.
+	Host() host.Host
+}
+
 // NodeProvider initializes and returns an IPFS node
-type NodeProvider func() (*core.IpfsNode, error)
+type NodeProvider func() (Node, error)
+
+// fullNode is the Node backed by the full IPFS node with libp2p networking.
+type fullNode struct {
+	nd     *core.IpfsNode
+	croute *countingRouting
+
+	// bitswap stats are cumulative, only their increase is reported
+	blocksReceived, blocksSent countTracker
+}
+
+var (
+	_ Networked       = (*fullNode)(nil)
+	_ metricsReporter = (*fullNode)(nil)
+)
+
+func newFullNode(nd *core.IpfsNode) *fullNode {
+	return &fullNode{
+		nd:     nd,
+		croute: &countingRouting{ContentRouting: nd.Routing},
+	}
+}
+
+func (n *fullNode) DAG() ipld.DAGService {
+	return n.nd.DAG
+}
+
+func (n *fullNode) Routing() routing.ContentRouting {
+	return n.croute
+}
+
+func (n *fullNode) Host() host.Host {
+	return n.nd.PeerHost
+}
+
+func (n *fullNode) Close() error {
+	return n.nd.Close()
+}
+
+// reportMetrics records the node stats to m. A stat that can't be read doesn't prevent the others
+// from being recorded; the first error is returned once all the stats were processed.
+func (n *fullNode) reportMetrics(m *Metrics) error {
+	var firstErr error
+	size, err := n.nd.Repo.GetStorageUsage()
+	if err != nil {
+		firstErr = fmt.Errorf("failed to get storage usage: %w", err)
+	} else {
+		m.RepoSizeBytes.Set(float64(size))
+	}
+
+	if bs, ok := n.nd.Exchange.(*bitswap.Bitswap); ok {
+		st, err := bs.Stat()
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to get bitswap stats: %w", err)
+		}
+		if err == nil {
+			n.blocksReceived.add(m.BitswapBlocksReceived, st.BlocksReceived)
+			n.blocksSent.add(m.BitswapBlocksSent, st.BlocksSent)
+		}
+	}
+
+	if n.nd.DHT != nil {
+		m.DHTRoutingTableSize.With("dht", "wan").Set(float64(n.nd.DHT.WAN.RoutingTable().Size()))
+		m.DHTRoutingTableSize.With("dht", "lan").Set(float64(n.nd.DHT.LAN.RoutingTable().Size()))
+	}
+
+	if n.nd.PeerHost != nil {
+		m.Peers.Set(float64(len(n.nd.PeerHost.Network().Peers())))
+	}
+
+	m.ProvideQueueLength.Set(float64(n.croute.pending()))
+	return firstErr
+}
+
+// countingRouting wraps the ContentRouting to keep track of the number of
+// CIDs being provided to the network.
+type countingRouting struct {
+	routing.ContentRouting
+
+	inFlight int64
+}
+
+func (r *countingRouting) Provide(ctx context.Context, id cid.Cid, announce bool) error {
+	atomic.AddInt64(&r.inFlight, 1)
+	defer atomic.AddInt64(&r.inFlight, -1)
+	return r.ContentRouting.Provide(ctx, id, announce)
+}
+
+func (r *countingRouting) pending() int64 {
+	return atomic.LoadInt64(&r.inFlight)
+}
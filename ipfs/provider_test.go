@@ -0,0 +1,81 @@
+package ipfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/routing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/libs/log"
+)
+
+func TestMetricsReporterFullNode(t *testing.T) {
+	nd, err := Mock()()
+	require.NoError(t, err)
+	t.Cleanup(func() { nd.Close() })
+
+	_, ok := nd.(Networked)
+	assert.True(t, ok)
+
+	reporter, ok := nd.(metricsReporter)
+	require.True(t, ok)
+	assert.NoError(t, reporter.reportMetrics(NopMetrics()))
+
+	r := NewMetricsReporter(nd, NopMetrics(), log.TestingLogger())
+	require.NoError(t, r.Start())
+	require.NoError(t, r.Stop())
+}
+
+func TestCountingRouting(t *testing.T) {
+	release := make(chan struct{})
+	croute := &countingRouting{ContentRouting: &blockingRouting{
+		ContentRouting: MockRouting(),
+		release:        release,
+	}}
+
+	id, err := cid.Decode("bafkqaaa")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, croute.Provide(context.Background(), id, true))
+	}()
+
+	assert.Eventually(t, func() bool { return croute.pending() == 1 }, time.Second, 10*time.Millisecond)
+	close(release)
+	<-done
+	assert.EqualValues(t, 0, croute.pending())
+}
+
+// blockingRouting blocks providing until released.
+type blockingRouting struct {
+	routing.ContentRouting
+
+	release chan struct{}
+}
+
+func (r *blockingRouting) Provide(context.Context, cid.Cid, bool) error {
+	<-r.release
+	return nil
+}
+
+func TestCountTracker(t *testing.T) {
+	c := generic.NewCounter("blocks")
+	var tracker countTracker
+
+	tracker.add(c, 5)
+	tracker.add(c, 5)
+	tracker.add(c, 8)
+	assert.EqualValues(t, 8, c.Value())
+
+	// the count restarted
+	tracker.add(c, 2)
+	assert.EqualValues(t, 10, c.Value())
+}
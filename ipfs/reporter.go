@@ -0,0 +1,94 @@
+package ipfs
+
+import (
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/libs/service"
+)
+
+// defaultReportInterval is how often the IPFS node stats are recorded to Metrics.
+const defaultReportInterval = 10 * time.Second
+
+// metricsReporter is implemented by Nodes able to record their stats to Metrics.
+type metricsReporter interface {
+	reportMetrics(*Metrics) error
+}
+
+// countTracker turns a cumulative count read from the node into increments of
+// a Counter.
+type countTracker struct {
+	last uint64
+}
+
+// add adds to c the increase of total since the last call. A total lower than
+// the previous one, e.g. after a restart of the node, is taken as a new start.
+func (t *countTracker) add(c metrics.Counter, total uint64) {
+	if total >= t.last {
+		c.Add(float64(total - t.last))
+	} else {
+		c.Add(float64(total))
+	}
+	t.last = total
+}
+
+// MetricsReporter periodically records the stats of the IPFS node to Metrics.
+// Nodes not exposing any stats, e.g. the in-process one, are ignored.
+type MetricsReporter struct {
+	service.BaseService
+
+	node     Node
+	metrics  *Metrics
+	interval time.Duration
+	ticker   *time.Ticker
+}
+
+// NewMetricsReporter returns a new MetricsReporter for the given Node.
+func NewMetricsReporter(node Node, metrics *Metrics, logger log.Logger) *MetricsReporter {
+	r := &MetricsReporter{
+		node:     node,
+		metrics:  metrics,
+		interval: defaultReportInterval,
+	}
+	r.BaseService = *service.NewBaseService(logger, "IPFSMetricsReporter", r)
+	return r
+}
+
+// OnStart implements service.Service by starting the reporting routine.
+func (r *MetricsReporter) OnStart() error {
+	if _, ok := r.node.(metricsReporter); !ok {
+		r.Logger.Info("IPFS node does not expose stats, skipping metrics reporting")
+		return nil
+	}
+	r.ticker = time.NewTicker(r.interval)
+	go r.reportRoutine()
+	return nil
+}
+
+// OnStop implements service.Service.
+func (r *MetricsReporter) OnStop() {
+	if r.ticker != nil {
+		r.ticker.Stop()
+	}
+}
+
+func (r *MetricsReporter) reportRoutine() {
+	reporter := r.node.(metricsReporter)
+	report := func() {
+		if err := reporter.reportMetrics(r.metrics); err != nil {
+			r.Logger.Error("Failed to report IPFS metrics", "err", err)
+		}
+	}
+
+	report()
+	for {
+		select {
+		case <-r.ticker.C:
+			report()
+		case <-r.Quit():
+			return
+		}
+	}
+}
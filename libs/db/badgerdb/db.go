@@ -21,11 +21,18 @@ func NewDB(dbName, dir string) (*BadgerDB, error) {
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return nil, err
 	}
+	return NewDBWithOptions(DefaultOptions(path))
+}
+
+// DefaultOptions returns the Badger options used for all the databases
+// stored at the given path, so that other Badger users within the node,
+// e.g. the embedded IPFS repo, can share the same tuning.
+func DefaultOptions(path string) badger.Options {
 	opts := badger.DefaultOptions(path)
 	opts.SyncWrites = false // note that we have Sync methods
 	// TODO(ismail): investigate if we don't want a logger here at least for errors though:
 	opts.Logger = nil // badger is too chatty by default
-	return NewDBWithOptions(opts)
+	return opts
 }
 
 // NewDBWithOptions creates a BadgerDB key value store
@@ -81,7 +81,7 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 
 			// We are suspecting that the primary is faulty, hence we hold the witness as the source of truth
 			// and generate evidence against the primary that we can send to the witness
-			primaryEv := newLightClientAttackEvidence(primaryBlock, witnessTrace[len(witnessTrace)-1], witnessTrace[0])
+			primaryEv := NewLightClientAttackEvidence(primaryBlock, witnessTrace[len(witnessTrace)-1], witnessTrace[0])
 			c.logger.Error("Attempted attack detected. Sending evidence againt primary by witness", "ev", primaryEv,
 				"primary", c.primary, "witness", supportingWitness)
 			c.sendEvidence(ctx, primaryEv, supportingWitness)
@@ -108,7 +108,7 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 			}
 
 			// We now use the primary trace to create evidence against the witness and send it to the primary
-			witnessEv := newLightClientAttackEvidence(witnessBlock, primaryTrace[len(primaryTrace)-1], primaryTrace[0])
+			witnessEv := NewLightClientAttackEvidence(witnessBlock, primaryTrace[len(primaryTrace)-1], primaryTrace[0])
 			c.logger.Error("Sending evidence against witness by primary", "ev", witnessEv,
 				"primary", c.primary, "witness", supportingWitness)
 			c.sendEvidence(ctx, witnessEv, c.primary)
@@ -229,9 +229,9 @@ func (c *Client) examineConflictingHeaderAgainstTrace(
 
 }
 
-// newLightClientAttackEvidence determines the type of attack and then forms the evidence filling out
+// NewLightClientAttackEvidence determines the type of attack and then forms the evidence filling out
 // all the fields such that it is ready to be sent to a full node.
-func newLightClientAttackEvidence(conflicted, trusted, common *types.LightBlock) *types.LightClientAttackEvidence {
+func NewLightClientAttackEvidence(conflicted, trusted, common *types.LightBlock) *types.LightClientAttackEvidence {
 	ev := &types.LightClientAttackEvidence{ConflictingBlock: conflicted}
 	// if this is an equivocation or amnesia attack, i.e. the validator sets are the same, then we
 	// return the height of the conflicting block else if it is a lunatic attack and the validator sets
@@ -40,6 +40,16 @@ func (e ErrInvalidHeader) Error() string {
 	return fmt.Sprintf("invalid header: %v", e.Reason)
 }
 
+// ErrInvalidFraudProof means the fraud proof either could not be verified
+// against the trusted block or does not prove any fraud.
+type ErrInvalidFraudProof struct {
+	Reason error
+}
+
+func (e ErrInvalidFraudProof) Error() string {
+	return fmt.Sprintf("invalid fraud proof: %v", e.Reason)
+}
+
 // ErrFailedHeaderCrossReferencing is returned when the detector was not able to cross reference the header
 // with any of the connected witnesses.
 var ErrFailedHeaderCrossReferencing = errors.New(
@@ -0,0 +1,70 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// StateTransitionVerifier re-executes txs statelessly, given only the root of the
+// pre-state and witnesses of the parts of the state accessed by the txs. It is
+// supplied by the application.
+type StateTransitionVerifier interface {
+	// VerifyStateTransition applies the txs, the first of which has the given index
+	// in the block, to the pre-state and returns the root of the resulting state.
+	VerifyStateTransition(preStateRoot, stateWitness []byte, firstTxIndex uint32, txs [][]byte) ([]byte, error)
+}
+
+// VerifyStateTransitionFraud verifies that fp proves an invalid state transition in
+// the block of the given trusted light block. It ensures that:
+//
+//	a) the light block carries the DataAvailabilityHeader committed to in its header
+//	b) the txs and intermediate state roots are proven against the DataAvailabilityHeader
+//	c) re-executing the txs on the pre-state does not result in the claimed root
+//
+// If the proof does not prove fraud, ErrInvalidFraudProof is returned.
+func VerifyStateTransitionFraud(
+	trusted *types.LightBlock,
+	fp *types.StateTransitionFraudProof,
+	verifier StateTransitionVerifier,
+) error {
+	if trusted == nil || trusted.SignedHeader == nil {
+		return errors.New("missing trusted header")
+	}
+	if trusted.Height != fp.Height() {
+		return ErrInvalidFraudProof{fmt.Errorf("proof is for height %d, trusted block has height %d",
+			fp.Height(), trusted.Height)}
+	}
+	if err := fp.ValidateBasic(); err != nil {
+		return ErrInvalidFraudProof{err}
+	}
+
+	dah := trusted.DataAvailabilityHeader
+	if dah == nil {
+		return errors.New("trusted light block has no DataAvailabilityHeader")
+	}
+	if !bytes.Equal(dah.Hash(), trusted.DataHash) {
+		return fmt.Errorf("DataAvailabilityHeader hash %X does not match data hash %X of the header",
+			dah.Hash(), trusted.DataHash)
+	}
+
+	preStateRoot, txs, postStateRoot, err := fp.ProvenStateTransition(dah, trusted.AppHash)
+	if err != nil {
+		return ErrInvalidFraudProof{err}
+	}
+
+	rawTxs := make([][]byte, len(txs))
+	for i, tx := range txs {
+		rawTxs[i] = tx
+	}
+	root, err := verifier.VerifyStateTransition(preStateRoot, fp.StateWitness, fp.FirstTxIndex, rawTxs)
+	if err != nil {
+		return ErrInvalidFraudProof{fmt.Errorf("failed to re-execute txs: %w", err)}
+	}
+	if bytes.Equal(root, postStateRoot) {
+		return ErrInvalidFraudProof{errors.New("state transition is valid")}
+	}
+	return nil
+}
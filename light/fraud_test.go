@@ -0,0 +1,144 @@
+package light_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/light"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// hashVerifier "executes" txs by hashing them onto the pre-state root.
+type hashVerifier struct{}
+
+func (hashVerifier) VerifyStateTransition(preStateRoot, _ []byte, _ uint32, txs [][]byte) ([]byte, error) {
+	h := sha256.New()
+	h.Write(preStateRoot)
+	for _, tx := range txs {
+		h.Write(tx)
+	}
+	return h.Sum(nil), nil
+}
+
+const txsPerRoot = 2
+
+// makeISRBlock returns a block with six txs and an intermediate state root for every
+// two of them, the second of which is invalid if requested.
+func makeISRBlock(invalidRoot bool) *types.Block {
+	txs := types.Txs{types.Tx("tx0"), types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3"), types.Tx("tx4"),
+		types.Tx("tx5")}
+	appHash := []byte("app-hash")
+
+	isrs := types.IntermediateStateRoots{TxsPerRoot: txsPerRoot}
+	root := appHash
+	for i := uint32(0); ; i++ {
+		first, end := isrs.TxRange(i, len(txs))
+		if first == end {
+			break
+		}
+		rawTxs := make([][]byte, 0, end-first)
+		for _, tx := range txs[first:end] {
+			rawTxs = append(rawTxs, tx)
+		}
+		root, _ = hashVerifier{}.VerifyStateTransition(root, nil, uint32(first), rawTxs)
+		isrs.RawRootsList = append(isrs.RawRootsList, root)
+	}
+	if invalidRoot {
+		isrs.RawRootsList[1] = []byte("invalid-root")
+	}
+
+	block := types.MakeBlock(1, txs, nil, isrs, types.Messages{}, nil)
+	block.AppHash = appHash
+	return block
+}
+
+func lightBlock(block *types.Block) *types.LightBlock {
+	return &types.LightBlock{
+		SignedHeader:           &types.SignedHeader{Header: &block.Header},
+		DataAvailabilityHeader: &block.DataAvailabilityHeader,
+	}
+}
+
+func TestVerifyStateTransitionFraud(t *testing.T) {
+	testCases := []struct {
+		name        string
+		invalidRoot bool
+		isrIndex    uint32
+		expErr      bool
+	}{
+		{"invalid root", true, 1, false},
+		{"valid root", false, 1, true},
+		{"valid first root", true, 0, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			block := makeISRBlock(tc.invalidRoot)
+			fp, err := types.NewStateTransitionFraudProof(block, tc.isrIndex, nil)
+			require.NoError(t, err)
+
+			err = light.VerifyStateTransitionFraud(lightBlock(block), fp, hashVerifier{})
+			if tc.expErr {
+				assert.IsType(t, light.ErrInvalidFraudProof{}, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// there are no txs for a root after the last one
+	block := makeISRBlock(true)
+	_, err := types.NewStateTransitionFraudProof(block, uint32(len(block.IntermediateStateRoots.RawRootsList)), nil)
+	assert.Error(t, err)
+}
+
+func TestVerifyStateTransitionFraudOtherTxRange(t *testing.T) {
+	// re-executing other txs than the ones of a root of an honest block results
+	// in another root, which must not be taken as fraud
+	testCases := []struct {
+		name     string
+		malleate func(*types.StateTransitionFraudProof)
+	}{
+		{"txs of the previous root", func(fp *types.StateTransitionFraudProof) {
+			fp.FirstTxIndex -= txsPerRoot
+		}},
+		{"txs of the next root", func(fp *types.StateTransitionFraudProof) {
+			fp.FirstTxIndex += txsPerRoot
+		}},
+		{"truncated range", func(fp *types.StateTransitionFraudProof) {
+			fp.FirstTxIndex++
+			fp.NumTxs--
+		}},
+		{"extended range", func(fp *types.StateTransitionFraudProof) { fp.NumTxs++ }},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			block := makeISRBlock(false)
+			fp, err := types.NewStateTransitionFraudProof(block, 1, nil)
+			require.NoError(t, err)
+			tc.malleate(fp)
+
+			err = light.VerifyStateTransitionFraud(lightBlock(block), fp, hashVerifier{})
+			assert.IsType(t, light.ErrInvalidFraudProof{}, err)
+		})
+	}
+}
+
+func TestVerifyStateTransitionFraudAgainstOtherBlock(t *testing.T) {
+	fp, err := types.NewStateTransitionFraudProof(makeISRBlock(true), 1, nil)
+	require.NoError(t, err)
+
+	// the proofs do not match the data of an honest block
+	err = light.VerifyStateTransitionFraud(lightBlock(makeISRBlock(false)), fp, hashVerifier{})
+	assert.IsType(t, light.ErrInvalidFraudProof{}, err)
+
+	// the DataAvailabilityHeader must match the header
+	lb := lightBlock(makeISRBlock(true))
+	lb.DataAvailabilityHeader = &makeISRBlock(false).DataAvailabilityHeader
+	err = light.VerifyStateTransitionFraud(lb, fp, hashVerifier{})
+	assert.Error(t, err)
+}
@@ -21,6 +21,7 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), ""),
 		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
 		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), ""),
+		"ipfs_info":            rpcserver.NewRPCFunc(makeIPFSInfoFunc(c), ""),
 		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight"),
 		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), ""),
 		"block":                rpcserver.NewRPCFunc(makeBlockFunc(c), "height"),
@@ -33,7 +34,8 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), ""),
 		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), ""),
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height"),
-		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit"),
+		"unconfirmed_tx":       rpcserver.NewRPCFunc(makeUnconfirmedTxFunc(c), "hash"),
+		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit,sender,min_priority"),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), ""),
 
 		// tx broadcast API
@@ -47,6 +49,10 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence"),
+		"pending_evidence": rpcserver.NewRPCFunc(makePendingEvidenceFunc(c),
+			"min_height,max_height,validator,type,page,per_page"),
+		"committed_evidence": rpcserver.NewRPCFunc(makeCommittedEvidenceFunc(c),
+			"min_height,max_height,validator,type,page,per_page"),
 	}
 }
 
@@ -75,6 +81,14 @@ func makeNetInfoFunc(c *lrpc.Client) rpcNetInfoFunc {
 	}
 }
 
+type rpcIPFSInfoFunc func(ctx *rpctypes.Context) (*ctypes.ResultIPFSInfo, error)
+
+func makeIPFSInfoFunc(c *lrpc.Client) rpcIPFSInfoFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultIPFSInfo, error) {
+		return c.IPFSInfo(ctx.Context())
+	}
+}
+
 type rpcBlockchainInfoFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
 
 func makeBlockchainInfoFunc(c *lrpc.Client) rpcBlockchainInfoFunc {
@@ -174,11 +188,32 @@ func makeConsensusParamsFunc(c *lrpc.Client) rpcConsensusParamsFunc {
 	}
 }
 
-type rpcUnconfirmedTxsFunc func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
+type rpcUnconfirmedTxFunc func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error)
+
+func makeUnconfirmedTxFunc(c *lrpc.Client) rpcUnconfirmedTxFunc {
+	return func(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+		return c.UnconfirmedTx(ctx.Context(), hash)
+	}
+}
+
+type rpcUnconfirmedTxsFunc func(
+	ctx *rpctypes.Context,
+	limit *int,
+	sender string,
+	minPriority *int64,
+) (*ctypes.ResultUnconfirmedTxs, error)
 
 func makeUnconfirmedTxsFunc(c *lrpc.Client) rpcUnconfirmedTxsFunc {
-	return func(ctx *rpctypes.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-		return c.UnconfirmedTxs(ctx.Context(), limit)
+	return func(
+		ctx *rpctypes.Context,
+		limit *int,
+		sender string,
+		minPriority *int64,
+	) (*ctypes.ResultUnconfirmedTxs, error) {
+		return c.UnconfirmedTxsWithOptions(ctx.Context(), limit, rpcclient.UnconfirmedTxsOptions{
+			Sender:      sender,
+			MinPriority: minPriority,
+		})
 	}
 }
 
@@ -244,3 +279,20 @@ func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
 		return c.BroadcastEvidence(ctx.Context(), ev)
 	}
 }
+
+type rpcEvidenceListFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64, validator bytes.HexBytes,
+	evType string, page, perPage *int) (*ctypes.ResultEvidenceList, error)
+
+func makePendingEvidenceFunc(c *lrpc.Client) rpcEvidenceListFunc {
+	return func(ctx *rpctypes.Context, minHeight, maxHeight int64, validator bytes.HexBytes,
+		evType string, page, perPage *int) (*ctypes.ResultEvidenceList, error) {
+		return c.PendingEvidence(ctx.Context(), minHeight, maxHeight, validator, evType, page, perPage)
+	}
+}
+
+func makeCommittedEvidenceFunc(c *lrpc.Client) rpcEvidenceListFunc {
+	return func(ctx *rpctypes.Context, minHeight, maxHeight int64, validator bytes.HexBytes,
+		evType string, page, perPage *int) (*ctypes.ResultEvidenceList, error) {
+		return c.CommittedEvidence(ctx.Context(), minHeight, maxHeight, validator, evType, page, perPage)
+	}
+}
@@ -175,10 +175,22 @@ func (c *Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resu
 	return c.next.BroadcastTxSync(ctx, tx)
 }
 
+func (c *Client) UnconfirmedTx(ctx context.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+	return c.next.UnconfirmedTx(ctx, hash)
+}
+
 func (c *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
 	return c.next.UnconfirmedTxs(ctx, limit)
 }
 
+func (c *Client) UnconfirmedTxsWithOptions(
+	ctx context.Context,
+	limit *int,
+	opts rpcclient.UnconfirmedTxsOptions,
+) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.next.UnconfirmedTxsWithOptions(ctx, limit, opts)
+}
+
 func (c *Client) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
 	return c.next.NumUnconfirmedTxs(ctx)
 }
@@ -191,6 +203,10 @@ func (c *Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return c.next.NetInfo(ctx)
 }
 
+func (c *Client) IPFSInfo(ctx context.Context) (*ctypes.ResultIPFSInfo, error) {
+	return c.next.IPFSInfo(ctx)
+}
+
 func (c *Client) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	return c.next.DumpConsensusState(ctx)
 }
@@ -494,6 +510,20 @@ func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*cty
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+// PendingEvidence calls rpcclient#PendingEvidence. The evidence is not verified, as it is not
+// part of any header.
+func (c *Client) PendingEvidence(ctx context.Context, minHeight, maxHeight int64, validator tmbytes.HexBytes,
+	evType string, page, perPage *int) (*ctypes.ResultEvidenceList, error) {
+	return c.next.PendingEvidence(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+}
+
+// CommittedEvidence calls rpcclient#CommittedEvidence. The evidence is not verified against the
+// blocks it was committed in.
+func (c *Client) CommittedEvidence(ctx context.Context, minHeight, maxHeight int64, validator tmbytes.HexBytes,
+	evType string, page, perPage *int) (*ctypes.ResultEvidenceList, error) {
+	return c.next.CommittedEvidence(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...)
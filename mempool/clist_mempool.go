@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
 	cfg "github.com/lazyledger/lazyledger-core/config"
@@ -65,6 +66,15 @@ type CListMempool struct {
 	// txsMap: txKey -> CElement
 	txsMap sync.Map
 
+	// Map for quick access to txs by the sender and nonce the app set for
+	// them, used to replace a tx with a higher priority one.
+	// txsBySender: senderNonce -> CElement
+	txsBySender sync.Map
+
+	// Serializes adding txs so that replacement and eviction decisions see a
+	// consistent mempool.
+	addMtx tmsync.Mutex
+
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache txCache
@@ -72,6 +82,26 @@ type CListMempool struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// publishes txs entering and leaving the mempool
+	eventBus types.MempoolEventPublisher
+
+	// Optional hook set by PriorityMempool, which decides whether a freshly
+	// checked tx fits once replaced, if not nil, is removed (possibly making
+	// room for it). If nil, isFullReplacing is used instead.
+	admitTx func(memTx, replaced *mempoolTx) error
+
+	// Optional index set by PriorityMempool, which is kept in sync with txs.
+	index txIndex
+}
+
+// txIndex is an additional index of the txs of the mempool. It is notified of
+// every tx added to or removed from txs and of every change of a tx priority.
+type txIndex interface {
+	add(e *clist.CElement)
+	remove(e *clist.CElement)
+	setPriority(e *clist.CElement, priority int64)
+	reset()
 }
 
 var _ Mempool = &CListMempool{}
@@ -95,6 +125,7 @@ func NewCListMempool(
 		recheckEnd:    nil,
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
+		eventBus:      types.NopEventBus{},
 	}
 	if config.CacheSize > 0 {
 		mempool.cache = newMapTxCache(config.CacheSize)
@@ -118,6 +149,12 @@ func (mem *CListMempool) SetLogger(l log.Logger) {
 	mem.logger = l
 }
 
+// SetEventBus sets the event bus txs entering and leaving the mempool are
+// published to.
+func (mem *CListMempool) SetEventBus(eventBus types.MempoolEventPublisher) {
+	mem.eventBus = eventBus
+}
+
 // WithPreCheck sets a filter for the mempool to reject a tx if f(tx) returns
 // false. This is ran before CheckTx. Only applies to the first created block.
 // After that, Update overwrites the existing value.
@@ -206,6 +243,13 @@ func (mem *CListMempool) Flush() {
 		mem.txsMap.Delete(key)
 		return true
 	})
+	mem.txsBySender.Range(func(key, _ interface{}) bool {
+		mem.txsBySender.Delete(key)
+		return true
+	})
+	if mem.index != nil {
+		mem.index.reset()
+	}
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -239,8 +283,13 @@ func (mem *CListMempool) CheckTx(tx types.Tx, cb func(*abci.Response), txInfo Tx
 
 	txSize := len(tx)
 
-	if err := mem.isFull(txSize); err != nil {
-		return err
+	// A priority mempool may still make room for the tx once the app tells us
+	// its priority, so only reject early if we have no way to evict. Txs found
+	// to be underpriced stay in the cache instead (see resCbFirstTime).
+	if mem.admitTx == nil {
+		if err := mem.isFull(txSize); err != nil {
+			return err
+		}
 	}
 
 	if txSize > mem.config.MaxTxBytes {
@@ -359,40 +408,104 @@ func (mem *CListMempool) reqResCb(
 //  - resCbFirstTime (lock not held) if tx is valid
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
 	e := mem.txs.PushBack(memTx)
+	if mem.index != nil {
+		mem.index.add(e)
+	}
 	mem.txsMap.Store(TxKey(memTx.tx), e)
-	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	if memTx.sender != "" {
+		mem.txsBySender.Store(memTx.senderNonce(), e)
+	}
+	atomic.AddInt64(&mem.txsBytes, int64(memTx.size()))
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
+
+	if err := mem.eventBus.PublishEventMempoolTxAdded(memTx.eventData("")); err != nil {
+		mem.logger.Error("Failed publishing mempool tx", "tx", txID(memTx.tx), "err", err)
+	}
 }
 
 // Called from:
-//  - Update (lock held) if tx was committed
+//  - Update (lock held) if tx was committed or expired
 // 	- resCbRecheck (lock not held) if tx was invalidated
-func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
+//  - resCbFirstTime (lock not held) if tx was replaced or evicted
+func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool, reason string) {
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
+	if mem.index != nil {
+		mem.index.remove(elem)
+	}
 	mem.txsMap.Delete(TxKey(tx))
-	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
+	memTx := elem.Value.(*mempoolTx)
+	atomic.AddInt64(&mem.txsBytes, int64(-memTx.size()))
+	if memTx.sender != "" {
+		if e, ok := mem.txsBySender.Load(memTx.senderNonce()); ok && e.(*clist.CElement) == elem {
+			mem.txsBySender.Delete(memTx.senderNonce())
+		}
+	}
 
 	if removeFromCache {
 		mem.cache.Remove(tx)
 	}
+
+	if err := mem.eventBus.PublishEventMempoolTxRemoved(memTx.eventData(reason)); err != nil {
+		mem.logger.Error("Failed publishing mempool tx", "tx", txID(tx), "err", err)
+	}
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
-func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bool) {
+func (mem *CListMempool) RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bool) error {
 	if e, ok := mem.txsMap.Load(txKey); ok {
 		memTx := e.(*clist.CElement).Value.(*mempoolTx)
 		if memTx != nil {
-			mem.removeTx(memTx.tx, e.(*clist.CElement), removeFromCache)
+			mem.removeTx(memTx.tx, e.(*clist.CElement), removeFromCache, TxRemovedManually)
+			return nil
 		}
 	}
+	return ErrTxNotFound
+}
+
+// TxByKey returns the transaction with the given TxKey index.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) TxByKey(txKey [TxKeySize]byte) (TxEntry, bool) {
+	if e, ok := mem.txsMap.Load(txKey); ok {
+		return e.(*clist.CElement).Value.(*mempoolTx).entry(), true
+	}
+	return TxEntry{}, false
+}
+
+// TxEntries returns all transactions in the order they were added.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) TxEntries() []TxEntry {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	return txEntries(mem.memTxs())
+}
+
+func txEntries(memTxs []*mempoolTx) []TxEntry {
+	entries := make([]TxEntry, len(memTxs))
+	for i, memTx := range memTxs {
+		entries[i] = memTx.entry()
+	}
+	return entries
 }
 
 func (mem *CListMempool) isFull(txSize int) error {
+	return mem.isFullReplacing(txSize, nil)
+}
+
+// isFullReplacing is like isFull, but counts the room replaced, if not nil,
+// frees once it is removed.
+func (mem *CListMempool) isFullReplacing(txSize int, replaced *mempoolTx) error {
 	var (
 		memSize  = mem.Size()
 		txsBytes = mem.TxsBytes()
 	)
+	if replaced != nil {
+		memSize--
+		txsBytes -= int64(replaced.size())
+	}
 
 	if memSize >= mem.config.Size || int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
 		return ErrMempoolIsFull{
@@ -404,6 +517,48 @@ func (mem *CListMempool) isFull(txSize int) error {
 	return nil
 }
 
+// admit returns an error if memTx can't be added to the mempool. If the
+// mempool holds a tx with the same sender and nonce, memTx replaces it as long
+// as it has a higher priority. The replaced tx is only removed once memTx is
+// known to fit.
+func (mem *CListMempool) admit(memTx *mempoolTx) error {
+	var (
+		replacedElem *clist.CElement
+		replaced     *mempoolTx
+	)
+	if memTx.sender != "" {
+		if e, ok := mem.txsBySender.Load(memTx.senderNonce()); ok {
+			replacedElem = e.(*clist.CElement)
+			replaced = replacedElem.Value.(*mempoolTx)
+			if memTx.Priority() <= replaced.Priority() {
+				return ErrTxReplacementUnderpriced{memTx.sender, memTx.nonce, replaced.Priority()}
+			}
+		}
+	}
+
+	var err error
+	if mem.admitTx != nil {
+		err = mem.admitTx(memTx, replaced)
+	} else {
+		err = mem.isFullReplacing(memTx.size(), replaced)
+	}
+	if err != nil {
+		return err
+	}
+
+	if replaced != nil {
+		mem.logger.Info("Replaced transaction",
+			"tx", txID(replaced.tx),
+			"by", txID(memTx.tx),
+			"sender", memTx.sender,
+			"nonce", memTx.nonce,
+		)
+		// keep the replaced tx in the cache, it would be rejected again
+		mem.removeTx(replaced.tx, replacedElem, false, TxRemovedReplaced)
+	}
+	return nil
+}
+
 // callback, which is called after the app checked the tx for the first time.
 //
 // The case where the app checks the tx for the second and subsequent times is
@@ -421,22 +576,36 @@ func (mem *CListMempool) resCbFirstTime(
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
+			memTx := &mempoolTx{
+				height:    mem.height,
+				gasWanted: r.CheckTx.GasWanted,
+				tx:        tx,
+				message:   types.MessageFromProto(r.CheckTx.Message),
+				priority:  r.CheckTx.Priority,
+				sender:    r.CheckTx.Sender,
+				nonce:     r.CheckTx.Nonce,
+				timestamp: time.Now(),
+			}
+
+			mem.addMtx.Lock()
 			// Check mempool isn't full again to reduce the chance of exceeding the
 			// limits.
-			if err := mem.isFull(len(tx)); err != nil {
-				// remove from cache (mempool might have a space later)
-				mem.cache.Remove(tx)
+			if err := mem.admit(memTx); err != nil {
+				mem.addMtx.Unlock()
+				// Keep txs which can't outbid any tx in the full mempool in the
+				// cache, so that peers gossiping them again don't make us check
+				// them with the app over and over. Otherwise, remove from cache
+				// (mempool might have a space later).
+				if _, ok := err.(ErrTxUnderpriced); !ok {
+					mem.cache.Remove(tx)
+				}
 				mem.logger.Error(err.Error())
 				return
 			}
 
-			memTx := &mempoolTx{
-				height:    mem.height,
-				gasWanted: r.CheckTx.GasWanted,
-				tx:        tx,
-			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
+			mem.addMtx.Unlock()
 			mem.logger.Info("Added good transaction",
 				"tx", txID(tx),
 				"res", r,
@@ -477,12 +646,19 @@ func (mem *CListMempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			// Good, nothing to do.
+			// Good, but the tx may pay for a different message and have a
+			// different priority now.
+			memTx.setMessage(types.MessageFromProto(r.CheckTx.Message), &mem.txsBytes)
+			if mem.index != nil {
+				mem.index.setPriority(mem.recheckCursor, r.CheckTx.Priority)
+			} else {
+				memTx.setPriority(r.CheckTx.Priority)
+			}
 		} else {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Info("Tx is no longer valid", "tx", txID(tx), "res", r, "err", postCheckErr)
 			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, true)
+			mem.removeTx(tx, mem.recheckCursor, true, TxRemovedInvalid)
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -527,16 +703,48 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
+	return reapMaxBytesMaxGas(mem.memTxs(), maxBytes, maxGas)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) ReapMaxSharesMaxGas(maxShares int, maxGas int64) (types.Txs, []types.Message) {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	return reapMaxSharesMaxGas(mem.memTxs(), maxShares, maxGas)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	return reapMaxTxs(mem.memTxs(), max)
+}
+
+// memTxs returns all txs in the order they were added to the mempool.
+func (mem *CListMempool) memTxs() []*mempoolTx {
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
+	return memTxs
+}
+
+// reapMaxBytesMaxGas takes txs from the front of memTxs until either the
+// encoded size of the txs and their messages exceeds maxBytes or their total
+// gas exceeds maxGas.
+func reapMaxBytesMaxGas(memTxs []*mempoolTx, maxBytes, maxGas int64) types.Txs {
 	var totalGas int64
 
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
-	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-
-		dataSize := types.ComputeProtoSizeForTxs(append(txs, memTx.tx))
+	txs := make([]types.Tx, 0, len(memTxs))
+	msgs := make([]types.Message, 0, len(memTxs))
+	for _, memTx := range memTxs {
+		msg := memTx.Message()
+		dataSize := types.ComputeProtoSizeForTxsAndMessages(append(txs, memTx.tx), append(msgs, msg))
 
 		// Check total size requirement
 		if maxBytes > -1 && dataSize > maxBytes {
@@ -552,22 +760,63 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 		}
 		totalGas = newTotalGas
 		txs = append(txs, memTx.tx)
+		msgs = append(msgs, msg)
 	}
 	return txs
 }
 
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
-	mem.updateMtx.RLock()
-	defer mem.updateMtx.RUnlock()
+// reapMaxSharesMaxGas takes txs from the front of memTxs until the txs, their
+// intermediate state roots and their messages no longer fit into maxShares
+// shares or the txs exceed maxGas.
+func reapMaxSharesMaxGas(memTxs []*mempoolTx, maxShares int, maxGas int64) (types.Txs, []types.Message) {
+	var (
+		txsLen    int // total delimited length of the txs
+		isrsLen   int // total delimited length of their intermediate state roots
+		msgShares int
+		totalGas  int64
+	)
+
+	isrLen := types.MaxIntermediateStateRootLen
+	txs := make([]types.Tx, 0, len(memTxs))
+	msgs := make([]types.Message, 0, len(memTxs))
+	for _, memTx := range memTxs {
+		msg := memTx.Message()
+		newTxsLen := txsLen + types.DelimitedLen(len(memTx.tx))
+		newIsrsLen := isrsLen + isrLen
+		newMsgShares := msgShares
+		if !msg.IsEmpty() {
+			newMsgShares += types.MsgSharesUsed(len(msg.Data))
+		}
 
+		// Check total shares requirement
+		sharesUsed := types.ContiguousSharesUsed(newTxsLen) + types.ContiguousSharesUsed(newIsrsLen) + newMsgShares
+		if maxShares > -1 && sharesUsed > maxShares {
+			return txs, msgs
+		}
+		// Check total gas requirement.
+		// If maxGas is negative, skip this check.
+		newTotalGas := totalGas + memTx.gasWanted
+		if maxGas > -1 && newTotalGas > maxGas {
+			return txs, msgs
+		}
+		txsLen, isrsLen, msgShares, totalGas = newTxsLen, newIsrsLen, newMsgShares, newTotalGas
+		txs = append(txs, memTx.tx)
+		msgs = append(msgs, msg)
+	}
+	return txs, msgs
+}
+
+// reapMaxTxs takes up to max txs from the front of memTxs.
+func reapMaxTxs(memTxs []*mempoolTx, max int) types.Txs {
 	if max < 0 {
-		max = mem.txs.Len()
+		max = len(memTxs)
 	}
 
-	txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	txs := make([]types.Tx, 0, tmmath.MinInt(len(memTxs), max))
+	for _, memTx := range memTxs {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
@@ -612,10 +861,15 @@ func (mem *CListMempool) Update(
 		//   100
 		// https://github.com/tendermint/tendermint/issues/3322.
 		if e, ok := mem.txsMap.Load(TxKey(tx)); ok {
-			mem.removeTx(tx, e.(*clist.CElement), false)
+			mem.removeTx(tx, e.(*clist.CElement), false, TxRemovedCommitted)
 		}
 	}
 
+	// Drop txs which have been in the mempool for too long.
+	if mem.config.TTLNumBlocks > 0 || mem.config.TTLDuration > 0 {
+		mem.purgeExpiredTxs(height)
+	}
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if mem.Size() > 0 {
@@ -636,6 +890,23 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// purgeExpiredTxs removes all txs which were checked more than TTLNumBlocks
+// blocks or TTLDuration ago. They are removed from the cache as well, so they
+// can be resubmitted.
+//
+// Lock() must be help by the caller during execution.
+func (mem *CListMempool) purgeExpiredTxs(height int64) {
+	now := time.Now()
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if (mem.config.TTLNumBlocks > 0 && height-memTx.Height() > mem.config.TTLNumBlocks) ||
+			(mem.config.TTLDuration > 0 && now.Sub(memTx.timestamp) > mem.config.TTLDuration) {
+			mem.logger.Info("Expired transaction", "tx", txID(memTx.tx), "height", memTx.Height())
+			mem.removeTx(memTx.tx, e, true, TxRemovedExpired)
+		}
+	}
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
@@ -670,13 +941,20 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64         // height that this tx had been validated in
+	priority  int64         // priority set by the app; updated on recheck
+	gasWanted int64         // amount of gas this tx states it will require
+	tx        types.Tx      //
+	message   types.Message // message paid for by tx, if any; updated on recheck
+	sender    string        // sender set by the app, if any
+	nonce     uint64        // nonce set by the app, identifies the tx of sender
+	timestamp time.Time     // time that this tx was added to the mempool
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
 	senders sync.Map
+
+	mtx sync.RWMutex // guards message
 }
 
 // Height returns the height for this transaction
@@ -684,6 +962,71 @@ func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
 
+// Priority returns the priority the app set for this transaction
+func (memTx *mempoolTx) Priority() int64 {
+	return atomic.LoadInt64(&memTx.priority)
+}
+
+func (memTx *mempoolTx) setPriority(priority int64) {
+	atomic.StoreInt64(&memTx.priority, priority)
+}
+
+// senderNonce is the key of a tx in txsBySender.
+type senderNonce struct {
+	sender string
+	nonce  uint64
+}
+
+func (memTx *mempoolTx) senderNonce() senderNonce {
+	return senderNonce{memTx.sender, memTx.nonce}
+}
+
+func (memTx *mempoolTx) entry() TxEntry {
+	return TxEntry{
+		Tx:       memTx.tx,
+		Height:   memTx.Height(),
+		Priority: memTx.Priority(),
+		Sender:   memTx.sender,
+		Nonce:    memTx.nonce,
+	}
+}
+
+// eventData returns the data published when the tx enters the mempool or, if
+// reason is set, leaves it.
+func (memTx *mempoolTx) eventData(reason string) types.EventDataMempoolTx {
+	return types.EventDataMempoolTx{
+		Tx:       memTx.tx,
+		Height:   memTx.Height(),
+		Priority: memTx.Priority(),
+		Sender:   memTx.sender,
+		Nonce:    memTx.nonce,
+		Reason:   reason,
+	}
+}
+
+// size returns the number of bytes the transaction and its message take up.
+func (memTx *mempoolTx) size() int {
+	msg := memTx.Message()
+	return len(memTx.tx) + len(msg.NamespaceID) + len(msg.Data)
+}
+
+// Message returns the message paid for by the transaction.
+func (memTx *mempoolTx) Message() types.Message {
+	memTx.mtx.RLock()
+	defer memTx.mtx.RUnlock()
+	return memTx.message
+}
+
+// setMessage replaces the message paid for by the transaction and adjusts
+// txsBytes by the change in size.
+func (memTx *mempoolTx) setMessage(msg types.Message, txsBytes *int64) {
+	memTx.mtx.Lock()
+	defer memTx.mtx.Unlock()
+	delta := len(msg.NamespaceID) + len(msg.Data) - len(memTx.message.NamespaceID) - len(memTx.message.Data)
+	memTx.message = msg
+	atomic.AddInt64(txsBytes, int64(delta))
+}
+
 //--------------------------------------------------------------------------------
 
 type txCache interface {
@@ -23,8 +23,10 @@ import (
 	cfg "github.com/lazyledger/lazyledger-core/config"
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	"github.com/lazyledger/lazyledger-core/proxy"
 	"github.com/lazyledger/lazyledger-core/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 )
 
 // A cleanupFunc cleans up any config / test files created for a particular
@@ -137,6 +139,156 @@ func TestReapMaxBytesMaxGas(t *testing.T) {
 	}
 }
 
+// messageApp pays for a message with every tx that has an even first byte.
+type messageApp struct {
+	*kvstore.Application
+}
+
+func (app messageApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := app.Application.CheckTx(req)
+	if req.Tx[0]%2 == 0 {
+		res.Message = &tmproto.Message{NamespaceId: []byte{1, 2, 3, 4, 5, 6, 7, 8}, Data: req.Tx}
+	}
+	return res
+}
+
+func TestReapMaxBytesMaxGasCountsMessages(t *testing.T) {
+	app := messageApp{kvstore.NewApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := types.Txs{types.Tx{0, 1}, types.Tx{1, 1}, types.Tx{2, 1}}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	// the messages take up space as well
+	assert.EqualValues(t, 3*2+2*(8+2), mempool.TxsBytes())
+
+	got, msgs := mempool.ReapMaxSharesMaxGas(-1, -1)
+	require.Equal(t, txs, got)
+	require.Len(t, msgs, 3)
+	assert.Equal(t, []byte(txs[0]), msgs[0].Data)
+	assert.True(t, msgs[1].IsEmpty())
+	assert.Equal(t, []byte(txs[2]), msgs[2].Data)
+
+	// the size of the messages counts towards maxBytes
+	assert.Less(t, len(mempool.ReapMaxBytesMaxGas(types.ComputeProtoSizeForTxs(txs), -1)), len(txs))
+	maxBytes := types.ComputeProtoSizeForTxsAndMessages(txs[:2], msgs[:2])
+	assert.Equal(t, txs[:2], mempool.ReapMaxBytesMaxGas(maxBytes, -1))
+
+	// removing txs frees the space of their messages
+	require.NoError(t, mempool.Update(1, txs, abciResponses(len(txs), abci.CodeTypeOK), nil, nil))
+	assert.EqualValues(t, 0, mempool.TxsBytes())
+}
+
+func TestReapMaxSharesMaxGas(t *testing.T) {
+	app := messageApp{kvstore.NewApplication()}
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// odd txs fill exactly one share once delimited, even ones pay for a
+	// message of their own bytes, which fills two shares and a byte
+	txs := make(types.Txs, 6)
+	for i := range txs {
+		size := consts.TxShareSize - 2
+		if i%2 == 0 {
+			size = 2*consts.MsgShareSize - 1
+		}
+		txs[i] = append([]byte{byte(i)}, tmrand.Bytes(size-1)...)
+		require.NoError(t, mempool.CheckTx(txs[i], nil, TxInfo{}))
+	}
+	require.Equal(t, 1, types.ContiguousSharesUsed(types.DelimitedLen(len(txs[1]))))
+	require.Equal(t, 3, types.MsgSharesUsed(len(txs[0])))
+	// the intermediate state roots of the first four txs fit into a single share
+	require.Equal(t, 1, types.ContiguousSharesUsed(4*types.MaxIntermediateStateRootLen))
+	require.Equal(t, 2, types.ContiguousSharesUsed(len(txs)*types.MaxIntermediateStateRootLen))
+
+	tests := []struct {
+		maxShares      int
+		maxGas         int64
+		expectedNumTxs int
+	}{
+		{-1, -1, 6},
+		{0, -1, 0},
+		{6, -1, 0},
+		{7, -1, 1},  // 3 shares of txs, 1 of roots, 3 of messages
+		{8, -1, 2},  // 4 shares of txs, 1 of roots, 3 of messages
+		{12, -1, 2}, // the next message does not fit
+		{13, -1, 3}, // 6 shares of txs, 1 of roots, 6 of messages
+		{14, -1, 4}, // 7 shares of txs, 1 of roots, 6 of messages
+		{19, -1, 4},
+		{20, -1, 5}, // 9 shares of txs, 2 of roots, 9 of messages
+		{21, -1, 6}, // 10 shares of txs, 2 of roots, 9 of messages
+		{100, -1, 6},
+		{-1, 0, 0},
+		{-1, 4, 4}, // every tx wants 1 gas
+		{14, 5, 4},
+		{100, 5, 5},
+	}
+	for tcIndex, tt := range tests {
+		got, msgs := mempool.ReapMaxSharesMaxGas(tt.maxShares, tt.maxGas)
+		assert.Equal(t, txs[:tt.expectedNumTxs], got, "tc #%d", tcIndex)
+		assert.Len(t, msgs, tt.expectedNumTxs, "tc #%d", tcIndex)
+	}
+}
+
+func TestReapMaxSharesMaxGasCountsIntermediateStateRoots(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// so many tiny txs that their roots need more shares than the txs do
+	isrsPerShare := consts.TxShareSize / types.MaxIntermediateStateRootLen
+	for i := 0; i < isrsPerShare+1; i++ {
+		require.NoError(t, mempool.CheckTx(types.Tx{byte(i)}, nil, TxInfo{}))
+	}
+
+	got, _ := mempool.ReapMaxSharesMaxGas(2, -1)
+	assert.Len(t, got, isrsPerShare)
+	got, _ = mempool.ReapMaxSharesMaxGas(3, -1)
+	assert.Len(t, got, isrsPerShare+1)
+}
+
+// changingMessageApp pays for its current message with every tx.
+type changingMessageApp struct {
+	*kvstore.Application
+	data *[]byte
+}
+
+func (app changingMessageApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := app.Application.CheckTx(req)
+	res.Message = &tmproto.Message{NamespaceId: []byte{1, 2, 3, 4, 5, 6, 7, 8}, Data: *app.data}
+	return res
+}
+
+func TestRecheckUpdatesMessages(t *testing.T) {
+	data := []byte{1}
+	app := changingMessageApp{kvstore.NewApplication(), &data}
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	tx := types.Tx{0, 1}
+	require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	assert.EqualValues(t, 2+8+1, mempool.TxsBytes())
+
+	// the tx pays for a bigger message after the next block
+	data = []byte{1, 2, 3}
+	require.NoError(t, mempool.Update(1, types.Txs{{1, 1}}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+
+	txs, msgs := mempool.ReapMaxSharesMaxGas(-1, -1)
+	require.Equal(t, types.Txs{tx}, txs)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, data, msgs[0].Data)
+	assert.EqualValues(t, 2+8+3, mempool.TxsBytes())
+
+	require.NoError(t, mempool.Update(2, types.Txs{tx}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	assert.EqualValues(t, 0, mempool.TxsBytes())
+}
+
 func TestMempoolFilters(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -215,6 +367,143 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempoolReplaceBySenderNonce(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{kvstore.NewApplication()})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// priority, sender, nonce
+	first := types.Tx{1, 7, 0}
+	otherNonce := types.Tx{1, 7, 1}
+	same := types.Tx{1, 7, 0, 1}
+	higher := types.Tx{2, 7, 0}
+	for _, tx := range []types.Tx{first, otherNonce, same} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	// a tx with the same sender and nonce needs a higher priority
+	assert.Equal(t, types.Txs{first, otherNonce}, mempool.ReapMaxTxs(-1))
+
+	require.NoError(t, mempool.CheckTx(higher, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{otherNonce, higher}, mempool.ReapMaxTxs(-1))
+	assert.EqualValues(t, len(otherNonce)+len(higher), mempool.TxsBytes())
+
+	// the replaced tx stays in the cache
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx(first, nil, TxInfo{}))
+
+	// the sender and nonce are released once the tx leaves the mempool
+	require.NoError(t, mempool.Update(1, types.Txs{higher}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	require.NoError(t, mempool.CheckTx(same, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{otherNonce, same}, mempool.ReapMaxTxs(-1))
+}
+
+func TestMempoolTTL(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+
+	t.Run("blocks", func(t *testing.T) {
+		config := cfg.ResetTestRoot("mempool_test")
+		config.Mempool.TTLNumBlocks = 2
+		mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+		defer cleanup()
+
+		require.NoError(t, mempool.CheckTx(types.Tx{0x01}, nil, TxInfo{}))
+		require.NoError(t, mempool.Update(1, nil, nil, nil, nil))
+		require.NoError(t, mempool.CheckTx(types.Tx{0x02}, nil, TxInfo{}))
+		require.NoError(t, mempool.Update(2, nil, nil, nil, nil))
+		assert.Equal(t, 2, mempool.Size())
+
+		// the first tx was checked 3 blocks ago
+		require.NoError(t, mempool.Update(3, nil, nil, nil, nil))
+		assert.Equal(t, types.Txs{{0x02}}, mempool.ReapMaxTxs(-1))
+
+		// expired txs are removed from the cache and can be resubmitted
+		require.NoError(t, mempool.CheckTx(types.Tx{0x01}, nil, TxInfo{}))
+		assert.Equal(t, 2, mempool.Size())
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		config := cfg.ResetTestRoot("mempool_test")
+		config.Mempool.TTLDuration = 100 * time.Millisecond
+		mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+		defer cleanup()
+
+		require.NoError(t, mempool.CheckTx(types.Tx{0x01}, nil, TxInfo{}))
+		time.Sleep(200 * time.Millisecond)
+		require.NoError(t, mempool.CheckTx(types.Tx{0x02}, nil, TxInfo{}))
+
+		require.NoError(t, mempool.Update(1, nil, nil, nil, nil))
+		assert.Equal(t, types.Txs{{0x02}}, mempool.ReapMaxTxs(-1))
+	})
+}
+
+func TestMempoolTxEntries(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{kvstore.NewApplication()})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	txs := types.Txs{{1, 7, 0}, {2, 0, 0}}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+
+	entry, ok := mempool.TxByKey(TxKey(txs[0]))
+	require.True(t, ok)
+	assert.Equal(t, TxEntry{Tx: txs[0], Priority: 1, Sender: "sender-7"}, entry)
+	assert.Equal(t, []TxEntry{entry, {Tx: txs[1], Priority: 2}}, mempool.TxEntries())
+
+	require.NoError(t, mempool.RemoveTxByKey(TxKey(txs[0]), true))
+	_, ok = mempool.TxByKey(TxKey(txs[0]))
+	assert.False(t, ok)
+	assert.Equal(t, ErrTxNotFound, mempool.RemoveTxByKey(TxKey(txs[0]), true))
+	assert.Equal(t, 1, mempool.Size())
+}
+
+func TestMempoolEvents(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{kvstore.NewApplication()})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	mempool.SetEventBus(eventBus)
+
+	added, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryMempoolTxAdded, 10)
+	require.NoError(t, err)
+	removed, err := eventBus.Subscribe(context.Background(), "test", types.EventQueryMempoolTxRemoved, 10)
+	require.NoError(t, err)
+
+	expectEvent := func(sub types.Subscription, tx types.Tx, reason string) {
+		t.Helper()
+		select {
+		case msg := <-sub.Out():
+			data := msg.Data().(types.EventDataMempoolTx)
+			assert.Equal(t, tx, data.Tx)
+			assert.Equal(t, reason, data.Reason)
+		case <-time.After(time.Second):
+			t.Fatal("did not receive an event after 1 sec.")
+		}
+	}
+
+	// priority, sender, nonce
+	committed, replaced, replacing, manual := types.Tx{1, 0, 0}, types.Tx{1, 7, 0}, types.Tx{2, 7, 0}, types.Tx{1, 0, 1}
+	for _, tx := range []types.Tx{committed, replaced, replacing, manual} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+		expectEvent(added, tx, "")
+	}
+	expectEvent(removed, replaced, TxRemovedReplaced)
+
+	require.NoError(t, mempool.Update(1, types.Txs{committed}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	expectEvent(removed, committed, TxRemovedCommitted)
+
+	require.NoError(t, mempool.RemoveTxByKey(TxKey(manual), true))
+	expectEvent(removed, manual, TxRemovedManually)
+}
+
 func TestTxsAvailable(t *testing.T) {
 	app := kvstore.NewApplication()
 	cc := proxy.NewLocalClientCreator(app)
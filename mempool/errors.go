@@ -8,6 +8,9 @@ import (
 var (
 	// ErrTxInCache is returned to the client if we saw tx earlier
 	ErrTxInCache = errors.New("tx already exists in cache")
+
+	// ErrTxNotFound is returned to the client if tx is not in the mempool
+	ErrTxNotFound = errors.New("tx not found in mempool")
 )
 
 // ErrTxTooLarge means the tx is too big to be sent in a message to other peers
@@ -36,6 +39,33 @@ func (e ErrMempoolIsFull) Error() string {
 		e.txsBytes, e.maxTxsBytes)
 }
 
+// ErrTxUnderpriced means the mempool is full and the tx doesn't have a higher
+// priority than any tx it could evict
+type ErrTxUnderpriced struct {
+	priority       int64
+	lowestPriority int64
+}
+
+func (e ErrTxUnderpriced) Error() string {
+	return fmt.Sprintf(
+		"mempool is full and tx priority %d does not exceed the lowest priority %d",
+		e.priority, e.lowestPriority)
+}
+
+// ErrTxReplacementUnderpriced means the mempool already holds a tx with the
+// same sender and nonce and at least the same priority
+type ErrTxReplacementUnderpriced struct {
+	sender   string
+	nonce    uint64
+	priority int64
+}
+
+func (e ErrTxReplacementUnderpriced) Error() string {
+	return fmt.Sprintf(
+		"mempool already has a tx from sender %q with nonce %d and priority %d",
+		e.sender, e.nonce, e.priority)
+}
+
 // ErrPreCheck is returned when tx is too big
 type ErrPreCheck struct {
 	Reason error
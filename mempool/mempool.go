@@ -20,16 +20,39 @@ type Mempool interface {
 
 	// ReapMaxBytesMaxGas reaps transactions from the mempool up to maxBytes
 	// bytes total with the condition that the total gasWanted must be less than
-	// maxGas.
+	// maxGas. The messages the transactions pay for count towards maxBytes.
 	// If both maxes are negative, there is no cap on the size of all returned
 	// transactions (~ all available transactions).
 	ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs
 
+	// ReapMaxSharesMaxGas reaps transactions from the mempool, along with the
+	// messages they pay for, as long as they fit into maxShares shares of the
+	// original data square and their total gasWanted doesn't exceed maxGas.
+	// Transactions are packed contiguously while every message starts at a new
+	// share. Every transaction also takes up room for an intermediate state
+	// root of at most types.MaxIntermediateStateRootLen bytes.
+	// If maxShares (maxGas) is negative, there is no cap on the number of
+	// shares (gas).
+	ReapMaxSharesMaxGas(maxShares int, maxGas int64) (types.Txs, []types.Message)
+
 	// ReapMaxTxs reaps up to max transactions from the mempool.
 	// If max is negative, there is no cap on the size of all returned
 	// transactions (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// TxByKey returns the transaction with the given key along with what the
+	// application reported about it in CheckTx.
+	TxByKey(txKey [TxKeySize]byte) (TxEntry, bool)
+
+	// TxEntries returns all transactions in the order they would be reaped
+	// along with what the application reported about them in CheckTx.
+	TxEntries() []TxEntry
+
+	// RemoveTxByKey removes the transaction with the given key from the
+	// mempool. It returns ErrTxNotFound if there is no such transaction.
+	// The mempool must be locked by the caller (see Lock).
+	RemoveTxByKey(txKey [TxKeySize]byte, removeFromCache bool) error
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely update.
 	Lock()
 
@@ -103,6 +126,28 @@ type TxInfo struct {
 	Context context.Context
 }
 
+// TxEntry is a transaction in the mempool along with what the application
+// reported about it in CheckTx.
+type TxEntry struct {
+	Tx types.Tx
+	// Height is the height the transaction was first checked at.
+	Height   int64
+	Priority int64
+	Sender   string
+	Nonce    uint64
+}
+
+// Reasons for a transaction to leave the mempool, as reported in
+// types.EventDataMempoolTx.
+const (
+	TxRemovedCommitted = "committed"
+	TxRemovedInvalid   = "invalid"
+	TxRemovedExpired   = "expired"
+	TxRemovedEvicted   = "evicted"
+	TxRemovedReplaced  = "replaced"
+	TxRemovedManually  = "removed"
+)
+
 //--------------------------------------------------------------------------------
 
 // PreCheckMaxBytes checks that the size of the transaction is smaller or equal to the expected maxBytes.
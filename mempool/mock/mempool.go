@@ -20,6 +20,16 @@ func (Mempool) CheckTx(_ types.Tx, _ func(*abci.Response), _ mempl.TxInfo) error
 }
 func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (Mempool) ReapMaxSharesMaxGas(_ int, _ int64) (types.Txs, []types.Message) {
+	return types.Txs{}, nil
+}
+func (Mempool) TxByKey(_ [mempl.TxKeySize]byte) (mempl.TxEntry, bool) {
+	return mempl.TxEntry{}, false
+}
+func (Mempool) TxEntries() []mempl.TxEntry { return nil }
+func (Mempool) RemoveTxByKey(_ [mempl.TxKeySize]byte, _ bool) error {
+	return mempl.ErrTxNotFound
+}
 func (Mempool) Update(
 	_ int64,
 	_ types.Txs,
@@ -0,0 +1,264 @@
+package mempool
+
+import (
+	"github.com/google/btree"
+
+	cfg "github.com/lazyledger/lazyledger-core/config"
+	"github.com/lazyledger/lazyledger-core/libs/clist"
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
+	"github.com/lazyledger/lazyledger-core/proxy"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// PriorityMempool is a mempool that orders transactions by the priority the
+// application assigns to them in ResponseCheckTx instead of by arrival. Txs
+// with equal priority keep their arrival order. When the mempool is full, txs
+// with a lower priority than the incoming one are evicted to make room for it.
+//
+// Txs are stored in the embedded CListMempool, so they are still gossiped in
+// the order they were added and the mempool Reactor works unchanged. They are
+// additionally indexed by priority, so that reaping and eviction don't need
+// to sort the whole mempool.
+type PriorityMempool struct {
+	*CListMempool
+
+	byPriority *priorityIndex
+}
+
+var _ Mempool = &PriorityMempool{}
+
+// NewPriorityMempool returns a new priority mempool with the given
+// configuration and connection to an application.
+func NewPriorityMempool(
+	config *cfg.MempoolConfig,
+	proxyAppConn proxy.AppConnMempool,
+	height int64,
+	options ...CListMempoolOption,
+) *PriorityMempool {
+	mem := &PriorityMempool{
+		CListMempool: NewCListMempool(config, proxyAppConn, height, options...),
+		byPriority:   newPriorityIndex(),
+	}
+	mem.admitTx = mem.makeRoom
+	mem.index = mem.byPriority
+	return mem
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	return reapMaxBytesMaxGas(mem.priorityTxs(), maxBytes, maxGas)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxSharesMaxGas(maxShares int, maxGas int64) (types.Txs, []types.Message) {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	return reapMaxSharesMaxGas(mem.priorityTxs(), maxShares, maxGas)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxTxs(max int) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	return reapMaxTxs(mem.priorityTxs(), max)
+}
+
+// TxEntries returns all transactions, highest priority first.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) TxEntries() []TxEntry {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	return txEntries(mem.priorityTxs())
+}
+
+// priorityTxs returns all txs, highest priority first.
+func (mem *PriorityMempool) priorityTxs() []*mempoolTx {
+	elems := mem.byPriority.ascend()
+	memTxs := make([]*mempoolTx, len(elems))
+	for i, e := range elems {
+		memTxs[i] = e.Value.(*mempoolTx)
+	}
+	return memTxs
+}
+
+// makeRoom is called instead of isFullReplacing once the app has checked
+// memTx. If the mempool is full, even after removing replaced, it evicts the
+// lowest priority txs (most recent first among equal priorities) as long as
+// they have a lower priority than memTx. Nothing is evicted if that wouldn't
+// free enough room. If memTx can't outbid even the lowest priority, it fails
+// with ErrTxUnderpriced before looking for victims.
+//
+// addMtx must be held by the caller during execution.
+func (mem *PriorityMempool) makeRoom(memTx, replaced *mempoolTx) error {
+	err := mem.isFullReplacing(memTx.size(), replaced)
+	if err == nil {
+		return nil
+	}
+	priority := memTx.Priority()
+	if lowest, ok := mem.byPriority.lowest(); ok && priority <= lowest {
+		return ErrTxUnderpriced{priority, lowest}
+	}
+
+	var (
+		numTxs   = mem.Size()
+		txsBytes = mem.TxsBytes()
+		victims  []*clist.CElement
+	)
+	if replaced != nil {
+		numTxs--
+		txsBytes -= int64(replaced.size())
+	}
+	full := func() bool {
+		return numTxs >= mem.config.Size || int64(memTx.size())+txsBytes > mem.config.MaxTxsBytes
+	}
+	mem.byPriority.descend(func(item *priorityItem) bool {
+		if !full() || item.priority >= priority {
+			return false
+		}
+		if victim := item.elem.Value.(*mempoolTx); victim != replaced {
+			victims = append(victims, item.elem)
+			numTxs--
+			txsBytes -= int64(victim.size())
+		}
+		return true
+	})
+	if full() {
+		return err
+	}
+
+	for _, e := range victims {
+		victim := e.Value.(*mempoolTx)
+		mem.logger.Info("Evicted transaction",
+			"tx", txID(victim.tx),
+			"priority", victim.Priority(),
+			"for", txID(memTx.tx),
+			"new-priority", priority,
+		)
+		// remove from cache so the tx can be resubmitted once there's room
+		mem.removeTx(victim.tx, e, true, TxRemovedEvicted)
+		mem.metrics.EvictedTxs.Add(1)
+	}
+	return nil
+}
+
+//--------------------------------------------------------------------------------
+
+// priorityItem is the entry of a tx in a priorityIndex.
+type priorityItem struct {
+	priority int64
+	seq      uint64 // order in which the txs were added
+	elem     *clist.CElement
+}
+
+// Less orders items by descending priority, then by arrival.
+func (item *priorityItem) Less(than btree.Item) bool {
+	other := than.(*priorityItem)
+	if item.priority != other.priority {
+		return item.priority > other.priority
+	}
+	return item.seq < other.seq
+}
+
+// priorityIndex orders the txs of a mempool by their priority. It implements
+// txIndex and is safe for concurrent use.
+type priorityIndex struct {
+	mtx   tmsync.Mutex
+	tree  *btree.BTree
+	items map[*clist.CElement]*priorityItem
+	seq   uint64
+}
+
+var _ txIndex = (*priorityIndex)(nil)
+
+func newPriorityIndex() *priorityIndex {
+	return &priorityIndex{
+		tree:  btree.New(32),
+		items: make(map[*clist.CElement]*priorityItem),
+	}
+}
+
+func (idx *priorityIndex) add(e *clist.CElement) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	idx.seq++
+	item := &priorityItem{priority: e.Value.(*mempoolTx).Priority(), seq: idx.seq, elem: e}
+	idx.items[e] = item
+	idx.tree.ReplaceOrInsert(item)
+}
+
+func (idx *priorityIndex) remove(e *clist.CElement) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	if item, ok := idx.items[e]; ok {
+		idx.tree.Delete(item)
+		delete(idx.items, e)
+	}
+}
+
+// setPriority sets the priority of the tx and moves it to its new position.
+func (idx *priorityIndex) setPriority(e *clist.CElement, priority int64) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	e.Value.(*mempoolTx).setPriority(priority)
+	item, ok := idx.items[e]
+	if !ok || item.priority == priority {
+		return
+	}
+	idx.tree.Delete(item)
+	item.priority = priority
+	idx.tree.ReplaceOrInsert(item)
+}
+
+func (idx *priorityIndex) reset() {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	idx.tree.Clear(false)
+	idx.items = make(map[*clist.CElement]*priorityItem)
+}
+
+// lowest returns the lowest priority of the txs and false if there are none.
+func (idx *priorityIndex) lowest() (int64, bool) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	if idx.tree.Len() == 0 {
+		return 0, false
+	}
+	return idx.tree.Max().(*priorityItem).priority, true
+}
+
+// ascend returns the txs from the highest priority to the lowest.
+func (idx *priorityIndex) ascend() []*clist.CElement {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	elems := make([]*clist.CElement, 0, idx.tree.Len())
+	idx.tree.Ascend(func(i btree.Item) bool {
+		elems = append(elems, i.(*priorityItem).elem)
+		return true
+	})
+	return elems
+}
+
+// descend calls fn for the txs from the lowest priority to the highest, most
+// recent first among equal priorities, as long as it returns true.
+// fn must not modify the index.
+func (idx *priorityIndex) descend(fn func(*priorityItem) bool) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+
+	idx.tree.Descend(func(i btree.Item) bool {
+		return fn(i.(*priorityItem))
+	})
+}
@@ -0,0 +1,167 @@
+package mempool
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/abci/example/kvstore"
+	abci "github.com/lazyledger/lazyledger-core/abci/types"
+	cfg "github.com/lazyledger/lazyledger-core/config"
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/proxy"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// priorityApp uses the first byte of a tx as its priority and, if it is not
+// zero, the second byte as its sender and the third as its nonce.
+type priorityApp struct {
+	*kvstore.Application
+}
+
+func (app priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := app.Application.CheckTx(req)
+	res.Priority = int64(req.Tx[0])
+	if req.Tx[1] != 0 {
+		res.Sender = fmt.Sprintf("sender-%d", req.Tx[1])
+		res.Nonce = uint64(req.Tx[2])
+	}
+	return res
+}
+
+func newPriorityMempool(t *testing.T, size int) (*PriorityMempool, cleanupFunc) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Type = cfg.MempoolTypePriority
+	config.Mempool.Size = size
+
+	cc := proxy.NewLocalClientCreator(priorityApp{kvstore.NewApplication()})
+	appConnMem, _ := cc.NewABCIClient()
+	appConnMem.SetLogger(log.TestingLogger().With("module", "abci-client", "connection", "mempool"))
+	require.NoError(t, appConnMem.Start())
+
+	mempool := NewPriorityMempool(config.Mempool, appConnMem, 0)
+	mempool.SetLogger(log.TestingLogger())
+	return mempool, func() { os.RemoveAll(config.RootDir) }
+}
+
+func TestPriorityMempoolReap(t *testing.T) {
+	mempool, cleanup := newPriorityMempool(t, 100)
+	defer cleanup()
+
+	txs := types.Txs{{1, 0, 0}, {3, 0, 1}, {2, 0, 2}, {3, 0, 3}}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	byPriority := types.Txs{txs[1], txs[3], txs[2], txs[0]}
+
+	assert.Equal(t, byPriority, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, byPriority, mempool.ReapMaxBytesMaxGas(-1, -1))
+	got, msgs := mempool.ReapMaxSharesMaxGas(-1, -1)
+	assert.Equal(t, byPriority, got)
+	assert.Len(t, msgs, len(txs))
+
+	// the highest priority txs are reaped first
+	maxBytes := types.ComputeProtoSizeForTxs(byPriority[:2])
+	assert.Equal(t, byPriority[:2], mempool.ReapMaxBytesMaxGas(maxBytes, -1))
+
+	// txs are still gossiped in the order they were added
+	i := 0
+	for e := mempool.TxsFront(); e != nil; e = e.Next() {
+		assert.Equal(t, txs[i], e.Value.(*mempoolTx).tx)
+		i++
+	}
+	assert.Equal(t, len(txs), i)
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	mempool, cleanup := newPriorityMempool(t, 2)
+	defer cleanup()
+
+	low, mid, high := types.Tx{1, 0, 0}, types.Tx{2, 0, 0}, types.Tx{3, 0, 0}
+	require.NoError(t, mempool.CheckTx(mid, nil, TxInfo{}))
+	require.NoError(t, mempool.CheckTx(low, nil, TxInfo{}))
+	require.Equal(t, 2, mempool.Size())
+
+	// a higher priority tx evicts the lowest priority one
+	require.NoError(t, mempool.CheckTx(high, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{high, mid}, mempool.ReapMaxTxs(-1))
+
+	// txs with a priority no higher than any in the mempool are rejected
+	require.NoError(t, mempool.CheckTx(types.Tx{2, 0, 1}, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{high, mid}, mempool.ReapMaxTxs(-1))
+	// and stay in the cache, so they aren't checked with the app again
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx(types.Tx{2, 0, 1}, nil, TxInfo{}))
+
+	// the evicted tx was removed from the cache so it can come back later
+	require.NoError(t, mempool.Update(1, types.Txs{high}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	require.NoError(t, mempool.CheckTx(low, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{mid, low}, mempool.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolReplaceWhenFull(t *testing.T) {
+	mempool, cleanup := newPriorityMempool(t, 1)
+	defer cleanup()
+	mempool.config.MaxTxsBytes = 4
+
+	// priority, sender, nonce
+	first := types.Tx{1, 7, 0}
+	require.NoError(t, mempool.CheckTx(first, nil, TxInfo{}))
+
+	// the replacement doesn't fit even once the replaced tx is gone, so it is
+	// rejected while the replaced tx is kept
+	tooBig := types.Tx{2, 7, 0, 0, 0}
+	require.NoError(t, mempool.CheckTx(tooBig, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{first}, mempool.ReapMaxTxs(-1))
+
+	// a replacement fitting into the room of the replaced tx is accepted
+	higher := types.Tx{2, 7, 0, 0}
+	require.NoError(t, mempool.CheckTx(higher, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{higher}, mempool.ReapMaxTxs(-1))
+	assert.EqualValues(t, len(higher), mempool.TxsBytes())
+}
+
+// recheckPriorityApp negates the priority of txs on recheck.
+type recheckPriorityApp struct {
+	priorityApp
+}
+
+func (app recheckPriorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	res := app.priorityApp.CheckTx(req)
+	if req.Type == abci.CheckTxType_Recheck {
+		res.Priority = -res.Priority
+	}
+	return res
+}
+
+func TestPriorityMempoolRecheckUpdatesPriority(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Mempool.Type = cfg.MempoolTypePriority
+	config.Mempool.Size = 3
+
+	cc := proxy.NewLocalClientCreator(recheckPriorityApp{priorityApp{kvstore.NewApplication()}})
+	appConnMem, _ := cc.NewABCIClient()
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() { _ = appConnMem.Stop() })
+	mempool := NewPriorityMempool(config.Mempool, appConnMem, 0)
+
+	low, mid, high := types.Tx{1, 0, 0}, types.Tx{2, 0, 0}, types.Tx{3, 0, 0}
+	for _, tx := range []types.Tx{low, mid, high} {
+		require.NoError(t, mempool.CheckTx(tx, nil, TxInfo{}))
+	}
+	assert.Equal(t, types.Txs{high, mid, low}, mempool.ReapMaxTxs(-1))
+
+	// the txs are reordered by the priorities they got on recheck
+	require.NoError(t, mempool.Update(1, types.Txs{{4, 0, 0}}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+	assert.Equal(t, types.Txs{low, mid, high}, mempool.ReapMaxTxs(-1))
+	entries := mempool.TxEntries()
+	require.Len(t, entries, 3)
+	assert.EqualValues(t, -1, entries[0].Priority)
+
+	// and the tx with the lowest priority now is evicted first
+	require.NoError(t, mempool.CheckTx(types.Tx{0, 0, 0}, nil, TxInfo{}))
+	assert.Equal(t, types.Txs{{0, 0, 0}, low, mid}, mempool.ReapMaxTxs(-1))
+}
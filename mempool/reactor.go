@@ -1,22 +1,28 @@
 package mempool
 
 import (
-	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	cfg "github.com/lazyledger/lazyledger-core/config"
 	"github.com/lazyledger/lazyledger-core/libs/clist"
 	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/libs/service"
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
 	protomem "github.com/lazyledger/lazyledger-core/proto/tendermint/mempool"
 	"github.com/lazyledger/lazyledger-core/types"
 )
 
+var (
+	_ service.Service = (*Reactor)(nil)
+	_ p2p.Wrapper     = (*protomem.Message)(nil)
+)
+
 const (
-	MempoolChannel = byte(0x30)
+	MempoolChannel = p2p.ChannelID(0x30)
 
 	peerCatchupSleepIntervalMS = 100 // If peer is behind, sleep this amount
 
@@ -27,31 +33,68 @@ const (
 	maxActiveIDs = math.MaxUint16
 )
 
+// GetChannelShims returns a map of ChannelDescriptorShim objects, where each
+// object wraps a reference to a legacy p2p ChannelDescriptor and the corresponding
+// p2p proto.Message the new p2p Channel is responsible for handling.
+//
+// TODO: Remove once p2p refactor is complete.
+// ref: https://github.com/tendermint/tendermint/issues/5670
+func GetChannelShims(config *cfg.MempoolConfig) map[p2p.ChannelID]*p2p.ChannelDescriptorShim {
+	return map[p2p.ChannelID]*p2p.ChannelDescriptorShim{
+		MempoolChannel: {
+			MsgType: new(protomem.Message),
+			Descriptor: &p2p.ChannelDescriptor{
+				ID:                  byte(MempoolChannel),
+				Priority:            5,
+				RecvMessageCapacity: config.MaxBatchBytes,
+			},
+		},
+	}
+}
+
+// PeerManager provides the information the reactor needs about peers that it
+// does not track itself. It is implemented by the consensus reactor.
+type PeerManager interface {
+	// GetHeight returns the latest height the peer is known to be at, or 0 if
+	// it is not known.
+	GetHeight(p2p.PeerID) int64
+}
+
 // Reactor handles mempool tx broadcasting amongst peers.
 // It maintains a map from peer ID to counter, to prevent gossiping txs to the
 // peers you received it from.
 type Reactor struct {
-	p2p.BaseReactor
-	config  *cfg.MempoolConfig
-	mempool *CListMempool
-	ids     *mempoolIDs
+	service.BaseService
+
+	config      *cfg.MempoolConfig
+	mempool     *CListMempool
+	ids         *mempoolIDs
+	peerMgr     PeerManager
+	mempoolCh   *p2p.Channel
+	peerUpdates *p2p.PeerUpdatesCh
+	closeCh     chan struct{}
+
+	peerWG sync.WaitGroup
+
+	mtx          tmsync.Mutex
+	peerRoutines map[string]*tmsync.Closer
 }
 
 type mempoolIDs struct {
 	mtx       tmsync.RWMutex
-	peerMap   map[p2p.ID]uint16
+	peerMap   map[string]uint16
 	nextID    uint16              // assumes that a node will never have over 65536 active peers
 	activeIDs map[uint16]struct{} // used to check if a given peerID key is used, the value doesn't matter
 }
 
-// Reserve searches for the next unused ID and assigns it to the
+// ReserveForPeer searches for the next unused ID and assigns it to the
 // peer.
-func (ids *mempoolIDs) ReserveForPeer(peer p2p.Peer) {
+func (ids *mempoolIDs) ReserveForPeer(peerID p2p.PeerID) {
 	ids.mtx.Lock()
 	defer ids.mtx.Unlock()
 
 	curID := ids.nextPeerID()
-	ids.peerMap[peer.ID()] = curID
+	ids.peerMap[peerID.String()] = curID
 	ids.activeIDs[curID] = struct{}{}
 }
 
@@ -73,185 +116,292 @@ func (ids *mempoolIDs) nextPeerID() uint16 {
 }
 
 // Reclaim returns the ID reserved for the peer back to unused pool.
-func (ids *mempoolIDs) Reclaim(peer p2p.Peer) {
+func (ids *mempoolIDs) Reclaim(peerID p2p.PeerID) {
 	ids.mtx.Lock()
 	defer ids.mtx.Unlock()
 
-	removedID, ok := ids.peerMap[peer.ID()]
+	removedID, ok := ids.peerMap[peerID.String()]
 	if ok {
 		delete(ids.activeIDs, removedID)
-		delete(ids.peerMap, peer.ID())
+		delete(ids.peerMap, peerID.String())
 	}
 }
 
 // GetForPeer returns an ID reserved for the peer.
-func (ids *mempoolIDs) GetForPeer(peer p2p.Peer) uint16 {
+func (ids *mempoolIDs) GetForPeer(peerID p2p.PeerID) uint16 {
 	ids.mtx.RLock()
 	defer ids.mtx.RUnlock()
 
-	return ids.peerMap[peer.ID()]
+	return ids.peerMap[peerID.String()]
 }
 
 func newMempoolIDs() *mempoolIDs {
 	return &mempoolIDs{
-		peerMap:   make(map[p2p.ID]uint16),
+		peerMap:   make(map[string]uint16),
 		activeIDs: map[uint16]struct{}{0: {}},
 		nextID:    1, // reserve unknownPeerID(0) for mempoolReactor.BroadcastTx
 	}
 }
 
-// NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
-	memR := &Reactor{
-		config:  config,
-		mempool: mempool,
-		ids:     newMempoolIDs(),
+// NewReactor returns a reference to a new mempool reactor, which implements
+// the service.Service interface. It accepts a logger, the mempool config, the
+// mempool, a PeerManager used to learn the peers' heights, a p2p Channel to
+// gossip txs on and a channel to listen for peer updates on. The PeerManager
+// may be nil, in which case txs are sent to peers regardless of their height.
+// Note, the reactor will close all p2p Channels when stopping.
+func NewReactor(
+	logger log.Logger,
+	config *cfg.MempoolConfig,
+	mempool *CListMempool,
+	peerMgr PeerManager,
+	mempoolCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdatesCh,
+) *Reactor {
+	r := &Reactor{
+		config:       config,
+		mempool:      mempool,
+		ids:          newMempoolIDs(),
+		peerMgr:      peerMgr,
+		mempoolCh:    mempoolCh,
+		peerUpdates:  peerUpdates,
+		closeCh:      make(chan struct{}),
+		peerRoutines: make(map[string]*tmsync.Closer),
 	}
-	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
-	return memR
+
+	r.BaseService = *service.NewBaseService(logger, "Mempool", r)
+	return r
 }
 
-// InitPeer implements Reactor by creating a state for the peer.
-func (memR *Reactor) InitPeer(peer p2p.Peer) p2p.Peer {
-	memR.ids.ReserveForPeer(peer)
-	return peer
+// OnStart starts separate go routines for each p2p Channel and listens for
+// envelopes on each. In addition, it also listens for peer updates and handles
+// messages on that p2p channel accordingly. The caller must be sure to execute
+// OnStop to ensure the outbound p2p Channels are closed. No error is returned.
+func (r *Reactor) OnStart() error {
+	if !r.config.Broadcast {
+		r.Logger.Info("Tx broadcasting is disabled")
+	}
+
+	go r.processMempoolCh()
+	go r.processPeerUpdates()
+
+	return nil
 }
 
-// SetLogger sets the Logger on the reactor and the underlying mempool.
-func (memR *Reactor) SetLogger(l log.Logger) {
-	memR.Logger = l
-	memR.mempool.SetLogger(l)
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {
+	r.mtx.Lock()
+	for _, c := range r.peerRoutines {
+		c.Close()
+	}
+	r.mtx.Unlock()
+
+	// Wait for all spawned broadcast goroutines to gracefully exit before
+	// closing the p2p Channel they send on.
+	r.peerWG.Wait()
+
+	// Close closeCh to signal to all spawned goroutines to gracefully exit. All
+	// p2p Channels should execute Close().
+	close(r.closeCh)
+
+	// Wait for all p2p Channels to be closed before returning. This ensures we
+	// can easily reason about synchronization of all p2p Channels and ensure no
+	// panics will occur.
+	<-r.mempoolCh.Done()
+	<-r.peerUpdates.Done()
 }
 
-// OnStart implements p2p.BaseReactor.
-func (memR *Reactor) OnStart() error {
-	if !memR.config.Broadcast {
-		memR.Logger.Info("Tx broadcasting is disabled")
+// handleMempoolMessage handles envelopes sent from peers on the MempoolChannel.
+// For every tx in the message, we execute CheckTx. It returns an error only if
+// the Envelope.Message is unknown for this channel or if it carries no txs.
+// This should never be called outside of handleMessage.
+func (r *Reactor) handleMempoolMessage(envelope p2p.Envelope) error {
+	logger := r.Logger.With("peer", envelope.From.String())
+
+	switch msg := envelope.Message.(type) {
+	case *protomem.Txs:
+		protoTxs := msg.GetTxs()
+		if len(protoTxs) == 0 {
+			return fmt.Errorf("empty txs received from peer %s", envelope.From)
+		}
+
+		txInfo := TxInfo{
+			SenderID:    r.ids.GetForPeer(envelope.From),
+			SenderP2PID: p2p.ID(envelope.From.String()),
+		}
+		for _, tx := range protoTxs {
+			if err := r.mempool.CheckTx(types.Tx(tx), nil, txInfo); err != nil {
+				logger.Info("Could not check tx", "tx", txID(tx), "err", err)
+			}
+		}
+
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
 	}
+
 	return nil
 }
 
-// GetChannels implements Reactor by returning the list of channels for this
-// reactor.
-func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
-	maxMsgSize := memR.config.MaxBatchBytes
-	return []*p2p.ChannelDescriptor{
-		{
-			ID:                  MempoolChannel,
-			Priority:            5,
-			RecvMessageCapacity: maxMsgSize,
-		},
+// handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
+// It will handle errors and any possible panics gracefully. A caller can handle
+// any error returned by sending a PeerError on the respective channel.
+func (r *Reactor) handleMessage(chID p2p.ChannelID, envelope p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			r.Logger.Error("recovering from processing message panic", "err", err)
+		}
+	}()
+
+	switch chID {
+	case MempoolChannel:
+		err = r.handleMempoolMessage(envelope)
+
+	default:
+		err = fmt.Errorf("unknown channel ID (%d) for envelope (%v)", chID, envelope)
 	}
+
+	return err
 }
 
-// AddPeer implements Reactor.
-// It starts a broadcast routine ensuring all txs are forwarded to the given peer.
-func (memR *Reactor) AddPeer(peer p2p.Peer) {
-	if memR.config.Broadcast {
-		go memR.broadcastTxRoutine(peer)
+// processMempoolCh implements a blocking event loop where we listen for p2p
+// Envelope messages from the mempoolCh. Any error encountered during message
+// execution will result in a PeerError being sent on the MempoolChannel. When
+// the reactor is stopped, we will catch the signal and close the p2p Channel
+// gracefully.
+func (r *Reactor) processMempoolCh() {
+	defer r.mempoolCh.Close()
+
+	for {
+		select {
+		case envelope := <-r.mempoolCh.In():
+			if err := r.handleMessage(r.mempoolCh.ID(), envelope); err != nil {
+				r.mempoolCh.Error() <- p2p.PeerError{
+					PeerID:   envelope.From,
+					Err:      err,
+					Severity: p2p.PeerErrorSeverityLow,
+				}
+			}
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on mempool channel; closing...")
+			return
+		}
 	}
 }
 
-// RemovePeer implements Reactor.
-func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
-	memR.ids.Reclaim(peer)
-	// broadcast routine checks if peer is gone and returns
-}
+// processPeerUpdate processes a PeerUpdate. For new or live peers it will
+// reserve a mempool ID and, if broadcasting is enabled, start a goroutine to
+// broadcast txs to that peer, which is stopped once the peer goes down.
+func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
+	r.Logger.Debug("received peer update", "peer", peerUpdate.PeerID.String(), "status", peerUpdate.Status)
 
-// Receive implements Reactor.
-// It adds any received transactions to the mempool.
-// XXX: do not call any methods that can block or incur heavy processing.
-// https://github.com/tendermint/tendermint/issues/2888
-func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	msg, err := memR.decodeMsg(msgBytes)
-	if err != nil {
-		memR.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
-		memR.Switch.StopPeerForError(src, err)
-		return
-	}
-	memR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
 
-	txInfo := TxInfo{SenderID: memR.ids.GetForPeer(src)}
-	if src != nil {
-		txInfo.SenderP2PID = src.ID()
-	}
-	for _, tx := range msg.Txs {
-		err = memR.mempool.CheckTx(tx, nil, txInfo)
-		if err != nil {
-			memR.Logger.Info("Could not check tx", "tx", txID(tx), "err", err)
+	peerKey := peerUpdate.PeerID.String()
+
+	switch peerUpdate.Status {
+	case p2p.PeerStatusNew, p2p.PeerStatusUp:
+		// Do not allow starting new tx broadcast loops after reactor shutdown
+		// has been initiated. This can happen after we've manually closed all
+		// peer broadcast loops, but the router still sends in-flight peer updates.
+		if !r.IsRunning() {
+			return
+		}
+
+		if _, ok := r.peerRoutines[peerKey]; !ok {
+			r.ids.ReserveForPeer(peerUpdate.PeerID)
+
+			closer := tmsync.NewCloser()
+			r.peerRoutines[peerKey] = closer
+
+			if r.config.Broadcast {
+				r.peerWG.Add(1)
+				go r.broadcastTxRoutine(peerUpdate.PeerID, closer)
+			}
+		}
+
+	case p2p.PeerStatusDown, p2p.PeerStatusRemoved, p2p.PeerStatusBanned:
+		if closer, ok := r.peerRoutines[peerKey]; ok {
+			closer.Close()
+			delete(r.peerRoutines, peerKey)
+			r.ids.Reclaim(peerUpdate.PeerID)
 		}
 	}
-	// broadcasting happens from go routines per peer
 }
 
-// PeerState describes the state of a peer.
-type PeerState interface {
-	GetHeight() int64
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. When the reactor is stopped, we will catch the signal and
+// close the p2p PeerUpdatesCh gracefully.
+func (r *Reactor) processPeerUpdates() {
+	defer r.peerUpdates.Close()
+
+	for {
+		select {
+		case peerUpdate := <-r.peerUpdates.Updates():
+			r.processPeerUpdate(peerUpdate)
+
+		case <-r.closeCh:
+			r.Logger.Debug("stopped listening on peer updates channel; closing...")
+			return
+		}
+	}
 }
 
 // Send new mempool txs to peer.
-func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
-	peerID := memR.ids.GetForPeer(peer)
+func (r *Reactor) broadcastTxRoutine(peerID p2p.PeerID, closer *tmsync.Closer) {
+	defer r.peerWG.Done()
+
+	memPeerID := r.ids.GetForPeer(peerID)
 	var next *clist.CElement
 
 	for {
-		// In case of both next.NextWaitChan() and peer.Quit() are variable at the same time
-		if !memR.IsRunning() || !peer.IsRunning() {
-			return
-		}
 		// This happens because the CElement we were looking at got garbage
 		// collected (removed). That is, .NextWait() returned nil. Go ahead and
 		// start from the beginning.
 		if next == nil {
 			select {
-			case <-memR.mempool.TxsWaitChan(): // Wait until a tx is available
-				if next = memR.mempool.TxsFront(); next == nil {
+			case <-r.mempool.TxsWaitChan(): // Wait until a tx is available
+				if next = r.mempool.TxsFront(); next == nil {
 					continue
 				}
-			case <-peer.Quit():
-				return
-			case <-memR.Quit():
+			case <-closer.Done():
 				return
 			}
 		}
 
-		// Make sure the peer is up to date.
-		peerState, ok := peer.Get(types.PeerStateKey).(PeerState)
-		if !ok {
-			// Peer does not have a state yet. We set it in the consensus reactor, but
-			// when we add peer in Switch, the order we call reactors#AddPeer is
-			// different every time due to us using a map. Sometimes other reactors
-			// will be initialized before the consensus reactor. We should wait a few
-			// milliseconds and retry.
-			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
-			continue
+		// Make sure the peer is up to date. The height is unknown (0) until
+		// the consensus reactor hears from the peer, in which case we wait a
+		// few milliseconds and retry.
+		memTx := next.Value.(*mempoolTx)
+		var peerHeight int64 = math.MaxInt64
+		if r.peerMgr != nil {
+			peerHeight = r.peerMgr.GetHeight(peerID)
 		}
 
 		// Allow for a lag of 1 block.
-		memTx := next.Value.(*mempoolTx)
-		if peerState.GetHeight() < memTx.Height()-1 {
-			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
-			continue
+		if peerHeight == 0 || peerHeight < memTx.Height()-1 {
+			select {
+			case <-time.After(peerCatchupSleepIntervalMS * time.Millisecond):
+				continue
+			case <-closer.Done():
+				return
+			}
 		}
 
-		txs := memR.txs(next, peerID, peerState.GetHeight()) // WARNING: mutates next!
+		txs := r.txs(next, memPeerID, peerHeight) // WARNING: mutates next!
 
 		// send txs
 		if len(txs) > 0 {
-			msg := protomem.Message{
-				Sum: &protomem.Message_Txs{
-					Txs: &protomem.Txs{Txs: txs},
-				},
-			}
-			bz, err := msg.Marshal()
-			if err != nil {
-				panic(err)
-			}
-			memR.Logger.Debug("Sending N txs to peer", "N", len(txs), "peer", peer)
-			success := peer.Send(MempoolChannel, bz)
-			if !success {
-				time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
-				continue
+			r.Logger.Debug("Sending N txs to peer", "N", len(txs), "peer", peerID.String())
+			select {
+			case r.mempoolCh.Out() <- p2p.Envelope{
+				To:      peerID,
+				Message: &protomem.Txs{Txs: txs},
+			}:
+			case <-closer.Done():
+				return
 			}
 		}
 
@@ -259,9 +409,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		case <-next.NextWaitChan():
 			// see the start of the for loop for nil check
 			next = next.Next()
-		case <-peer.Quit():
-			return
-		case <-memR.Quit():
+		case <-closer.Done():
 			return
 		}
 	}
@@ -270,7 +418,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 // txs iterates over the transaction list and builds a batch of txs. next is
 // included.
 // WARNING: mutates next!
-func (memR *Reactor) txs(next *clist.CElement, peerID uint16, peerHeight int64) [][]byte {
+func (r *Reactor) txs(next *clist.CElement, peerID uint16, peerHeight int64) [][]byte {
 	batch := make([][]byte, 0)
 
 	for {
@@ -283,7 +431,7 @@ func (memR *Reactor) txs(next *clist.CElement, peerID uint16, peerHeight int64)
 					Txs: &protomem.Txs{Txs: append(batch, memTx.tx)},
 				},
 			}
-			if batchMsg.Size() > memR.config.MaxBatchBytes {
+			if batchMsg.Size() > r.config.MaxBatchBytes {
 				return batch
 			}
 
@@ -297,47 +445,3 @@ func (memR *Reactor) txs(next *clist.CElement, peerID uint16, peerHeight int64)
 		next = n
 	}
 }
-
-//-----------------------------------------------------------------------------
-// Messages
-
-func (memR *Reactor) decodeMsg(bz []byte) (TxsMessage, error) {
-	msg := protomem.Message{}
-	err := msg.Unmarshal(bz)
-	if err != nil {
-		return TxsMessage{}, err
-	}
-
-	var message TxsMessage
-
-	if i, ok := msg.Sum.(*protomem.Message_Txs); ok {
-		txs := i.Txs.GetTxs()
-
-		if len(txs) == 0 {
-			return message, errors.New("empty TxsMessage")
-		}
-
-		decoded := make([]types.Tx, len(txs))
-		for j, tx := range txs {
-			decoded[j] = types.Tx(tx)
-		}
-
-		message = TxsMessage{
-			Txs: decoded,
-		}
-		return message, nil
-	}
-	return message, fmt.Errorf("msg type: %T is not supported", msg)
-}
-
-//-------------------------------------
-
-// TxsMessage is a Message containing transactions.
-type TxsMessage struct {
-	Txs []types.Tx
-}
-
-// String returns a string representation of the TxsMessage.
-func (m *TxsMessage) String() string {
-	return fmt.Sprintf("[TxsMessage %v]", m.Txs)
-}
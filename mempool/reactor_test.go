@@ -2,8 +2,6 @@ package mempool
 
 import (
 	"encoding/hex"
-	"errors"
-	"net"
 	"sync"
 	"testing"
 	"time"
@@ -19,7 +17,6 @@ import (
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
 	"github.com/lazyledger/lazyledger-core/p2p"
-	"github.com/lazyledger/lazyledger-core/p2p/mock"
 	memproto "github.com/lazyledger/lazyledger-core/proto/tendermint/mempool"
 	"github.com/lazyledger/lazyledger-core/proxy"
 	"github.com/lazyledger/lazyledger-core/types"
@@ -30,12 +27,117 @@ const (
 	timeout = 120 * time.Second // ridiculously high because CircleCI is slow
 )
 
-type peerState struct {
-	height int64
+type reactorTestSuite struct {
+	reactor *Reactor
+	peerID  p2p.PeerID
+
+	mempoolChannel   *p2p.Channel
+	mempoolInCh      chan p2p.Envelope
+	mempoolOutCh     chan p2p.Envelope
+	mempoolPeerErrCh chan p2p.PeerError
+
+	peerUpdatesCh chan p2p.PeerUpdate
+	peerUpdates   *p2p.PeerUpdatesCh
+}
+
+// setup creates n mempool reactors backed by a kvstore application. The
+// reactors are not connected to each other; see connectReactors.
+func setup(t *testing.T, config *cfg.MempoolConfig, n int, chBuf uint) []*reactorTestSuite {
+	t.Helper()
+
+	logger := mempoolLogger()
+	suites := make([]*reactorTestSuite, n)
+	for i := 0; i < n; i++ {
+		app := kvstore.NewApplication()
+		cc := proxy.NewLocalClientCreator(app)
+		mempool, cleanup := newMempoolWithApp(cc)
+		t.Cleanup(cleanup)
+
+		rts := &reactorTestSuite{
+			peerID:           p2p.PeerID{byte(i + 1)},
+			mempoolInCh:      make(chan p2p.Envelope, chBuf),
+			mempoolOutCh:     make(chan p2p.Envelope, chBuf),
+			mempoolPeerErrCh: make(chan p2p.PeerError, chBuf),
+			peerUpdatesCh:    make(chan p2p.PeerUpdate),
+		}
+		rts.peerUpdates = p2p.NewPeerUpdates(rts.peerUpdatesCh)
+
+		rts.mempoolChannel = p2p.NewChannel(
+			MempoolChannel,
+			new(memproto.Message),
+			rts.mempoolInCh,
+			rts.mempoolOutCh,
+			rts.mempoolPeerErrCh,
+		)
+
+		// a nil PeerManager, so we dont start the consensus states
+		rts.reactor = NewReactor(
+			logger.With("validator", i),
+			config,
+			mempool,
+			nil,
+			rts.mempoolChannel,
+			rts.peerUpdates,
+		)
+
+		require.NoError(t, rts.reactor.Start())
+		require.True(t, rts.reactor.IsRunning())
+
+		suites[i] = rts
+	}
+
+	t.Cleanup(func() {
+		for _, rts := range suites {
+			if rts.reactor.IsRunning() {
+				require.NoError(t, rts.reactor.Stop())
+				require.False(t, rts.reactor.IsRunning())
+			}
+		}
+	})
+
+	return suites
+}
+
+// simulateRouter delivers the envelopes sent by each reactor to the reactors
+// they are addressed to.
+func simulateRouter(suites []*reactorTestSuite) {
+	for _, src := range suites {
+		go func(src *reactorTestSuite) {
+			for envelope := range src.mempoolOutCh {
+				for _, dst := range suites {
+					if dst == src || !(envelope.Broadcast || envelope.To.Equal(dst.peerID)) {
+						continue
+					}
+
+					select {
+					case dst.mempoolInCh <- p2p.Envelope{From: src.peerID, Message: envelope.Message}:
+					case <-dst.mempoolChannel.Done():
+					}
+				}
+			}
+		}(src)
+	}
+}
+
+// connectReactors informs every reactor that all the other reactors are up.
+func connectReactors(suites []*reactorTestSuite) {
+	simulateRouter(suites)
+
+	for _, rts := range suites {
+		for _, peer := range suites {
+			if peer != rts {
+				rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peer.peerID, Status: p2p.PeerStatusUp}
+			}
+		}
+	}
 }
 
-func (ps peerState) GetHeight() int64 {
-	return ps.height
+func reactors(suites []*reactorTestSuite) []*Reactor {
+	reactors := make([]*Reactor, len(suites))
+	for i, rts := range suites {
+		reactors[i] = rts.reactor
+	}
+	return reactors
 }
 
 // Send a bunch of txs to the first reactor's mempool and wait for them all to
@@ -44,44 +146,27 @@ func TestReactorBroadcastTxsMessage(t *testing.T) {
 	config := cfg.TestConfig()
 	// if there were more than two reactors, the order of transactions could not be
 	// asserted in waitForTxsOnReactors (due to transactions gossiping). If we
-	// replace Connect2Switches (full mesh) with a func, which connects first
+	// replace connectReactors (full mesh) with a func, which connects first
 	// reactor to others and nothing else, this test should also pass with >2 reactors.
 	const N = 2
-	reactors := makeAndConnectReactors(config, N)
-	defer func() {
-		for _, r := range reactors {
-			if err := r.Stop(); err != nil {
-				assert.NoError(t, err)
-			}
-		}
-	}()
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			peer.Set(types.PeerStateKey, peerState{1})
-		}
-	}
+	suites := setup(t, config.Mempool, N, 0)
+	connectReactors(suites)
 
-	txs := checkTxs(t, reactors[0].mempool, numTxs, UnknownPeerID)
-	waitForTxsOnReactors(t, txs, reactors)
+	txs := checkTxs(t, suites[0].reactor.mempool, numTxs, UnknownPeerID)
+	waitForTxsOnReactors(t, txs, reactors(suites))
 }
 
 // regression test for https://github.com/tendermint/tendermint/issues/5408
 func TestReactorConcurrency(t *testing.T) {
 	config := cfg.TestConfig()
 	const N = 2
-	reactors := makeAndConnectReactors(config, N)
-	defer func() {
-		for _, r := range reactors {
-			if err := r.Stop(); err != nil {
-				assert.NoError(t, err)
-			}
-		}
-	}()
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			peer.Set(types.PeerStateKey, peerState{1})
-		}
-	}
+	// Flushing the mempool below races with txs received from the other
+	// reactor, which may be reported as peer errors. Buffer the channels so
+	// those don't block the reactors.
+	suites := setup(t, config.Mempool, N, 1000)
+	connectReactors(suites)
+	reactors := reactors(suites)
+
 	var wg sync.WaitGroup
 
 	const numTxs = 5
@@ -130,23 +215,37 @@ func TestReactorConcurrency(t *testing.T) {
 func TestReactorNoBroadcastToSender(t *testing.T) {
 	config := cfg.TestConfig()
 	const N = 2
-	reactors := makeAndConnectReactors(config, N)
-	defer func() {
-		for _, r := range reactors {
-			if err := r.Stop(); err != nil {
-				assert.NoError(t, err)
-			}
-		}
-	}()
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			peer.Set(types.PeerStateKey, peerState{1})
-		}
-	}
+	suites := setup(t, config.Mempool, N, 0)
+	connectReactors(suites)
 
 	const peerID = 1
-	checkTxs(t, reactors[0].mempool, numTxs, peerID)
-	ensureNoTxs(t, reactors[peerID], 100*time.Millisecond)
+	checkTxs(t, suites[0].reactor.mempool, numTxs, peerID)
+	ensureNoTxs(t, suites[peerID].reactor, 100*time.Millisecond)
+}
+
+// Txs are not sent to peers that are more than one block behind them.
+func TestReactorNoBroadcastToLaggingPeer(t *testing.T) {
+	config := cfg.TestConfig()
+	suites := setup(t, config.Mempool, 2, 0)
+	peerMgr := &peerHeights{heights: make(map[string]int64)}
+	suites[0].reactor.peerMgr = peerMgr
+	connectReactors(suites)
+
+	// the txs are checked at height 3 while the peer's height is unknown
+	reactor := suites[0].reactor
+	reactor.mempool.Lock()
+	err := reactor.mempool.Update(3, []types.Tx{}, make([]*abci.ResponseDeliverTx, 0), nil, nil)
+	reactor.mempool.Unlock()
+	require.NoError(t, err)
+	txs := checkTxs(t, reactor.mempool, 10, UnknownPeerID)
+	ensureNoTxs(t, suites[1].reactor, 300*time.Millisecond)
+
+	peerMgr.SetHeight(suites[1].peerID, 1)
+	ensureNoTxs(t, suites[1].reactor, 300*time.Millisecond)
+
+	// allow for a lag of 1 block
+	peerMgr.SetHeight(suites[1].peerID, 2)
+	waitForTxsOnReactors(t, txs, reactors(suites))
 }
 
 func TestReactor_MaxBatchBytes(t *testing.T) {
@@ -154,19 +253,9 @@ func TestReactor_MaxBatchBytes(t *testing.T) {
 	config.Mempool.MaxBatchBytes = 1024
 
 	const N = 2
-	reactors := makeAndConnectReactors(config, N)
-	defer func() {
-		for _, r := range reactors {
-			if err := r.Stop(); err != nil {
-				assert.NoError(t, err)
-			}
-		}
-	}()
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			peer.Set(types.PeerStateKey, peerState{1})
-		}
-	}
+	suites := setup(t, config.Mempool, N, 0)
+	connectReactors(suites)
+	reactors := reactors(suites)
 
 	// Broadcast a tx, which has the max size (minus proto overhead)
 	// => ensure it's received by the second reactor.
@@ -184,9 +273,20 @@ func TestReactor_MaxBatchBytes(t *testing.T) {
 	err = reactors[0].mempool.CheckTx(tx2, nil, TxInfo{SenderID: UnknownPeerID})
 	require.NoError(t, err)
 	ensureNoTxs(t, reactors[1], 100*time.Millisecond)
-	// => ensure the second reactor did not disconnect from us
-	out, in, _ := reactors[1].Switch.NumPeers()
-	assert.Equal(t, 1, out+in)
+	// => ensure the second reactor did not report us
+	require.Empty(t, suites[1].mempoolPeerErrCh)
+}
+
+func TestReactorEmptyTxsReportsPeerError(t *testing.T) {
+	config := cfg.TestConfig()
+	suites := setup(t, config.Mempool, 1, 1)
+	rts := suites[0]
+
+	rts.mempoolInCh <- p2p.Envelope{From: p2p.PeerID{0xAA}, Message: &memproto.Txs{}}
+
+	peerErr := <-rts.mempoolPeerErrCh
+	require.Error(t, peerErr.Err)
+	require.Equal(t, p2p.PeerID{0xAA}, peerErr.PeerID)
 }
 
 func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
@@ -196,18 +296,11 @@ func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
 
 	config := cfg.TestConfig()
 	const N = 2
-	reactors := makeAndConnectReactors(config, N)
-	defer func() {
-		for _, r := range reactors {
-			if err := r.Stop(); err != nil {
-				assert.NoError(t, err)
-			}
-		}
-	}()
+	suites := setup(t, config.Mempool, N, 0)
+	connectReactors(suites)
 
 	// stop peer
-	sw := reactors[1].Switch
-	sw.StopPeerForError(sw.Peers().List()[0], errors.New("some reason"))
+	suites[1].peerUpdatesCh <- p2p.PeerUpdate{PeerID: suites[0].peerID, Status: p2p.PeerStatusDown}
 
 	// check that we are not leaking any go-routines
 	// i.e. broadcastTxRoutine finishes when peer is stopped
@@ -221,13 +314,12 @@ func TestBroadcastTxForPeerStopsWhenReactorStops(t *testing.T) {
 
 	config := cfg.TestConfig()
 	const N = 2
-	reactors := makeAndConnectReactors(config, N)
+	suites := setup(t, config.Mempool, N, 0)
+	connectReactors(suites)
 
 	// stop reactors
-	for _, r := range reactors {
-		if err := r.Stop(); err != nil {
-			assert.NoError(t, err)
-		}
+	for _, rts := range suites {
+		require.NoError(t, rts.reactor.Stop())
 	}
 
 	// check that we are not leaking any go-routines
@@ -238,15 +330,15 @@ func TestBroadcastTxForPeerStopsWhenReactorStops(t *testing.T) {
 func TestMempoolIDsBasic(t *testing.T) {
 	ids := newMempoolIDs()
 
-	peer := mock.NewPeer(net.IP{127, 0, 0, 1})
+	peerID := p2p.PeerID{0xAA}
 
-	ids.ReserveForPeer(peer)
-	assert.EqualValues(t, 1, ids.GetForPeer(peer))
-	ids.Reclaim(peer)
+	ids.ReserveForPeer(peerID)
+	assert.EqualValues(t, 1, ids.GetForPeer(peerID))
+	ids.Reclaim(peerID)
 
-	ids.ReserveForPeer(peer)
-	assert.EqualValues(t, 2, ids.GetForPeer(peer))
-	ids.Reclaim(peer)
+	ids.ReserveForPeer(peerID)
+	assert.EqualValues(t, 2, ids.GetForPeer(peerID))
+	ids.Reclaim(peerID)
 }
 
 func TestMempoolIDsPanicsIfNodeRequestsOvermaxActiveIDs(t *testing.T) {
@@ -258,33 +350,27 @@ func TestMempoolIDsPanicsIfNodeRequestsOvermaxActiveIDs(t *testing.T) {
 	ids := newMempoolIDs()
 
 	for i := 0; i < maxActiveIDs-1; i++ {
-		peer := mock.NewPeer(net.IP{127, 0, 0, 1})
-		ids.ReserveForPeer(peer)
+		ids.ReserveForPeer(p2p.PeerID{byte(i >> 8), byte(i)})
 	}
 
 	assert.Panics(t, func() {
-		peer := mock.NewPeer(net.IP{127, 0, 0, 1})
-		ids.ReserveForPeer(peer)
+		ids.ReserveForPeer(p2p.PeerID{0xFF, 0xFF, 0xFF})
 	})
 }
 
 func TestDontExhaustMaxActiveIDs(t *testing.T) {
 	config := cfg.TestConfig()
-	const N = 1
-	reactors := makeAndConnectReactors(config, N)
-	defer func() {
-		for _, r := range reactors {
-			if err := r.Stop(); err != nil {
-				assert.NoError(t, err)
-			}
-		}
-	}()
-	reactor := reactors[0]
+	suites := setup(t, config.Mempool, 1, 0)
+	rts := suites[0]
 
 	for i := 0; i < maxActiveIDs+1; i++ {
-		peer := mock.NewPeer(nil)
-		reactor.Receive(MempoolChannel, peer, []byte{0x1, 0x2, 0x3})
-		reactor.AddPeer(peer)
+		peerID := p2p.PeerID{byte(i >> 8), byte(i)}
+		rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusUp}
+		rts.mempoolInCh <- p2p.Envelope{
+			From:    peerID,
+			Message: &memproto.Txs{Txs: [][]byte{{0x1, 0x2, 0x3}}},
+		}
+		rts.peerUpdatesCh <- p2p.PeerUpdate{PeerID: peerID, Status: p2p.PeerStatusDown}
 	}
 }
 
@@ -301,26 +387,22 @@ func mempoolLogger() log.Logger {
 	})
 }
 
-// connect N mempool reactors through N switches
-func makeAndConnectReactors(config *cfg.Config, n int) []*Reactor {
-	reactors := make([]*Reactor, n)
-	logger := mempoolLogger()
-	for i := 0; i < n; i++ {
-		app := kvstore.NewApplication()
-		cc := proxy.NewLocalClientCreator(app)
-		mempool, cleanup := newMempoolWithApp(cc)
-		defer cleanup()
-
-		reactors[i] = NewReactor(config.Mempool, mempool) // so we dont start the consensus states
-		reactors[i].SetLogger(logger.With("validator", i))
-	}
+// peerHeights is a PeerManager with settable peer heights.
+type peerHeights struct {
+	mtx     sync.Mutex
+	heights map[string]int64
+}
 
-	p2p.MakeConnectedSwitches(config.P2P, n, func(i int, s *p2p.Switch) *p2p.Switch {
-		s.AddReactor("MEMPOOL", reactors[i])
-		return s
+func (ph *peerHeights) SetHeight(peerID p2p.PeerID, height int64) {
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	ph.heights[peerID.String()] = height
+}
 
-	}, p2p.Connect2Switches)
-	return reactors
+func (ph *peerHeights) GetHeight(peerID p2p.PeerID) int64 {
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	return ph.heights[peerID.String()]
 }
 
 func waitForTxsOnReactors(t *testing.T, txs types.Txs, reactors []*Reactor) {
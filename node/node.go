@@ -5,7 +5,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"net"
 	"net/http"
 	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
@@ -34,7 +33,9 @@ import (
 	"github.com/lazyledger/lazyledger-core/light"
 	mempl "github.com/lazyledger/lazyledger-core/mempool"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/bridge"
 	"github.com/lazyledger/lazyledger-core/p2p/pex"
+	"github.com/lazyledger/lazyledger-core/p2p/trust"
 	"github.com/lazyledger/lazyledger-core/privval"
 	"github.com/lazyledger/lazyledger-core/proxy"
 	rpccore "github.com/lazyledger/lazyledger-core/rpc/core"
@@ -106,7 +107,7 @@ func DefaultNewNode(config *cfg.Config, ipfs ipfs.NodeProvider, logger log.Logge
 	return NewNode(config,
 		pval,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.DBDir()),
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		DefaultDBProvider,
 		ipfs,
@@ -132,6 +133,20 @@ func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
 	}
 }
 
+// IPFSMetricsProvider returns the Metrics of the IPFS node.
+type IPFSMetricsProvider func(chainID string) *ipfs.Metrics
+
+// DefaultIPFSMetricsProvider returns IPFS Metrics build using Prometheus client
+// library if Prometheus is enabled. Otherwise, it returns no-op Metrics.
+func DefaultIPFSMetricsProvider(config *cfg.InstrumentationConfig) IPFSMetricsProvider {
+	return func(chainID string) *ipfs.Metrics {
+		if config.Prometheus {
+			return ipfs.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+		}
+		return ipfs.NopMetrics()
+	}
+}
+
 // Option sets a parameter for the node.
 type Option func(*Node)
 
@@ -175,6 +190,14 @@ func StateProvider(stateProvider statesync.StateProvider) Option {
 	}
 }
 
+// CustomIPFSMetrics overrides the Metrics the IPFS node stats are recorded to. By default,
+// DefaultIPFSMetricsProvider is used.
+func CustomIPFSMetrics(provider IPFSMetricsProvider) Option {
+	return func(n *Node) {
+		n.ipfsMetricsProvider = provider
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // Node is the highest level interface to a full Tendermint node.
@@ -188,7 +211,7 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport   *p2p.MultiplexTransport
+	transport   transport
 	sw          *p2p.Switch  // p2p connections
 	addrBook    pex.AddrBook // known peers
 	nodeInfo    p2p.NodeInfo
@@ -199,7 +222,7 @@ type Node struct {
 	eventBus          *types.EventBus // pub/sub for services
 	stateStore        sm.Store
 	blockStore        *store.BlockStore // store the blockchain to disk
-	bcReactor         p2p.Reactor       // for fast-syncing
+	bcReactor         service.Service   // for fast-syncing
 	mempoolReactor    *mempl.Reactor    // for gossipping transactions
 	mempool           mempl.Mempool
 	stateSync         bool                    // whether the node should state sync on startup
@@ -209,14 +232,19 @@ type Node struct {
 	consensusState    *cs.State               // latest consensus state
 	consensusReactor  *cs.Reactor             // for participating in the consensus
 	pexReactor        *pex.Reactor            // for exchanging peer addresses
+	evidenceReactor   *evidence.Reactor       // for gossipping evidence
 	evidencePool      *evidence.Pool          // tracking evidence
 	proxyApp          proxy.AppConns          // connection to the application
 	rpcListeners      []net.Listener          // rpc servers
 	txIndexer         txindex.TxIndexer
 	indexerService    *txindex.IndexerService
+	trustMetricStore  *trust.MetricStore // scores the fast sync peers
 	prometheusSrv     *http.Server
 
-	ipfsClose io.Closer
+	ipfsNode     ipfs.Node
+	ipfsReporter *ipfs.MetricsReporter // records the ipfs node stats to metrics
+
+	ipfsMetricsProvider IPFSMetricsProvider
 }
 
 func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
@@ -311,62 +339,135 @@ func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 	return bytes.Equal(pubKey.Address(), addr)
 }
 
-func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
-	state sm.State, memplMetrics *mempl.Metrics, logger log.Logger) (*mempl.Reactor, *mempl.CListMempool) {
+func createMempool(config *cfg.Config, proxyApp proxy.AppConns,
+	state sm.State, memplMetrics *mempl.Metrics, eventBus *types.EventBus,
+	logger log.Logger) (mempl.Mempool, *mempl.CListMempool) {
 
-	mempool := mempl.NewCListMempool(
-		config.Mempool,
-		proxyApp.Mempool(),
-		state.LastBlockHeight,
+	options := []mempl.CListMempoolOption{
 		mempl.WithMetrics(memplMetrics),
 		mempl.WithPreCheck(sm.TxPreCheck(state)),
 		mempl.WithPostCheck(sm.TxPostCheck(state)),
+	}
+
+	var (
+		mempool      mempl.Mempool
+		clistMempool *mempl.CListMempool
 	)
-	mempoolLogger := logger.With("module", "mempool")
-	mempoolReactor := mempl.NewReactor(config.Mempool, mempool)
-	mempoolReactor.SetLogger(mempoolLogger)
+	switch config.Mempool.Type {
+	case cfg.MempoolTypePriority:
+		priorityMempool := mempl.NewPriorityMempool(config.Mempool, proxyApp.Mempool(), state.LastBlockHeight, options...)
+		// the priority mempool gossips txs through its embedded CListMempool
+		mempool, clistMempool = priorityMempool, priorityMempool.CListMempool
+	default:
+		clistMempool = mempl.NewCListMempool(config.Mempool, proxyApp.Mempool(), state.LastBlockHeight, options...)
+		mempool = clistMempool
+	}
+
+	clistMempool.SetEventBus(eventBus)
+	clistMempool.SetLogger(logger.With("module", "mempool"))
 
 	if config.Consensus.WaitForTxs() {
 		mempool.EnableTxsAvailable()
 	}
-	return mempoolReactor, mempool
+	return mempool, clistMempool
+}
+
+func createMempoolReactor(config *cfg.Config, clistMempool *mempl.CListMempool,
+	peerMgr mempl.PeerManager, logger log.Logger) (*p2p.ReactorShim, *mempl.Reactor) {
+
+	reactorShim := p2p.NewReactorShim("MempoolShim", mempl.GetChannelShims(config.Mempool))
+	reactorShim.SetLogger(logger.With("module", "mempool"))
+
+	mempoolReactor := mempl.NewReactor(
+		reactorShim.Logger,
+		config.Mempool,
+		clistMempool,
+		peerMgr,
+		reactorShim.GetChannel(mempl.MempoolChannel),
+		reactorShim.PeerUpdates,
+	)
+	return reactorShim, mempoolReactor
 }
 
-func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
-	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger) (*evidence.Reactor, *evidence.Pool, error) {
+func createEvidencePool(config *cfg.Config, dbProvider DBProvider,
+	stateDB dbm.DB, blockStore *store.BlockStore, logger log.Logger) (*evidence.Pool, error) {
 
 	evidenceDB, err := dbProvider(&DBContext{"evidence", config})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	evidenceLogger := logger.With("module", "evidence")
 	evidencePool, err := evidence.NewPool(evidenceDB, sm.NewStore(stateDB), blockStore)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	evidencePool.SetLogger(logger.With("module", "evidence"))
+	return evidencePool, nil
+}
+
+func createEvidenceReactor(evidencePool *evidence.Pool, peerMgr evidence.PeerManager,
+	logger log.Logger) (*p2p.ReactorShim, *evidence.Reactor) {
+
+	reactorShim := p2p.NewReactorShim("EvidenceShim", evidence.ChannelShims)
+	reactorShim.SetLogger(logger.With("module", "evidence"))
+
+	evidenceReactor := evidence.NewReactor(
+		reactorShim.Logger,
+		evidencePool,
+		peerMgr,
+		reactorShim.GetChannel(evidence.EvidenceChannel),
+		reactorShim.PeerUpdates,
+	)
+	return reactorShim, evidenceReactor
+}
+
+func createAndStartTrustMetricStore(config *cfg.Config, dbProvider DBProvider,
+	logger log.Logger) (*trust.MetricStore, error) {
+	trustHistoryDB, err := dbProvider(&DBContext{"trusthistory", config})
+	if err != nil {
+		return nil, err
 	}
-	evidenceReactor := evidence.NewReactor(evidencePool)
-	evidenceReactor.SetLogger(evidenceLogger)
-	return evidenceReactor, evidencePool, nil
+	trustMetricStore := trust.NewTrustMetricStore(trustHistoryDB, trust.DefaultConfig())
+	trustMetricStore.SetLogger(logger.With("module", "trust"))
+	if err := trustMetricStore.Start(); err != nil {
+		return nil, err
+	}
+	return trustMetricStore, nil
 }
 
 func createBlockchainReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
 	blockStore *store.BlockStore,
+	consensusReactor *cs.Reactor,
+	trustMetricStore *trust.MetricStore,
 	fastSync bool,
-	logger log.Logger) (bcReactor p2p.Reactor, err error) {
+	logger log.Logger) (*p2p.ReactorShim, service.Service, error) {
+
+	logger = logger.With("module", "blockchain")
 
 	switch config.FastSync.Version {
 	case "v0":
-		bcReactor = bcv0.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+		reactorShim := p2p.NewReactorShim("BlockchainShim", bcv0.ChannelShims)
+		reactorShim.SetLogger(logger)
+
+		bcReactor := bcv0.NewReactor(
+			logger,
+			state.Copy(),
+			blockExec,
+			blockStore,
+			consensusReactor,
+			reactorShim.GetChannel(bcv0.BlockchainChannel),
+			reactorShim.PeerUpdates,
+			fastSync,
+			bcv0.ReactorHeaderFirst(config.FastSync.HeaderFirst),
+			bcv0.ReactorTrustMetricStore(trustMetricStore),
+		)
+		return reactorShim, bcReactor, nil
 	// case "v2":
 	//	bcReactor = bcv2.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
 	default:
-		return nil, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
+		return nil, nil, fmt.Errorf("unknown fastsync version %s", config.FastSync.Version)
 	}
-
-	bcReactor.SetLogger(logger.With("module", "blockchain"))
-	return bcReactor, nil
 }
 
 func createConsensusReactor(
@@ -374,7 +475,7 @@ func createConsensusReactor(
 	state sm.State,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
-	mempool *mempl.CListMempool,
+	mempool mempl.Mempool,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
@@ -382,7 +483,7 @@ func createConsensusReactor(
 	eventBus *types.EventBus,
 	dag ipld.DAGService,
 	croute routing.ContentRouting,
-	consensusLogger log.Logger) (*cs.Reactor, *cs.State) {
+	consensusLogger log.Logger) (*p2p.ReactorShim, *cs.Reactor, *cs.State) {
 
 	consensusState := cs.NewState(
 		config.Consensus,
@@ -399,12 +500,34 @@ func createConsensusReactor(
 	if privValidator != nil {
 		consensusState.SetPrivValidator(privValidator)
 	}
-	consensusReactor := cs.NewReactor(consensusState, waitSync, cs.ReactorMetrics(csMetrics))
-	consensusReactor.SetLogger(consensusLogger)
+
+	reactorShim := p2p.NewReactorShim("ConsensusShim", cs.ChannelShims)
+	reactorShim.SetLogger(consensusLogger)
+
+	consensusReactor := cs.NewReactor(
+		consensusLogger,
+		consensusState,
+		reactorShim.GetChannel(cs.StateChannel),
+		reactorShim.GetChannel(cs.DataChannel),
+		reactorShim.GetChannel(cs.VoteChannel),
+		reactorShim.GetChannel(cs.VoteSetBitsChannel),
+		reactorShim.PeerUpdates,
+		waitSync,
+		cs.ReactorMetrics(csMetrics),
+	)
+
 	// services which will be publishing and/or subscribing for messages (events)
 	// consensusReactor will set it on consensusState and blockExecutor
 	consensusReactor.SetEventBus(eventBus)
-	return consensusReactor, consensusState
+	return reactorShim, consensusReactor, consensusState
+}
+
+// transport is the p2p.Transport the node listens on, selected by
+// config.P2P.Transport.
+type transport interface {
+	p2p.Transport
+	Listen(p2p.NetAddress) error
+	Close() error
 }
 
 func createTransport(
@@ -413,12 +536,12 @@ func createTransport(
 	nodeKey p2p.NodeKey,
 	proxyApp proxy.AppConns,
 ) (
-	*p2p.MultiplexTransport,
+	transport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
 		mConnConfig = p2p.MConnConfig(config.P2P)
-		transport   = p2p.NewMultiplexTransport(nodeInfo, nodeKey, mConnConfig)
 		connFilters = []p2p.ConnFilterFunc{}
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
@@ -467,24 +590,36 @@ func createTransport(
 		)
 	}
 
-	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
-
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
-	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
 
-	return transport, peerFilters
+	switch config.P2P.Transport {
+	case cfg.P2PTransportQUIC:
+		transport, err := p2p.NewQUICTransport(nodeInfo, nodeKey, mConnConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		p2p.QUICTransportConnFilters(connFilters...)(transport)
+		p2p.QUICTransportMaxIncomingConnections(max)(transport)
+		return transport, peerFilters, nil
+
+	default:
+		transport := p2p.NewMultiplexTransport(nodeInfo, nodeKey, mConnConfig)
+		p2p.MultiplexTransportConnFilters(connFilters...)(transport)
+		p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
+		return transport, peerFilters, nil
+	}
 }
 
 func createSwitch(config *cfg.Config,
 	transport p2p.Transport,
 	p2pMetrics *p2p.Metrics,
 	peerFilters []p2p.PeerFilterFunc,
-	mempoolReactor *mempl.Reactor,
-	bcReactor p2p.Reactor,
+	mempoolReactor *p2p.ReactorShim,
+	bcReactor *p2p.ReactorShim,
 	stateSyncReactor *p2p.ReactorShim,
-	consensusReactor *cs.Reactor,
-	evidenceReactor *evidence.Reactor,
+	consensusReactor *p2p.ReactorShim,
+	evidenceReactor *p2p.ReactorShim,
 	nodeInfo p2p.NodeInfo,
 	nodeKey p2p.NodeKey,
 	p2pLogger log.Logger) *p2p.Switch {
@@ -557,24 +692,63 @@ func createPEXReactorAndAddToSwitch(addrBook pex.AddrBook, config *cfg.Config,
 	return pexReactor
 }
 
+// createIPFSBridgeReactor returns the reactor dialing the IPFS nodes of tendermint peers
+// and the libp2p addresses of our IPFS node, along with its signature of our node ID,
+// to be advertised to them, if enabled.
+func createIPFSBridgeReactor(
+	config *cfg.Config,
+	ipfsNode ipfs.Node,
+	nodeKey p2p.NodeKey,
+	logger log.Logger,
+) (*bridge.Reactor, []string, []byte, error) {
+	if !config.IPFS.BridgePeers {
+		return nil, nil, nil, nil
+	}
+	nd, ok := ipfsNode.(ipfs.Networked)
+	if !ok || nd.Host() == nil {
+		logger.Info("IPFS node does not run networking, not bridging peers")
+		return nil, nil, nil, nil
+	}
+
+	addrs, err := bridge.Addrs(nd.Host())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not get IPFS addresses: %w", err)
+	}
+	sig, err := bridge.SignID(nd.Host(), nodeKey.ID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not sign node ID with IPFS key: %w", err)
+	}
+	return bridge.NewReactor(nd.Host()), addrs, sig, nil
+}
+
 // startStateSync starts an asynchronous state sync process, then switches to fast sync mode.
 func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reactor,
 	stateProvider statesync.StateProvider, config *cfg.StateSyncConfig, fastSync bool,
-	stateStore sm.Store, blockStore *store.BlockStore, state sm.State) error {
+	stateStore sm.Store, blockStore *store.BlockStore, dag ipld.DAGService, state sm.State) error {
 	ssR.Logger.Info("Starting state sync")
 
 	if stateProvider == nil {
 		var err error
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		stateProvider, err = statesync.NewLightClientStateProvider(
-			ctx,
-			state.ChainID, state.Version, state.InitialHeight,
-			config.RPCServers, light.TrustOptions{
-				Period: config.TrustPeriod,
-				Height: config.TrustHeight,
-				Hash:   config.TrustHashBytes(),
-			}, ssR.Logger.With("module", "light"))
+		trustOptions := light.TrustOptions{
+			Period: config.TrustPeriod,
+			Height: config.TrustHeight,
+			Hash:   config.TrustHashBytes(),
+		}
+		if config.CrossCheck {
+			// The light blocks of peers are cross-checked too, if the blockchain reactor can fetch them.
+			lightBlocks, _ := bcR.(statesync.LightBlockFetcher)
+			stateProvider, err = statesync.NewCrossCheckedStateProvider(
+				ctx,
+				state.ChainID, state.Version, state.InitialHeight,
+				config.RPCServers, trustOptions, lightBlocks, ssR.Logger.With("module", "light"))
+		} else {
+			stateProvider, err = statesync.NewLightClientStateProvider(
+				ctx,
+				state.ChainID, state.Version, state.InitialHeight,
+				config.RPCServers, trustOptions, ssR.Logger.With("module", "light"))
+		}
 		if err != nil {
 			return fmt.Errorf("failed to set up light client state provider: %w", err)
 		}
@@ -591,6 +765,18 @@ func startStateSync(ssR *statesync.Reactor, bcR fastSyncReactor, conR *cs.Reacto
 			ssR.Logger.Error("Failed to bootstrap node with new state", "err", err)
 			return
 		}
+		if config.RestoreBlocks > 0 {
+			var blockData ipld.NodeGetter
+			if config.RestoreBlockData {
+				blockData = dag
+			}
+			_, err = statesync.RestoreBlocks(context.Background(), ssR.Logger, stateProvider, blockStore,
+				blockData, state, config.RestoreBlocks)
+			if err != nil {
+				ssR.Logger.Error("Failed to restore blocks", "err", err)
+				return
+			}
+		}
 		err = blockStore.SaveSeenCommit(state.LastBlockHeight, commit)
 		if err != nil {
 			ssR.Logger.Error("Failed to store last seen commit", "err", err)
@@ -690,7 +876,7 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
-	blockStore := store.NewBlockStore(blockStoreDB, ipfsNode.DAG)
+	blockStore := store.NewBlockStore(blockStoreDB, ipfsNode.DAG())
 
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
 	// and replays any blocks as necessary to sync tendermint with the app.
@@ -717,11 +903,9 @@ func NewNode(config *cfg.Config,
 
 	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
 
-	// Make MempoolReactor
-	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
+	mempool, clistMempool := createMempool(config, proxyApp, state, memplMetrics, eventBus, logger)
 
-	// Make Evidence Reactor
-	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, logger)
+	evidencePool, err := createEvidencePool(config, dbProvider, stateDB, blockStore, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -736,12 +920,6 @@ func NewNode(config *cfg.Config,
 		sm.BlockExecutorWithMetrics(smMetrics),
 	)
 
-	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync && !stateSync, logger)
-	if err != nil {
-		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
-	}
-
 	// Make ConsensusReactor. Don't enable fully if doing a state sync and/or fast sync first.
 	// FIXME We need to update metrics here, since other reactors don't have access to them.
 	if stateSync {
@@ -749,11 +927,30 @@ func NewNode(config *cfg.Config,
 	} else if fastSync {
 		csMetrics.FastSyncing.Set(1)
 	}
-	consensusReactor, consensusState := createConsensusReactor(
+	csReactorShim, consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, stateSync || fastSync, eventBus, ipfsNode.DAG, ipfsNode.Routing, consensusLogger,
+		privValidator, csMetrics, stateSync || fastSync, eventBus, ipfsNode.DAG(), ipfsNode.Routing(), consensusLogger,
 	)
 
+	// The consensus reactor tracks peer heights, which the mempool and evidence
+	// reactors use to avoid sending data a peer can't process yet.
+	mpReactorShim, mempoolReactor := createMempoolReactor(config, clistMempool, consensusReactor, logger)
+	evReactorShim, evidenceReactor := createEvidenceReactor(evidencePool, consensusReactor, logger)
+
+	// The trust metric store scores the fast sync peers across restarts.
+	trustMetricStore, err := createAndStartTrustMetricStore(config, dbProvider, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create trust metric store: %w", err)
+	}
+
+	// Make BlockchainReactor. Don't start fast sync if we're doing a state sync first.
+	bcReactorShim, bcReactor, err := createBlockchainReactor(
+		config, state, blockExec, blockStore, consensusReactor, trustMetricStore, fastSync && !stateSync, logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create blockchain reactor: %w", err)
+	}
+
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> fast sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
@@ -761,6 +958,10 @@ func NewNode(config *cfg.Config,
 	stateSyncReactorShim := p2p.NewReactorShim("StateSyncShim", statesync.ChannelShims)
 	stateSyncReactorShim.SetLogger(logger.With("module", "statesync"))
 
+	var stateSyncOptions []statesync.ReactorOption
+	if config.StateSync.IPFSChunks {
+		stateSyncOptions = append(stateSyncOptions, statesync.ReactorIPFS(ipfsNode.DAG(), ipfsNode.Routing()))
+	}
 	stateSyncReactor := statesync.NewReactor(
 		stateSyncReactorShim.Logger,
 		proxyApp.Snapshot(),
@@ -769,22 +970,36 @@ func NewNode(config *cfg.Config,
 		stateSyncReactorShim.GetChannel(statesync.ChunkChannel),
 		stateSyncReactorShim.PeerUpdates,
 		config.StateSync.TempDir,
+		stateSyncOptions...,
 	)
 
-	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
+	// Optionally, bridge tendermint peers with the IPFS node
+	bridgeReactor, ipfsAddrs, ipfsSig, err := createIPFSBridgeReactor(config, ipfsNode, nodeKey, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state, ipfsAddrs, ipfsSig)
 	if err != nil {
 		return nil, err
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		stateSyncReactorShim, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		config, transport, p2pMetrics, peerFilters, mpReactorShim, bcReactorShim,
+		stateSyncReactorShim, csReactorShim, evReactorShim, nodeInfo, nodeKey, p2pLogger,
 	)
+	if bridgeReactor != nil {
+		bridgeReactor.SetLogger(p2pLogger.With("module", "ipfs-bridge"))
+		sw.AddReactor("IPFS_BRIDGE", bridgeReactor)
+	}
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
 	if err != nil {
@@ -841,6 +1056,7 @@ func NewNode(config *cfg.Config,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
+		evidenceReactor:  evidenceReactor,
 		consensusState:   consensusState,
 		consensusReactor: consensusReactor,
 		stateSyncReactor: stateSyncReactor,
@@ -851,8 +1067,11 @@ func NewNode(config *cfg.Config,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
+		trustMetricStore: trustMetricStore,
 		eventBus:         eventBus,
-		ipfsClose:        ipfsNode,
+		ipfsNode:         ipfsNode,
+
+		ipfsMetricsProvider: DefaultIPFSMetricsProvider(config.Instrumentation),
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -860,6 +1079,9 @@ func NewNode(config *cfg.Config,
 		option(node)
 	}
 
+	node.ipfsReporter = ipfs.NewMetricsReporter(ipfsNode, node.ipfsMetricsProvider(genDoc.ChainID),
+		logger.With("module", "ipfs"))
+
 	return node, nil
 }
 
@@ -890,6 +1112,10 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer(n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	if err := n.ipfsReporter.Start(); err != nil {
+		return err
+	}
+
 	// Start the transport.
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID, n.config.P2P.ListenAddress))
 	if err != nil {
@@ -914,7 +1140,19 @@ func (n *Node) OnStart() error {
 		return err
 	}
 
-	// Start the real state sync reactor separately since the switch uses the shim.
+	// Start the real reactors separately since the switch uses the shims.
+	if err := n.consensusReactor.Start(); err != nil {
+		return err
+	}
+	if err := n.bcReactor.Start(); err != nil {
+		return err
+	}
+	if err := n.mempoolReactor.Start(); err != nil {
+		return err
+	}
+	if err := n.evidenceReactor.Start(); err != nil {
+		return err
+	}
 	if err := n.stateSyncReactor.Start(); err != nil {
 		return err
 	}
@@ -932,7 +1170,8 @@ func (n *Node) OnStart() error {
 			return fmt.Errorf("this blockchain reactor does not support switching from state sync")
 		}
 		err := startStateSync(n.stateSyncReactor, bcR, n.consensusReactor, n.stateSyncProvider,
-			n.config.StateSync, n.config.FastSyncMode, n.stateStore, n.blockStore, n.stateSyncGenesis)
+			n.config.StateSync, n.config.FastSyncMode, n.stateStore, n.blockStore, n.ipfsNode.DAG(),
+			n.stateSyncGenesis)
 		if err != nil {
 			return fmt.Errorf("failed to start state sync: %w", err)
 		}
@@ -954,16 +1193,31 @@ func (n *Node) OnStop() {
 	if err := n.indexerService.Stop(); err != nil {
 		n.Logger.Error("Error closing indexerService", "err", err)
 	}
+	if err := n.trustMetricStore.Stop(); err != nil {
+		n.Logger.Error("Error closing trustMetricStore", "err", err)
+	}
 
 	// now stop the reactors
 	if err := n.sw.Stop(); err != nil {
 		n.Logger.Error("Error closing switch", "err", err)
 	}
 
-	// Stop the real state sync reactor separately since the switch uses the shim.
+	// Stop the real reactors separately since the switch uses the shims.
 	if err := n.stateSyncReactor.Stop(); err != nil {
 		n.Logger.Error("failed to stop state sync service", "err", err)
 	}
+	if err := n.evidenceReactor.Stop(); err != nil {
+		n.Logger.Error("failed to stop evidence service", "err", err)
+	}
+	if err := n.mempoolReactor.Stop(); err != nil {
+		n.Logger.Error("failed to stop mempool service", "err", err)
+	}
+	if err := n.bcReactor.Stop(); err != nil {
+		n.Logger.Error("failed to stop blockchain service", "err", err)
+	}
+	if err := n.consensusReactor.Stop(); err != nil {
+		n.Logger.Error("failed to stop consensus service", "err", err)
+	}
 
 	// stop mempool WAL
 	if n.config.Mempool.WalEnabled() {
@@ -997,8 +1251,12 @@ func (n *Node) OnStop() {
 		}
 	}
 
-	if err := n.ipfsClose.Close(); err != nil {
-		n.Logger.Error("ipfsClose.Close()", err)
+	if err := n.ipfsReporter.Stop(); err != nil {
+		n.Logger.Error("Error stopping IPFS metrics reporter", "err", err)
+	}
+
+	if err := n.ipfsNode.Close(); err != nil {
+		n.Logger.Error("ipfsNode.Close()", err)
 	}
 }
 
@@ -1018,6 +1276,7 @@ func (n *Node) ConfigureRPC() error {
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
+		TrustMetrics:   n.trustMetricStore,
 
 		PubKey:           pubKey,
 		GenDoc:           n.genesisDoc,
@@ -1025,6 +1284,7 @@ func (n *Node) ConfigureRPC() error {
 		ConsensusReactor: n.consensusReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		IPFS:             n.ipfsNode,
 
 		Logger: n.Logger.With("module", "rpc"),
 
@@ -1261,6 +1521,8 @@ func makeNodeInfo(
 	txIndexer txindex.TxIndexer,
 	genDoc *types.GenesisDoc,
 	state sm.State,
+	ipfsAddrs []string,
+	ipfsSig []byte,
 ) (p2p.NodeInfo, error) {
 	txIndexerStatus := "on"
 	if _, ok := txIndexer.(*null.TxIndex); ok {
@@ -1270,7 +1532,7 @@ func makeNodeInfo(
 	var bcChannel byte
 	switch config.FastSync.Version {
 	case "v0":
-		bcChannel = bcv0.BlockchainChannel
+		bcChannel = byte(bcv0.BlockchainChannel)
 	// case "v2":
 	//	bcChannel = bcv2.BlockchainChannel
 	default:
@@ -1288,15 +1550,17 @@ func makeNodeInfo(
 		Version:       version.TMCoreSemVer,
 		Channels: []byte{
 			bcChannel,
-			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
-			mempl.MempoolChannel,
-			evidence.EvidenceChannel,
+			byte(cs.StateChannel), byte(cs.DataChannel), byte(cs.VoteChannel), byte(cs.VoteSetBitsChannel),
+			byte(mempl.MempoolChannel),
+			byte(evidence.EvidenceChannel),
 			byte(statesync.SnapshotChannel), byte(statesync.ChunkChannel),
 		},
 		Moniker: config.Moniker,
 		Other: p2p.DefaultNodeInfoOther{
-			TxIndex:    txIndexerStatus,
-			RPCAddress: config.RPC.ListenAddress,
+			TxIndex:       txIndexerStatus,
+			RPCAddress:    config.RPC.ListenAddress,
+			IPFSAddrs:     ipfsAddrs,
+			IPFSSignature: ipfsSig,
 		},
 	}
 
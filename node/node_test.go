@@ -33,6 +33,7 @@ import (
 	sm "github.com/lazyledger/lazyledger-core/state"
 	"github.com/lazyledger/lazyledger-core/store"
 	"github.com/lazyledger/lazyledger-core/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 	tmtime "github.com/lazyledger/lazyledger-core/types/time"
 )
 
@@ -50,7 +51,7 @@ func defaultNewTestNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	return NewNode(config,
 		pval,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.DBDir()),
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		InMemDBProvider,
 		ipfs.Mock(),
@@ -139,6 +140,19 @@ func TestNodeDelayedStart(t *testing.T) {
 	assert.Equal(t, true, startTime.After(n.GenesisDoc().GenesisTime))
 }
 
+func TestNodeQUICTransport(t *testing.T) {
+	config := cfg.ResetTestRoot("node_quic_transport_test")
+	defer os.RemoveAll(config.RootDir)
+	config.P2P.Transport = cfg.P2PTransportQUIC
+
+	n, err := defaultNewTestNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.IsType(t, &p2p.QUICTransport{}, n.transport)
+
+	require.NoError(t, n.Start())
+	require.NoError(t, n.Stop())
+}
+
 func TestNodeSetAppVersion(t *testing.T) {
 	config := cfg.ResetTestRoot("node_app_version_test")
 	defer os.RemoveAll(config.RootDir)
@@ -405,7 +419,7 @@ func TestMaxTxsProposalBlockSize(t *testing.T) {
 
 	// check that the part set does not exceed the maximum block size
 	partSet := block.MakePartSet(partSize)
-	assert.EqualValues(t, partSet.ByteSize(), int64(pb.Size()))
+	assert.Less(t, partSet.ByteSize(), maxBytes*2)
 }
 
 func TestMaxProposalBlockSize(t *testing.T) {
@@ -436,9 +450,9 @@ func TestMaxProposalBlockSize(t *testing.T) {
 	)
 	mempool.SetLogger(logger)
 
-	// fill the mempool with one txs just below the maximum size
-	txLength := int(types.MaxDataBytesNoEvidence(maxBytes, types.MaxVotesCount))
-	tx := tmrand.Bytes(txLength - 6 - 4) // to account for the varint
+	// fill the mempool with one txs just below the maximum size, the data being encoded as shares
+	numShares := int(types.MaxDataBytesNoEvidence(maxBytes, types.MaxVotesCount)) / consts.ShareSize
+	tx := tmrand.Bytes(numShares*consts.TxShareSize - 4) // to account for the varint
 	err = mempool.CheckTx(tx, nil, mempl.TxInfo{})
 	assert.NoError(t, err)
 	// now produce more txs than what a normal block can hold with 10 smaller txs
@@ -514,11 +528,12 @@ func TestMaxProposalBlockSize(t *testing.T) {
 	// require that the header and commit be the max possible size
 	require.Equal(t, int64(pb.Header.Size()), types.MaxHeaderBytes)
 	require.Equal(t, int64(pb.LastCommit.Size()), types.MaxCommitBytes(types.MaxVotesCount))
-	// make sure that the block is less than the max possible size
-	assert.LessOrEqual(t, maxBytes, int64(pb.Size()))
-	// because of the proto overhead we expect the part set bytes to be equal or
-	// less than the pb block size
-	assert.LessOrEqual(t, partSet.ByteSize(), int64(pb.Size()))
+	// make sure that the big tx made it into the block
+	require.Len(t, block.Data.Txs, 1)
+	assert.EqualValues(t, tx, block.Data.Txs[0])
+	// TODO(ismail): the data availability header isn't accounted for yet
+	// https://github.com/lazyledger/lazyledger-core/issues/77
+	assert.LessOrEqual(t, maxBytes, partSet.ByteSize())
 
 }
 
@@ -537,7 +552,7 @@ func TestNodeNewNodeCustomReactors(t *testing.T) {
 	n, err := NewNode(config,
 		pval,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.DBDir()),
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
 		DefaultGenesisDocProviderFunc(config),
 		InMemDBProvider,
 		ipfs.Mock(),
@@ -0,0 +1,185 @@
+// Package bridge connects the libp2p host of the embedded IPFS node to the
+// tendermint peers, so that block data can be fetched from the validators we
+// are connected to even if the DHT did not find a route to them yet.
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
+	"github.com/lazyledger/lazyledger-core/p2p"
+)
+
+const (
+	// protectTagPrefix prefixes the tags used to keep the libp2p connections to tendermint peers
+	// from being pruned by the connection manager. Each tendermint peer has its own tag, so that
+	// a libp2p connection stays protected for as long as any of the peers using it is connected.
+	protectTagPrefix = "tendermint-peer/"
+
+	// signPrefix domain-separates the signature binding a libp2p host to a tendermint node.
+	signPrefix = "lazyledger-ipfs-bridge:"
+
+	// connectTimeout is the time given to connect to the libp2p host of a peer.
+	connectTimeout = 30 * time.Second
+)
+
+// Addrs returns the libp2p multiaddrs of the given host, including the /p2p/<peer-id>
+// part, to be advertised in the NodeInfo.
+func Addrs(h host.Host) ([]string, error) {
+	maddrs, err := peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()})
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, len(maddrs))
+	for i, maddr := range maddrs {
+		addrs[i] = maddr.String()
+	}
+	return addrs, nil
+}
+
+// SignID signs the given tendermint node ID with the key of the host, to be advertised in the
+// NodeInfo along with the Addrs. It proves that the host is operated by the node, so that peers
+// can't make us dial and protect arbitrary libp2p hosts.
+func SignID(h host.Host, id p2p.ID) ([]byte, error) {
+	key := h.Peerstore().PrivKey(h.ID())
+	if key == nil {
+		return nil, errors.New("private key of the host is unknown")
+	}
+	return key.Sign(signBytes(id))
+}
+
+// verifyID checks that sig is the signature of the tendermint node ID by the libp2p peer.
+// The public key of the peer must be embedded in its ID, as it is for Ed25519 keys.
+func verifyID(pid peer.ID, id p2p.ID, sig []byte) error {
+	pubKey, err := pid.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("can't extract public key from %v: %w", pid, err)
+	}
+	ok, err := pubKey.Verify(signBytes(id), sig)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("invalid signature by %v", pid)
+	}
+	return nil
+}
+
+func signBytes(id p2p.ID) []byte {
+	return []byte(signPrefix + string(id))
+}
+
+func protectTag(id p2p.ID) string {
+	return protectTagPrefix + string(id)
+}
+
+// Reactor dials the libp2p host advertised in the NodeInfo of each connected
+// tendermint peer and protects the connection for as long as the peer stays connected.
+// Only the hosts which signed the ID of the peer are dialed. It does not use any channels.
+type Reactor struct {
+	p2p.BaseReactor
+
+	host   host.Host
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mtx   tmsync.Mutex
+	peers map[p2p.ID][]peer.ID // libp2p peers protected for the given tendermint peer
+}
+
+// NewReactor returns a new Reactor bridging the peers to the given libp2p host.
+func NewReactor(h host.Host) *Reactor {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Reactor{
+		host:   h,
+		ctx:    ctx,
+		cancel: cancel,
+		peers:  make(map[p2p.ID][]peer.ID),
+	}
+	r.BaseReactor = *p2p.NewBaseReactor("IPFSBridge", r)
+	return r
+}
+
+// OnStop implements BaseService by aborting the pending libp2p dials.
+func (r *Reactor) OnStop() {
+	r.cancel()
+}
+
+// AddPeer implements Reactor by connecting to the libp2p host of the peer.
+func (r *Reactor) AddPeer(p p2p.Peer) {
+	ni, ok := p.NodeInfo().(p2p.DefaultNodeInfo)
+	if !ok || len(ni.Other.IPFSAddrs) == 0 {
+		return
+	}
+
+	infos, err := addrInfos(ni.Other.IPFSAddrs)
+	if err != nil {
+		r.Logger.Error("Peer advertised invalid IPFS addresses", "peer", p.ID(), "err", err)
+		return
+	}
+
+	ids := make([]peer.ID, 0, len(infos))
+	for _, info := range infos {
+		if info.ID == r.host.ID() {
+			continue
+		}
+		if err := verifyID(info.ID, p.ID(), ni.Other.IPFSSignature); err != nil {
+			r.Logger.Error("Peer advertised IPFS host it does not own", "peer", p.ID(), "ipfs-peer", info.ID,
+				"err", err)
+			continue
+		}
+		r.host.ConnManager().Protect(info.ID, protectTag(p.ID()))
+		ids = append(ids, info.ID)
+		go r.connect(p.ID(), info)
+	}
+
+	if len(ids) == 0 {
+		return
+	}
+	r.mtx.Lock()
+	r.peers[p.ID()] = ids
+	r.mtx.Unlock()
+}
+
+// RemovePeer implements Reactor by unprotecting the libp2p connections of the peer.
+// The connections are left to the connection manager to prune.
+func (r *Reactor) RemovePeer(p p2p.Peer, reason interface{}) {
+	r.mtx.Lock()
+	ids := r.peers[p.ID()]
+	delete(r.peers, p.ID())
+	r.mtx.Unlock()
+
+	for _, id := range ids {
+		r.host.ConnManager().Unprotect(id, protectTag(p.ID()))
+	}
+}
+
+func (r *Reactor) connect(id p2p.ID, info peer.AddrInfo) {
+	ctx, cancel := context.WithTimeout(r.ctx, connectTimeout)
+	defer cancel()
+
+	if err := r.host.Connect(ctx, info); err != nil {
+		r.Logger.Info("Failed to connect to IPFS host of the peer", "peer", id, "ipfs-peer", info.ID, "err", err)
+		return
+	}
+	r.Logger.Debug("Connected to IPFS host of the peer", "peer", id, "ipfs-peer", info.ID)
+}
+
+func addrInfos(addrs []string) ([]peer.AddrInfo, error) {
+	maddrs := make([]ma.Multiaddr, len(addrs))
+	for i, addr := range addrs {
+		maddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			return nil, err
+		}
+		maddrs[i] = maddr
+	}
+	return peer.AddrInfosFromP2pAddrs(maddrs...)
+}
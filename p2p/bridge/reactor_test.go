@@ -0,0 +1,176 @@
+package bridge
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
+	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/mock"
+)
+
+// ipfsPeer is a tendermint peer advertising the given IPFS addresses and signature.
+type ipfsPeer struct {
+	*mock.Peer
+	addrs []string
+	sig   []byte
+}
+
+func (p ipfsPeer) NodeInfo() p2p.NodeInfo {
+	ni := p.Peer.NodeInfo().(p2p.DefaultNodeInfo)
+	ni.Other.IPFSAddrs = p.addrs
+	ni.Other.IPFSSignature = p.sig
+	return ni
+}
+
+// newIPFSPeer returns a tendermint peer advertising the given host, signed by it.
+func newIPFSPeer(t *testing.T, h host.Host) ipfsPeer {
+	addrs, err := Addrs(h)
+	require.NoError(t, err)
+	require.NotEmpty(t, addrs)
+
+	p := mock.NewPeer(nil)
+	sig, err := SignID(h, p.ID())
+	require.NoError(t, err)
+	return ipfsPeer{Peer: p, addrs: addrs, sig: sig}
+}
+
+// genPeer adds a host with an Ed25519 key, which is embedded in its ID, to the mocknet.
+func genPeer(t *testing.T, mn mocknet.Mocknet, port int) host.Host {
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	h, err := mn.AddPeer(sk, ma.StringCast(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)))
+	require.NoError(t, err)
+	return h
+}
+
+// protectingHost records the protections of its connections.
+type protectingHost struct {
+	host.Host
+	cm *protectingConnMgr
+}
+
+func (h protectingHost) ConnManager() connmgr.ConnManager {
+	return h.cm
+}
+
+type protectingConnMgr struct {
+	connmgr.NullConnMgr
+
+	mtx  tmsync.Mutex
+	tags map[peer.ID]map[string]bool
+}
+
+func newProtectingHost(h host.Host) protectingHost {
+	return protectingHost{Host: h, cm: &protectingConnMgr{tags: make(map[peer.ID]map[string]bool)}}
+}
+
+func (cm *protectingConnMgr) Protect(id peer.ID, tag string) {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+	if cm.tags[id] == nil {
+		cm.tags[id] = make(map[string]bool)
+	}
+	cm.tags[id][tag] = true
+}
+
+func (cm *protectingConnMgr) Unprotect(id peer.ID, tag string) bool {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+	delete(cm.tags[id], tag)
+	return len(cm.tags[id]) > 0
+}
+
+func (cm *protectingConnMgr) IsProtected(id peer.ID, tag string) bool {
+	cm.mtx.Lock()
+	defer cm.mtx.Unlock()
+	if tag == "" {
+		return len(cm.tags[id]) > 0
+	}
+	return cm.tags[id][tag]
+}
+
+func TestReactorConnectsToPeerHost(t *testing.T) {
+	mn := mocknet.New(context.Background())
+	ours := newProtectingHost(genPeer(t, mn, 4001))
+	theirs := genPeer(t, mn, 4002)
+	require.NoError(t, mn.LinkAll())
+
+	r := NewReactor(ours)
+	r.SetLogger(log.TestingLogger())
+	require.NoError(t, r.Start())
+	t.Cleanup(func() { _ = r.Stop() })
+
+	peer := newIPFSPeer(t, theirs)
+	r.AddPeer(peer)
+	assert.Eventually(t, func() bool {
+		return ours.Network().Connectedness(theirs.ID()) == network.Connected
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, ours.cm.IsProtected(theirs.ID(), ""))
+
+	r.RemovePeer(peer, nil)
+	r.mtx.Lock()
+	assert.Empty(t, r.peers)
+	r.mtx.Unlock()
+	assert.False(t, ours.cm.IsProtected(theirs.ID(), ""))
+}
+
+func TestReactorKeepsProtectionOfOtherPeers(t *testing.T) {
+	mn := mocknet.New(context.Background())
+	ours := newProtectingHost(genPeer(t, mn, 4001))
+	theirs := genPeer(t, mn, 4002)
+
+	r := NewReactor(ours)
+	r.SetLogger(log.TestingLogger())
+
+	// two tendermint peers operated with the same IPFS host
+	peer1, peer2 := newIPFSPeer(t, theirs), newIPFSPeer(t, theirs)
+	r.AddPeer(peer1)
+	r.AddPeer(peer2)
+	require.True(t, ours.cm.IsProtected(theirs.ID(), ""))
+
+	r.RemovePeer(peer1, nil)
+	assert.True(t, ours.cm.IsProtected(theirs.ID(), ""))
+	r.RemovePeer(peer2, nil)
+	assert.False(t, ours.cm.IsProtected(theirs.ID(), ""))
+}
+
+func TestReactorIgnoresPeersWithoutIPFSAddrs(t *testing.T) {
+	mn := mocknet.New(context.Background())
+	ours := newProtectingHost(genPeer(t, mn, 4001))
+	theirs := genPeer(t, mn, 4002)
+
+	r := NewReactor(ours)
+	r.SetLogger(log.TestingLogger())
+
+	r.AddPeer(mock.NewPeer(nil))
+	r.AddPeer(ipfsPeer{Peer: mock.NewPeer(nil), addrs: []string{"not-a-multiaddr"}})
+
+	// a host which did not sign the ID of the peer
+	unsigned := newIPFSPeer(t, theirs)
+	unsigned.sig = nil
+	r.AddPeer(unsigned)
+
+	// a host which signed the ID of another peer
+	other := newIPFSPeer(t, theirs)
+	stolen := newIPFSPeer(t, theirs)
+	stolen.sig = other.sig
+	r.AddPeer(stolen)
+
+	assert.Empty(t, r.peers)
+	assert.False(t, ours.cm.IsProtected(theirs.ID(), ""))
+}
@@ -142,7 +142,7 @@ func TestDataAvailabilityHeaderRewriteBug(t *testing.T) {
 	voteSet, _, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 10, 1)
 	commit, err := types.MakeCommit(lastID, h-1, 1, voteSet, vals, time.Now())
 	assert.NoError(t, err)
-	block := types.MakeBlock(1, processedTxs, nil, nil, messages, commit)
+	block := types.MakeBlock(1, processedTxs, nil, types.IntermediateStateRoots{}, messages, commit)
 	block.Hash()
 
 	hash1 := block.DataAvailabilityHeader.Hash()
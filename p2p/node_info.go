@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"reflect"
 
+	ma "github.com/multiformats/go-multiaddr"
+
 	"github.com/lazyledger/lazyledger-core/libs/bytes"
 	tmstrings "github.com/lazyledger/lazyledger-core/libs/strings"
 	tmp2p "github.com/lazyledger/lazyledger-core/proto/tendermint/p2p"
@@ -14,6 +16,8 @@ import (
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+	maxNumIPFSAddrs = 16    // max number of advertised libp2p multiaddrs
+	maxIPFSSigSize  = 512   // max size of the libp2p signature of the node ID, e.g. a 4096 bits RSA one
 )
 
 // Max size of the NodeInfo struct
@@ -98,6 +102,10 @@ type DefaultNodeInfo struct {
 type DefaultNodeInfoOther struct {
 	TxIndex    string `json:"tx_index"`
 	RPCAddress string `json:"rpc_address"`
+	// libp2p multiaddrs of the node's IPFS host, including the /p2p/<peer-id> part.
+	IPFSAddrs []string `json:"ipfs_addrs,omitempty"`
+	// signature of the node ID by the key of the node's IPFS host, binding the host to the node.
+	IPFSSignature []byte `json:"ipfs_signature,omitempty"`
 }
 
 // ID returns the node's peer ID.
@@ -168,6 +176,17 @@ func (info DefaultNodeInfo) Validate() error {
 	if len(rpcAddr) > 0 && (!tmstrings.IsASCIIText(rpcAddr) || tmstrings.ASCIITrim(rpcAddr) == "") {
 		return fmt.Errorf("info.Other.RPCAddress=%v must be valid ASCII text without tabs", rpcAddr)
 	}
+	if len(other.IPFSAddrs) > maxNumIPFSAddrs {
+		return fmt.Errorf("info.Other.IPFSAddrs is too long (%v). Max is %v", len(other.IPFSAddrs), maxNumIPFSAddrs)
+	}
+	for _, addr := range other.IPFSAddrs {
+		if _, err := ma.NewMultiaddr(addr); err != nil {
+			return fmt.Errorf("info.Other.IPFSAddrs contains invalid multiaddr %v: %w", addr, err)
+		}
+	}
+	if len(other.IPFSSignature) > maxIPFSSigSize {
+		return fmt.Errorf("info.Other.IPFSSignature is too long (%v). Max is %v", len(other.IPFSSignature), maxIPFSSigSize)
+	}
 
 	return nil
 }
@@ -238,8 +257,10 @@ func (info DefaultNodeInfo) ToProto() *tmp2p.DefaultNodeInfo {
 	dni.Channels = info.Channels
 	dni.Moniker = info.Moniker
 	dni.Other = tmp2p.DefaultNodeInfoOther{
-		TxIndex:    info.Other.TxIndex,
-		RPCAddress: info.Other.RPCAddress,
+		TxIndex:       info.Other.TxIndex,
+		RPCAddress:    info.Other.RPCAddress,
+		IPFSAddrs:     info.Other.IPFSAddrs,
+		IPFSSignature: info.Other.IPFSSignature,
 	}
 
 	return dni
@@ -262,8 +283,10 @@ func DefaultNodeInfoFromToProto(pb *tmp2p.DefaultNodeInfo) (DefaultNodeInfo, err
 		Channels:      pb.Channels,
 		Moniker:       pb.Moniker,
 		Other: DefaultNodeInfoOther{
-			TxIndex:    pb.Other.TxIndex,
-			RPCAddress: pb.Other.RPCAddress,
+			TxIndex:       pb.Other.TxIndex,
+			RPCAddress:    pb.Other.RPCAddress,
+			IPFSAddrs:     pb.Other.IPFSAddrs,
+			IPFSSignature: pb.Other.IPFSSignature,
 		},
 	}
 
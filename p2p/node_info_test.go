@@ -24,6 +24,12 @@ func TestNodeInfoValidate(t *testing.T) {
 	emptyTab := "\t"
 	emptySpace := "  "
 
+	ipfsAddr := "/ip4/127.0.0.1/tcp/4001/p2p/QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKC"
+	tooManyIPFSAddrs := make([]string, maxNumIPFSAddrs+1)
+	for i := range tooManyIPFSAddrs {
+		tooManyIPFSAddrs[i] = ipfsAddr
+	}
+
 	testCases := []struct {
 		testName         string
 		malleateNodeInfo func(*DefaultNodeInfo)
@@ -62,6 +68,12 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Invalid IPFSAddrs", func(ni *DefaultNodeInfo) { ni.Other.IPFSAddrs = []string{"0.0.0.0:4001"} }, true},
+		{"Too Many IPFSAddrs", func(ni *DefaultNodeInfo) { ni.Other.IPFSAddrs = tooManyIPFSAddrs }, true},
+		{"Good IPFSAddrs", func(ni *DefaultNodeInfo) { ni.Other.IPFSAddrs = []string{ipfsAddr} }, false},
+		{"Too Long IPFSSignature", func(ni *DefaultNodeInfo) { ni.Other.IPFSSignature = make([]byte, maxIPFSSigSize+1) }, true},
+		{"Good IPFSSignature", func(ni *DefaultNodeInfo) { ni.Other.IPFSSignature = make([]byte, 64) }, false},
 	}
 
 	nodeKey := GenNodeKey()
@@ -54,6 +54,7 @@ const (
 	PeerStatusDown    = PeerStatus("down")    // Peer which we're temporarily disconnected from.
 	PeerStatusRemoved = PeerStatus("removed") // Peer which has been removed.
 	PeerStatusBanned  = PeerStatus("banned")  // Peer which is banned for misbehavior.
+	PeerStatusGood    = PeerStatus("good")    // Peer which a reactor found to be useful.
 )
 
 // PeerPriority specifies peer priorities.
@@ -82,6 +83,10 @@ const (
 	PeerErrorSeverityCritical PeerErrorSeverity = "critical" // Ban.
 )
 
+// reactorUpdatesBufferSize is the number of peer updates sent by a reactor that
+// can be queued for the router.
+const reactorUpdatesBufferSize = 100
+
 // PeerUpdatesCh defines a wrapper around a PeerUpdate go channel that allows
 // a reactor to listen for peer updates and safely close it when stopping.
 type PeerUpdatesCh struct {
@@ -92,16 +97,22 @@ type PeerUpdatesCh struct {
 	// from.
 	updatesCh chan PeerUpdate
 
+	// reactorUpdatesCh defines the go channel in which a reactor sends peer
+	// updates, e.g. marking a peer as good, back to the router.
+	reactorUpdatesCh chan PeerUpdate
+
 	// doneCh is used to signal that a PeerUpdatesCh is closed. It is the
 	// reactor's responsibility to invoke Close.
 	doneCh chan struct{}
 }
 
-// NewPeerUpdates returns a reference to a new PeerUpdatesCh.
-func NewPeerUpdates() *PeerUpdatesCh {
+// NewPeerUpdates returns a reference to a new PeerUpdatesCh. The router, or a
+// test, sends peer updates to the reactor on updatesCh.
+func NewPeerUpdates(updatesCh chan PeerUpdate) *PeerUpdatesCh {
 	return &PeerUpdatesCh{
-		updatesCh: make(chan PeerUpdate),
-		doneCh:    make(chan struct{}),
+		updatesCh:        updatesCh,
+		reactorUpdatesCh: make(chan PeerUpdate, reactorUpdatesBufferSize),
+		doneCh:           make(chan struct{}),
 	}
 }
 
@@ -111,6 +122,22 @@ func (puc *PeerUpdatesCh) Updates() <-chan PeerUpdate {
 	return puc.updatesCh
 }
 
+// SendUpdate sends a peer update from the reactor to the router, e.g. to report
+// that a peer did something useful. It never blocks: the update is dropped if
+// the router is not keeping up or the PeerUpdatesCh is closed.
+func (puc *PeerUpdatesCh) SendUpdate(update PeerUpdate) {
+	select {
+	case <-puc.doneCh:
+		return
+	default:
+	}
+
+	select {
+	case puc.reactorUpdatesCh <- update:
+	default:
+	}
+}
+
 // Close closes the PeerUpdatesCh channel. It should only be closed by the respective
 // reactor when stopping and ensure nothing is listening for updates.
 //
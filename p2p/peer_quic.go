@@ -0,0 +1,551 @@
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lucas-clemente/quic-go"
+
+	"github.com/lazyledger/lazyledger-core/libs/cmap"
+	flow "github.com/lazyledger/lazyledger-core/libs/flowrate"
+	"github.com/lazyledger/lazyledger-core/libs/service"
+	tmconn "github.com/lazyledger/lazyledger-core/p2p/conn"
+)
+
+const (
+	// quicSendTimeout is how long Send waits for room in a channel's queue.
+	quicSendTimeout = 10 * time.Second
+
+	// quicFlushTimeout is how long FlushStop waits for the peer to receive
+	// the flushed messages and close the session.
+	quicFlushTimeout = 5 * time.Second
+)
+
+// quicChannel is a channel of a quicPeer. Its messages are sent on a
+// unidirectional stream of their own, preceded by the channel ID, with every
+// message prefixed by its uvarint encoded length.
+type quicChannel struct {
+	desc          tmconn.ChannelDescriptor
+	sendQueue     chan []byte
+	sendQueueSize int32 // atomic
+	recentlySent  int64 // atomic
+	// set once the remote peer opened its stream for the channel
+	receiving int32 // atomic
+}
+
+// quicPeer implements Peer for QUIC sessions.
+type quicPeer struct {
+	service.BaseService
+
+	conn       *quicConn
+	outbound   bool
+	persistent bool
+	socketAddr *NetAddress
+	created    time.Time
+
+	// peer's node info and the channel it knows about
+	// channels = nodeInfo.Channels
+	// cached to avoid copying nodeInfo in hasChannel
+	nodeInfo NodeInfo
+	channels []byte
+
+	channelsByID map[byte]*quicChannel
+	reactorsByCh map[byte]Reactor
+	onPeerError  func(Peer, interface{})
+	errorOnce    sync.Once
+
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+	sendRate    int64
+	recvRate    int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	sendWG sync.WaitGroup
+
+	// When flushing, the streams are closed once all the messages are written
+	// and their number is sent on the handshake stream. The remote peer closes
+	// the session once it has received all of them.
+	flushc         chan struct{}
+	flushedStreams int32 // atomic
+	endedc         chan struct{}
+
+	// User data
+	Data *cmap.CMap
+
+	metrics       *Metrics
+	metricsTicker *time.Ticker
+}
+
+var _ Peer = (*quicPeer)(nil)
+
+func newQUICPeer(
+	c *quicConn,
+	nodeInfo NodeInfo,
+	cfg peerConfig,
+	socketAddr *NetAddress,
+	mConfig tmconn.MConnConfig,
+) *quicPeer {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &quicPeer{
+		conn:          c,
+		outbound:      cfg.outbound,
+		persistent:    cfg.persistent(nodeInfo, socketAddr),
+		socketAddr:    socketAddr,
+		created:       time.Now(),
+		nodeInfo:      nodeInfo,
+		channels:      nodeInfo.(DefaultNodeInfo).Channels, // TODO
+		channelsByID:  make(map[byte]*quicChannel, len(cfg.chDescs)),
+		reactorsByCh:  cfg.reactorsByCh,
+		onPeerError:   cfg.onPeerError,
+		sendMonitor:   flow.New(0, 0),
+		recvMonitor:   flow.New(0, 0),
+		sendRate:      mConfig.SendRate,
+		recvRate:      mConfig.RecvRate,
+		ctx:           ctx,
+		cancel:        cancel,
+		flushc:        make(chan struct{}),
+		endedc:        make(chan struct{}, maxNumChannels),
+		Data:          cmap.NewCMap(),
+		metricsTicker: time.NewTicker(metricsTickerDuration),
+		metrics:       cfg.metrics,
+	}
+	if p.metrics == nil {
+		p.metrics = NopMetrics()
+	}
+	for _, desc := range cfg.chDescs {
+		desc := desc.FillDefaults()
+		p.channelsByID[desc.ID] = &quicChannel{
+			desc:      desc,
+			sendQueue: make(chan []byte, desc.SendQueueCapacity),
+		}
+	}
+	p.BaseService = *service.NewBaseService(nil, "Peer", p)
+
+	return p
+}
+
+// String representation.
+func (p *quicPeer) String() string {
+	if p.outbound {
+		return fmt.Sprintf("Peer{QUIC %v %v out}", p.RemoteAddr(), p.ID())
+	}
+
+	return fmt.Sprintf("Peer{QUIC %v %v in}", p.RemoteAddr(), p.ID())
+}
+
+//---------------------------------------------------
+// Implements service.Service
+
+// OnStart implements BaseService.
+func (p *quicPeer) OnStart() error {
+	if err := p.BaseService.OnStart(); err != nil {
+		return err
+	}
+
+	for _, ch := range p.channelsByID {
+		if !p.hasChannel(ch.desc.ID) {
+			continue
+		}
+		p.sendWG.Add(1)
+		go p.sendRoutine(ch)
+	}
+	go p.acceptStreamsRoutine()
+	go p.flushRoutine()
+	go p.metricsReporter()
+
+	return nil
+}
+
+// FlushStop mimics OnStop but additionally ensures that all successful
+// .Send() calls will get flushed before closing the connection.
+// NOTE: it is not safe to call this method more than once.
+func (p *quicPeer) FlushStop() {
+	p.metricsTicker.Stop()
+	p.BaseService.OnStop()
+
+	close(p.flushc)
+	p.sendWG.Wait()
+
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(atomic.LoadInt32(&p.flushedStreams)))
+	if _, err := p.conn.Write(buf[:n]); err == nil {
+		_ = p.conn.Stream.Close()
+	}
+
+	select {
+	case <-p.conn.sess.Context().Done():
+	case <-time.After(quicFlushTimeout):
+	}
+
+	p.cancel()
+	_ = p.conn.Close()
+}
+
+// OnStop implements BaseService.
+func (p *quicPeer) OnStop() {
+	p.metricsTicker.Stop()
+	p.BaseService.OnStop()
+	p.cancel()
+	if err := p.conn.Close(); err != nil {
+		p.Logger.Debug("Error while stopping peer", "err", err)
+	}
+}
+
+//---------------------------------------------------
+// Implements Peer
+
+// ID returns the peer's ID - the hex encoded hash of its pubkey.
+func (p *quicPeer) ID() ID {
+	return p.nodeInfo.ID()
+}
+
+// RemoteIP returns the IP of the remote end of the session.
+func (p *quicPeer) RemoteIP() net.IP {
+	return p.RemoteAddr().(*net.UDPAddr).IP
+}
+
+// RemoteAddr returns the address of the remote end of the session.
+func (p *quicPeer) RemoteAddr() net.Addr {
+	return p.conn.RemoteAddr()
+}
+
+// IsOutbound returns true if the connection is outbound, false otherwise.
+func (p *quicPeer) IsOutbound() bool {
+	return p.outbound
+}
+
+// IsPersistent returns true if the peer is persitent, false otherwise.
+func (p *quicPeer) IsPersistent() bool {
+	return p.persistent
+}
+
+// CloseConn closes the session. Used for cleaning up in cases where the peer
+// had not been started at all.
+func (p *quicPeer) CloseConn() error {
+	return p.conn.Close()
+}
+
+// NodeInfo returns a copy of the peer's NodeInfo.
+func (p *quicPeer) NodeInfo() NodeInfo {
+	return p.nodeInfo
+}
+
+// Status returns the peer's ConnectionStatus.
+func (p *quicPeer) Status() tmconn.ConnectionStatus {
+	status := tmconn.ConnectionStatus{
+		Duration:    time.Since(p.created),
+		SendMonitor: p.sendMonitor.Status(),
+		RecvMonitor: p.recvMonitor.Status(),
+		Channels:    make([]tmconn.ChannelStatus, 0, len(p.channelsByID)),
+	}
+	for _, ch := range p.channelsByID {
+		status.Channels = append(status.Channels, tmconn.ChannelStatus{
+			ID:                ch.desc.ID,
+			SendQueueCapacity: cap(ch.sendQueue),
+			SendQueueSize:     int(atomic.LoadInt32(&ch.sendQueueSize)),
+			Priority:          ch.desc.Priority,
+			RecentlySent:      atomic.LoadInt64(&ch.recentlySent),
+		})
+	}
+	return status
+}
+
+// SocketAddr returns the address of the socket.
+// For outbound peers, it's the address dialed (after DNS resolution).
+// For inbound peers, it's the address returned by the underlying connection
+// (not what's reported in the peer's NodeInfo).
+func (p *quicPeer) SocketAddr() *NetAddress {
+	return p.socketAddr
+}
+
+// Send msg bytes to the channel identified by chID byte. Returns false if the
+// send queue is still full after a timeout.
+func (p *quicPeer) Send(chID byte, msgBytes []byte) bool {
+	ch, ok := p.sendChannel(chID)
+	if !ok {
+		return false
+	}
+
+	select {
+	case ch.sendQueue <- msgBytes:
+	case <-time.After(quicSendTimeout):
+		return false
+	case <-p.Quit():
+		return false
+	}
+	p.queued(ch, msgBytes)
+	return true
+}
+
+// TrySend msg bytes to the channel identified by chID byte. Immediately returns
+// false if the send queue is full.
+func (p *quicPeer) TrySend(chID byte, msgBytes []byte) bool {
+	ch, ok := p.sendChannel(chID)
+	if !ok {
+		return false
+	}
+
+	select {
+	case ch.sendQueue <- msgBytes:
+	default:
+		return false
+	}
+	p.queued(ch, msgBytes)
+	return true
+}
+
+// Get the data for a given key.
+func (p *quicPeer) Get(key string) interface{} {
+	return p.Data.Get(key)
+}
+
+// Set sets the data for the given key.
+func (p *quicPeer) Set(key string, data interface{}) {
+	p.Data.Set(key, data)
+}
+
+// sendChannel returns the channel to send on, if the peer is running and both
+// ends know about the channel.
+func (p *quicPeer) sendChannel(chID byte) (*quicChannel, bool) {
+	if !p.IsRunning() {
+		// see Switch#Broadcast, where we fetch the list of peers and loop over
+		// them - while we're looping, one peer may be removed and stopped.
+		return nil, false
+	} else if !p.hasChannel(chID) {
+		return nil, false
+	}
+
+	ch, ok := p.channelsByID[chID]
+	if !ok {
+		p.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+	}
+	return ch, ok
+}
+
+func (p *quicPeer) queued(ch *quicChannel, msgBytes []byte) {
+	atomic.AddInt32(&ch.sendQueueSize, 1)
+	labels := []string{
+		"peer_id", string(p.ID()),
+		"chID", fmt.Sprintf("%#x", ch.desc.ID),
+	}
+	p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+}
+
+// hasChannel returns true if the peer reported
+// knowing about the given chID.
+func (p *quicPeer) hasChannel(chID byte) bool {
+	for _, ch := range p.channels {
+		if ch == chID {
+			return true
+		}
+	}
+	return false
+}
+
+//---------------------------------------------------
+
+// sendRoutine opens the stream of the channel and writes the queued messages
+// to it until the peer is stopped. When flushing, it writes the remaining
+// messages and closes the stream.
+func (p *quicPeer) sendRoutine(ch *quicChannel) {
+	defer p.sendWG.Done()
+
+	stream, err := p.conn.sess.OpenUniStreamSync(p.ctx)
+	if err != nil {
+		p.stopForError(err)
+		return
+	}
+
+	w := bufio.NewWriter(stream)
+	write := func(msgBytes []byte) error {
+		var lenBuf [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(lenBuf[:], uint64(len(msgBytes)))
+		if _, err := w.Write(lenBuf[:n]); err != nil {
+			return err
+		}
+		if _, err := w.Write(msgBytes); err != nil {
+			return err
+		}
+		atomic.AddInt32(&ch.sendQueueSize, -1)
+		atomic.AddInt64(&ch.recentlySent, int64(n+len(msgBytes)))
+		p.sendMonitor.Limit(n+len(msgBytes), p.sendRate, true)
+		p.sendMonitor.Update(n + len(msgBytes))
+
+		// Only flush once the queue is drained to batch writes.
+		if len(ch.sendQueue) == 0 {
+			return w.Flush()
+		}
+		return nil
+	}
+
+	if err := w.WriteByte(ch.desc.ID); err != nil {
+		p.stopForError(err)
+		return
+	}
+
+	for {
+		select {
+		case msgBytes := <-ch.sendQueue:
+			if err := write(msgBytes); err != nil {
+				p.stopForError(err)
+				return
+			}
+
+		case <-p.flushc:
+			for len(ch.sendQueue) > 0 {
+				if err := write(<-ch.sendQueue); err != nil {
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+			if err := stream.Close(); err == nil {
+				atomic.AddInt32(&p.flushedStreams, 1)
+			}
+			return
+
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// acceptStreamsRoutine accepts the streams the remote peer opens for its
+// channels.
+func (p *quicPeer) acceptStreamsRoutine() {
+	for {
+		stream, err := p.conn.sess.AcceptUniStream(p.ctx)
+		if err != nil {
+			p.stopForError(err)
+			return
+		}
+
+		go p.recvRoutine(stream)
+	}
+}
+
+// recvRoutine reads the messages of a channel's stream and passes them to the
+// channel's reactor until the remote peer closes the stream.
+func (p *quicPeer) recvRoutine(stream quic.ReceiveStream) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.Logger.Error("Peer panicked", "err", r)
+			p.stopForError(fmt.Errorf("recovered from panic: %v", r))
+		}
+	}()
+
+	r := bufio.NewReader(stream)
+	chID, err := r.ReadByte()
+	if err != nil {
+		p.stopForError(err)
+		return
+	}
+	ch, ok := p.channelsByID[chID]
+	reactor := p.reactorsByCh[chID]
+	if !ok || reactor == nil {
+		p.stopForError(fmt.Errorf("unknown channel %X", chID))
+		return
+	}
+	// Each channel has a single stream, so that a remote peer can't make us
+	// spawn an unbounded number of receive routines.
+	if !atomic.CompareAndSwapInt32(&ch.receiving, 0, 1) {
+		p.stopForError(fmt.Errorf("duplicate stream for channel %X", chID))
+		return
+	}
+
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			// The remote peer is flushing before disconnecting.
+			select {
+			case p.endedc <- struct{}{}:
+			case <-p.ctx.Done():
+			}
+			return
+		} else if err != nil {
+			p.stopForError(err)
+			return
+		}
+		if size > uint64(ch.desc.RecvMessageCapacity) {
+			p.stopForError(fmt.Errorf("received message exceeds available capacity: %v < %v",
+				ch.desc.RecvMessageCapacity, size))
+			return
+		}
+
+		msgBytes := make([]byte, size)
+		if _, err := io.ReadFull(r, msgBytes); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			p.stopForError(err)
+			return
+		}
+		p.recvMonitor.Limit(len(msgBytes), p.recvRate, true)
+		p.recvMonitor.Update(len(msgBytes))
+
+		labels := []string{
+			"peer_id", string(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+		}
+		p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+		reactor.Receive(chID, p, msgBytes)
+	}
+}
+
+// flushRoutine waits for the remote peer to flush, by sending the number of
+// streams it closed on the handshake stream, and disconnects once all of
+// them were received.
+func (p *quicPeer) flushRoutine() {
+	n, err := binary.ReadUvarint(bufio.NewReader(p.conn))
+	if err != nil {
+		// The session is closed, which acceptStreamsRoutine reports.
+		return
+	}
+
+	for i := uint64(0); i < n; i++ {
+		select {
+		case <-p.endedc:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+	p.stopForError(io.EOF)
+}
+
+// stopForError reports the first error of the peer, unless it is stopping.
+func (p *quicPeer) stopForError(err error) {
+	if p.ctx.Err() != nil {
+		return
+	}
+	p.errorOnce.Do(func() {
+		if p.onPeerError != nil {
+			p.onPeerError(p, err)
+		}
+	})
+}
+
+func (p *quicPeer) metricsReporter() {
+	for {
+		select {
+		case <-p.metricsTicker.C:
+			var sendQueueSize float64
+			for _, ch := range p.channelsByID {
+				sendQueueSize += float64(atomic.LoadInt32(&ch.sendQueueSize))
+			}
+
+			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+		case <-p.Quit():
+			return
+		}
+	}
+}
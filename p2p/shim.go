@@ -60,7 +60,7 @@ func NewReactorShim(name string, descriptors map[ChannelID]*ChannelDescriptorShi
 
 	rs := &ReactorShim{
 		Name:        name,
-		PeerUpdates: NewPeerUpdates(),
+		PeerUpdates: NewPeerUpdates(make(chan PeerUpdate)),
 		Channels:    channels,
 	}
 
@@ -174,6 +174,33 @@ func (rs *ReactorShim) handlePeerErrors() {
 	}
 }
 
+// handleReactorPeerUpdates starts a separate go-routine where we listen for
+// peer updates sent by the reactor. A peer reported as good is marked as such
+// on the legacy p2p Switch.
+func (rs *ReactorShim) handleReactorPeerUpdates() {
+	go func() {
+		for {
+			select {
+			case update := <-rs.PeerUpdates.reactorUpdatesCh:
+				if update.Status != PeerStatusGood {
+					continue
+				}
+
+				peer := rs.Switch.peers.Get(ID(update.PeerID.String()))
+				if peer == nil {
+					rs.Logger.Debug("failed to handle peer update; failed to find peer", "peer", update.PeerID.String())
+					continue
+				}
+
+				rs.Switch.MarkPeerAsGood(peer)
+
+			case <-rs.PeerUpdates.Done():
+				return
+			}
+		}
+	}()
+}
+
 // OnStart executes the reactor shim's OnStart hook where we start all the
 // necessary go-routines in order to proxy peer envelopes and errors per p2p
 // Channel.
@@ -185,6 +212,7 @@ func (rs *ReactorShim) OnStart() error {
 	// start envelope proxying and peer error handling in separate go routines
 	rs.proxyPeerEnvelopes()
 	rs.handlePeerErrors()
+	rs.handleReactorPeerUpdates()
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"bytes"
 	"fmt"
 	mrand "math/rand"
 	"net"
@@ -17,6 +18,10 @@ import (
 
 const testCh = 0x01
 
+// memoryConnectTimeout bounds how long ConnectMemorySwitches waits for the
+// dialed switch to add the peer.
+const memoryConnectTimeout = 10 * time.Second
+
 //------------------------------------------------
 
 type mockNodeInfo struct {
@@ -162,6 +167,55 @@ func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
 	return nil
 }
 
+// MakeConnectedMemorySwitches is like MakeConnectedSwitches, but the switches
+// communicate through the given in-memory network instead of real sockets.
+// If connect==ConnectMemorySwitches, the switches will be fully connected.
+// NOTE: panics if any switch fails to start.
+func MakeConnectedMemorySwitches(network *MemoryNetwork,
+	cfg *config.P2PConfig,
+	n int,
+	initSwitch func(int, *Switch) *Switch,
+	connect func([]*Switch, int, int),
+) []*Switch {
+	switches := make([]*Switch, n)
+	for i := 0; i < n; i++ {
+		switches[i] = MakeMemorySwitch(network, cfg, i, initSwitch)
+	}
+
+	if err := StartSwitches(switches); err != nil {
+		panic(err)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			connect(switches, i, j)
+		}
+	}
+
+	return switches
+}
+
+// ConnectMemorySwitches will connect switches i and j by dialing j from i
+// through their MemoryTransports. Blocks until both switches added the peer.
+// NOTE: caller ensures i and j are within bounds. Panics if j doesn't add the
+// peer within memoryConnectTimeout.
+func ConnectMemorySwitches(switches []*Switch, i, j int) {
+	switchI := switches[i]
+	switchJ := switches[j]
+
+	if err := switchI.DialPeerWithAddress(switchJ.NetAddress()); err != nil {
+		panic(err)
+	}
+
+	deadline := time.Now().Add(memoryConnectTimeout)
+	for !switchJ.Peers().Has(switchI.NodeInfo().ID()) {
+		if time.Now().After(deadline) {
+			panic(fmt.Sprintf("switch %d did not add switch %d as a peer within %v", j, i, memoryConnectTimeout))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 // StartSwitches calls sw.Start() for each given switch.
 // It returns the first encountered error.
 func StartSwitches(switches []*Switch) error {
@@ -184,6 +238,45 @@ func MakeSwitch(
 
 	nodeKey := GenNodeKey()
 	nodeInfo := testNodeInfo(nodeKey.ID, fmt.Sprintf("node%d", i))
+	t := NewMultiplexTransport(nodeInfo, nodeKey, MConnConfig(cfg))
+
+	return makeSwitch(cfg, i, t, &t.nodeInfo, nodeKey, nodeInfo, initSwitch, opts...)
+}
+
+// MakeMemorySwitch is like MakeSwitch, but the switch uses a MemoryTransport
+// connected to the given network.
+func MakeMemorySwitch(
+	network *MemoryNetwork,
+	cfg *config.P2PConfig,
+	i int,
+	initSwitch func(int, *Switch) *Switch,
+	opts ...SwitchOption,
+) *Switch {
+
+	nodeKey := GenNodeKey()
+	nodeInfo := testNodeInfo(nodeKey.ID, fmt.Sprintf("node%d", i))
+	t := NewMemoryTransport(network, nodeInfo, nodeKey, MConnConfig(cfg))
+
+	return makeSwitch(cfg, i, t, &t.nodeInfo, nodeKey, nodeInfo, initSwitch, opts...)
+}
+
+// makeSwitch starts listening on the transport and creates the switch. The
+// transport's NodeInfo, referenced by transportNodeInfo, is updated with the
+// channels of the switch's reactors.
+func makeSwitch(
+	cfg *config.P2PConfig,
+	i int,
+	t interface {
+		Transport
+		transportLifecycle
+	},
+	transportNodeInfo *NodeInfo,
+	nodeKey NodeKey,
+	nodeInfo NodeInfo,
+	initSwitch func(int, *Switch) *Switch,
+	opts ...SwitchOption,
+) *Switch {
+
 	addr, err := NewNetAddressString(
 		IDAddressString(nodeKey.ID, nodeInfo.(DefaultNodeInfo).ListenAddr),
 	)
@@ -191,8 +284,6 @@ func MakeSwitch(
 		panic(err)
 	}
 
-	t := NewMultiplexTransport(nodeInfo, nodeKey, MConnConfig(cfg))
-
 	if err := t.Listen(*addr); err != nil {
 		panic(err)
 	}
@@ -204,13 +295,15 @@ func MakeSwitch(
 
 	ni := nodeInfo.(DefaultNodeInfo)
 	for ch := range sw.reactorsByCh {
-		ni.Channels = append(ni.Channels, ch)
+		if !bytes.Contains(ni.Channels, []byte{ch}) {
+			ni.Channels = append(ni.Channels, ch)
+		}
 	}
 	nodeInfo = ni
 
 	// TODO: We need to setup reactors ahead of time so the NodeInfo is properly
 	// populated and we don't have to do those awkward overrides and setters.
-	t.nodeInfo = nodeInfo
+	*transportNodeInfo = nodeInfo
 	sw.SetNodeInfo(nodeInfo)
 
 	return sw
@@ -52,6 +52,23 @@ type peerConfig struct {
 	metrics      *Metrics
 }
 
+// persistent tells if the peer is persistent, using the dialed address for
+// outbound peers and the self-reported address for inbound peers.
+func (cfg peerConfig) persistent(ni NodeInfo, socketAddr *NetAddress) bool {
+	if cfg.isPersistent == nil {
+		return false
+	}
+	if cfg.outbound {
+		return cfg.isPersistent(socketAddr)
+	}
+
+	selfReportedAddr, err := ni.NetAddress()
+	if err != nil {
+		return false
+	}
+	return cfg.isPersistent(selfReportedAddr)
+}
+
 // Transport emits and connects to Peers. The implementation of Peer is left to
 // the transport. Each transport is also responsible to filter establishing
 // peers specific to its domain.
@@ -343,7 +360,20 @@ func (mt *MultiplexTransport) cleanup(c net.Conn) error {
 	return c.Close()
 }
 
-func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
+func (mt *MultiplexTransport) filterConn(c net.Conn) error {
+	return filterConn(c, mt.conns, mt.connFilters, mt.resolver, mt.filterTimeout)
+}
+
+// filterConn rejects the connection if it is already present in conns or any
+// of the filters rejects it, and adds it to conns otherwise. The connection is
+// closed if rejected.
+func filterConn(
+	c net.Conn,
+	conns ConnSet,
+	connFilters []ConnFilterFunc,
+	resolver IPResolver,
+	filterTimeout time.Duration,
+) (err error) {
 	defer func() {
 		if err != nil {
 			_ = c.Close()
@@ -351,21 +381,21 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 	}()
 
 	// Reject if connection is already present.
-	if mt.conns.Has(c) {
+	if conns.Has(c) {
 		return ErrRejected{conn: c, isDuplicate: true}
 	}
 
 	// Resolve ips for incoming conn.
-	ips, err := resolveIPs(mt.resolver, c)
+	ips, err := resolveIPs(resolver, c)
 	if err != nil {
 		return err
 	}
 
-	errc := make(chan error, len(mt.connFilters))
+	errc := make(chan error, len(connFilters))
 
-	for _, f := range mt.connFilters {
+	for _, f := range connFilters {
 		go func(f ConnFilterFunc, c net.Conn, ips []net.IP, errc chan<- error) {
-			errc <- f(mt.conns, c, ips)
+			errc <- f(conns, c, ips)
 		}(f, c, ips, errc)
 	}
 
@@ -375,13 +405,13 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 			if err != nil {
 				return ErrRejected{conn: c, err: err, isFiltered: true}
 			}
-		case <-time.After(mt.filterTimeout):
+		case <-time.After(filterTimeout):
 			return ErrFilterTimeout{}
 		}
 
 	}
 
-	mt.conns.Set(c, ips)
+	conns.Set(c, ips)
 
 	return nil
 }
@@ -396,7 +426,23 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}()
 
-	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey)
+	return upgradeConn(c, dialedAddr, mt.handshakeTimeout, mt.nodeKey, &mt.nodeInfo)
+}
+
+// upgradeConn secures the connection and exchanges NodeInfo with the peer,
+// rejecting peers that are misconfigured, incompatible or ourselves. For
+// outgoing connections, dialedAddr must be set to ensure we reached the peer
+// we intended to. The NodeInfo referenced by nodeInfoRef is only read once the
+// connection is secured, as it may still be updated while the transport is
+// listening.
+func upgradeConn(
+	c net.Conn,
+	dialedAddr *NetAddress,
+	timeout time.Duration,
+	nodeKey NodeKey,
+	nodeInfoRef *NodeInfo,
+) (secretConn *conn.SecretConnection, nodeInfo NodeInfo, err error) {
+	secretConn, err = upgradeSecretConn(c, timeout, nodeKey.PrivKey)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -407,22 +453,12 @@ func (mt *MultiplexTransport) upgrade(
 
 	// For outgoing conns, ensure connection key matches dialed key.
 	connID := PubKeyToID(secretConn.RemotePubKey())
-	if dialedAddr != nil {
-		if dialedID := dialedAddr.ID; connID != dialedID {
-			return nil, nil, ErrRejected{
-				conn: c,
-				id:   connID,
-				err: fmt.Errorf(
-					"conn.ID (%v) dialed ID (%v) mismatch",
-					connID,
-					dialedID,
-				),
-				isAuthFailure: true,
-			}
-		}
+	if err := checkDialedID(c, connID, dialedAddr); err != nil {
+		return nil, nil, err
 	}
 
-	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
+	ourNodeInfo := *nodeInfoRef
+	nodeInfo, err = handshake(secretConn, timeout, ourNodeInfo)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -431,8 +467,37 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	if err := checkPeerNodeInfo(c, connID, nodeInfo, ourNodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	return secretConn, nodeInfo, nil
+}
+
+// checkDialedID ensures that the authenticated ID of an outgoing connection
+// matches the ID we dialed. dialedAddr is nil for incoming connections.
+func checkDialedID(c net.Conn, connID ID, dialedAddr *NetAddress) error {
+	if dialedAddr == nil || connID == dialedAddr.ID {
+		return nil
+	}
+
+	return ErrRejected{
+		conn: c,
+		id:   connID,
+		err: fmt.Errorf(
+			"conn.ID (%v) dialed ID (%v) mismatch",
+			connID,
+			dialedAddr.ID,
+		),
+		isAuthFailure: true,
+	}
+}
+
+// checkPeerNodeInfo rejects peers whose NodeInfo is invalid, doesn't match the
+// authenticated connection ID, is our own or is incompatible with ours.
+func checkPeerNodeInfo(c net.Conn, connID ID, nodeInfo, ourNodeInfo NodeInfo) error {
 	if err := nodeInfo.Validate(); err != nil {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn:              c,
 			err:               err,
 			isNodeInfoInvalid: true,
@@ -441,7 +506,7 @@ func (mt *MultiplexTransport) upgrade(
 
 	// Ensure connection key matches self reported key.
 	if connID != nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+		return ErrRejected{
 			conn: c,
 			id:   connID,
 			err: fmt.Errorf(
@@ -454,8 +519,8 @@ func (mt *MultiplexTransport) upgrade(
 	}
 
 	// Reject self.
-	if mt.nodeInfo.ID() == nodeInfo.ID() {
-		return nil, nil, ErrRejected{
+	if ourNodeInfo.ID() == nodeInfo.ID() {
+		return ErrRejected{
 			addr:   *NewNetAddress(nodeInfo.ID(), c.RemoteAddr()),
 			conn:   c,
 			id:     nodeInfo.ID(),
@@ -463,8 +528,8 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	if err := mt.nodeInfo.CompatibleWith(nodeInfo); err != nil {
-		return nil, nil, ErrRejected{
+	if err := ourNodeInfo.CompatibleWith(nodeInfo); err != nil {
+		return ErrRejected{
 			conn:           c,
 			err:            err,
 			id:             nodeInfo.ID(),
@@ -472,7 +537,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
-	return secretConn, nodeInfo, nil
+	return nil
 }
 
 func (mt *MultiplexTransport) wrapPeer(
@@ -481,29 +546,29 @@ func (mt *MultiplexTransport) wrapPeer(
 	cfg peerConfig,
 	socketAddr *NetAddress,
 ) Peer {
+	return wrapPeer(c, ni, cfg, socketAddr, mt.mConfig)
+}
 
-	persistent := false
-	if cfg.isPersistent != nil {
-		if cfg.outbound {
-			persistent = cfg.isPersistent(socketAddr)
-		} else {
-			selfReportedAddr, err := ni.NetAddress()
-			if err == nil {
-				persistent = cfg.isPersistent(selfReportedAddr)
-			}
-		}
-	}
+// wrapPeer creates a Peer for an upgraded connection according to the
+// configuration provided by the Switch.
+func wrapPeer(
+	c net.Conn,
+	ni NodeInfo,
+	cfg peerConfig,
+	socketAddr *NetAddress,
+	mConfig conn.MConnConfig,
+) Peer {
 
 	peerConn := newPeerConn(
 		cfg.outbound,
-		persistent,
+		cfg.persistent(ni, socketAddr),
 		c,
 		socketAddr,
 	)
 
 	p := newPeer(
 		peerConn,
-		mt.mConfig,
+		mConfig,
 		ni,
 		cfg.reactorsByCh,
 		cfg.chDescs,
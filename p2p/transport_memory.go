@@ -0,0 +1,582 @@
+package p2p
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lazyledger/lazyledger-core/p2p/conn"
+)
+
+const (
+	defaultRetransmitTimeout = 200 * time.Millisecond
+
+	// memorySendBuffer is how much data a writer may queue on a link with
+	// limited bandwidth before it blocks until the link has caught up.
+	memorySendBuffer = 64 * 1024
+)
+
+// MemoryLinkConfig describes the conditions of a link between two peers of a
+// MemoryNetwork. The zero value is a perfect link.
+type MemoryLinkConfig struct {
+	// Latency is the one-way delay of every write.
+	Latency time.Duration
+	// Bandwidth limits the link to the given number of bytes per second in
+	// each direction. 0 means unlimited. Writers block once the data the link
+	// hasn't transmitted yet exceeds a send buffer of 64KB.
+	Bandwidth int64
+	// LossRate is the probability in [0, 1) of a write getting lost. Since
+	// peers communicate over reliable streams, a lost write is modelled the way
+	// TCP experiences it: it is retransmitted after RetransmitTimeout, delaying
+	// it and everything written after it.
+	LossRate float64
+	// RetransmitTimeout defaults to 200ms.
+	RetransmitTimeout time.Duration
+}
+
+// ValidateBasic performs basic validation.
+func (cfg MemoryLinkConfig) ValidateBasic() error {
+	if cfg.Latency < 0 {
+		return fmt.Errorf("latency can't be negative, got %v", cfg.Latency)
+	}
+	if cfg.Bandwidth < 0 {
+		return fmt.Errorf("bandwidth can't be negative, got %d", cfg.Bandwidth)
+	}
+	if cfg.LossRate < 0 || cfg.LossRate >= 1 {
+		return fmt.Errorf("loss rate must be in [0, 1), got %v", cfg.LossRate)
+	}
+	if cfg.RetransmitTimeout < 0 {
+		return fmt.Errorf("retransmit timeout can't be negative, got %v", cfg.RetransmitTimeout)
+	}
+	return nil
+}
+
+// sendBufferTime is how long it takes the link to transmit a full send buffer.
+func (cfg MemoryLinkConfig) sendBufferTime() time.Duration {
+	return time.Duration(memorySendBuffer * int64(time.Second) / cfg.Bandwidth)
+}
+
+func (cfg MemoryLinkConfig) retransmitTimeout() time.Duration {
+	if cfg.RetransmitTimeout == 0 {
+		return defaultRetransmitTimeout
+	}
+	return cfg.RetransmitTimeout
+}
+
+// MemoryNetworkOption sets an optional parameter on the MemoryNetwork.
+type MemoryNetworkOption func(*MemoryNetwork)
+
+// MemoryNetworkLink sets the conditions of all links of the network, unless
+// overridden for a pair of peers with SetLink. It panics if the config is
+// invalid.
+func MemoryNetworkLink(cfg MemoryLinkConfig) MemoryNetworkOption {
+	if err := cfg.ValidateBasic(); err != nil {
+		panic(err)
+	}
+	return func(n *MemoryNetwork) { n.defaultLink = cfg }
+}
+
+// MemoryNetworkSeed seeds the source of randomness used to simulate packet
+// loss, so that the loss pattern is reproducible for a given sequence of
+// writes. Default: 0.
+func MemoryNetworkSeed(seed int64) MemoryNetworkOption {
+	return func(n *MemoryNetwork) { n.rand = rand.New(rand.NewSource(seed)) } // nolint:gosec
+}
+
+// MemoryNetwork connects MemoryTransports within a single process, simulating
+// the conditions of the links between them. It allows whole networks of
+// nodes to be tested without real sockets.
+type MemoryNetwork struct {
+	mtx         sync.RWMutex
+	transports  map[ID]*MemoryTransport
+	defaultLink MemoryLinkConfig
+	links       map[memoryLinkKey]MemoryLinkConfig
+
+	randMtx sync.Mutex
+	rand    *rand.Rand
+}
+
+// memoryLinkKey identifies the link between two peers, regardless of the
+// direction.
+type memoryLinkKey struct {
+	a, b ID
+}
+
+func newMemoryLinkKey(a, b ID) memoryLinkKey {
+	if a > b {
+		a, b = b, a
+	}
+	return memoryLinkKey{a: a, b: b}
+}
+
+// NewMemoryNetwork returns a new network with perfect links.
+func NewMemoryNetwork(options ...MemoryNetworkOption) *MemoryNetwork {
+	n := &MemoryNetwork{
+		transports: make(map[ID]*MemoryTransport),
+		links:      make(map[memoryLinkKey]MemoryLinkConfig),
+		rand:       rand.New(rand.NewSource(0)), // nolint:gosec
+	}
+	for _, option := range options {
+		option(n)
+	}
+	return n
+}
+
+// SetLink sets the conditions of the link between the peers a and b, in both
+// directions. It applies to existing connections too.
+func (n *MemoryNetwork) SetLink(a, b ID, cfg MemoryLinkConfig) error {
+	if err := cfg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.links[newMemoryLinkKey(a, b)] = cfg
+	return nil
+}
+
+func (n *MemoryNetwork) link(a, b ID) MemoryLinkConfig {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+
+	if cfg, ok := n.links[newMemoryLinkKey(a, b)]; ok {
+		return cfg
+	}
+	return n.defaultLink
+}
+
+// lose reports whether a write is lost on a link with the given loss rate.
+func (n *MemoryNetwork) lose(rate float64) bool {
+	if rate == 0 {
+		return false
+	}
+
+	n.randMtx.Lock()
+	defer n.randMtx.Unlock()
+	return n.rand.Float64() < rate
+}
+
+func (n *MemoryNetwork) listen(mt *MemoryTransport, addr NetAddress) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if _, ok := n.transports[addr.ID]; ok {
+		return fmt.Errorf("a memory transport is already listening for %v", addr.ID)
+	}
+	n.transports[addr.ID] = mt
+	return nil
+}
+
+func (n *MemoryNetwork) transport(id ID) (*MemoryTransport, bool) {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+
+	mt, ok := n.transports[id]
+	return mt, ok
+}
+
+func (n *MemoryNetwork) remove(mt *MemoryTransport) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.transports[mt.netAddr.ID] == mt {
+		delete(n.transports, mt.netAddr.ID)
+	}
+}
+
+// MemoryTransport is a Transport whose peers are connected through a
+// MemoryNetwork. Connections are upgraded like the ones of the
+// MultiplexTransport, but connection filters are not supported.
+type MemoryTransport struct {
+	network *MemoryNetwork
+	netAddr NetAddress
+
+	acceptc   chan accept
+	closec    chan struct{}
+	closeOnce sync.Once
+
+	handshakeTimeout time.Duration
+	nodeInfo         NodeInfo
+	nodeKey          NodeKey
+	mConfig          conn.MConnConfig
+}
+
+// Test MemoryTransport for interface completeness.
+var _ Transport = (*MemoryTransport)(nil)
+var _ transportLifecycle = (*MemoryTransport)(nil)
+
+// NewMemoryTransport returns a transport connected to the given network.
+func NewMemoryTransport(
+	network *MemoryNetwork,
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	mConfig conn.MConnConfig,
+) *MemoryTransport {
+	return &MemoryTransport{
+		network:          network,
+		acceptc:          make(chan accept),
+		closec:           make(chan struct{}),
+		handshakeTimeout: defaultHandshakeTimeout,
+		nodeInfo:         nodeInfo,
+		nodeKey:          nodeKey,
+		mConfig:          mConfig,
+	}
+}
+
+// NetAddress implements Transport.
+func (mt *MemoryTransport) NetAddress() NetAddress {
+	return mt.netAddr
+}
+
+// Accept implements Transport.
+func (mt *MemoryTransport) Accept(cfg peerConfig) (Peer, error) {
+	select {
+	case a := <-mt.acceptc:
+		if a.err != nil {
+			return nil, a.err
+		}
+
+		cfg.outbound = false
+
+		return wrapPeer(a.conn, a.nodeInfo, cfg, a.netAddr, mt.mConfig), nil
+	case <-mt.closec:
+		return nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements Transport.
+func (mt *MemoryTransport) Dial(addr NetAddress, cfg peerConfig) (Peer, error) {
+	remote, ok := mt.network.transport(addr.ID)
+	if !ok {
+		return nil, fmt.Errorf("no memory transport listening for %v", addr)
+	}
+
+	c, rc := newMemoryConnPair(mt.network, mt.netAddr, addr)
+	if !remote.acceptConn(rc) {
+		_ = c.Close()
+		return nil, fmt.Errorf("memory transport for %v is closed", addr)
+	}
+
+	secretConn, nodeInfo, err := upgradeConn(c, &addr, mt.handshakeTimeout, mt.nodeKey, &mt.nodeInfo)
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return wrapPeer(secretConn, nodeInfo, cfg, &addr, mt.mConfig), nil
+}
+
+// acceptConn upgrades an incoming connection asynchronously and makes it
+// available to Accept. It returns false if the transport is closed.
+func (mt *MemoryTransport) acceptConn(c *memoryConn) bool {
+	select {
+	case <-mt.closec:
+		return false
+	default:
+	}
+
+	go func() {
+		secretConn, nodeInfo, err := upgradeConn(c, nil, mt.handshakeTimeout, mt.nodeKey, &mt.nodeInfo)
+
+		a := accept{nodeInfo: nodeInfo, err: err}
+		if err == nil {
+			a.conn = secretConn
+			a.netAddr = NewNetAddress(PubKeyToID(secretConn.RemotePubKey()), c.RemoteAddr())
+		} else {
+			_ = c.Close()
+		}
+
+		select {
+		case mt.acceptc <- a:
+		case <-mt.closec:
+			_ = c.Close()
+		}
+	}()
+
+	return true
+}
+
+// Cleanup implements Transport.
+func (mt *MemoryTransport) Cleanup(p Peer) {
+	_ = p.CloseConn()
+}
+
+// Close implements transportLifecycle.
+func (mt *MemoryTransport) Close() error {
+	mt.closeOnce.Do(func() {
+		close(mt.closec)
+		mt.network.remove(mt)
+	})
+	return nil
+}
+
+// Listen implements transportLifecycle. Peers can dial the transport by the
+// ID of the given address.
+func (mt *MemoryTransport) Listen(addr NetAddress) error {
+	if err := mt.network.listen(mt, addr); err != nil {
+		return err
+	}
+	mt.netAddr = addr
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// memoryConn is one end of an in-memory connection. Writes are queued and
+// become readable on the other end once the link delivers them. On links with
+// limited bandwidth, they block while the send buffer is full.
+type memoryConn struct {
+	network       *MemoryNetwork
+	local, remote NetAddress
+
+	r, w *memoryPipe
+
+	closeOnce sync.Once
+}
+
+var _ net.Conn = (*memoryConn)(nil)
+
+func newMemoryConnPair(network *MemoryNetwork, a, b NetAddress) (*memoryConn, *memoryConn) {
+	ab, ba := newMemoryPipe(), newMemoryPipe()
+	return &memoryConn{network: network, local: a, remote: b, r: ba, w: ab},
+		&memoryConn{network: network, local: b, remote: a, r: ab, w: ba}
+}
+
+// Read implements net.Conn.
+func (c *memoryConn) Read(b []byte) (int, error) {
+	return c.r.read(b)
+}
+
+// Write implements net.Conn.
+func (c *memoryConn) Write(b []byte) (int, error) {
+	return c.w.write(b, c.network.link(c.local.ID, c.remote.ID), c.network)
+}
+
+// Close implements net.Conn. Data already written is still delivered to the
+// other end.
+func (c *memoryConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.r.closeReader()
+		c.w.closeWriter()
+	})
+	return nil
+}
+
+// LocalAddr implements net.Conn.
+func (c *memoryConn) LocalAddr() net.Addr {
+	return &net.TCPAddr{IP: c.local.IP, Port: int(c.local.Port)}
+}
+
+// RemoteAddr implements net.Conn.
+func (c *memoryConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: c.remote.IP, Port: int(c.remote.Port)}
+}
+
+// SetDeadline implements net.Conn.
+func (c *memoryConn) SetDeadline(t time.Time) error {
+	c.r.setDeadline(t)
+	c.w.setWriteDeadline(t)
+	return nil
+}
+
+// SetReadDeadline implements net.Conn.
+func (c *memoryConn) SetReadDeadline(t time.Time) error {
+	c.r.setDeadline(t)
+	return nil
+}
+
+// SetWriteDeadline implements net.Conn.
+func (c *memoryConn) SetWriteDeadline(t time.Time) error {
+	c.w.setWriteDeadline(t)
+	return nil
+}
+
+// memorySegment is a write in flight, readable from deliverAt onwards.
+type memorySegment struct {
+	data      []byte
+	deliverAt time.Time
+}
+
+// memoryPipe is one direction of a memoryConn.
+type memoryPipe struct {
+	mtx          sync.Mutex
+	segments     []memorySegment
+	busyUntil    time.Time // when the link is done transmitting the queued writes
+	lastDelivery time.Time
+	deadline     time.Time
+	wdeadline    time.Time
+	readerClosed bool
+	writerClosed bool
+
+	notifyCh  chan struct{} // signals readers that the pipe changed
+	wnotifyCh chan struct{} // signals blocked writers that the pipe changed
+}
+
+func newMemoryPipe() *memoryPipe {
+	return &memoryPipe{notifyCh: make(chan struct{}, 1), wnotifyCh: make(chan struct{}, 1)}
+}
+
+func (p *memoryPipe) notify() {
+	select {
+	case p.notifyCh <- struct{}{}:
+	default:
+	}
+	select {
+	case p.wnotifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (p *memoryPipe) write(b []byte, cfg MemoryLinkConfig, network *MemoryNetwork) (int, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// Block while the link is still busy transmitting more than the send buffer.
+	var now time.Time
+	for {
+		if p.writerClosed || p.readerClosed {
+			return 0, io.ErrClosedPipe
+		}
+
+		now = time.Now()
+		if cfg.Bandwidth == 0 {
+			break
+		}
+		wait := p.busyUntil.Sub(now) - cfg.sendBufferTime()
+		if wait <= 0 {
+			break
+		}
+		if !p.wdeadline.IsZero() {
+			if !now.Before(p.wdeadline) {
+				return 0, os.ErrDeadlineExceeded
+			}
+			if untilDeadline := p.wdeadline.Sub(now); untilDeadline < wait {
+				wait = untilDeadline
+			}
+		}
+		p.mtx.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-p.wnotifyCh:
+		case <-timer.C:
+		}
+		timer.Stop()
+
+		p.mtx.Lock()
+	}
+
+	// The write is transmitted once the link is done with the previous ones.
+	sentAt := now
+	if p.busyUntil.After(sentAt) {
+		sentAt = p.busyUntil
+	}
+	if cfg.Bandwidth > 0 {
+		sentAt = sentAt.Add(time.Duration(int64(len(b)) * int64(time.Second) / cfg.Bandwidth))
+	}
+	p.busyUntil = sentAt
+
+	deliverAt := sentAt.Add(cfg.Latency)
+	for network.lose(cfg.LossRate) {
+		deliverAt = deliverAt.Add(cfg.retransmitTimeout())
+	}
+	// Streams are ordered, so a write can't overtake a previous one.
+	if deliverAt.Before(p.lastDelivery) {
+		deliverAt = p.lastDelivery
+	}
+	p.lastDelivery = deliverAt
+
+	data := make([]byte, len(b))
+	copy(data, b)
+	p.segments = append(p.segments, memorySegment{data: data, deliverAt: deliverAt})
+	p.notify()
+
+	return len(b), nil
+}
+
+func (p *memoryPipe) read(b []byte) (int, error) {
+	for {
+		p.mtx.Lock()
+		if p.readerClosed {
+			p.mtx.Unlock()
+			return 0, io.ErrClosedPipe
+		}
+
+		now := time.Now()
+		if !p.deadline.IsZero() && !now.Before(p.deadline) {
+			p.mtx.Unlock()
+			return 0, os.ErrDeadlineExceeded
+		}
+
+		var wait time.Duration = -1
+		if len(p.segments) > 0 {
+			seg := &p.segments[0]
+			if !seg.deliverAt.After(now) {
+				n := copy(b, seg.data)
+				seg.data = seg.data[n:]
+				if len(seg.data) == 0 {
+					p.segments = p.segments[1:]
+				}
+				p.mtx.Unlock()
+				return n, nil
+			}
+			wait = seg.deliverAt.Sub(now)
+		} else if p.writerClosed {
+			p.mtx.Unlock()
+			return 0, io.EOF
+		}
+
+		if !p.deadline.IsZero() {
+			if untilDeadline := p.deadline.Sub(now); wait < 0 || untilDeadline < wait {
+				wait = untilDeadline
+			}
+		}
+		p.mtx.Unlock()
+
+		if wait < 0 {
+			<-p.notifyCh
+			continue
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-p.notifyCh:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+func (p *memoryPipe) setDeadline(t time.Time) {
+	p.mtx.Lock()
+	p.deadline = t
+	p.mtx.Unlock()
+	p.notify()
+}
+
+func (p *memoryPipe) setWriteDeadline(t time.Time) {
+	p.mtx.Lock()
+	p.wdeadline = t
+	p.mtx.Unlock()
+	p.notify()
+}
+
+func (p *memoryPipe) closeReader() {
+	p.mtx.Lock()
+	p.readerClosed = true
+	p.mtx.Unlock()
+	p.notify()
+}
+
+func (p *memoryPipe) closeWriter() {
+	p.mtx.Lock()
+	p.writerClosed = true
+	p.mtx.Unlock()
+	p.notify()
+}
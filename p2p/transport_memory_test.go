@@ -0,0 +1,248 @@
+package p2p
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/config"
+	"github.com/lazyledger/lazyledger-core/p2p/conn"
+)
+
+func newTestMemoryTransport(t *testing.T, network *MemoryNetwork) *MemoryTransport {
+	nodeKey := GenNodeKey()
+	nodeInfo := testNodeInfo(nodeKey.ID, "memory")
+	mt := NewMemoryTransport(network, nodeInfo, nodeKey, conn.DefaultMConnConfig())
+
+	addr, err := NewNetAddressString(IDAddressString(nodeKey.ID, nodeInfo.(DefaultNodeInfo).ListenAddr))
+	require.NoError(t, err)
+	require.NoError(t, mt.Listen(*addr))
+	t.Cleanup(func() { _ = mt.Close() })
+
+	return mt
+}
+
+func TestMemoryTransportDialAccept(t *testing.T) {
+	network := NewMemoryNetwork()
+	mt1 := newTestMemoryTransport(t, network)
+	mt2 := newTestMemoryTransport(t, network)
+
+	acceptc := make(chan Peer, 1)
+	go func() {
+		p, err := mt2.Accept(peerConfig{})
+		require.NoError(t, err)
+		acceptc <- p
+	}()
+
+	p, err := mt1.Dial(mt2.NetAddress(), peerConfig{})
+	require.NoError(t, err)
+	assert.True(t, p.IsOutbound())
+	assert.Equal(t, mt2.NetAddress().ID, p.ID())
+
+	select {
+	case p := <-acceptc:
+		assert.False(t, p.IsOutbound())
+		assert.Equal(t, mt1.NetAddress().ID, p.ID())
+		assert.Equal(t, mt1.NetAddress().IP.String(), p.RemoteIP().String())
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the dialed peer")
+	}
+}
+
+func TestMemoryTransportDialErrors(t *testing.T) {
+	network := NewMemoryNetwork()
+	mt := newTestMemoryTransport(t, network)
+
+	// nobody is listening for the address
+	addr := mt.NetAddress()
+	addr.ID = GenNodeKey().ID
+	_, err := mt.Dial(addr, peerConfig{})
+	require.Error(t, err)
+
+	// a transport can't be dialed once closed
+	closed := newTestMemoryTransport(t, network)
+	require.NoError(t, closed.Close())
+	_, err = mt.Dial(closed.NetAddress(), peerConfig{})
+	require.Error(t, err)
+
+	// nor can a transport dial itself
+	go func() { _, _ = mt.Accept(peerConfig{}) }()
+	_, err = mt.Dial(mt.NetAddress(), peerConfig{})
+	require.Error(t, err)
+	rejected, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %T", err)
+	assert.True(t, rejected.IsSelf())
+}
+
+func TestMemoryTransportListenTwice(t *testing.T) {
+	network := NewMemoryNetwork()
+	mt := newTestMemoryTransport(t, network)
+
+	other := NewMemoryTransport(network, mt.nodeInfo, mt.nodeKey, conn.DefaultMConnConfig())
+	require.Error(t, other.Listen(mt.NetAddress()))
+}
+
+func TestMemoryConnLatency(t *testing.T) {
+	const latency = 50 * time.Millisecond
+	network := NewMemoryNetwork(MemoryNetworkLink(MemoryLinkConfig{Latency: latency}))
+	a, b := newMemoryConnPair(network, NetAddress{ID: "a"}, NetAddress{ID: "b"})
+
+	start := time.Now()
+	_, err := a.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(b, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(latency))
+}
+
+func TestMemoryConnBandwidth(t *testing.T) {
+	// 2 writes of 1000 bytes take 200ms to transmit at 10KB/s
+	network := NewMemoryNetwork(MemoryNetworkLink(MemoryLinkConfig{Bandwidth: 10000}))
+	a, b := newMemoryConnPair(network, NetAddress{ID: "a"}, NetAddress{ID: "b"})
+
+	start := time.Now()
+	data := bytes.Repeat([]byte{0x01}, 1000)
+	for i := 0; i < 2; i++ {
+		_, err := a.Write(data)
+		require.NoError(t, err)
+	}
+
+	buf := make([]byte, 2000)
+	_, err := io.ReadFull(b, buf)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(200*time.Millisecond))
+}
+
+func TestMemoryConnBandwidthBlocksWriter(t *testing.T) {
+	// the link takes 100ms to transmit a full send buffer
+	network := NewMemoryNetwork(MemoryNetworkLink(MemoryLinkConfig{Bandwidth: 10 * memorySendBuffer}))
+	a, _ := newMemoryConnPair(network, NetAddress{ID: "a"}, NetAddress{ID: "b"})
+
+	start := time.Now()
+	data := bytes.Repeat([]byte{0x01}, memorySendBuffer)
+	for i := 0; i < 4; i++ {
+		_, err := a.Write(data)
+		require.NoError(t, err)
+	}
+	// the last write waited until the link transmitted the first two
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(200*time.Millisecond))
+
+	// writes time out while the send buffer is full
+	require.NoError(t, a.SetWriteDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err := a.Write(data)
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+}
+
+func TestMemoryConnLossKeepsStreamIntact(t *testing.T) {
+	network := NewMemoryNetwork(
+		MemoryNetworkLink(MemoryLinkConfig{LossRate: 0.5, RetransmitTimeout: time.Millisecond}),
+		MemoryNetworkSeed(42),
+	)
+	a, b := newMemoryConnPair(network, NetAddress{ID: "a"}, NetAddress{ID: "b"})
+
+	var sent bytes.Buffer
+	for i := 0; i < 100; i++ {
+		_, err := a.Write([]byte{byte(i)})
+		require.NoError(t, err)
+		sent.WriteByte(byte(i))
+	}
+	require.NoError(t, a.Close())
+
+	received, err := io.ReadAll(b)
+	require.NoError(t, err)
+	assert.Equal(t, sent.Bytes(), received)
+}
+
+func TestMemoryConnSetLink(t *testing.T) {
+	network := NewMemoryNetwork()
+	a, b := newMemoryConnPair(network, NetAddress{ID: "a"}, NetAddress{ID: "b"})
+
+	require.Error(t, network.SetLink("a", "b", MemoryLinkConfig{LossRate: 1}))
+	// the link is symmetric and applies to existing connections
+	require.NoError(t, network.SetLink("b", "a", MemoryLinkConfig{Latency: time.Hour}))
+
+	_, err := a.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	require.NoError(t, b.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, err = b.Read(make([]byte, 4))
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+}
+
+func TestMemoryConnClose(t *testing.T) {
+	network := NewMemoryNetwork()
+	a, b := newMemoryConnPair(network, NetAddress{ID: "a"}, NetAddress{ID: "b"})
+
+	_, err := a.Write([]byte("bye"))
+	require.NoError(t, err)
+	require.NoError(t, a.Close())
+
+	// data written before closing is still delivered
+	received, err := io.ReadAll(b)
+	require.NoError(t, err)
+	assert.Equal(t, "bye", string(received))
+
+	_, err = b.Write([]byte("hello?"))
+	assert.Equal(t, io.ErrClosedPipe, err)
+	_, err = a.Read(make([]byte, 1))
+	assert.Equal(t, io.ErrClosedPipe, err)
+}
+
+func TestMemoryLinkConfigValidateBasic(t *testing.T) {
+	testCases := []struct {
+		cfg     MemoryLinkConfig
+		wantErr bool
+	}{
+		{MemoryLinkConfig{}, false},
+		{MemoryLinkConfig{Latency: time.Second, Bandwidth: 1024, LossRate: 0.1}, false},
+		{MemoryLinkConfig{Latency: -1}, true},
+		{MemoryLinkConfig{Bandwidth: -1}, true},
+		{MemoryLinkConfig{LossRate: -0.1}, true},
+		{MemoryLinkConfig{LossRate: 1}, true},
+		{MemoryLinkConfig{RetransmitTimeout: -1}, true},
+	}
+	for _, tc := range testCases {
+		err := tc.cfg.ValidateBasic()
+		if tc.wantErr {
+			assert.Error(t, err, "%+v", tc.cfg)
+		} else {
+			assert.NoError(t, err, "%+v", tc.cfg)
+		}
+	}
+}
+
+func TestMemorySwitches(t *testing.T) {
+	network := NewMemoryNetwork(MemoryNetworkLink(MemoryLinkConfig{
+		Latency:   10 * time.Millisecond,
+		Bandwidth: 1 << 20,
+		LossRate:  0.1,
+	}))
+
+	const n = 3
+	switches := MakeConnectedMemorySwitches(network, config.TestP2PConfig(), n, initSwitchFunc, ConnectMemorySwitches)
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	for _, sw := range switches {
+		assert.Equal(t, n-1, sw.Peers().Size())
+	}
+
+	msg := []byte("over the memory network")
+	switches[0].Broadcast(byte(0x00), msg)
+	for _, sw := range switches[1:] {
+		assertMsgReceivedWithTimeout(t, msg, byte(0x00), sw.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+	}
+}
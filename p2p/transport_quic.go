@@ -0,0 +1,398 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lucas-clemente/quic-go"
+
+	"github.com/lazyledger/lazyledger-core/crypto/ed25519"
+	"github.com/lazyledger/lazyledger-core/p2p/conn"
+)
+
+const (
+	// quicALPN is the application protocol negotiated by QUIC peers.
+	quicALPN = "tendermint/p2p"
+
+	// quicMaxIdleTimeout is how long a QUIC session may stay idle before it's
+	// closed. Keep alives are sent to prevent idle peers from timing out.
+	quicMaxIdleTimeout = 30 * time.Second
+
+	// quicErrorCode is the application error code sent when closing sessions.
+	quicErrorCode quic.ErrorCode = 0
+)
+
+// QUICTransportOption sets an optional parameter on the QUICTransport.
+type QUICTransportOption func(*QUICTransport)
+
+// QUICTransportConnFilters sets the filters for rejection new connections.
+func QUICTransportConnFilters(filters ...ConnFilterFunc) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.connFilters = filters }
+}
+
+// QUICTransportFilterTimeout sets the timeout waited for filter calls to
+// return.
+func QUICTransportFilterTimeout(timeout time.Duration) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.filterTimeout = timeout }
+}
+
+// QUICTransportMaxIncomingConnections sets the maximum number of
+// simultaneous connections (incoming). Default: 0 (unlimited)
+func QUICTransportMaxIncomingConnections(n int) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.maxIncomingConnections = int32(n) }
+}
+
+// QUICTransport accepts and dials QUIC sessions and upgrades them to peers
+// sending the messages of every channel on a stream of its own, so a slow
+// channel does not block the others.
+//
+// The TLS identity of the node is a self-signed certificate for the node key,
+// which must be an ed25519 key. All the nodes of a network must use the same
+// transport, as QUIC runs over UDP on the listen port.
+type QUICTransport struct {
+	netAddr                NetAddress
+	listener               quic.Listener
+	maxIncomingConnections int32 // see MaxIncomingConnections
+	incomingConnections    int32
+
+	acceptc   chan accept
+	closec    chan struct{}
+	closeOnce sync.Once
+
+	// Lookup table for duplicate ip and id checks.
+	conns       ConnSet
+	connFilters []ConnFilterFunc
+
+	dialTimeout      time.Duration
+	filterTimeout    time.Duration
+	handshakeTimeout time.Duration
+	nodeInfo         NodeInfo
+	nodeKey          NodeKey
+	resolver         IPResolver
+	tlsConfig        *tls.Config
+
+	mConfig conn.MConnConfig
+}
+
+// Test QUICTransport for interface completeness.
+var _ Transport = (*QUICTransport)(nil)
+var _ transportLifecycle = (*QUICTransport)(nil)
+
+// NewQUICTransport returns a QUIC transport using the node key as TLS
+// identity. It fails if the node key is not an ed25519 key.
+func NewQUICTransport(
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	mConfig conn.MConnConfig,
+) (*QUICTransport, error) {
+	tlsConfig, err := newQUICTLSConfig(nodeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QUICTransport{
+		acceptc:          make(chan accept),
+		closec:           make(chan struct{}),
+		dialTimeout:      defaultDialTimeout,
+		filterTimeout:    defaultFilterTimeout,
+		handshakeTimeout: defaultHandshakeTimeout,
+		mConfig:          mConfig,
+		nodeInfo:         nodeInfo,
+		nodeKey:          nodeKey,
+		conns:            NewConnSet(),
+		resolver:         net.DefaultResolver,
+		tlsConfig:        tlsConfig,
+	}, nil
+}
+
+// NetAddress implements Transport.
+func (qt *QUICTransport) NetAddress() NetAddress {
+	return qt.netAddr
+}
+
+// Accept implements Transport.
+func (qt *QUICTransport) Accept(cfg peerConfig) (Peer, error) {
+	select {
+	case a := <-qt.acceptc:
+		if a.err != nil {
+			return nil, a.err
+		}
+
+		cfg.outbound = false
+
+		return newQUICPeer(a.conn.(*quicConn), a.nodeInfo, cfg, a.netAddr, qt.mConfig), nil
+	case <-qt.closec:
+		return nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements Transport.
+func (qt *QUICTransport) Dial(addr NetAddress, cfg peerConfig) (Peer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), qt.dialTimeout)
+	defer cancel()
+
+	sess, err := quic.DialAddrContext(ctx, addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	c, nodeInfo, err := qt.upgrade(sess, &addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return newQUICPeer(c, nodeInfo, cfg, &addr, qt.mConfig), nil
+}
+
+// Close implements transportLifecycle.
+func (qt *QUICTransport) Close() error {
+	var err error
+	qt.closeOnce.Do(func() {
+		close(qt.closec)
+
+		if qt.listener != nil {
+			err = qt.listener.Close()
+		}
+	})
+	return err
+}
+
+// Listen implements transportLifecycle.
+func (qt *QUICTransport) Listen(addr NetAddress) error {
+	ln, err := quic.ListenAddr(addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return err
+	}
+
+	// Set the port if we listened on an arbitrary one.
+	if addr.Port == 0 {
+		addr.Port = uint16(ln.Addr().(*net.UDPAddr).Port)
+	}
+
+	qt.netAddr = addr
+	qt.listener = ln
+
+	go qt.acceptPeers()
+
+	return nil
+}
+
+// Cleanup removes the given address from the connections set and
+// closes the session.
+func (qt *QUICTransport) Cleanup(p Peer) {
+	if !p.IsOutbound() {
+		atomic.AddInt32(&qt.incomingConnections, -1)
+	}
+	qt.conns.RemoveAddr(p.RemoteAddr())
+	_ = p.CloseConn()
+}
+
+func (qt *QUICTransport) acceptPeers() {
+	for {
+		sess, err := qt.listener.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case <-qt.closec:
+				return
+			default:
+			}
+
+			qt.acceptc <- accept{err: err}
+			return
+		}
+
+		if max := qt.maxIncomingConnections; max > 0 &&
+			atomic.LoadInt32(&qt.incomingConnections) >= max {
+			_ = sess.CloseWithError(quicErrorCode, "too many connections")
+			continue
+		}
+		atomic.AddInt32(&qt.incomingConnections, 1)
+
+		// Upgrade the sessions asynchronously to avoid head-of-line blocking.
+		go func(sess quic.Session) {
+			var netAddr *NetAddress
+
+			c, nodeInfo, err := qt.upgrade(sess, nil)
+			if err == nil {
+				netAddr = NewNetAddress(nodeInfo.ID(), sess.RemoteAddr())
+			} else {
+				atomic.AddInt32(&qt.incomingConnections, -1)
+			}
+
+			select {
+			case qt.acceptc <- accept{netAddr, c, nodeInfo, err}:
+				// Make the upgraded peer available.
+			case <-qt.closec:
+				// Give up if the transport was closed.
+				_ = sess.CloseWithError(quicErrorCode, "transport closed")
+			}
+		}(sess)
+	}
+}
+
+// upgrade authenticates the session using the peer's TLS certificate, filters
+// it and exchanges NodeInfo with the peer over the first stream of the
+// session, which the dialer opens. For outgoing sessions, dialedAddr must be
+// set to ensure we reached the peer we intended to.
+func (qt *QUICTransport) upgrade(
+	sess quic.Session,
+	dialedAddr *NetAddress,
+) (_ *quicConn, nodeInfo NodeInfo, err error) {
+	c := &quicConn{sess: sess}
+	defer func() {
+		if err != nil {
+			qt.conns.Remove(c)
+			_ = c.Close()
+		}
+	}()
+
+	connID, err := quicPeerID(sess.ConnectionState().PeerCertificates)
+	if err != nil {
+		return nil, nil, ErrRejected{conn: c, err: err, isAuthFailure: true}
+	}
+	if err := checkDialedID(c, connID, dialedAddr); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), qt.handshakeTimeout)
+	defer cancel()
+	if dialedAddr != nil {
+		c.Stream, err = sess.OpenStreamSync(ctx)
+	} else {
+		c.Stream, err = sess.AcceptStream(ctx)
+	}
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake stream failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	// TODO(xla): Evaluate if we should apply filters if we explicitly dial.
+	if err := filterConn(c, qt.conns, qt.connFilters, qt.resolver, qt.filterTimeout); err != nil {
+		return nil, nil, err
+	}
+
+	nodeInfo, err = handshake(c, qt.handshakeTimeout, qt.nodeInfo)
+	if err != nil {
+		return nil, nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake failed: %v", err),
+			isAuthFailure: true,
+		}
+	}
+
+	if err := checkPeerNodeInfo(c, connID, nodeInfo, qt.nodeInfo); err != nil {
+		return nil, nil, err
+	}
+
+	return c, nodeInfo, nil
+}
+
+func (qt *QUICTransport) quicConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeTimeout: qt.handshakeTimeout,
+		MaxIdleTimeout:   quicMaxIdleTimeout,
+		KeepAlive:        true,
+	}
+}
+
+// quicConn is a QUIC session along with the stream used for the NodeInfo
+// handshake. It implements net.Conn for the handshake and connection filters.
+type quicConn struct {
+	quic.Stream
+	sess quic.Session
+}
+
+var _ net.Conn = (*quicConn)(nil)
+
+// LocalAddr implements net.Conn.
+func (c *quicConn) LocalAddr() net.Addr { return c.sess.LocalAddr() }
+
+// RemoteAddr implements net.Conn.
+func (c *quicConn) RemoteAddr() net.Addr { return c.sess.RemoteAddr() }
+
+// Close closes the whole session.
+func (c *quicConn) Close() error {
+	return c.sess.CloseWithError(quicErrorCode, "")
+}
+
+// newQUICTLSConfig returns a TLS config presenting a self-signed certificate
+// for the node key. Peers are authenticated by their certificate's key, so no
+// certificate authority is involved.
+func newQUICTLSConfig(nodeKey NodeKey) (*tls.Config, error) {
+	privKey, ok := nodeKey.PrivKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("QUIC transport requires an ed25519 node key, got %s", nodeKey.PrivKey.Type())
+	}
+	key := stded25519.PrivateKey(privKey.Bytes())
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(100, 0, 0),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{{Certificate: [][]byte{certDER}, PrivateKey: key}},
+		ClientAuth:   tls.RequireAnyClientCert,
+		// The certificate chain is verified by VerifyPeerCertificate instead.
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			certs := make([]*x509.Certificate, len(rawCerts))
+			for i, raw := range rawCerts {
+				cert, err := x509.ParseCertificate(raw)
+				if err != nil {
+					return err
+				}
+				certs[i] = cert
+			}
+			_, err := quicPeerID(certs)
+			return err
+		},
+		NextProtos: []string{quicALPN},
+	}, nil
+}
+
+// quicPeerID returns the ID of the peer presenting the given certificates,
+// which must be a single valid self-signed certificate for an ed25519 key.
+func quicPeerID(certs []*x509.Certificate) (ID, error) {
+	if len(certs) != 1 {
+		return "", fmt.Errorf("expected one certificate, got %d", len(certs))
+	}
+	cert := certs[0]
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return "", errors.New("certificate expired or not yet valid")
+	}
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		return "", fmt.Errorf("invalid certificate signature: %w", err)
+	}
+
+	pubKey, ok := cert.PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an ed25519 certificate key, got %T", cert.PublicKey)
+	}
+
+	return PubKeyToID(ed25519.PubKey(pubKey)), nil
+}
@@ -0,0 +1,219 @@
+package p2p
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/crypto/secp256k1"
+	"github.com/lazyledger/lazyledger-core/p2p/conn"
+)
+
+func newTestQUICTransport(t *testing.T) *QUICTransport {
+	nodeKey := GenNodeKey()
+	nodeInfo := testNodeInfo(nodeKey.ID, "quic")
+	qt, err := NewQUICTransport(nodeInfo, nodeKey, conn.DefaultMConnConfig())
+	require.NoError(t, err)
+
+	addr, err := NewNetAddressString(IDAddressString(nodeKey.ID, nodeInfo.(DefaultNodeInfo).ListenAddr))
+	require.NoError(t, err)
+	require.NoError(t, qt.Listen(*addr))
+	t.Cleanup(func() { _ = qt.Close() })
+
+	return qt
+}
+
+// makeQUICSwitchPair creates two switches using QUIC transports over loopback
+// and connects them.
+func makeQUICSwitchPair(t *testing.T, initSwitch func(int, *Switch) *Switch) (*Switch, *Switch) {
+	switches := make([]*Switch, 2)
+	for i := range switches {
+		nodeKey := GenNodeKey()
+		nodeInfo := testNodeInfo(nodeKey.ID, fmt.Sprintf("node%d", i))
+		qt, err := NewQUICTransport(nodeInfo, nodeKey, MConnConfig(cfg))
+		require.NoError(t, err)
+
+		switches[i] = makeSwitch(cfg, i, qt, &qt.nodeInfo, nodeKey, nodeInfo, initSwitch)
+	}
+	require.NoError(t, StartSwitches(switches))
+	t.Cleanup(func() {
+		for _, sw := range switches {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	s1, s2 := switches[0], switches[1]
+	require.NoError(t, s1.DialPeerWithAddress(s2.NetAddress()))
+	require.Eventually(t, func() bool {
+		return s1.Peers().Has(s2.NodeInfo().ID()) && s2.Peers().Has(s1.NodeInfo().ID())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return s1, s2
+}
+
+func TestQUICTransportDialAccept(t *testing.T) {
+	qt1 := newTestQUICTransport(t)
+	qt2 := newTestQUICTransport(t)
+
+	acceptc := make(chan Peer, 1)
+	go func() {
+		p, err := qt2.Accept(peerConfig{})
+		require.NoError(t, err)
+		acceptc <- p
+	}()
+
+	p, err := qt1.Dial(qt2.NetAddress(), peerConfig{})
+	require.NoError(t, err)
+	assert.True(t, p.IsOutbound())
+	assert.Equal(t, qt2.NetAddress().ID, p.ID())
+
+	select {
+	case p := <-acceptc:
+		assert.False(t, p.IsOutbound())
+		assert.Equal(t, qt1.NetAddress().ID, p.ID())
+		assert.Equal(t, "127.0.0.1", p.RemoteIP().String())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the dialed peer")
+	}
+}
+
+func TestQUICTransportDialWrongID(t *testing.T) {
+	qt1 := newTestQUICTransport(t)
+	qt2 := newTestQUICTransport(t)
+
+	go func() { _, _ = qt2.Accept(peerConfig{}) }()
+
+	addr := qt2.NetAddress()
+	addr.ID = GenNodeKey().ID
+	_, err := qt1.Dial(addr, peerConfig{})
+	require.Error(t, err)
+	rejected, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %T", err)
+	assert.True(t, rejected.IsAuthFailure())
+}
+
+func TestQUICTransportRequiresEd25519NodeKey(t *testing.T) {
+	nodeKey := NodeKey{PrivKey: secp256k1.GenPrivKey()}
+	nodeKey.ID = PubKeyToID(nodeKey.PrivKey.PubKey())
+
+	_, err := NewQUICTransport(testNodeInfo(nodeKey.ID, "quic"), nodeKey, conn.DefaultMConnConfig())
+	require.Error(t, err)
+}
+
+func TestQUICSwitches(t *testing.T) {
+	s1, s2 := makeQUICSwitchPair(t, initSwitchFunc)
+
+	ch0Msg := []byte("channel zero")
+	ch2Msg := []byte("channel two")
+	s1.Broadcast(byte(0x00), ch0Msg)
+	s1.Broadcast(byte(0x02), ch2Msg)
+
+	assertMsgReceivedWithTimeout(t, ch0Msg, byte(0x00), s2.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+	assertMsgReceivedWithTimeout(t, ch2Msg, byte(0x02), s2.Reactor("bar").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+}
+
+// blockingReactor blocks receiving until released.
+type blockingReactor struct {
+	*TestReactor
+	release chan struct{}
+}
+
+func (br *blockingReactor) Receive(chID byte, peer Peer, msgBytes []byte) {
+	<-br.release
+	br.TestReactor.Receive(chID, peer, msgBytes)
+}
+
+func TestQUICPeerChannelsDoNotBlockEachOther(t *testing.T) {
+	blocking := &blockingReactor{
+		TestReactor: NewTestReactor([]*conn.ChannelDescriptor{{ID: byte(0x05), Priority: 10}}, true),
+		release:     make(chan struct{}),
+	}
+	defer close(blocking.release)
+
+	s1, s2 := makeQUICSwitchPair(t, func(i int, sw *Switch) *Switch {
+		sw = initSwitchFunc(i, sw)
+		sw.AddReactor("blocking", blocking)
+		return sw
+	})
+
+	// the messages of the blocked channel must not hold up the other channel
+	for i := 0; i < 10; i++ {
+		s1.Broadcast(byte(0x05), []byte("blocked"))
+	}
+	msg := []byte("not blocked")
+	s1.Broadcast(byte(0x00), msg)
+
+	assertMsgReceivedWithTimeout(t, msg, byte(0x00), s2.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+}
+
+func TestQUICPeerFlushStop(t *testing.T) {
+	s1, s2 := makeQUICSwitchPair(t, initSwitchFunc)
+	peer := s1.Peers().Get(s2.NodeInfo().ID())
+	require.NotNil(t, peer)
+
+	const n = 100
+	for i := 0; i < n; i++ {
+		require.True(t, peer.Send(byte(0x00), []byte{byte(i)}))
+	}
+	peer.FlushStop()
+
+	reactor := s2.Reactor("foo").(*TestReactor)
+	assert.Eventually(t, func() bool { return len(reactor.getMsgs(byte(0x00))) == n }, 5*time.Second, 10*time.Millisecond)
+	// the remote peer disconnects once it received everything
+	assert.Eventually(t, func() bool { return s2.Peers().Size() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestQUICPeerDuplicateStream(t *testing.T) {
+	s1, s2 := makeQUICSwitchPair(t, initSwitchFunc)
+	peer := s1.Peers().Get(s2.NodeInfo().ID())
+	require.NotNil(t, peer)
+	// make sure the stream of the channel is open already
+	require.True(t, peer.Send(byte(0x00), []byte("first")))
+	assertMsgReceivedWithTimeout(t, []byte("first"), byte(0x00), s2.Reactor("foo").(*TestReactor),
+		10*time.Millisecond, 5*time.Second)
+
+	// a second stream for the same channel disconnects the peer
+	stream, err := peer.(*quicPeer).conn.sess.OpenUniStreamSync(context.Background())
+	require.NoError(t, err)
+	_, err = stream.Write([]byte{0x00})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return s2.Peers().Size() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestQUICPeerID(t *testing.T) {
+	nodeKey := GenNodeKey()
+	tlsConfig, err := newQUICTLSConfig(nodeKey)
+	require.NoError(t, err)
+	require.Len(t, tlsConfig.Certificates, 1)
+
+	rawCerts := tlsConfig.Certificates[0].Certificate
+	require.NoError(t, tlsConfig.VerifyPeerCertificate(rawCerts, nil))
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	require.NoError(t, err)
+	id, err := quicPeerID([]*x509.Certificate{cert})
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.ID, id)
+
+	// certificates not signed by their own key are rejected
+	other, err := newQUICTLSConfig(GenNodeKey())
+	require.NoError(t, err)
+	otherCert, err := x509.ParseCertificate(other.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	forged := *cert
+	forged.Signature = otherCert.Signature
+	_, err = quicPeerID([]*x509.Certificate{&forged})
+	assert.Error(t, err)
+
+	// so are certificate chains
+	_, err = quicPeerID([]*x509.Certificate{cert, otherCert})
+	assert.Error(t, err)
+	assert.Error(t, tlsConfig.VerifyPeerCertificate(nil, nil))
+}
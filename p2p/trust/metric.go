@@ -172,6 +172,14 @@ func (tm *Metric) Pause() {
 	tm.paused = true
 }
 
+// isPaused returns whether the metric is paused, i.e. the peer disconnected
+func (tm *Metric) isPaused() bool {
+	tm.mtx.Lock()
+	defer tm.mtx.Unlock()
+
+	return tm.paused
+}
+
 // BadEvents indicates that an undesirable event(s) took place
 func (tm *Metric) BadEvents(num int) {
 	tm.mtx.Lock()
@@ -13,7 +13,12 @@ import (
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 )
 
-const defaultStorePeriodicSaveInterval = 1 * time.Minute
+const (
+	defaultStorePeriodicSaveInterval = 1 * time.Minute
+
+	// Above this number of trust metrics, the metrics of disconnected peers are pruned
+	defaultStoreMaxPeerMetrics = 1000
+)
 
 var trustMetricKey = []byte("trustMetricStore")
 
@@ -107,6 +112,10 @@ func (tms *MetricStore) GetPeerTrustMetric(key string) *Metric {
 
 	tm, ok := tms.peerMetrics[key]
 	if !ok {
+		// Make room for the metric by forgetting a disconnected peer
+		if tms.size() >= defaultStoreMaxPeerMetrics {
+			tms.prune()
+		}
 		// If the metric is not available, we will create it
 		tm = NewMetricWithConfig(tms.config)
 		if err := tm.Start(); err != nil {
@@ -118,6 +127,19 @@ func (tms *MetricStore) GetPeerTrustMetric(key string) *Metric {
 	return tm
 }
 
+// PeerTrustScore returns the trust score of the peer identified by the key, without
+// creating a trust metric for it. Peers without a metric have the score of a new metric.
+func (tms *MetricStore) PeerTrustScore(key string) int {
+	tms.mtx.Lock()
+	tm, ok := tms.peerMetrics[key]
+	tms.mtx.Unlock()
+
+	if !ok {
+		tm = NewMetricWithConfig(tms.config)
+	}
+	return tm.TrustScore()
+}
+
 // PeerDisconnected pauses the trust metric associated with the peer identified by the key
 func (tms *MetricStore) PeerDisconnected(key string) {
 	tms.mtx.Lock()
@@ -145,6 +167,32 @@ func (tms *MetricStore) size() int {
 	return len(tms.peerMetrics)
 }
 
+// prune removes the paused metric with the highest trust value, as the history of
+// the least trusted peers is the one worth keeping. Metrics which aren't paused
+// belong to connected peers, and are kept.
+func (tms *MetricStore) prune() {
+	var (
+		pruneKey   string
+		pruneValue = -1.0
+	)
+	for key, tm := range tms.peerMetrics {
+		if !tm.isPaused() {
+			continue
+		}
+		if value := tm.TrustValue(); value > pruneValue {
+			pruneKey, pruneValue = key, value
+		}
+	}
+	if pruneKey == "" {
+		return
+	}
+
+	if err := tms.peerMetrics[pruneKey].Stop(); err != nil {
+		tms.Logger.Error("unable to stop metric", "error", err)
+	}
+	delete(tms.peerMetrics, pruneKey)
+}
+
 /* Loading & Saving */
 /* Both loadFromDB and savetoDB assume the mutex has been acquired */
 
@@ -159,3 +159,57 @@ func TestTrustMetricStorePeerScore(t *testing.T) {
 	err = store.Stop()
 	require.NoError(t, err)
 }
+
+func TestTrustMetricStorePeerTrustScore(t *testing.T) {
+	historyDB := memdb.NewDB()
+
+	store := NewTrustMetricStore(historyDB, DefaultConfig())
+	store.SetLogger(log.TestingLogger())
+	err := store.Start()
+	require.NoError(t, err)
+
+	// Unknown peers have a perfect score, without getting a metric
+	assert.Equal(t, 100, store.PeerTrustScore("TestKey"))
+	assert.Zero(t, store.Size())
+
+	tm := store.GetPeerTrustMetric("TestKey")
+	tm.BadEvents(10)
+	assert.Equal(t, tm.TrustScore(), store.PeerTrustScore("TestKey"))
+	assert.NotEqual(t, 100, store.PeerTrustScore("TestKey"))
+	assert.Equal(t, 1, store.Size())
+
+	err = store.Stop()
+	require.NoError(t, err)
+}
+
+func TestTrustMetricStorePrune(t *testing.T) {
+	historyDB := memdb.NewDB()
+
+	store := NewTrustMetricStore(historyDB, DefaultConfig())
+	store.SetLogger(log.TestingLogger())
+	err := store.Start()
+	require.NoError(t, err)
+
+	// Fill the store with connected peers, which are never pruned
+	for i := 0; i < defaultStoreMaxPeerMetrics; i++ {
+		store.GetPeerTrustMetric(fmt.Sprintf("peer_%d", i))
+	}
+	store.GetPeerTrustMetric("connected")
+	assert.Equal(t, defaultStoreMaxPeerMetrics+1, store.Size())
+
+	// Once disconnected, the most trusted peers are forgotten first
+	store.GetPeerTrustMetric("peer_0").BadEvents(10)
+	store.PeerDisconnected("peer_0")
+	store.PeerDisconnected("peer_1")
+	store.GetPeerTrustMetric("new_peer")
+	assert.Equal(t, defaultStoreMaxPeerMetrics+1, store.Size())
+	assert.Contains(t, store.peerMetrics, "peer_0")
+	assert.NotContains(t, store.peerMetrics, "peer_1")
+
+	store.GetPeerTrustMetric("other_peer")
+	assert.Equal(t, defaultStoreMaxPeerMetrics+1, store.Size())
+	assert.NotContains(t, store.peerMetrics, "peer_0")
+
+	err = store.Stop()
+	require.NoError(t, err)
+}
@@ -1,4 +1,4 @@
-package blockchain
+package blockchain_test
 
 import (
 	"encoding/hex"
@@ -13,6 +13,12 @@ import (
 	"github.com/lazyledger/lazyledger-core/types"
 )
 
+func wrapAndValidate(t *testing.T, pb proto.Message) error {
+	msg := new(bcproto.Message)
+	require.NoError(t, msg.Wrap(pb))
+	return msg.Validate()
+}
+
 func TestBcBlockRequestMessageValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName      string
@@ -28,7 +34,7 @@ func TestBcBlockRequestMessageValidateBasic(t *testing.T) {
 		tc := tc
 		t.Run(tc.testName, func(t *testing.T) {
 			request := bcproto.BlockRequest{Height: tc.requestHeight}
-			assert.Equal(t, tc.expectErr, ValidateMsg(&request) != nil, "Validate Basic had an unexpected result")
+			assert.Equal(t, tc.expectErr, wrapAndValidate(t, &request) != nil, "Validate Basic had an unexpected result")
 		})
 	}
 }
@@ -48,14 +54,14 @@ func TestBcNoBlockResponseMessageValidateBasic(t *testing.T) {
 		tc := tc
 		t.Run(tc.testName, func(t *testing.T) {
 			nonResponse := bcproto.NoBlockResponse{Height: tc.nonResponseHeight}
-			assert.Equal(t, tc.expectErr, ValidateMsg(&nonResponse) != nil, "Validate Basic had an unexpected result")
+			assert.Equal(t, tc.expectErr, wrapAndValidate(t, &nonResponse) != nil, "Validate Basic had an unexpected result")
 		})
 	}
 }
 
 func TestBcStatusRequestMessageValidateBasic(t *testing.T) {
 	request := bcproto.StatusRequest{}
-	assert.NoError(t, ValidateMsg(&request))
+	assert.NoError(t, wrapAndValidate(t, &request))
 }
 
 func TestBcStatusResponseMessageValidateBasic(t *testing.T) {
@@ -73,14 +79,37 @@ func TestBcStatusResponseMessageValidateBasic(t *testing.T) {
 		tc := tc
 		t.Run(tc.testName, func(t *testing.T) {
 			response := bcproto.StatusResponse{Height: tc.responseHeight}
-			assert.Equal(t, tc.expectErr, ValidateMsg(&response) != nil, "Validate Basic had an unexpected result")
+			assert.Equal(t, tc.expectErr, wrapAndValidate(t, &response) != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
+func TestBcLightBlocksRequestMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName  string
+		height    int64
+		count     int64
+		expectErr bool
+	}{
+		{"Valid Request Message", 1, 1, false},
+		{"Valid Request Message", 1, 100, false},
+		{"Invalid Request Message", -1, 1, true},
+		{"Invalid Request Message", 1, 0, true},
+		{"Invalid Request Message", 1, -1, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			request := bcproto.LightBlocksRequest{Height: tc.height, Count: tc.count}
+			assert.Equal(t, tc.expectErr, wrapAndValidate(t, &request) != nil, "Validate Basic had an unexpected result")
 		})
 	}
 }
 
 // nolint:lll // ignore line length in tests
 func TestBlockchainMessageVectors(t *testing.T) {
-	block := types.MakeBlock(int64(3), []types.Tx{types.Tx("Hello World")}, nil, nil, types.Messages{}, nil)
+	block := types.MakeBlock(int64(3), []types.Tx{types.Tx("Hello World")}, nil, types.IntermediateStateRoots{}, types.Messages{}, nil)
 	block.Version.Block = 11 // overwrite updated protocol version
 
 	bpb, err := block.ToProto()
@@ -112,6 +141,12 @@ func TestBlockchainMessageVectors(t *testing.T) {
 		{"StatusResponseMessage", &bcproto.Message{Sum: &bcproto.Message_StatusResponse{
 			StatusResponse: &bcproto.StatusResponse{Height: math.MaxInt64, Base: math.MaxInt64}}},
 			"2a1408ffffffffffffffff7f10ffffffffffffffff7f"},
+		{"LightBlocksRequestMessage", &bcproto.Message{Sum: &bcproto.Message_LightBlocksRequest{
+			LightBlocksRequest: &bcproto.LightBlocksRequest{Height: 1, Count: 2}}},
+			"320408011002"},
+		{"LightBlocksResponseMessage", &bcproto.Message{Sum: &bcproto.Message_LightBlocksResponse{
+			LightBlocksResponse: &bcproto.LightBlocksResponse{Height: 1}}},
+			"3a020801"},
 	}
 
 	for _, tc := range testCases {
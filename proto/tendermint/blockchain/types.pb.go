@@ -248,6 +248,115 @@ func (m *StatusResponse) GetBase() int64 {
 	return 0
 }
 
+// LightBlocksRequest requests the light blocks of count consecutive heights,
+// starting at a specific height.
+type LightBlocksRequest struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Count  int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *LightBlocksRequest) Reset()         { *m = LightBlocksRequest{} }
+func (m *LightBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*LightBlocksRequest) ProtoMessage()    {}
+func (*LightBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2927480384e78499, []int{5}
+}
+func (m *LightBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LightBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LightBlocksRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LightBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LightBlocksRequest.Merge(m, src)
+}
+func (m *LightBlocksRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LightBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LightBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LightBlocksRequest proto.InternalMessageInfo
+
+func (m *LightBlocksRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *LightBlocksRequest) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// LightBlocksResponse returns the requested light blocks the peer has, in order
+// of height. It is empty if the peer does not have the light block at the
+// requested height.
+type LightBlocksResponse struct {
+	Height      int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	LightBlocks []*types.LightBlock `protobuf:"bytes,2,rep,name=light_blocks,json=lightBlocks,proto3" json:"light_blocks,omitempty"`
+}
+
+func (m *LightBlocksResponse) Reset()         { *m = LightBlocksResponse{} }
+func (m *LightBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*LightBlocksResponse) ProtoMessage()    {}
+func (*LightBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2927480384e78499, []int{6}
+}
+func (m *LightBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LightBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LightBlocksResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LightBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LightBlocksResponse.Merge(m, src)
+}
+func (m *LightBlocksResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LightBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LightBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LightBlocksResponse proto.InternalMessageInfo
+
+func (m *LightBlocksResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *LightBlocksResponse) GetLightBlocks() []*types.LightBlock {
+	if m != nil {
+		return m.LightBlocks
+	}
+	return nil
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_BlockRequest
@@ -255,6 +364,8 @@ type Message struct {
 	//	*Message_BlockResponse
 	//	*Message_StatusRequest
 	//	*Message_StatusResponse
+	//	*Message_LightBlocksRequest
+	//	*Message_LightBlocksResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -262,7 +373,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2927480384e78499, []int{5}
+	return fileDescriptor_2927480384e78499, []int{7}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -312,12 +423,20 @@ type Message_StatusRequest struct {
 type Message_StatusResponse struct {
 	StatusResponse *StatusResponse `protobuf:"bytes,5,opt,name=status_response,json=statusResponse,proto3,oneof" json:"status_response,omitempty"`
 }
+type Message_LightBlocksRequest struct {
+	LightBlocksRequest *LightBlocksRequest `protobuf:"bytes,6,opt,name=light_blocks_request,json=lightBlocksRequest,proto3,oneof" json:"light_blocks_request,omitempty"`
+}
+type Message_LightBlocksResponse struct {
+	LightBlocksResponse *LightBlocksResponse `protobuf:"bytes,7,opt,name=light_blocks_response,json=lightBlocksResponse,proto3,oneof" json:"light_blocks_response,omitempty"`
+}
 
-func (*Message_BlockRequest) isMessage_Sum()    {}
-func (*Message_NoBlockResponse) isMessage_Sum() {}
-func (*Message_BlockResponse) isMessage_Sum()   {}
-func (*Message_StatusRequest) isMessage_Sum()   {}
-func (*Message_StatusResponse) isMessage_Sum()  {}
+func (*Message_BlockRequest) isMessage_Sum()        {}
+func (*Message_NoBlockResponse) isMessage_Sum()     {}
+func (*Message_BlockResponse) isMessage_Sum()       {}
+func (*Message_StatusRequest) isMessage_Sum()       {}
+func (*Message_StatusResponse) isMessage_Sum()      {}
+func (*Message_LightBlocksRequest) isMessage_Sum()  {}
+func (*Message_LightBlocksResponse) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -361,6 +480,20 @@ func (m *Message) GetStatusResponse() *StatusResponse {
 	return nil
 }
 
+func (m *Message) GetLightBlocksRequest() *LightBlocksRequest {
+	if x, ok := m.GetSum().(*Message_LightBlocksRequest); ok {
+		return x.LightBlocksRequest
+	}
+	return nil
+}
+
+func (m *Message) GetLightBlocksResponse() *LightBlocksResponse {
+	if x, ok := m.GetSum().(*Message_LightBlocksResponse); ok {
+		return x.LightBlocksResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -369,6 +502,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_BlockResponse)(nil),
 		(*Message_StatusRequest)(nil),
 		(*Message_StatusResponse)(nil),
+		(*Message_LightBlocksRequest)(nil),
+		(*Message_LightBlocksResponse)(nil),
 	}
 }
 
@@ -378,37 +513,45 @@ func init() {
 	proto.RegisterType((*BlockResponse)(nil), "tendermint.blockchain.BlockResponse")
 	proto.RegisterType((*StatusRequest)(nil), "tendermint.blockchain.StatusRequest")
 	proto.RegisterType((*StatusResponse)(nil), "tendermint.blockchain.StatusResponse")
+	proto.RegisterType((*LightBlocksRequest)(nil), "tendermint.blockchain.LightBlocksRequest")
+	proto.RegisterType((*LightBlocksResponse)(nil), "tendermint.blockchain.LightBlocksResponse")
 	proto.RegisterType((*Message)(nil), "tendermint.blockchain.Message")
 }
 
 func init() { proto.RegisterFile("tendermint/blockchain/types.proto", fileDescriptor_2927480384e78499) }
 
 var fileDescriptor_2927480384e78499 = []byte{
-	// 379 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xc1, 0x4e, 0xc2, 0x40,
-	0x10, 0x86, 0x5b, 0x0b, 0x98, 0x0c, 0x94, 0xc6, 0x26, 0x2a, 0x31, 0xa6, 0xd1, 0xaa, 0x44, 0x0f,
-	0xb4, 0x09, 0x5e, 0x8d, 0x89, 0x9c, 0x88, 0x09, 0xc6, 0x54, 0x4f, 0x7a, 0x20, 0x6d, 0xd9, 0xb4,
-	0x8d, 0xd0, 0xc5, 0xee, 0xf6, 0x80, 0x4f, 0xe1, 0x33, 0xf8, 0x34, 0x1e, 0x39, 0x7a, 0x34, 0xf0,
-	0x22, 0x86, 0xdd, 0x52, 0x4a, 0x03, 0xf5, 0xb6, 0x3b, 0xfd, 0xe7, 0x9b, 0x7f, 0xfe, 0x74, 0xe1,
-	0x94, 0xa2, 0x70, 0x80, 0xa2, 0x51, 0x10, 0x52, 0xd3, 0x19, 0x62, 0xf7, 0xcd, 0xf5, 0xed, 0x20,
-	0x34, 0xe9, 0x64, 0x8c, 0x88, 0x31, 0x8e, 0x30, 0xc5, 0xea, 0xfe, 0x4a, 0x62, 0xac, 0x24, 0x47,
-	0xc7, 0x99, 0x4e, 0x26, 0xe7, 0xfd, 0xbc, 0x49, 0x6f, 0x42, 0xad, 0xb3, 0xb8, 0x5a, 0xe8, 0x3d,
-	0x46, 0x84, 0xaa, 0x07, 0x50, 0xf1, 0x51, 0xe0, 0xf9, 0xb4, 0x21, 0x9e, 0x88, 0x97, 0x92, 0x95,
-	0xdc, 0xf4, 0x2b, 0x50, 0x1e, 0x70, 0xa2, 0x24, 0x63, 0x1c, 0x12, 0xb4, 0x55, 0x7a, 0x0b, 0xf2,
-	0xba, 0xb0, 0x05, 0x65, 0x36, 0x92, 0xe9, 0xaa, 0xed, 0x43, 0x23, 0x63, 0x94, 0x2f, 0xc0, 0xf5,
-	0x5c, 0xa5, 0x2b, 0x20, 0x3f, 0x51, 0x9b, 0xc6, 0x24, 0xf1, 0xa4, 0xdf, 0x40, 0x7d, 0x59, 0x28,
-	0x1e, 0xad, 0xaa, 0x50, 0x72, 0x6c, 0x82, 0x1a, 0x3b, 0xac, 0xca, 0xce, 0xfa, 0x97, 0x04, 0xbb,
-	0x3d, 0x44, 0x88, 0xed, 0x21, 0xf5, 0x1e, 0x64, 0x36, 0xa3, 0x1f, 0x71, 0x74, 0xe2, 0xe8, 0xcc,
-	0xd8, 0x18, 0x9d, 0x91, 0x4d, 0xa6, 0x2b, 0x58, 0x35, 0x27, 0x9b, 0xd4, 0x33, 0xec, 0x85, 0xb8,
-	0xbf, 0xc4, 0x71, 0x63, 0x6c, 0x70, 0xb5, 0xdd, 0xdc, 0xc2, 0xcb, 0x25, 0xd8, 0x15, 0x2c, 0x25,
-	0xcc, 0x85, 0xda, 0x83, 0x7a, 0x0e, 0x29, 0x31, 0xe4, 0x79, 0xb1, 0xc5, 0x14, 0x28, 0x3b, 0x79,
-	0x1c, 0x61, 0xd1, 0xa5, 0x1b, 0x97, 0x0a, 0x71, 0x6b, 0xc1, 0x2f, 0x70, 0x24, 0x5b, 0x50, 0x1f,
-	0x41, 0x49, 0x71, 0x89, 0xbd, 0x32, 0xe3, 0x5d, 0xfc, 0xc3, 0x4b, 0xfd, 0xd5, 0xc9, 0x5a, 0xa5,
-	0x53, 0x06, 0x89, 0xc4, 0xa3, 0xce, 0xeb, 0xf7, 0x4c, 0x13, 0xa7, 0x33, 0x4d, 0xfc, 0x9d, 0x69,
-	0xe2, 0xe7, 0x5c, 0x13, 0xa6, 0x73, 0x4d, 0xf8, 0x99, 0x6b, 0xc2, 0xcb, 0x9d, 0x17, 0x50, 0x3f,
-	0x76, 0x0c, 0x17, 0x8f, 0xcc, 0xa1, 0xfd, 0x31, 0x19, 0xa2, 0x81, 0x87, 0xa2, 0xcc, 0xb1, 0xe5,
-	0xe2, 0x08, 0x99, 0xec, 0x6f, 0x36, 0x37, 0x3e, 0x12, 0xa7, 0xc2, 0x3e, 0x5e, 0xff, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0x4e, 0xb2, 0xd6, 0x72, 0x44, 0x03, 0x00, 0x00,
+	// 478 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x86, 0xed, 0xe6, 0x52, 0xe9, 0xe4, 0x26, 0xa6, 0x2d, 0x44, 0xa8, 0xb2, 0x8a, 0x81, 0xaa,
+	0x45, 0xaa, 0x2d, 0x85, 0x2d, 0x02, 0x91, 0x55, 0x84, 0x28, 0x42, 0x86, 0x15, 0x08, 0x05, 0xdb,
+	0x1d, 0x25, 0x11, 0xce, 0x4c, 0xf0, 0x8c, 0x17, 0xe5, 0x29, 0x78, 0x2c, 0x96, 0x5d, 0xb2, 0x44,
+	0xc9, 0x8b, 0xa0, 0x9c, 0x71, 0x9c, 0xb1, 0x9d, 0x4b, 0x77, 0xe3, 0x33, 0xff, 0x7c, 0xf3, 0xff,
+	0x3e, 0x47, 0x03, 0x4f, 0x24, 0x65, 0x37, 0x34, 0x9e, 0x4e, 0x98, 0x74, 0x83, 0x88, 0x87, 0x3f,
+	0xc2, 0xb1, 0x3f, 0x61, 0xae, 0xbc, 0x9d, 0x51, 0xe1, 0xcc, 0x62, 0x2e, 0x39, 0x39, 0x59, 0x4b,
+	0x9c, 0xb5, 0xe4, 0xf1, 0xa9, 0x76, 0x12, 0xe5, 0xea, 0xbc, 0x3a, 0xb4, 0x61, 0x57, 0x43, 0xda,
+	0xe7, 0xd0, 0xec, 0x2f, 0xc5, 0x1e, 0xfd, 0x99, 0x50, 0x21, 0xc9, 0x43, 0xa8, 0x8f, 0xe9, 0x64,
+	0x34, 0x96, 0x5d, 0xf3, 0xcc, 0xbc, 0xa8, 0x78, 0xe9, 0x97, 0x7d, 0x09, 0x9d, 0x0f, 0x3c, 0x55,
+	0x8a, 0x19, 0x67, 0x82, 0x6e, 0x95, 0xbe, 0x86, 0x56, 0x5e, 0x78, 0x05, 0x35, 0x34, 0x84, 0xba,
+	0x46, 0xef, 0x91, 0xa3, 0xc5, 0x50, 0x5e, 0x94, 0x5e, 0xa9, 0xec, 0x0e, 0xb4, 0x3e, 0x49, 0x5f,
+	0x26, 0x22, 0xf5, 0x64, 0xbf, 0x82, 0xf6, 0xaa, 0xb0, 0xfb, 0x6a, 0x42, 0xa0, 0x1a, 0xf8, 0x82,
+	0x76, 0x0f, 0xb0, 0x8a, 0x6b, 0xbb, 0x0f, 0xe4, 0xfd, 0x72, 0x13, 0xef, 0x10, 0x7b, 0x72, 0x92,
+	0x63, 0xa8, 0x85, 0x3c, 0x61, 0x32, 0x45, 0xa8, 0x0f, 0x9b, 0xc1, 0x51, 0x8e, 0xb1, 0xc7, 0xc6,
+	0x1b, 0x68, 0x46, 0xcb, 0xc5, 0x10, 0x03, 0x89, 0xee, 0xc1, 0x59, 0xe5, 0xa2, 0xd1, 0x3b, 0x2d,
+	0xe7, 0x5e, 0x43, 0xbd, 0x46, 0xb4, 0xbe, 0xc0, 0x5e, 0x54, 0xe1, 0xf0, 0x9a, 0x0a, 0xe1, 0x8f,
+	0x28, 0x79, 0x07, 0x2d, 0xc4, 0x0c, 0x63, 0x65, 0x3d, 0xfd, 0x8b, 0x4f, 0x9d, 0x8d, 0xc3, 0xe0,
+	0xe8, 0xdd, 0x1c, 0x18, 0x5e, 0x33, 0xd0, 0xbb, 0xfb, 0x19, 0x1e, 0x30, 0x3e, 0x5c, 0xe1, 0x54,
+	0x0a, 0x4c, 0xda, 0xe8, 0x9d, 0x6f, 0xe1, 0x15, 0xba, 0x3e, 0x30, 0xbc, 0x0e, 0x2b, 0x0c, 0xc2,
+	0x35, 0xb4, 0x0b, 0xc8, 0x0a, 0x22, 0x9f, 0xed, 0xb6, 0x98, 0x01, 0x5b, 0x41, 0x11, 0x27, 0xb0,
+	0xdd, 0x59, 0xe2, 0xea, 0x4e, 0x5c, 0x6e, 0x58, 0x96, 0x38, 0xa1, 0x17, 0xc8, 0x47, 0xe8, 0x64,
+	0xb8, 0xd4, 0x5e, 0x0d, 0x79, 0xcf, 0xf7, 0xf0, 0x32, 0x7f, 0x6d, 0x91, 0x9f, 0xbe, 0x6f, 0x70,
+	0xac, 0xb7, 0x37, 0xb3, 0x59, 0x47, 0xec, 0xe5, 0x16, 0x6c, 0x79, 0x08, 0x07, 0x86, 0x47, 0xa2,
+	0xf2, 0x68, 0x7e, 0x87, 0x93, 0x02, 0x3e, 0xb5, 0x7d, 0x88, 0xfc, 0x17, 0xf7, 0xe1, 0x67, 0xde,
+	0x8f, 0xa2, 0x72, 0xb9, 0x5f, 0x83, 0x8a, 0x48, 0xa6, 0xfd, 0xaf, 0x7f, 0xe6, 0x96, 0x79, 0x37,
+	0xb7, 0xcc, 0x7f, 0x73, 0xcb, 0xfc, 0xbd, 0xb0, 0x8c, 0xbb, 0x85, 0x65, 0xfc, 0x5d, 0x58, 0xc6,
+	0x97, 0xb7, 0xa3, 0x89, 0x1c, 0x27, 0x81, 0x13, 0xf2, 0xa9, 0x1b, 0xf9, 0xbf, 0x6e, 0x23, 0x7a,
+	0x33, 0xa2, 0xb1, 0xb6, 0xbc, 0x0a, 0x79, 0x4c, 0x5d, 0x7c, 0x42, 0xdc, 0x8d, 0xef, 0x56, 0x50,
+	0xc7, 0xcd, 0x97, 0xff, 0x07, 0x00, 0x5d, 0x29, 0x71, 0xae, 0xd7, 0x04, 0x00, 0x00,
 }
 
 func (m *BlockRequest) Marshal() (dAtA []byte, err error) {
@@ -558,6 +701,81 @@ func (m *StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *LightBlocksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LightBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LightBlocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LightBlocksResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LightBlocksResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LightBlocksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LightBlocks) > 0 {
+		for iNdEx := len(m.LightBlocks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LightBlocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Message) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -695,6 +913,48 @@ func (m *Message_StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_LightBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_LightBlocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LightBlocksRequest != nil {
+		{
+			size, err := m.LightBlocksRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_LightBlocksResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_LightBlocksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LightBlocksResponse != nil {
+		{
+			size, err := m.LightBlocksResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -767,6 +1027,39 @@ func (m *StatusResponse) Size() (n int) {
 	return n
 }
 
+func (m *LightBlocksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Count != 0 {
+		n += 1 + sovTypes(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *LightBlocksResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if len(m.LightBlocks) > 0 {
+		for _, e := range m.LightBlocks {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
@@ -839,6 +1132,30 @@ func (m *Message_StatusResponse) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_LightBlocksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LightBlocksRequest != nil {
+		l = m.LightBlocksRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_LightBlocksResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LightBlocksResponse != nil {
+		l = m.LightBlocksResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -1223,6 +1540,203 @@ func (m *StatusResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LightBlocksRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LightBlocksRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LightBlocksRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LightBlocksResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LightBlocksResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LightBlocksResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LightBlocks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LightBlocks = append(m.LightBlocks, &types.LightBlock{})
+			if err := m.LightBlocks[len(m.LightBlocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1427,6 +1941,76 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_StatusResponse{v}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LightBlocksRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LightBlocksRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_LightBlocksRequest{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LightBlocksResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LightBlocksResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_LightBlocksResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
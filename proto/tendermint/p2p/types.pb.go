@@ -244,8 +244,10 @@ func (m *DefaultNodeInfo) GetOther() DefaultNodeInfoOther {
 }
 
 type DefaultNodeInfoOther struct {
-	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
-	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
+	TxIndex       string   `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	RPCAddress    string   `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
+	IPFSAddrs     []string `protobuf:"bytes,3,rep,name=ipfs_addrs,json=ipfsAddrs,proto3" json:"ipfs_addrs,omitempty"`
+	IPFSSignature []byte   `protobuf:"bytes,4,opt,name=ipfs_signature,json=ipfsSignature,proto3" json:"ipfs_signature,omitempty"`
 }
 
 func (m *DefaultNodeInfoOther) Reset()         { *m = DefaultNodeInfoOther{} }
@@ -295,6 +297,20 @@ func (m *DefaultNodeInfoOther) GetRPCAddress() string {
 	return ""
 }
 
+func (m *DefaultNodeInfoOther) GetIPFSAddrs() []string {
+	if m != nil {
+		return m.IPFSAddrs
+	}
+	return nil
+}
+
+func (m *DefaultNodeInfoOther) GetIPFSSignature() []byte {
+	if m != nil {
+		return m.IPFSSignature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NetAddress)(nil), "tendermint.p2p.NetAddress")
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
@@ -305,38 +321,42 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 491 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x3d, 0x6f, 0x1a, 0x41,
-	0x10, 0xe5, 0x8e, 0xe3, 0xc3, 0x43, 0x30, 0xce, 0x0a, 0x45, 0x67, 0x8a, 0x3b, 0x84, 0x52, 0xd0,
-	0x84, 0x93, 0x48, 0x95, 0x54, 0x09, 0xa1, 0xa1, 0x71, 0x4e, 0x2b, 0x2b, 0x45, 0x1a, 0x04, 0xb7,
-	0x6b, 0x58, 0x71, 0xec, 0xae, 0xf6, 0xd6, 0x09, 0xce, 0xaf, 0xc8, 0xcf, 0x72, 0xe9, 0x32, 0x15,
-	0x8a, 0x8e, 0x32, 0x7f, 0x22, 0xba, 0xdd, 0x73, 0x82, 0x91, 0xbb, 0x79, 0xf3, 0x76, 0xe6, 0xcd,
-	0x3c, 0xcd, 0x42, 0x4f, 0x53, 0x4e, 0xa8, 0xda, 0x32, 0xae, 0x23, 0x39, 0x96, 0x91, 0xbe, 0x93,
-	0x34, 0x1b, 0x49, 0x25, 0xb4, 0x40, 0xe7, 0xff, 0xb9, 0x91, 0x1c, 0xcb, 0x5e, 0x77, 0x25, 0x56,
-	0xc2, 0x50, 0x51, 0x11, 0xd9, 0x57, 0x83, 0x18, 0xe0, 0x8a, 0xea, 0x8f, 0x84, 0x28, 0x9a, 0x65,
-	0xe8, 0x15, 0xb8, 0x8c, 0xf8, 0x4e, 0xdf, 0x19, 0x9e, 0x4d, 0xea, 0xf9, 0x3e, 0x74, 0x67, 0x53,
-	0xec, 0x32, 0x62, 0xf2, 0xd2, 0x77, 0x8f, 0xf2, 0x31, 0x76, 0x99, 0x44, 0x08, 0x3c, 0x29, 0x94,
-	0xf6, 0xab, 0x7d, 0x67, 0xd8, 0xc6, 0x26, 0x1e, 0x5c, 0x43, 0x27, 0x2e, 0x5a, 0x27, 0x22, 0xfd,
-	0x42, 0x55, 0xc6, 0x04, 0x47, 0x97, 0x50, 0x95, 0x63, 0x69, 0xfa, 0x7a, 0x93, 0x46, 0xbe, 0x0f,
-	0xab, 0xf1, 0x38, 0xc6, 0x45, 0x0e, 0x75, 0xa1, 0xb6, 0x4c, 0x45, 0xb2, 0x31, 0xcd, 0x3d, 0x6c,
-	0x01, 0xba, 0x80, 0xea, 0x42, 0x4a, 0xd3, 0xd6, 0xc3, 0x45, 0x38, 0xf8, 0xe3, 0x42, 0x67, 0x4a,
-	0x6f, 0x16, 0xb7, 0xa9, 0xbe, 0x12, 0x84, 0xce, 0xf8, 0x8d, 0x40, 0x31, 0x5c, 0xc8, 0x52, 0x69,
-	0xfe, 0xcd, 0x4a, 0x19, 0x8d, 0xd6, 0x38, 0x1c, 0x3d, 0x5d, 0x7e, 0x74, 0x32, 0xd1, 0xc4, 0xbb,
-	0xdf, 0x87, 0x15, 0xdc, 0x91, 0x27, 0x83, 0xbe, 0x83, 0x0e, 0xb1, 0x22, 0x73, 0x2e, 0x08, 0x9d,
-	0x33, 0x52, 0x2e, 0xfd, 0x32, 0xdf, 0x87, 0xed, 0x63, 0xfd, 0x29, 0x6e, 0x93, 0x23, 0x48, 0x50,
-	0x08, 0xad, 0x94, 0x65, 0x9a, 0xf2, 0xf9, 0x82, 0x10, 0x65, 0x46, 0x3f, 0xc3, 0x60, 0x53, 0x85,
-	0xbd, 0xc8, 0x87, 0x06, 0xa7, 0xfa, 0xbb, 0x50, 0x1b, 0xdf, 0x33, 0xe4, 0x23, 0x2c, 0x98, 0xc7,
-	0xf1, 0x6b, 0x96, 0x29, 0x21, 0xea, 0x41, 0x33, 0x59, 0x2f, 0x38, 0xa7, 0x69, 0xe6, 0xd7, 0xfb,
-	0xce, 0xf0, 0x05, 0xfe, 0x87, 0x8b, 0xaa, 0xad, 0xe0, 0x6c, 0x43, 0x95, 0xdf, 0xb0, 0x55, 0x25,
-	0x44, 0x1f, 0xa0, 0x26, 0xf4, 0x9a, 0x2a, 0xbf, 0x69, 0xcc, 0x78, 0x7d, 0x6a, 0xc6, 0x89, 0x8f,
-	0x9f, 0x8b, 0xb7, 0xa5, 0x23, 0xb6, 0x70, 0xb0, 0x84, 0xee, 0x73, 0x8f, 0xd0, 0x25, 0x34, 0xf5,
-	0x6e, 0xce, 0x38, 0xa1, 0x3b, 0x7b, 0x25, 0xb8, 0xa1, 0x77, 0xb3, 0x02, 0xa2, 0x08, 0x5a, 0x4a,
-	0x26, 0x66, 0x79, 0x9a, 0x65, 0xa5, 0x6d, 0xe7, 0xf9, 0x3e, 0x04, 0x1c, 0x7f, 0x2a, 0xef, 0x0b,
-	0x83, 0x92, 0x49, 0x19, 0x4f, 0xae, 0xef, 0xf3, 0xc0, 0x79, 0xc8, 0x03, 0xe7, 0x77, 0x1e, 0x38,
-	0x3f, 0x0f, 0x41, 0xe5, 0xe1, 0x10, 0x54, 0x7e, 0x1d, 0x82, 0xca, 0xd7, 0xf7, 0x2b, 0xa6, 0xd7,
-	0xb7, 0xcb, 0x51, 0x22, 0xb6, 0x51, 0xba, 0xf8, 0x71, 0x97, 0x52, 0xb2, 0xa2, 0xea, 0x28, 0x7c,
-	0x93, 0x08, 0x45, 0x23, 0x7b, 0xcb, 0x4f, 0x7f, 0xc0, 0xb2, 0x6e, 0xb2, 0x6f, 0xff, 0x06, 0x00,
-	0x00, 0xff, 0xff, 0xe5, 0x5f, 0x5d, 0xf5, 0x1a, 0x03, 0x00, 0x00,
+	// 547 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x4f, 0x4f, 0xdb, 0x3e,
+	0x18, 0x6e, 0x9a, 0x40, 0xe9, 0x0b, 0xa5, 0xfc, 0x2c, 0xf4, 0x53, 0xe0, 0x90, 0x54, 0xd5, 0x0e,
+	0x3d, 0x6c, 0x8d, 0xd4, 0x5d, 0xb6, 0x9d, 0xb6, 0x0e, 0x4d, 0xea, 0x85, 0x45, 0x06, 0xed, 0xb0,
+	0x4b, 0x15, 0x62, 0x53, 0x2c, 0x82, 0x6d, 0xd9, 0x66, 0x83, 0x7d, 0x8a, 0x7d, 0x2c, 0xb4, 0x13,
+	0xc7, 0x9d, 0xa2, 0x29, 0x1c, 0xf7, 0x25, 0x26, 0x3b, 0x29, 0x2b, 0xd5, 0x6e, 0xef, 0xf3, 0x3e,
+	0xcf, 0xfb, 0xef, 0x49, 0x0c, 0x87, 0x86, 0x72, 0x42, 0xd5, 0x15, 0xe3, 0x26, 0x91, 0x13, 0x99,
+	0x98, 0x5b, 0x49, 0xf5, 0x58, 0x2a, 0x61, 0x04, 0xda, 0xfd, 0xcb, 0x8d, 0xe5, 0x44, 0x1e, 0xee,
+	0x2f, 0xc4, 0x42, 0x38, 0x2a, 0xb1, 0x51, 0xad, 0x1a, 0xa6, 0x00, 0xc7, 0xd4, 0xbc, 0x23, 0x44,
+	0x51, 0xad, 0xd1, 0xff, 0xd0, 0x66, 0x24, 0xf4, 0x06, 0xde, 0xa8, 0x3b, 0xdd, 0xac, 0xca, 0xb8,
+	0x3d, 0x3b, 0xc2, 0x6d, 0x46, 0x5c, 0x5e, 0x86, 0xed, 0x95, 0x7c, 0x8a, 0xdb, 0x4c, 0x22, 0x04,
+	0x81, 0x14, 0xca, 0x84, 0xfe, 0xc0, 0x1b, 0xf5, 0xb0, 0x8b, 0x87, 0xa7, 0xd0, 0x4f, 0x6d, 0xeb,
+	0x5c, 0x14, 0x9f, 0xa8, 0xd2, 0x4c, 0x70, 0x74, 0x00, 0xbe, 0x9c, 0x48, 0xd7, 0x37, 0x98, 0x76,
+	0xaa, 0x32, 0xf6, 0xd3, 0x49, 0x8a, 0x6d, 0x0e, 0xed, 0xc3, 0xc6, 0x59, 0x21, 0xf2, 0x4b, 0xd7,
+	0x3c, 0xc0, 0x35, 0x40, 0x7b, 0xe0, 0x67, 0x52, 0xba, 0xb6, 0x01, 0xb6, 0xe1, 0xf0, 0x77, 0x1b,
+	0xfa, 0x47, 0xf4, 0x3c, 0xbb, 0x2e, 0xcc, 0xb1, 0x20, 0x74, 0xc6, 0xcf, 0x05, 0x4a, 0x61, 0x4f,
+	0x36, 0x93, 0xe6, 0x5f, 0xea, 0x51, 0x6e, 0xc6, 0xf6, 0x24, 0x1e, 0x3f, 0x3d, 0x7e, 0xbc, 0xb6,
+	0xd1, 0x34, 0xb8, 0x2b, 0xe3, 0x16, 0xee, 0xcb, 0xb5, 0x45, 0x5f, 0x43, 0x9f, 0xd4, 0x43, 0xe6,
+	0x5c, 0x10, 0x3a, 0x67, 0xa4, 0x39, 0xfa, 0xbf, 0xaa, 0x8c, 0x7b, 0xab, 0xf3, 0x8f, 0x70, 0x8f,
+	0xac, 0x40, 0x82, 0x62, 0xd8, 0x2e, 0x98, 0x36, 0x94, 0xcf, 0x33, 0x42, 0x94, 0x5b, 0xbd, 0x8b,
+	0xa1, 0x4e, 0x59, 0x7b, 0x51, 0x08, 0x1d, 0x4e, 0xcd, 0x57, 0xa1, 0x2e, 0xc3, 0xc0, 0x91, 0x4b,
+	0x68, 0x99, 0xe5, 0xfa, 0x1b, 0x35, 0xd3, 0x40, 0x74, 0x08, 0x5b, 0xf9, 0x45, 0xc6, 0x39, 0x2d,
+	0x74, 0xb8, 0x39, 0xf0, 0x46, 0x3b, 0xf8, 0x11, 0xdb, 0xaa, 0x2b, 0xc1, 0xd9, 0x25, 0x55, 0x61,
+	0xa7, 0xae, 0x6a, 0x20, 0x7a, 0x0b, 0x1b, 0xc2, 0x5c, 0x50, 0x15, 0x6e, 0x39, 0x33, 0x9e, 0xad,
+	0x9b, 0xb1, 0xe6, 0xe3, 0x47, 0xab, 0x6d, 0x1c, 0xa9, 0x0b, 0x87, 0x3f, 0x3c, 0xd8, 0xff, 0x97,
+	0x0a, 0x1d, 0xc0, 0x96, 0xb9, 0x99, 0x33, 0x4e, 0xe8, 0x4d, 0xfd, 0x9b, 0xe0, 0x8e, 0xb9, 0x99,
+	0x59, 0x88, 0x12, 0xd8, 0x56, 0x32, 0x77, 0xd7, 0x53, 0xad, 0x1b, 0xdf, 0x76, 0xab, 0x32, 0x06,
+	0x9c, 0xbe, 0x6f, 0x7e, 0x30, 0x0c, 0x4a, 0xe6, 0x4d, 0x8c, 0x9e, 0x03, 0x30, 0x79, 0xae, 0x5d,
+	0x85, 0x0e, 0xfd, 0x81, 0x3f, 0xea, 0x4e, 0x7b, 0x55, 0x19, 0x77, 0x67, 0xe9, 0x87, 0x13, 0x2b,
+	0xd2, 0xb8, 0x6b, 0x05, 0x2e, 0x44, 0xaf, 0x60, 0xd7, 0xa9, 0x35, 0x5b, 0xf0, 0xcc, 0x5c, 0x2b,
+	0xea, 0x5c, 0xdc, 0xa9, 0xbf, 0x8c, 0xad, 0x38, 0x59, 0x12, 0xb8, 0x67, 0x85, 0x8f, 0x70, 0x7a,
+	0x7a, 0x57, 0x45, 0xde, 0x7d, 0x15, 0x79, 0xbf, 0xaa, 0xc8, 0xfb, 0xfe, 0x10, 0xb5, 0xee, 0x1f,
+	0xa2, 0xd6, 0xcf, 0x87, 0xa8, 0xf5, 0xf9, 0xcd, 0x82, 0x99, 0x8b, 0xeb, 0xb3, 0x71, 0x2e, 0xae,
+	0x92, 0x22, 0xfb, 0x76, 0x5b, 0x50, 0xb2, 0xa0, 0x6a, 0x25, 0x7c, 0x91, 0x0b, 0x45, 0x93, 0xfa,
+	0xd1, 0x3c, 0x7d, 0x6a, 0x67, 0x9b, 0x2e, 0xfb, 0xf2, 0xcf, 0x00, 0xe9, 0xd8, 0x4c, 0xdb, 0x83,
+	0x03, 0x00, 0x00,
 }
 
 func (m *NetAddress) Marshal() (dAtA []byte, err error) {
@@ -524,6 +544,22 @@ func (m *DefaultNodeInfoOther) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.IPFSSignature) > 0 {
+		i -= len(m.IPFSSignature)
+		copy(dAtA[i:], m.IPFSSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.IPFSSignature)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.IPFSAddrs) > 0 {
+		for iNdEx := len(m.IPFSAddrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IPFSAddrs[iNdEx])
+			copy(dAtA[i:], m.IPFSAddrs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.IPFSAddrs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.RPCAddress) > 0 {
 		i -= len(m.RPCAddress)
 		copy(dAtA[i:], m.RPCAddress)
@@ -641,6 +677,16 @@ func (m *DefaultNodeInfoOther) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.IPFSAddrs) > 0 {
+		for _, s := range m.IPFSAddrs {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.IPFSSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1302,6 +1348,72 @@ func (m *DefaultNodeInfoOther) Unmarshal(dAtA []byte) error {
 			}
 			m.RPCAddress = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IPFSAddrs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IPFSAddrs = append(m.IPFSAddrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IPFSSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IPFSSignature = append(m.IPFSSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.IPFSSignature == nil {
+				m.IPFSSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -175,6 +175,8 @@ type SnapshotsResponse struct {
 	Chunks   uint32 `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
 	Hash     []byte `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
 	Metadata []byte `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// CIDs of the chunks published to IPFS, if any, ordered by chunk index.
+	ChunkCids [][]byte `protobuf:"bytes,6,rep,name=chunk_cids,json=chunkCids,proto3" json:"chunk_cids,omitempty"`
 }
 
 func (m *SnapshotsResponse) Reset()         { *m = SnapshotsResponse{} }
@@ -245,6 +247,13 @@ func (m *SnapshotsResponse) GetMetadata() []byte {
 	return nil
 }
 
+func (m *SnapshotsResponse) GetChunkCids() [][]byte {
+	if m != nil {
+		return m.ChunkCids
+	}
+	return nil
+}
+
 type ChunkRequest struct {
 	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Format uint32 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
@@ -392,33 +401,34 @@ func init() {
 func init() { proto.RegisterFile("tendermint/statesync/types.proto", fileDescriptor_a1c2869546ca7914) }
 
 var fileDescriptor_a1c2869546ca7914 = []byte{
-	// 404 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x4f, 0x8b, 0xd3, 0x40,
-	0x1c, 0x4d, 0xfa, 0x9f, 0x9f, 0x8d, 0xb4, 0x43, 0x91, 0xe0, 0x21, 0x94, 0x08, 0xea, 0xc5, 0x04,
-	0xf4, 0x0b, 0x48, 0xbd, 0x54, 0xd0, 0xcb, 0xa8, 0x20, 0xbd, 0xc8, 0x34, 0x19, 0x93, 0x60, 0x33,
-	0x89, 0xf9, 0x4d, 0xc0, 0x7a, 0xf7, 0xe4, 0x65, 0x3f, 0xd6, 0x1e, 0x7b, 0x5c, 0xf6, 0xb4, 0xb4,
-	0x5f, 0x64, 0xc9, 0x24, 0x4d, 0xb3, 0xdd, 0xb2, 0xcb, 0xc2, 0xde, 0xe6, 0xbd, 0xbc, 0xbc, 0x79,
-	0xef, 0xc1, 0xc0, 0x54, 0x72, 0xe1, 0xf3, 0x2c, 0x8e, 0x84, 0x74, 0x51, 0x32, 0xc9, 0x71, 0x2d,
-	0x3c, 0x57, 0xae, 0x53, 0x8e, 0x4e, 0x9a, 0x25, 0x32, 0x21, 0x93, 0x83, 0xc2, 0xa9, 0x15, 0xf6,
-	0x65, 0x0b, 0xfa, 0x9f, 0x39, 0x22, 0x0b, 0x38, 0xf9, 0x06, 0x63, 0x14, 0x2c, 0xc5, 0x30, 0x91,
-	0xf8, 0x23, 0xe3, 0xbf, 0x73, 0x8e, 0xd2, 0xd4, 0xa7, 0xfa, 0xeb, 0x27, 0x6f, 0x5f, 0x3a, 0xa7,
-	0xfe, 0x76, 0xbe, 0xec, 0xe5, 0xb4, 0x54, 0xcf, 0x35, 0x3a, 0xc2, 0x23, 0x8e, 0x7c, 0x07, 0xd2,
-	0xb4, 0xc5, 0x34, 0x11, 0xc8, 0xcd, 0x96, 0xf2, 0x7d, 0x75, 0xaf, 0x6f, 0x29, 0x9f, 0x6b, 0x74,
-	0x8c, 0xc7, 0x24, 0xf9, 0x08, 0x86, 0x17, 0xe6, 0xe2, 0x57, 0x1d, 0xb6, 0xad, 0x4c, 0xed, 0xd3,
-	0xa6, 0x1f, 0x0a, 0xe9, 0x21, 0xe8, 0xd0, 0x6b, 0x60, 0xf2, 0x09, 0x9e, 0xee, 0xad, 0xaa, 0x80,
-	0x1d, 0xe5, 0xf5, 0xe2, 0x4e, 0xaf, 0x3a, 0x9c, 0xe1, 0x35, 0x89, 0x59, 0x17, 0xda, 0x98, 0xc7,
-	0x36, 0x81, 0xd1, 0xf1, 0x42, 0xf6, 0x7f, 0x1d, 0xc6, 0xb7, 0xea, 0x91, 0x67, 0xd0, 0x0b, 0x79,
-	0x14, 0x84, 0xe5, 0xde, 0x1d, 0x5a, 0xa1, 0x82, 0xff, 0x99, 0x64, 0x31, 0x93, 0x6a, 0x2f, 0x83,
-	0x56, 0xa8, 0xe0, 0xd5, 0x8d, 0xa8, 0x2a, 0x1b, 0xb4, 0x42, 0x84, 0x40, 0x27, 0x64, 0x18, 0xaa,
-	0xf0, 0x43, 0xaa, 0xce, 0xe4, 0x39, 0x0c, 0x62, 0x2e, 0x99, 0xcf, 0x24, 0x33, 0xbb, 0x8a, 0xaf,
-	0xb1, 0xfd, 0x15, 0x86, 0xcd, 0x59, 0x1e, 0x9c, 0x63, 0x02, 0xdd, 0x48, 0xf8, 0xfc, 0x4f, 0x15,
-	0xa3, 0x04, 0xf6, 0x3f, 0x1d, 0x8c, 0x1b, 0x0b, 0x3d, 0x8e, 0x6f, 0xc1, 0xaa, 0x9e, 0x55, 0xbd,
-	0x12, 0x10, 0x13, 0xfa, 0x71, 0x84, 0x18, 0x89, 0x40, 0xd5, 0x1b, 0xd0, 0x3d, 0x9c, 0x2d, 0xce,
-	0xb7, 0x96, 0xbe, 0xd9, 0x5a, 0xfa, 0xd5, 0xd6, 0xd2, 0xcf, 0x76, 0x96, 0xb6, 0xd9, 0x59, 0xda,
-	0xc5, 0xce, 0xd2, 0x16, 0xef, 0x83, 0x48, 0x86, 0xf9, 0xd2, 0xf1, 0x92, 0xd8, 0x5d, 0xb1, 0xbf,
-	0xeb, 0x15, 0xf7, 0x03, 0x9e, 0x35, 0x8e, 0x6f, 0xbc, 0x24, 0xe3, 0xae, 0x7a, 0x39, 0xee, 0xa9,
-	0xa7, 0xb5, 0xec, 0xa9, 0x6f, 0xef, 0xae, 0x03, 0x00, 0x00, 0xff, 0xff, 0x1a, 0xf2, 0xd0, 0x63,
-	0x79, 0x03, 0x00, 0x00,
+	// 419 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcf, 0xca, 0xd3, 0x40,
+	0x1c, 0x4c, 0xbe, 0xfe, 0xf9, 0x3e, 0x7f, 0x26, 0xf2, 0x75, 0x29, 0x12, 0x04, 0x43, 0x89, 0xa0,
+	0xbd, 0x98, 0x80, 0xbe, 0x80, 0xb4, 0x97, 0x0a, 0x7a, 0x59, 0x15, 0xa4, 0x17, 0xd9, 0x26, 0x6b,
+	0xb2, 0xd8, 0x6c, 0x62, 0x7e, 0x1b, 0xb0, 0xde, 0xbd, 0xfb, 0x1c, 0x3e, 0x89, 0xc7, 0x1e, 0xc5,
+	0x93, 0xb4, 0x2f, 0x22, 0xd9, 0xa4, 0x69, 0xac, 0x45, 0x11, 0xbc, 0xed, 0x4c, 0x26, 0xb3, 0x33,
+	0x03, 0x0b, 0x13, 0xc5, 0x65, 0xc4, 0x8b, 0x54, 0x48, 0x15, 0xa0, 0x62, 0x8a, 0xe3, 0x46, 0x86,
+	0x81, 0xda, 0xe4, 0x1c, 0xfd, 0xbc, 0xc8, 0x54, 0x46, 0xc6, 0x47, 0x85, 0xdf, 0x2a, 0xbc, 0xef,
+	0x17, 0x70, 0xf9, 0x9c, 0x23, 0xb2, 0x98, 0x93, 0x57, 0x30, 0x42, 0xc9, 0x72, 0x4c, 0x32, 0x85,
+	0x6f, 0x0a, 0xfe, 0xbe, 0xe4, 0xa8, 0x1c, 0x73, 0x62, 0x4e, 0x6f, 0x3e, 0xba, 0xef, 0x9f, 0xfb,
+	0xdb, 0x7f, 0x71, 0x90, 0xd3, 0x5a, 0xbd, 0x30, 0xe8, 0x35, 0x9e, 0x70, 0xe4, 0x35, 0x90, 0xae,
+	0x2d, 0xe6, 0x99, 0x44, 0xee, 0x5c, 0x68, 0xdf, 0x07, 0x7f, 0xf5, 0xad, 0xe5, 0x0b, 0x83, 0x8e,
+	0xf0, 0x94, 0x24, 0x4f, 0xc1, 0x0e, 0x93, 0x52, 0xbe, 0x6b, 0xc3, 0xf6, 0xb4, 0xa9, 0x77, 0xde,
+	0x74, 0x5e, 0x49, 0x8f, 0x41, 0xad, 0xb0, 0x83, 0xc9, 0x33, 0xb8, 0x75, 0xb0, 0x6a, 0x02, 0xf6,
+	0xb5, 0xd7, 0xbd, 0x3f, 0x7a, 0xb5, 0xe1, 0xec, 0xb0, 0x4b, 0xcc, 0x06, 0xd0, 0xc3, 0x32, 0xf5,
+	0x08, 0x5c, 0x9f, 0x2e, 0xe4, 0x7d, 0x31, 0x61, 0xf4, 0x5b, 0x3d, 0x72, 0x1b, 0x86, 0x09, 0x17,
+	0x71, 0x52, 0xef, 0xdd, 0xa7, 0x0d, 0xaa, 0xf8, 0xb7, 0x59, 0x91, 0x32, 0xa5, 0xf7, 0xb2, 0x69,
+	0x83, 0x2a, 0x5e, 0xdf, 0x88, 0xba, 0xb2, 0x4d, 0x1b, 0x44, 0x08, 0xf4, 0x13, 0x86, 0x89, 0x0e,
+	0x6f, 0x51, 0x7d, 0x26, 0x77, 0xe0, 0x2a, 0xe5, 0x8a, 0x45, 0x4c, 0x31, 0x67, 0xa0, 0xf9, 0x16,
+	0x93, 0xbb, 0x00, 0x75, 0xed, 0x50, 0x44, 0xe8, 0x0c, 0x27, 0xbd, 0xa9, 0x45, 0x6f, 0x68, 0x66,
+	0x2e, 0x22, 0xf4, 0x5e, 0x82, 0xd5, 0x5d, 0xed, 0x9f, 0x63, 0x8e, 0x61, 0x20, 0x64, 0xc4, 0x3f,
+	0x34, 0x29, 0x6b, 0xe0, 0x7d, 0x32, 0xc1, 0xfe, 0x65, 0xc0, 0xff, 0xe3, 0x5b, 0xb1, 0x3a, 0x7a,
+	0xd3, 0xbe, 0x06, 0xc4, 0x81, 0xcb, 0x54, 0x20, 0x0a, 0x19, 0xeb, 0xf6, 0x57, 0xf4, 0x00, 0x67,
+	0xcb, 0xaf, 0x3b, 0xd7, 0xdc, 0xee, 0x5c, 0xf3, 0xc7, 0xce, 0x35, 0x3f, 0xef, 0x5d, 0x63, 0xbb,
+	0x77, 0x8d, 0x6f, 0x7b, 0xd7, 0x58, 0x3e, 0x89, 0x85, 0x4a, 0xca, 0x95, 0x1f, 0x66, 0x69, 0xb0,
+	0x66, 0x1f, 0x37, 0x6b, 0x1e, 0xc5, 0xbc, 0xe8, 0x1c, 0x1f, 0x86, 0x59, 0xc1, 0x03, 0xfd, 0xb0,
+	0x82, 0x73, 0x2f, 0x6f, 0x35, 0xd4, 0xdf, 0x1e, 0xff, 0x1c, 0x00, 0x23, 0x46, 0x92, 0xf0, 0x98,
+	0x03, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -580,6 +590,15 @@ func (m *SnapshotsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ChunkCids) > 0 {
+		for iNdEx := len(m.ChunkCids) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ChunkCids[iNdEx])
+			copy(dAtA[i:], m.ChunkCids[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ChunkCids[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Metadata) > 0 {
 		i -= len(m.Metadata)
 		copy(dAtA[i:], m.Metadata)
@@ -808,6 +827,12 @@ func (m *SnapshotsResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if len(m.ChunkCids) > 0 {
+		for _, b := range m.ChunkCids {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1260,6 +1285,38 @@ func (m *SnapshotsResponse) Unmarshal(dAtA []byte) error {
 				m.Metadata = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkCids", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChunkCids = append(m.ChunkCids, make([]byte, postIndex-iNdEx))
+			copy(m.ChunkCids[len(m.ChunkCids)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
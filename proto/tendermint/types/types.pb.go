@@ -499,6 +499,7 @@ type Evidence struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Evidence_DuplicateVoteEvidence
 	//	*Evidence_LightClientAttackEvidence
+	//	*Evidence_InvalidDAHeaderEvidence
 	Sum isEvidence_Sum `protobuf_oneof:"sum"`
 }
 
@@ -547,9 +548,13 @@ type Evidence_DuplicateVoteEvidence struct {
 type Evidence_LightClientAttackEvidence struct {
 	LightClientAttackEvidence *LightClientAttackEvidence `protobuf:"bytes,2,opt,name=light_client_attack_evidence,json=lightClientAttackEvidence,proto3,oneof" json:"light_client_attack_evidence,omitempty"`
 }
+type Evidence_InvalidDAHeaderEvidence struct {
+	InvalidDAHeaderEvidence *InvalidDAHeaderEvidence `protobuf:"bytes,3,opt,name=invalid_da_header_evidence,json=invalidDaHeaderEvidence,proto3,oneof" json:"invalid_da_header_evidence,omitempty"`
+}
 
 func (*Evidence_DuplicateVoteEvidence) isEvidence_Sum()     {}
 func (*Evidence_LightClientAttackEvidence) isEvidence_Sum() {}
+func (*Evidence_InvalidDAHeaderEvidence) isEvidence_Sum()   {}
 
 func (m *Evidence) GetSum() isEvidence_Sum {
 	if m != nil {
@@ -572,11 +577,19 @@ func (m *Evidence) GetLightClientAttackEvidence() *LightClientAttackEvidence {
 	return nil
 }
 
+func (m *Evidence) GetInvalidDAHeaderEvidence() *InvalidDAHeaderEvidence {
+	if x, ok := m.GetSum().(*Evidence_InvalidDAHeaderEvidence); ok {
+		return x.InvalidDAHeaderEvidence
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Evidence) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Evidence_DuplicateVoteEvidence)(nil),
 		(*Evidence_LightClientAttackEvidence)(nil),
+		(*Evidence_InvalidDAHeaderEvidence)(nil),
 	}
 }
 
@@ -734,6 +747,278 @@ func (m *LightClientAttackEvidence) GetTimestamp() time.Time {
 	return time.Time{}
 }
 
+// StateTransitionFraudProof proves that applying a range of txs of a block to the
+// state committed to by an intermediate state root does not result in the next one.
+// It carries the shares of the txs and of both roots.
+type StateTransitionFraudProof struct {
+	Height       int64        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	FirstTxIndex uint32       `protobuf:"varint,2,opt,name=first_tx_index,json=firstTxIndex,proto3" json:"first_tx_index,omitempty"`
+	NumTxs       uint32       `protobuf:"varint,3,opt,name=num_txs,json=numTxs,proto3" json:"num_txs,omitempty"`
+	TxProofs     []ShareProof `protobuf:"bytes,4,rep,name=tx_proofs,json=txProofs,proto3" json:"tx_proofs"`
+	ISRProofs    []ShareProof `protobuf:"bytes,5,rep,name=isr_proofs,json=isrProofs,proto3" json:"isr_proofs"`
+	StateWitness []byte       `protobuf:"bytes,6,opt,name=state_witness,json=stateWitness,proto3" json:"state_witness,omitempty"`
+}
+
+func (m *StateTransitionFraudProof) Reset()         { *m = StateTransitionFraudProof{} }
+func (m *StateTransitionFraudProof) String() string { return proto.CompactTextString(m) }
+func (*StateTransitionFraudProof) ProtoMessage()    {}
+func (*StateTransitionFraudProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{8}
+}
+func (m *StateTransitionFraudProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StateTransitionFraudProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StateTransitionFraudProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StateTransitionFraudProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateTransitionFraudProof.Merge(m, src)
+}
+func (m *StateTransitionFraudProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *StateTransitionFraudProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateTransitionFraudProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateTransitionFraudProof proto.InternalMessageInfo
+
+func (m *StateTransitionFraudProof) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateTransitionFraudProof) GetFirstTxIndex() uint32 {
+	if m != nil {
+		return m.FirstTxIndex
+	}
+	return 0
+}
+
+func (m *StateTransitionFraudProof) GetNumTxs() uint32 {
+	if m != nil {
+		return m.NumTxs
+	}
+	return 0
+}
+
+func (m *StateTransitionFraudProof) GetTxProofs() []ShareProof {
+	if m != nil {
+		return m.TxProofs
+	}
+	return nil
+}
+
+func (m *StateTransitionFraudProof) GetISRProofs() []ShareProof {
+	if m != nil {
+		return m.ISRProofs
+	}
+	return nil
+}
+
+func (m *StateTransitionFraudProof) GetStateWitness() []byte {
+	if m != nil {
+		return m.StateWitness
+	}
+	return nil
+}
+
+// InvalidDAHeaderEvidence proves that a proposer signed a proposal whose data availability
+// header does not match the data of the proposed block. The shares of one row or column are
+// proven, either by the block parts holding them or against the orthogonal roots of the header.
+type InvalidDAHeaderEvidence struct {
+	Proposal         *Proposal `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	ProposerAddress  []byte    `protobuf:"bytes,2,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	BlockParts       []*Part   `protobuf:"bytes,3,rep,name=block_parts,json=blockParts,proto3" json:"block_parts,omitempty"`
+	ValidatorPower   int64     `protobuf:"varint,4,opt,name=validator_power,json=validatorPower,proto3" json:"validator_power,omitempty"`
+	TotalVotingPower int64     `protobuf:"varint,5,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
+	Timestamp        time.Time `protobuf:"bytes,6,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	// whether index is the one of a column rather than of a row
+	Column bool `protobuf:"varint,7,opt,name=column,proto3" json:"column,omitempty"`
+	// index of the row or column in the extended data square
+	Index       uint32       `protobuf:"varint,8,opt,name=index,proto3" json:"index,omitempty"`
+	ShareProofs []ShareProof `protobuf:"bytes,9,rep,name=share_proofs,json=shareProofs,proto3" json:"share_proofs"`
+	// root recomputed from the proven shares
+	Root []byte `protobuf:"bytes,10,opt,name=root,proto3" json:"root,omitempty"`
+}
+
+func (m *InvalidDAHeaderEvidence) Reset()         { *m = InvalidDAHeaderEvidence{} }
+func (m *InvalidDAHeaderEvidence) String() string { return proto.CompactTextString(m) }
+func (*InvalidDAHeaderEvidence) ProtoMessage()    {}
+func (*InvalidDAHeaderEvidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{9}
+}
+func (m *InvalidDAHeaderEvidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InvalidDAHeaderEvidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InvalidDAHeaderEvidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InvalidDAHeaderEvidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidDAHeaderEvidence.Merge(m, src)
+}
+func (m *InvalidDAHeaderEvidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *InvalidDAHeaderEvidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidDAHeaderEvidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvalidDAHeaderEvidence proto.InternalMessageInfo
+
+func (m *InvalidDAHeaderEvidence) GetProposal() *Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *InvalidDAHeaderEvidence) GetProposerAddress() []byte {
+	if m != nil {
+		return m.ProposerAddress
+	}
+	return nil
+}
+
+func (m *InvalidDAHeaderEvidence) GetBlockParts() []*Part {
+	if m != nil {
+		return m.BlockParts
+	}
+	return nil
+}
+
+func (m *InvalidDAHeaderEvidence) GetValidatorPower() int64 {
+	if m != nil {
+		return m.ValidatorPower
+	}
+	return 0
+}
+
+func (m *InvalidDAHeaderEvidence) GetTotalVotingPower() int64 {
+	if m != nil {
+		return m.TotalVotingPower
+	}
+	return 0
+}
+
+func (m *InvalidDAHeaderEvidence) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
+func (m *InvalidDAHeaderEvidence) GetColumn() bool {
+	if m != nil {
+		return m.Column
+	}
+	return false
+}
+
+func (m *InvalidDAHeaderEvidence) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *InvalidDAHeaderEvidence) GetShareProofs() []ShareProof {
+	if m != nil {
+		return m.ShareProofs
+	}
+	return nil
+}
+
+func (m *InvalidDAHeaderEvidence) GetRoot() []byte {
+	if m != nil {
+		return m.Root
+	}
+	return nil
+}
+
+// ShareProof proves the inclusion of a share of the original data square in the
+// root of its row.
+type ShareProof struct {
+	// index of the share in the original data square, counted row by row
+	Index uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Share []byte   `protobuf:"bytes,2,opt,name=share,proto3" json:"share,omitempty"`
+	Nodes [][]byte `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (m *ShareProof) Reset()         { *m = ShareProof{} }
+func (m *ShareProof) String() string { return proto.CompactTextString(m) }
+func (*ShareProof) ProtoMessage()    {}
+func (*ShareProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d3a6e55e2345de56, []int{10}
+}
+func (m *ShareProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShareProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShareProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShareProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShareProof.Merge(m, src)
+}
+func (m *ShareProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShareProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShareProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShareProof proto.InternalMessageInfo
+
+func (m *ShareProof) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ShareProof) GetShare() []byte {
+	if m != nil {
+		return m.Share
+	}
+	return nil
+}
+
+func (m *ShareProof) GetNodes() [][]byte {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
 type EvidenceList struct {
 	Evidence []Evidence `protobuf:"bytes,1,rep,name=evidence,proto3" json:"evidence"`
 }
@@ -742,7 +1027,7 @@ func (m *EvidenceList) Reset()         { *m = EvidenceList{} }
 func (m *EvidenceList) String() string { return proto.CompactTextString(m) }
 func (*EvidenceList) ProtoMessage()    {}
 func (*EvidenceList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{8}
+	return fileDescriptor_d3a6e55e2345de56, []int{11}
 }
 func (m *EvidenceList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -780,13 +1065,15 @@ func (m *EvidenceList) GetEvidence() []Evidence {
 
 type IntermediateStateRoots struct {
 	RawRootsList [][]byte `protobuf:"bytes,1,rep,name=raw_roots_list,json=rawRootsList,proto3" json:"raw_roots_list,omitempty"`
+	// number of txs applied to the state for every root, the last root may have fewer
+	TxsPerRoot uint32 `protobuf:"varint,2,opt,name=txs_per_root,json=txsPerRoot,proto3" json:"txs_per_root,omitempty"`
 }
 
 func (m *IntermediateStateRoots) Reset()         { *m = IntermediateStateRoots{} }
 func (m *IntermediateStateRoots) String() string { return proto.CompactTextString(m) }
 func (*IntermediateStateRoots) ProtoMessage()    {}
 func (*IntermediateStateRoots) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{9}
+	return fileDescriptor_d3a6e55e2345de56, []int{12}
 }
 func (m *IntermediateStateRoots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -822,6 +1109,13 @@ func (m *IntermediateStateRoots) GetRawRootsList() [][]byte {
 	return nil
 }
 
+func (m *IntermediateStateRoots) GetTxsPerRoot() uint32 {
+	if m != nil {
+		return m.TxsPerRoot
+	}
+	return 0
+}
+
 type Messages struct {
 	MessagesList []*Message `protobuf:"bytes,1,rep,name=messages_list,json=messagesList,proto3" json:"messages_list,omitempty"`
 }
@@ -830,7 +1124,7 @@ func (m *Messages) Reset()         { *m = Messages{} }
 func (m *Messages) String() string { return proto.CompactTextString(m) }
 func (*Messages) ProtoMessage()    {}
 func (*Messages) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{10}
+	return fileDescriptor_d3a6e55e2345de56, []int{13}
 }
 func (m *Messages) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -875,7 +1169,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{11}
+	return fileDescriptor_d3a6e55e2345de56, []int{14}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -939,7 +1233,7 @@ func (m *DataAvailabilityHeader) Reset()         { *m = DataAvailabilityHeader{}
 func (m *DataAvailabilityHeader) String() string { return proto.CompactTextString(m) }
 func (*DataAvailabilityHeader) ProtoMessage()    {}
 func (*DataAvailabilityHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{12}
+	return fileDescriptor_d3a6e55e2345de56, []int{15}
 }
 func (m *DataAvailabilityHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -999,7 +1293,7 @@ func (m *Vote) Reset()         { *m = Vote{} }
 func (m *Vote) String() string { return proto.CompactTextString(m) }
 func (*Vote) ProtoMessage()    {}
 func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{13}
+	return fileDescriptor_d3a6e55e2345de56, []int{16}
 }
 func (m *Vote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1097,7 +1391,7 @@ func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{14}
+	return fileDescriptor_d3a6e55e2345de56, []int{17}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1173,7 +1467,7 @@ func (m *CommitSig) Reset()         { *m = CommitSig{} }
 func (m *CommitSig) String() string { return proto.CompactTextString(m) }
 func (*CommitSig) ProtoMessage()    {}
 func (*CommitSig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{15}
+	return fileDescriptor_d3a6e55e2345de56, []int{18}
 }
 func (m *CommitSig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1245,7 +1539,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{16}
+	return fileDescriptor_d3a6e55e2345de56, []int{19}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1339,7 +1633,7 @@ func (m *SignedHeader) Reset()         { *m = SignedHeader{} }
 func (m *SignedHeader) String() string { return proto.CompactTextString(m) }
 func (*SignedHeader) ProtoMessage()    {}
 func (*SignedHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{17}
+	return fileDescriptor_d3a6e55e2345de56, []int{20}
 }
 func (m *SignedHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1392,7 +1686,7 @@ func (m *LightBlock) Reset()         { *m = LightBlock{} }
 func (m *LightBlock) String() string { return proto.CompactTextString(m) }
 func (*LightBlock) ProtoMessage()    {}
 func (*LightBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{18}
+	return fileDescriptor_d3a6e55e2345de56, []int{21}
 }
 func (m *LightBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1454,7 +1748,7 @@ func (m *BlockMeta) Reset()         { *m = BlockMeta{} }
 func (m *BlockMeta) String() string { return proto.CompactTextString(m) }
 func (*BlockMeta) ProtoMessage()    {}
 func (*BlockMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{19}
+	return fileDescriptor_d3a6e55e2345de56, []int{22}
 }
 func (m *BlockMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1529,7 +1823,7 @@ func (m *TxProof) Reset()         { *m = TxProof{} }
 func (m *TxProof) String() string { return proto.CompactTextString(m) }
 func (*TxProof) ProtoMessage()    {}
 func (*TxProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d3a6e55e2345de56, []int{20}
+	return fileDescriptor_d3a6e55e2345de56, []int{23}
 }
 func (m *TxProof) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1590,6 +1884,9 @@ func init() {
 	proto.RegisterType((*Evidence)(nil), "tendermint.types.Evidence")
 	proto.RegisterType((*DuplicateVoteEvidence)(nil), "tendermint.types.DuplicateVoteEvidence")
 	proto.RegisterType((*LightClientAttackEvidence)(nil), "tendermint.types.LightClientAttackEvidence")
+	proto.RegisterType((*StateTransitionFraudProof)(nil), "tendermint.types.StateTransitionFraudProof")
+	proto.RegisterType((*InvalidDAHeaderEvidence)(nil), "tendermint.types.InvalidDAHeaderEvidence")
+	proto.RegisterType((*ShareProof)(nil), "tendermint.types.ShareProof")
 	proto.RegisterType((*EvidenceList)(nil), "tendermint.types.EvidenceList")
 	proto.RegisterType((*IntermediateStateRoots)(nil), "tendermint.types.IntermediateStateRoots")
 	proto.RegisterType((*Messages)(nil), "tendermint.types.Messages")
@@ -1608,125 +1905,144 @@ func init() {
 func init() { proto.RegisterFile("tendermint/types/types.proto", fileDescriptor_d3a6e55e2345de56) }
 
 var fileDescriptor_d3a6e55e2345de56 = []byte{
-	// 1879 bytes of a gzipped FileDescriptorProto
+	// 2184 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4b, 0x6f, 0x1b, 0xc9,
-	0x11, 0xd6, 0xf0, 0x21, 0x92, 0x45, 0x52, 0xa2, 0x3a, 0x92, 0x4c, 0xc9, 0x36, 0xc5, 0x30, 0x8f,
-	0xd5, 0xbe, 0x28, 0xc7, 0x1b, 0xe4, 0x01, 0x6c, 0x16, 0x4b, 0x4a, 0x5a, 0x9b, 0x59, 0x3d, 0x88,
-	0xa1, 0x56, 0x79, 0x5c, 0x06, 0x4d, 0x4e, 0x9b, 0x9c, 0x78, 0x38, 0x3d, 0x98, 0x6e, 0xca, 0x92,
-	0x8f, 0x39, 0x6d, 0x74, 0xf2, 0x1f, 0x10, 0x72, 0x48, 0x0e, 0xf9, 0x29, 0x7b, 0x09, 0xb0, 0xb7,
-	0xe4, 0x12, 0x27, 0x91, 0x73, 0x08, 0x90, 0x3f, 0x11, 0xf4, 0x63, 0x86, 0x43, 0x91, 0x74, 0x1c,
-	0x43, 0xd8, 0x0b, 0xc1, 0xae, 0xfa, 0xaa, 0xba, 0xaa, 0xba, 0xaa, 0xba, 0x7a, 0xe0, 0x1e, 0x27,
-	0x9e, 0x4d, 0x82, 0xa1, 0xe3, 0xf1, 0x1d, 0x7e, 0xe1, 0x13, 0xa6, 0x7e, 0xeb, 0x7e, 0x40, 0x39,
-	0x45, 0xa5, 0x31, 0xb7, 0x2e, 0xe9, 0x9b, 0xab, 0x7d, 0xda, 0xa7, 0x92, 0xb9, 0x23, 0xfe, 0x29,
-	0xdc, 0xe6, 0x56, 0x9f, 0xd2, 0xbe, 0x4b, 0x76, 0xe4, 0xaa, 0x3b, 0x7a, 0xb2, 0xc3, 0x9d, 0x21,
-	0x61, 0x1c, 0x0f, 0x7d, 0x0d, 0xb8, 0x1f, 0xdb, 0xa6, 0x17, 0x5c, 0xf8, 0x9c, 0x0a, 0x2c, 0x7d,
-	0xa2, 0xd9, 0x95, 0x18, 0xfb, 0x8c, 0x04, 0xcc, 0xa1, 0x5e, 0xdc, 0x8e, 0xcd, 0xea, 0x94, 0x95,
-	0x67, 0xd8, 0x75, 0x6c, 0xcc, 0x69, 0xa0, 0x10, 0xb5, 0x9f, 0x42, 0xb1, 0x8d, 0x03, 0xde, 0x21,
-	0xfc, 0x31, 0xc1, 0x36, 0x09, 0xd0, 0x2a, 0xa4, 0x39, 0xe5, 0xd8, 0x2d, 0x1b, 0x55, 0x63, 0xbb,
-	0x68, 0xaa, 0x05, 0x42, 0x90, 0x1a, 0x60, 0x36, 0x28, 0x27, 0xaa, 0xc6, 0x76, 0xc1, 0x94, 0xff,
-	0x6b, 0x03, 0x48, 0x09, 0x51, 0x21, 0xe1, 0x78, 0x36, 0x39, 0x0f, 0x25, 0xe4, 0x42, 0x50, 0xbb,
-	0x17, 0x9c, 0x30, 0x2d, 0xa2, 0x16, 0xe8, 0x87, 0x90, 0x96, 0xf6, 0x97, 0x93, 0x55, 0x63, 0x3b,
-	0xff, 0xb0, 0x5c, 0x8f, 0x05, 0x4a, 0xf9, 0x57, 0x6f, 0x0b, 0x7e, 0x33, 0xf5, 0xd5, 0xcb, 0xad,
-	0x05, 0x53, 0x81, 0x6b, 0x2e, 0x64, 0x9a, 0x2e, 0xed, 0x3d, 0x6d, 0xed, 0x45, 0x86, 0x18, 0x63,
-	0x43, 0xd0, 0x21, 0x2c, 0xfb, 0x38, 0xe0, 0x16, 0x23, 0xdc, 0x1a, 0x48, 0x2f, 0xe4, 0xa6, 0xf9,
-	0x87, 0x5b, 0xf5, 0x9b, 0xe7, 0x50, 0x9f, 0x70, 0x56, 0xef, 0x52, 0xf4, 0xe3, 0xc4, 0xda, 0xef,
-	0xd3, 0xb0, 0xa8, 0x83, 0xf1, 0x33, 0xc8, 0xe8, 0xb0, 0xca, 0x0d, 0xf3, 0x0f, 0xef, 0xc7, 0x35,
-	0x6a, 0x56, 0x7d, 0x97, 0x7a, 0x8c, 0x78, 0x6c, 0xc4, 0xb4, 0xbe, 0x50, 0x06, 0x7d, 0x1f, 0xb2,
-	0xbd, 0x01, 0x76, 0x3c, 0xcb, 0xb1, 0xa5, 0x45, 0xb9, 0x66, 0xfe, 0xfa, 0xe5, 0x56, 0x66, 0x57,
-	0xd0, 0x5a, 0x7b, 0x66, 0x46, 0x32, 0x5b, 0x36, 0x5a, 0x87, 0xc5, 0x01, 0x71, 0xfa, 0x03, 0x2e,
-	0xc3, 0x92, 0x34, 0xf5, 0x0a, 0xfd, 0x04, 0x52, 0x22, 0x21, 0xca, 0x29, 0xb9, 0xf7, 0x66, 0x5d,
-	0x65, 0x4b, 0x3d, 0xcc, 0x96, 0xfa, 0x49, 0x98, 0x2d, 0xcd, 0xac, 0xd8, 0xf8, 0xc5, 0xdf, 0xb7,
-	0x0c, 0x53, 0x4a, 0xa0, 0x5d, 0x28, 0xba, 0x98, 0x71, 0xab, 0x2b, 0xc2, 0x26, 0xb6, 0x4f, 0x4b,
-	0x15, 0x1b, 0xd3, 0x01, 0xd1, 0x81, 0xd5, 0xa6, 0xe7, 0x85, 0x94, 0x22, 0xd9, 0x68, 0x1b, 0x4a,
-	0x52, 0x49, 0x8f, 0x0e, 0x87, 0x0e, 0xb7, 0x64, 0xdc, 0x17, 0x65, 0xdc, 0x97, 0x04, 0x7d, 0x57,
-	0x92, 0x1f, 0x8b, 0x13, 0xf8, 0x31, 0x94, 0xbd, 0xd1, 0xd0, 0xa2, 0x81, 0xd3, 0x77, 0x3c, 0xec,
-	0x5a, 0x36, 0xe6, 0xd8, 0x62, 0x03, 0x1c, 0x10, 0x56, 0xce, 0x54, 0x8d, 0xed, 0x94, 0xb9, 0xe6,
-	0x8d, 0x86, 0xc7, 0x9a, 0xbd, 0x87, 0x39, 0xee, 0x48, 0x26, 0xba, 0x0b, 0x39, 0x89, 0x95, 0xba,
-	0xb3, 0x52, 0x77, 0x56, 0x10, 0xa4, 0xd6, 0x77, 0x60, 0x39, 0x4a, 0x57, 0xa6, 0x20, 0x39, 0xb5,
-	0xfd, 0x98, 0x2c, 0x81, 0x0f, 0x60, 0xd5, 0x23, 0xe7, 0xdc, 0xba, 0x89, 0x06, 0x89, 0x46, 0x82,
-	0x77, 0x3a, 0x29, 0xf1, 0x3d, 0x58, 0xea, 0x85, 0xa7, 0xa6, 0xb0, 0x79, 0x89, 0x2d, 0x46, 0x54,
-	0x09, 0xdb, 0x80, 0x2c, 0xf6, 0x7d, 0x05, 0x28, 0x48, 0x40, 0x06, 0xfb, 0xbe, 0x64, 0xbd, 0x07,
-	0x2b, 0x32, 0x38, 0x01, 0x61, 0x23, 0x97, 0x6b, 0x25, 0x45, 0x89, 0x59, 0x16, 0x0c, 0x53, 0xd1,
-	0x25, 0xf6, 0x3b, 0x50, 0x24, 0x67, 0x8e, 0x4d, 0xbc, 0x1e, 0x51, 0xb8, 0x25, 0x89, 0x2b, 0x84,
-	0x44, 0x09, 0x7a, 0x17, 0x4a, 0x7e, 0x40, 0x7d, 0xca, 0x48, 0x60, 0x61, 0xdb, 0x0e, 0x08, 0x63,
-	0xe5, 0x65, 0xa5, 0x2f, 0xa4, 0x37, 0x14, 0xb9, 0xf6, 0xdb, 0x04, 0xa4, 0x44, 0x10, 0x51, 0x09,
-	0x92, 0xfc, 0x9c, 0x95, 0x8d, 0x6a, 0x72, 0xbb, 0x60, 0x8a, 0xbf, 0x68, 0x00, 0x65, 0xc7, 0xe3,
-	0x24, 0x18, 0x12, 0xdb, 0xc1, 0x9c, 0x58, 0x8c, 0x8b, 0xdf, 0x80, 0x52, 0xce, 0x74, 0x51, 0x6c,
-	0x4f, 0xe7, 0x40, 0x2b, 0x26, 0xd1, 0x11, 0x02, 0xa6, 0xc0, 0xeb, 0x94, 0x58, 0x77, 0x66, 0x72,
-	0xd1, 0xa7, 0x90, 0x0d, 0xed, 0xd7, 0xd5, 0x5c, 0x99, 0xd6, 0xbc, 0xaf, 0x11, 0x07, 0x0e, 0xe3,
-	0x5a, 0x5f, 0x24, 0x85, 0x3e, 0x86, 0xec, 0x90, 0x30, 0x86, 0xfb, 0x84, 0x45, 0x29, 0x3e, 0xa5,
-	0xe1, 0x50, 0x23, 0x42, 0xe9, 0x50, 0xa2, 0xf6, 0x2f, 0x03, 0xb2, 0xa1, 0x7a, 0x84, 0xe1, 0x8e,
-	0x3d, 0xf2, 0x5d, 0xa7, 0x27, 0xbc, 0x3d, 0xa3, 0x9c, 0x58, 0x91, 0x6d, 0xaa, 0x70, 0xdf, 0x99,
-	0xd6, 0xbc, 0x17, 0x0a, 0x9c, 0x52, 0x4e, 0x42, 0x4d, 0x8f, 0x17, 0xcc, 0x35, 0x7b, 0x16, 0x03,
-	0x79, 0x70, 0xcf, 0x15, 0x55, 0x69, 0xf5, 0x5c, 0x87, 0x78, 0xdc, 0xc2, 0x9c, 0xe3, 0xde, 0xd3,
-	0xf1, 0x3e, 0x2a, 0xba, 0xef, 0x4f, 0xef, 0x73, 0x20, 0xa4, 0x76, 0xa5, 0x50, 0x43, 0xca, 0xc4,
-	0xf6, 0xda, 0x70, 0xe7, 0x31, 0x9b, 0x69, 0x48, 0xb2, 0xd1, 0xb0, 0xf6, 0x22, 0x01, 0x6b, 0x33,
-	0x2d, 0x45, 0x1f, 0xc2, 0xa2, 0xf4, 0x14, 0x6b, 0x17, 0xd7, 0xa7, 0xb7, 0x16, 0x78, 0x33, 0x2d,
-	0x50, 0x8d, 0x08, 0xde, 0xd5, 0x96, 0xbe, 0x16, 0xde, 0x44, 0x1f, 0x00, 0x92, 0xad, 0x5f, 0x44,
-	0xd3, 0xf1, 0xfa, 0x96, 0x4f, 0x9f, 0x91, 0x40, 0xf7, 0xa7, 0x92, 0xe4, 0x9c, 0x4a, 0x46, 0x5b,
-	0xd0, 0x27, 0x4a, 0x55, 0x43, 0x53, 0x12, 0x3a, 0x2e, 0x55, 0x05, 0x6c, 0x42, 0x2e, 0xba, 0xe3,
-	0x74, 0x53, 0x7a, 0xb3, 0xbe, 0x36, 0x16, 0xab, 0xfd, 0x39, 0x01, 0x1b, 0x73, 0x83, 0x8a, 0x5a,
-	0xb0, 0xd2, 0xa3, 0xde, 0x13, 0xd7, 0xe9, 0x49, 0xbb, 0x65, 0x07, 0xd4, 0x11, 0xba, 0x37, 0xe7,
-	0x70, 0x64, 0xc3, 0x33, 0x4b, 0x31, 0x31, 0x49, 0x11, 0x75, 0x2b, 0x7a, 0x1f, 0xf5, 0x2c, 0xdd,
-	0x9e, 0x13, 0xd2, 0xa7, 0x82, 0x22, 0x3e, 0x56, 0x4d, 0xfa, 0x08, 0x56, 0xbb, 0x17, 0xcf, 0xb1,
-	0xc7, 0x1d, 0x8f, 0xc4, 0x3a, 0x50, 0x39, 0x59, 0x4d, 0x6e, 0xe7, 0x1f, 0xde, 0x9d, 0x11, 0xe5,
-	0x10, 0x63, 0x7e, 0x2b, 0x12, 0x1c, 0xb7, 0xa7, 0x39, 0x81, 0x4f, 0xcd, 0x09, 0xfc, 0x6d, 0xc4,
-	0xf3, 0x00, 0x0a, 0xf1, 0x3a, 0x15, 0x75, 0x19, 0xab, 0x9e, 0xe4, 0xec, 0xba, 0x8c, 0xf2, 0xf4,
-	0x46, 0x55, 0xd7, 0x3e, 0x81, 0xf5, 0xd9, 0xfd, 0x04, 0x7d, 0x17, 0x96, 0x02, 0xfc, 0x4c, 0x35,
-	0x23, 0xcb, 0x75, 0x18, 0xd7, 0x8d, 0xab, 0x10, 0xe0, 0x67, 0x12, 0x21, 0x76, 0xaf, 0xfd, 0x1c,
-	0xb2, 0x61, 0xcd, 0xa3, 0x4f, 0xa0, 0x18, 0xd6, 0xfb, 0x58, 0x60, 0xe6, 0x35, 0xa6, 0x45, 0xcc,
-	0x42, 0x88, 0x97, 0xba, 0x3e, 0x85, 0x8c, 0x66, 0xa0, 0x6f, 0x43, 0xc1, 0xc3, 0x43, 0xc2, 0x7c,
-	0xdc, 0x23, 0xe2, 0x42, 0x54, 0x03, 0x44, 0x3e, 0xa2, 0xb5, 0x6c, 0x31, 0x5b, 0x88, 0xbb, 0x27,
-	0x1c, 0x72, 0xc4, 0xff, 0xda, 0x2f, 0x61, 0x5d, 0x74, 0xda, 0xc6, 0x19, 0x76, 0x5c, 0xdc, 0x75,
-	0x5c, 0x87, 0x5f, 0xe8, 0xd9, 0xe0, 0x2e, 0xe4, 0x02, 0xaa, 0xbd, 0xd1, 0x8e, 0x64, 0x03, 0xaa,
-	0x1c, 0x11, 0xbb, 0xf5, 0xa8, 0x3b, 0x1a, 0x7a, 0x51, 0xeb, 0x15, 0xfc, 0xbc, 0xa2, 0x49, 0x48,
-	0xed, 0xdf, 0x09, 0x48, 0x89, 0x82, 0x43, 0x1f, 0x41, 0x4a, 0xf8, 0x20, 0x2d, 0x5a, 0x9a, 0x35,
-	0xb3, 0x74, 0x9c, 0xbe, 0x47, 0xec, 0x43, 0xd6, 0x3f, 0xb9, 0xf0, 0x89, 0x29, 0xc1, 0xb1, 0x91,
-	0x21, 0x31, 0x31, 0x32, 0xac, 0x42, 0x3a, 0xa0, 0x23, 0xcf, 0x96, 0x95, 0x9a, 0x36, 0xd5, 0x02,
-	0xed, 0x43, 0x36, 0x9a, 0x04, 0x52, 0xff, 0x6b, 0x12, 0x58, 0x16, 0x07, 0x2a, 0xe6, 0x14, 0x4d,
-	0x30, 0x33, 0x5d, 0x3d, 0x10, 0xdc, 0x42, 0xb2, 0xa1, 0xf7, 0x61, 0x65, 0xdc, 0x29, 0xc2, 0x7b,
-	0x4e, 0x4d, 0x15, 0xa5, 0x88, 0xa1, 0x2f, 0xba, 0xc9, 0xb6, 0xa2, 0x86, 0xcc, 0x8c, 0xf4, 0x6b,
-	0xdc, 0x56, 0x5a, 0x72, 0xda, 0xbc, 0x07, 0x39, 0xe6, 0xf4, 0x3d, 0xcc, 0x47, 0x01, 0xd1, 0x73,
-	0xc4, 0x98, 0x50, 0xfb, 0xa7, 0x01, 0x8b, 0x6a, 0x5a, 0x89, 0xc5, 0xcd, 0x98, 0x1d, 0xb7, 0xc4,
-	0xbc, 0xb8, 0x25, 0xdf, 0x3e, 0x6e, 0x0d, 0x80, 0xc8, 0x18, 0x71, 0xd5, 0xcd, 0x69, 0x0c, 0xca,
-	0xc4, 0x8e, 0xd3, 0xd7, 0x35, 0x15, 0x13, 0x42, 0x5b, 0x90, 0x57, 0xa3, 0xad, 0x1a, 0x20, 0xd2,
-	0xd2, 0x45, 0x50, 0x24, 0x31, 0x3e, 0xd4, 0xfe, 0x66, 0x40, 0x2e, 0x52, 0x80, 0x1a, 0x50, 0x0c,
-	0x0d, 0xb7, 0x9e, 0xb8, 0xb8, 0xaf, 0x93, 0xeb, 0xfe, 0x5c, 0xeb, 0x3f, 0x73, 0x71, 0xdf, 0xcc,
-	0x6b, 0x83, 0xc5, 0x62, 0xf6, 0x41, 0x25, 0xe6, 0x1c, 0xd4, 0x44, 0x66, 0x24, 0xdf, 0x2e, 0x33,
-	0x26, 0xce, 0x30, 0x75, 0xf3, 0x0c, 0xbf, 0x4c, 0x42, 0xb6, 0x2d, 0xe7, 0x20, 0xec, 0x7e, 0x13,
-	0x25, 0x73, 0x17, 0x72, 0x3e, 0x75, 0x2d, 0xc5, 0x49, 0x49, 0x4e, 0xd6, 0xa7, 0xae, 0x39, 0x95,
-	0x17, 0xe9, 0x5b, 0xaa, 0xa7, 0xc5, 0x5b, 0x88, 0x5a, 0xe6, 0x46, 0xd4, 0x50, 0x47, 0xcc, 0xd7,
-	0xe1, 0xa3, 0x28, 0x3b, 0x6f, 0xfe, 0x9b, 0xdd, 0xe1, 0x9a, 0x85, 0xeb, 0x97, 0x5b, 0xd9, 0xbd,
-	0x86, 0x5a, 0x89, 0xb9, 0x5c, 0x3f, 0x90, 0x02, 0x28, 0xa8, 0xf8, 0xea, 0x4e, 0xf8, 0x40, 0x04,
-	0x56, 0xee, 0x60, 0x4c, 0xbf, 0xea, 0xd4, 0x0e, 0x5a, 0x87, 0xc6, 0x09, 0x09, 0xf5, 0xa8, 0xd0,
-	0xb3, 0x48, 0x79, 0x5e, 0x31, 0x98, 0x1a, 0x57, 0xfb, 0x8f, 0x01, 0x30, 0xbe, 0xab, 0xc5, 0xfb,
-	0x86, 0x49, 0x13, 0xac, 0x89, 0x9d, 0x2b, 0xf3, 0x32, 0x41, 0xef, 0x5f, 0x60, 0x71, 0xbb, 0x77,
-	0xa1, 0x38, 0xce, 0x70, 0x46, 0x42, 0x63, 0x2a, 0xaf, 0xb9, 0xb2, 0x3b, 0x84, 0x9b, 0x85, 0xb3,
-	0xd8, 0x6a, 0x32, 0xc2, 0xc9, 0x5b, 0x8a, 0xf0, 0xef, 0x12, 0x90, 0x93, 0x8e, 0x1e, 0x12, 0x8e,
-	0x27, 0xb2, 0xcd, 0x78, 0xfb, 0x6c, 0xbb, 0x0f, 0xa0, 0xd4, 0x30, 0xe7, 0x39, 0xd1, 0x35, 0x90,
-	0x93, 0x94, 0x8e, 0xf3, 0x9c, 0xa0, 0x1f, 0x45, 0xa7, 0x98, 0x7c, 0xfd, 0x29, 0xea, 0xee, 0x14,
-	0x9e, 0xe5, 0x1d, 0xc8, 0x88, 0xb7, 0x9f, 0x78, 0x87, 0xa8, 0x21, 0x65, 0xd1, 0x1b, 0x0d, 0x4f,
-	0xce, 0x19, 0xda, 0x8f, 0x47, 0x26, 0xfd, 0xff, 0x45, 0x26, 0x16, 0x8b, 0xdf, 0x40, 0xe6, 0xe4,
-	0x5c, 0x7e, 0x14, 0x50, 0x57, 0x2e, 0xd5, 0x2f, 0x51, 0x75, 0x81, 0x67, 0x05, 0x41, 0xbe, 0x9f,
-	0x66, 0xdc, 0xde, 0xa8, 0xfe, 0x86, 0x9f, 0x1b, 0xf4, 0x87, 0x86, 0xf7, 0xfe, 0x62, 0x40, 0x3e,
-	0xd6, 0x10, 0xd1, 0x0f, 0x60, 0xad, 0x79, 0x70, 0xbc, 0xfb, 0xb9, 0xd5, 0xda, 0xb3, 0x3e, 0x3b,
-	0x68, 0x3c, 0xb2, 0xbe, 0x38, 0xfa, 0xfc, 0xe8, 0xf8, 0x17, 0x47, 0xa5, 0x85, 0xcd, 0xf5, 0xcb,
-	0xab, 0x2a, 0x8a, 0x61, 0xbf, 0xf0, 0x9e, 0x7a, 0xf4, 0x99, 0x87, 0x76, 0x60, 0x75, 0x52, 0xa4,
-	0xd1, 0xec, 0xec, 0x1f, 0x9d, 0x94, 0x8c, 0xcd, 0xb5, 0xcb, 0xab, 0xea, 0x4a, 0x4c, 0xa2, 0xd1,
-	0x65, 0xc4, 0xe3, 0xd3, 0x02, 0xbb, 0xc7, 0x87, 0x87, 0xad, 0x93, 0x52, 0x62, 0x4a, 0x40, 0x5f,
-	0x61, 0xef, 0xc2, 0xca, 0xa4, 0xc0, 0x51, 0xeb, 0xa0, 0x94, 0xdc, 0x44, 0x97, 0x57, 0xd5, 0xa5,
-	0x18, 0xfa, 0xc8, 0x71, 0x37, 0xb3, 0x5f, 0xfe, 0xa1, 0xb2, 0xf0, 0xa7, 0x3f, 0x56, 0x0c, 0xe1,
-	0x59, 0x71, 0xa2, 0x29, 0xa2, 0x0f, 0xe0, 0x4e, 0xa7, 0xf5, 0xe8, 0x68, 0x7f, 0xcf, 0x3a, 0xec,
-	0x3c, 0xb2, 0x4e, 0x7e, 0xd5, 0xde, 0x8f, 0x79, 0xb7, 0x7c, 0x79, 0x55, 0xcd, 0x6b, 0x97, 0xe6,
-	0xa1, 0xdb, 0xe6, 0xfe, 0xe9, 0xf1, 0xc9, 0x7e, 0xc9, 0x50, 0xe8, 0x76, 0x40, 0xc4, 0xcb, 0x41,
-	0xa2, 0x1f, 0xc0, 0xc6, 0x0c, 0x74, 0xe4, 0xd8, 0xca, 0xe5, 0x55, 0xb5, 0xd8, 0x0e, 0x88, 0xaa,
-	0x6d, 0x29, 0x51, 0x87, 0xf2, 0xb4, 0xc4, 0x71, 0xfb, 0xb8, 0xd3, 0x38, 0x28, 0x55, 0x37, 0x4b,
-	0x97, 0x57, 0xd5, 0x42, 0xd8, 0xfd, 0x05, 0x7e, 0xec, 0x59, 0xf3, 0xf4, 0xab, 0xeb, 0x8a, 0xf1,
-	0xf5, 0x75, 0xc5, 0xf8, 0xc7, 0x75, 0xc5, 0x78, 0xf1, 0xaa, 0xb2, 0xf0, 0xf5, 0xab, 0xca, 0xc2,
-	0x5f, 0x5f, 0x55, 0x16, 0x7e, 0xfd, 0x71, 0xdf, 0xe1, 0x83, 0x51, 0xb7, 0xde, 0xa3, 0xc3, 0x1d,
-	0x17, 0x3f, 0xbf, 0x70, 0x89, 0xdd, 0x27, 0x41, 0xec, 0xef, 0x87, 0x3d, 0x1a, 0xe8, 0x8f, 0x6f,
-	0x3b, 0x37, 0xbf, 0x94, 0x75, 0x17, 0x25, 0xfd, 0xa3, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0xfb,
-	0xec, 0xf0, 0x8b, 0xea, 0x13, 0x00, 0x00,
+	0xf1, 0xd7, 0xf0, 0xcd, 0x22, 0x29, 0x51, 0xfd, 0x97, 0x65, 0x4a, 0xb6, 0x29, 0xfe, 0xb9, 0x49,
+	0x56, 0xfb, 0xa2, 0x36, 0xde, 0x20, 0x9b, 0x00, 0x9b, 0x64, 0x49, 0x49, 0xb6, 0x99, 0xd5, 0x0b,
+	0x43, 0xad, 0x37, 0xc9, 0x65, 0xd2, 0xe2, 0xb4, 0xc9, 0x89, 0x87, 0x33, 0xc4, 0x74, 0x53, 0x96,
+	0x7c, 0x0c, 0x10, 0x60, 0xa3, 0x93, 0xbf, 0x80, 0x90, 0x43, 0x72, 0xd8, 0x8f, 0xb2, 0x97, 0x00,
+	0x7b, 0x4b, 0x72, 0x88, 0x93, 0xc8, 0x97, 0x00, 0xc9, 0x87, 0x08, 0xba, 0xba, 0x67, 0x38, 0x14,
+	0xc9, 0xac, 0xd7, 0x30, 0x72, 0x21, 0xd8, 0x55, 0xbf, 0x7a, 0x74, 0x75, 0x75, 0x55, 0xf5, 0xc0,
+	0x6d, 0xc1, 0x3c, 0x9b, 0x05, 0x03, 0xc7, 0x13, 0x5b, 0xe2, 0x7c, 0xc8, 0xb8, 0xfa, 0x6d, 0x0c,
+	0x03, 0x5f, 0xf8, 0xa4, 0x3c, 0xe6, 0x36, 0x90, 0xbe, 0xbe, 0xd2, 0xf3, 0x7b, 0x3e, 0x32, 0xb7,
+	0xe4, 0x3f, 0x85, 0x5b, 0xdf, 0xe8, 0xf9, 0x7e, 0xcf, 0x65, 0x5b, 0xb8, 0x3a, 0x19, 0x3d, 0xda,
+	0x12, 0xce, 0x80, 0x71, 0x41, 0x07, 0x43, 0x0d, 0xb8, 0x13, 0x33, 0xd3, 0x0d, 0xce, 0x87, 0xc2,
+	0x97, 0x58, 0xff, 0x91, 0x66, 0x57, 0x63, 0xec, 0x53, 0x16, 0x70, 0xc7, 0xf7, 0xe2, 0x7e, 0xac,
+	0xd7, 0xa6, 0xbc, 0x3c, 0xa5, 0xae, 0x63, 0x53, 0xe1, 0x07, 0x0a, 0x51, 0xff, 0x21, 0x94, 0x8e,
+	0x68, 0x20, 0x3a, 0x4c, 0x3c, 0x60, 0xd4, 0x66, 0x01, 0x59, 0x81, 0xb4, 0xf0, 0x05, 0x75, 0x2b,
+	0x46, 0xcd, 0xd8, 0x2c, 0x99, 0x6a, 0x41, 0x08, 0xa4, 0xfa, 0x94, 0xf7, 0x2b, 0x89, 0x9a, 0xb1,
+	0x59, 0x34, 0xf1, 0x7f, 0xbd, 0x0f, 0x29, 0x29, 0x2a, 0x25, 0x1c, 0xcf, 0x66, 0x67, 0xa1, 0x04,
+	0x2e, 0x24, 0xf5, 0xe4, 0x5c, 0x30, 0xae, 0x45, 0xd4, 0x82, 0x7c, 0x0f, 0xd2, 0xe8, 0x7f, 0x25,
+	0x59, 0x33, 0x36, 0x0b, 0x77, 0x2b, 0x8d, 0x58, 0xa0, 0xd4, 0xfe, 0x1a, 0x47, 0x92, 0xdf, 0x4a,
+	0x7d, 0xf9, 0x7c, 0x63, 0xc1, 0x54, 0xe0, 0xba, 0x0b, 0xd9, 0x96, 0xeb, 0x77, 0x1f, 0xb7, 0x77,
+	0x22, 0x47, 0x8c, 0xb1, 0x23, 0x64, 0x1f, 0x96, 0x86, 0x34, 0x10, 0x16, 0x67, 0xc2, 0xea, 0xe3,
+	0x2e, 0xd0, 0x68, 0xe1, 0xee, 0x46, 0xe3, 0xfa, 0x39, 0x34, 0x26, 0x36, 0xab, 0xad, 0x94, 0x86,
+	0x71, 0x62, 0xfd, 0x77, 0x69, 0xc8, 0xe8, 0x60, 0xfc, 0x08, 0xb2, 0x3a, 0xac, 0x68, 0xb0, 0x70,
+	0xf7, 0x4e, 0x5c, 0xa3, 0x66, 0x35, 0xb6, 0x7d, 0x8f, 0x33, 0x8f, 0x8f, 0xb8, 0xd6, 0x17, 0xca,
+	0x90, 0xef, 0x40, 0xae, 0xdb, 0xa7, 0x8e, 0x67, 0x39, 0x36, 0x7a, 0x94, 0x6f, 0x15, 0xae, 0x9e,
+	0x6f, 0x64, 0xb7, 0x25, 0xad, 0xbd, 0x63, 0x66, 0x91, 0xd9, 0xb6, 0xc9, 0x2a, 0x64, 0xfa, 0xcc,
+	0xe9, 0xf5, 0x05, 0x86, 0x25, 0x69, 0xea, 0x15, 0xf9, 0x01, 0xa4, 0x64, 0x42, 0x54, 0x52, 0x68,
+	0x7b, 0xbd, 0xa1, 0xb2, 0xa5, 0x11, 0x66, 0x4b, 0xe3, 0x38, 0xcc, 0x96, 0x56, 0x4e, 0x1a, 0x7e,
+	0xf6, 0xb7, 0x0d, 0xc3, 0x44, 0x09, 0xb2, 0x0d, 0x25, 0x97, 0x72, 0x61, 0x9d, 0xc8, 0xb0, 0x49,
+	0xf3, 0x69, 0x54, 0xb1, 0x36, 0x1d, 0x10, 0x1d, 0x58, 0xed, 0x7a, 0x41, 0x4a, 0x29, 0x92, 0x4d,
+	0x36, 0xa1, 0x8c, 0x4a, 0xba, 0xfe, 0x60, 0xe0, 0x08, 0x0b, 0xe3, 0x9e, 0xc1, 0xb8, 0x2f, 0x4a,
+	0xfa, 0x36, 0x92, 0x1f, 0xc8, 0x13, 0xf8, 0x10, 0x2a, 0xde, 0x68, 0x60, 0xf9, 0x81, 0xd3, 0x73,
+	0x3c, 0xea, 0x5a, 0x36, 0x15, 0xd4, 0xe2, 0x7d, 0x1a, 0x30, 0x5e, 0xc9, 0xd6, 0x8c, 0xcd, 0x94,
+	0x79, 0xc3, 0x1b, 0x0d, 0x0e, 0x35, 0x7b, 0x87, 0x0a, 0xda, 0x41, 0x26, 0xb9, 0x05, 0x79, 0xc4,
+	0xa2, 0xee, 0x1c, 0xea, 0xce, 0x49, 0x02, 0x6a, 0x7d, 0x13, 0x96, 0xa2, 0x74, 0xe5, 0x0a, 0x92,
+	0x57, 0xe6, 0xc7, 0x64, 0x04, 0xbe, 0x0f, 0x2b, 0x1e, 0x3b, 0x13, 0xd6, 0x75, 0x34, 0x20, 0x9a,
+	0x48, 0xde, 0xc3, 0x49, 0x89, 0x6f, 0xc3, 0x62, 0x37, 0x3c, 0x35, 0x85, 0x2d, 0x20, 0xb6, 0x14,
+	0x51, 0x11, 0xb6, 0x06, 0x39, 0x3a, 0x1c, 0x2a, 0x40, 0x11, 0x01, 0x59, 0x3a, 0x1c, 0x22, 0xeb,
+	0x6d, 0x58, 0xc6, 0xe0, 0x04, 0x8c, 0x8f, 0x5c, 0xa1, 0x95, 0x94, 0x10, 0xb3, 0x24, 0x19, 0xa6,
+	0xa2, 0x23, 0xf6, 0x0d, 0x28, 0xb1, 0x53, 0xc7, 0x66, 0x5e, 0x97, 0x29, 0xdc, 0x22, 0xe2, 0x8a,
+	0x21, 0x11, 0x41, 0x6f, 0x41, 0x79, 0x18, 0xf8, 0x43, 0x9f, 0xb3, 0xc0, 0xa2, 0xb6, 0x1d, 0x30,
+	0xce, 0x2b, 0x4b, 0x4a, 0x5f, 0x48, 0x6f, 0x2a, 0x72, 0xfd, 0xd7, 0x09, 0x48, 0xc9, 0x20, 0x92,
+	0x32, 0x24, 0xc5, 0x19, 0xaf, 0x18, 0xb5, 0xe4, 0x66, 0xd1, 0x94, 0x7f, 0x49, 0x1f, 0x2a, 0x8e,
+	0x27, 0x58, 0x30, 0x60, 0xb6, 0x43, 0x05, 0xb3, 0xb8, 0x90, 0xbf, 0x81, 0xef, 0x0b, 0xae, 0x2f,
+	0xc5, 0xe6, 0x74, 0x0e, 0xb4, 0x63, 0x12, 0x1d, 0x29, 0x60, 0x4a, 0xbc, 0x4e, 0x89, 0x55, 0x67,
+	0x26, 0x97, 0x7c, 0x0c, 0xb9, 0xd0, 0x7f, 0x7d, 0x9b, 0xab, 0xd3, 0x9a, 0x77, 0x35, 0x62, 0xcf,
+	0xe1, 0x42, 0xeb, 0x8b, 0xa4, 0xc8, 0x47, 0x90, 0x1b, 0x30, 0xce, 0x69, 0x8f, 0xf1, 0x28, 0xc5,
+	0xa7, 0x34, 0xec, 0x6b, 0x44, 0x28, 0x1d, 0x4a, 0xd4, 0xff, 0x9d, 0x80, 0x5c, 0xa8, 0x9e, 0x50,
+	0xb8, 0x69, 0x8f, 0x86, 0xae, 0xd3, 0x95, 0xbb, 0x3d, 0xf5, 0x05, 0xb3, 0x22, 0xdf, 0xd4, 0xc5,
+	0x7d, 0x73, 0x5a, 0xf3, 0x4e, 0x28, 0xf0, 0xd0, 0x17, 0x2c, 0xd4, 0xf4, 0x60, 0xc1, 0xbc, 0x61,
+	0xcf, 0x62, 0x10, 0x0f, 0x6e, 0xbb, 0xf2, 0x56, 0x5a, 0x5d, 0xd7, 0x61, 0x9e, 0xb0, 0xa8, 0x10,
+	0xb4, 0xfb, 0x78, 0x6c, 0x47, 0x45, 0xf7, 0x9d, 0x69, 0x3b, 0x7b, 0x52, 0x6a, 0x1b, 0x85, 0x9a,
+	0x28, 0x13, 0xb3, 0xb5, 0xe6, 0xce, 0x63, 0x92, 0xdf, 0x18, 0xb0, 0xee, 0x78, 0x98, 0xd2, 0x96,
+	0x4d, 0x75, 0x61, 0xb3, 0xae, 0x85, 0xfc, 0xad, 0x59, 0x87, 0x89, 0x32, 0x3b, 0x4d, 0x55, 0xc3,
+	0x42, 0x7d, 0xad, 0x5b, 0x57, 0xcf, 0x37, 0x6e, 0xce, 0x61, 0x3e, 0x58, 0x30, 0x6f, 0x6a, 0x5b,
+	0x3b, 0xf4, 0x9a, 0x5c, 0x1a, 0x92, 0x7c, 0x34, 0xa8, 0x3f, 0x4b, 0xc0, 0x8d, 0x99, 0x11, 0x23,
+	0xef, 0x41, 0x06, 0x23, 0x4e, 0x75, 0xa8, 0x57, 0xa7, 0x7d, 0x92, 0x78, 0x33, 0x2d, 0x51, 0xcd,
+	0x08, 0x7e, 0x52, 0x49, 0x7c, 0x3d, 0xbc, 0x45, 0xde, 0x05, 0x82, 0x2d, 0x48, 0x9e, 0xaa, 0xe3,
+	0xf5, 0xac, 0xa1, 0xff, 0x84, 0x05, 0xba, 0x4e, 0x96, 0x91, 0xf3, 0x10, 0x19, 0x47, 0x92, 0x3e,
+	0x51, 0x32, 0x34, 0x34, 0x85, 0xd0, 0x71, 0xc9, 0x50, 0xc0, 0x16, 0xe4, 0xa3, 0x5e, 0x5b, 0x49,
+	0x7f, 0x83, 0xfa, 0x3a, 0x16, 0xab, 0xff, 0x31, 0x01, 0x6b, 0x73, 0x0f, 0x97, 0xb4, 0x61, 0xb9,
+	0xeb, 0x7b, 0x8f, 0x5c, 0xa7, 0x8b, 0x7e, 0x63, 0x25, 0xd6, 0x11, 0xba, 0x3d, 0x27, 0x49, 0xb0,
+	0xf0, 0x9a, 0xe5, 0x98, 0x18, 0x52, 0x64, 0xfd, 0x90, 0x35, 0xd8, 0xf7, 0x2c, 0xdd, 0x26, 0x12,
+	0xb8, 0xa7, 0xa2, 0x22, 0x3e, 0x40, 0x1a, 0x39, 0x80, 0x95, 0x93, 0xf3, 0xa7, 0xd4, 0x13, 0x8e,
+	0xc7, 0x62, 0x95, 0xb0, 0x92, 0xac, 0x25, 0x37, 0x0b, 0x77, 0x6f, 0xcd, 0x88, 0x72, 0x88, 0x31,
+	0xff, 0x2f, 0x12, 0x8c, 0x68, 0x7c, 0x4e, 0xe0, 0x53, 0x73, 0x02, 0xff, 0x3a, 0xe2, 0xf9, 0x45,
+	0x02, 0xd6, 0xb0, 0xc0, 0x1c, 0x07, 0xd4, 0xe3, 0x8e, 0x70, 0x7c, 0xef, 0x5e, 0x40, 0x47, 0x36,
+	0x4e, 0x04, 0xb1, 0x26, 0x69, 0x4c, 0x34, 0xc9, 0x6f, 0xc1, 0xe2, 0x23, 0x27, 0xe0, 0xc2, 0x12,
+	0x67, 0x96, 0x9a, 0x43, 0x12, 0x38, 0x87, 0x14, 0x91, 0x7a, 0x7c, 0xd6, 0x96, 0x34, 0x72, 0x13,
+	0xb2, 0xb2, 0x43, 0xc9, 0x6a, 0x99, 0x44, 0x76, 0xc6, 0x1b, 0x0d, 0x8e, 0xcf, 0x38, 0xf9, 0x09,
+	0xe4, 0xc5, 0x99, 0x85, 0x73, 0x86, 0xac, 0x42, 0xc9, 0xd9, 0xc7, 0x83, 0xed, 0x2a, 0x3e, 0x99,
+	0xe4, 0xc4, 0x19, 0x2e, 0x39, 0x39, 0x00, 0x70, 0x78, 0x10, 0x6a, 0x48, 0xbf, 0x84, 0x86, 0x65,
+	0xa9, 0xe1, 0xea, 0xf9, 0x46, 0xbe, 0xdd, 0x31, 0x95, 0x12, 0x33, 0xef, 0xf0, 0x40, 0xeb, 0x7b,
+	0x03, 0x4a, 0xaa, 0x68, 0x3f, 0x71, 0x84, 0x27, 0x9b, 0x80, 0x6a, 0xb9, 0x45, 0x24, 0x7e, 0xa6,
+	0x68, 0xf5, 0xbf, 0x24, 0x61, 0xde, 0x5d, 0x26, 0xdf, 0x87, 0x9c, 0x6a, 0x18, 0x7a, 0x88, 0x9b,
+	0x59, 0x56, 0x8f, 0x34, 0xc2, 0x8c, 0xb0, 0x33, 0x1b, 0x50, 0x62, 0x66, 0x03, 0x22, 0x1f, 0x42,
+	0x41, 0x4d, 0x16, 0x72, 0x72, 0x0a, 0x53, 0x6c, 0x75, 0xf6, 0xb4, 0x65, 0x02, 0x42, 0xe5, 0x5f,
+	0xfe, 0xf2, 0xf7, 0x73, 0x76, 0xf6, 0xa5, 0x5f, 0x26, 0xfb, 0x32, 0xaf, 0x94, 0x7d, 0x32, 0xbf,
+	0xba, 0xbe, 0x3b, 0x1a, 0x78, 0x38, 0xb1, 0xe4, 0x4c, 0xbd, 0x1a, 0x8f, 0xb7, 0xb9, 0xf8, 0x78,
+	0xbb, 0x0b, 0x45, 0x9c, 0x6f, 0xc2, 0x73, 0xcf, 0xbf, 0x74, 0xe6, 0x14, 0x78, 0x44, 0xe1, 0x72,
+	0x9c, 0x95, 0xbd, 0x59, 0x4f, 0x2a, 0xf8, 0xbf, 0x7e, 0x00, 0x30, 0x16, 0x9a, 0x3f, 0x5d, 0xa3,
+	0x9a, 0x70, 0xba, 0xc6, 0x85, 0xa4, 0x7a, 0xbe, 0xcd, 0xd4, 0x81, 0x14, 0x4d, 0xb5, 0xa8, 0xef,
+	0x41, 0x31, 0xde, 0x86, 0x65, 0xdb, 0x8d, 0x35, 0xc7, 0xe4, 0xec, 0xfc, 0x88, 0xca, 0xff, 0xb5,
+	0xa6, 0x5d, 0xff, 0x25, 0xac, 0xce, 0x1e, 0x17, 0xe4, 0x45, 0x0c, 0xe8, 0x13, 0x35, 0x6b, 0x58,
+	0xae, 0xc3, 0x85, 0x9e, 0x4b, 0x8a, 0x01, 0x7d, 0x82, 0x08, 0xb4, 0x5e, 0x83, 0xa2, 0x38, 0xe3,
+	0xd6, 0x90, 0x05, 0x88, 0xd4, 0x97, 0x15, 0xc4, 0x19, 0x3f, 0x62, 0x81, 0x84, 0xd5, 0x7f, 0x0a,
+	0xb9, 0xb0, 0xe9, 0x93, 0x1f, 0x43, 0x29, 0x6c, 0xf8, 0x63, 0x95, 0x33, 0xe7, 0x58, 0x2d, 0x62,
+	0x16, 0x43, 0xbc, 0xb4, 0x56, 0xff, 0x18, 0xb2, 0x9a, 0x41, 0xfe, 0x1f, 0x8a, 0x1e, 0x1d, 0x30,
+	0x3e, 0xa4, 0x5d, 0x26, 0x27, 0x62, 0xf5, 0x82, 0x28, 0x44, 0xb4, 0xb6, 0x2d, 0x4f, 0xc3, 0xa6,
+	0x82, 0x86, 0xaf, 0x1c, 0xf9, 0xbf, 0xfe, 0x33, 0x58, 0x95, 0xa3, 0x56, 0xf3, 0x94, 0x3a, 0x2e,
+	0x3d, 0x71, 0x5c, 0x47, 0x9c, 0xeb, 0xc7, 0xc1, 0x2d, 0xc8, 0x07, 0xbe, 0xde, 0xaf, 0xde, 0x6a,
+	0x2e, 0xf0, 0xd5, 0x56, 0xa5, 0x35, 0x95, 0x3f, 0xd1, 0xec, 0x25, 0xf9, 0x05, 0x45, 0x43, 0x48,
+	0xfd, 0x9f, 0x09, 0x48, 0xc9, 0x4e, 0x47, 0x3e, 0x80, 0x94, 0xdc, 0x03, 0x7a, 0xb4, 0x38, 0xeb,
+	0xd1, 0xd2, 0x71, 0x7a, 0x1e, 0xb3, 0xf7, 0x79, 0xef, 0xf8, 0x7c, 0xc8, 0x4c, 0x04, 0xc7, 0xca,
+	0x61, 0x62, 0xa2, 0x1c, 0xae, 0x40, 0x3a, 0xf0, 0x47, 0x9e, 0x8d, 0x65, 0x2e, 0x6d, 0xaa, 0x05,
+	0xd9, 0x85, 0x5c, 0xf4, 0x14, 0x48, 0x7d, 0xdd, 0x53, 0x60, 0x49, 0xd7, 0xa7, 0xf0, 0xd1, 0x65,
+	0x66, 0x4f, 0xf4, 0x8b, 0xe0, 0x35, 0x54, 0x79, 0xf2, 0x0e, 0x2c, 0x8f, 0x4b, 0x40, 0x58, 0x67,
+	0x54, 0x8d, 0x2b, 0x47, 0x8c, 0xb0, 0xd0, 0x4c, 0xd4, 0x0b, 0x75, 0x0f, 0xb2, 0xb8, 0xaf, 0x71,
+	0xbd, 0x50, 0xf5, 0xfd, 0x36, 0xe4, 0xb9, 0xd3, 0xf3, 0xa8, 0x18, 0x05, 0x4c, 0x3f, 0x24, 0xc6,
+	0x84, 0xfa, 0x3f, 0x0c, 0xc8, 0xa8, 0xe7, 0xca, 0xdc, 0x36, 0x12, 0xc5, 0x2d, 0x31, 0x2f, 0x6e,
+	0xc9, 0x57, 0x8f, 0x5b, 0x13, 0x20, 0x72, 0x26, 0xec, 0x32, 0x33, 0x3a, 0xb2, 0x72, 0xb1, 0xe3,
+	0xf4, 0xf4, 0xad, 0x8b, 0x09, 0x91, 0x0d, 0x28, 0xe8, 0x11, 0x10, 0x5f, 0x10, 0x69, 0xdc, 0x22,
+	0x28, 0x92, 0x7c, 0x3f, 0xd4, 0xff, 0x6a, 0x40, 0x3e, 0x52, 0x40, 0x9a, 0x50, 0x0a, 0x1d, 0xb7,
+	0x1e, 0xb9, 0xb4, 0xa7, 0x93, 0xeb, 0xce, 0x5c, 0xef, 0xef, 0xb9, 0xb4, 0x67, 0x16, 0xb4, 0xc3,
+	0x72, 0x31, 0xfb, 0xa0, 0x12, 0x73, 0x0e, 0x6a, 0x22, 0x33, 0x92, 0xaf, 0x96, 0x19, 0x13, 0x67,
+	0x98, 0xba, 0x7e, 0x86, 0x9f, 0x27, 0x21, 0x17, 0x76, 0xad, 0xff, 0xc5, 0x95, 0xb9, 0x05, 0xf9,
+	0xa1, 0xef, 0x5a, 0x8a, 0x93, 0x42, 0x4e, 0x6e, 0xe8, 0xbb, 0xe6, 0x54, 0x5e, 0xa4, 0x5f, 0xd3,
+	0x7d, 0xca, 0xbc, 0x86, 0xa8, 0x65, 0xaf, 0x45, 0x8d, 0x74, 0x20, 0x1f, 0x3d, 0x1e, 0x2a, 0xb9,
+	0x79, 0x0f, 0xc0, 0xd9, 0x15, 0xae, 0x55, 0xbc, 0x7a, 0xbe, 0x91, 0x0b, 0xe7, 0x0b, 0xf9, 0x30,
+	0x57, 0xff, 0xea, 0x01, 0x14, 0x55, 0x7c, 0xd5, 0x9a, 0xbc, 0x2f, 0x03, 0x8b, 0x16, 0x8c, 0xe9,
+	0xcf, 0x3a, 0xca, 0x82, 0xd6, 0x91, 0xe9, 0x47, 0x12, 0xea, 0xab, 0x42, 0x25, 0x31, 0x4f, 0x42,
+	0xe5, 0xb2, 0xa9, 0x71, 0xf5, 0x7f, 0x19, 0x00, 0xe3, 0x21, 0x59, 0x7e, 0xe0, 0xe0, 0xe8, 0x82,
+	0x35, 0x61, 0xb9, 0x3a, 0x2f, 0x13, 0xb4, 0xfd, 0x22, 0x8f, 0xfb, 0xbd, 0x0d, 0xa5, 0x71, 0x86,
+	0x73, 0x16, 0x3a, 0x53, 0xfd, 0x2f, 0xb3, 0x72, 0x87, 0x09, 0xb3, 0x78, 0x1a, 0x5b, 0x4d, 0x46,
+	0x38, 0xf9, 0x9a, 0x22, 0xfc, 0xdb, 0x04, 0xe4, 0x71, 0xa3, 0xfb, 0x4c, 0xd0, 0x89, 0x6c, 0x33,
+	0x5e, 0x3d, 0xdb, 0xee, 0x80, 0x1a, 0xc5, 0x2c, 0xee, 0x3c, 0x65, 0xfa, 0x0e, 0xe4, 0x91, 0xd2,
+	0x71, 0x9e, 0xca, 0xb9, 0x31, 0x33, 0xb1, 0x8b, 0xb9, 0xa7, 0xa8, 0xab, 0x53, 0x78, 0x96, 0xb1,
+	0xd1, 0x5a, 0xcd, 0x72, 0xe1, 0x68, 0xbd, 0x1b, 0x8f, 0x4c, 0xfa, 0x9b, 0x45, 0x26, 0x16, 0x8b,
+	0x5f, 0x41, 0xf6, 0x58, 0x0d, 0xdb, 0xaa, 0xe5, 0xfa, 0xfa, 0x53, 0x94, 0x6a, 0xe0, 0x39, 0x49,
+	0xc0, 0x0f, 0x28, 0x33, 0xba, 0x37, 0x69, 0xbc, 0xe4, 0xf7, 0x46, 0xfd, 0xa5, 0xf1, 0xed, 0x3f,
+	0x19, 0x50, 0x88, 0x15, 0x44, 0xf2, 0x5d, 0xb8, 0xd1, 0xda, 0x3b, 0xdc, 0xfe, 0xc4, 0x6a, 0xef,
+	0x58, 0xf7, 0xf6, 0x9a, 0xf7, 0xad, 0x4f, 0x0f, 0x3e, 0x39, 0x38, 0xfc, 0xec, 0xa0, 0xbc, 0xb0,
+	0xbe, 0x7a, 0x71, 0x59, 0x23, 0x31, 0xec, 0xa7, 0xde, 0x63, 0xcf, 0x7f, 0xe2, 0x91, 0x2d, 0x58,
+	0x99, 0x14, 0x69, 0xb6, 0x3a, 0xbb, 0x07, 0xc7, 0x65, 0x63, 0xfd, 0xc6, 0xc5, 0x65, 0x6d, 0x39,
+	0x26, 0xd1, 0x3c, 0xe1, 0xcc, 0x13, 0xd3, 0x02, 0xdb, 0x87, 0xfb, 0xfb, 0xed, 0xe3, 0x72, 0x62,
+	0x4a, 0x40, 0xb7, 0xb0, 0xb7, 0x60, 0x79, 0x52, 0xe0, 0xa0, 0xbd, 0x57, 0x4e, 0xae, 0x93, 0x8b,
+	0xcb, 0xda, 0x62, 0x0c, 0x7d, 0xe0, 0xb8, 0xeb, 0xb9, 0xcf, 0x7f, 0x5f, 0x5d, 0xf8, 0xe2, 0x0f,
+	0x55, 0x43, 0xee, 0xac, 0x34, 0x51, 0x14, 0xc9, 0xbb, 0x70, 0xb3, 0xd3, 0xbe, 0x7f, 0xb0, 0xbb,
+	0x63, 0xed, 0x77, 0xee, 0x5b, 0xc7, 0x3f, 0x3f, 0xda, 0x8d, 0xed, 0x6e, 0xe9, 0xe2, 0xb2, 0x56,
+	0xd0, 0x5b, 0x9a, 0x87, 0x3e, 0x32, 0x77, 0x1f, 0x1e, 0x1e, 0xef, 0x96, 0x0d, 0x85, 0x3e, 0x0a,
+	0x98, 0x7c, 0xb2, 0x23, 0xfa, 0x7d, 0x58, 0x9b, 0x81, 0x8e, 0x36, 0xb6, 0x7c, 0x71, 0x59, 0x2b,
+	0x1d, 0x05, 0x4c, 0xdd, 0x6d, 0x94, 0x68, 0x40, 0x65, 0x5a, 0xe2, 0xf0, 0xe8, 0xb0, 0xd3, 0xdc,
+	0x2b, 0xd7, 0xd6, 0xcb, 0x17, 0x97, 0xb5, 0x62, 0x58, 0xfd, 0x25, 0x7e, 0xbc, 0xb3, 0xd6, 0xc3,
+	0x2f, 0xaf, 0xaa, 0xc6, 0x57, 0x57, 0x55, 0xe3, 0xef, 0x57, 0x55, 0xe3, 0xd9, 0x8b, 0xea, 0xc2,
+	0x57, 0x2f, 0xaa, 0x0b, 0x7f, 0x7e, 0x51, 0x5d, 0xf8, 0xc5, 0x47, 0x3d, 0x47, 0xf4, 0x47, 0x27,
+	0x8d, 0xae, 0x3f, 0xd8, 0x72, 0xe9, 0xd3, 0x73, 0x97, 0xd9, 0x3d, 0x16, 0xc4, 0xfe, 0xbe, 0xd7,
+	0xf5, 0x03, 0xfd, 0xf5, 0x7d, 0xeb, 0xfa, 0xa7, 0xf2, 0x93, 0x0c, 0xd2, 0x3f, 0xf8, 0xcf, 0x00,
+	0x6a, 0xf2, 0x56, 0x46, 0xeb, 0x17, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -2116,6 +2432,27 @@ func (m *Evidence_LightClientAttackEvidence) MarshalToSizedBuffer(dAtA []byte) (
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Evidence_InvalidDAHeaderEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Evidence_InvalidDAHeaderEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.InvalidDAHeaderEvidence != nil {
+		{
+			size, err := m.InvalidDAHeaderEvidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *DuplicateVoteEvidence) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2136,12 +2473,12 @@ func (m *DuplicateVoteEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err11 != nil {
-		return 0, err11
+	n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err13 != nil {
+		return 0, err13
 	}
-	i -= n11
-	i = encodeVarintTypes(dAtA, i, uint64(n11))
+	i -= n13
+	i = encodeVarintTypes(dAtA, i, uint64(n13))
 	i--
 	dAtA[i] = 0x2a
 	if m.ValidatorPower != 0 {
@@ -2201,12 +2538,12 @@ func (m *LightClientAttackEvidence) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
-	n14, err14 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err14 != nil {
-		return 0, err14
+	n16, err16 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err16 != nil {
+		return 0, err16
 	}
-	i -= n14
-	i = encodeVarintTypes(dAtA, i, uint64(n14))
+	i -= n16
+	i = encodeVarintTypes(dAtA, i, uint64(n16))
 	i--
 	dAtA[i] = 0x2a
 	if m.TotalVotingPower != 0 {
@@ -2248,7 +2585,7 @@ func (m *LightClientAttackEvidence) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
-func (m *EvidenceList) Marshal() (dAtA []byte, err error) {
+func (m *StateTransitionFraudProof) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2258,20 +2595,27 @@ func (m *EvidenceList) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *EvidenceList) MarshalTo(dAtA []byte) (int, error) {
+func (m *StateTransitionFraudProof) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *EvidenceList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *StateTransitionFraudProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Evidence) > 0 {
-		for iNdEx := len(m.Evidence) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.StateWitness) > 0 {
+		i -= len(m.StateWitness)
+		copy(dAtA[i:], m.StateWitness)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.StateWitness)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.ISRProofs) > 0 {
+		for iNdEx := len(m.ISRProofs) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Evidence[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.ISRProofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -2279,7 +2623,227 @@ func (m *EvidenceList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintTypes(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0xa
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.TxProofs) > 0 {
+		for iNdEx := len(m.TxProofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TxProofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.NumTxs != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.NumTxs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FirstTxIndex != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.FirstTxIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InvalidDAHeaderEvidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InvalidDAHeaderEvidence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InvalidDAHeaderEvidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Root) > 0 {
+		i -= len(m.Root)
+		copy(dAtA[i:], m.Root)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Root)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.ShareProofs) > 0 {
+		for iNdEx := len(m.ShareProofs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ShareProofs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Column {
+		i--
+		if m.Column {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	n18, err18 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err18 != nil {
+		return 0, err18
+	}
+	i -= n18
+	i = encodeVarintTypes(dAtA, i, uint64(n18))
+	i--
+	dAtA[i] = 0x32
+	if m.TotalVotingPower != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TotalVotingPower))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ValidatorPower != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ValidatorPower))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.BlockParts) > 0 {
+		for iNdEx := len(m.BlockParts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BlockParts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ProposerAddress) > 0 {
+		i -= len(m.ProposerAddress)
+		copy(dAtA[i:], m.ProposerAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProposerAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Proposal != nil {
+		{
+			size, err := m.Proposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ShareProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShareProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ShareProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Nodes[iNdEx])
+			copy(dAtA[i:], m.Nodes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Nodes[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Share) > 0 {
+		i -= len(m.Share)
+		copy(dAtA[i:], m.Share)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Share)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EvidenceList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EvidenceList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EvidenceList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Evidence) > 0 {
+		for iNdEx := len(m.Evidence) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Evidence[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
 	return len(dAtA) - i, nil
@@ -2305,6 +2869,11 @@ func (m *IntermediateStateRoots) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if m.TxsPerRoot != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TxsPerRoot))
+		i--
+		dAtA[i] = 0x10
+	}
 	if len(m.RawRootsList) > 0 {
 		for iNdEx := len(m.RawRootsList) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.RawRootsList[iNdEx])
@@ -2471,12 +3040,12 @@ func (m *Vote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x32
 	}
-	n16, err16 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err16 != nil {
-		return 0, err16
+	n20, err20 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err20 != nil {
+		return 0, err20
 	}
-	i -= n16
-	i = encodeVarintTypes(dAtA, i, uint64(n16))
+	i -= n20
+	i = encodeVarintTypes(dAtA, i, uint64(n20))
 	i--
 	dAtA[i] = 0x2a
 	{
@@ -2598,12 +3167,12 @@ func (m *CommitSig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x22
 	}
-	n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err19 != nil {
-		return 0, err19
+	n23, err23 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err23 != nil {
+		return 0, err23
 	}
-	i -= n19
-	i = encodeVarintTypes(dAtA, i, uint64(n19))
+	i -= n23
+	i = encodeVarintTypes(dAtA, i, uint64(n23))
 	i--
 	dAtA[i] = 0x1a
 	if len(m.ValidatorAddress) > 0 {
@@ -2660,12 +3229,12 @@ func (m *Proposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x3a
 	}
-	n21, err21 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err21 != nil {
-		return 0, err21
+	n25, err25 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err25 != nil {
+		return 0, err25
 	}
-	i -= n21
-	i = encodeVarintTypes(dAtA, i, uint64(n21))
+	i -= n25
+	i = encodeVarintTypes(dAtA, i, uint64(n25))
 	i--
 	dAtA[i] = 0x32
 	{
@@ -3099,6 +3668,18 @@ func (m *Evidence_LightClientAttackEvidence) Size() (n int) {
 	}
 	return n
 }
+func (m *Evidence_InvalidDAHeaderEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InvalidDAHeaderEvidence != nil {
+		l = m.InvalidDAHeaderEvidence.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *DuplicateVoteEvidence) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3151,6 +3732,109 @@ func (m *LightClientAttackEvidence) Size() (n int) {
 	return n
 }
 
+func (m *StateTransitionFraudProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.FirstTxIndex != 0 {
+		n += 1 + sovTypes(uint64(m.FirstTxIndex))
+	}
+	if m.NumTxs != 0 {
+		n += 1 + sovTypes(uint64(m.NumTxs))
+	}
+	if len(m.TxProofs) > 0 {
+		for _, e := range m.TxProofs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ISRProofs) > 0 {
+		for _, e := range m.ISRProofs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.StateWitness)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *InvalidDAHeaderEvidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proposal != nil {
+		l = m.Proposal.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ProposerAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.BlockParts) > 0 {
+		for _, e := range m.BlockParts {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.ValidatorPower != 0 {
+		n += 1 + sovTypes(uint64(m.ValidatorPower))
+	}
+	if m.TotalVotingPower != 0 {
+		n += 1 + sovTypes(uint64(m.TotalVotingPower))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Column {
+		n += 2
+	}
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	if len(m.ShareProofs) > 0 {
+		for _, e := range m.ShareProofs {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ShareProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	l = len(m.Share)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, b := range m.Nodes {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *EvidenceList) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3178,6 +3862,9 @@ func (m *IntermediateStateRoots) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.TxsPerRoot != 0 {
+		n += 1 + sovTypes(uint64(m.TxsPerRoot))
+	}
 	return n
 }
 
@@ -4613,12 +5300,47 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Evidence_LightClientAttackEvidence{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InvalidDAHeaderEvidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &InvalidDAHeaderEvidence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Evidence_InvalidDAHeaderEvidence{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
 			if skippy < 0 {
 				return ErrInvalidLengthTypes
 			}
@@ -5027,7 +5749,7 @@ func (m *LightClientAttackEvidence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EvidenceList) Unmarshal(dAtA []byte) error {
+func (m *StateTransitionFraudProof) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5050,15 +5772,72 @@ func (m *EvidenceList) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EvidenceList: wiretype end group for non-group")
+			return fmt.Errorf("proto: StateTransitionFraudProof: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EvidenceList: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StateTransitionFraudProof: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstTxIndex", wireType)
+			}
+			m.FirstTxIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FirstTxIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumTxs", wireType)
+			}
+			m.NumTxs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumTxs |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxProofs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5085,11 +5864,79 @@ func (m *EvidenceList) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Evidence = append(m.Evidence, Evidence{})
-			if err := m.Evidence[len(m.Evidence)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.TxProofs = append(m.TxProofs, ShareProof{})
+			if err := m.TxProofs[len(m.TxProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ISRProofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ISRProofs = append(m.ISRProofs, ShareProof{})
+			if err := m.ISRProofs[len(m.ISRProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateWitness", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StateWitness = append(m.StateWitness[:0], dAtA[iNdEx:postIndex]...)
+			if m.StateWitness == nil {
+				m.StateWitness = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -5114,7 +5961,7 @@ func (m *EvidenceList) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IntermediateStateRoots) Unmarshal(dAtA []byte) error {
+func (m *InvalidDAHeaderEvidence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5137,15 +5984,51 @@ func (m *IntermediateStateRoots) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IntermediateStateRoots: wiretype end group for non-group")
+			return fmt.Errorf("proto: InvalidDAHeaderEvidence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IntermediateStateRoots: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InvalidDAHeaderEvidence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RawRootsList", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proposal == nil {
+				m.Proposal = &Proposal{}
+			}
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerAddress", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5172,9 +6055,552 @@ func (m *IntermediateStateRoots) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RawRootsList = append(m.RawRootsList, make([]byte, postIndex-iNdEx))
-			copy(m.RawRootsList[len(m.RawRootsList)-1], dAtA[iNdEx:postIndex])
+			m.ProposerAddress = append(m.ProposerAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposerAddress == nil {
+				m.ProposerAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockParts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockParts = append(m.BlockParts, &Part{})
+			if err := m.BlockParts[len(m.BlockParts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorPower", wireType)
+			}
+			m.ValidatorPower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidatorPower |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalVotingPower", wireType)
+			}
+			m.TotalVotingPower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalVotingPower |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Column = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShareProofs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShareProofs = append(m.ShareProofs, ShareProof{})
+			if err := m.ShareProofs[len(m.ShareProofs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = append(m.Root[:0], dAtA[iNdEx:postIndex]...)
+			if m.Root == nil {
+				m.Root = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShareProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShareProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShareProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Share", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Share = append(m.Share[:0], dAtA[iNdEx:postIndex]...)
+			if m.Share == nil {
+				m.Share = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, make([]byte, postIndex-iNdEx))
+			copy(m.Nodes[len(m.Nodes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EvidenceList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EvidenceList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EvidenceList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Evidence = append(m.Evidence, Evidence{})
+			if err := m.Evidence[len(m.Evidence)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IntermediateStateRoots) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IntermediateStateRoots: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IntermediateStateRoots: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RawRootsList", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RawRootsList = append(m.RawRootsList, make([]byte, postIndex-iNdEx))
+			copy(m.RawRootsList[len(m.RawRootsList)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxsPerRoot", wireType)
+			}
+			m.TxsPerRoot = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxsPerRoot |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
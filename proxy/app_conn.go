@@ -19,10 +19,12 @@ type AppConnConsensus interface {
 	InitChainSync(context.Context, types.RequestInitChain) (*types.ResponseInitChain, error)
 
 	PreprocessTxsSync(context.Context, types.RequestPreprocessTxs) (*types.ResponsePreprocessTxs, error)
+	ProcessProposalSync(context.Context, types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
 	BeginBlockSync(context.Context, types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	DeliverTxAsync(context.Context, types.RequestDeliverTx) (*abcicli.ReqRes, error)
 	EndBlockSync(context.Context, types.RequestEndBlock) (*types.ResponseEndBlock, error)
 	CommitSync(context.Context) (*types.ResponseCommit, error)
+	GenerateFraudProofSync(context.Context, types.RequestGenerateFraudProof) (*types.ResponseGenerateFraudProof, error)
 }
 
 type AppConnMempool interface {
@@ -110,6 +112,20 @@ func (app *appConnConsensus) PreprocessTxsSync(
 	return app.appConn.PreprocessTxsSync(ctx, req)
 }
 
+func (app *appConnConsensus) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	return app.appConn.ProcessProposalSync(ctx, req)
+}
+
+func (app *appConnConsensus) GenerateFraudProofSync(
+	ctx context.Context,
+	req types.RequestGenerateFraudProof,
+) (*types.ResponseGenerateFraudProof, error) {
+	return app.appConn.GenerateFraudProofSync(ctx, req)
+}
+
 //------------------------------------------------
 // Implements AppConnMempool (subset of abcicli.Client)
 
@@ -1,6 +1,8 @@
 package proxy
 
 import (
+	"fmt"
+
 	abcicli "github.com/lazyledger/lazyledger-core/abci/client"
 	"github.com/lazyledger/lazyledger-core/abci/example/counter"
 	"github.com/lazyledger/lazyledger-core/abci/example/kvstore"
@@ -14,6 +16,12 @@ type ClientCreator interface {
 	NewABCIClient() (abcicli.Client, error)
 }
 
+// reconnectingClientCreator is implemented by ClientCreators able to create
+// clients which reconnect to the app whenever the connection is lost.
+type reconnectingClientCreator interface {
+	newReconnectingABCIClient() (abcicli.Client, error)
+}
+
 //----------------------------------------------------
 // local proxy uses a mutex on an in-proc app
 
@@ -35,11 +43,56 @@ func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
 	return abcicli.NewLocalClient(l.mtx, l.app), nil
 }
 
+//---------------------------------------------------------------
+// remote proxy opens new connections to an external app process
+
+type remoteClientCreator struct {
+	addr        string
+	transport   string
+	mustConnect bool
+}
+
+// NewRemoteClientCreator returns a ClientCreator for the given address (e.g.
+// "192.168.0.1") and transport (e.g. "tcp"). Set mustConnect to true if you
+// want the client to connect before reporting success. Otherwise, the client
+// keeps retrying to connect.
+//
+// The clients of the query, mempool and snapshot connections also reconnect
+// whenever the connection is lost, while losing the consensus connection stops
+// the node (see AppConns).
+func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
+	return &remoteClientCreator{
+		addr:        addr,
+		transport:   transport,
+		mustConnect: mustConnect,
+	}
+}
+
+func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
+	remoteApp, err := abcicli.NewClient(r.addr, r.transport, r.mustConnect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+
+	return remoteApp, nil
+}
+
+func (r *remoteClientCreator) newReconnectingABCIClient() (abcicli.Client, error) {
+	remoteApp, err := abcicli.NewReconnectingClient(r.addr, r.transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+
+	return remoteApp, nil
+}
+
 // DefaultClientCreator returns a default ClientCreator, which will create a
-// local client if app is one of: 'counter', 'counter_serial', 'kvstore',
-// 'persistent_kvstore' or 'noop', otherwise - a remote client.
-func DefaultClientCreator(app, dbDir string) ClientCreator {
-	switch app {
+// local client if addr is one of: 'counter', 'counter_serial', 'kvstore',
+// 'persistent_kvstore' or 'noop', otherwise - a remote client connecting to
+// addr (e.g. "tcp://127.0.0.1:26658" or "unix://app.sock") over the given
+// transport ('socket' or 'grpc').
+func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
+	switch addr {
 	case "counter":
 		return NewLocalClientCreator(counter.NewApplication(false))
 	case "counter_serial":
@@ -51,6 +104,7 @@ func DefaultClientCreator(app, dbDir string) ClientCreator {
 	case "noop":
 		return NewLocalClientCreator(types.NewBaseApplication())
 	default:
-		panic("RemoteClientCreator not implemented in lazyledger-core")
+		mustConnect := false // loop retrying
+		return NewRemoteClientCreator(addr, transport, mustConnect)
 	}
 }
@@ -178,8 +178,20 @@ func (app *multiAppConn) stopAllClients() {
 	}
 }
 
+// abciClientFor creates and starts the client for the given connection. All
+// connections but the consensus one reconnect if the client creator supports
+// it: the consensus connection can't be re-established in the middle of a
+// block, the app would have executed only part of it.
 func (app *multiAppConn) abciClientFor(conn string) (abcicli.Client, error) {
-	c, err := app.clientCreator.NewABCIClient()
+	var (
+		c   abcicli.Client
+		err error
+	)
+	if rc, ok := app.clientCreator.(reconnectingClientCreator); ok && conn != connConsensus {
+		c, err = rc.newReconnectingABCIClient()
+	} else {
+		c, err = app.clientCreator.NewABCIClient()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error creating ABCI client (%s connection): %w", conn, err)
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	abcicli "github.com/lazyledger/lazyledger-core/abci/client"
 	abcimocks "github.com/lazyledger/lazyledger-core/abci/client/mocks"
 	"github.com/lazyledger/lazyledger-core/proxy/mocks"
 )
@@ -88,3 +89,41 @@ func TestAppConns_Failure(t *testing.T) {
 		t.Fatal("expected process to receive SIGTERM signal")
 	}
 }
+
+type reconnectingClientCreatorMock struct {
+	*mocks.ClientCreator
+	reconnecting abcicli.Client
+}
+
+func (m *reconnectingClientCreatorMock) newReconnectingABCIClient() (abcicli.Client, error) {
+	return m.reconnecting, nil
+}
+
+func TestAppConns_OnlyConsensusDoesNotReconnect(t *testing.T) {
+	quitCh := make(<-chan struct{})
+
+	newClientMock := func(times int) *abcimocks.Client {
+		clientMock := &abcimocks.Client{}
+		clientMock.On("SetLogger", mock.Anything).Return().Times(times)
+		clientMock.On("Start").Return(nil).Times(times)
+		clientMock.On("Stop").Return(nil).Times(times)
+		clientMock.On("Quit").Return(quitCh).Maybe()
+		return clientMock
+	}
+	consensusClient := newClientMock(1)
+	reconnectingClient := newClientMock(3)
+
+	clientCreatorMock := &mocks.ClientCreator{}
+	clientCreatorMock.On("NewABCIClient").Return(consensusClient, nil).Once()
+
+	appConns := NewAppConns(&reconnectingClientCreatorMock{
+		ClientCreator: clientCreatorMock,
+		reconnecting:  reconnectingClient,
+	})
+	require.NoError(t, appConns.Start())
+	require.NoError(t, appConns.Stop())
+
+	clientCreatorMock.AssertExpectations(t)
+	consensusClient.AssertExpectations(t)
+	reconnectingClient.AssertExpectations(t)
+}
@@ -133,6 +133,19 @@ func TestBroadcastEvidence_DuplicateVoteEvidence(t *testing.T) {
 		err = client.WaitForHeight(c, status.SyncInfo.LatestBlockHeight+2, nil)
 		require.NoError(t, err)
 
+		// the evidence got committed
+		committed, err := c.CommittedEvidence(context.Background(), correct.Height(), correct.Height(),
+			pv.Key.Address, "DuplicateVoteEvidence", nil, nil)
+		require.NoError(t, err)
+		committedHashes := make([][]byte, len(committed.Evidence))
+		for i, ev := range committed.Evidence {
+			committedHashes[i] = ev.Hash()
+		}
+		assert.Contains(t, committedHashes, correct.Hash())
+		pending, err := c.PendingEvidence(context.Background(), 0, 0, pv.Key.Address, "", nil, nil)
+		require.NoError(t, err)
+		assert.Zero(t, pending.Total)
+
 		ed25519pub := pv.Key.PubKey.(ed25519.PubKey)
 		rawpub := ed25519pub.Bytes()
 		result2, err := c.ABCIQuery(context.Background(), "/val", rawpub)
@@ -285,15 +285,35 @@ func (c *baseRPCClient) broadcastTX(
 	return result, nil
 }
 
-func (c *baseRPCClient) UnconfirmedTxs(
+func (c *baseRPCClient) UnconfirmedTx(ctx context.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+	result := new(ctypes.ResultUnconfirmedTx)
+	_, err := c.caller.Call(ctx, "unconfirmed_tx", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.UnconfirmedTxsWithOptions(ctx, limit, rpcclient.UnconfirmedTxsOptions{})
+}
+
+func (c *baseRPCClient) UnconfirmedTxsWithOptions(
 	ctx context.Context,
 	limit *int,
+	opts rpcclient.UnconfirmedTxsOptions,
 ) (*ctypes.ResultUnconfirmedTxs, error) {
 	result := new(ctypes.ResultUnconfirmedTxs)
 	params := make(map[string]interface{})
 	if limit != nil {
 		params["limit"] = limit
 	}
+	if opts.Sender != "" {
+		params["sender"] = opts.Sender
+	}
+	if opts.MinPriority != nil {
+		params["min_priority"] = opts.MinPriority
+	}
 	_, err := c.caller.Call(ctx, "unconfirmed_txs", params, result)
 	if err != nil {
 		return nil, err
@@ -328,6 +348,15 @@ func (c *baseRPCClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, err
 	return result, nil
 }
 
+func (c *baseRPCClient) IPFSInfo(ctx context.Context) (*ctypes.ResultIPFSInfo, error) {
+	result := new(ctypes.ResultIPFSInfo)
+	_, err := c.caller.Call(ctx, "ipfs_info", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	result := new(ctypes.ResultDumpConsensusState)
 	_, err := c.caller.Call(ctx, "dump_consensus_state", map[string]interface{}{}, result)
@@ -542,6 +571,61 @@ func (c *baseRPCClient) BroadcastEvidence(
 	return result, nil
 }
 
+func (c *baseRPCClient) PendingEvidence(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvidenceList, error) {
+	return c.listEvidence(ctx, "pending_evidence", minHeight, maxHeight, validator, evType, page, perPage)
+}
+
+func (c *baseRPCClient) CommittedEvidence(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvidenceList, error) {
+	return c.listEvidence(ctx, "committed_evidence", minHeight, maxHeight, validator, evType, page, perPage)
+}
+
+func (c *baseRPCClient) listEvidence(
+	ctx context.Context,
+	method string,
+	minHeight,
+	maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvidenceList, error) {
+	result := new(ctypes.ResultEvidenceList)
+	params := map[string]interface{}{"min_height": minHeight, "max_height": maxHeight}
+	if len(validator) > 0 {
+		params["validator"] = validator
+	}
+	if evType != "" {
+		params["type"] = evType
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, method, params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 //-----------------------------------------------------------------------------
 // WSEvents
 
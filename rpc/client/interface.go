@@ -91,6 +91,7 @@ type StatusClient interface {
 // usually.
 type NetworkClient interface {
 	NetInfo(context.Context) (*ctypes.ResultNetInfo, error)
+	IPFSInfo(context.Context) (*ctypes.ResultIPFSInfo, error)
 	DumpConsensusState(context.Context) (*ctypes.ResultDumpConsensusState, error)
 	ConsensusState(context.Context) (*ctypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error)
@@ -116,15 +117,22 @@ type EventsClient interface {
 
 // MempoolClient shows us data about current mempool state.
 type MempoolClient interface {
+	UnconfirmedTx(ctx context.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error)
 	UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error)
+	UnconfirmedTxsWithOptions(ctx context.Context, limit *int,
+		opts UnconfirmedTxsOptions) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 	CheckTx(context.Context, types.Tx) (*ctypes.ResultCheckTx, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
-// behaviour.
+// behaviour, and for listing the evidence known to the node.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+	PendingEvidence(ctx context.Context, minHeight, maxHeight int64, validator bytes.HexBytes, evType string,
+		page, perPage *int) (*ctypes.ResultEvidenceList, error)
+	CommittedEvidence(ctx context.Context, minHeight, maxHeight int64, validator bytes.HexBytes, evType string,
+		page, perPage *int) (*ctypes.ResultEvidenceList, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
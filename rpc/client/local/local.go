@@ -99,8 +99,20 @@ func (c *Local) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.Resul
 	return core.BroadcastTxSync(c.ctx, tx)
 }
 
+func (c *Local) UnconfirmedTx(ctx context.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+	return core.UnconfirmedTx(c.ctx, hash)
+}
+
 func (c *Local) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
-	return core.UnconfirmedTxs(c.ctx, limit)
+	return c.UnconfirmedTxsWithOptions(ctx, limit, rpcclient.UnconfirmedTxsOptions{})
+}
+
+func (c *Local) UnconfirmedTxsWithOptions(
+	ctx context.Context,
+	limit *int,
+	opts rpcclient.UnconfirmedTxsOptions,
+) (*ctypes.ResultUnconfirmedTxs, error) {
+	return core.UnconfirmedTxs(c.ctx, limit, opts.Sender, opts.MinPriority)
 }
 
 func (c *Local) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -115,6 +127,10 @@ func (c *Local) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(c.ctx)
 }
 
+func (c *Local) IPFSInfo(ctx context.Context) (*ctypes.ResultIPFSInfo, error) {
+	return core.IPFSInfo(c.ctx)
+}
+
 func (c *Local) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	return core.DumpConsensusState(c.ctx)
 }
@@ -199,6 +215,30 @@ func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctyp
 	return core.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) PendingEvidence(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvidenceList, error) {
+	return core.PendingEvidence(c.ctx, minHeight, maxHeight, validator, evType, page, perPage)
+}
+
+func (c *Local) CommittedEvidence(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	page,
+	perPage *int,
+) (*ctypes.ResultEvidenceList, error) {
+	return core.CommittedEvidence(c.ctx, minHeight, maxHeight, validator, evType, page, perPage)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
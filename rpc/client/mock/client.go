@@ -120,6 +120,10 @@ func (c Client) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(&rpctypes.Context{})
 }
 
+func (c Client) IPFSInfo(ctx context.Context) (*ctypes.ResultIPFSInfo, error) {
+	return core.IPFSInfo(&rpctypes.Context{})
+}
+
 func (c Client) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	return core.ConsensusState(&rpctypes.Context{})
 }
@@ -184,3 +188,13 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
 	return core.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
+
+func (c Client) PendingEvidence(ctx context.Context, minHeight, maxHeight int64, validator bytes.HexBytes,
+	evType string, page, perPage *int) (*ctypes.ResultEvidenceList, error) {
+	return core.PendingEvidence(&rpctypes.Context{}, minHeight, maxHeight, validator, evType, page, perPage)
+}
+
+func (c Client) CommittedEvidence(ctx context.Context, minHeight, maxHeight int64, validator bytes.HexBytes,
+	evType string, page, perPage *int) (*ctypes.ResultEvidenceList, error) {
+	return core.CommittedEvidence(&rpctypes.Context{}, minHeight, maxHeight, validator, evType, page, perPage)
+}
@@ -321,6 +321,29 @@ func (_m *Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultC
 	return r0, r1
 }
 
+// CommittedEvidence provides a mock function with given fields: ctx, minHeight, maxHeight, validator, evType, page, perPage
+func (_m *Client) CommittedEvidence(ctx context.Context, minHeight int64, maxHeight int64, validator bytes.HexBytes, evType string, page *int, perPage *int) (*coretypes.ResultEvidenceList, error) {
+	ret := _m.Called(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+
+	var r0 *coretypes.ResultEvidenceList
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bytes.HexBytes, string, *int, *int) *coretypes.ResultEvidenceList); ok {
+		r0 = rf(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidenceList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, bytes.HexBytes, string, *int, *int) error); ok {
+		r1 = rf(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusParams provides a mock function with given fields: ctx, height
 func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	ret := _m.Called(ctx, height)
@@ -436,6 +459,29 @@ func (_m *Client) Health(_a0 context.Context) (*coretypes.ResultHealth, error) {
 	return r0, r1
 }
 
+// IPFSInfo provides a mock function with given fields: _a0
+func (_m *Client) IPFSInfo(_a0 context.Context) (*coretypes.ResultIPFSInfo, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultIPFSInfo
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultIPFSInfo); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultIPFSInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsRunning provides a mock function with given fields:
 func (_m *Client) IsRunning() bool {
 	ret := _m.Called()
@@ -529,6 +575,29 @@ func (_m *Client) OnStop() {
 	_m.Called()
 }
 
+// PendingEvidence provides a mock function with given fields: ctx, minHeight, maxHeight, validator, evType, page, perPage
+func (_m *Client) PendingEvidence(ctx context.Context, minHeight int64, maxHeight int64, validator bytes.HexBytes, evType string, page *int, perPage *int) (*coretypes.ResultEvidenceList, error) {
+	ret := _m.Called(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+
+	var r0 *coretypes.ResultEvidenceList
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, bytes.HexBytes, string, *int, *int) *coretypes.ResultEvidenceList); ok {
+		r0 = rf(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidenceList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, bytes.HexBytes, string, *int, *int) error); ok {
+		r1 = rf(ctx, minHeight, maxHeight, validator, evType, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Quit provides a mock function with given fields:
 func (_m *Client) Quit() <-chan struct{} {
 	ret := _m.Called()
@@ -705,6 +774,29 @@ func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *
 	return r0, r1
 }
 
+// UnconfirmedTx provides a mock function with given fields: ctx, hash
+func (_m *Client) UnconfirmedTx(ctx context.Context, hash []byte) (*coretypes.ResultUnconfirmedTx, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultUnconfirmedTx
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) *coretypes.ResultUnconfirmedTx); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultUnconfirmedTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnconfirmedTxs provides a mock function with given fields: ctx, limit
 func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(ctx, limit)
@@ -728,6 +820,29 @@ func (_m *Client) UnconfirmedTxs(ctx context.Context, limit *int) (*coretypes.Re
 	return r0, r1
 }
 
+// UnconfirmedTxsWithOptions provides a mock function with given fields: ctx, limit, opts
+func (_m *Client) UnconfirmedTxsWithOptions(ctx context.Context, limit *int, opts client.UnconfirmedTxsOptions) (*coretypes.ResultUnconfirmedTxs, error) {
+	ret := _m.Called(ctx, limit, opts)
+
+	var r0 *coretypes.ResultUnconfirmedTxs
+	if rf, ok := ret.Get(0).(func(context.Context, *int, client.UnconfirmedTxsOptions) *coretypes.ResultUnconfirmedTxs); ok {
+		r0 = rf(ctx, limit, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultUnconfirmedTxs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int, client.UnconfirmedTxsOptions) error); ok {
+		r1 = rf(ctx, limit, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Unsubscribe provides a mock function with given fields: ctx, subscriber, query
 func (_m *Client) Unsubscribe(ctx context.Context, subscriber string, query string) error {
 	ret := _m.Called(ctx, subscriber, query)
@@ -129,6 +129,17 @@ func TestNetInfo(t *testing.T) {
 	}
 }
 
+func TestIPFSInfo(t *testing.T) {
+	for i, c := range GetClients() {
+		nc, ok := c.(client.NetworkClient)
+		require.True(t, ok, "%d", i)
+		info, err := nc.IPFSInfo(context.Background())
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.NotEmpty(t, info.ID)
+		assert.Equal(t, len(info.Peers), info.NPeers)
+	}
+}
+
 func TestDumpConsensusState(t *testing.T) {
 	for i, c := range GetClients() {
 		// FIXME: fix server so it doesn't panic on invalid input
@@ -9,3 +9,12 @@ type ABCIQueryOptions struct {
 
 // DefaultABCIQueryOptions are latest height (0) and prove false.
 var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
+
+// UnconfirmedTxsOptions can be used to filter the txs returned by
+// UnconfirmedTxsWithOptions. The zero value doesn't filter any txs.
+type UnconfirmedTxsOptions struct {
+	// Sender only returns the txs of the given sender, if not empty.
+	Sender string
+	// MinPriority only returns the txs with at least the given priority, if not nil.
+	MinPriority *int64
+}
@@ -143,15 +143,14 @@ func DataAvailabilityHeader(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.Re
 		return nil, err
 	}
 
-	// TODO: store DAHeader to avoid loading the full block each time
-	// depends on either:
-	// - https://github.com/lazyledger/lazyledger-core/pull/312, or
-	// - https://github.com/lazyledger/lazyledger-core/pull/218
-	block := env.BlockStore.LoadBlock(height)
-	_ = block.Hash()
-	dah := block.DataAvailabilityHeader
+	// The DAHeader is stored in the block meta, which is also available for
+	// blocks restored by state sync without their data.
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("data availability header for height %d not found", height)
+	}
 	return &ctypes.ResultDataAvailabilityHeader{
-		DataAvailabilityHeader: dah,
+		DataAvailabilityHeader: blockMeta.DAHeader,
 	}, nil
 }
 
@@ -1,11 +1,10 @@
 package core
 
 import (
-	cm "github.com/lazyledger/lazyledger-core/consensus"
 	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
+	"github.com/lazyledger/lazyledger-core/p2p"
 	ctypes "github.com/lazyledger/lazyledger-core/rpc/core/types"
 	rpctypes "github.com/lazyledger/lazyledger-core/rpc/jsonrpc/types"
-	"github.com/lazyledger/lazyledger-core/types"
 )
 
 // Validators gets the validator set at the given block height.
@@ -53,7 +52,11 @@ func DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState
 	peers := env.P2PPeers.Peers().List()
 	peerStates := make([]ctypes.PeerStateInfo, len(peers))
 	for i, peer := range peers {
-		peerState, ok := peer.Get(types.PeerStateKey).(*cm.PeerState)
+		peerID, err := p2p.PeerIDFromString(string(peer.ID()))
+		if err != nil {
+			return nil, err
+		}
+		peerState, ok := env.ConsensusReactor.GetPeerState(peerID)
 		if !ok { // peer does not have a state yet
 			continue
 		}
@@ -73,9 +76,15 @@ func DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState
 	if err != nil {
 		return nil, err
 	}
+	// Get data availability reports for the recent heights.
+	dataAvailability, err := env.ConsensusState.GetDataAvailabilityJSON()
+	if err != nil {
+		return nil, err
+	}
 	return &ctypes.ResultDumpConsensusState{
-		RoundState: roundState,
-		Peers:      peerStates}, nil
+		RoundState:       roundState,
+		Peers:            peerStates,
+		DataAvailability: dataAvailability}, nil
 }
 
 // ConsensusState returns a concise summary of the consensus state.
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	ctypes "github.com/lazyledger/lazyledger-core/rpc/core/types"
 	rpctypes "github.com/lazyledger/lazyledger-core/rpc/jsonrpc/types"
 )
@@ -10,3 +12,19 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeRemoveTx removes a transaction from the mempool by its hash. The
+// transaction is removed from the cache as well, so it can be resubmitted.
+func UnsafeRemoveTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnsafeRemoveTx, error) {
+	txKey, err := txKeyFromHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	// the mempool must not change while a block is committed or txs are rechecked
+	env.Mempool.Lock()
+	defer env.Mempool.Unlock()
+	if err := env.Mempool.RemoveTxByKey(txKey, true); err != nil {
+		return nil, fmt.Errorf("remove tx (%X): %w", hash, err)
+	}
+	return &ctypes.ResultUnsafeRemoveTx{}, nil
+}
@@ -38,7 +38,9 @@ Endpoints that require arguments:
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
 /subscribe?event=_
+/remove_tx?hash=_
 /tx?hash=_&prove=_
+/unconfirmed_tx?hash=_
 /unsubscribe?event=_
 ```
 */
@@ -7,9 +7,12 @@ import (
 	cfg "github.com/lazyledger/lazyledger-core/config"
 	"github.com/lazyledger/lazyledger-core/consensus"
 	"github.com/lazyledger/lazyledger-core/crypto"
+	"github.com/lazyledger/lazyledger-core/evidence"
+	"github.com/lazyledger/lazyledger-core/ipfs"
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	mempl "github.com/lazyledger/lazyledger-core/mempool"
 	"github.com/lazyledger/lazyledger-core/p2p"
+	"github.com/lazyledger/lazyledger-core/p2p/trust"
 	"github.com/lazyledger/lazyledger-core/proxy"
 	sm "github.com/lazyledger/lazyledger-core/state"
 	"github.com/lazyledger/lazyledger-core/state/txindex"
@@ -46,6 +49,13 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetDataAvailabilityJSON() ([]byte, error)
+}
+
+type evidencePool interface {
+	AddEvidence(types.Evidence) error
+	ListPendingEvidence(filter evidence.Filter, skip, limit int) ([]types.Evidence, int, error)
+	ListCommittedEvidence(filter evidence.Filter, skip, limit int) ([]types.Evidence, int, error)
 }
 
 type transport interface {
@@ -73,10 +83,11 @@ type Environment struct {
 	// interfaces defined in types and above
 	StateStore     sm.Store
 	BlockStore     sm.BlockStore
-	EvidencePool   sm.EvidencePool
+	EvidencePool   evidencePool
 	ConsensusState Consensus
 	P2PPeers       peers
 	P2PTransport   transport
+	TrustMetrics   *trust.MetricStore // optional, scores of the fast sync peers
 
 	// objects
 	PubKey           crypto.PubKey
@@ -85,6 +96,7 @@ type Environment struct {
 	ConsensusReactor *consensus.Reactor
 	EventBus         *types.EventBus // thread safe
 	Mempool          mempl.Mempool
+	IPFS             ipfs.Node
 
 	Logger log.Logger
 
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lazyledger/lazyledger-core/evidence"
+	"github.com/lazyledger/lazyledger-core/libs/bytes"
 	ctypes "github.com/lazyledger/lazyledger-core/rpc/core/types"
 	rpctypes "github.com/lazyledger/lazyledger-core/rpc/jsonrpc/types"
 	"github.com/lazyledger/lazyledger-core/types"
@@ -25,3 +27,73 @@ func BroadcastEvidence(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.Result
 	}
 	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
+
+// PendingEvidence lists the evidence which is waiting to be committed, ordered by height. It can
+// be filtered by height range, by the address of the misbehaving validator and by evidence type.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/pending_evidence
+func PendingEvidence(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultEvidenceList, error) {
+	return listEvidence(env.EvidencePool.ListPendingEvidence, minHeight, maxHeight, validator, evType,
+		pagePtr, perPagePtr)
+}
+
+// CommittedEvidence lists the evidence which has been committed, ordered by height. It can be
+// filtered by height range, by the address of the misbehaving validator and by evidence type.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/committed_evidence
+func CommittedEvidence(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultEvidenceList, error) {
+	return listEvidence(env.EvidencePool.ListCommittedEvidence, minHeight, maxHeight, validator, evType,
+		pagePtr, perPagePtr)
+}
+
+func listEvidence(
+	list func(filter evidence.Filter, skip, limit int) ([]types.Evidence, int, error),
+	minHeight, maxHeight int64,
+	validator bytes.HexBytes,
+	evType string,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultEvidenceList, error) {
+	if minHeight < 0 || maxHeight < 0 {
+		return nil, errors.New("heights must be non-negative")
+	}
+	if maxHeight > 0 && minHeight > maxHeight {
+		return nil, fmt.Errorf("min height %d can't be greater than max height %d", minHeight, maxHeight)
+	}
+
+	// only the evidence of the requested page is loaded, the rest is counted
+	perPage := validatePerPage(perPagePtr)
+	page := 1
+	if pagePtr != nil {
+		page = *pagePtr
+	}
+	skipCount := validateSkipCount(page, perPage)
+
+	evList, totalCount, err := list(evidence.Filter{
+		MinHeight: minHeight,
+		MaxHeight: maxHeight,
+		Validator: types.Address(validator),
+		Type:      evType,
+	}, skipCount, perPage)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validatePage(pagePtr, perPage, totalCount); err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultEvidenceList{
+		Evidence: evList,
+		Count:    len(evList),
+		Total:    totalCount,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/evidence"
+	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
+	rpctypes "github.com/lazyledger/lazyledger-core/rpc/jsonrpc/types"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// listingEvidencePool lists the same evidence regardless of the filter, and records the filter
+// and the page.
+type listingEvidencePool struct {
+	evidence    []types.Evidence
+	filter      evidence.Filter
+	skip, limit int
+}
+
+func (p *listingEvidencePool) AddEvidence(types.Evidence) error { return nil }
+
+func (p *listingEvidencePool) ListPendingEvidence(
+	filter evidence.Filter,
+	skip, limit int,
+) ([]types.Evidence, int, error) {
+	p.filter, p.skip, p.limit = filter, skip, limit
+	if skip > len(p.evidence) {
+		return []types.Evidence{}, len(p.evidence), nil
+	}
+	return p.evidence[skip:tmmath.MinInt(skip+limit, len(p.evidence))], len(p.evidence), nil
+}
+
+func (p *listingEvidencePool) ListCommittedEvidence(
+	filter evidence.Filter,
+	skip, limit int,
+) ([]types.Evidence, int, error) {
+	return p.ListPendingEvidence(filter, skip, limit)
+}
+
+func TestPendingEvidence(t *testing.T) {
+	val := types.NewMockPV()
+	pool := &listingEvidencePool{}
+	for h := int64(1); h <= 5; h++ {
+		pool.evidence = append(pool.evidence,
+			types.NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), val, "test-chain"))
+	}
+	env = &Environment{EvidencePool: pool}
+
+	one, two, three := 1, 2, 3
+	res, err := PendingEvidence(&rpctypes.Context{}, 2, 4, val.PrivKey.PubKey().Address(), "DuplicateVoteEvidence",
+		&two, &two)
+	require.NoError(t, err)
+	assert.Equal(t, evidence.Filter{
+		MinHeight: 2,
+		MaxHeight: 4,
+		Validator: val.PrivKey.PubKey().Address(),
+		Type:      "DuplicateVoteEvidence",
+	}, pool.filter)
+	assert.Equal(t, 2, pool.skip)
+	assert.Equal(t, 2, pool.limit)
+	assert.Equal(t, pool.evidence[2:4], res.Evidence)
+	assert.Equal(t, 2, res.Count)
+	assert.Equal(t, 5, res.Total)
+
+	res, err = CommittedEvidence(&rpctypes.Context{}, 0, 0, nil, "", &three, &two)
+	require.NoError(t, err)
+	assert.Equal(t, pool.evidence[4:], res.Evidence)
+	assert.Equal(t, 1, res.Count)
+
+	res, err = PendingEvidence(&rpctypes.Context{}, 0, 0, nil, "", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, pool.evidence, res.Evidence)
+
+	// invalid arguments
+	_, err = PendingEvidence(&rpctypes.Context{}, -1, 0, nil, "", nil, nil)
+	assert.Error(t, err)
+	_, err = PendingEvidence(&rpctypes.Context{}, 5, 4, nil, "", nil, nil)
+	assert.Error(t, err)
+	_, err = PendingEvidence(&rpctypes.Context{}, 0, 0, nil, "", &three, &two)
+	assert.NoError(t, err)
+	four := 4
+	_, err = PendingEvidence(&rpctypes.Context{}, 0, 0, nil, "", &four, &two)
+	assert.Error(t, err)
+	_, err = CommittedEvidence(&rpctypes.Context{}, 0, 0, nil, "", &one, &one)
+	assert.NoError(t, err)
+}
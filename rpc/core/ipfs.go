@@ -0,0 +1,41 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/lazyledger/lazyledger-core/ipfs"
+	ctypes "github.com/lazyledger/lazyledger-core/rpc/core/types"
+	rpctypes "github.com/lazyledger/lazyledger-core/rpc/jsonrpc/types"
+)
+
+// IPFSInfo returns the identity, listen addresses and connected peers of the
+// IPFS node. It fails if the node does not run IPFS networking.
+func IPFSInfo(ctx *rpctypes.Context) (*ctypes.ResultIPFSInfo, error) {
+	nd, ok := env.IPFS.(ipfs.Networked)
+	if !ok || nd.Host() == nil {
+		return nil, errors.New("IPFS node does not run networking")
+	}
+	host := nd.Host()
+
+	addrs := make([]string, 0, len(host.Addrs()))
+	for _, addr := range host.Addrs() {
+		addrs = append(addrs, addr.String())
+	}
+
+	connected := host.Network().Peers()
+	peers := make([]ctypes.IPFSPeer, 0, len(connected))
+	for _, id := range connected {
+		peer := ctypes.IPFSPeer{ID: id.String()}
+		if conns := host.Network().ConnsToPeer(id); len(conns) > 0 {
+			peer.Address = conns[0].RemoteMultiaddr().String()
+		}
+		peers = append(peers, peer)
+	}
+
+	return &ctypes.ResultIPFSInfo{
+		ID:        host.ID().String(),
+		Addresses: addrs,
+		NPeers:    len(peers),
+		Peers:     peers,
+	}, nil
+}
@@ -132,13 +132,38 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 }
 
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
-// including their number.
+// including their number. If ?sender is given, only txs the app reported that
+// sender for in CheckTx are returned; if ?min_priority is given, only txs with
+// at least that priority are.
 // More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_txs
-func UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfirmedTxs, error) {
+func UnconfirmedTxs(
+	ctx *rpctypes.Context,
+	limitPtr *int,
+	sender string,
+	minPriorityPtr *int64,
+) (*ctypes.ResultUnconfirmedTxs, error) {
 	// reuse per_page validator
 	limit := validatePerPage(limitPtr)
 
-	txs := env.Mempool.ReapMaxTxs(limit)
+	var txs []types.Tx
+	if sender == "" && minPriorityPtr == nil {
+		txs = env.Mempool.ReapMaxTxs(limit)
+	} else {
+		txs = make([]types.Tx, 0)
+		for _, entry := range env.Mempool.TxEntries() {
+			if len(txs) == limit {
+				break
+			}
+			if sender != "" && entry.Sender != sender {
+				continue
+			}
+			if minPriorityPtr != nil && entry.Priority < *minPriorityPtr {
+				continue
+			}
+			txs = append(txs, entry.Tx)
+		}
+	}
+
 	return &ctypes.ResultUnconfirmedTxs{
 		Count:      len(txs),
 		Total:      env.Mempool.Size(),
@@ -146,6 +171,29 @@ func UnconfirmedTxs(ctx *rpctypes.Context, limitPtr *int) (*ctypes.ResultUnconfi
 		Txs:        txs}, nil
 }
 
+// UnconfirmedTx gets an unconfirmed transaction by its hash, along with what
+// the app reported about it in CheckTx.
+// More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_tx
+func UnconfirmedTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultUnconfirmedTx, error) {
+	txKey, err := txKeyFromHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := env.Mempool.TxByKey(txKey)
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found in mempool", hash)
+	}
+	return &ctypes.ResultUnconfirmedTx{
+		Hash:     hash,
+		Height:   entry.Height,
+		Priority: entry.Priority,
+		Sender:   entry.Sender,
+		Nonce:    entry.Nonce,
+		Tx:       entry.Tx,
+	}, nil
+}
+
 // NumUnconfirmedTxs gets number of unconfirmed transactions.
 // More: https://docs.tendermint.com/master/rpc/#/Info/num_unconfirmed_txs
 func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, error) {
@@ -155,6 +203,14 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 		TotalBytes: env.Mempool.TxsBytes()}, nil
 }
 
+func txKeyFromHash(hash []byte) (txKey [mempl.TxKeySize]byte, err error) {
+	if len(hash) != mempl.TxKeySize {
+		return txKey, fmt.Errorf("expected tx hash of %d bytes, got %d", mempl.TxKeySize, len(hash))
+	}
+	copy(txKey[:], hash)
+	return txKey, nil
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/check_tx
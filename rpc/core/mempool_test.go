@@ -0,0 +1,108 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mempl "github.com/lazyledger/lazyledger-core/mempool"
+	"github.com/lazyledger/lazyledger-core/mempool/mock"
+	rpctypes "github.com/lazyledger/lazyledger-core/rpc/jsonrpc/types"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// entriesMempool is a mempool holding the given entries.
+type entriesMempool struct {
+	mock.Mempool
+	entries []mempl.TxEntry
+	locked  bool
+}
+
+func (mem *entriesMempool) Lock()   { mem.locked = true }
+func (mem *entriesMempool) Unlock() { mem.locked = false }
+
+func (mem *entriesMempool) Size() int                  { return len(mem.entries) }
+func (mem *entriesMempool) TxEntries() []mempl.TxEntry { return mem.entries }
+
+func (mem *entriesMempool) TxByKey(txKey [mempl.TxKeySize]byte) (mempl.TxEntry, bool) {
+	for _, entry := range mem.entries {
+		if mempl.TxKey(entry.Tx) == txKey {
+			return entry, true
+		}
+	}
+	return mempl.TxEntry{}, false
+}
+
+func (mem *entriesMempool) RemoveTxByKey(txKey [mempl.TxKeySize]byte, _ bool) error {
+	if !mem.locked {
+		panic("mempool not locked")
+	}
+	for i, entry := range mem.entries {
+		if mempl.TxKey(entry.Tx) == txKey {
+			mem.entries = append(mem.entries[:i], mem.entries[i+1:]...)
+			return nil
+		}
+	}
+	return mempl.ErrTxNotFound
+}
+
+func TestUnconfirmedTxsFilters(t *testing.T) {
+	entries := []mempl.TxEntry{
+		{Tx: types.Tx("a"), Priority: 3, Sender: "alice"},
+		{Tx: types.Tx("b"), Priority: 1, Sender: "bob"},
+		{Tx: types.Tx("c"), Priority: 2, Sender: "alice"},
+		{Tx: types.Tx("d"), Priority: 5},
+	}
+	env = &Environment{Mempool: &entriesMempool{entries: entries}}
+
+	two, three := int64(2), int64(3)
+	one := 1
+	testCases := []struct {
+		limit       *int
+		sender      string
+		minPriority *int64
+		expected    types.Txs
+	}{
+		{nil, "alice", nil, types.Txs{types.Tx("a"), types.Tx("c")}},
+		{nil, "", &two, types.Txs{types.Tx("a"), types.Tx("c"), types.Tx("d")}},
+		{nil, "alice", &three, types.Txs{types.Tx("a")}},
+		{&one, "", &two, types.Txs{types.Tx("a")}},
+		{nil, "carol", nil, types.Txs{}},
+	}
+
+	for i, tc := range testCases {
+		res, err := UnconfirmedTxs(&rpctypes.Context{}, tc.limit, tc.sender, tc.minPriority)
+		require.NoError(t, err, i)
+		assert.Equal(t, tc.expected, types.Txs(res.Txs), i)
+		assert.Equal(t, len(tc.expected), res.Count, i)
+		assert.Equal(t, len(entries), res.Total, i)
+	}
+}
+
+func TestUnconfirmedTxAndUnsafeRemoveTx(t *testing.T) {
+	tx := types.Tx("a")
+	env = &Environment{Mempool: &entriesMempool{entries: []mempl.TxEntry{
+		{Tx: tx, Height: 4, Priority: 3, Sender: "alice", Nonce: 7},
+	}}}
+
+	_, err := UnconfirmedTx(&rpctypes.Context{}, []byte("short"))
+	assert.Error(t, err)
+
+	res, err := UnconfirmedTx(&rpctypes.Context{}, tx.Hash())
+	require.NoError(t, err)
+	assert.EqualValues(t, tx.Hash(), res.Hash)
+	assert.Equal(t, tx, res.Tx)
+	assert.EqualValues(t, 4, res.Height)
+	assert.EqualValues(t, 3, res.Priority)
+	assert.Equal(t, "alice", res.Sender)
+	assert.EqualValues(t, 7, res.Nonce)
+
+	_, err = UnsafeRemoveTx(&rpctypes.Context{}, tx.Hash())
+	require.NoError(t, err)
+	assert.False(t, env.Mempool.(*entriesMempool).locked)
+	_, err = UnconfirmedTx(&rpctypes.Context{}, tx.Hash())
+	assert.Error(t, err)
+	_, err = UnsafeRemoveTx(&rpctypes.Context{}, tx.Hash())
+	assert.ErrorIs(t, err, mempl.ErrTxNotFound)
+}
@@ -17,6 +17,7 @@ var Routes = map[string]*rpc.RPCFunc{
 	"health":                   rpc.NewRPCFunc(Health, ""),
 	"status":                   rpc.NewRPCFunc(Status, ""),
 	"net_info":                 rpc.NewRPCFunc(NetInfo, ""),
+	"ipfs_info":                rpc.NewRPCFunc(IPFSInfo, ""),
 	"blockchain":               rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
 	"genesis":                  rpc.NewRPCFunc(Genesis, ""),
 	"block":                    rpc.NewRPCFunc(Block, "height"),
@@ -31,7 +32,8 @@ var Routes = map[string]*rpc.RPCFunc{
 	"dump_consensus_state":     rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":          rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":         rpc.NewRPCFunc(ConsensusParams, "height"),
-	"unconfirmed_txs":          rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
+	"unconfirmed_tx":           rpc.NewRPCFunc(UnconfirmedTx, "hash"),
+	"unconfirmed_txs":          rpc.NewRPCFunc(UnconfirmedTxs, "limit,sender,min_priority"),
 	"num_unconfirmed_txs":      rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
 
 	// tx broadcast API
@@ -45,6 +47,8 @@ var Routes = map[string]*rpc.RPCFunc{
 
 	// evidence API
 	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"pending_evidence":   rpc.NewRPCFunc(PendingEvidence, "min_height,max_height,validator,type,page,per_page"),
+	"committed_evidence": rpc.NewRPCFunc(CommittedEvidence, "min_height,max_height,validator,type,page,per_page"),
 }
 
 // AddUnsafeRoutes adds unsafe routes.
@@ -53,4 +57,5 @@ func AddUnsafeRoutes() {
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional,private")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	Routes["remove_tx"] = rpc.NewRPCFunc(UnsafeRemoveTx, "hash")
 }
@@ -108,6 +108,20 @@ type ResultNetInfo struct {
 	Peers     []Peer   `json:"peers"`
 }
 
+// Info about the IPFS node
+type ResultIPFSInfo struct {
+	ID        string     `json:"id"`
+	Addresses []string   `json:"addresses"`
+	NPeers    int        `json:"n_peers"`
+	Peers     []IPFSPeer `json:"peers"`
+}
+
+// A connected IPFS peer
+type IPFSPeer struct {
+	ID      string `json:"id"`
+	Address string `json:"address"`
+}
+
 // Log from dialing seeds
 type ResultDialSeeds struct {
 	Log string `json:"log"`
@@ -124,6 +138,8 @@ type Peer struct {
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	// TrustScore is the score of the peer in fast sync, between 0 and 100.
+	TrustScore *int `json:"trust_score,omitempty"`
 }
 
 // Validators for a height.
@@ -145,8 +161,9 @@ type ResultConsensusParams struct {
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
-	RoundState json.RawMessage `json:"round_state"`
-	Peers      []PeerStateInfo `json:"peers"`
+	RoundState       json.RawMessage `json:"round_state"`
+	Peers            []PeerStateInfo `json:"peers"`
+	DataAvailability json.RawMessage `json:"data_availability"`
 }
 
 // UNSTABLE
@@ -207,6 +224,16 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// Mempool tx along with what the app reported about it in CheckTx
+type ResultUnconfirmedTx struct {
+	Hash     bytes.HexBytes `json:"hash"`
+	Height   int64          `json:"height"`
+	Priority int64          `json:"priority"`
+	Sender   string         `json:"sender"`
+	Nonce    uint64         `json:"nonce"`
+	Tx       types.Tx       `json:"tx"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -222,9 +249,19 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// Result of listing pending or committed evidence
+type ResultEvidenceList struct {
+	Evidence []types.Evidence `json:"evidence"`
+	// Count of actual evidence in this result
+	Count int `json:"count"`
+	// Total number of evidence matching the filter
+	Total int `json:"total"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
+	ResultUnsafeRemoveTx     struct{}
 	ResultUnsafeProfile      struct{}
 	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
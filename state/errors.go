@@ -48,6 +48,13 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	ErrIntermediateStateRootMismatch struct {
+		Height int64
+		Index  int
+		Block  []byte
+		App    []byte
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -99,3 +106,13 @@ func (e ErrNoConsensusParamsForHeight) Error() string {
 func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("could not find results for height #%d", e.Height)
 }
+
+func (e ErrIntermediateStateRootMismatch) Error() string {
+	return fmt.Sprintf(
+		"intermediate state root #%d of block %d (%X) does not match app's root (%X)",
+		e.Index,
+		e.Height,
+		e.Block,
+		e.App,
+	)
+}
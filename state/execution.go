@@ -1,9 +1,11 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
@@ -15,6 +17,7 @@ import (
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	"github.com/lazyledger/lazyledger-core/proxy"
 	"github.com/lazyledger/lazyledger-core/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 )
 
 //-----------------------------------------------------------------------------
@@ -101,22 +104,28 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	maxGas := state.ConsensusParams.Block.MaxGas
 
-	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	evidence, _ := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
 
-	// Fetch a limited amount of valid txs
-	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
+	// Fetch as many valid txs, up to the max gas, as fit into the original data
+	// square next to the evidence, along with the messages they pay for and
+	// room for their intermediate state roots.
+	evData := types.EvidenceData{Evidence: evidence}
+	maxShares := consts.MaxSquareSize*consts.MaxSquareSize - evData.SharesUsed()
+	txs, msgs := blockExec.mempool.ReapMaxSharesMaxGas(maxShares, maxGas)
+
+	// The encoded block must not exceed MaxBytes either, with its data encoded as shares.
+	maxDataBytes := types.MaxDataBytes(maxBytes, int64(evData.SharesUsed()*consts.ShareSize), state.Validators.Size())
+	n := sort.Search(len(txs), func(i int) bool {
+		return types.ComputeShareSizeForTxsAndMessages(txs[:i+1], msgs[:i+1]) > maxDataBytes
+	})
+	txs, msgs = txs[:n], msgs[:n]
 
-	// TODO(ismail): reaping the mempool has to happen in relation to a max
-	// allowed square size instead of (only) Gas / bytes
-	// maybe the mempool actually should track things separately
-	// meaning that CheckTx should already do the mapping:
-	// Tx -> Txs, Message
-	// https://github.com/lazyledger/lazyledger-core/issues/77
-	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
 	l := len(txs)
 	bzs := make([][]byte, l)
+	pbmsgs := make([]tmproto.Message, l)
 	for i := 0; i < l; i++ {
 		bzs[i] = txs[i]
+		pbmsgs[i] = msgs[i].ToProto()
 	}
 
 	// TODO(ismail):
@@ -128,7 +137,7 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	//  2. feed them into MakeBlock below:
 	processedBlockTxs, err := blockExec.proxyApp.PreprocessTxsSync(
 		context.Background(),
-		abci.RequestPreprocessTxs{Txs: bzs},
+		abci.RequestPreprocessTxs{Txs: bzs, Messages: pbmsgs},
 	)
 	if err != nil {
 		// The App MUST ensure that only valid (and hence 'processable')
@@ -147,15 +156,31 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 
 	lp := len(ppt)
 	processedTxs := make(types.Txs, lp)
-	if lp > 0 {
-		for i := 0; i < l; i++ {
-			processedTxs[i] = ppt[i]
-		}
+	for i := 0; i < lp; i++ {
+		processedTxs[i] = ppt[i]
 	}
 
 	messages := types.MessagesFromProto(pbmessages)
 
-	return state.MakeBlock(height, processedTxs, evidence, nil, messages, commit, proposerAddr)
+	pbisrs := processedBlockTxs.GetIntermediateStateRoots()
+	isrs := types.IntermediateStateRoots{TxsPerRoot: pbisrs.GetTxsPerRoot()}
+	for _, isr := range pbisrs.GetRawRootsList() {
+		isrs.RawRootsList = append(isrs.RawRootsList, isr)
+	}
+	switch {
+	case len(isrs.RawRootsList) == 0:
+		isrs.TxsPerRoot = 0
+	case isrs.TxsPerRoot > uint32(len(processedTxs)):
+		// a single root covers all txs
+		isrs.TxsPerRoot = uint32(len(processedTxs))
+	}
+	if err := isrs.ValidateBasic(len(processedTxs)); err != nil {
+		// As above, the app must return a root for every TxsPerRoot txs it returned, we
+		// can not propose a valid block otherwise.
+		panic(fmt.Sprintf("app returned invalid intermediate state roots: %v", err))
+	}
+
+	return state.MakeBlock(height, processedTxs, evidence, isrs, messages, commit, proposerAddr)
 }
 
 // ValidateBlock validates the given block against the given state.
@@ -170,6 +195,30 @@ func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) e
 	return blockExec.evpool.CheckEvidence(block.Evidence.Evidence)
 }
 
+// ProcessProposal asks the application to vet the data of the given proposed block.
+// It returns false only if the application rejected the block. An UNKNOWN result, as
+// returned by applications not implementing ProcessProposal, accepts the block.
+func (blockExec *BlockExecutor) ProcessProposal(block *types.Block) (bool, error) {
+	pb, err := block.ToProto()
+	if err != nil {
+		return false, err
+	}
+
+	res, err := blockExec.proxyApp.ProcessProposalSync(
+		context.Background(),
+		abci.RequestProcessProposal{
+			Header:                 pb.Header,
+			Data:                   pb.Data,
+			DataAvailabilityHeader: pb.DataAvailabilityHeader,
+		},
+	)
+	if err != nil {
+		return false, ErrProxyAppConn(err)
+	}
+
+	return res.Result != abci.ResponseProcessProposal_REJECT, nil
+}
+
 // ApplyBlock validates the block against the state, executes it against the app,
 // fires the relevant events, commits the app, and saves the new state and responses.
 // It returns the new state and the block height to retain (pruning older blocks).
@@ -193,6 +242,18 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, 0, ErrProxyAppConn(err)
 	}
 
+	// The intermediate state roots committed to by the proposer were vetted by the
+	// validators in ProcessProposal, before voting. If they still don't match the ones
+	// computed by the app, the block is rejected before the app commits its state, so
+	// that the node doesn't build on top of it. The evidence of the invalid state
+	// transition is published for light clients, which can verify it.
+	if err := validateIntermediateStateRoots(block, abciResponses.DeliverTxs); err != nil {
+		if mismatch, ok := err.(ErrIntermediateStateRootMismatch); ok {
+			blockExec.publishStateTransitionFraud(block, mismatch.Index)
+		}
+		return state, 0, ErrInvalidBlock(err)
+	}
+
 	fail.Fail() // XXX
 
 	// Save the results before we commit.
@@ -424,6 +485,115 @@ func getBeginBlockValidatorInfo(block *types.Block, store Store,
 	}
 }
 
+// publishStateTransitionFraud publishes the proof that the intermediate state root
+// isrIndex of the block is invalid. The proof is not evidence against validators and
+// verifying it requires the stateless verifier of the app: it is only published for
+// light clients.
+func (blockExec *BlockExecutor) publishStateTransitionFraud(block *types.Block, isrIndex int) {
+	fp := blockExec.stateTransitionFraudProof(block, isrIndex)
+	if fp == nil {
+		return
+	}
+	if err := blockExec.eventBus.PublishEventStateTransitionFraud(types.EventDataStateTransitionFraud{
+		Proof:  fp,
+		Height: block.Height,
+	}); err != nil {
+		blockExec.logger.Error("Error publishing state transition fraud", "err", err)
+	}
+}
+
+// stateTransitionFraudProof asks the app for the witnesses of the txs leading to the
+// invalid intermediate state root isrIndex of the block and returns the proof of it.
+// It returns nil if the block lacks that root or the proof could not be created.
+func (blockExec *BlockExecutor) stateTransitionFraudProof(
+	block *types.Block,
+	isrIndex int,
+) *types.StateTransitionFraudProof {
+	if isrIndex >= len(block.IntermediateStateRoots.RawRootsList) {
+		return nil
+	}
+	first, end := block.IntermediateStateRoots.TxRange(uint32(isrIndex), len(block.Txs))
+	if first >= end {
+		return nil
+	}
+
+	txs := make([][]byte, end-first)
+	for i := range txs {
+		txs[i] = block.Txs[first+i]
+	}
+	res, err := blockExec.proxyApp.GenerateFraudProofSync(
+		context.Background(),
+		abci.RequestGenerateFraudProof{Height: block.Height, FirstTxIndex: uint32(first), Txs: txs},
+	)
+	if err != nil {
+		blockExec.logger.Error("Failed to generate fraud proof", "height", block.Height, "err", err)
+		return nil
+	}
+
+	fp, err := types.NewStateTransitionFraudProof(block, uint32(isrIndex), res.StateWitness)
+	if err != nil {
+		blockExec.logger.Error("Failed to create state transition fraud proof", "height", block.Height, "err", err)
+		return nil
+	}
+	return fp
+}
+
+// validateIntermediateStateRoots checks that the intermediate state roots in the
+// block data match the ones returned by the app in the DeliverTx responses of the
+// last tx of every root, see types.IntermediateStateRoots.TxRange. An app returning
+// no roots at all doesn't commit to intermediate state roots.
+func validateIntermediateStateRoots(block *types.Block, deliverTxs []*abci.ResponseDeliverTx) error {
+	var appRoots [][]byte
+	for _, res := range deliverTxs {
+		if res != nil && len(res.IntermediateStateRoot) > 0 {
+			appRoots = intermediateStateRoots(block.IntermediateStateRoots, deliverTxs)
+			break
+		}
+	}
+
+	blockRoots := block.IntermediateStateRoots.RawRootsList
+	for i := 0; i < len(blockRoots) || i < len(appRoots); i++ {
+		var blockRoot, appRoot []byte
+		if i < len(blockRoots) {
+			blockRoot = blockRoots[i]
+		}
+		if i < len(appRoots) {
+			appRoot = appRoots[i]
+		}
+		if !bytes.Equal(blockRoot, appRoot) {
+			return ErrIntermediateStateRootMismatch{
+				Height: block.Height,
+				Index:  i,
+				Block:  blockRoot,
+				App:    appRoot,
+			}
+		}
+	}
+	return nil
+}
+
+// intermediateStateRoots returns the roots the app returned for the last tx of
+// every intermediate state root of the block. A missing root is returned as nil.
+// If the block commits to no roots, all the roots returned by the app are.
+func intermediateStateRoots(isrs types.IntermediateStateRoots, deliverTxs []*abci.ResponseDeliverTx) [][]byte {
+	var roots [][]byte
+	if isrs.TxsPerRoot == 0 {
+		for _, res := range deliverTxs {
+			if root := res.GetIntermediateStateRoot(); len(root) > 0 {
+				roots = append(roots, root)
+			}
+		}
+		return roots
+	}
+	for i := uint32(0); ; i++ {
+		first, end := isrs.TxRange(i, len(deliverTxs))
+		if first == end {
+			return roots
+		}
+		roots = append(roots, deliverTxs[end-1].GetIntermediateStateRoot())
+	}
+}
+
 func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 	params tmproto.ValidatorParams) error {
 	for _, valUpdate := range abciUpdates {
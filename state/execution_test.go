@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lazyledger/lazyledger-core/abci/example/kvstore"
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
 	"github.com/lazyledger/lazyledger-core/crypto"
 	"github.com/lazyledger/lazyledger-core/crypto/ed25519"
@@ -57,6 +59,327 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+type proposalApp struct {
+	abci.BaseApplication
+
+	result abci.ResponseProcessProposal_Result
+	req    abci.RequestProcessProposal
+}
+
+func (app *proposalApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	app.req = req
+	return abci.ResponseProcessProposal{Result: app.result}
+}
+
+// TestProcessProposal ensures the block data is passed to the app and only
+// an explicit REJECT rejects the block.
+func TestProcessProposal(t *testing.T) {
+	testCases := []struct {
+		result   abci.ResponseProcessProposal_Result
+		accepted bool
+	}{
+		{abci.ResponseProcessProposal_ACCEPT, true},
+		{abci.ResponseProcessProposal_REJECT, false},
+		{abci.ResponseProcessProposal_UNKNOWN, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.result.String(), func(t *testing.T) {
+			app := &proposalApp{result: tc.result}
+			cc := proxy.NewLocalClientCreator(app)
+			proxyApp := proxy.NewAppConns(cc)
+			err := proxyApp.Start()
+			require.Nil(t, err)
+			defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+			state, stateDB, _ := makeState(1, 1)
+			stateStore := sm.NewStore(stateDB)
+
+			blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+				mmock.Mempool{}, sm.EmptyEvidencePool{})
+
+			block := makeBlock(state, 1)
+			accepted, err := blockExec.ProcessProposal(block)
+			require.NoError(t, err)
+			assert.Equal(t, tc.accepted, accepted)
+			header, err := types.HeaderFromProto(&app.req.Header)
+			require.NoError(t, err)
+			assert.Equal(t, block.Hash(), header.Hash())
+			assert.Equal(t, len(block.Txs), len(app.req.Data.Txs))
+			assert.NotNil(t, app.req.DataAvailabilityHeader)
+		})
+	}
+}
+
+// isrApp returns an intermediate state root after every txsPerRoot txs and
+// proposes the given roots in PreprocessTxs.
+type isrApp struct {
+	testApp
+
+	txsPerRoot int
+	nTxs       int
+	roots      [][]byte
+}
+
+func (app *isrApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	app.nTxs++
+	res := abci.ResponseDeliverTx{}
+	if app.nTxs%app.txsPerRoot == 0 {
+		res.IntermediateStateRoot = isr(app.nTxs)
+	}
+	return res
+}
+
+func (app *isrApp) PreprocessTxs(req abci.RequestPreprocessTxs) abci.ResponsePreprocessTxs {
+	return abci.ResponsePreprocessTxs{
+		Txs: req.Txs,
+		IntermediateStateRoots: &tmproto.IntermediateStateRoots{
+			RawRootsList: app.roots,
+			TxsPerRoot:   uint32(app.txsPerRoot),
+		},
+	}
+}
+
+func (app *isrApp) GenerateFraudProof(req abci.RequestGenerateFraudProof) abci.ResponseGenerateFraudProof {
+	return abci.ResponseGenerateFraudProof{StateWitness: []byte("witness")}
+}
+
+func isr(n int) []byte {
+	return []byte(fmt.Sprintf("root-%d", n))
+}
+
+// isrs returns the roots of isrApp for nTxs txs.
+func isrs(txsPerRoot, nTxs int) types.IntermediateStateRoots {
+	roots := types.IntermediateStateRoots{TxsPerRoot: uint32(txsPerRoot)}
+	for n := txsPerRoot; n <= nTxs; n += txsPerRoot {
+		roots.RawRootsList = append(roots.RawRootsList, isr(n))
+	}
+	return roots
+}
+
+func TestApplyBlockIntermediateStateRoots(t *testing.T) {
+	const txsPerRoot = 2
+	roots := isrs(txsPerRoot, nTxsPerBlock)
+	wrongRoot := isrs(txsPerRoot, nTxsPerBlock)
+	wrongRoot.RawRootsList[len(wrongRoot.RawRootsList)-1] = []byte("wrong")
+
+	testCases := []struct {
+		name     string
+		roots    types.IntermediateStateRoots
+		expErr   bool
+		expEvIdx int // index of the root the fraud evidence is expected for, -1 for none
+	}{
+		{"matching roots", roots, false, -1},
+		{"no roots", types.IntermediateStateRoots{}, true, -1},
+		{"other txs per root", isrs(1, nTxsPerBlock), true, 0},
+		{"wrong root", wrongRoot, true, len(roots.RawRootsList) - 1},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			app := &isrApp{txsPerRoot: txsPerRoot}
+			cc := proxy.NewLocalClientCreator(app)
+			proxyApp := proxy.NewAppConns(cc)
+			err := proxyApp.Start()
+			require.Nil(t, err)
+			defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+			state, stateDB, _ := makeState(1, 1)
+			stateStore := sm.NewStore(stateDB)
+
+			blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+				mmock.Mempool{}, sm.EmptyEvidencePool{})
+
+			eventBus := types.NewEventBus()
+			require.NoError(t, eventBus.Start())
+			defer eventBus.Stop() //nolint:errcheck // ignore for tests
+			blockExec.SetEventBus(eventBus)
+
+			fraudSub, err := eventBus.Subscribe(context.Background(), "TestApplyBlockIntermediateStateRoots",
+				types.EventQueryStateTransitionFraud)
+			require.NoError(t, err)
+
+			block, _ := state.MakeBlock(1, makeTxs(state.LastBlockHeight), nil, tc.roots, types.Messages{},
+				new(types.Commit), state.Validators.GetProposer().Address)
+			blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+			_, _, err = blockExec.ApplyBlock(state, blockID, block)
+			if !tc.expErr {
+				require.NoError(t, err)
+				_, err = stateStore.LoadABCIResponses(block.Height)
+				assert.NoError(t, err)
+			} else {
+				// the block is rejected before its results are saved
+				assert.IsType(t, sm.ErrIntermediateStateRootMismatch{}, err)
+				_, err = stateStore.LoadABCIResponses(block.Height)
+				assert.Error(t, err)
+			}
+
+			if tc.expEvIdx < 0 {
+				select {
+				case msg := <-fraudSub.Out():
+					t.Fatalf("unexpected state transition fraud: %v", msg.Data())
+				default:
+				}
+				return
+			}
+			var fp *types.StateTransitionFraudProof
+			select {
+			case msg := <-fraudSub.Out():
+				event, ok := msg.Data().(types.EventDataStateTransitionFraud)
+				require.True(t, ok, "Expected event of type EventDataStateTransitionFraud, got %T", msg.Data())
+				assert.Equal(t, block.Height, event.Height)
+				fp = event.Proof
+			case <-time.After(1 * time.Second):
+				t.Fatal("Did not receive EventStateTransitionFraud within 1 sec.")
+			}
+			require.NotNil(t, fp)
+			first, end := tc.roots.TxRange(uint32(tc.expEvIdx), nTxsPerBlock)
+			assert.EqualValues(t, first, fp.FirstTxIndex)
+			assert.EqualValues(t, end-first, fp.NumTxs)
+			assert.Equal(t, []byte("witness"), fp.StateWitness)
+		})
+	}
+}
+
+func TestValidateIntermediateStateRootsNilResponse(t *testing.T) {
+	const txsPerRoot = 2
+	state, _, _ := makeState(1, 1)
+	txs := makeTxs(state.LastBlockHeight)
+	deliverTxs := make([]*abci.ResponseDeliverTx, len(txs))
+	for i := range deliverTxs {
+		if (i+1)%txsPerRoot == 0 {
+			deliverTxs[i] = &abci.ResponseDeliverTx{IntermediateStateRoot: isr(i + 1)}
+		}
+	}
+	block, _ := state.MakeBlock(1, txs, nil, isrs(txsPerRoot, len(txs)), types.Messages{}, new(types.Commit),
+		state.Validators.GetProposer().Address)
+
+	assert.NoError(t, sm.ValidateIntermediateStateRoots(block, deliverTxs))
+	deliverTxs[len(deliverTxs)-1] = nil
+	assert.IsType(t, sm.ErrIntermediateStateRootMismatch{}, sm.ValidateIntermediateStateRoots(block, deliverTxs))
+}
+
+func TestValidateIntermediateStateRootsUnused(t *testing.T) {
+	state, _, _ := makeState(1, 1)
+	txs := makeTxs(state.LastBlockHeight)
+	block, _ := state.MakeBlock(1, txs, nil, types.IntermediateStateRoots{}, types.Messages{}, new(types.Commit),
+		state.Validators.GetProposer().Address)
+
+	// an app not returning any roots doesn't commit to them
+	deliverTxs := make([]*abci.ResponseDeliverTx, len(txs))
+	assert.NoError(t, sm.ValidateIntermediateStateRoots(block, deliverTxs))
+
+	// once it returns one, the block must commit to it
+	deliverTxs[len(txs)-1] = &abci.ResponseDeliverTx{IntermediateStateRoot: isr(len(txs))}
+	assert.IsType(t, sm.ErrIntermediateStateRootMismatch{}, sm.ValidateIntermediateStateRoots(block, deliverTxs))
+}
+
+func TestCreateProposalBlockIntermediateStateRoots(t *testing.T) {
+	testCases := []struct {
+		name          string
+		txsPerRoot    int
+		roots         [][]byte
+		expTxsPerRoot uint32
+	}{
+		{"one root per tx", 1, [][]byte{isr(1), isr(2), isr(3)}, 1},
+		{"one root for two txs", 2, [][]byte{isr(2), isr(3)}, 2},
+		{"one root for more txs than proposed", 5, [][]byte{isr(3)}, 3},
+		{"no roots", 2, nil, 0},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			app := &isrApp{txsPerRoot: tc.txsPerRoot, roots: tc.roots}
+			cc := proxy.NewLocalClientCreator(app)
+			proxyApp := proxy.NewAppConns(cc)
+			err := proxyApp.Start()
+			require.Nil(t, err)
+			defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+			state, stateDB, _ := makeState(1, 1)
+			stateStore := sm.NewStore(stateDB)
+
+			mempool := messageMempool{
+				txs:  types.Txs{types.Tx("tx0"), types.Tx("tx1"), types.Tx("tx2")},
+				msgs: []types.Message{types.MessageEmpty, types.MessageEmpty, types.MessageEmpty},
+			}
+			blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+				mempool, sm.EmptyEvidencePool{})
+
+			block, _ := blockExec.CreateProposalBlock(1, state, new(types.Commit),
+				state.Validators.GetProposer().Address)
+			assert.Equal(t, tc.expTxsPerRoot, block.IntermediateStateRoots.TxsPerRoot)
+			require.Len(t, block.IntermediateStateRoots.RawRootsList, len(tc.roots))
+			for i, root := range tc.roots {
+				assert.EqualValues(t, root, block.IntermediateStateRoots.RawRootsList[i])
+			}
+			assert.NoError(t, block.ValidateBasic())
+		})
+	}
+}
+
+// messageMempool reaps fixed txs along with the messages they pay for.
+type messageMempool struct {
+	mmock.Mempool
+
+	txs  types.Txs
+	msgs []types.Message
+}
+
+func (mem messageMempool) ReapMaxSharesMaxGas(_ int, _ int64) (types.Txs, []types.Message) {
+	return mem.txs, mem.msgs
+}
+
+func TestCreateProposalBlockMessages(t *testing.T) {
+	var preprocessed abci.RequestPreprocessTxs
+	app := &messageApp{Application: kvstore.NewApplication(), req: &preprocessed}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+
+	msg := types.Message{NamespaceID: []byte{1, 2, 3, 4, 5, 6, 7, 8}, Data: []byte("message")}
+	mempool := messageMempool{
+		txs:  types.Txs{types.Tx("tx0"), types.Tx("tx1")},
+		msgs: []types.Message{types.MessageEmpty, msg},
+	}
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mempool, sm.EmptyEvidencePool{})
+
+	block, _ := blockExec.CreateProposalBlock(1, state, new(types.Commit), state.Validators.GetProposer().Address)
+
+	// the app received the txs along with their messages
+	require.Len(t, preprocessed.Messages, 2)
+	assert.Empty(t, preprocessed.Messages[0].Data)
+	assert.Equal(t, msg.ToProto(), preprocessed.Messages[1])
+
+	assert.Equal(t, mempool.txs, block.Txs)
+	assert.Equal(t, []types.Message{msg}, block.Messages.MessagesList)
+
+	// and the messages survive encoding the block
+	pb, err := block.ToProto()
+	require.NoError(t, err)
+	decoded, err := types.BlockFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, block.Messages, decoded.Messages)
+}
+
+type messageApp struct {
+	*kvstore.Application
+
+	req *abci.RequestPreprocessTxs
+}
+
+func (app *messageApp) PreprocessTxs(req abci.RequestPreprocessTxs) abci.ResponsePreprocessTxs {
+	*app.req = req
+	return app.Application.PreprocessTxs(req)
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -100,7 +423,7 @@ func TestBeginBlockValidators(t *testing.T) {
 		lastCommit := types.NewCommit(1, 0, prevBlockID, tc.lastCommitSigs)
 
 		// block for height 2
-		block, _ := state.MakeBlock(2, makeTxs(2), nil, nil,
+		block, _ := state.MakeBlock(2, makeTxs(2), nil, types.IntermediateStateRoots{},
 			types.Messages{}, lastCommit, state.Validators.GetProposer().Address)
 
 		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateStore, 1)
@@ -45,3 +45,9 @@ func SaveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *type
 	stateStore := dbStore{db}
 	return stateStore.saveValidatorsInfo(height, lastHeightChanged, valSet)
 }
+
+// ValidateIntermediateStateRoots is an alias for validateIntermediateStateRoots
+// exported from execution.go, exclusively and explicitly for testing.
+func ValidateIntermediateStateRoots(block *types.Block, deliverTxs []*abci.ResponseDeliverTx) error {
+	return validateIntermediateStateRoots(block, deliverTxs)
+}
@@ -58,7 +58,7 @@ func makeAndApplyGoodBlock(state sm.State, height int64, lastCommit *types.Commi
 		height,
 		makeTxs(height),
 		evidence,
-		nil,
+		types.IntermediateStateRoots{},
 		types.Messages{},
 		lastCommit,
 		proposerAddr,
@@ -144,7 +144,7 @@ func makeBlock(state sm.State, height int64) *types.Block {
 		height,
 		makeTxs(state.LastBlockHeight),
 		nil,
-		nil,
+		types.IntermediateStateRoots{},
 		types.Messages{},
 		new(types.Commit),
 		state.Validators.GetProposer().Address,
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
-	tmbytes "github.com/lazyledger/lazyledger-core/libs/bytes"
 
 	tmstate "github.com/lazyledger/lazyledger-core/proto/tendermint/state"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
@@ -237,7 +236,7 @@ func (state State) MakeBlock(
 	height int64,
 	txs []types.Tx,
 	evidence []types.Evidence,
-	intermediateStateRoots []tmbytes.HexBytes,
+	intermediateStateRoots types.IntermediateStateRoots,
 	messages types.Messages,
 	commit *types.Commit,
 	proposerAddress []byte,
@@ -0,0 +1,162 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/lazyledger/rsmt2d"
+
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
+	"github.com/lazyledger/lazyledger-core/p2p/ipld"
+	sm "github.com/lazyledger/lazyledger-core/state"
+	"github.com/lazyledger/lazyledger-core/store"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// restoreDataTimeout is the timeout for reconstructing the data of a single restored block.
+const restoreDataTimeout = 30 * time.Second
+
+// restoredBlock is a signed header and data availability header fetched from the state provider.
+type restoredBlock struct {
+	sh  *types.SignedHeader
+	dah *types.DataAvailabilityHeader
+}
+
+// RestoreBlocks fetches the headers, commits and data availability headers of the last n blocks,
+// up to and including the last block height of the synced state, from the state provider and
+// saves them to the block store, which must be empty. If dag is non-nil, the data of each block
+// is also reconstructed from the DAG and the full block is saved, falling back to saving just its
+// headers and commit if that fails.
+//
+// Blocks are fetched from the snapshot height downwards and only saved once fetching stops, so
+// the restored blocks always end at the snapshot height, even if older blocks are unavailable.
+// It returns the number of restored blocks.
+func RestoreBlocks(
+	ctx context.Context,
+	logger log.Logger,
+	stateProvider StateProvider,
+	blockStore *store.BlockStore,
+	dag format.NodeGetter,
+	state sm.State,
+	n int64,
+) (int64, error) {
+	if blockStore.Height() > 0 {
+		return 0, fmt.Errorf("block store is not empty, it has blocks up to height %v", blockStore.Height())
+	}
+	if n <= 0 || state.LastBlockHeight <= 0 {
+		return 0, nil
+	}
+
+	first := tmmath.MaxInt64(state.LastBlockHeight-n+1, state.InitialHeight)
+	blocks := make([]restoredBlock, 0, state.LastBlockHeight-first+1)
+	for height := state.LastBlockHeight; height >= first; height-- {
+		block, err := fetchBlock(ctx, stateProvider, height)
+		if err != nil {
+			if len(blocks) == 0 {
+				return 0, err
+			}
+			logger.Error("Failed to fetch block, only restoring later blocks", "height", height, "err", err)
+			break
+		}
+		blocks = append(blocks, block)
+	}
+
+	// The block data is validated against the header, which needs the last commit of the block.
+	// For all but the oldest block it is the commit of the previous restored block.
+	var lastCommit *types.Commit
+	if dag != nil {
+		oldest := blocks[len(blocks)-1].sh.Height
+		if oldest == state.InitialHeight {
+			lastCommit = types.NewCommit(0, 0, types.BlockID{}, nil)
+		} else {
+			commit, err := stateProvider.Commit(ctx, uint64(oldest-1))
+			if err != nil {
+				logger.Error("Failed to fetch last commit of oldest block", "height", oldest, "err", err)
+			}
+			lastCommit = commit
+		}
+	}
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		b := blocks[i]
+		if dag != nil {
+			block, parts, err := reconstructBlock(ctx, dag, b, lastCommit)
+			lastCommit = b.sh.Commit
+			if err == nil {
+				blockStore.SaveBlock(block, parts, b.sh.Commit)
+				continue
+			}
+			logger.Error("Failed to reconstruct block data, only restoring headers",
+				"height", b.sh.Height, "err", err)
+		}
+		if err := blockStore.SaveSignedHeader(b.sh, b.dah); err != nil {
+			return int64(len(blocks) - 1 - i), fmt.Errorf("failed to save block %v: %w", b.sh.Height, err)
+		}
+	}
+
+	logger.Info("Restored blocks", "base", blockStore.Base(), "height", blockStore.Height(),
+		"data", dag != nil)
+	return int64(len(blocks)), nil
+}
+
+// fetchBlock fetches the signed header and data availability header at the given height, and
+// checks that they match.
+func fetchBlock(ctx context.Context, stateProvider StateProvider, height int64) (restoredBlock, error) {
+	sh, err := stateProvider.SignedHeader(ctx, uint64(height))
+	if err != nil {
+		return restoredBlock{}, fmt.Errorf("failed to fetch signed header: %w", err)
+	}
+	dah, err := stateProvider.DataAvailabilityHeader(ctx, uint64(height))
+	if err != nil {
+		return restoredBlock{}, fmt.Errorf("failed to fetch data availability header: %w", err)
+	}
+	if sh.Header == nil || sh.Commit == nil || !bytes.Equal(sh.Hash(), sh.Commit.BlockID.Hash) {
+		return restoredBlock{}, fmt.Errorf("invalid signed header at height %v", height)
+	}
+	if !bytes.Equal(dah.Hash(), sh.DataHash) {
+		return restoredBlock{}, fmt.Errorf("data availability header hash %X does not match data hash %X",
+			dah.Hash(), sh.DataHash)
+	}
+	return restoredBlock{sh: sh, dah: dah}, nil
+}
+
+// reconstructBlock retrieves the block data from the DAG and assembles the full block, verifying
+// that it matches the restored headers and commit.
+func reconstructBlock(
+	ctx context.Context,
+	dag format.NodeGetter,
+	b restoredBlock,
+	lastCommit *types.Commit,
+) (*types.Block, *types.PartSet, error) {
+	if lastCommit == nil {
+		return nil, nil, errors.New("last commit is not available")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, restoreDataTimeout)
+	defer cancel()
+	data, err := ipld.RetrieveBlockData(ctx, b.dah, dag, rsmt2d.NewRSGF8Codec())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block := &types.Block{
+		Header:                 *b.sh.Header,
+		Data:                   data,
+		DataAvailabilityHeader: *b.dah,
+		LastCommit:             lastCommit,
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return nil, nil, fmt.Errorf("invalid block: %w", err)
+	}
+	parts := block.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	if !blockID.Equals(b.sh.Commit.BlockID) {
+		return nil, nil, fmt.Errorf("block ID %v does not match commit for block %v", blockID, b.sh.Commit.BlockID)
+	}
+	return block, parts, nil
+}
@@ -0,0 +1,154 @@
+package statesync
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/ipfs"
+	"github.com/lazyledger/lazyledger-core/libs/db/memdb"
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/p2p/ipld"
+	sm "github.com/lazyledger/lazyledger-core/state"
+	"github.com/lazyledger/lazyledger-core/statesync/mocks"
+	"github.com/lazyledger/lazyledger-core/store"
+	"github.com/lazyledger/lazyledger-core/types"
+	tmtime "github.com/lazyledger/lazyledger-core/types/time"
+)
+
+// makeChain makes a chain of the given number of blocks, returning the blocks and their commits,
+// along with the state after the last block.
+func makeChain(t *testing.T, height int64) ([]*types.Block, []*types.Commit, sm.State) {
+	val, _ := types.RandValidator(false, 10)
+	genDoc := &types.GenesisDoc{
+		ChainID:     "test-chain",
+		GenesisTime: tmtime.Now(),
+		Validators:  []types.GenesisValidator{{Address: val.Address, PubKey: val.PubKey, Power: val.VotingPower}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	blocks := make([]*types.Block, 0, height)
+	commits := make([]*types.Commit, 0, height)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	for h := int64(1); h <= height; h++ {
+		txs := []types.Tx{types.Tx([]byte{byte(h), 1}), types.Tx([]byte{byte(h), 2})}
+		block, parts := state.MakeBlock(h, txs, nil, types.IntermediateStateRoots{}, types.Messages{}, lastCommit, val.Address)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		commit := types.NewCommit(h, 0, blockID, []types.CommitSig{
+			types.NewCommitSigForBlock([]byte("signature"), val.Address, block.Time.Add(time.Second)),
+		})
+
+		blocks = append(blocks, block)
+		commits = append(commits, commit)
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators
+		lastCommit = commit
+	}
+	return blocks, commits, state
+}
+
+// mockChainProvider sets up a state provider serving the given chain.
+func mockChainProvider(blocks []*types.Block, commits []*types.Commit) *mocks.StateProvider {
+	stateProvider := &mocks.StateProvider{}
+	for i, block := range blocks {
+		height := uint64(block.Height)
+		stateProvider.On("SignedHeader", mock.Anything, height).Return(
+			&types.SignedHeader{Header: &blocks[i].Header, Commit: commits[i]}, nil)
+		stateProvider.On("DataAvailabilityHeader", mock.Anything, height).Return(
+			&blocks[i].DataAvailabilityHeader, nil)
+		stateProvider.On("Commit", mock.Anything, height).Return(commits[i], nil)
+	}
+	return stateProvider
+}
+
+func TestRestoreBlocks(t *testing.T) {
+	blocks, commits, state := makeChain(t, 5)
+	stateProvider := mockChainProvider(blocks, commits)
+	blockStore := store.NewBlockStore(memdb.NewDB(), mdutils.Mock())
+
+	n, err := RestoreBlocks(ctx, log.TestingLogger(), stateProvider, blockStore, nil, state, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, n)
+	assert.EqualValues(t, 3, blockStore.Base())
+	assert.EqualValues(t, 5, blockStore.Height())
+
+	for _, block := range blocks[2:] {
+		meta := blockStore.LoadBlockMeta(block.Height)
+		require.NotNil(t, meta)
+		assert.Equal(t, block.Hash(), meta.BlockID.Hash)
+		assert.Equal(t, block.DataAvailabilityHeader.Hash(), meta.DAHeader.Hash())
+		assert.Nil(t, blockStore.LoadBlock(block.Height))
+	}
+	assert.Equal(t, commits[4].Hash(), blockStore.LoadSeenCommit(5).Hash())
+	assert.Equal(t, commits[3].Hash(), blockStore.LoadBlockCommit(4).Hash())
+
+	// the block store must be empty
+	_, err = RestoreBlocks(ctx, log.TestingLogger(), stateProvider, blockStore, nil, state, 3)
+	require.Error(t, err)
+}
+
+func TestRestoreBlocks_Data(t *testing.T) {
+	blocks, commits, state := makeChain(t, 5)
+	stateProvider := mockChainProvider(blocks, commits)
+	blockStore := store.NewBlockStore(memdb.NewDB(), mdutils.Mock())
+
+	// the data of block 3 is unavailable, so only its headers are restored
+	dag := mdutils.Mock()
+	for _, block := range blocks[3:] {
+		require.NoError(t, ipld.PutBlock(ctx, dag, block, ipfs.MockRouting(), log.TestingLogger()))
+	}
+
+	n, err := RestoreBlocks(ctx, log.TestingLogger(), stateProvider, blockStore, dag, state, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, n)
+
+	assert.Nil(t, blockStore.LoadBlock(3))
+	assert.NotNil(t, blockStore.LoadBlockMeta(3))
+	for _, block := range blocks[3:] {
+		restored := blockStore.LoadBlock(block.Height)
+		require.NotNil(t, restored, "block %v", block.Height)
+		assert.Equal(t, block.Hash(), restored.Hash())
+		assert.Equal(t, block.Data.Txs, restored.Data.Txs)
+	}
+}
+
+func TestRestoreBlocks_FetchError(t *testing.T) {
+	blocks, commits, state := makeChain(t, 5)
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("SignedHeader", mock.Anything, uint64(3)).Return(nil, errors.New("boom"))
+	for i := 3; i < 5; i++ {
+		height := uint64(blocks[i].Height)
+		stateProvider.On("SignedHeader", mock.Anything, height).Return(
+			&types.SignedHeader{Header: &blocks[i].Header, Commit: commits[i]}, nil)
+		stateProvider.On("DataAvailabilityHeader", mock.Anything, height).Return(
+			&blocks[i].DataAvailabilityHeader, nil)
+	}
+	blockStore := store.NewBlockStore(memdb.NewDB(), mdutils.Mock())
+
+	// only the blocks after the failed one are restored
+	n, err := RestoreBlocks(ctx, log.TestingLogger(), stateProvider, blockStore, nil, state, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+	assert.EqualValues(t, 4, blockStore.Base())
+	assert.EqualValues(t, 5, blockStore.Height())
+
+	// mismatched data availability headers are rejected
+	stateProvider = &mocks.StateProvider{}
+	stateProvider.On("SignedHeader", mock.Anything, uint64(5)).Return(
+		&types.SignedHeader{Header: &blocks[4].Header, Commit: commits[4]}, nil)
+	stateProvider.On("DataAvailabilityHeader", mock.Anything, uint64(5)).Return(
+		&blocks[3].DataAvailabilityHeader, nil)
+	blockStore = store.NewBlockStore(memdb.NewDB(), mdutils.Mock())
+	_, err = RestoreBlocks(ctx, log.TestingLogger(), stateProvider, blockStore, nil, state, 5)
+	require.Error(t, err)
+	assert.EqualValues(t, 0, blockStore.Height())
+}
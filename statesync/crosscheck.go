@@ -0,0 +1,358 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	"github.com/lazyledger/lazyledger-core/light"
+	lightprovider "github.com/lazyledger/lazyledger-core/light/provider"
+	tmstate "github.com/lazyledger/lazyledger-core/proto/tendermint/state"
+	sm "github.com/lazyledger/lazyledger-core/state"
+	"github.com/lazyledger/lazyledger-core/types"
+)
+
+// errProvidersDiverged is returned when the cross-checked state providers disagree.
+var errProvidersDiverged = errors.New("state providers diverged")
+
+// lightBlockProvider is a state provider which can also provide the light blocks its state data
+// is verified by, and report evidence against conflicting light blocks. This allows the multi
+// state provider to find out which light block two diverging providers disagree on.
+type lightBlockProvider interface {
+	StateProvider
+	// LightBlock returns the verified light block at the given height.
+	LightBlock(ctx context.Context, height uint64) (*types.LightBlock, error)
+	// TrustedRoot returns the light block that the provider's trust is rooted in.
+	TrustedRoot() (*types.LightBlock, error)
+	// ReportEvidence reports evidence of misbehavior to the provider's source.
+	ReportEvidence(ctx context.Context, ev types.Evidence) error
+}
+
+// multiStateProvider is a state provider which queries several independent state providers in
+// parallel and cross-checks their responses.
+type multiStateProvider struct {
+	logger    log.Logger
+	providers []StateProvider
+}
+
+// providerResult is the result of querying a single state provider.
+type providerResult struct {
+	provider StateProvider
+	value    interface{}
+}
+
+// NewMultiStateProvider creates a new StateProvider which queries all of the given state
+// providers in parallel. At least two providers must respond, and all providers which respond
+// must agree, otherwise an error is returned. Only the app hash, validator sets, consensus
+// parameters and block IDs of the state are cross-checked.
+//
+// When two providers which also provide light blocks diverge, the conflicting light blocks are
+// turned into LightClientAttackEvidence, which is reported to each provider against the other,
+// like the light client's attack detector does with its primary and witnesses.
+func NewMultiStateProvider(logger log.Logger, providers ...StateProvider) (StateProvider, error) {
+	if len(providers) < 2 {
+		return nil, fmt.Errorf("at least 2 state providers are required, got %v", len(providers))
+	}
+	return &multiStateProvider{
+		logger:    logger,
+		providers: providers,
+	}, nil
+}
+
+// NewCrossCheckedStateProvider creates a new StateProvider which runs an independent light
+// client with each of the given RPC servers as its primary, and the other servers as its
+// witnesses, and cross-checks them with a multi state provider. If lightBlocks is not nil, an
+// additional light client fetches light blocks from peers with it, using the RPC servers as
+// witnesses and for the data which isn't part of light blocks.
+//
+// Servers for which the light client can't be set up, e.g. since they are unreachable, are
+// skipped, as long as at least two state providers remain.
+func NewCrossCheckedStateProvider(
+	ctx context.Context,
+	chainID string,
+	version tmstate.Version,
+	initialHeight int64,
+	servers []string,
+	trustOptions light.TrustOptions,
+	lightBlocks LightBlockFetcher,
+	logger log.Logger,
+) (StateProvider, error) {
+	if len(servers) < 2 {
+		return nil, fmt.Errorf("at least 2 RPC servers are required, got %v", len(servers))
+	}
+	rpcs, remotes, err := rpcProviders(chainID, servers)
+	if err != nil {
+		return nil, err
+	}
+
+	// The light clients are set up in parallel, so that unreachable servers don't hold up the others.
+	type primary struct {
+		provider  lightprovider.Provider
+		witnesses []lightprovider.Provider
+	}
+	primaries := make([]primary, 0, len(servers)+1)
+	for i := range rpcs {
+		witnesses := make([]lightprovider.Provider, 0, len(rpcs)-1)
+		witnesses = append(witnesses, rpcs[i+1:]...)
+		witnesses = append(witnesses, rpcs[:i]...)
+		primaries = append(primaries, primary{provider: rpcs[i], witnesses: witnesses})
+	}
+	if lightBlocks != nil {
+		primaries = append(primaries, primary{
+			provider:  &p2pProvider{chainID: chainID, fetcher: lightBlocks},
+			witnesses: rpcs,
+		})
+	}
+
+	type setupResult struct {
+		provider StateProvider
+		err      error
+	}
+	results := make([]chan setupResult, len(primaries))
+	for i, p := range primaries {
+		results[i] = make(chan setupResult, 1)
+		go func(p primary, ch chan<- setupResult) {
+			provider, err := newLightClientStateProvider(ctx, chainID, version, initialHeight, p.provider,
+				p.witnesses, remotes, trustOptions, logger.With("primary", p.provider))
+			if err != nil {
+				ch <- setupResult{err: err}
+				return
+			}
+			ch <- setupResult{provider: provider}
+		}(p, results[i])
+	}
+
+	providers := make([]StateProvider, 0, len(primaries))
+	var lastErr error
+	for i, ch := range results {
+		result := <-ch
+		if result.err != nil {
+			logger.Error("Failed to set up light client, skipping it", "primary", primaries[i].provider,
+				"err", result.err)
+			lastErr = fmt.Errorf("failed to set up light client with primary %v: %w", primaries[i].provider,
+				result.err)
+			continue
+		}
+		providers = append(providers, result.provider)
+	}
+	if len(providers) < 2 {
+		return nil, fmt.Errorf("only %v state providers could be set up: %w", len(providers), lastErr)
+	}
+	return NewMultiStateProvider(logger, providers...)
+}
+
+// AppHash implements StateProvider.
+func (p *multiStateProvider) AppHash(ctx context.Context, height uint64) ([]byte, error) {
+	results, err := p.query(ctx, func(sp StateProvider) (interface{}, error) {
+		return sp.AppHash(ctx, height)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = p.crossCheck(ctx, "app hash", results, []uint64{height, height + 1, height + 2},
+		func(v interface{}) []byte { return v.([]byte) })
+	if err != nil {
+		return nil, err
+	}
+	return results[0].value.([]byte), nil
+}
+
+// Commit implements StateProvider.
+func (p *multiStateProvider) Commit(ctx context.Context, height uint64) (*types.Commit, error) {
+	results, err := p.query(ctx, func(sp StateProvider) (interface{}, error) {
+		return sp.Commit(ctx, height)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = p.crossCheck(ctx, "commit", results, []uint64{height},
+		func(v interface{}) []byte { return v.(*types.Commit).BlockID.Hash })
+	if err != nil {
+		return nil, err
+	}
+	return results[0].value.(*types.Commit), nil
+}
+
+// State implements StateProvider.
+func (p *multiStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
+	results, err := p.query(ctx, func(sp StateProvider) (interface{}, error) {
+		return sp.State(ctx, height)
+	})
+	if err != nil {
+		return sm.State{}, err
+	}
+	err = p.crossCheck(ctx, "state", results, []uint64{height, height + 1, height + 2},
+		func(v interface{}) []byte { return stateHash(v.(sm.State)) })
+	if err != nil {
+		return sm.State{}, err
+	}
+	return results[0].value.(sm.State), nil
+}
+
+// SignedHeader implements StateProvider.
+func (p *multiStateProvider) SignedHeader(ctx context.Context, height uint64) (*types.SignedHeader, error) {
+	results, err := p.query(ctx, func(sp StateProvider) (interface{}, error) {
+		return sp.SignedHeader(ctx, height)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = p.crossCheck(ctx, "signed header", results, []uint64{height},
+		func(v interface{}) []byte { return v.(*types.SignedHeader).Hash() })
+	if err != nil {
+		return nil, err
+	}
+	return results[0].value.(*types.SignedHeader), nil
+}
+
+// DataAvailabilityHeader implements StateProvider.
+func (p *multiStateProvider) DataAvailabilityHeader(
+	ctx context.Context,
+	height uint64,
+) (*types.DataAvailabilityHeader, error) {
+	results, err := p.query(ctx, func(sp StateProvider) (interface{}, error) {
+		return sp.DataAvailabilityHeader(ctx, height)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = p.crossCheck(ctx, "data availability header", results, []uint64{height},
+		func(v interface{}) []byte { return v.(*types.DataAvailabilityHeader).Hash() })
+	if err != nil {
+		return nil, err
+	}
+	return results[0].value.(*types.DataAvailabilityHeader), nil
+}
+
+// query calls fn with each provider in parallel, returning the results of the providers which
+// responded, in provider order. It errors if fewer than two providers responded.
+func (p *multiStateProvider) query(
+	ctx context.Context,
+	fn func(StateProvider) (interface{}, error),
+) ([]providerResult, error) {
+	type response struct {
+		value interface{}
+		err   error
+	}
+	responses := make([]chan response, len(p.providers))
+	for i, sp := range p.providers {
+		responses[i] = make(chan response, 1)
+		go func(sp StateProvider, ch chan<- response) {
+			value, err := fn(sp)
+			ch <- response{value: value, err: err}
+		}(sp, responses[i])
+	}
+
+	results := make([]providerResult, 0, len(p.providers))
+	var lastErr error
+	for i, ch := range responses {
+		resp := <-ch
+		if resp.err != nil {
+			p.logger.Debug("State provider failed to respond", "provider", i, "err", resp.err)
+			lastErr = resp.err
+			continue
+		}
+		results = append(results, providerResult{provider: p.providers[i], value: resp.value})
+	}
+	if len(results) < 2 {
+		return nil, fmt.Errorf("only %v of %v state providers responded: %w", len(results), len(p.providers), lastErr)
+	}
+	return results, nil
+}
+
+// crossCheck checks that all results have the same key. If a result diverges from the first
+// one, evidence is reported for the first conflicting light block at the given heights, if any.
+func (p *multiStateProvider) crossCheck(
+	ctx context.Context,
+	what string,
+	results []providerResult,
+	heights []uint64,
+	key func(interface{}) []byte,
+) error {
+	expected := key(results[0].value)
+	for _, result := range results[1:] {
+		if actual := key(result.value); !bytes.Equal(expected, actual) {
+			p.logger.Error("State providers diverged", "data", what, "expected", expected, "actual", actual)
+			p.reportDivergence(ctx, results[0].provider, result.provider, heights)
+			return fmt.Errorf("%w on %v: %X != %X", errProvidersDiverged, what, expected, actual)
+		}
+	}
+	return nil
+}
+
+// reportDivergence looks for conflicting light blocks of two diverging providers at the given
+// heights, and reports evidence of a light client attack against each provider to the other.
+// Since neither provider can be held as the source of truth, evidence is formed both ways.
+func (p *multiStateProvider) reportDivergence(ctx context.Context, a, b StateProvider, heights []uint64) {
+	lbpA, okA := a.(lightBlockProvider)
+	lbpB, okB := b.(lightBlockProvider)
+	if !okA || !okB {
+		return
+	}
+
+	for _, height := range heights {
+		blockA, err := lbpA.LightBlock(ctx, height)
+		if err != nil {
+			p.logger.Info("Failed to fetch light block from diverging provider", "height", height, "err", err)
+			return
+		}
+		blockB, err := lbpB.LightBlock(ctx, height)
+		if err != nil {
+			p.logger.Info("Failed to fetch light block from diverging provider", "height", height, "err", err)
+			return
+		}
+		if bytes.Equal(blockA.Hash(), blockB.Hash()) {
+			continue
+		}
+
+		// The evidence is verified against a block both providers trust.
+		rootA, err := lbpA.TrustedRoot()
+		if err != nil {
+			p.logger.Info("Failed to fetch trusted root of diverging provider", "err", err)
+			return
+		}
+		rootB, err := lbpB.TrustedRoot()
+		if err != nil {
+			p.logger.Info("Failed to fetch trusted root of diverging provider", "err", err)
+			return
+		}
+		if !bytes.Equal(rootA.Hash(), rootB.Hash()) {
+			p.logger.Error("Diverging providers have different trusted roots, can't form evidence",
+				"rootA", rootA.Hash(), "rootB", rootB.Hash())
+			return
+		}
+
+		evA := light.NewLightClientAttackEvidence(blockA, blockB, rootB)
+		p.logger.Error("Attempted attack detected. Sending evidence against provider", "ev", evA)
+		if err := lbpB.ReportEvidence(ctx, evA); err != nil {
+			p.logger.Error("Failed to report evidence", "ev", evA, "err", err)
+		}
+		evB := light.NewLightClientAttackEvidence(blockB, blockA, rootA)
+		p.logger.Error("Attempted attack detected. Sending evidence against provider", "ev", evB)
+		if err := lbpA.ReportEvidence(ctx, evB); err != nil {
+			p.logger.Error("Failed to report evidence", "ev", evB, "err", err)
+		}
+		return
+	}
+	p.logger.Info("Diverging providers agree on light blocks", "heights", heights)
+}
+
+// stateHash hashes the parts of the state which are cross-checked.
+func stateHash(state sm.State) []byte {
+	hasher := sha256.New()
+	for _, bz := range [][]byte{
+		state.AppHash,
+		state.LastResultsHash,
+		state.LastBlockID.Hash,
+		state.LastValidators.Hash(),
+		state.Validators.Hash(),
+		state.NextValidators.Hash(),
+		types.HashConsensusParams(state.ConsensusParams),
+	} {
+		hasher.Write(bz)
+	}
+	return hasher.Sum(nil)
+}
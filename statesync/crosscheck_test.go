@@ -0,0 +1,235 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
+	"github.com/lazyledger/lazyledger-core/libs/log"
+	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
+	lightprovider "github.com/lazyledger/lazyledger-core/light/provider"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
+	tmversion "github.com/lazyledger/lazyledger-core/proto/tendermint/version"
+	"github.com/lazyledger/lazyledger-core/statesync/mocks"
+	"github.com/lazyledger/lazyledger-core/types"
+	"github.com/lazyledger/lazyledger-core/version"
+)
+
+// fakeLightBlockProvider is a lightBlockProvider serving the given light blocks and recording
+// the evidence reported to it.
+type fakeLightBlockProvider struct {
+	*mocks.StateProvider
+	blocks map[uint64]*types.LightBlock
+
+	mtx      tmsync.Mutex
+	evidence []types.Evidence
+}
+
+func newFakeLightBlockProvider(blocks ...*types.LightBlock) *fakeLightBlockProvider {
+	p := &fakeLightBlockProvider{
+		StateProvider: &mocks.StateProvider{},
+		blocks:        make(map[uint64]*types.LightBlock),
+	}
+	for _, block := range blocks {
+		p.blocks[uint64(block.Height)] = block
+	}
+	return p
+}
+
+func (p *fakeLightBlockProvider) LightBlock(ctx context.Context, height uint64) (*types.LightBlock, error) {
+	block, ok := p.blocks[height]
+	if !ok {
+		return nil, errors.New("light block not found")
+	}
+	return block, nil
+}
+
+func (p *fakeLightBlockProvider) TrustedRoot() (*types.LightBlock, error) {
+	return p.LightBlock(context.Background(), 1)
+}
+
+func (p *fakeLightBlockProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.evidence = append(p.evidence, ev)
+	return nil
+}
+
+func (p *fakeLightBlockProvider) reportedEvidence() []types.Evidence {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.evidence
+}
+
+// makeLightBlock makes a light block at the given height with the given app hash, signed by
+// all the given validators.
+func makeLightBlock(
+	t *testing.T,
+	height int64,
+	appHash []byte,
+	vals *types.ValidatorSet,
+	privVals []types.PrivValidator,
+) *types.LightBlock {
+	header := &types.Header{
+		Version:            tmversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            "test-chain",
+		Height:             height,
+		Time:               time.Date(2020, 1, 1, 0, 0, int(height), 0, time.UTC),
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		AppHash:            appHash,
+		ProposerAddress:    vals.Proposer.Address,
+	}
+	blockID := types.BlockID{
+		Hash:          header.Hash(),
+		PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))},
+	}
+	voteSet := types.NewVoteSet(header.ChainID, height, 0, tmproto.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals, header.Time)
+	require.NoError(t, err)
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+}
+
+func TestNewMultiStateProvider(t *testing.T) {
+	_, err := NewMultiStateProvider(log.TestingLogger(), &mocks.StateProvider{})
+	require.Error(t, err)
+
+	_, err = NewMultiStateProvider(log.TestingLogger(), &mocks.StateProvider{}, &mocks.StateProvider{})
+	require.NoError(t, err)
+}
+
+// fakeLightBlockFetcher returns the light block at the requested height, or at height 10 if
+// the latest one is requested, made with the given function.
+type fakeLightBlockFetcher struct {
+	makeBlock func(height int64) *types.LightBlock
+}
+
+func (f fakeLightBlockFetcher) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if f.makeBlock == nil {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if height == 0 {
+		height = 10
+	}
+	return f.makeBlock(height), nil
+}
+
+func TestP2PProvider(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(1, 10)
+	provider := &p2pProvider{chainID: "test-chain", fetcher: fakeLightBlockFetcher{
+		makeBlock: func(height int64) *types.LightBlock {
+			return makeLightBlock(t, height, []byte("app_hash"), vals, privVals)
+		},
+	}}
+
+	lb, err := provider.LightBlock(context.Background(), 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, lb.Height)
+	lb, err = provider.LightBlock(context.Background(), 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, lb.Height)
+
+	// light blocks for other heights or chains are rejected
+	provider.fetcher = fakeLightBlockFetcher{makeBlock: func(int64) *types.LightBlock {
+		return makeLightBlock(t, 3, []byte("app_hash"), vals, privVals)
+	}}
+	_, err = provider.LightBlock(context.Background(), 5)
+	assert.IsType(t, lightprovider.ErrBadLightBlock{}, err)
+	provider.chainID = "other-chain"
+	_, err = provider.LightBlock(context.Background(), 3)
+	assert.IsType(t, lightprovider.ErrBadLightBlock{}, err)
+
+	// peers not responding in time are reported as such
+	provider.fetcher = fakeLightBlockFetcher{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = provider.LightBlock(ctx, 5)
+	assert.Equal(t, lightprovider.ErrNoResponse, err)
+}
+
+func TestMultiStateProvider_Agree(t *testing.T) {
+	appHash := []byte("app_hash")
+	providers := make([]StateProvider, 0, 3)
+	for i := 0; i < 2; i++ {
+		sp := &mocks.StateProvider{}
+		sp.On("AppHash", mock.Anything, uint64(1)).Return(appHash, nil)
+		providers = append(providers, sp)
+	}
+	failing := &mocks.StateProvider{}
+	failing.On("AppHash", mock.Anything, uint64(1)).Return(nil, errors.New("boom"))
+	providers = append(providers, failing)
+
+	stateProvider, err := NewMultiStateProvider(log.TestingLogger(), providers...)
+	require.NoError(t, err)
+
+	// failing providers are ignored, as long as at least two respond
+	hash, err := stateProvider.AppHash(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, appHash, hash)
+
+	stateProvider, err = NewMultiStateProvider(log.TestingLogger(), providers[0], failing)
+	require.NoError(t, err)
+	_, err = stateProvider.AppHash(ctx, 1)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, errProvidersDiverged))
+}
+
+func TestMultiStateProvider_Diverge(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(3, 10)
+	root := makeLightBlock(t, 1, []byte("app_hash_1"), vals, privVals)
+	last := makeLightBlock(t, 2, []byte("app_hash_2"), vals, privVals)
+	honest := makeLightBlock(t, 3, []byte("app_hash_3"), vals, privVals)
+	forged := makeLightBlock(t, 3, []byte("forged"), vals, privVals)
+
+	providerA := newFakeLightBlockProvider(root, last, honest)
+	providerA.On("AppHash", mock.Anything, uint64(2)).Return([]byte(honest.AppHash), nil)
+	providerB := newFakeLightBlockProvider(root, last, forged)
+	providerB.On("AppHash", mock.Anything, uint64(2)).Return([]byte(forged.AppHash), nil)
+
+	stateProvider, err := NewMultiStateProvider(log.TestingLogger(), providerA, providerB)
+	require.NoError(t, err)
+	_, err = stateProvider.AppHash(ctx, 2)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errProvidersDiverged))
+
+	// evidence against each provider is reported to the other
+	evA := providerB.reportedEvidence()
+	require.Len(t, evA, 1)
+	lcaA, ok := evA[0].(*types.LightClientAttackEvidence)
+	require.True(t, ok)
+	assert.Equal(t, honest.Hash(), lcaA.ConflictingBlock.Hash())
+	// a conflicting app hash makes it a lunatic attack, forked off the common trusted root
+	assert.EqualValues(t, 1, lcaA.CommonHeight)
+	assert.Len(t, lcaA.ByzantineValidators, 3)
+
+	evB := providerA.reportedEvidence()
+	require.Len(t, evB, 1)
+	lcaB, ok := evB[0].(*types.LightClientAttackEvidence)
+	require.True(t, ok)
+	assert.Equal(t, forged.Hash(), lcaB.ConflictingBlock.Hash())
+}
+
+func TestMultiStateProvider_DivergeWithoutLightBlocks(t *testing.T) {
+	providerA := &mocks.StateProvider{}
+	providerA.On("Commit", mock.Anything, uint64(1)).Return(
+		&types.Commit{BlockID: types.BlockID{Hash: []byte("a")}}, nil)
+	providerB := &mocks.StateProvider{}
+	providerB.On("Commit", mock.Anything, uint64(1)).Return(
+		&types.Commit{BlockID: types.BlockID{Hash: []byte("b")}}, nil)
+
+	stateProvider, err := NewMultiStateProvider(log.TestingLogger(), providerA, providerB)
+	require.NoError(t, err)
+	_, err = stateProvider.Commit(ctx, 1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errProvidersDiverged))
+}
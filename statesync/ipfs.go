@@ -0,0 +1,106 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-merkledag"
+)
+
+// chunkLeafSize is the maximum size of the leaves a snapshot chunk is split into when it is
+// published to IPFS, keeping the blocks well below the bitswap message size limit.
+const chunkLeafSize = 256 << 10
+
+// putChunk adds a snapshot chunk to the DAG, returning the CID of its root node. The chunk is
+// split into raw leaves, linked in order by a single protobuf root node.
+func putChunk(ctx context.Context, dag format.DAGService, bz []byte) (cid.Cid, error) {
+	root := new(merkledag.ProtoNode)
+	nodes := make([]format.Node, 0, len(bz)/chunkLeafSize+2)
+	for len(bz) > 0 {
+		n := chunkLeafSize
+		if len(bz) < n {
+			n = len(bz)
+		}
+		leaf := merkledag.NewRawNode(bz[:n])
+		if err := root.AddNodeLink("", leaf); err != nil {
+			return cid.Undef, err
+		}
+		nodes = append(nodes, leaf)
+		bz = bz[n:]
+	}
+	nodes = append(nodes, root)
+
+	if err := dag.AddMany(ctx, nodes); err != nil {
+		return cid.Undef, err
+	}
+	return root.Cid(), nil
+}
+
+// getChunk fetches a snapshot chunk added by putChunk from the DAG.
+func getChunk(ctx context.Context, dag format.NodeGetter, id cid.Cid) ([]byte, error) {
+	nd, err := dag.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := nd.(*merkledag.ProtoNode)
+	if !ok {
+		return nil, fmt.Errorf("unexpected chunk root node type %T", nd)
+	}
+
+	links := root.Links()
+	cids := make([]cid.Cid, 0, len(links))
+	size := uint64(0)
+	for _, link := range links {
+		if link.Cid.Type() != cid.Raw {
+			return nil, fmt.Errorf("unexpected chunk leaf %v", link.Cid)
+		}
+		cids = append(cids, link.Cid)
+		size += link.Size
+	}
+	if size > uint64(chunkMsgSize) {
+		return nil, fmt.Errorf("chunk of %v bytes exceeds the maximum chunk size %v", size, chunkMsgSize)
+	}
+
+	// Identical leaves share a CID, so they are fetched once and looked up when assembling the chunk.
+	leaves := make(map[cid.Cid][]byte, len(cids))
+	for opt := range dag.GetMany(ctx, cids) {
+		if opt.Err != nil {
+			return nil, opt.Err
+		}
+		leaves[opt.Node.Cid()] = opt.Node.RawData()
+	}
+
+	bz := make([]byte, 0, size)
+	for _, link := range links {
+		leaf, ok := leaves[link.Cid]
+		if !ok {
+			return nil, fmt.Errorf("missing chunk leaf %v", link.Cid)
+		}
+		if len(bz)+len(leaf) > chunkMsgSize {
+			return nil, fmt.Errorf("chunk exceeds the maximum chunk size %v", chunkMsgSize)
+		}
+		bz = append(bz, leaf...)
+	}
+	return bz, nil
+}
+
+// parseChunkCIDs parses the chunk CIDs advertised for a snapshot with the given number of chunks.
+func parseChunkCIDs(bzs [][]byte, chunks uint32) ([]cid.Cid, error) {
+	if len(bzs) == 0 {
+		return nil, nil
+	}
+	if len(bzs) != int(chunks) {
+		return nil, fmt.Errorf("got %v chunk CIDs for %v chunks", len(bzs), chunks)
+	}
+	cids := make([]cid.Cid, 0, len(bzs))
+	for _, bz := range bzs {
+		id, err := cid.Cast(bz)
+		if err != nil {
+			return nil, err
+		}
+		cids = append(cids, id)
+	}
+	return cids, nil
+}
@@ -0,0 +1,91 @@
+package statesync
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-merkledag"
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
+)
+
+func TestPutGetChunk(t *testing.T) {
+	testcases := map[string][]byte{
+		"empty":              {},
+		"single byte":        {1},
+		"single leaf":        tmrand.Bytes(chunkLeafSize),
+		"multiple leaves":    tmrand.Bytes(3*chunkLeafSize + 5),
+		"identical leaves":   bytes.Repeat([]byte{7}, 3*chunkLeafSize),
+		"maximum chunk size": tmrand.Bytes(chunkMsgSize),
+	}
+	for name, chunk := range testcases {
+		chunk := chunk
+		t.Run(name, func(t *testing.T) {
+			dag := mdutils.Mock()
+			id, err := putChunk(ctx, dag, chunk)
+			require.NoError(t, err)
+
+			bz, err := getChunk(ctx, dag, id)
+			require.NoError(t, err)
+			assert.Equal(t, chunk, bz)
+		})
+	}
+}
+
+func TestGetChunk_Invalid(t *testing.T) {
+	dag := mdutils.Mock()
+
+	// the root must be a protobuf node
+	leaf := merkledag.NewRawNode([]byte{1, 2, 3})
+	require.NoError(t, dag.Add(ctx, leaf))
+	_, err := getChunk(ctx, dag, leaf.Cid())
+	require.Error(t, err)
+
+	// linking raw leaves only
+	root := new(merkledag.ProtoNode)
+	inner := merkledag.NodeWithData([]byte{1})
+	require.NoError(t, root.AddNodeLink("", inner))
+	require.NoError(t, dag.AddMany(ctx, []format.Node{inner, root}))
+	_, err = getChunk(ctx, dag, root.Cid())
+	require.Error(t, err)
+
+	// which must not exceed the chunk size
+	root = new(merkledag.ProtoNode)
+	for i := 0; i < chunkMsgSize/chunkLeafSize+1; i++ {
+		require.NoError(t, root.AddNodeLink("", merkledag.NewRawNode(bytes.Repeat([]byte{1}, chunkLeafSize))))
+	}
+	require.NoError(t, dag.Add(ctx, root))
+	_, err = getChunk(ctx, dag, root.Cid())
+	require.Error(t, err)
+
+	// and must all be available
+	root = new(merkledag.ProtoNode)
+	require.NoError(t, root.AddNodeLink("", leaf))
+	require.NoError(t, root.AddNodeLink("", merkledag.NewRawNode([]byte{4})))
+	require.NoError(t, dag.Add(ctx, root))
+	_, err = getChunk(ctx, dag, root.Cid())
+	require.Error(t, err)
+}
+
+func TestParseChunkCIDs(t *testing.T) {
+	id := merkledag.NewRawNode([]byte{1}).Cid()
+
+	cids, err := parseChunkCIDs(nil, 2)
+	require.NoError(t, err)
+	assert.Nil(t, cids)
+
+	cids, err = parseChunkCIDs([][]byte{id.Bytes(), id.Bytes()}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []cid.Cid{id, id}, cids)
+
+	_, err = parseChunkCIDs([][]byte{id.Bytes()}, 2)
+	require.Error(t, err)
+
+	_, err = parseChunkCIDs([][]byte{id.Bytes(), {1, 2, 3}}, 2)
+	require.Error(t, err)
+}
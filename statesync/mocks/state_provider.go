@@ -62,6 +62,52 @@ func (_m *StateProvider) Commit(ctx context.Context, height uint64) (*types.Comm
 	return r0, r1
 }
 
+// DataAvailabilityHeader provides a mock function with given fields: ctx, height
+func (_m *StateProvider) DataAvailabilityHeader(ctx context.Context, height uint64) (*types.DataAvailabilityHeader, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *types.DataAvailabilityHeader
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.DataAvailabilityHeader); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DataAvailabilityHeader)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignedHeader provides a mock function with given fields: ctx, height
+func (_m *StateProvider) SignedHeader(ctx context.Context, height uint64) (*types.SignedHeader, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *types.SignedHeader
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.SignedHeader); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SignedHeader)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // State provides a mock function with given fields: ctx, height
 func (_m *StateProvider) State(ctx context.Context, height uint64) (state.State, error) {
 	ret := _m.Called(ctx, height)
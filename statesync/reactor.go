@@ -7,6 +7,10 @@ import (
 	"sort"
 	"time"
 
+	"github.com/ipfs/go-cid"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/routing"
+
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	"github.com/lazyledger/lazyledger-core/libs/service"
@@ -85,6 +89,28 @@ type Reactor struct {
 	// received snapshots and chunks into the sync.
 	mtx    tmsync.RWMutex
 	syncer *syncer
+
+	// These are only set when snapshot chunks are published to and fetched from
+	// IPFS. The chunk CIDs of published snapshots are kept in published, where
+	// the snapshot currently being published has a nil entry.
+	dag       format.DAGService
+	croute    routing.ContentRouting
+	pubMtx    tmsync.Mutex
+	published map[snapshotKey][]cid.Cid
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// ReactorIPFS makes the reactor publish the snapshots it serves to IPFS and
+// advertise their chunk CIDs to peers. When syncing, chunks of snapshots with
+// advertised CIDs are fetched from IPFS, falling back to requesting them from
+// peers.
+func ReactorIPFS(dag format.DAGService, croute routing.ContentRouting) ReactorOption {
+	return func(r *Reactor) {
+		r.dag = dag
+		r.croute = croute
+	}
 }
 
 // NewReactor returns a reference to a new state sync reactor, which implements
@@ -98,6 +124,7 @@ func NewReactor(
 	snapshotCh, chunkCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdatesCh,
 	tempDir string,
+	options ...ReactorOption,
 ) *Reactor {
 	r := &Reactor{
 		conn:        conn,
@@ -107,6 +134,11 @@ func NewReactor(
 		peerUpdates: peerUpdates,
 		closeCh:     make(chan struct{}),
 		tempDir:     tempDir,
+		published:   make(map[snapshotKey][]cid.Cid),
+	}
+
+	for _, option := range options {
+		option(r)
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "StateSync", r)
@@ -161,6 +193,7 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 			r.Logger.Error("failed to fetch snapshots", "err", err)
 			return nil
 		}
+		chunkCIDs := r.publishedChunkCIDs(snapshots)
 
 		for _, snapshot := range snapshots {
 			r.Logger.Debug(
@@ -172,11 +205,12 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 			r.snapshotCh.Out() <- p2p.Envelope{
 				To: envelope.From,
 				Message: &ssproto.SnapshotsResponse{
-					Height:   snapshot.Height,
-					Format:   snapshot.Format,
-					Chunks:   snapshot.Chunks,
-					Hash:     snapshot.Hash,
-					Metadata: snapshot.Metadata,
+					Height:    snapshot.Height,
+					Format:    snapshot.Format,
+					Chunks:    snapshot.Chunks,
+					Hash:      snapshot.Hash,
+					Metadata:  snapshot.Metadata,
+					ChunkCids: chunkCIDs[snapshot.Key()],
 				},
 			}
 		}
@@ -196,12 +230,17 @@ func (r *Reactor) handleSnapshotMessage(envelope p2p.Envelope) error {
 			"format", msg.Format,
 			"peer", envelope.From.String(),
 		)
-		_, err := r.syncer.AddSnapshot(envelope.From, &snapshot{
-			Height:   msg.Height,
-			Format:   msg.Format,
-			Chunks:   msg.Chunks,
-			Hash:     msg.Hash,
-			Metadata: msg.Metadata,
+		chunkCIDs, err := parseChunkCIDs(msg.ChunkCids, msg.Chunks)
+		if err != nil {
+			r.Logger.Debug("ignoring invalid chunk CIDs", "height", msg.Height, "format", msg.Format, "err", err)
+		}
+		_, err = r.syncer.AddSnapshot(envelope.From, &snapshot{
+			Height:    msg.Height,
+			Format:    msg.Format,
+			Chunks:    msg.Chunks,
+			Hash:      msg.Hash,
+			Metadata:  msg.Metadata,
+			ChunkCIDs: chunkCIDs,
 		})
 		if err != nil {
 			r.Logger.Error(
@@ -475,6 +514,132 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	return snapshots, nil
 }
 
+// publishedChunkCIDs returns the encoded chunk CIDs of the given snapshots which
+// have been published to IPFS, keyed by snapshot. Unless a snapshot is already
+// being published, it starts publishing the most recent unpublished one in the
+// background. Published snapshots which are no longer served are forgotten.
+func (r *Reactor) publishedChunkCIDs(snapshots []*snapshot) map[snapshotKey][][]byte {
+	if r.dag == nil {
+		return nil
+	}
+
+	r.pubMtx.Lock()
+	defer r.pubMtx.Unlock()
+
+	var (
+		served      = make(map[snapshotKey]bool, len(snapshots))
+		chunkCIDs   = make(map[snapshotKey][][]byte, len(snapshots))
+		unpublished *snapshot
+		publishing  bool
+	)
+	for _, snapshot := range snapshots {
+		key := snapshot.Key()
+		served[key] = true
+
+		cids, ok := r.published[key]
+		switch {
+		case !ok && unpublished == nil:
+			unpublished = snapshot
+		case len(cids) > 0:
+			chunkCIDs[key] = make([][]byte, len(cids))
+			for i, id := range cids {
+				chunkCIDs[key][i] = id.Bytes()
+			}
+		}
+	}
+	for key, cids := range r.published {
+		switch {
+		case cids == nil:
+			publishing = true
+		case !served[key]:
+			delete(r.published, key)
+		}
+	}
+
+	if unpublished != nil && !publishing {
+		r.published[unpublished.Key()] = nil
+		go r.publishSnapshot(unpublished)
+	}
+	return chunkCIDs
+}
+
+// publishSnapshot loads the chunks of a snapshot from the app and publishes them
+// to IPFS, recording their CIDs once done.
+func (r *Reactor) publishSnapshot(snapshot *snapshot) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-r.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	start := time.Now()
+	cids := make([]cid.Cid, 0, snapshot.Chunks)
+	for index := uint32(0); index < snapshot.Chunks; index++ {
+		id, err := r.publishChunk(ctx, snapshot, index)
+		if err != nil {
+			r.Logger.Error(
+				"failed to publish snapshot to IPFS",
+				"height", snapshot.Height,
+				"format", snapshot.Format,
+				"chunk", index,
+				"err", err,
+			)
+
+			r.pubMtx.Lock()
+			delete(r.published, snapshot.Key())
+			r.pubMtx.Unlock()
+			return
+		}
+		cids = append(cids, id)
+	}
+
+	r.pubMtx.Lock()
+	r.published[snapshot.Key()] = cids
+	r.pubMtx.Unlock()
+
+	r.Logger.Info(
+		"published snapshot to IPFS",
+		"height", snapshot.Height,
+		"format", snapshot.Format,
+		"chunks", snapshot.Chunks,
+		"elapsed", time.Since(start),
+	)
+}
+
+// publishChunk loads a snapshot chunk from the app, adds it to the DAG and
+// provides it, returning its CID.
+func (r *Reactor) publishChunk(ctx context.Context, snapshot *snapshot, index uint32) (cid.Cid, error) {
+	resp, err := r.conn.LoadSnapshotChunkSync(ctx, abci.RequestLoadSnapshotChunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Chunk:  index,
+	})
+	if err != nil {
+		return cid.Undef, err
+	}
+	if resp.Chunk == nil {
+		return cid.Undef, errors.New("chunk not found")
+	}
+
+	id, err := putChunk(ctx, r.dag, resp.Chunk)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	// The chunk can still be fetched from peers connected over IPFS if providing
+	// fails, e.g. since the DHT has no peers yet, so we merely log the error.
+	if r.croute != nil {
+		if err := r.croute.Provide(ctx, id, true); err != nil {
+			r.Logger.Debug("failed to provide snapshot chunk", "cid", id, "err", err)
+		}
+	}
+	return id, nil
+}
+
 // Sync runs a state sync, returning the new state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
 func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
@@ -484,7 +649,8 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 		return sm.State{}, nil, errors.New("a state sync is already in progress")
 	}
 
-	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out(), r.chunkCh.Out(), r.tempDir)
+	r.syncer = newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.snapshotCh.Out(), r.chunkCh.Out(),
+		r.tempDir, r.dag)
 	r.mtx.Unlock()
 
 	// request snapshots from all currently connected peers
@@ -5,9 +5,12 @@ import (
 	"testing"
 	"time"
 
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
+	"github.com/lazyledger/lazyledger-core/ipfs"
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	"github.com/lazyledger/lazyledger-core/p2p"
 	ssproto "github.com/lazyledger/lazyledger-core/proto/tendermint/statesync"
@@ -42,6 +45,7 @@ func setup(
 	connQuery *proxymocks.AppConnQuery,
 	stateProvider *mocks.StateProvider,
 	chBuf uint,
+	options ...ReactorOption,
 ) *reactorTestSuite {
 	t.Helper()
 
@@ -62,7 +66,7 @@ func setup(
 		chunkInCh:         make(chan p2p.Envelope, chBuf),
 		chunkOutCh:        make(chan p2p.Envelope, chBuf),
 		chunkPeerErrCh:    make(chan p2p.PeerError, chBuf),
-		peerUpdates:       p2p.NewPeerUpdates(),
+		peerUpdates:       p2p.NewPeerUpdates(make(chan p2p.PeerUpdate)),
 		conn:              conn,
 		connQuery:         connQuery,
 		stateProvider:     stateProvider,
@@ -92,6 +96,7 @@ func setup(
 		rts.chunkChannel,
 		rts.peerUpdates,
 		"",
+		options...,
 	)
 
 	rts.syncer = newSyncer(
@@ -102,6 +107,7 @@ func setup(
 		rts.snapshotOutCh,
 		rts.chunkOutCh,
 		"",
+		nil,
 	)
 
 	require.NoError(t, rts.reactor.Start())
@@ -270,6 +276,50 @@ func TestReactor_SnapshotsRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_SnapshotsRequest_IPFS(t *testing.T) {
+	chunks := [][]byte{{1, 2, 3}, {4, 5, 6}}
+	s := &abci.Snapshot{Height: 1, Format: 1, Chunks: uint32(len(chunks)), Hash: []byte{1}}
+
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", context.Background(), abci.RequestListSnapshots{}).Return(
+		&abci.ResponseListSnapshots{Snapshots: []*abci.Snapshot{s}}, nil)
+	for i, chunk := range chunks {
+		conn.On("LoadSnapshotChunkSync", mock.Anything, abci.RequestLoadSnapshotChunk{
+			Height: s.Height, Format: s.Format, Chunk: uint32(i),
+		}).Return(&abci.ResponseLoadSnapshotChunk{Chunk: chunk}, nil)
+	}
+
+	dag := mdutils.Mock()
+	rts := setup(t, conn, nil, nil, 2, ReactorIPFS(dag, ipfs.MockRouting()))
+
+	requestSnapshot := func() *ssproto.SnapshotsResponse {
+		rts.snapshotInCh <- p2p.Envelope{
+			From:    p2p.PeerID{0xAA},
+			Message: &ssproto.SnapshotsRequest{},
+		}
+		e := <-rts.snapshotOutCh
+		return e.Message.(*ssproto.SnapshotsResponse)
+	}
+
+	// the snapshot is advertised without CIDs while it's being published
+	response := requestSnapshot()
+	require.Empty(t, response.ChunkCids)
+
+	var cids [][]byte
+	retryUntil(t, func() bool {
+		cids = requestSnapshot().ChunkCids
+		return len(cids) > 0
+	}, 5*time.Second)
+
+	parsed, err := parseChunkCIDs(cids, s.Chunks)
+	require.NoError(t, err)
+	for i, id := range parsed {
+		chunk, err := getChunk(ctx, dag, id)
+		require.NoError(t, err)
+		require.Equal(t, chunks[i], chunk)
+	}
+}
+
 // retryUntil will continue to evaluate fn and will return successfully when true
 // or fail when the timeout is reached.
 func retryUntil(t *testing.T, fn func() bool, timeout time.Duration) {
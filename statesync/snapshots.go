@@ -9,6 +9,8 @@ import (
 	"sort"
 	"time"
 
+	"github.com/ipfs/go-cid"
+
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
 	"github.com/lazyledger/lazyledger-core/p2p"
 )
@@ -24,6 +26,10 @@ type snapshot struct {
 	Hash     []byte
 	Metadata []byte
 
+	// ChunkCIDs are the CIDs of the chunks published to IPFS by the peer which sent the snapshot,
+	// if any. They are not part of the snapshot key.
+	ChunkCIDs []cid.Cid
+
 	trustedAppHash []byte // populated by light client
 }
 
@@ -48,6 +54,7 @@ type snapshotPool struct {
 	tmsync.Mutex
 	snapshots     map[snapshotKey]*snapshot
 	snapshotPeers map[snapshotKey]map[string]p2p.PeerID
+	chunkCIDs     map[snapshotKey]map[string][]cid.Cid // chunk CIDs advertised by each peer
 
 	// indexes for fast searches
 	formatIndex map[uint32]map[snapshotKey]bool
@@ -66,6 +73,7 @@ func newSnapshotPool(stateProvider StateProvider) *snapshotPool {
 		stateProvider:     stateProvider,
 		snapshots:         make(map[snapshotKey]*snapshot),
 		snapshotPeers:     make(map[snapshotKey]map[string]p2p.PeerID),
+		chunkCIDs:         make(map[snapshotKey]map[string][]cid.Cid),
 		formatIndex:       make(map[uint32]map[snapshotKey]bool),
 		heightIndex:       make(map[uint64]map[snapshotKey]bool),
 		peerIndex:         make(map[string]map[snapshotKey]bool),
@@ -114,6 +122,13 @@ func (p *snapshotPool) Add(peer p2p.PeerID, snapshot *snapshot) (bool, error) {
 	}
 	p.peerIndex[peer.String()][key] = true
 
+	if len(snapshot.ChunkCIDs) > 0 && len(snapshot.ChunkCIDs) == int(snapshot.Chunks) {
+		if p.chunkCIDs[key] == nil {
+			p.chunkCIDs[key] = make(map[string][]cid.Cid)
+		}
+		p.chunkCIDs[key][peer.String()] = snapshot.ChunkCIDs
+	}
+
 	if p.snapshots[key] != nil {
 		return false, nil
 	}
@@ -170,6 +185,29 @@ func (p *snapshotPool) GetPeers(snapshot *snapshot) []p2p.PeerID {
 	return peers
 }
 
+// GetChunkCID returns the CID of a snapshot chunk published to IPFS, along with the peer which
+// advertised it, if any peer did. The peer is picked randomly.
+func (p *snapshotPool) GetChunkCID(snapshot *snapshot, index uint32) (cid.Cid, p2p.PeerID, bool) {
+	key := snapshot.Key()
+
+	p.Lock()
+	defer p.Unlock()
+
+	peers := make([]string, 0, len(p.chunkCIDs[key]))
+	for peer := range p.chunkCIDs[key] {
+		peers = append(peers, peer)
+	}
+	if len(peers) == 0 {
+		return cid.Undef, nil, false
+	}
+	peer := peers[rand.Intn(len(peers))] // nolint:gosec // G404: Use of weak random number generator
+	cids := p.chunkCIDs[key][peer]
+	if int(index) >= len(cids) {
+		return cid.Undef, nil, false
+	}
+	return cids[index], p.snapshotPeers[key][peer], true
+}
+
 // Ranked returns a list of snapshots ranked by preference. The current heuristic is very naïve,
 // preferring the snapshot with the greatest height, then greatest format, then greatest number of
 // peers. This can be improved quite a lot.
@@ -250,6 +288,7 @@ func (p *snapshotPool) RemovePeer(peerID p2p.PeerID) {
 func (p *snapshotPool) removePeer(peerID p2p.PeerID) {
 	for key := range p.peerIndex[peerID.String()] {
 		delete(p.snapshotPeers[key], peerID.String())
+		delete(p.chunkCIDs[key], peerID.String())
 		if len(p.snapshotPeers[key]) == 0 {
 			p.removeSnapshot(key)
 		}
@@ -272,4 +311,5 @@ func (p *snapshotPool) removeSnapshot(key snapshotKey) {
 		delete(p.peerIndex[peerID], key)
 	}
 	delete(p.snapshotPeers, key)
+	delete(p.chunkCIDs, key)
 }
@@ -3,6 +3,8 @@ package statesync
 import (
 	"testing"
 
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-merkledag"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -325,3 +327,46 @@ func TestSnapshotPool_RemovePeer(t *testing.T) {
 	require.Equal(t, peerAID, peers1[0])
 	require.Equal(t, peerBID, peers1[1])
 }
+
+func TestSnapshotPool_GetChunkCID(t *testing.T) {
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+	pool := newSnapshotPool(stateProvider)
+
+	peerAID := p2p.PeerID{0xAA}
+	peerBID := p2p.PeerID{0xBB}
+	cids := []cid.Cid{
+		merkledag.NewRawNode([]byte{0}).Cid(),
+		merkledag.NewRawNode([]byte{1}).Cid(),
+	}
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}
+	_, err := pool.Add(peerAID, s)
+	require.NoError(t, err)
+
+	// no CIDs have been advertised yet
+	_, _, ok := pool.GetChunkCID(s, 0)
+	require.False(t, ok)
+
+	// CIDs which don't match the chunks are ignored
+	_, err = pool.Add(peerBID, &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}, ChunkCIDs: cids[:1]})
+	require.NoError(t, err)
+	_, _, ok = pool.GetChunkCID(s, 0)
+	require.False(t, ok)
+
+	_, err = pool.Add(peerBID, &snapshot{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}, ChunkCIDs: cids})
+	require.NoError(t, err)
+	id, peer, ok := pool.GetChunkCID(s, 1)
+	require.True(t, ok)
+	require.Equal(t, cids[1], id)
+	require.Equal(t, peerBID, peer)
+
+	_, _, ok = pool.GetChunkCID(s, 2)
+	require.False(t, ok)
+
+	// the CIDs are dropped along with the peer which advertised them
+	pool.RejectPeer(peerBID)
+	_, _, ok = pool.GetChunkCID(s, 1)
+	require.False(t, ok)
+	require.Len(t, pool.GetPeers(s), 1)
+}
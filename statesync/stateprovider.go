@@ -1,7 +1,9 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -31,6 +33,10 @@ type StateProvider interface {
 	Commit(ctx context.Context, height uint64) (*types.Commit, error)
 	// State returns a state object at the given height.
 	State(ctx context.Context, height uint64) (sm.State, error)
+	// SignedHeader returns the header and commit at the given height.
+	SignedHeader(ctx context.Context, height uint64) (*types.SignedHeader, error)
+	// DataAvailabilityHeader returns the data availability header at the given height.
+	DataAvailabilityHeader(ctx context.Context, height uint64) (*types.DataAvailabilityHeader, error)
 }
 
 // lightClientStateProvider is a state provider using the light client.
@@ -56,21 +62,33 @@ func NewLightClientStateProvider(
 		return nil, fmt.Errorf("at least 2 RPC servers are required, got %v", len(servers))
 	}
 
-	providers := make([]lightprovider.Provider, 0, len(servers))
-	providerRemotes := make(map[lightprovider.Provider]string)
-	for _, server := range servers {
-		client, err := rpcClient(server)
-		if err != nil {
-			return nil, fmt.Errorf("failed to set up RPC client: %w", err)
-		}
-		provider := lighthttp.NewWithClient(chainID, client)
-		providers = append(providers, provider)
-		// We store the RPC addresses keyed by provider, so we can find the address of the primary
-		// provider used by the light client and use it to fetch consensus parameters.
-		providerRemotes[provider] = server
+	providers, providerRemotes, err := rpcProviders(chainID, servers)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := newLightClientStateProvider(ctx, chainID, version, initialHeight, providers[0], providers[1:],
+		providerRemotes, trustOptions, logger)
+	if err != nil {
+		return nil, err
 	}
+	return sp, nil
+}
 
-	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
+// newLightClientStateProvider creates a new StateProvider using a light client with the given
+// primary and witnesses. The data which isn't part of light blocks is fetched via RPC, from one
+// of the servers in providerRemotes, keyed by the provider using the server.
+func newLightClientStateProvider(
+	ctx context.Context,
+	chainID string,
+	version tmstate.Version,
+	initialHeight int64,
+	primary lightprovider.Provider,
+	witnesses []lightprovider.Provider,
+	providerRemotes map[lightprovider.Provider]string,
+	trustOptions light.TrustOptions,
+	logger log.Logger,
+) (*lightClientStateProvider, error) {
+	lc, err := light.NewClient(ctx, chainID, trustOptions, primary, witnesses,
 		lightdb.New(memdb.NewDB(), ""), light.Logger(logger), light.MaxRetryAttempts(5))
 	if err != nil {
 		return nil, err
@@ -83,6 +101,27 @@ func NewLightClientStateProvider(
 	}, nil
 }
 
+// rpcProviders sets up a light client provider for each of the given RPC servers. It also returns
+// the RPC addresses keyed by provider, so we can find the address of the primary provider used by
+// the light client and use it to fetch consensus parameters.
+func rpcProviders(
+	chainID string,
+	servers []string,
+) ([]lightprovider.Provider, map[lightprovider.Provider]string, error) {
+	providers := make([]lightprovider.Provider, 0, len(servers))
+	providerRemotes := make(map[lightprovider.Provider]string)
+	for _, server := range servers {
+		client, err := rpcClient(server)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up RPC client: %w", err)
+		}
+		provider := lighthttp.NewWithClient(chainID, client)
+		providers = append(providers, provider)
+		providerRemotes[provider] = server
+	}
+	return providers, providerRemotes, nil
+}
+
 // AppHash implements StateProvider.
 func (s *lightClientStateProvider) AppHash(ctx context.Context, height uint64) ([]byte, error) {
 	s.Lock()
@@ -123,6 +162,47 @@ func (s *lightClientStateProvider) Commit(ctx context.Context, height uint64) (*
 	return header.Commit, nil
 }
 
+// SignedHeader implements StateProvider.
+func (s *lightClientStateProvider) SignedHeader(ctx context.Context, height uint64) (*types.SignedHeader, error) {
+	s.Lock()
+	defer s.Unlock()
+	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return header.SignedHeader, nil
+}
+
+// DataAvailabilityHeader implements StateProvider.
+func (s *lightClientStateProvider) DataAvailabilityHeader(
+	ctx context.Context,
+	height uint64,
+) (*types.DataAvailabilityHeader, error) {
+	s.Lock()
+	defer s.Unlock()
+	header, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// The DAHeader is fetched via RPC and verified against the data hash of
+	// the light client verified header.
+	rpc, err := s.rpc()
+	if err != nil {
+		return nil, err
+	}
+	result, err := rpc.DataAvailabilityHeader(ctx, &header.Height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch data availability header for height %v: %w", height, err)
+	}
+	dah := &result.DataAvailabilityHeader
+	if !bytes.Equal(dah.Hash(), header.DataHash) {
+		return nil, fmt.Errorf("data availability header hash %X does not match data hash %X of header %v",
+			dah.Hash(), header.DataHash, height)
+	}
+	return dah, nil
+}
+
 // State implements StateProvider.
 func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
 	s.Lock()
@@ -169,15 +249,11 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	state.LastHeightValidatorsChanged = nextLightBlock.Height
 
 	// We'll also need to fetch consensus params via RPC, using light client verification.
-	primaryURL, ok := s.providers[s.lc.Primary()]
-	if !ok || primaryURL == "" {
-		return sm.State{}, fmt.Errorf("could not find address for primary light client provider")
-	}
-	primaryRPC, err := rpcClient(primaryURL)
+	rpc, err := s.rpc()
 	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
+		return sm.State{}, err
 	}
-	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
+	rpcclient := lightrpc.NewClient(rpc, s.lc)
 	result, err := rpcclient.ConsensusParams(ctx, &nextLightBlock.Height)
 	if err != nil {
 		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
@@ -188,6 +264,52 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	return state, nil
 }
 
+// LightBlock implements lightBlockProvider.
+func (s *lightClientStateProvider) LightBlock(ctx context.Context, height uint64) (*types.LightBlock, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.lc.VerifyLightBlockAtHeight(ctx, int64(height), time.Now())
+}
+
+// TrustedRoot implements lightBlockProvider. The light client's trust is rooted in the first
+// light block in its store.
+func (s *lightClientStateProvider) TrustedRoot() (*types.LightBlock, error) {
+	s.Lock()
+	defer s.Unlock()
+	height, err := s.lc.FirstTrustedHeight()
+	if err != nil {
+		return nil, err
+	}
+	return s.lc.TrustedLightBlock(height)
+}
+
+// ReportEvidence implements lightBlockProvider, reporting the evidence to the primary provider.
+func (s *lightClientStateProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	return s.lc.Primary().ReportEvidence(ctx, ev)
+}
+
+// rpc sets up an RPC client for the primary light client provider. If the primary is not an RPC
+// server, e.g. since it fetches light blocks from peers, it uses the server of a witness instead.
+// This is safe as all data fetched via RPC is verified against the light client verified headers.
+func (s *lightClientStateProvider) rpc() (*rpchttp.HTTP, error) {
+	url, ok := s.providers[s.lc.Primary()]
+	if !ok {
+		for _, witness := range s.lc.Witnesses() {
+			if url, ok = s.providers[witness]; ok {
+				break
+			}
+		}
+	}
+	if !ok || url == "" {
+		return nil, fmt.Errorf("could not find address for a light client provider")
+	}
+	rpc, err := rpcClient(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create RPC client: %w", err)
+	}
+	return rpc, nil
+}
+
 // rpcClient sets up a new RPC client
 func rpcClient(server string) (*rpchttp.HTTP, error) {
 	if !strings.Contains(server, "://") {
@@ -199,3 +321,52 @@ func rpcClient(server string) (*rpchttp.HTTP, error) {
 	}
 	return c, nil
 }
+
+// LightBlockFetcher fetches unverified light blocks from peers, e.g. via the fast sync reactor.
+type LightBlockFetcher interface {
+	// LightBlock returns the light block at the given height, or the latest one if height is 0.
+	LightBlock(ctx context.Context, height int64) (*types.LightBlock, error)
+}
+
+// p2pProvider is a light client provider fetching light blocks from peers.
+type p2pProvider struct {
+	chainID string
+	fetcher LightBlockFetcher
+}
+
+var _ lightprovider.Provider = (*p2pProvider)(nil)
+
+func (p *p2pProvider) String() string {
+	return "p2p"
+}
+
+// LightBlock implements lightprovider.Provider.
+func (p *p2pProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	lb, err := p.fetcher.LightBlock(ctx, height)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, lightprovider.ErrNoResponse
+		}
+		return nil, err
+	}
+	if height != 0 && lb.Height != height {
+		return nil, lightprovider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %v does not match requested height %v", lb.Height, height),
+		}
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	}
+	return lb, nil
+}
+
+// DASLightBlock implements lightprovider.Provider. Peers don't send the data availability
+// header with light blocks.
+func (p *p2pProvider) DASLightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	return nil, lightprovider.ErrDAHeaderNotFound
+}
+
+// ReportEvidence implements lightprovider.Provider. Evidence can't be reported to peers.
+func (p *p2pProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	return errors.New("reporting evidence to peers is not supported")
+}
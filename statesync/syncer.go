@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"time"
 
+	format "github.com/ipfs/go-ipld-format"
+
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
 	"github.com/lazyledger/lazyledger-core/libs/log"
 	tmsync "github.com/lazyledger/lazyledger-core/libs/sync"
@@ -57,6 +59,7 @@ type syncer struct {
 	snapshotCh    chan<- p2p.Envelope
 	chunkCh       chan<- p2p.Envelope
 	tempDir       string
+	dag           format.NodeGetter // fetches chunks from IPFS, if set
 
 	mtx    tmsync.RWMutex
 	chunks *chunkQueue
@@ -70,6 +73,7 @@ func newSyncer(
 	stateProvider StateProvider,
 	snapshotCh, chunkCh chan<- p2p.Envelope,
 	tempDir string,
+	dag format.NodeGetter,
 ) *syncer {
 	return &syncer{
 		logger:        logger,
@@ -80,6 +84,7 @@ func newSyncer(
 		snapshotCh:    snapshotCh,
 		chunkCh:       chunkCh,
 		tempDir:       tempDir,
+		dag:           dag,
 	}
 }
 
@@ -406,6 +411,10 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
+		if s.fetchChunkFromIPFS(ctx, snapshot, chunks, index) {
+			continue
+		}
+
 		ticker := time.NewTicker(chunkRequestTimeout)
 		defer ticker.Stop()
 		s.requestChunk(snapshot, index)
@@ -420,6 +429,42 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
+// fetchChunkFromIPFS fetches a chunk from IPFS if a peer advertised its CID, and adds it to the
+// chunk queue with that peer as the sender. It returns false if the chunk must be requested from
+// peers instead.
+func (s *syncer) fetchChunkFromIPFS(ctx context.Context, snapshot *snapshot, chunks *chunkQueue,
+	index uint32) bool {
+	if s.dag == nil {
+		return false
+	}
+	id, sender, ok := s.snapshots.GetChunkCID(snapshot, index)
+	if !ok {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chunkRequestTimeout)
+	defer cancel()
+	bz, err := getChunk(ctx, s.dag, id)
+	if err != nil {
+		s.logger.Info("Failed to fetch snapshot chunk from IPFS, requesting it from peers",
+			"height", snapshot.Height, "format", snapshot.Format, "chunk", index, "cid", id, "err", err)
+		return false
+	}
+
+	_, err = chunks.Add(&chunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+		Chunk:  bz,
+		Sender: sender,
+	})
+	if err != nil {
+		s.logger.Error("Failed to add chunk fetched from IPFS", "chunk", index, "err", err)
+		return false
+	}
+	return true
+}
+
 // requestChunk requests a chunk from a peer.
 func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	peer := s.snapshots.GetPeer(snapshot)
@@ -6,6 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-merkledag"
+	mdutils "github.com/ipfs/go-merkledag/test"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -636,6 +639,39 @@ func TestSyncer_applyChunks_RejectSenders(t *testing.T) {
 	}
 }
 
+func TestSyncer_fetchChunkFromIPFS(t *testing.T) {
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
+	rts := setup(t, nil, nil, stateProvider, 2)
+
+	dag := mdutils.Mock()
+	rts.syncer.dag = dag
+	id, err := putChunk(ctx, dag, []byte{1, 2, 3})
+	require.NoError(t, err)
+	missing := merkledag.NewRawNode([]byte{4, 5, 6}).Cid()
+
+	peerID := p2p.PeerID{0xAA}
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1}, ChunkCIDs: []cid.Cid{id, missing, id}}
+	_, err = rts.syncer.AddSnapshot(peerID, s)
+	require.NoError(t, err)
+
+	chunks, err := newChunkQueue(s, "")
+	require.NoError(t, err)
+	defer chunks.Close()
+
+	cctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.True(t, rts.syncer.fetchChunkFromIPFS(cctx, s, chunks, 0))
+	require.True(t, chunks.Has(0))
+	require.Equal(t, peerID, chunks.GetSender(0))
+	require.False(t, rts.syncer.fetchChunkFromIPFS(cctx, s, chunks, 1))
+	require.False(t, chunks.Has(1))
+
+	// chunks aren't fetched from IPFS once the advertising peer is rejected
+	rts.syncer.snapshots.RejectPeer(peerID)
+	require.False(t, rts.syncer.fetchChunkFromIPFS(cctx, s, chunks, 2))
+}
+
 func TestSyncer_verifyApp(t *testing.T) {
 	boom := errors.New("boom")
 	s := &snapshot{Height: 3, Format: 1, Chunks: 5, Hash: []byte{1, 2, 3}, trustedAppHash: []byte("app_hash")}
@@ -1,6 +1,8 @@
 package store
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -27,6 +29,8 @@ well as the Commit.  In the future this may change, perhaps by moving
 the Commit data outside the Block. (TODO)
 
 The store can be assumed to contain all contiguous blocks between base and height (inclusive).
+Blocks restored after state sync may be stored without their data though, see
+SaveSignedHeader and FullBlockBase.
 
 // NOTE: BlockStore methods will panic if they encounter errors
 // deserializing loaded data, indicating probable corruption on disk.
@@ -39,9 +43,10 @@ type BlockStore struct {
 	// database contents. The only reason for keeping these fields in the struct is that the data
 	// can't efficiently be queried from the database since the key encoding we use is not
 	// lexicographically ordered (see https://github.com/tendermint/tendermint/issues/4567).
-	mtx    tmsync.RWMutex
-	base   int64
-	height int64
+	mtx           tmsync.RWMutex
+	base          int64
+	height        int64
+	fullBlockBase int64
 
 	ipfsDagAPI ipld.DAGService
 }
@@ -51,10 +56,11 @@ type BlockStore struct {
 func NewBlockStore(db dbm.DB, dagAPI ipld.DAGService) *BlockStore {
 	bs := LoadBlockStoreState(db)
 	return &BlockStore{
-		base:       bs.Base,
-		height:     bs.Height,
-		db:         db,
-		ipfsDagAPI: dagAPI,
+		base:          bs.Base,
+		height:        bs.Height,
+		fullBlockBase: bs.FullBlockBase,
+		db:            db,
+		ipfsDagAPI:    dagAPI,
 	}
 }
 
@@ -72,6 +78,18 @@ func (bs *BlockStore) Height() int64 {
 	return bs.height
 }
 
+// FullBlockBase returns the first height of the contiguous blocks up to Height which are stored
+// with their data, i.e. which LoadBlock returns. It returns 0 for empty block stores and if the
+// block at Height is stored without its data.
+func (bs *BlockStore) FullBlockBase() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	if bs.fullBlockBase > bs.height {
+		return 0
+	}
+	return bs.fullBlockBase
+}
+
 // Size returns the number of blocks in the block store.
 func (bs *BlockStore) Size() int64 {
 	bs.mtx.RLock()
@@ -100,7 +118,6 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 		return nil
 	}
 
-	pbb := new(tmproto.Block)
 	buf := []byte{}
 	for i := 0; i < int(blockMeta.BlockID.PartSetHeader.Total); i++ {
 		part := bs.LoadBlockPart(height, i)
@@ -111,18 +128,13 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 		}
 		buf = append(buf, part.Bytes...)
 	}
-	err := proto.Unmarshal(buf, pbb)
+	block, err := types.BlockFromPartSetBytes(buf)
 	if err != nil {
 		// NOTE: The existence of meta should imply the existence of the
 		// block. So, make sure meta is only saved after blocks are saved.
 		panic(fmt.Sprintf("Error reading block: %v", err))
 	}
 
-	block, err := types.BlockFromProto(pbb)
-	if err != nil {
-		panic(fmt.Errorf("error from proto block: %w", err))
-	}
-
 	return block
 }
 
@@ -147,6 +159,38 @@ func (bs *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	return bs.LoadBlock(height)
 }
 
+// LoadBlockEvidence returns the evidence of the block at the given height, only reading the parts
+// of the block which are needed, see types.EvidenceFromParts.
+// If no block is found for the given height, it returns nil.
+func (bs *BlockStore) LoadBlockEvidence(height int64) types.EvidenceList {
+	blockMeta := bs.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil
+	}
+	firstPart := bs.LoadBlockPart(height, 0)
+	if firstPart == nil {
+		return nil
+	}
+
+	// all parts but the last one are of the size of the first one
+	parts := map[int]*types.Part{0: firstPart}
+	evidence, err := types.EvidenceFromParts(func(i int) *types.Part {
+		if i >= int(blockMeta.BlockID.PartSetHeader.Total) {
+			return nil
+		}
+		part, ok := parts[i]
+		if !ok {
+			part = bs.LoadBlockPart(height, i)
+			parts[i] = part
+		}
+		return part
+	}, uint32(len(firstPart.Bytes)))
+	if err != nil {
+		panic(fmt.Sprintf("Error reading block evidence: %v", err))
+	}
+	return evidence
+}
+
 // LoadBlockPart returns the Part at the given index
 // from the block at the given height.
 // If no part is found for the given height and index, it returns nil.
@@ -273,6 +317,9 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 		// tries to access missing blocks.
 		bs.mtx.Lock()
 		bs.base = base
+		if bs.fullBlockBase < base {
+			bs.fullBlockBase = base
+		}
 		bs.mtx.Unlock()
 		bs.saveState()
 
@@ -393,6 +440,7 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.height = height
 	if bs.base == 0 {
 		bs.base = height
+		bs.fullBlockBase = height
 	}
 	bs.mtx.Unlock()
 
@@ -400,6 +448,71 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.saveState()
 }
 
+// SaveSignedHeader persists the header, commit and data availability header
+// of a block without its data, e.g. for the blocks restored after state sync.
+// LoadBlockMeta, LoadBlockCommit and LoadSeenCommit return the saved data for
+// the height, while LoadBlock and LoadBlockPart return nil. Heights must be
+// saved contiguously, like blocks.
+func (bs *BlockStore) SaveSignedHeader(sh *types.SignedHeader, dah *types.DataAvailabilityHeader) error {
+	if sh == nil || sh.Header == nil || sh.Commit == nil {
+		return errors.New("BlockStore can only save a complete signed header")
+	}
+	if dah == nil {
+		return errors.New("BlockStore can only save a signed header with a data availability header")
+	}
+
+	height := sh.Height
+	hash := sh.Hash()
+	if g, w := height, bs.Height()+1; bs.Base() > 0 && g != w {
+		return fmt.Errorf("BlockStore can only save contiguous blocks. Wanted %v, got %v", w, g)
+	}
+	if !bytes.Equal(hash, sh.Commit.BlockID.Hash) {
+		return fmt.Errorf("commit signs block %X, header is block %X", sh.Commit.BlockID.Hash, hash)
+	}
+	if !bytes.Equal(dah.Hash(), sh.DataHash) {
+		return fmt.Errorf("data availability header hash %X does not match data hash %X of the header",
+			dah.Hash(), sh.DataHash)
+	}
+
+	blockMeta := &types.BlockMeta{
+		BlockID:  sh.Commit.BlockID,
+		Header:   *sh.Header,
+		DAHeader: *dah,
+	}
+	pbm, err := blockMeta.ToProto()
+	if err != nil {
+		return fmt.Errorf("failed to marshal block meta: %w", err)
+	}
+	if err := bs.db.Set(calcBlockMetaKey(height), mustEncode(pbm)); err != nil {
+		return err
+	}
+	if err := bs.db.Set(calcBlockHashKey(hash), []byte(fmt.Sprintf("%d", height))); err != nil {
+		return err
+	}
+
+	// The commit for the height is both the block commit, until the next block
+	// is saved with its last commit, and the seen commit.
+	commitBytes := mustEncode(sh.Commit.ToProto())
+	if err := bs.db.Set(calcBlockCommitKey(height), commitBytes); err != nil {
+		return err
+	}
+	if err := bs.db.Set(calcSeenCommitKey(height), commitBytes); err != nil {
+		return err
+	}
+
+	bs.mtx.Lock()
+	bs.height = height
+	if bs.base == 0 {
+		bs.base = height
+	}
+	// The blocks stored with their data start above this height at the earliest.
+	bs.fullBlockBase = height + 1
+	bs.mtx.Unlock()
+
+	bs.saveState()
+	return nil
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 	pbp, err := part.ToProto()
 	if err != nil {
@@ -414,8 +527,9 @@ func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 func (bs *BlockStore) saveState() {
 	bs.mtx.RLock()
 	bss := tmstore.BlockStoreState{
-		Base:   bs.base,
-		Height: bs.height,
+		Base:          bs.base,
+		Height:        bs.height,
+		FullBlockBase: bs.fullBlockBase,
 	}
 	bs.mtx.RUnlock()
 	SaveBlockStoreState(&bss, bs.db)
@@ -492,6 +606,11 @@ func LoadBlockStoreState(db dbm.DB) tmstore.BlockStoreState {
 	if bsj.Height > 0 && bsj.Base == 0 {
 		bsj.Base = 1
 	}
+	// Backwards compatibility with persisted data from before FullBlockBase existed,
+	// when all blocks were stored with their data.
+	if bsj.Height > 0 && bsj.FullBlockBase == 0 {
+		bsj.FullBlockBase = bsj.Base
+	}
 	return bsj
 }
 
@@ -59,7 +59,7 @@ func makeTxs(height int64) (txs []types.Tx) {
 
 func makeBlock(height int64, state sm.State, lastCommit *types.Commit) *types.Block {
 	block, _ := state.MakeBlock(height, makeTxs(height), nil,
-		nil, types.Messages{}, lastCommit, state.Validators.GetProposer().Address)
+		types.IntermediateStateRoots{}, types.Messages{}, lastCommit, state.Validators.GetProposer().Address)
 	return block
 }
 
@@ -86,10 +86,13 @@ func TestLoadBlockStoreState(t *testing.T) {
 	}
 
 	testCases := []blockStoreTest{
-		{"success", &tmstore.BlockStoreState{Base: 100, Height: 1000},
-			tmstore.BlockStoreState{Base: 100, Height: 1000}},
+		{"success", &tmstore.BlockStoreState{Base: 100, Height: 1000, FullBlockBase: 200},
+			tmstore.BlockStoreState{Base: 100, Height: 1000, FullBlockBase: 200}},
 		{"empty", &tmstore.BlockStoreState{}, tmstore.BlockStoreState{}},
-		{"no base", &tmstore.BlockStoreState{Height: 1000}, tmstore.BlockStoreState{Base: 1, Height: 1000}},
+		{"no base", &tmstore.BlockStoreState{Height: 1000},
+			tmstore.BlockStoreState{Base: 1, Height: 1000, FullBlockBase: 1}},
+		{"no full block base", &tmstore.BlockStoreState{Base: 100, Height: 1000},
+			tmstore.BlockStoreState{Base: 100, Height: 1000, FullBlockBase: 100}},
 	}
 
 	for _, tc := range testCases {
@@ -431,6 +434,22 @@ func TestLoadBlockPart(t *testing.T) {
 		"expecting successful retrieval of previously saved block")
 }
 
+func TestLoadBlockEvidence(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+	require.Nil(t, bs.LoadBlockEvidence(1), "a non-existent block has no evidence")
+
+	ev := types.NewMockDuplicateVoteEvidenceWithValidator(1, tmtime.Now(), types.NewMockPV(), state.ChainID)
+	block, _ := state.MakeBlock(1, makeTxs(1), types.EvidenceList{ev}, types.IntermediateStateRoots{},
+		types.Messages{}, new(types.Commit), state.Validators.GetProposer().Address)
+	bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(1, tmtime.Now()))
+
+	evidence := bs.LoadBlockEvidence(1)
+	if assert.Len(t, evidence, 1) {
+		assert.Equal(t, ev.Hash(), evidence[0].Hash())
+	}
+}
+
 func TestPruneBlocks(t *testing.T) {
 	config := cfg.ResetTestRoot("blockchain_reactor_test")
 	defer os.RemoveAll(config.RootDir)
@@ -472,8 +491,9 @@ func TestPruneBlocks(t *testing.T) {
 	assert.EqualValues(t, 1500, bs.Height())
 	assert.EqualValues(t, 301, bs.Size())
 	assert.EqualValues(t, tmstore.BlockStoreState{
-		Base:   1200,
-		Height: 1500,
+		Base:          1200,
+		Height:        1500,
+		FullBlockBase: 1200,
 	}, LoadBlockStoreState(db))
 
 	require.NotNil(t, bs.LoadBlock(1200))
@@ -560,6 +580,56 @@ func TestLoadBlockMeta(t *testing.T) {
 	}
 }
 
+func TestBlockStoreSaveSignedHeader(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+
+	signedHeader := func(block *types.Block) *types.SignedHeader {
+		commit := makeTestCommit(block.Height, tmtime.Now())
+		commit.BlockID = types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(2).Header()}
+		return &types.SignedHeader{Header: &block.Header, Commit: commit}
+	}
+
+	block5 := makeBlock(5, state, new(types.Commit))
+	sh5 := signedHeader(block5)
+	require.NoError(t, bs.SaveSignedHeader(sh5, &block5.DataAvailabilityHeader))
+	assert.EqualValues(t, 5, bs.Base())
+	assert.EqualValues(t, 5, bs.Height())
+	assert.EqualValues(t, 0, bs.FullBlockBase())
+
+	// blocks must be contiguous
+	block7 := makeBlock(7, state, new(types.Commit))
+	require.Error(t, bs.SaveSignedHeader(signedHeader(block7), &block7.DataAvailabilityHeader))
+
+	// the data availability header must match the header
+	block6 := makeBlock(6, state, new(types.Commit))
+	sh6 := signedHeader(block6)
+	require.Error(t, bs.SaveSignedHeader(sh6, &block5.DataAvailabilityHeader))
+	// and so must the commit
+	sh6.Commit.BlockID = sh5.Commit.BlockID
+	require.Error(t, bs.SaveSignedHeader(sh6, &block6.DataAvailabilityHeader))
+	sh6 = signedHeader(block6)
+	require.NoError(t, bs.SaveSignedHeader(sh6, &block6.DataAvailabilityHeader))
+
+	// a full block can follow the signed headers
+	bs.SaveBlock(block7, block7.MakePartSet(2), makeTestCommit(7, tmtime.Now()))
+	assert.EqualValues(t, 5, bs.Base())
+	assert.EqualValues(t, 7, bs.Height())
+	assert.EqualValues(t, 7, bs.FullBlockBase())
+	assert.EqualValues(t, 7, LoadBlockStoreState(bs.db).FullBlockBase)
+
+	meta := bs.LoadBlockMeta(6)
+	require.NotNil(t, meta)
+	assert.Equal(t, sh6.Commit.BlockID, meta.BlockID)
+	assert.Equal(t, block6.Hash(), meta.Header.Hash())
+	assert.Equal(t, block6.DataAvailabilityHeader.Hash(), meta.DAHeader.Hash())
+	assert.Equal(t, sh5.Commit.Hash(), bs.LoadBlockCommit(5).Hash())
+	assert.Equal(t, sh6.Commit.Hash(), bs.LoadSeenCommit(6).Hash())
+	assert.Nil(t, bs.LoadBlock(6))
+	assert.Nil(t, bs.LoadBlockPart(6, 0))
+	assert.NotNil(t, bs.LoadBlock(7))
+}
+
 func TestBlockFetchAtHeight(t *testing.T) {
 	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
 	defer cleanup()
@@ -2,9 +2,11 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,6 +31,10 @@ import (
 )
 
 const (
+	// blockSharesOffset is the offset of the first data share in the
+	// serialization of a block, after the number of data shares.
+	blockSharesOffset = 4
+
 	// MaxHeaderBytes is a maximum header size.
 	// NOTE: Because app hash can be of arbitrary size, the header is therefore not
 	// capped in size and thus this number should be seen as a soft max
@@ -122,6 +128,30 @@ func (dah *DataAvailabilityHeader) Hash() []byte {
 	return dah.hash
 }
 
+// ValidateBasic checks that the DAHeader describes a valid extended data square:
+// the same number of row and column roots, which is a power of two not larger than
+// the width of the extended square of MaxSquareSize.
+func (dah *DataAvailabilityHeader) ValidateBasic() error {
+	if dah == nil {
+		return errors.New("nil DataAvailabilityHeader")
+	}
+	if len(dah.RowsRoots) != len(dah.ColumnRoots) {
+		return fmt.Errorf("number of row roots (%d) doesn't match number of column roots (%d)",
+			len(dah.RowsRoots), len(dah.ColumnRoots))
+	}
+	width := len(dah.RowsRoots)
+	if width == 0 {
+		return errors.New("no row and column roots")
+	}
+	if width&(width-1) != 0 {
+		return fmt.Errorf("square width %d is not a power of two", width)
+	}
+	if maxWidth := 2 * consts.MaxSquareSize; width > maxWidth {
+		return fmt.Errorf("square width %d exceeds the maximum of %d", width, maxWidth)
+	}
+	return nil
+}
+
 func (dah *DataAvailabilityHeader) ToProto() (*tmproto.DataAvailabilityHeader, error) {
 	if dah == nil {
 		return nil, errors.New("nil DataAvailabilityHeader")
@@ -194,6 +224,10 @@ func (b *Block) ValidateBasic() error {
 		return fmt.Errorf("wrong Header.DataHash. Expected %X, got %X", w, g)
 	}
 
+	if err := b.IntermediateStateRoots.ValidateBasic(len(b.Txs)); err != nil {
+		return fmt.Errorf("invalid intermediate state roots: %w", err)
+	}
+
 	// NOTE: b.Evidence.Evidence may be nil, but we're just looping.
 	for i, ev := range b.Evidence.Evidence {
 		if err := ev.ValidateBasic(); err != nil {
@@ -223,9 +257,47 @@ func (b *Block) fillHeader() {
 
 // TODO: Move out from 'types' package
 // fillDataAvailabilityHeader fills in any remaining DataAvailabilityHeader fields
-// that are a function of the block data.
+// that are a function of the block data. It panics if the data doesn't fit into
+// the largest data square, which must not happen for locally built blocks.
 func (b *Block) fillDataAvailabilityHeader() {
-	namespacedShares, dataSharesLen := b.Data.ComputeShares()
+	dah, dataSharesLen, err := computeDataAvailabilityHeader(b.Data)
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error: %v", err))
+	}
+	b.DataAvailabilityHeader, b.NumOriginalDataShares = dah, dataSharesLen
+	b.DataHash = b.DataAvailabilityHeader.Hash()
+}
+
+// ValidateData checks that the block data matches the data availability header and the number
+// of original data shares in the header, by erasure coding the data and recomputing the header.
+// This is expensive, so it is not part of ValidateBasic.
+func (b *Block) ValidateData() error {
+	if b == nil {
+		return errors.New("nil block")
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	dah, dataSharesLen, err := computeDataAvailabilityHeader(b.Data)
+	if err != nil {
+		return fmt.Errorf("invalid data: %w", err)
+	}
+	if w, g := dah.Hash(), b.DataAvailabilityHeader.Hash(); !bytes.Equal(w, g) {
+		return fmt.Errorf("wrong DataAvailabilityHeader. Expected %X, got %X", w, g)
+	}
+	if b.NumOriginalDataShares != dataSharesLen {
+		return fmt.Errorf("wrong Header.NumOriginalDataShares. Expected %v, got %v",
+			dataSharesLen, b.NumOriginalDataShares)
+	}
+	return nil
+}
+
+// computeDataAvailabilityHeader erasure codes the data and computes its data availability header,
+// also returning the number of original data shares. It errors if the data doesn't fit into the
+// largest data square.
+func computeDataAvailabilityHeader(data Data) (DataAvailabilityHeader, uint64, error) {
+	namespacedShares, dataSharesLen := data.ComputeShares()
 	shares := namespacedShares.RawShares()
 
 	// create the nmt wrapper to generate row and col commitments
@@ -236,14 +308,14 @@ func (b *Block) fillDataAvailabilityHeader() {
 	// we should switch to the rsmt2d.LeopardFF16 codec:
 	extendedDataSquare, err := rsmt2d.ComputeExtendedDataSquare(shares, rsmt2d.NewRSGF8Codec(), tree.Constructor)
 	if err != nil {
-		panic(fmt.Sprintf("unexpected error: %v", err))
+		return DataAvailabilityHeader{}, 0, err
 	}
 
 	// generate the row and col roots using the EDS and nmt wrapper
 	rowRoots := extendedDataSquare.RowRoots()
 	colRoots := extendedDataSquare.ColumnRoots()
 
-	b.DataAvailabilityHeader = DataAvailabilityHeader{
+	dah := DataAvailabilityHeader{
 		RowsRoots:   make([]namespace.IntervalDigest, extendedDataSquare.Width()),
 		ColumnRoots: make([]namespace.IntervalDigest, extendedDataSquare.Width()),
 	}
@@ -253,19 +325,17 @@ func (b *Block) fillDataAvailabilityHeader() {
 	for i := 0; i < len(rowRoots); i++ {
 		rowRoot, err := namespace.IntervalDigestFromBytes(consts.NamespaceSize, rowRoots[i])
 		if err != nil {
-			panic(err)
+			return DataAvailabilityHeader{}, 0, err
 		}
 		colRoot, err := namespace.IntervalDigestFromBytes(consts.NamespaceSize, colRoots[i])
 		if err != nil {
-			panic(err)
+			return DataAvailabilityHeader{}, 0, err
 		}
-		b.DataAvailabilityHeader.RowsRoots[i] = rowRoot
-		b.DataAvailabilityHeader.ColumnRoots[i] = colRoot
+		dah.RowsRoots[i] = rowRoot
+		dah.ColumnRoots[i] = colRoot
 	}
 
-	// return the root hash of DA Header
-	b.DataHash = b.DataAvailabilityHeader.Hash()
-	b.NumOriginalDataShares = uint64(dataSharesLen)
+	return dah, uint64(dataSharesLen), nil
 }
 
 // Hash computes and returns the block hash.
@@ -286,6 +356,10 @@ func (b *Block) Hash() tmbytes.HexBytes {
 
 // MakePartSet returns a PartSet containing parts of a serialized block.
 // This is the form in which the block is gossipped to peers.
+// The block data is serialized as the original data shares, without the tail
+// padding, so that the shares of a row can be proven against the PartSetHeader,
+// see InvalidDAHeaderEvidence. They are preceded by their number, as a big endian
+// uint32, and followed by the protobuf encoding of the rest of the block.
 // CONTRACT: partSize is greater than zero.
 func (b *Block) MakePartSet(partSize uint32) *PartSet {
 	if b == nil {
@@ -298,11 +372,128 @@ func (b *Block) MakePartSet(partSize uint32) *PartSet {
 	if err != nil {
 		panic(err)
 	}
+	pbb.Data = tmproto.Data{}
 	bz, err := proto.Marshal(pbb)
 	if err != nil {
 		panic(err)
 	}
-	return NewPartSetFromData(bz, partSize)
+
+	shares, dataSharesLen := b.Data.ComputeShares()
+	data := make([]byte, blockSharesOffset, blockSharesOffset+dataSharesLen*consts.ShareSize+len(bz))
+	binary.BigEndian.PutUint32(data, uint32(dataSharesLen))
+	for _, share := range shares[:dataSharesLen] {
+		data = append(data, share.Share...)
+	}
+	return NewPartSetFromData(append(data, bz...), partSize)
+}
+
+// BlockFromPartSetBytes decodes a block from the bytes of its parts, see MakePartSet.
+// It returns an error if the block is invalid.
+func BlockFromPartSetBytes(bz []byte) (*Block, error) {
+	if len(bz) < blockSharesOffset {
+		return nil, errors.New("missing number of data shares")
+	}
+	numShares := binary.BigEndian.Uint32(bz)
+	if numShares > consts.MaxSquareSize*consts.MaxSquareSize {
+		return nil, fmt.Errorf("too many data shares: %d", numShares)
+	}
+	end := blockSharesOffset + int(numShares)*consts.ShareSize
+	if end > len(bz) {
+		return nil, fmt.Errorf("%d data shares don't fit into %d bytes", numShares, len(bz))
+	}
+	shares := make([][]byte, numShares)
+	for i := range shares {
+		start := blockSharesOffset + i*consts.ShareSize
+		shares[i] = bz[start : start+consts.ShareSize]
+	}
+	data, err := dataFromShares(shares)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data shares: %w", err)
+	}
+
+	pbb := new(tmproto.Block)
+	if err := proto.Unmarshal(bz[end:], pbb); err != nil {
+		return nil, err
+	}
+	if pd := pbb.Data; len(pd.Txs) > 0 || len(pd.IntermediateStateRoots.RawRootsList) > 0 ||
+		pd.IntermediateStateRoots.TxsPerRoot != 0 || len(pd.Evidence.Evidence) > 0 ||
+		len(pd.Messages.MessagesList) > 0 {
+		return nil, errors.New("block data outside of the data shares")
+	}
+	pbb.Data = data.ToProto()
+	evidence, err := data.Evidence.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pbb.Data.Evidence = *evidence
+	return BlockFromProto(pbb)
+}
+
+// EvidenceFromParts decodes the evidence of a block from its parts, all but the last one of
+// partSize bytes, see MakePartSet. As the data shares are sorted by namespace, the evidence
+// shares are found by a binary search, so that only the parts holding them and a few others are
+// read. getPart returns the part of the given index, or nil if it is missing.
+func EvidenceFromParts(getPart func(int) *Part, partSize uint32) (EvidenceList, error) {
+	bz, err := readPartBytes(getPart, partSize, 0, blockSharesOffset)
+	if err != nil {
+		return nil, err
+	}
+	numShares := int(binary.BigEndian.Uint32(bz))
+	if numShares > consts.MaxSquareSize*consts.MaxSquareSize {
+		return nil, fmt.Errorf("too many data shares: %d", numShares)
+	}
+
+	var searchErr error
+	compareNamespace := func(i int) int {
+		nid, err := readPartBytes(getPart, partSize, blockSharesOffset+i*consts.ShareSize, consts.NamespaceSize)
+		if err != nil {
+			searchErr = err
+			return 0
+		}
+		return bytes.Compare(nid, consts.EvidenceNamespaceID)
+	}
+	start := sort.Search(numShares, func(i int) bool { return compareNamespace(i) >= 0 })
+	end := sort.Search(numShares, func(i int) bool { return compareNamespace(i) > 0 })
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if start == end {
+		return nil, nil
+	}
+
+	shares := make([][]byte, end-start)
+	for i := range shares {
+		shares[i], err = readPartBytes(getPart, partSize, blockSharesOffset+(start+i)*consts.ShareSize,
+			consts.ShareSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	evd, err := parseEvd(shares)
+	if err != nil {
+		return nil, fmt.Errorf("invalid evidence shares: %w", err)
+	}
+	return evd.Evidence, nil
+}
+
+// readPartBytes returns the n bytes at the given offset of the bytes of a block, split into parts
+// of partSize bytes. getPart returns the part of the given index, or nil if it is missing.
+func readPartBytes(getPart func(int) *Part, partSize uint32, offset, n int) ([]byte, error) {
+	bz := make([]byte, 0, n)
+	for len(bz) < n {
+		i, start := offset/int(partSize), offset%int(partSize)
+		part := getPart(i)
+		if part == nil {
+			return nil, fmt.Errorf("missing block part #%d", i)
+		}
+		if start >= len(part.Bytes) {
+			return nil, fmt.Errorf("block part #%d ends at byte %d", i, len(part.Bytes))
+		}
+		end := tmmath.MinInt(len(part.Bytes), start+n-len(bz))
+		bz = append(bz, part.Bytes[start:end]...)
+		offset += end - start
+	}
+	return bz, nil
 }
 
 // HashesTo is a convenience function that checks if a block hashes to the given argument.
@@ -479,7 +670,7 @@ func MaxDataBytesNoEvidence(maxBytes int64, valsCount int) int64 {
 // It populates the same set of fields validated by ValidateBasic.
 func MakeBlock(
 	height int64,
-	txs []Tx, evidence []Evidence, intermediateStateRoots []tmbytes.HexBytes, messages Messages,
+	txs []Tx, evidence []Evidence, intermediateStateRoots IntermediateStateRoots, messages Messages,
 	lastCommit *Commit) *Block {
 	block := &Block{
 		Header: Header{
@@ -488,7 +679,7 @@ func MakeBlock(
 		},
 		Data: Data{
 			Txs:                    txs,
-			IntermediateStateRoots: IntermediateStateRoots{RawRootsList: intermediateStateRoots},
+			IntermediateStateRoots: intermediateStateRoots,
 			Evidence:               EvidenceData{Evidence: evidence},
 			Messages:               messages,
 		},
@@ -957,14 +1148,8 @@ func NewCommit(height int64, round int32, blockID BlockID, commitSigs []CommitSi
 // Inverse of VoteSet.MakeCommit().
 func CommitToVoteSet(chainID string, commit *Commit, vals *ValidatorSet) *VoteSet {
 	voteSet := NewVoteSet(chainID, commit.Height, commit.Round, tmproto.PrecommitType, vals)
-	for idx, commitSig := range commit.Signatures {
-		if commitSig.Absent() {
-			continue // OK, some precommits can be missing.
-		}
-		added, err := voteSet.AddVote(commit.GetVote(int32(idx)))
-		if !added || err != nil {
-			panic(fmt.Sprintf("Failed to reconstruct LastCommit: %v", err))
-		}
+	if err := voteSet.addCommit(commit); err != nil {
+		panic(fmt.Sprintf("Failed to reconstruct LastCommit: %v", err))
 	}
 	return voteSet
 }
@@ -1217,19 +1402,134 @@ type Messages struct {
 
 type IntermediateStateRoots struct {
 	RawRootsList []tmbytes.HexBytes `json:"intermediate_roots"`
+	// TxsPerRoot is the number of txs applied to the state for every root, as
+	// returned by the app. The last root covers the remaining txs, which may be fewer.
+	TxsPerRoot uint32 `json:"txs_per_root"`
+}
+
+// TxRange returns the range [first, end) of the txs of a block with numTxs txs that are
+// applied to the previous state root to get the intermediate state root index. The range
+// is empty if the block has no txs for that root.
+func (roots IntermediateStateRoots) TxRange(index uint32, numTxs int) (first, end int) {
+	first64 := uint64(index) * uint64(roots.TxsPerRoot)
+	end64 := first64 + uint64(roots.TxsPerRoot)
+	if end64 > uint64(numTxs) {
+		end64 = uint64(numTxs)
+	}
+	if first64 > end64 {
+		first64 = end64
+	}
+	return int(first64), int(end64)
+}
+
+// ValidateBasic checks that there is one root for every TxsPerRoot txs of a block with
+// numTxs txs, or no root at all. TxsPerRoot can't exceed the number of txs, so that it is
+// the number of txs of the first root.
+func (roots IntermediateStateRoots) ValidateBasic(numTxs int) error {
+	if len(roots.RawRootsList) == 0 {
+		if roots.TxsPerRoot != 0 {
+			return fmt.Errorf("%d txs per root but no roots", roots.TxsPerRoot)
+		}
+		return nil
+	}
+	if roots.TxsPerRoot == 0 {
+		return errors.New("zero txs per root")
+	}
+	if int64(roots.TxsPerRoot) > int64(numTxs) {
+		return fmt.Errorf("more txs per root than txs: %d > %d", roots.TxsPerRoot, numTxs)
+	}
+	if want := (numTxs + int(roots.TxsPerRoot) - 1) / int(roots.TxsPerRoot); len(roots.RawRootsList) != want {
+		return fmt.Errorf("expected %d roots for %d txs, got %d", want, numTxs, len(roots.RawRootsList))
+	}
+	for i, root := range roots.RawRootsList {
+		if len(root) == 0 {
+			return fmt.Errorf("empty root #%d", i)
+		}
+	}
+	return nil
+}
+
+// splitIntoShares lays out the roots of a block with the given txs in shares.
+func (roots IntermediateStateRoots) splitIntoShares(txs Txs) NamespacedShares {
+	shares := splitContiguous(consts.IntermediateStateRootsNamespaceID, roots.marshalDelimited(txs))
+	return shares
 }
 
-func (roots IntermediateStateRoots) splitIntoShares() NamespacedShares {
+// marshalDelimited encodes the roots of a block with the given txs as they are laid out
+// in shares. Every root is prefixed with the range of txs applied to the previous root to
+// compute it, which commits to TxsPerRoot along with the roots, and with the position of
+// the first of these txs in the tx shares, so that they can be found without parsing the
+// preceding txs.
+func (roots IntermediateStateRoots) marshalDelimited(txs Txs) [][]byte {
 	rawDatas := make([][]byte, 0, len(roots.RawRootsList))
-	for _, root := range roots.RawRootsList {
-		rawData, err := root.MarshalDelimited()
+	txsLen, nextTx := 0, 0
+	for i, root := range roots.RawRootsList {
+		first, end := roots.TxRange(uint32(i), len(txs))
+		for ; nextTx < first; nextTx++ {
+			txsLen += DelimitedLen(len(txs[nextTx]))
+		}
+		txShare, txPos := contiguousPosition(txsLen)
+		isr := intermediateStateRoot{
+			firstTx: uint64(first),
+			numTxs:  uint64(end - first),
+			txShare: uint64(txShare),
+			txPos:   uint64(txPos),
+			root:    root,
+		}
+		rawData, err := tmbytes.HexBytes(isr.marshal()).MarshalDelimited()
 		if err != nil {
 			panic(fmt.Sprintf("app returned intermediate state root that can not be encoded %#v", root))
 		}
 		rawDatas = append(rawDatas, rawData)
 	}
-	shares := splitContiguous(consts.IntermediateStateRootsNamespaceID, rawDatas)
-	return shares
+	return rawDatas
+}
+
+// intermediateStateRoot is an intermediate state root as it is laid out in shares.
+type intermediateStateRoot struct {
+	firstTx uint64 // index of the first tx applied to the previous root
+	numTxs  uint64 // number of txs applied to the previous root
+	txShare uint64 // index of the share of the first tx among the tx shares
+	txPos   uint64 // position of the first tx in its share, see contiguousPosition
+	root    []byte
+}
+
+func (isr intermediateStateRoot) marshal() []byte {
+	bz := make([]byte, 0, 4*binary.MaxVarintLen64+len(isr.root))
+	bz = appendUvarint(bz, isr.firstTx)
+	bz = appendUvarint(bz, isr.numTxs)
+	bz = appendUvarint(bz, isr.txShare)
+	bz = appendUvarint(bz, isr.txPos)
+	return append(bz, isr.root...)
+}
+
+func unmarshalIntermediateStateRoot(bz []byte) (intermediateStateRoot, error) {
+	var (
+		isr    intermediateStateRoot
+		fields = []*uint64{&isr.firstTx, &isr.numTxs, &isr.txShare, &isr.txPos}
+	)
+	for _, field := range fields {
+		var n int
+		*field, n = binary.Uvarint(bz)
+		if n <= 0 {
+			return intermediateStateRoot{}, errors.New("invalid intermediate state root prefix")
+		}
+		bz = bz[n:]
+	}
+	isr.root = bz
+	return isr, nil
+}
+
+// contiguousPosition returns the index of the share, and the position within it, at which
+// data packed contiguously into shares starts when it is preceded by offset bytes.
+func contiguousPosition(offset int) (share, pos int) {
+	return offset / consts.TxShareSize, offset%consts.TxShareSize + consts.NamespaceSize + consts.ShareReservedBytes
+}
+
+func appendUvarint(bz []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(bz, buf[:n]...)
 }
 
 func (msgs Messages) splitIntoShares() NamespacedShares {
@@ -1252,7 +1552,7 @@ func (data *Data) ComputeShares() (NamespacedShares, int) {
 
 	// reserved shares:
 	txShares := data.Txs.splitIntoShares()
-	intermRootsShares := data.IntermediateStateRoots.splitIntoShares()
+	intermRootsShares := data.IntermediateStateRoots.splitIntoShares(data.Txs)
 	evidenceShares := data.Evidence.splitIntoShares()
 
 	// application data shares from messages:
@@ -1324,6 +1624,19 @@ var (
 	MessagesEmpty = Messages{}
 )
 
+// ToProto converts Message to protobuf
+func (m Message) ToProto() tmproto.Message {
+	return tmproto.Message{
+		NamespaceId: m.NamespaceID,
+		Data:        m.Data,
+	}
+}
+
+// IsEmpty returns true if the message has neither a namespace nor data.
+func (m Message) IsEmpty() bool {
+	return len(m.NamespaceID) == 0 && len(m.Data) == 0
+}
+
 func MessageFromProto(p *tmproto.Message) Message {
 	if p == nil {
 		return MessageEmpty
@@ -1386,7 +1699,15 @@ func (data *Data) ToProto() tmproto.Data {
 		}
 		tp.IntermediateStateRoots.RawRootsList = roots
 	}
-	// TODO(ismail): fill in messages too
+	tp.IntermediateStateRoots.TxsPerRoot = data.IntermediateStateRoots.TxsPerRoot
+	if len(data.Messages.MessagesList) > 0 {
+		msgs := make([]*tmproto.Message, len(data.Messages.MessagesList))
+		for i := range data.Messages.MessagesList {
+			msg := data.Messages.MessagesList[i].ToProto()
+			msgs[i] = &msg
+		}
+		tp.Messages.MessagesList = msgs
+	}
 
 	// TODO(ismail): handle evidence here instead of the block
 	// for the sake of consistency
@@ -1430,6 +1751,7 @@ func DataFromProto(dp *tmproto.Data) (Data, error) {
 	} else {
 		data.IntermediateStateRoots = IntermediateStateRoots{}
 	}
+	data.IntermediateStateRoots.TxsPerRoot = dp.IntermediateStateRoots.TxsPerRoot
 
 	return *data, nil
 }
@@ -1525,6 +1847,12 @@ func (data *EvidenceData) FromProto(eviData *tmproto.EvidenceList) error {
 	return nil
 }
 
+// SharesUsed returns the number of shares the evidence takes up in the
+// original data square.
+func (data *EvidenceData) SharesUsed() int {
+	return len(data.splitIntoShares())
+}
+
 func (data *EvidenceData) splitIntoShares() NamespacedShares {
 	rawDatas := make([][]byte, 0, len(data.Evidence))
 	for _, ev := range data.Evidence {
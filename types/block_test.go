@@ -5,6 +5,7 @@ import (
 	// number generator here and we can run the tests a bit faster
 	stdbytes "bytes"
 	"encoding/hex"
+	"io/ioutil"
 	"math"
 	mrand "math/rand"
 	"os"
@@ -47,7 +48,7 @@ func TestBlockAddEvidence(t *testing.T) {
 	ev := NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[0], "block-test-chain")
 	evList := []Evidence{ev}
 
-	block := MakeBlock(h, txs, evList, nil, Messages{}, commit)
+	block := MakeBlock(h, txs, evList, IntermediateStateRoots{}, Messages{}, commit)
 	require.NotNil(t, block)
 	require.Equal(t, 1, len(block.Evidence.Evidence))
 	require.NotNil(t, block.EvidenceHash)
@@ -108,7 +109,7 @@ func TestBlockValidateBasic(t *testing.T) {
 		tc := tc
 		i := i
 		t.Run(tc.testName, func(t *testing.T) {
-			block := MakeBlock(h, txs, evList, nil, Messages{}, commit)
+			block := MakeBlock(h, txs, evList, IntermediateStateRoots{}, Messages{}, commit)
 			block.ProposerAddress = valSet.GetProposer().Address
 			tc.malleateBlock(block)
 			err = block.ValidateBasic()
@@ -118,15 +119,83 @@ func TestBlockValidateBasic(t *testing.T) {
 	}
 }
 
+func TestIntermediateStateRootsValidateBasic(t *testing.T) {
+	root := bytes.HexBytes(tmrand.Bytes(32))
+
+	testCases := []struct {
+		testName string
+		isrs     IntermediateStateRoots
+		numTxs   int
+		expErr   bool
+	}{
+		{"no roots", IntermediateStateRoots{}, 3, false},
+		{"txs per root without roots", IntermediateStateRoots{TxsPerRoot: 1}, 3, true},
+		{"zero txs per root", IntermediateStateRoots{RawRootsList: []bytes.HexBytes{root}}, 3, true},
+		{"more txs per root than txs", IntermediateStateRoots{
+			RawRootsList: []bytes.HexBytes{root},
+			TxsPerRoot:   4,
+		}, 3, true},
+		{"too few roots", IntermediateStateRoots{
+			RawRootsList: []bytes.HexBytes{root},
+			TxsPerRoot:   2,
+		}, 3, true},
+		{"empty root", IntermediateStateRoots{
+			RawRootsList: []bytes.HexBytes{root, {}},
+			TxsPerRoot:   2,
+		}, 3, true},
+		{"valid", IntermediateStateRoots{
+			RawRootsList: []bytes.HexBytes{root, root},
+			TxsPerRoot:   2,
+		}, 3, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			err := tc.isrs.ValidateBasic(tc.numTxs)
+			assert.Equal(t, tc.expErr, err != nil, err)
+		})
+	}
+}
+
 func TestBlockHash(t *testing.T) {
 	assert.Nil(t, (*Block)(nil).Hash())
-	assert.Nil(t, MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil, Messages{}, nil).Hash())
+	assert.Nil(t, MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, IntermediateStateRoots{}, Messages{}, nil).Hash())
+}
+
+func TestBlockValidateData(t *testing.T) {
+	assert.Error(t, (*Block)(nil).ValidateData())
+
+	block := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, IntermediateStateRoots{}, Messages{}, nil)
+	require.NoError(t, block.ValidateData())
+
+	// data that doesn't match the data availability header is rejected
+	other := MakeBlock(int64(3), []Tx{Tx("Goodbye World")}, nil, IntermediateStateRoots{}, Messages{}, nil)
+	block.Data = other.Data
+	assert.Error(t, block.ValidateData())
+
+	block = MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, IntermediateStateRoots{}, Messages{}, nil)
+	block.NumOriginalDataShares++
+	assert.Error(t, block.ValidateData())
+
+	// so is data which doesn't fit into the largest data square
+	block = MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, IntermediateStateRoots{}, Messages{}, nil)
+	block.Data.Txs = oversizedTxs()
+	assert.Error(t, block.ValidateData())
+}
+
+// oversizedTxs returns txs which need more shares than the largest data square has.
+func oversizedTxs() Txs {
+	txs := make(Txs, consts.MaxSquareSize*consts.MaxSquareSize+1)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(consts.TxShareSize - 2)
+	}
+	return txs
 }
 
 func TestBlockMakePartSet(t *testing.T) {
 	assert.Nil(t, (*Block)(nil).MakePartSet(2))
 
-	partSet := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil, Messages{}, nil).MakePartSet(1024)
+	partSet := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, IntermediateStateRoots{}, Messages{}, nil).MakePartSet(1024)
 	assert.NotNil(t, partSet)
 	assert.EqualValues(t, 1, partSet.Total())
 }
@@ -144,11 +213,81 @@ func TestBlockMakePartSetWithEvidence(t *testing.T) {
 	ev := NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[0], "block-test-chain")
 	evList := []Evidence{ev}
 
-	partSet := MakeBlock(h, []Tx{Tx("Hello World")}, evList, nil, Messages{}, commit).MakePartSet(512)
+	partSet := MakeBlock(h, []Tx{Tx("Hello World")}, evList, IntermediateStateRoots{}, Messages{}, commit).MakePartSet(512)
 	assert.NotNil(t, partSet)
 	assert.EqualValues(t, 5, partSet.Total())
 }
 
+func TestBlockFromPartSetBytes(t *testing.T) {
+	lastID := makeBlockIDRandom()
+	h := int64(3)
+	voteSet, valSet, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(lastID, h-1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	ev := NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[0], "block-test-chain")
+
+	txs := []Tx{Tx("Hello World"), Tx(tmrand.Bytes(3 * consts.TxShareSize))}
+	isr := IntermediateStateRoots{RawRootsList: []bytes.HexBytes{tmrand.Bytes(32)}, TxsPerRoot: 2}
+	msgs := Messages{MessagesList: []Message{
+		{NamespaceID: []byte{1, 2, 3, 4, 5, 6, 7, 8}, Data: tmrand.Bytes(2 * consts.MsgShareSize)},
+	}}
+	block := MakeBlock(h, txs, []Evidence{ev}, isr, msgs, commit)
+	block.ValidatorsHash = valSet.Hash()
+	block.ProposerAddress = valSet.Proposer.Address
+	partSet := block.MakePartSet(512)
+	require.Greater(t, partSet.Total(), uint32(1))
+
+	bz, err := ioutil.ReadAll(partSet.GetReader())
+	require.NoError(t, err)
+	decoded, err := BlockFromPartSetBytes(bz)
+	require.NoError(t, err)
+	assert.Equal(t, block.Hash(), decoded.Hash())
+	assert.Equal(t, block.Data.Txs, decoded.Data.Txs)
+	assert.Equal(t, block.Data.IntermediateStateRoots, decoded.Data.IntermediateStateRoots)
+	assert.Equal(t, block.Data.Messages, decoded.Data.Messages)
+	assert.Equal(t, block.Data.Evidence.Hash(), decoded.Data.Evidence.Hash())
+
+	_, err = BlockFromPartSetBytes(bz[:blockSharesOffset])
+	assert.Error(t, err, "the shares are missing")
+}
+
+func TestEvidenceFromParts(t *testing.T) {
+	ev := NewMockDuplicateVoteEvidence(3, time.Now(), "block-test-chain")
+	txs := make([]Tx, 100)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(2 * consts.TxShareSize)
+	}
+	msgs := Messages{MessagesList: []Message{
+		{NamespaceID: []byte{1, 2, 3, 4, 5, 6, 7, 8}, Data: tmrand.Bytes(50 * consts.MsgShareSize)},
+	}}
+
+	testCases := []struct {
+		name     string
+		evidence []Evidence
+	}{
+		{"no evidence", nil},
+		{"evidence", []Evidence{ev}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			block := MakeBlock(3, txs, tc.evidence, IntermediateStateRoots{}, msgs, nil)
+			partSet := block.MakePartSet(512)
+			read := make(map[int]bool)
+			evidence, err := EvidenceFromParts(func(i int) *Part {
+				read[i] = true
+				return partSet.GetPart(i)
+			}, 512)
+			require.NoError(t, err)
+			assert.Equal(t, block.Evidence.Evidence.Hash(), evidence.Hash())
+			assert.Less(t, len(read), int(partSet.Total())/2, "only a few parts are read")
+
+			_, err = EvidenceFromParts(func(i int) *Part { return nil }, 512)
+			assert.Error(t, err, "the parts are missing")
+		})
+	}
+}
+
 func TestBlockHashesTo(t *testing.T) {
 	assert.False(t, (*Block)(nil).HashesTo(nil))
 
@@ -161,7 +300,7 @@ func TestBlockHashesTo(t *testing.T) {
 	ev := NewMockDuplicateVoteEvidenceWithValidator(h, time.Now(), vals[0], "block-test-chain")
 	evList := []Evidence{ev}
 
-	block := MakeBlock(h, []Tx{Tx("Hello World")}, evList, nil, Messages{}, commit)
+	block := MakeBlock(h, []Tx{Tx("Hello World")}, evList, IntermediateStateRoots{}, Messages{}, commit)
 	block.ValidatorsHash = valSet.Hash()
 	assert.False(t, block.HashesTo([]byte{}))
 	assert.False(t, block.HashesTo([]byte("something else")))
@@ -169,7 +308,7 @@ func TestBlockHashesTo(t *testing.T) {
 }
 
 func TestBlockSize(t *testing.T) {
-	size := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil, Messages{}, nil).Size()
+	size := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, IntermediateStateRoots{}, Messages{}, nil).Size()
 	if size <= 0 {
 		t.Fatal("Size of the block is zero or negative")
 	}
@@ -180,7 +319,7 @@ func TestBlockString(t *testing.T) {
 	assert.Equal(t, "nil-Block", (*Block)(nil).StringIndented(""))
 	assert.Equal(t, "nil-Block", (*Block)(nil).StringShort())
 
-	block := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, nil, Messages{}, nil)
+	block := MakeBlock(int64(3), []Tx{Tx("Hello World")}, nil, IntermediateStateRoots{}, Messages{}, nil)
 	assert.NotEqual(t, "nil-Block", block.String())
 	assert.NotEqual(t, "nil-Block", block.StringIndented(""))
 	assert.NotEqual(t, "nil-Block", block.StringShort())
@@ -561,6 +700,27 @@ func TestCommitToVoteSet(t *testing.T) {
 	}
 }
 
+func TestCommitToVoteSetVerifiesSignatures(t *testing.T) {
+	lastID := makeBlockIDRandom()
+	h := int64(3)
+
+	voteSet, valSet, vals := randVoteSet(h-1, 1, tmproto.PrecommitType, 10, 1)
+	commit, err := MakeCommit(lastID, h-1, 1, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	chainID := voteSet.ChainID()
+	assert.NotPanics(t, func() { CommitToVoteSet(chainID, commit, valSet) })
+
+	// the signatures are verified as a batch, which must not hide a bad one
+	v := commit.GetVote(4).ToProto()
+	require.NoError(t, vals[4].SignVote("CentaurusA", v))
+	commit.Signatures[4].Signature = v.Signature
+	assert.Panics(t, func() { CommitToVoteSet(chainID, commit, valSet) })
+
+	// the same goes for a signature which can't be added to the batch
+	commit.Signatures[4].Signature = v.Signature[1:]
+	assert.Panics(t, func() { CommitToVoteSet(chainID, commit, valSet) })
+}
+
 func TestCommitToVoteSetWithVotesForNilBlock(t *testing.T) {
 	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
 
@@ -660,10 +820,10 @@ func TestBlockIDValidateBasic(t *testing.T) {
 func TestBlockProtoBuf(t *testing.T) {
 	h := mrand.Int63()
 	c1 := randCommit(time.Now())
-	b1 := MakeBlock(h, []Tx{Tx([]byte{1})}, []Evidence{}, nil, Messages{}, &Commit{Signatures: []CommitSig{}})
+	b1 := MakeBlock(h, []Tx{Tx([]byte{1})}, []Evidence{}, IntermediateStateRoots{}, Messages{}, &Commit{Signatures: []CommitSig{}})
 	b1.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
 
-	b2 := MakeBlock(h, []Tx{Tx([]byte{1})}, []Evidence{}, nil, Messages{}, c1)
+	b2 := MakeBlock(h, []Tx{Tx([]byte{1})}, []Evidence{}, IntermediateStateRoots{}, Messages{}, c1)
 	b2.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
 	evidenceTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
 	evi := NewMockDuplicateVoteEvidence(h, evidenceTime, "block-test-chain")
@@ -672,7 +832,7 @@ func TestBlockProtoBuf(t *testing.T) {
 	_ = b2.Evidence.ByteSize()
 	b2.EvidenceHash = b2.Evidence.Hash()
 
-	b3 := MakeBlock(h, []Tx{}, []Evidence{}, nil, Messages{}, c1)
+	b3 := MakeBlock(h, []Tx{}, []Evidence{}, IntermediateStateRoots{}, Messages{}, c1)
 	b3.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
 	testCases := []struct {
 		msg      string
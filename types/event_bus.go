@@ -162,6 +162,10 @@ func (b *EventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return b.Publish(EventNewEvidence, evidence)
 }
 
+func (b *EventBus) PublishEventStateTransitionFraud(data EventDataStateTransitionFraud) error {
+	return b.Publish(EventStateTransitionFraud, data)
+}
+
 func (b *EventBus) PublishEventVote(data EventDataVote) error {
 	return b.Publish(EventVote, data)
 }
@@ -223,10 +227,42 @@ func (b *EventBus) PublishEventLock(data EventDataRoundState) error {
 	return b.Publish(EventLock, data)
 }
 
+func (b *EventBus) PublishEventWithheldBlock(data EventDataWithheldBlock) error {
+	return b.Publish(EventWithheldBlock, data)
+}
+
 func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+// PublishEventMempoolTxAdded publishes a tx entering the mempool. Note it will
+// add predefined keys (EventTypeKey, TxHashKey and, if the tx has a sender,
+// TxSenderKey).
+func (b *EventBus) PublishEventMempoolTxAdded(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventMempoolTxAdded, data)
+}
+
+// PublishEventMempoolTxRemoved publishes a tx leaving the mempool. Note it
+// will add the same predefined keys as PublishEventMempoolTxAdded.
+func (b *EventBus) PublishEventMempoolTxRemoved(data EventDataMempoolTx) error {
+	return b.publishEventMempoolTx(EventMempoolTxRemoved, data)
+}
+
+func (b *EventBus) publishEventMempoolTx(eventType string, data EventDataMempoolTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	events := map[string][]string{
+		EventTypeKey: {eventType},
+		TxHashKey:    {fmt.Sprintf("%X", data.Tx.Hash())},
+	}
+	if data.Sender != "" {
+		events[TxSenderKey] = []string{data.Sender}
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
 //-----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -259,6 +295,10 @@ func (NopEventBus) PublishEventNewEvidence(evidence EventDataNewEvidence) error
 	return nil
 }
 
+func (NopEventBus) PublishEventStateTransitionFraud(data EventDataStateTransitionFraud) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventVote(data EventDataVote) error {
 	return nil
 }
@@ -306,3 +346,15 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventWithheldBlock(data EventDataWithheldBlock) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventMempoolTxAdded(data EventDataMempoolTx) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventMempoolTxRemoved(data EventDataMempoolTx) error {
+	return nil
+}
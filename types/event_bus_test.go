@@ -64,6 +64,36 @@ func TestEventBusPublishEventTx(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventMempoolTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	data := EventDataMempoolTx{Tx: tx, Height: 1, Priority: 2, Sender: "alice", Nonce: 3, Reason: "committed"}
+
+	// PublishEventMempoolTxRemoved adds the hash and sender of the tx as keys
+	query := fmt.Sprintf("tm.event='MempoolTxRemoved' AND tx.hash='%X' AND tx.sender='alice'", tx.Hash())
+	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query), 1)
+	require.NoError(t, err)
+
+	// the added event does not match the query
+	require.NoError(t, eventBus.PublishEventMempoolTxAdded(EventDataMempoolTx{Tx: tx, Sender: "alice"}))
+	require.NoError(t, eventBus.PublishEventMempoolTxRemoved(data))
+
+	select {
+	case msg := <-sub.Out():
+		assert.Equal(t, data, msg.Data().(EventDataMempoolTx))
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a mempool tx after 1 sec.")
+	}
+}
+
 func TestEventBusPublishEventNewBlock(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -74,7 +104,7 @@ func TestEventBusPublishEventNewBlock(t *testing.T) {
 		}
 	})
 
-	block := MakeBlock(0, []Tx{}, []Evidence{}, nil, Messages{}, nil)
+	block := MakeBlock(0, []Tx{}, []Evidence{}, IntermediateStateRoots{}, Messages{}, nil)
 	resultBeginBlock := abci.ResponseBeginBlock{
 		Events: []abci.Event{
 			{Type: "testType", Attributes: []abci.EventAttribute{{Key: []byte("baz"), Value: []byte("1")}}},
@@ -233,7 +263,7 @@ func TestEventBusPublishEventNewBlockHeader(t *testing.T) {
 		}
 	})
 
-	block := MakeBlock(0, []Tx{}, []Evidence{}, nil, Messages{}, nil)
+	block := MakeBlock(0, []Tx{}, []Evidence{}, IntermediateStateRoots{}, Messages{}, nil)
 	resultBeginBlock := abci.ResponseBeginBlock{
 		Events: []abci.Event{
 			{Type: "testType", Attributes: []abci.EventAttribute{{Key: []byte("baz"), Value: []byte("1")}}},
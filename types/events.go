@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
+	tmbytes "github.com/lazyledger/lazyledger-core/libs/bytes"
 	tmjson "github.com/lazyledger/lazyledger-core/libs/json"
 	tmpubsub "github.com/lazyledger/lazyledger-core/libs/pubsub"
 	tmquery "github.com/lazyledger/lazyledger-core/libs/pubsub/query"
@@ -16,11 +17,18 @@ const (
 	// after a block has been committed.
 	// These are also used by the tx indexer for async indexing.
 	// All of this data can be fetched through the rpc.
-	EventNewBlock            = "NewBlock"
-	EventNewBlockHeader      = "NewBlockHeader"
-	EventNewEvidence         = "NewEvidence"
-	EventTx                  = "Tx"
-	EventValidatorSetUpdates = "ValidatorSetUpdates"
+	EventNewBlock             = "NewBlock"
+	EventNewBlockHeader       = "NewBlockHeader"
+	EventNewEvidence          = "NewEvidence"
+	EventStateTransitionFraud = "StateTransitionFraud"
+	EventTx                   = "Tx"
+	EventValidatorSetUpdates  = "ValidatorSetUpdates"
+
+	// Mempool events.
+	// These are triggered from the mempool package whenever a tx enters or
+	// leaves the mempool.
+	EventMempoolTxAdded   = "MempoolTxAdded"
+	EventMempoolTxRemoved = "MempoolTxRemoved"
 
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
@@ -36,6 +44,11 @@ const (
 	EventUnlock           = "Unlock"
 	EventValidBlock       = "ValidBlock"
 	EventVote             = "Vote"
+
+	// Data availability events.
+	// These are triggered from the consensus package whenever the data behind
+	// a proposal could not be put to or retrieved from the network.
+	EventWithheldBlock = "WithheldBlock"
 )
 
 // ENCODING / DECODING
@@ -49,6 +62,7 @@ func init() {
 	tmjson.RegisterType(EventDataNewBlock{}, "tendermint/event/NewBlock")
 	tmjson.RegisterType(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader")
 	tmjson.RegisterType(EventDataNewEvidence{}, "tendermint/event/NewEvidence")
+	tmjson.RegisterType(EventDataStateTransitionFraud{}, "tendermint/event/StateTransitionFraud")
 	tmjson.RegisterType(EventDataTx{}, "tendermint/event/Tx")
 	tmjson.RegisterType(EventDataRoundState{}, "tendermint/event/RoundState")
 	tmjson.RegisterType(EventDataNewRound{}, "tendermint/event/NewRound")
@@ -56,6 +70,8 @@ func init() {
 	tmjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	tmjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
+	tmjson.RegisterType(EventDataWithheldBlock{}, "tendermint/event/WithheldBlock")
+	tmjson.RegisterType(EventDataMempoolTx{}, "tendermint/event/MempoolTx")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -82,6 +98,15 @@ type EventDataNewEvidence struct {
 	Height int64 `json:"height"`
 }
 
+// EventDataStateTransitionFraud is fired when the app computes different
+// intermediate state roots for a block than the ones the block commits to.
+// The proof shows the invalid state transition to light clients.
+type EventDataStateTransitionFraud struct {
+	Proof *StateTransitionFraudProof `json:"proof"`
+
+	Height int64 `json:"height"`
+}
+
 // All txs fire EventDataTx
 type EventDataTx struct {
 	abci.TxResult
@@ -125,6 +150,29 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// EventDataWithheldBlock is fired when a data availability check of
+// a proposal fails. Check is one of "provide", "sample" or "reconstruct".
+type EventDataWithheldBlock struct {
+	Height int64            `json:"height"`
+	Round  int32            `json:"round"`
+	DAHash tmbytes.HexBytes `json:"da_hash"`
+	Check  string           `json:"check"`
+	Error  string           `json:"error"`
+}
+
+// EventDataMempoolTx is fired when a tx enters or leaves the mempool. Priority,
+// Sender and Nonce are as reported by the app in CheckTx. Reason is only set
+// when the tx leaves the mempool and is one of "committed", "invalid",
+// "expired", "evicted", "replaced" or "removed".
+type EventDataMempoolTx struct {
+	Tx       Tx     `json:"tx"`
+	Height   int64  `json:"height"`
+	Priority int64  `json:"priority"`
+	Sender   string `json:"sender"`
+	Nonce    uint64 `json:"nonce"`
+	Reason   string `json:"reason,omitempty"`
+}
+
 // PUBSUB
 
 const (
@@ -136,25 +184,33 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// TxSenderKey is a reserved key, used to specify the sender of a tx in the
+	// mempool.
+	// see EventBus#PublishEventMempoolTxAdded
+	TxSenderKey = "tx.sender"
 )
 
 var (
-	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
-	EventQueryLock                = QueryForEvent(EventLock)
-	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
-	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
-	EventQueryNewEvidence         = QueryForEvent(EventNewEvidence)
-	EventQueryNewRound            = QueryForEvent(EventNewRound)
-	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
-	EventQueryPolka               = QueryForEvent(EventPolka)
-	EventQueryRelock              = QueryForEvent(EventRelock)
-	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
-	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
-	EventQueryTx                  = QueryForEvent(EventTx)
-	EventQueryUnlock              = QueryForEvent(EventUnlock)
-	EventQueryValidatorSetUpdates = QueryForEvent(EventValidatorSetUpdates)
-	EventQueryValidBlock          = QueryForEvent(EventValidBlock)
-	EventQueryVote                = QueryForEvent(EventVote)
+	EventQueryCompleteProposal     = QueryForEvent(EventCompleteProposal)
+	EventQueryLock                 = QueryForEvent(EventLock)
+	EventQueryMempoolTxAdded       = QueryForEvent(EventMempoolTxAdded)
+	EventQueryMempoolTxRemoved     = QueryForEvent(EventMempoolTxRemoved)
+	EventQueryNewBlock             = QueryForEvent(EventNewBlock)
+	EventQueryNewBlockHeader       = QueryForEvent(EventNewBlockHeader)
+	EventQueryNewEvidence          = QueryForEvent(EventNewEvidence)
+	EventQueryNewRound             = QueryForEvent(EventNewRound)
+	EventQueryStateTransitionFraud = QueryForEvent(EventStateTransitionFraud)
+	EventQueryNewRoundStep         = QueryForEvent(EventNewRoundStep)
+	EventQueryPolka                = QueryForEvent(EventPolka)
+	EventQueryRelock               = QueryForEvent(EventRelock)
+	EventQueryTimeoutPropose       = QueryForEvent(EventTimeoutPropose)
+	EventQueryTimeoutWait          = QueryForEvent(EventTimeoutWait)
+	EventQueryTx                   = QueryForEvent(EventTx)
+	EventQueryUnlock               = QueryForEvent(EventUnlock)
+	EventQueryValidatorSetUpdates  = QueryForEvent(EventValidatorSetUpdates)
+	EventQueryValidBlock           = QueryForEvent(EventValidBlock)
+	EventQueryVote                 = QueryForEvent(EventVote)
+	EventQueryWithheldBlock        = QueryForEvent(EventWithheldBlock)
 )
 
 func EventQueryTxFor(tx Tx) tmpubsub.Query {
@@ -170,6 +226,7 @@ type BlockEventPublisher interface {
 	PublishEventNewBlock(block EventDataNewBlock) error
 	PublishEventNewBlockHeader(header EventDataNewBlockHeader) error
 	PublishEventNewEvidence(evidence EventDataNewEvidence) error
+	PublishEventStateTransitionFraud(EventDataStateTransitionFraud) error
 	PublishEventTx(EventDataTx) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
 }
@@ -177,3 +234,9 @@ type BlockEventPublisher interface {
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
+
+// MempoolEventPublisher publishes txs entering and leaving the mempool
+type MempoolEventPublisher interface {
+	PublishEventMempoolTxAdded(EventDataMempoolTx) error
+	PublishEventMempoolTxRemoved(EventDataMempoolTx) error
+}
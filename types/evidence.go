@@ -9,12 +9,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lazyledger/nmt"
+	"github.com/lazyledger/rsmt2d"
+
 	abci "github.com/lazyledger/lazyledger-core/abci/types"
+	"github.com/lazyledger/lazyledger-core/crypto"
 	"github.com/lazyledger/lazyledger-core/crypto/merkle"
 	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
 	tmjson "github.com/lazyledger/lazyledger-core/libs/json"
 	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
+	"github.com/lazyledger/lazyledger-core/p2p/ipld/wrapper"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 )
 
 // Evidence represents any provable malicious activity by a validator.
@@ -411,6 +417,403 @@ func LightClientAttackEvidenceFromProto(lpb *tmproto.LightClientAttackEvidence)
 
 //------------------------------------------------------------------------------------------
 
+// InvalidDAHeaderEvidence proves that a proposer signed a proposal whose DataAvailabilityHeader
+// does not match the data of the proposed block. It proves the shares of one row or column of
+// the extended data square, and holds the root recomputed from them, which differs from the one
+// in the header:
+//   - the data shares of an original row are proven against the part set header of the
+//     proposal by the parts holding them, see Block.MakePartSet, along with the first part,
+//     which holds the number of data shares
+//   - the shares of the first half of any other row or column are proven against the roots
+//     of the orthogonal original rows or columns in the header, which is only done once
+//     these roots are known to match the block data
+//
+// The evidence thus never takes more than a few block parts, or the shares of a row of the
+// largest square, and fits within the default MaxBytes of the evidence consensus params.
+type InvalidDAHeaderEvidence struct {
+	Proposal        *Proposal
+	ProposerAddress Address
+	Column          bool   // whether Index is the one of a column rather than of a row
+	Index           uint32 // index of the row or column in the extended data square
+	BlockParts      []*Part
+	ShareProofs     []ShareProof // indexed by the position of the share in the row or column
+	Root            []byte       // root recomputed from the proven shares
+
+	// abci specific information
+	ValidatorPower   int64
+	TotalVotingPower int64
+	Timestamp        time.Time // timestamp of the block at the height of the proposal
+}
+
+var _ Evidence = &InvalidDAHeaderEvidence{}
+
+// maxInvalidDAHeaderParts is the number of parts proving the data shares of an original row of
+// the largest square: the first part and the ones the shares of the row span.
+const maxInvalidDAHeaderParts = 1 + (consts.MaxSquareSize*consts.ShareSize-1)/int(BlockPartSizeBytes) + 2
+
+// NewInvalidDAHeaderEvidence creates InvalidDAHeaderEvidence against the proposer of the given
+// proposal, from the complete part set of the proposed block. The validator set must be the one
+// of the proposal's height and round. It returns an error if the data availability header of the
+// proposal matches the block data.
+func NewInvalidDAHeaderEvidence(
+	proposal *Proposal,
+	blockParts *PartSet,
+	blockTime time.Time,
+	valSet *ValidatorSet,
+) (*InvalidDAHeaderEvidence, error) {
+	if proposal == nil || blockParts == nil || valSet == nil {
+		return nil, errors.New("missing proposal, block parts or validator set")
+	}
+	if !blockParts.IsComplete() {
+		return nil, errors.New("incomplete block parts")
+	}
+	if err := proposal.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid proposal: %w", err)
+	}
+	proposer := valSet.GetProposer()
+	ev := &InvalidDAHeaderEvidence{
+		Proposal:         proposal,
+		ProposerAddress:  proposer.Address,
+		ValidatorPower:   proposer.VotingPower,
+		TotalVotingPower: valSet.TotalVotingPower(),
+		Timestamp:        blockTime,
+	}
+
+	dah := proposal.DAHeader
+	squareSize := len(dah.RowsRoots) / 2
+	if squareSize == 0 {
+		return nil, errors.New("empty data availability header")
+	}
+
+	// Check the original rows first, as the other ones are proven against them.
+	shares := make([][]byte, 0, squareSize*squareSize)
+	for row := 0; row < squareSize; row++ {
+		var used []int
+		getPart := func(i int) *Part {
+			used = append(used, i)
+			return blockParts.GetPart(i)
+		}
+		rowShares, err := rowSharesFromParts(getPart, squareSize, row)
+		if err != nil {
+			return nil, fmt.Errorf("can't read the data shares of row %d: %w", row, err)
+		}
+		root, err := axisRoot(rowShares, squareSize, uint(row))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(root, dah.RowsRoots[row].Bytes()) {
+			ev.Index, ev.Root = uint32(row), root
+			for _, i := range used {
+				if len(ev.BlockParts) == 0 || ev.BlockParts[len(ev.BlockParts)-1].Index != uint32(i) {
+					ev.BlockParts = append(ev.BlockParts, blockParts.GetPart(i))
+				}
+			}
+			return ev, ev.ValidateBasic()
+		}
+		shares = append(shares, rowShares...)
+	}
+
+	// Then the columns, proven against the original rows, and last the remaining rows, proven
+	// against the original columns.
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(squareSize))
+	eds, err := rsmt2d.ComputeExtendedDataSquare(shares, rsmt2d.NewRSGF8Codec(), tree.Constructor)
+	if err != nil {
+		return nil, err
+	}
+	for i, root := range eds.ColumnRoots() {
+		if !bytes.Equal(root, dah.ColumnRoots[i].Bytes()) {
+			ev.Column, ev.Index, ev.Root = true, uint32(i), root
+			for row := uint(0); row < uint(squareSize); row++ {
+				_, nodes, _, _ := rowTree(eds, row).Prove(i)
+				ev.ShareProofs = append(ev.ShareProofs, ShareProof{Index: uint32(row), Share: eds.Cell(row, uint(i)), Nodes: nodes})
+			}
+			return ev, ev.ValidateBasic()
+		}
+	}
+	for i, root := range eds.RowRoots()[squareSize:] {
+		row := squareSize + i
+		if !bytes.Equal(root, dah.RowsRoots[row].Bytes()) {
+			ev.Index, ev.Root = uint32(row), root
+			for col := uint(0); col < uint(squareSize); col++ {
+				_, nodes, _, _ := columnTree(eds, col).Prove(row)
+				ev.ShareProofs = append(ev.ShareProofs, ShareProof{Index: uint32(col), Share: eds.Cell(uint(row), col), Nodes: nodes})
+			}
+			return ev, ev.ValidateBasic()
+		}
+	}
+	return nil, errors.New("data availability header of the proposal matches the block data")
+}
+
+// ABCI returns the application relevant representation of the evidence
+func (ev *InvalidDAHeaderEvidence) ABCI() []abci.Evidence {
+	return []abci.Evidence{{
+		Type: abci.EvidenceType_INVALID_DA_HEADER,
+		Validator: abci.Validator{
+			Address: ev.ProposerAddress,
+			Power:   ev.ValidatorPower,
+		},
+		Height:           ev.Proposal.Height,
+		Time:             ev.Timestamp,
+		TotalVotingPower: ev.TotalVotingPower,
+	}}
+}
+
+// Bytes returns the proto-encoded evidence as a byte array
+func (ev *InvalidDAHeaderEvidence) Bytes() []byte {
+	pbe, err := ev.ToProto()
+	if err != nil {
+		panic(err)
+	}
+	bz, err := pbe.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Hash returns the hash of the evidence.
+func (ev *InvalidDAHeaderEvidence) Hash() []byte {
+	return tmhash.Sum(ev.Bytes())
+}
+
+// Height returns the height of the proposal.
+func (ev *InvalidDAHeaderEvidence) Height() int64 {
+	return ev.Proposal.Height
+}
+
+// String returns a string representation of the evidence.
+func (ev *InvalidDAHeaderEvidence) String() string {
+	axis := "Row"
+	if ev.Column {
+		axis = "Column"
+	}
+	return fmt.Sprintf("InvalidDAHeaderEvidence{Proposal: %v, Proposer: %v, %s: %d, Root: %X}",
+		ev.Proposal, ev.ProposerAddress, axis, ev.Index, ev.Root)
+}
+
+// Time returns the time of the block at the height of the proposal.
+func (ev *InvalidDAHeaderEvidence) Time() time.Time {
+	return ev.Timestamp
+}
+
+// ValidateBasic performs basic validation.
+func (ev *InvalidDAHeaderEvidence) ValidateBasic() error {
+	if ev.Proposal == nil {
+		return errors.New("nil proposal")
+	}
+	if err := ev.Proposal.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid proposal: %w", err)
+	}
+	if len(ev.ProposerAddress) != crypto.AddressSize {
+		return fmt.Errorf("expected proposer address size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(ev.ProposerAddress))
+	}
+	if width := len(ev.Proposal.DAHeader.RowsRoots); int64(ev.Index) >= int64(width) {
+		return fmt.Errorf("index %d out of the extended data square of width %d", ev.Index, width)
+	}
+	if len(ev.BlockParts) > maxInvalidDAHeaderParts {
+		return fmt.Errorf("too many block parts: %d > %d", len(ev.BlockParts), maxInvalidDAHeaderParts)
+	}
+	for i, part := range ev.BlockParts {
+		if part == nil {
+			return fmt.Errorf("nil block part #%d", i)
+		}
+		if err := part.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid block part #%d: %w", i, err)
+		}
+	}
+	if len(ev.ShareProofs) > consts.MaxSquareSize {
+		return fmt.Errorf("too many share proofs: %d > %d", len(ev.ShareProofs), consts.MaxSquareSize)
+	}
+	for i, proof := range ev.ShareProofs {
+		if err := proof.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid share proof #%d: %w", i, err)
+		}
+	}
+	if len(ev.Root) != 2*consts.NamespaceSize+tmhash.Size {
+		return fmt.Errorf("expected root size to be %d bytes, got %d bytes",
+			2*consts.NamespaceSize+tmhash.Size, len(ev.Root))
+	}
+	if ev.ValidatorPower <= 0 {
+		return errors.New("non-positive validator power")
+	}
+	if ev.TotalVotingPower < ev.ValidatorPower {
+		return errors.New("total voting power is less than the validator power")
+	}
+	return nil
+}
+
+// ProvenRoot verifies the proofs of the shares of the row or column against the proposal, and
+// returns the root of the row or column recomputed from them.
+func (ev *InvalidDAHeaderEvidence) ProvenRoot() ([]byte, error) {
+	dah := ev.Proposal.DAHeader
+	squareSize := len(dah.RowsRoots) / 2
+	if squareSize == 0 {
+		return nil, errors.New("empty data availability header")
+	}
+
+	var shares [][]byte
+	if !ev.Column && int(ev.Index) < squareSize {
+		if len(ev.ShareProofs) != 0 {
+			return nil, errors.New("original row proven by share proofs instead of block parts")
+		}
+		header := ev.Proposal.BlockID.PartSetHeader
+		parts := make(map[int]*Part, len(ev.BlockParts))
+		for _, part := range ev.BlockParts {
+			if part.Index >= header.Total || part.Proof.Index != int64(part.Index) ||
+				part.Proof.Total != int64(header.Total) || part.Proof.Verify(header.Hash, part.Bytes) != nil {
+				return nil, fmt.Errorf("invalid proof of block part #%d", part.Index)
+			}
+			// all parts but the last one are of the same size, so that shares can be located
+			if part.Index+1 < header.Total && len(part.Bytes) != int(BlockPartSizeBytes) {
+				return nil, fmt.Errorf("block part #%d has %d bytes, expected %d",
+					part.Index, len(part.Bytes), BlockPartSizeBytes)
+			}
+			parts[int(part.Index)] = part
+		}
+		var err error
+		shares, err = rowSharesFromParts(func(i int) *Part { return parts[i] }, squareSize, int(ev.Index))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if len(ev.BlockParts) != 0 {
+			return nil, errors.New("extended row or column proven by block parts instead of share proofs")
+		}
+		if len(ev.ShareProofs) != squareSize {
+			return nil, fmt.Errorf("expected %d share proofs, got %d", squareSize, len(ev.ShareProofs))
+		}
+		// the share at position i is proven against the i-th orthogonal root
+		roots := dah.ColumnRoots
+		if ev.Column {
+			roots = dah.RowsRoots
+		}
+		for i, proof := range ev.ShareProofs {
+			if proof.Index != uint32(i) {
+				return nil, fmt.Errorf("expected share proof #%d, got #%d", i, proof.Index)
+			}
+			nID := leafNamespace(proof.Share, int(ev.Index) >= squareSize)
+			inclusion := nmt.NewInclusionProof(int(ev.Index), int(ev.Index)+1, proof.Nodes, true)
+			if !inclusion.VerifyInclusion(consts.NewBaseHashFunc, nID, proof.Share, roots[i]) {
+				return nil, fmt.Errorf("invalid proof of share #%d", i)
+			}
+			shares = append(shares, proof.Share)
+		}
+	}
+	return axisRoot(shares, squareSize, uint(ev.Index))
+}
+
+// rowSharesFromParts returns the original data shares of the given row of a square of the given
+// size from the parts of a block, see Block.MakePartSet. The shares past the data shares of the
+// block are tail padding. getPart returns the part of the given index, or nil if it is missing.
+func rowSharesFromParts(getPart func(int) *Part, squareSize, row int) ([][]byte, error) {
+	bz, err := readPartBytes(getPart, BlockPartSizeBytes, 0, blockSharesOffset)
+	if err != nil {
+		return nil, err
+	}
+	numShares := int64(binary.BigEndian.Uint32(bz))
+	shares := make([][]byte, squareSize)
+	for i := range shares {
+		index := int64(row*squareSize + i)
+		if index >= numShares {
+			shares[i] = make([]byte, consts.ShareSize)
+			continue
+		}
+		offset := blockSharesOffset + int(index)*consts.ShareSize
+		if shares[i], err = readPartBytes(getPart, BlockPartSizeBytes, offset, consts.ShareSize); err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
+
+// axisRoot erasure codes the shares of the first half of the row or column of the given index of
+// an extended data square of the given original size, and returns the root of its tree.
+func axisRoot(shares [][]byte, squareSize int, index uint) ([]byte, error) {
+	parity, err := rsmt2d.NewRSGF8Codec().Encode(shares)
+	if err != nil {
+		return nil, err
+	}
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(squareSize))
+	for i, share := range append(shares, parity...) {
+		tree.Push(share, rsmt2d.SquareIndex{Axis: index, Cell: uint(i)})
+	}
+	return tree.Root(), nil
+}
+
+// ToProto encodes InvalidDAHeaderEvidence to protobuf
+func (ev *InvalidDAHeaderEvidence) ToProto() (*tmproto.InvalidDAHeaderEvidence, error) {
+	proposal, err := ev.Proposal.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]*tmproto.Part, len(ev.BlockParts))
+	for i, part := range ev.BlockParts {
+		pp, err := part.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = pp
+	}
+	proofs := make([]tmproto.ShareProof, len(ev.ShareProofs))
+	for i, proof := range ev.ShareProofs {
+		proofs[i] = proof.ToProto()
+	}
+
+	return &tmproto.InvalidDAHeaderEvidence{
+		Proposal:         proposal,
+		ProposerAddress:  ev.ProposerAddress,
+		Column:           ev.Column,
+		Index:            ev.Index,
+		BlockParts:       parts,
+		ShareProofs:      proofs,
+		Root:             ev.Root,
+		ValidatorPower:   ev.ValidatorPower,
+		TotalVotingPower: ev.TotalVotingPower,
+		Timestamp:        ev.Timestamp,
+	}, nil
+}
+
+// InvalidDAHeaderEvidenceFromProto decodes protobuf
+func InvalidDAHeaderEvidenceFromProto(pb *tmproto.InvalidDAHeaderEvidence) (*InvalidDAHeaderEvidence, error) {
+	if pb == nil {
+		return nil, errors.New("empty invalid data availability header evidence")
+	}
+
+	proposal, err := ProposalFromProto(pb.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	var parts []*Part
+	for _, pp := range pb.BlockParts {
+		part, err := PartFromProto(pp)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	var proofs []ShareProof
+	for _, pp := range pb.ShareProofs {
+		proofs = append(proofs, ShareProofFromProto(pp))
+	}
+
+	ev := &InvalidDAHeaderEvidence{
+		Proposal:         proposal,
+		ProposerAddress:  pb.ProposerAddress,
+		Column:           pb.Column,
+		Index:            pb.Index,
+		BlockParts:       parts,
+		ShareProofs:      proofs,
+		Root:             pb.Root,
+		ValidatorPower:   pb.ValidatorPower,
+		TotalVotingPower: pb.TotalVotingPower,
+		Timestamp:        pb.Timestamp,
+	}
+	return ev, ev.ValidateBasic()
+}
+
+//------------------------------------------------------------------------------------------
+
 // EvidenceList is a list of Evidence. Evidences is not a word.
 type EvidenceList []Evidence
 
@@ -473,6 +876,17 @@ func EvidenceToProto(evidence Evidence) (*tmproto.Evidence, error) {
 			},
 		}, nil
 
+	case *InvalidDAHeaderEvidence:
+		pbev, err := evi.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		return &tmproto.Evidence{
+			Sum: &tmproto.Evidence_InvalidDAHeaderEvidence{
+				InvalidDAHeaderEvidence: pbev,
+			},
+		}, nil
+
 	default:
 		return nil, fmt.Errorf("toproto: evidence is not recognized: %T", evi)
 	}
@@ -490,6 +904,8 @@ func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
 		return DuplicateVoteEvidenceFromProto(evi.DuplicateVoteEvidence)
 	case *tmproto.Evidence_LightClientAttackEvidence:
 		return LightClientAttackEvidenceFromProto(evi.LightClientAttackEvidence)
+	case *tmproto.Evidence_InvalidDAHeaderEvidence:
+		return InvalidDAHeaderEvidenceFromProto(evi.InvalidDAHeaderEvidence)
 	default:
 		return nil, errors.New("evidence is not recognized")
 	}
@@ -498,6 +914,7 @@ func EvidenceFromProto(evidence *tmproto.Evidence) (Evidence, error) {
 func init() {
 	tmjson.RegisterType(&DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence")
 	tmjson.RegisterType(&LightClientAttackEvidence{}, "tendermint/LightClientAttackEvidence")
+	tmjson.RegisterType(&InvalidDAHeaderEvidence{}, "tendermint/InvalidDAHeaderEvidence")
 }
 
 //-------------------------------------------- ERRORS --------------------------------------
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"math"
 	mrand "math/rand"
 	"testing"
@@ -9,11 +10,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/lazyledger/lazyledger-core/abci/types"
 	"github.com/lazyledger/lazyledger-core/crypto"
 	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
 	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
 	tmversion "github.com/lazyledger/lazyledger-core/proto/tendermint/version"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 	"github.com/lazyledger/lazyledger-core/version"
 )
 
@@ -214,6 +217,229 @@ func TestLightClientAttackEvidenceValidation(t *testing.T) {
 
 }
 
+// makeDAHeaderBlock returns a block whose data fills every row and column of a square of
+// size 8, the byte value of the txs telling blocks apart.
+func makeDAHeaderBlock(valSet *ValidatorSet, value byte) *Block {
+	txs := make(Txs, 16)
+	for i := range txs {
+		txs[i] = bytes.Repeat([]byte{value + byte(i)}, 3*consts.TxShareSize)
+	}
+	block := MakeBlock(3, txs, nil, IntermediateStateRoots{}, Messages{}, NewCommit(0, 0, BlockID{}, nil))
+	block.ValidatorsHash = valSet.Hash()
+	block.ProposerAddress = valSet.Proposer.Address
+	return block
+}
+
+// makeInvalidDAHeaderEvidence makes evidence against a proposer which signed a proposal of the
+// block with the data availability header returned by malleate.
+func makeInvalidDAHeaderEvidence(
+	t *testing.T,
+	chainID string,
+	block *Block,
+	valSet *ValidatorSet,
+	privVal PrivValidator,
+	dah *DataAvailabilityHeader,
+) (*InvalidDAHeaderEvidence, error) {
+	parts := block.MakePartSet(BlockPartSizeBytes)
+	blockID := BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+	proposal := NewProposal(block.Height, 0, -1, blockID, dah)
+	p, err := proposal.ToProto()
+	require.NoError(t, err)
+	require.NoError(t, privVal.SignProposal(chainID, p))
+	proposal.Signature = p.Signature
+
+	return NewInvalidDAHeaderEvidence(proposal, parts, defaultVoteTime, valSet)
+}
+
+// mixDAHeaders returns a copy of the data availability header, with the roots of the other one
+// at the given indices.
+func mixDAHeaders(dah, other *DataAvailabilityHeader, rows, cols []int) *DataAvailabilityHeader {
+	mixed := &DataAvailabilityHeader{
+		RowsRoots:   append(NmtRoots{}, dah.RowsRoots...),
+		ColumnRoots: append(NmtRoots{}, dah.ColumnRoots...),
+	}
+	for _, i := range rows {
+		mixed.RowsRoots[i] = other.RowsRoots[i]
+	}
+	for _, i := range cols {
+		mixed.ColumnRoots[i] = other.ColumnRoots[i]
+	}
+	return mixed
+}
+
+func TestInvalidDAHeaderEvidence(t *testing.T) {
+	valSet, privVals := RandValidatorSet(1, 10)
+	block, other := makeDAHeaderBlock(valSet, 0), makeDAHeaderBlock(valSet, 100)
+	dah, otherDAH := &block.DataAvailabilityHeader, &other.DataAvailabilityHeader
+	require.Len(t, dah.RowsRoots, 16)
+
+	testCases := []struct {
+		name       string
+		rows, cols []int
+		column     bool
+		index      uint32
+	}{
+		{"all roots", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, []int{0, 1}, false, 0},
+		{"original row", []int{5, 12}, []int{3}, false, 5},
+		{"original column", []int{12}, []int{3, 12}, true, 3},
+		{"parity column", []int{12}, []int{12}, true, 12},
+		{"parity row", []int{15}, nil, false, 15},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			signed := mixDAHeaders(dah, otherDAH, tc.rows, tc.cols)
+			ev, err := makeInvalidDAHeaderEvidence(t, "mychain", block, valSet, privVals[0], signed)
+			require.NoError(t, err)
+			assert.Equal(t, block.Height, ev.Height())
+			assert.Equal(t, defaultVoteTime, ev.Time())
+			abciEv := ev.ABCI()
+			require.Len(t, abciEv, 1)
+			assert.Equal(t, abci.EvidenceType_INVALID_DA_HEADER, abciEv[0].Type)
+			assert.EqualValues(t, block.ProposerAddress, abciEv[0].Validator.Address)
+			assert.EqualValues(t, 10, abciEv[0].Validator.Power)
+
+			assert.Equal(t, tc.column, ev.Column)
+			assert.Equal(t, tc.index, ev.Index)
+			trueRoot, signedRoot := dah.RowsRoots[tc.index], signed.RowsRoots[tc.index]
+			if tc.column {
+				trueRoot, signedRoot = dah.ColumnRoots[tc.index], signed.ColumnRoots[tc.index]
+			}
+			root, err := ev.ProvenRoot()
+			require.NoError(t, err)
+			assert.Equal(t, ev.Root, root)
+			assert.Equal(t, trueRoot.Bytes(), root)
+			assert.NotEqual(t, signedRoot.Bytes(), root)
+		})
+	}
+
+	_, err := makeInvalidDAHeaderEvidence(t, "mychain", block, valSet, privVals[0], dah)
+	assert.Error(t, err, "the data availability header matches the block")
+}
+
+func TestInvalidDAHeaderEvidenceOfLargeBlock(t *testing.T) {
+	valSet, privVals := RandValidatorSet(1, 10)
+	// a block of the largest square, larger than the evidence may be
+	txs := make(Txs, 8)
+	for i := range txs {
+		txs[i] = bytes.Repeat([]byte{byte(i)}, 600*consts.TxShareSize)
+	}
+	block := MakeBlock(3, txs, nil, IntermediateStateRoots{}, Messages{}, NewCommit(0, 0, BlockID{}, nil))
+	block.ValidatorsHash = valSet.Hash()
+	block.ProposerAddress = valSet.Proposer.Address
+	dah := &block.DataAvailabilityHeader
+	require.Len(t, dah.RowsRoots, 2*consts.MaxSquareSize)
+	maxBytes := DefaultEvidenceParams().MaxBytes
+	require.Greater(t, block.MakePartSet(BlockPartSizeBytes).ByteSize(), maxBytes)
+
+	// the last row of data spans two parts
+	signed := mixDAHeaders(dah, &DataAvailabilityHeader{RowsRoots: dah.ColumnRoots}, []int{31}, nil)
+	ev, err := makeInvalidDAHeaderEvidence(t, "mychain", block, valSet, privVals[0], signed)
+	require.NoError(t, err)
+	assert.EqualValues(t, 31, ev.Index)
+	assert.Len(t, ev.BlockParts, maxInvalidDAHeaderParts)
+	root, err := ev.ProvenRoot()
+	require.NoError(t, err)
+	assert.Equal(t, dah.RowsRoots[31].Bytes(), root)
+
+	// a row of tail padding only takes the first part
+	signed = mixDAHeaders(dah, &DataAvailabilityHeader{RowsRoots: dah.ColumnRoots}, []int{100}, nil)
+	ev, err = makeInvalidDAHeaderEvidence(t, "mychain", block, valSet, privVals[0], signed)
+	require.NoError(t, err)
+	assert.EqualValues(t, 100, ev.Index)
+	assert.Len(t, ev.BlockParts, 1)
+	root, err = ev.ProvenRoot()
+	require.NoError(t, err)
+	assert.Equal(t, dah.RowsRoots[100].Bytes(), root)
+
+	// evidence proven by shares fits too
+	signed = mixDAHeaders(dah, &DataAvailabilityHeader{ColumnRoots: dah.RowsRoots}, nil, []int{200})
+	ev, err = makeInvalidDAHeaderEvidence(t, "mychain", block, valSet, privVals[0], signed)
+	require.NoError(t, err)
+	assert.True(t, ev.Column)
+	assert.Len(t, ev.ShareProofs, consts.MaxSquareSize)
+	for _, ev := range []Evidence{ev} {
+		pbev, err := EvidenceToProto(ev)
+		require.NoError(t, err)
+		evList := tmproto.EvidenceList{Evidence: []tmproto.Evidence{*pbev}}
+		assert.LessOrEqual(t, int64(evList.Size()), maxBytes)
+	}
+}
+
+func TestInvalidDAHeaderEvidenceTampering(t *testing.T) {
+	valSet, privVals := RandValidatorSet(1, 10)
+	block, other := makeDAHeaderBlock(valSet, 0), makeDAHeaderBlock(valSet, 100)
+	dah, otherDAH := &block.DataAvailabilityHeader, &other.DataAvailabilityHeader
+	rowDAH := mixDAHeaders(dah, otherDAH, []int{6}, nil)
+	columnDAH := mixDAHeaders(dah, otherDAH, nil, []int{9})
+
+	testCases := []struct {
+		name     string
+		dah      *DataAvailabilityHeader
+		malleate func(*InvalidDAHeaderEvidence)
+	}{
+		{"tampered block part", rowDAH, func(ev *InvalidDAHeaderEvidence) { ev.BlockParts[0].Bytes[10] ^= 0xFF }},
+		{"missing block part", rowDAH, func(ev *InvalidDAHeaderEvidence) { ev.BlockParts = nil }},
+		{"other row", rowDAH, func(ev *InvalidDAHeaderEvidence) { ev.Index = 5 }},
+		{"row as column", rowDAH, func(ev *InvalidDAHeaderEvidence) { ev.Column = true }},
+		{"row proven by shares", columnDAH, func(ev *InvalidDAHeaderEvidence) { ev.Column = false; ev.Index = 6 }},
+		{"tampered share", columnDAH, func(ev *InvalidDAHeaderEvidence) { ev.ShareProofs[3].Share[20] ^= 0xFF }},
+		{"missing share", columnDAH, func(ev *InvalidDAHeaderEvidence) { ev.ShareProofs = ev.ShareProofs[1:] }},
+		{"swapped shares", columnDAH, func(ev *InvalidDAHeaderEvidence) {
+			ev.ShareProofs[0], ev.ShareProofs[1] = ev.ShareProofs[1], ev.ShareProofs[0]
+		}},
+		{"other column", columnDAH, func(ev *InvalidDAHeaderEvidence) { ev.Index = 10 }},
+		{"column as row", columnDAH, func(ev *InvalidDAHeaderEvidence) { ev.Column = false }},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ev, err := makeInvalidDAHeaderEvidence(t, "mychain", block, valSet, privVals[0], tc.dah)
+			require.NoError(t, err)
+			tc.malleate(ev)
+			root, err := ev.ProvenRoot()
+			assert.True(t, err != nil || !bytes.Equal(root, ev.Root))
+		})
+	}
+}
+
+func TestInvalidDAHeaderEvidenceValidation(t *testing.T) {
+	valSet, privVals := RandValidatorSet(1, 10)
+	block, other := makeDAHeaderBlock(valSet, 0), makeDAHeaderBlock(valSet, 100)
+
+	testCases := []struct {
+		testName  string
+		malleate  func(ev *InvalidDAHeaderEvidence)
+		expectErr bool
+	}{
+		{"Good InvalidDAHeaderEvidence", func(ev *InvalidDAHeaderEvidence) {}, false},
+		{"Nil proposal", func(ev *InvalidDAHeaderEvidence) { ev.Proposal = nil }, true},
+		{"Unsigned proposal", func(ev *InvalidDAHeaderEvidence) { ev.Proposal.Signature = nil }, true},
+		{"Nil data availability header", func(ev *InvalidDAHeaderEvidence) { ev.Proposal.DAHeader = nil }, true},
+		{"Invalid proposer address", func(ev *InvalidDAHeaderEvidence) { ev.ProposerAddress = []byte("addr") }, true},
+		{"Index out of the square", func(ev *InvalidDAHeaderEvidence) { ev.Index = 16 }, true},
+		{"Nil block part", func(ev *InvalidDAHeaderEvidence) { ev.BlockParts[0] = nil }, true},
+		{"Too many block parts", func(ev *InvalidDAHeaderEvidence) {
+			for len(ev.BlockParts) <= maxInvalidDAHeaderParts {
+				ev.BlockParts = append(ev.BlockParts, ev.BlockParts[0])
+			}
+		}, true},
+		{"Invalid share proof", func(ev *InvalidDAHeaderEvidence) { ev.ShareProofs = []ShareProof{{}} }, true},
+		{"Invalid root", func(ev *InvalidDAHeaderEvidence) { ev.Root = ev.Root[1:] }, true},
+		{"Zero validator power", func(ev *InvalidDAHeaderEvidence) { ev.ValidatorPower = 0 }, true},
+		{"Total voting power too low", func(ev *InvalidDAHeaderEvidence) { ev.TotalVotingPower = 1 }, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			ev, err := makeInvalidDAHeaderEvidence(t, "mychain", block, valSet, privVals[0], &other.DataAvailabilityHeader)
+			require.NoError(t, err)
+			tc.malleate(ev)
+			assert.Equal(t, tc.expectErr, ev.ValidateBasic() != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestMockEvidenceValidateBasic(t *testing.T) {
 	goodEvidence := NewMockDuplicateVoteEvidence(int64(1), time.Now(), "mock-chain-id")
 	assert.Nil(t, goodEvidence.ValidateBasic())
@@ -287,6 +513,18 @@ func TestEvidenceProto(t *testing.T) {
 	header2.LastBlockID = blockID
 	header2.ChainID = chainID
 
+	// -------- Blocks --------
+	valSet, privVals := RandValidatorSet(1, 10)
+	block, other := makeDAHeaderBlock(valSet, 0), makeDAHeaderBlock(valSet, 100)
+	dah := &block.DataAvailabilityHeader
+	rowEv, err := makeInvalidDAHeaderEvidence(t, chainID, block, valSet, privVals[0], &other.DataAvailabilityHeader)
+	require.NoError(t, err)
+	columnEv, err := makeInvalidDAHeaderEvidence(t, chainID, block, valSet, privVals[0],
+		mixDAHeaders(dah, &other.DataAvailabilityHeader, nil, []int{2}))
+	require.NoError(t, err)
+	// decoding caches the hash of the header
+	columnEv.Proposal.DAHeader.Hash()
+
 	tests := []struct {
 		testName     string
 		evidence     Evidence
@@ -298,6 +536,9 @@ func TestEvidenceProto(t *testing.T) {
 		{"DuplicateVoteEvidence nil voteB", &DuplicateVoteEvidence{VoteA: v, VoteB: nil}, false, true},
 		{"DuplicateVoteEvidence nil voteA", &DuplicateVoteEvidence{VoteA: nil, VoteB: v}, false, true},
 		{"DuplicateVoteEvidence success", &DuplicateVoteEvidence{VoteA: v2, VoteB: v}, false, false},
+		{"InvalidDAHeaderEvidence empty fail", &InvalidDAHeaderEvidence{}, false, true},
+		{"InvalidDAHeaderEvidence of a row success", rowEv, false, false},
+		{"InvalidDAHeaderEvidence of a column success", columnEv, false, false},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -314,6 +555,10 @@ func TestEvidenceProto(t *testing.T) {
 				assert.Error(t, err, tt.testName)
 				return
 			}
+			if ev, ok := evi.(*InvalidDAHeaderEvidence); ok {
+				// the data availability header caches its hash once the proposal got signed
+				ev.Proposal.DAHeader.Hash()
+			}
 			require.Equal(t, tt.evidence, evi, tt.testName)
 		})
 	}
@@ -0,0 +1,298 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
+)
+
+// StateTransitionFraudProof proves that applying a range of txs of a block to the state
+// committed to by an intermediate state root does not result in the next intermediate
+// state root of the block. The range is the one of the invalid root, see
+// IntermediateStateRoots.TxRange.
+// It only carries the shares of these txs and of the two roots bounding them, each
+// proven against the DataAvailabilityHeader of the block, so that light clients can
+// verify it without downloading or executing the block. The shares of the roots locate
+// the txs, see IntermediateStateRoots.marshalDelimited.
+// It is not evidence against validators: full nodes publish it with
+// EventStateTransitionFraud when executing a block.
+type StateTransitionFraudProof struct {
+	BlockHeight  int64
+	FirstTxIndex uint32       // index of the first tx applied to the pre-state
+	NumTxs       uint32       // number of txs applied to the pre-state
+	TxProofs     []ShareProof // consecutive shares of the txs
+	ISRProofs    []ShareProof // consecutive shares of the roots of the pre-state and of the post-state
+	StateWitness []byte       // app specific witnesses of the pre-state accessed by the txs
+}
+
+// NewStateTransitionFraudProof creates a StateTransitionFraudProof claiming that the
+// txs of the block preceding its intermediate state root isrIndex do not result in it.
+func NewStateTransitionFraudProof(
+	block *Block,
+	isrIndex uint32,
+	stateWitness []byte,
+) (*StateTransitionFraudProof, error) {
+	isrs := block.IntermediateStateRoots
+	first, end := isrs.TxRange(isrIndex, len(block.Txs))
+	if first == end || int64(isrIndex) >= int64(len(isrs.RawRootsList)) {
+		return nil, fmt.Errorf("no txs precede intermediate state root %d", isrIndex)
+	}
+
+	txLens := make([]int, len(block.Txs))
+	for i, tx := range block.Txs {
+		txLens[i] = DelimitedLen(len(tx))
+	}
+	rawISRs := isrs.marshalDelimited(block.Txs)
+	isrLens := make([]int, len(rawISRs))
+	for i, rawISR := range rawISRs {
+		isrLens[i] = len(rawISR)
+	}
+
+	// the shares of the intermediate state roots follow the ones of the txs
+	txStart, txEnd := contiguousShareRange(txLens, first, end)
+	_, numTxShares := contiguousShareRange(txLens, 0, len(txLens))
+	preIndex := int(isrIndex)
+	if preIndex > 0 {
+		preIndex--
+	}
+	isrStart, isrEnd := contiguousShareRange(isrLens, preIndex, int(isrIndex)+1)
+
+	txProofs, err := block.ProveShares(txStart, txEnd)
+	if err != nil {
+		return nil, err
+	}
+	isrProofs, err := block.ProveShares(numTxShares+isrStart, numTxShares+isrEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	fp := &StateTransitionFraudProof{
+		BlockHeight:  block.Height,
+		FirstTxIndex: uint32(first),
+		NumTxs:       uint32(end - first),
+		TxProofs:     txProofs,
+		ISRProofs:    isrProofs,
+		StateWitness: stateWitness,
+	}
+	return fp, fp.ValidateBasic()
+}
+
+// Height returns the height of the block with the invalid state transition.
+func (fp *StateTransitionFraudProof) Height() int64 {
+	return fp.BlockHeight
+}
+
+// String returns a string representation of the proof.
+func (fp *StateTransitionFraudProof) String() string {
+	return fmt.Sprintf("StateTransitionFraudProof{Height: %d, Txs: %d-%d}",
+		fp.BlockHeight, fp.FirstTxIndex, uint64(fp.FirstTxIndex)+uint64(fp.NumTxs))
+}
+
+// ValidateBasic performs basic validation.
+func (fp *StateTransitionFraudProof) ValidateBasic() error {
+	if fp.BlockHeight <= 0 {
+		return errors.New("negative or zero height")
+	}
+	if fp.NumTxs == 0 {
+		return errors.New("no txs")
+	}
+	if len(fp.TxProofs) == 0 {
+		return errors.New("missing tx proofs")
+	}
+	if len(fp.ISRProofs) == 0 {
+		return errors.New("missing intermediate state root proofs")
+	}
+	for i, p := range fp.TxProofs {
+		if err := p.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid tx proof #%d: %w", i, err)
+		}
+	}
+	for i, p := range fp.ISRProofs {
+		if err := p.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid intermediate state root proof #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ProvenStateTransition verifies the proofs against the DataAvailabilityHeader of the
+// block and returns the txs FirstTxIndex to FirstTxIndex+NumTxs along with the roots of
+// the state before and after applying them. The roots must commit to exactly these txs.
+// The pre-state of the first txs of the block is the state after the previous block,
+// i.e. the AppHash in the header of the block.
+func (fp *StateTransitionFraudProof) ProvenStateTransition(
+	dah *DataAvailabilityHeader,
+	appHash []byte,
+) (preStateRoot []byte, txs Txs, postStateRoot []byte, err error) {
+	pre, post, err := fp.provenIntermediateStateRoots(dah)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	preStateRoot = appHash
+	if fp.FirstTxIndex > 0 {
+		preStateRoot = pre.root
+	}
+
+	// the tx shares are the first shares of the square, so that the index of a share
+	// among them is the one in the square
+	shares, err := verifyConsecutiveShares(dah, consts.TxNamespaceID, fp.TxProofs)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid tx proofs: %w", err)
+	}
+	if uint64(fp.TxProofs[0].Index) != post.txShare || post.txPos >= consts.ShareSize {
+		return nil, nil, nil, fmt.Errorf("tx %d is at share %d position %d, proofs start at share %d",
+			fp.FirstTxIndex, post.txShare, post.txPos, fp.TxProofs[0].Index)
+	}
+	rawTxs, err := parseDelimitedUnits(shares, int(post.txPos))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(rawTxs) < int(fp.NumTxs) {
+		return nil, nil, nil, fmt.Errorf("tx proofs contain %d of %d txs", len(rawTxs), fp.NumTxs)
+	}
+
+	txs = make(Txs, fp.NumTxs)
+	for i := range txs {
+		txs[i] = Tx(rawTxs[i])
+	}
+	return preStateRoot, txs, post.root, nil
+}
+
+// provenIntermediateStateRoots verifies the intermediate state root proofs against the
+// DataAvailabilityHeader and returns the root of the txs of the proof along with the one
+// preceding it. There is no preceding root for the first txs of the block.
+func (fp *StateTransitionFraudProof) provenIntermediateStateRoots(
+	dah *DataAvailabilityHeader,
+) (pre, post intermediateStateRoot, err error) {
+	shares, err := verifyConsecutiveShares(dah, consts.IntermediateStateRootsNamespaceID, fp.ISRProofs)
+	if err != nil {
+		return pre, post, fmt.Errorf("invalid intermediate state root proofs: %w", err)
+	}
+	// the reserved byte of the first share points to the first root starting in it
+	start := int(shares[0][consts.NamespaceSize])
+	if start == 0 {
+		return pre, post, fmt.Errorf("no intermediate state root starts in share %d", fp.ISRProofs[0].Index)
+	}
+	rawISRs, err := parseDelimitedUnits(shares, start)
+	if err != nil {
+		return pre, post, err
+	}
+
+	isrs := make([]intermediateStateRoot, len(rawISRs))
+	for i, rawISR := range rawISRs {
+		if isrs[i], err = unmarshalIntermediateStateRoot(rawISR); err != nil {
+			return pre, post, err
+		}
+	}
+	for i, isr := range isrs {
+		if isr.firstTx != uint64(fp.FirstTxIndex) {
+			continue
+		}
+		if isr.numTxs != uint64(fp.NumTxs) {
+			return pre, post, fmt.Errorf("intermediate state root of txs %d-%d covers txs %d-%d",
+				fp.FirstTxIndex, uint64(fp.FirstTxIndex)+uint64(fp.NumTxs), isr.firstTx, isr.firstTx+isr.numTxs)
+		}
+		if fp.FirstTxIndex == 0 {
+			return pre, isr, nil
+		}
+		if i == 0 || isrs[i-1].firstTx+isrs[i-1].numTxs != isr.firstTx {
+			return pre, post, fmt.Errorf("missing the intermediate state root preceding tx %d", fp.FirstTxIndex)
+		}
+		return isrs[i-1], isr, nil
+	}
+	return pre, post, fmt.Errorf("no intermediate state root of the txs from %d", fp.FirstTxIndex)
+}
+
+// ToProto encodes StateTransitionFraudProof to protobuf
+func (fp *StateTransitionFraudProof) ToProto() *tmproto.StateTransitionFraudProof {
+	txProofs := make([]tmproto.ShareProof, len(fp.TxProofs))
+	for i, p := range fp.TxProofs {
+		txProofs[i] = p.ToProto()
+	}
+	isrProofs := make([]tmproto.ShareProof, len(fp.ISRProofs))
+	for i, p := range fp.ISRProofs {
+		isrProofs[i] = p.ToProto()
+	}
+
+	return &tmproto.StateTransitionFraudProof{
+		Height:       fp.BlockHeight,
+		FirstTxIndex: fp.FirstTxIndex,
+		NumTxs:       fp.NumTxs,
+		TxProofs:     txProofs,
+		ISRProofs:    isrProofs,
+		StateWitness: fp.StateWitness,
+	}
+}
+
+// StateTransitionFraudProofFromProto decodes protobuf
+func StateTransitionFraudProofFromProto(
+	pb *tmproto.StateTransitionFraudProof,
+) (*StateTransitionFraudProof, error) {
+	if pb == nil {
+		return nil, errors.New("empty state transition fraud proof")
+	}
+
+	txProofs := make([]ShareProof, len(pb.TxProofs))
+	for i, p := range pb.TxProofs {
+		txProofs[i] = ShareProofFromProto(p)
+	}
+	isrProofs := make([]ShareProof, len(pb.ISRProofs))
+	for i, p := range pb.ISRProofs {
+		isrProofs[i] = ShareProofFromProto(p)
+	}
+
+	fp := &StateTransitionFraudProof{
+		BlockHeight:  pb.Height,
+		FirstTxIndex: pb.FirstTxIndex,
+		NumTxs:       pb.NumTxs,
+		TxProofs:     txProofs,
+		ISRProofs:    isrProofs,
+		StateWitness: pb.StateWitness,
+	}
+	return fp, fp.ValidateBasic()
+}
+
+// contiguousShareRange returns the range of shares taken up by the units first to end
+// out of units of the given lengths, once they are packed contiguously into shares.
+func contiguousShareRange(lens []int, first, end int) (start, stop int) {
+	var offset, length int
+	for _, l := range lens[:first] {
+		offset += l
+	}
+	for _, l := range lens[first:end] {
+		length += l
+	}
+	return offset / consts.TxShareSize, ContiguousSharesUsed(offset + length)
+}
+
+// parseDelimitedUnits parses the length delimited units packed contiguously into the
+// given consecutive shares, starting at position pos of the first share. It stops at the
+// first unit which isn't contained in the shares.
+func parseDelimitedUnits(shares [][]byte, pos int) ([][]byte, error) {
+	const dataStart = consts.NamespaceSize + consts.ShareReservedBytes
+	if pos < dataStart || pos >= consts.ShareSize {
+		return nil, fmt.Errorf("invalid position %d in share", pos)
+	}
+	data := make([]byte, 0, len(shares)*consts.TxShareSize)
+	for _, share := range shares {
+		data = append(data, share[dataStart:]...)
+	}
+	data = data[pos-dataStart:]
+
+	var units [][]byte
+	for {
+		unitLen, n := binary.Uvarint(data)
+		if n < 0 {
+			return nil, errors.New("invalid length delimiter")
+		}
+		// the delimiter is cut off, or the unit is either padding or cut off
+		if n == 0 || unitLen == 0 || unitLen > uint64(len(data)-n) {
+			return units, nil
+		}
+		units = append(units, data[n:n+int(unitLen)])
+		data = data[n+int(unitLen):]
+	}
+}
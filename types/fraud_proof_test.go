@@ -0,0 +1,122 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
+)
+
+const isrTxsPerRoot = 2
+
+// makeISRBlock returns a block with six txs spanning several shares each and an
+// intermediate state root for every two of them.
+func makeISRBlock() *Block {
+	txs := make(Txs, 6)
+	for i := range txs {
+		txs[i] = Tx(bytes.Repeat([]byte{byte(i)}, 2*consts.TxShareSize))
+	}
+	isrs := IntermediateStateRoots{TxsPerRoot: isrTxsPerRoot}
+	for i := 0; i < len(txs); i += isrTxsPerRoot {
+		isrs.RawRootsList = append(isrs.RawRootsList, tmhash.Sum([]byte(fmt.Sprintf("isr%d", i))))
+	}
+	block := MakeBlock(3, txs, nil, isrs, Messages{}, nil)
+	block.AppHash = tmhash.Sum([]byte("app"))
+	return block
+}
+
+func TestStateTransitionFraudProof(t *testing.T) {
+	block := makeISRBlock()
+	dah := &block.DataAvailabilityHeader
+	roots := block.IntermediateStateRoots.RawRootsList
+	numTxShares := len(block.Txs.splitIntoShares())
+
+	for i := range roots {
+		fp, err := NewStateTransitionFraudProof(block, uint32(i), []byte("witness"))
+		require.NoError(t, err)
+		assert.Equal(t, block.Height, fp.Height())
+
+		first, end := block.IntermediateStateRoots.TxRange(uint32(i), len(block.Txs))
+		assert.EqualValues(t, first, fp.FirstTxIndex)
+		assert.EqualValues(t, end-first, fp.NumTxs)
+		// only the shares of the disputed txs are proven
+		assert.Less(t, len(fp.TxProofs), numTxShares)
+
+		pre, txs, post, err := fp.ProvenStateTransition(dah, block.AppHash)
+		require.NoError(t, err)
+		assert.Equal(t, block.Txs[first:end], txs)
+		assert.EqualValues(t, roots[i], post)
+		if i == 0 {
+			assert.EqualValues(t, block.AppHash, pre)
+		} else {
+			assert.EqualValues(t, roots[i-1], pre)
+		}
+	}
+
+	_, err := NewStateTransitionFraudProof(block, uint32(len(roots)), nil)
+	assert.Error(t, err)
+}
+
+func TestStateTransitionFraudProofTampering(t *testing.T) {
+	block := makeISRBlock()
+	dah := &block.DataAvailabilityHeader
+
+	testCases := []struct {
+		name     string
+		malleate func(*StateTransitionFraudProof)
+	}{
+		{"later first tx", func(fp *StateTransitionFraudProof) { fp.FirstTxIndex++ }},
+		{"earlier first tx", func(fp *StateTransitionFraudProof) { fp.FirstTxIndex-- }},
+		{"more txs", func(fp *StateTransitionFraudProof) { fp.NumTxs++ }},
+		{"fewer txs", func(fp *StateTransitionFraudProof) { fp.NumTxs-- }},
+		{"no txs", func(fp *StateTransitionFraudProof) { fp.NumTxs = 0 }},
+		{"tampered tx share", func(fp *StateTransitionFraudProof) {
+			fp.TxProofs[0].Share[consts.ShareSize-1] ^= 0xFF
+		}},
+		{"tampered root share", func(fp *StateTransitionFraudProof) {
+			fp.ISRProofs[0].Share[consts.ShareSize-1] ^= 0xFF
+		}},
+		{"missing tx share", func(fp *StateTransitionFraudProof) { fp.TxProofs = fp.TxProofs[:len(fp.TxProofs)-1] }},
+		{"missing root proofs", func(fp *StateTransitionFraudProof) { fp.ISRProofs = nil }},
+		{"root shares as tx shares", func(fp *StateTransitionFraudProof) { fp.TxProofs = fp.ISRProofs }},
+		{"shifted tx shares", func(fp *StateTransitionFraudProof) { fp.TxProofs = fp.TxProofs[1:] }},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fp, err := NewStateTransitionFraudProof(block, 1, nil)
+			require.NoError(t, err)
+			tc.malleate(fp)
+
+			err = fp.ValidateBasic()
+			if err == nil {
+				_, _, _, err = fp.ProvenStateTransition(dah, block.AppHash)
+			}
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStateTransitionFraudProofProto(t *testing.T) {
+	fp, err := NewStateTransitionFraudProof(makeISRBlock(), 1, []byte("witness"))
+	require.NoError(t, err)
+
+	bz, err := fp.ToProto().Marshal()
+	require.NoError(t, err)
+	pb := new(tmproto.StateTransitionFraudProof)
+	require.NoError(t, pb.Unmarshal(bz))
+	fp2, err := StateTransitionFraudProofFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, fp, fp2)
+
+	_, err = StateTransitionFraudProofFromProto(nil)
+	assert.Error(t, err)
+	_, err = StateTransitionFraudProofFromProto(&tmproto.StateTransitionFraudProof{})
+	assert.Error(t, err)
+}
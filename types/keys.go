@@ -1,6 +0,0 @@
-package types
-
-// UNSTABLE
-var (
-	PeerStateKey = "ConsensusReactor.peerState"
-)
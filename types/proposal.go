@@ -68,6 +68,9 @@ func (p *Proposal) ValidateBasic() error {
 	if !p.BlockID.IsComplete() {
 		return fmt.Errorf("expected a complete, non-empty BlockID, got: %v", p.BlockID)
 	}
+	if err := p.DAHeader.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong DAHeader: %v", err)
+	}
 
 	// NOTE: Timestamp validation is subtle and handled elsewhere.
 
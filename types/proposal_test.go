@@ -13,6 +13,7 @@ import (
 	"github.com/lazyledger/lazyledger-core/libs/protoio"
 	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
 )
 
 var (
@@ -157,6 +158,22 @@ func TestProposalValidateBasic(t *testing.T) {
 		{"Too big Signature", func(p *Proposal) {
 			p.Signature = make([]byte, MaxSignatureSize+1)
 		}, true},
+		{"Nil DAHeader", func(p *Proposal) { p.DAHeader = nil }, true},
+		{"Empty DAHeader", func(p *Proposal) { p.DAHeader = &DataAvailabilityHeader{} }, true},
+		{"Mismatched DAHeader roots", func(p *Proposal) {
+			p.DAHeader = &DataAvailabilityHeader{
+				RowsRoots:   append(p.DAHeader.RowsRoots, p.DAHeader.RowsRoots...),
+				ColumnRoots: p.DAHeader.ColumnRoots,
+			}
+		}, true},
+		{"DAHeader width not a power of two", func(p *Proposal) {
+			roots := append(p.DAHeader.RowsRoots, p.DAHeader.RowsRoots[0], p.DAHeader.RowsRoots[0])
+			p.DAHeader = &DataAvailabilityHeader{RowsRoots: roots, ColumnRoots: roots}
+		}, true},
+		{"Too wide DAHeader", func(p *Proposal) {
+			roots := make(NmtRoots, 4*consts.MaxSquareSize)
+			p.DAHeader = &DataAvailabilityHeader{RowsRoots: roots, ColumnRoots: roots}
+		}, true},
 	}
 	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
 	dah := makeDAHeaderRandom()
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 
 	"github.com/gogo/protobuf/proto"
 	tmbytes "github.com/lazyledger/lazyledger-core/libs/bytes"
@@ -16,6 +17,19 @@ import (
 func DataFromSquare(eds *rsmt2d.ExtendedDataSquare) (Data, error) {
 	originalWidth := eds.Width() / 2
 
+	shares := make([][]byte, 0, originalWidth*originalWidth)
+	// iterate over each row index
+	for x := uint(0); x < originalWidth; x++ {
+		// iterate over each col index
+		for y := uint(0); y < originalWidth; y++ {
+			shares = append(shares, eds.Cell(x, y))
+		}
+	}
+	return dataFromShares(shares)
+}
+
+// dataFromShares extracts block data from the shares of an original data square.
+func dataFromShares(shares [][]byte) (Data, error) {
 	// sort block data shares by namespace
 	var (
 		sortedTxShares  [][]byte
@@ -24,34 +38,29 @@ func DataFromSquare(eds *rsmt2d.ExtendedDataSquare) (Data, error) {
 		sortedMsgShares [][]byte
 	)
 
-	// iterate over each row index
-	for x := uint(0); x < originalWidth; x++ {
-		// iterate over each col index
-		for y := uint(0); y < originalWidth; y++ {
-			// sort the data of that share types via namespace
-			share := eds.Cell(x, y)
-			nid := share[:consts.NamespaceSize]
-			switch {
-			case bytes.Equal(consts.TxNamespaceID, nid):
-				sortedTxShares = append(sortedTxShares, share)
-
-			case bytes.Equal(consts.IntermediateStateRootsNamespaceID, nid):
-				sortedISRShares = append(sortedISRShares, share)
-
-			case bytes.Equal(consts.EvidenceNamespaceID, nid):
-				sortedEvdShares = append(sortedEvdShares, share)
-
-			case bytes.Equal(consts.TailPaddingNamespaceID, nid):
-				continue
-
-			// ignore unused but reserved namespaces
-			case bytes.Compare(nid, consts.MaxReservedNamespace) < 1:
-				continue
-
-			// every other namespaceID should be a message
-			default:
-				sortedMsgShares = append(sortedMsgShares, share)
-			}
+	for _, share := range shares {
+		// sort the data of that share types via namespace
+		nid := share[:consts.NamespaceSize]
+		switch {
+		case bytes.Equal(consts.TxNamespaceID, nid):
+			sortedTxShares = append(sortedTxShares, share)
+
+		case bytes.Equal(consts.IntermediateStateRootsNamespaceID, nid):
+			sortedISRShares = append(sortedISRShares, share)
+
+		case bytes.Equal(consts.EvidenceNamespaceID, nid):
+			sortedEvdShares = append(sortedEvdShares, share)
+
+		case bytes.Equal(consts.TailPaddingNamespaceID, nid):
+			continue
+
+		// ignore unused but reserved namespaces
+		case bytes.Compare(nid, consts.MaxReservedNamespace) < 1:
+			continue
+
+		// every other namespaceID should be a message
+		default:
+			sortedMsgShares = append(sortedMsgShares, share)
 		}
 	}
 
@@ -61,7 +70,7 @@ func DataFromSquare(eds *rsmt2d.ExtendedDataSquare) (Data, error) {
 		return Data{}, err
 	}
 
-	isrs, err := parseISRs(sortedISRShares)
+	isrs, err := parseISRs(sortedISRShares, txs)
 	if err != nil {
 		return Data{}, err
 	}
@@ -102,18 +111,56 @@ func parseTxs(shares [][]byte) (Txs, error) {
 }
 
 // parseISRs collects all the intermediate state roots from the shares provided
-func parseISRs(shares [][]byte) (IntermediateStateRoots, error) {
+// and checks that they cover the given txs of the block.
+// The number of txs per root is the one of the first root.
+func parseISRs(shares [][]byte, txs Txs) (IntermediateStateRoots, error) {
 	rawISRs, err := processContiguousShares(shares)
 	if err != nil {
 		return IntermediateStateRoots{}, err
 	}
 
-	ISRs := make([]tmbytes.HexBytes, len(rawISRs))
+	var (
+		ISRs       = make([]tmbytes.HexBytes, len(rawISRs))
+		txsPerRoot uint64
+		nextTx     uint64
+		txsLen     int
+	)
 	for i := 0; i < len(ISRs); i++ {
-		ISRs[i] = rawISRs[i]
+		isr, err := unmarshalIntermediateStateRoot(rawISRs[i])
+		if err != nil {
+			return IntermediateStateRoots{}, err
+		}
+		if i == 0 {
+			txsPerRoot = isr.numTxs
+		}
+		if isr.firstTx != nextTx || isr.numTxs == 0 || isr.numTxs > txsPerRoot ||
+			isr.numTxs > uint64(len(txs))-nextTx {
+			return IntermediateStateRoots{}, fmt.Errorf("intermediate state root %d covers txs %d-%d, "+
+				"expected up to %d of %d txs from %d", i, isr.firstTx, isr.firstTx+isr.numTxs, txsPerRoot,
+				len(txs), nextTx)
+		}
+		if isr.numTxs < txsPerRoot && i != len(ISRs)-1 {
+			return IntermediateStateRoots{}, fmt.Errorf("intermediate state root %d covers %d txs, expected %d",
+				i, isr.numTxs, txsPerRoot)
+		}
+		if txShare, txPos := contiguousPosition(txsLen); isr.txShare != uint64(txShare) ||
+			isr.txPos != uint64(txPos) {
+			return IntermediateStateRoots{}, fmt.Errorf("intermediate state root %d locates tx %d at "+
+				"share %d position %d, expected share %d position %d", i, isr.firstTx, isr.txShare, isr.txPos,
+				txShare, txPos)
+		}
+		ISRs[i] = isr.root
+		for _, tx := range txs[isr.firstTx : isr.firstTx+isr.numTxs] {
+			txsLen += DelimitedLen(len(tx))
+		}
+		nextTx += isr.numTxs
+	}
+	if len(ISRs) > 0 && nextTx != uint64(len(txs)) {
+		return IntermediateStateRoots{}, fmt.Errorf("intermediate state roots cover %d of %d txs",
+			nextTx, len(txs))
 	}
 
-	return IntermediateStateRoots{RawRootsList: ISRs}, nil
+	return IntermediateStateRoots{RawRootsList: ISRs, TxsPerRoot: uint32(txsPerRoot)}, nil
 }
 
 // parseEvd collects all evidence from the shares provided.
@@ -0,0 +1,192 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/lazyledger/nmt"
+	"github.com/lazyledger/nmt/namespace"
+	"github.com/lazyledger/rsmt2d"
+
+	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
+	"github.com/lazyledger/lazyledger-core/p2p/ipld/wrapper"
+	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
+	"github.com/lazyledger/lazyledger-core/types/consts"
+)
+
+// ShareProof proves that a share is part of the original data square committed to
+// by a DataAvailabilityHeader.
+type ShareProof struct {
+	Index uint32   `json:"index"` // index of the share in the original data square, counted row by row
+	Share []byte   `json:"share"`
+	Nodes [][]byte `json:"nodes"`
+}
+
+// maxShareProofNodes is the number of nodes proving a share in a row of the
+// extended data square of the largest size.
+var maxShareProofNodes = bits.Len(2*consts.MaxSquareSize - 1)
+
+// ValidateBasic performs basic validation.
+func (p ShareProof) ValidateBasic() error {
+	if len(p.Share) != consts.ShareSize {
+		return fmt.Errorf("share has %d bytes, expected %d", len(p.Share), consts.ShareSize)
+	}
+	if len(p.Nodes) > maxShareProofNodes {
+		return fmt.Errorf("too many nodes: %d > %d", len(p.Nodes), maxShareProofNodes)
+	}
+	for i, node := range p.Nodes {
+		if len(node) != 2*consts.NamespaceSize+tmhash.Size {
+			return fmt.Errorf("node %d has %d bytes, expected %d", i, len(node), 2*consts.NamespaceSize+tmhash.Size)
+		}
+	}
+	return nil
+}
+
+// Verify checks the proof against the root of the row of the share.
+func (p ShareProof) Verify(dah *DataAvailabilityHeader) bool {
+	if p.ValidateBasic() != nil {
+		return false
+	}
+	squareSize := uint64(len(dah.RowsRoots) / 2)
+	if uint64(p.Index) >= squareSize*squareSize {
+		return false
+	}
+	row, col := uint64(p.Index)/squareSize, uint64(p.Index)%squareSize
+	proof := nmt.NewInclusionProof(int(col), int(col)+1, p.Nodes, true)
+
+	// the leaves of the tree are prefixed with the namespace once more, see
+	// wrapper.ErasuredNamespacedMerkleTree
+	nID := append(make(namespace.ID, 0, consts.NamespaceSize), p.Share[:consts.NamespaceSize]...)
+	return proof.VerifyInclusion(consts.NewBaseHashFunc, nID, p.Share, dah.RowsRoots[row])
+}
+
+// ToProto converts ShareProof to protobuf
+func (p ShareProof) ToProto() tmproto.ShareProof {
+	return tmproto.ShareProof{
+		Index: p.Index,
+		Share: p.Share,
+		Nodes: p.Nodes,
+	}
+}
+
+// ShareProofFromProto converts a protobuf ShareProof to ShareProof
+func ShareProofFromProto(pb tmproto.ShareProof) ShareProof {
+	return ShareProof{
+		Index: pb.Index,
+		Share: pb.Share,
+		Nodes: pb.Nodes,
+	}
+}
+
+// ProveShares returns the proofs of the shares start to end of the original data
+// square of the block, counting the shares row by row.
+func (b *Block) ProveShares(start, end int) ([]ShareProof, error) {
+	eds, err := b.extendedDataSquare()
+	if err != nil {
+		return nil, err
+	}
+	squareSize := eds.Width() / 2
+	if start < 0 || start >= end || end > int(squareSize*squareSize) {
+		return nil, fmt.Errorf("invalid range of shares %d-%d of a square of size %d", start, end, squareSize)
+	}
+
+	var (
+		proofs = make([]ShareProof, 0, end-start)
+		tree   *wrapper.ErasuredNamespacedMerkleTree
+	)
+	for i := start; i < end; i++ {
+		row, col := uint(i)/squareSize, uint(i)%squareSize
+		if tree == nil || col == 0 {
+			tree = rowTree(eds, row)
+		}
+		_, nodes, _, _ := tree.Prove(int(col))
+		proofs = append(proofs, ShareProof{
+			Index: uint32(i),
+			Share: eds.Cell(row, col),
+			Nodes: nodes,
+		})
+	}
+	return proofs, nil
+}
+
+// extendedDataSquare erasure codes the original data of the block, after checking that
+// it is laid out in as many shares as the header commits to.
+func (b *Block) extendedDataSquare() (*rsmt2d.ExtendedDataSquare, error) {
+	namespacedShares, dataSharesLen := b.Data.ComputeShares()
+	if b.NumOriginalDataShares != uint64(dataSharesLen) {
+		return nil, fmt.Errorf("block data has %d original data shares, header commits to %d",
+			dataSharesLen, b.NumOriginalDataShares)
+	}
+	shares := namespacedShares.RawShares()
+
+	squareSize := uint64(math.Sqrt(float64(len(shares))))
+	tree := wrapper.NewErasuredNamespacedMerkleTree(squareSize)
+	return rsmt2d.ComputeExtendedDataSquare(shares, rsmt2d.NewRSGF8Codec(), tree.Constructor)
+}
+
+// rowTree returns the tree of the given row of the extended data square.
+func rowTree(eds *rsmt2d.ExtendedDataSquare, row uint) *wrapper.ErasuredNamespacedMerkleTree {
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(eds.Width() / 2))
+	for col, share := range eds.Row(row) {
+		tree.Push(share, rsmt2d.SquareIndex{Axis: row, Cell: uint(col)})
+	}
+	return &tree
+}
+
+// columnTree returns the tree of the given column of the extended data square.
+func columnTree(eds *rsmt2d.ExtendedDataSquare, col uint) *wrapper.ErasuredNamespacedMerkleTree {
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(eds.Width() / 2))
+	for row, share := range eds.Column(col) {
+		tree.Push(share, rsmt2d.SquareIndex{Axis: col, Cell: uint(row)})
+	}
+	return &tree
+}
+
+// leafNamespace returns the namespace a share is pushed with to the tree of a row or column,
+// see wrapper.ErasuredNamespacedMerkleTree.
+func leafNamespace(share []byte, parity bool) namespace.ID {
+	nID := make(namespace.ID, 0, consts.NamespaceSize)
+	switch {
+	case parity:
+		return append(nID, consts.ParitySharesNamespaceID...)
+	case bytes.Equal(share[:consts.NamespaceSize], make([]byte, consts.NamespaceSize)):
+		return append(nID, consts.TailPaddingNamespaceID...)
+	default:
+		return append(nID, share[:consts.NamespaceSize]...)
+	}
+}
+
+// verifyConsecutiveShares checks that the proofs are valid for consecutive shares of the
+// given namespace in the original data committed to by the DataAvailabilityHeader and
+// returns these shares in order.
+func verifyConsecutiveShares(
+	dah *DataAvailabilityHeader,
+	nID namespace.ID,
+	proofs []ShareProof,
+) ([][]byte, error) {
+	if dah == nil {
+		return nil, errors.New("nil DataAvailabilityHeader")
+	}
+	if len(proofs) == 0 {
+		return nil, errors.New("no shares")
+	}
+
+	shares := make([][]byte, len(proofs))
+	for i, proof := range proofs {
+		if uint64(proof.Index) != uint64(proofs[0].Index)+uint64(i) {
+			return nil, fmt.Errorf("share %d is not next to share %d", proof.Index, proofs[0].Index+uint32(i)-1)
+		}
+		if !proof.Verify(dah) {
+			return nil, fmt.Errorf("invalid proof for share %d", proof.Index)
+		}
+		if !nID.Equal(proof.Share[:consts.NamespaceSize]) {
+			return nil, fmt.Errorf("share %d has namespace %X, expected %X",
+				proof.Index, proof.Share[:consts.NamespaceSize], nID)
+		}
+		shares[i] = proof.Share
+	}
+	return shares, nil
+}
@@ -2,7 +2,9 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 
+	"github.com/lazyledger/lazyledger-core/crypto/tmhash"
 	"github.com/lazyledger/lazyledger-core/types/consts"
 	"github.com/lazyledger/nmt/namespace"
 )
@@ -75,13 +77,13 @@ func splitContiguous(nid namespace.ID, rawDatas [][]byte) []NamespacedShare {
 	return shares
 }
 
-// getNextChunk gets the next chunk for contiguous shares
+// getNextChunk gets the next chunk for contiguous shares along with the position
+// in the share of the first data segment beginning in the chunk, or 0 if none does.
 // Precondition: none of the slices in rawDatas is zero-length
 // This precondition should always hold at this point since zero-length txs are simply invalid.
 func getNextChunk(rawDatas [][]byte, outerIndex int, innerIndex int, width int) ([]byte, int, int, int) {
 	rawData := make([]byte, 0, width)
 	startIndex := 0
-	firstBytesToFetch := 0
 
 	curIndex := 0
 	for curIndex < width && outerIndex < len(rawDatas) {
@@ -89,14 +91,11 @@ func getNextChunk(rawDatas [][]byte, outerIndex int, innerIndex int, width int)
 		if bytesToFetch == 0 {
 			panic("zero-length contiguous share data is invalid")
 		}
-		if curIndex == 0 {
-			firstBytesToFetch = bytesToFetch
-		}
-		// If we've already placed some data in this chunk, that means
-		// a new data segment begins
-		if curIndex != 0 {
+		// The reserved byte points to the first data segment beginning in
+		// this chunk, if any
+		if startIndex == 0 && innerIndex == 0 {
 			// Offset by the fixed reserved bytes at the beginning of the share
-			startIndex = firstBytesToFetch + consts.NamespaceSize + consts.ShareReservedBytes
+			startIndex = curIndex + consts.NamespaceSize + consts.ShareReservedBytes
 		}
 		rawData = append(rawData, rawDatas[outerIndex][innerIndex:innerIndex+bytesToFetch]...)
 		innerIndex += bytesToFetch
@@ -110,6 +109,35 @@ func getNextChunk(rawDatas [][]byte, outerIndex int, innerIndex int, width int)
 	return rawData, outerIndex, innerIndex, startIndex
 }
 
+// IntermediateStateRootSize is the size of the intermediate state roots
+// proposers leave room for in the original data square, one after every tx.
+const IntermediateStateRootSize = tmhash.Size
+
+// MaxIntermediateStateRootLen is the maximum length an intermediate state root of
+// IntermediateStateRootSize bytes takes up in shares, once it is prefixed with the
+// range of txs it covers and the position of the first of them, see
+// IntermediateStateRoots.marshalDelimited.
+var MaxIntermediateStateRootLen = DelimitedLen(4*binary.MaxVarintLen32 + IntermediateStateRootSize)
+
+// DelimitedLen returns the length of data of the given length once it is
+// prefixed with its length, see Tx.MarshalDelimited.
+func DelimitedLen(n int) int {
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	return binary.PutUvarint(lenBuf, uint64(n)) + n
+}
+
+// ContiguousSharesUsed returns the number of shares data of the given total
+// delimited length takes up when it is packed contiguously, as txs are.
+func ContiguousSharesUsed(delimitedLen int) int {
+	return (delimitedLen + consts.TxShareSize - 1) / consts.TxShareSize
+}
+
+// MsgSharesUsed returns the number of shares a message with data of the given
+// length takes up. Every message starts at a new share.
+func MsgSharesUsed(dataLen int) int {
+	return (DelimitedLen(dataLen) + consts.MsgShareSize - 1) / consts.MsgShareSize
+}
+
 func GenerateTailPaddingShares(n int, shareWidth int) NamespacedShares {
 	shares := make([]NamespacedShare, n)
 	for i := 0; i < n; i++ {
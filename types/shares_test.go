@@ -25,6 +25,8 @@ type splitter interface {
 func TestMakeShares(t *testing.T) {
 	reservedTxNamespaceID := append(bytes.Repeat([]byte{0}, 7), 1)
 	reservedEvidenceNamespaceID := append(bytes.Repeat([]byte{0}, 7), 3)
+	// the reserved byte of a share starting with new data points right after it
+	firstDataStart := byte(consts.NamespaceSize + consts.ShareReservedBytes)
 	val := NewMockPV()
 	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
 	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
@@ -67,7 +69,7 @@ func TestMakeShares(t *testing.T) {
 				},
 			}, NamespacedShares{NamespacedShare{
 				Share: append(
-					append(reservedEvidenceNamespaceID, byte(0)),
+					append(reservedEvidenceNamespaceID, firstDataStart),
 					testEvidenceBytes[:consts.TxShareSize]...,
 				),
 				ID: reservedEvidenceNamespaceID,
@@ -86,7 +88,7 @@ func TestMakeShares(t *testing.T) {
 			NamespacedShares{
 				NamespacedShare{
 					Share: append(
-						append(reservedTxNamespaceID, byte(0)),
+						append(reservedTxNamespaceID, firstDataStart),
 						zeroPadIfNecessary(smolTxLenDelimited, consts.TxShareSize)...,
 					),
 					ID: reservedTxNamespaceID,
@@ -100,7 +102,7 @@ func TestMakeShares(t *testing.T) {
 			NamespacedShares{
 				NamespacedShare{
 					Share: append(
-						append(reservedTxNamespaceID, byte(0)),
+						append(reservedTxNamespaceID, firstDataStart),
 						largeTxLenDelimited[:consts.TxShareSize]...,
 					),
 					ID: reservedTxNamespaceID,
@@ -121,7 +123,7 @@ func TestMakeShares(t *testing.T) {
 			NamespacedShares{
 				NamespacedShare{
 					Share: append(
-						append(reservedTxNamespaceID, byte(0)),
+						append(reservedTxNamespaceID, firstDataStart),
 						largeTxLenDelimited[:consts.TxShareSize]...,
 					),
 					ID: reservedTxNamespaceID,
@@ -209,23 +211,42 @@ func Test_appendToSharesOverwrite(t *testing.T) {
 	assert.Equal(t, extraCopy, []byte(newShare.Share[:consts.MsgShareSize]))
 }
 
+func TestSharesUsed(t *testing.T) {
+	for _, size := range []int{1, 100, consts.TxShareSize - 1, consts.TxShareSize, consts.MsgShareSize, 1000} {
+		for _, count := range []int{1, 3, 10} {
+			txs := generateRandomContiguousShares(count, size)
+			delimitedLen := 0
+			for _, tx := range txs {
+				delimitedLen += DelimitedLen(len(tx))
+			}
+			assert.Equal(t, len(txs.splitIntoShares()), ContiguousSharesUsed(delimitedLen),
+				"%d txs of size %d", count, size)
+		}
+
+		msg := generateRandomMessage(size)
+		msgs := Messages{MessagesList: []Message{msg}}
+		assert.Equal(t, len(msgs.splitIntoShares()), MsgSharesUsed(len(msg.Data)), "message of size %d", len(msg.Data))
+	}
+}
+
 func TestDataFromSquare(t *testing.T) {
 	type test struct {
-		name     string
-		txCount  int
-		isrCount int
-		evdCount int
-		msgCount int
-		maxSize  int // max size of each tx or msg
+		name       string
+		txCount    int
+		txsPerRoot int
+		evdCount   int
+		msgCount   int
+		maxSize    int // max size of each tx or msg
 	}
 
 	tests := []test{
 		{"one of each random small size", 1, 1, 1, 1, 40},
 		{"one of each random large size", 1, 1, 1, 1, 400},
-		{"many of each random large size", 10, 10, 10, 10, 40},
-		{"many of each random large size", 10, 10, 10, 10, 400},
+		{"many of each random large size", 10, 1, 10, 10, 40},
+		{"many of each random large size", 10, 1, 10, 10, 400},
+		{"many txs per intermediate state root", 10, 3, 10, 10, 400},
 		{"only transactions", 10, 0, 0, 0, 400},
-		{"only intermediate state roots", 0, 10, 0, 0, 400},
+		{"only transactions and intermediate state roots", 10, 2, 0, 0, 400},
 		{"only evidence", 0, 0, 10, 0, 400},
 		{"only messages", 0, 0, 0, 10, 400},
 	}
@@ -238,7 +259,7 @@ func TestDataFromSquare(t *testing.T) {
 			data := generateRandomBlockData(
 				t,
 				tc.txCount,
-				tc.isrCount,
+				tc.txsPerRoot,
 				tc.evdCount,
 				tc.msgCount,
 				tc.maxSize,
@@ -451,10 +472,10 @@ func Test_parseDelimiter(t *testing.T) {
 }
 
 // generateRandomBlockData returns randomly generated block data for testing purposes
-func generateRandomBlockData(t *testing.T, txCount, isrCount, evdCount, msgCount, maxSize int) Data {
+func generateRandomBlockData(t *testing.T, txCount, txsPerRoot, evdCount, msgCount, maxSize int) Data {
 	var out Data
 	out.Txs = generateRandomlySizedContiguousShares(txCount, maxSize)
-	out.IntermediateStateRoots = generateRandomISR(isrCount)
+	out.IntermediateStateRoots = generateRandomISR(txCount, txsPerRoot)
 	out.Evidence = generateIdenticalEvidence(t, evdCount)
 	out.Messages = generateRandomlySizedMessages(msgCount, maxSize)
 	return out
@@ -485,12 +506,17 @@ func generateRandomContiguousShares(count, size int) Txs {
 	return txs
 }
 
-func generateRandomISR(count int) IntermediateStateRoots {
-	roots := make([]tmbytes.HexBytes, count)
-	for i := 0; i < count; i++ {
+// generateRandomISR returns a root for every txsPerRoot of txCount txs, or none if
+// txsPerRoot is zero.
+func generateRandomISR(txCount, txsPerRoot int) IntermediateStateRoots {
+	if txsPerRoot == 0 {
+		return IntermediateStateRoots{RawRootsList: []tmbytes.HexBytes{}}
+	}
+	roots := make([]tmbytes.HexBytes, (txCount+txsPerRoot-1)/txsPerRoot)
+	for i := range roots {
 		roots[i] = tmbytes.HexBytes(generateRandomContiguousShares(1, 32)[0])
 	}
-	return IntermediateStateRoots{RawRootsList: roots}
+	return IntermediateStateRoots{RawRootsList: roots, TxsPerRoot: uint32(txsPerRoot)}
 }
 
 func generateIdenticalEvidence(t *testing.T, count int) EvidenceData {
@@ -159,3 +159,30 @@ func ComputeProtoSizeForTxs(txs []Tx) int64 {
 	pdData := data.ToProto()
 	return int64(pdData.Size())
 }
+
+// ComputeProtoSizeForTxsAndMessages wraps the transactions and the non-empty
+// messages in tmproto.Data{} and calculates the byte size.
+func ComputeProtoSizeForTxsAndMessages(txs []Tx, msgs []Message) int64 {
+	data := Data{Txs: txs}
+	for _, msg := range msgs {
+		if !msg.IsEmpty() {
+			data.Messages.MessagesList = append(data.Messages.MessagesList, msg)
+		}
+	}
+	pdData := data.ToProto()
+	return int64(pdData.Size())
+}
+
+// ComputeShareSizeForTxsAndMessages returns the byte size of the shares the
+// transactions and the non-empty messages are laid out in, which is how block
+// data is serialized in the parts of a block, see Block.MakePartSet.
+func ComputeShareSizeForTxsAndMessages(txs []Tx, msgs []Message) int64 {
+	data := Data{Txs: txs}
+	for _, msg := range msgs {
+		if !msg.IsEmpty() {
+			data.Messages.MessagesList = append(data.Messages.MessagesList, msg)
+		}
+	}
+	numShares := len(data.Txs.splitIntoShares()) + len(data.Messages.splitIntoShares())
+	return int64(numShares * consts.ShareSize)
+}
@@ -9,6 +9,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/lazyledger/lazyledger-core/crypto"
+	"github.com/lazyledger/lazyledger-core/crypto/batch"
 	"github.com/lazyledger/lazyledger-core/crypto/merkle"
 	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
@@ -680,6 +682,7 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 
 	talliedVotingPower := int64(0)
 	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+	sigs := make([]commitSigToVerify, 0, len(commit.Signatures))
 	for idx, commitSig := range commit.Signatures {
 		if commitSig.Absent() {
 			continue // OK, some signatures can be absent.
@@ -688,13 +691,8 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 		// The vals and commit have a 1-to-1 correspondance.
 		// This means we don't need the validator address or to do any lookup.
 		val := vals.Validators[idx]
+		sigs = append(sigs, commitSigToVerify{idx: idx, val: val})
 
-		// Validate signature.
-		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-		}
-		// Good!
 		if commitSig.ForBlock() {
 			talliedVotingPower += val.VotingPower
 		}
@@ -704,6 +702,11 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 		// }
 	}
 
+	// Validate signatures.
+	if err := verifyCommitSigs(chainID, commit, sigs); err != nil {
+		return err
+	}
+
 	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
 		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
 	}
@@ -738,6 +741,7 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 
 	talliedVotingPower := int64(0)
 	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3
+	sigs := make([]commitSigToVerify, 0, len(commit.Signatures))
 	for idx, commitSig := range commit.Signatures {
 		// No need to verify absent or nil votes.
 		if !commitSig.ForBlock() {
@@ -747,22 +751,26 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 		// The vals and commit have a 1-to-1 correspondance.
 		// This means we don't need the validator address or to do any lookup.
 		val := vals.Validators[idx]
-
-		// Validate signature.
-		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-		}
+		sigs = append(sigs, commitSigToVerify{idx: idx, val: val})
 
 		talliedVotingPower += val.VotingPower
 
-		// return as soon as +2/3 of the signatures are verified
+		// only verify the signatures needed to reach +2/3
 		if talliedVotingPower > votingPowerNeeded {
-			return nil
+			break
 		}
 	}
 
-	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+	// Validate signatures.
+	if err := verifyCommitSigs(chainID, commit, sigs); err != nil {
+		return err
+	}
+
+	if talliedVotingPower <= votingPowerNeeded {
+		return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+	}
+
+	return nil
 }
 
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
@@ -794,6 +802,7 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 	}
 	votingPowerNeeded := totalVotingPowerMulByNumerator / int64(trustLevel.Denominator)
 
+	sigs := make([]commitSigToVerify, 0, len(commit.Signatures))
 	for idx, commitSig := range commit.Signatures {
 		// No need to verify absent or nil votes.
 		if !commitSig.ForBlock() {
@@ -811,22 +820,84 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
 			}
 			seenVals[valIdx] = idx
-
-			// Validate signature.
-			voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
-			if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
-				return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
-			}
+			sigs = append(sigs, commitSigToVerify{idx: idx, val: val})
 
 			talliedVotingPower += val.VotingPower
 
+			// only verify the signatures needed to reach trustLevel
 			if talliedVotingPower > votingPowerNeeded {
-				return nil
+				break
 			}
 		}
 	}
 
-	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+	// Validate signatures.
+	if err := verifyCommitSigs(chainID, commit, sigs); err != nil {
+		return err
+	}
+
+	if talliedVotingPower <= votingPowerNeeded {
+		return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+	}
+
+	return nil
+}
+
+// commitSigToVerify is a signature in a commit together with the validator
+// that is supposed to have made it.
+type commitSigToVerify struct {
+	idx int // index of the signature in the commit
+	val *Validator
+}
+
+// verifyCommitSigs verifies the given commit signatures. If there is more than
+// one and the validators' keys support it, the signatures are verified as a
+// batch, which is considerably faster. If the batch turns out to be invalid,
+// the signatures are checked one by one to tell which one is wrong.
+func verifyCommitSigs(chainID string, commit *Commit, sigs []commitSigToVerify) error {
+	if len(sigs) > 1 {
+		if bv, ok := batch.CreateBatchVerifier(sigs[0].val.PubKey); ok {
+			return verifyCommitSigsBatch(bv, chainID, commit, sigs)
+		}
+	}
+
+	return verifyCommitSigsSingle(chainID, commit, sigs)
+}
+
+func verifyCommitSigsBatch(bv crypto.BatchVerifier, chainID string, commit *Commit,
+	sigs []commitSigToVerify) error {
+	for _, s := range sigs {
+		voteSignBytes := commit.VoteSignBytes(chainID, int32(s.idx))
+		if err := bv.Add(s.val.PubKey, voteSignBytes, commit.Signatures[s.idx].Signature); err != nil {
+			// e.g. validators with different key types or a malformed
+			// signature; let the single verification report the problem.
+			return verifyCommitSigsSingle(chainID, commit, sigs)
+		}
+	}
+
+	ok, validSigs := bv.Verify()
+	if ok {
+		return nil
+	}
+	for i, valid := range validSigs {
+		if !valid {
+			idx := sigs[i].idx
+			return fmt.Errorf("wrong signature (#%d): %X", idx, commit.Signatures[idx].Signature)
+		}
+	}
+
+	// the batch failed even though every signature is valid on its own
+	return errors.New("batch signature verification failed")
+}
+
+func verifyCommitSigsSingle(chainID string, commit *Commit, sigs []commitSigToVerify) error {
+	for _, s := range sigs {
+		voteSignBytes := commit.VoteSignBytes(chainID, int32(s.idx))
+		if !s.val.PubKey.VerifySignature(voteSignBytes, commit.Signatures[s.idx].Signature) {
+			return fmt.Errorf("wrong signature (#%d): %X", s.idx, commit.Signatures[s.idx].Signature)
+		}
+	}
+	return nil
 }
 
 // findPreviousProposer reverses the compare proposer priority function to find the validator
@@ -16,6 +16,7 @@ import (
 
 	"github.com/lazyledger/lazyledger-core/crypto"
 	"github.com/lazyledger/lazyledger-core/crypto/ed25519"
+	"github.com/lazyledger/lazyledger-core/crypto/sr25519"
 	tmmath "github.com/lazyledger/lazyledger-core/libs/math"
 	tmrand "github.com/lazyledger/lazyledger-core/libs/rand"
 	tmproto "github.com/lazyledger/lazyledger-core/proto/tendermint/types"
@@ -814,6 +815,101 @@ func TestValidatorSet_VerifyCommitLightTrusting_ReturnsAsSoonAsTrustLevelOfVotin
 	assert.NoError(t, err)
 }
 
+// makeCommitWithKeys returns a commit for blockID signed by validators of equal
+// voting power with the given keys, along with their validator set and private
+// validators, in the same order.
+func makeCommitWithKeys(
+	t *testing.T,
+	chainID string,
+	blockID BlockID,
+	h int64,
+	privKeys ...crypto.PrivKey,
+) (*Commit, *ValidatorSet, []PrivValidator) {
+	valz := make([]*Validator, len(privKeys))
+	vals := make([]PrivValidator, len(privKeys))
+	for i, privKey := range privKeys {
+		valz[i] = NewValidator(privKey.PubKey(), 10)
+		vals[i] = NewMockPVWithParams(privKey, false, false)
+	}
+	sort.Sort(PrivValidatorsByAddress(vals))
+	valSet := NewValidatorSet(valz)
+
+	voteSet := NewVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	return commit, valSet, vals
+}
+
+func TestValidatorSet_VerifyCommit_BatchVerification(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	keySets := []struct {
+		name    string
+		genKeys func() []crypto.PrivKey
+	}{
+		{"ed25519", func() []crypto.PrivKey {
+			return []crypto.PrivKey{ed25519.GenPrivKey(), ed25519.GenPrivKey(), ed25519.GenPrivKey(),
+				ed25519.GenPrivKey()}
+		}},
+		{"sr25519", func() []crypto.PrivKey {
+			return []crypto.PrivKey{sr25519.GenPrivKey(), sr25519.GenPrivKey(), sr25519.GenPrivKey(),
+				sr25519.GenPrivKey()}
+		}},
+		// the batch verifier of the first key rejects the keys of the other type,
+		// so that the signatures are verified one by one
+		{"mixed", func() []crypto.PrivKey {
+			return []crypto.PrivKey{ed25519.GenPrivKey(), sr25519.GenPrivKey(), ed25519.GenPrivKey(),
+				sr25519.GenPrivKey()}
+		}},
+	}
+	testCases := []struct {
+		name     string
+		malleate func(commit *Commit, val PrivValidator)
+		expErr   string
+	}{
+		{"good", func(*Commit, PrivValidator) {}, ""},
+		{"wrong signature", func(commit *Commit, val PrivValidator) {
+			v := commit.GetVote(1).ToProto()
+			require.NoError(t, val.SignVote("CentaurusA", v))
+			commit.Signatures[1].Signature = v.Signature
+		}, "wrong signature (#1)"},
+		// adding the signature to the batch fails, so that the signatures are
+		// verified one by one
+		{"malformed signature", func(commit *Commit, _ PrivValidator) {
+			commit.Signatures[1].Signature = commit.Signatures[1].Signature[1:]
+		}, "wrong signature (#1)"},
+	}
+
+	for _, ks := range keySets {
+		for _, tc := range testCases {
+			ks, tc := ks, tc
+			t.Run(ks.name+" "+tc.name, func(t *testing.T) {
+				commit, valSet, vals := makeCommitWithKeys(t, chainID, blockID, h, ks.genKeys()...)
+				tc.malleate(commit, vals[1])
+
+				// at least two signatures of the commit are verified by each of them
+				errs := map[string]error{
+					"VerifyCommit":      valSet.VerifyCommit(chainID, blockID, h, commit),
+					"VerifyCommitLight": valSet.VerifyCommitLight(chainID, blockID, h, commit),
+					"VerifyCommitLightTrusting": valSet.VerifyCommitLightTrusting(chainID, commit,
+						tmmath.Fraction{Numerator: 1, Denominator: 3}),
+				}
+				for name, err := range errs {
+					if tc.expErr == "" {
+						assert.NoError(t, err, name)
+					} else if assert.Error(t, err, name) {
+						assert.Contains(t, err.Error(), tc.expErr, name)
+					}
+				}
+			})
+		}
+	}
+}
+
 func TestEmptySet(t *testing.T) {
 
 	var valList []*Validator
@@ -147,11 +147,48 @@ func (voteSet *VoteSet) AddVote(vote *Vote) (added bool, err error) {
 	voteSet.mtx.Lock()
 	defer voteSet.mtx.Unlock()
 
-	return voteSet.addVote(vote)
+	return voteSet.addVote(vote, false)
+}
+
+// addCommit adds the votes of a commit for the height and round of the vote
+// set. Their signatures are verified as a batch if the validators' keys
+// support it, see ValidatorSet.VerifyCommit.
+func (voteSet *VoteSet) addCommit(commit *Commit) error {
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+
+	sigs := make([]commitSigToVerify, 0, len(commit.Signatures))
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue // OK, some precommits can be missing.
+		}
+		_, val := voteSet.valSet.GetByIndex(int32(idx))
+		if val == nil {
+			return fmt.Errorf(
+				"cannot find validator %d in valSet of size %d: %w",
+				idx, voteSet.valSet.Size(), ErrVoteInvalidValidatorIndex)
+		}
+		sigs = append(sigs, commitSigToVerify{idx: idx, val: val})
+	}
+	if err := verifyCommitSigs(voteSet.chainID, commit, sigs); err != nil {
+		return fmt.Errorf("failed to verify commit with ChainID %s: %w", voteSet.chainID, err)
+	}
+
+	for _, s := range sigs {
+		added, err := voteSet.addVote(commit.GetVote(int32(s.idx)), true)
+		if err != nil {
+			return err
+		}
+		if !added {
+			return fmt.Errorf("duplicate vote of validator %d", s.idx)
+		}
+	}
+	return nil
 }
 
 // NOTE: Validates as much as possible before attempting to verify the signature.
-func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
+// The signature is not verified again if sigVerified is set.
+func (voteSet *VoteSet) addVote(vote *Vote, sigVerified bool) (added bool, err error) {
 	if vote == nil {
 		return false, ErrVoteNil
 	}
@@ -200,8 +237,11 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	}
 
 	// Check signature.
-	if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
-		return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
+	if !sigVerified {
+		if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
+			return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w",
+				voteSet.chainID, val.PubKey, err)
+		}
 	}
 
 	// Add vote and get conflicting vote if any.